@@ -300,6 +300,36 @@ func (a *Agent) controller(startingBalance btcutil.Amount) {
 	pendingOpens := make(map[NodeID]Channel)
 	var pendingMtx sync.Mutex
 
+	// openChan attempts to carry out a single attachment directive. If
+	// the attempt fails, then the target node is marked as failed so it
+	// isn't selected again.
+	openChan := func(directive AttachmentDirective) {
+		pub := directive.PeerKey
+		err := a.cfg.ChanController.OpenChannel(
+			directive.PeerKey, directive.ChanAmt, directive.Addrs,
+		)
+		if err != nil {
+			log.Warnf("Unable to open channel to %x of %v: %v",
+				pub.SerializeCompressed(), directive.ChanAmt,
+				err)
+
+			// As the attempt failed, we'll clear it from the set
+			// of pending channels.
+			pendingMtx.Lock()
+			nID := NewNodeID(directive.PeerKey)
+			delete(pendingOpens, nID)
+
+			// Mark this node as failed so we don't attempt it
+			// again.
+			failedNodes[nID] = struct{}{}
+			pendingMtx.Unlock()
+
+			// Trigger the autopilot controller to re-evaluate
+			// everything and possibly retry with a different node.
+			a.OnChannelOpenFailure()
+		}
+	}
+
 	updateBalance := func() {
 		newBalance, err := a.cfg.WalletBalance()
 		if err != nil {
@@ -427,11 +457,10 @@ func (a *Agent) controller(startingBalance btcutil.Amount) {
 				"directives: %v", spew.Sdump(chanCandidates))
 
 			// For each recommended attachment directive, we'll
-			// launch a new goroutine to attempt to carry out the
-			// directive. If any of these succeed, then we'll
-			// receive a new state update, taking us back to the
-			// top of our controller loop.
+			// record the channel as pending, unless doing so would
+			// take us past the number of allowed pending opens.
 			pendingMtx.Lock()
+			var directives []AttachmentDirective
 			for _, chanCandidate := range chanCandidates {
 				// Before we proceed, we'll check to see if
 				// this attempt would take us past the total
@@ -454,42 +483,41 @@ func (a *Agent) controller(startingBalance btcutil.Amount) {
 					Node:     nID,
 				}
 
-				go func(directive AttachmentDirective) {
-
-					pub := directive.PeerKey
-					err := a.cfg.ChanController.OpenChannel(
+				directives = append(directives, chanCandidate)
+			}
+			pendingMtx.Unlock()
 
-						directive.PeerKey,
-						directive.ChanAmt,
-						directive.Addrs,
+			// If we're opening several channels at once, then
+			// we'll attempt to fund all of them within a single
+			// transaction. As a single peer failing causes the
+			// entire batch to fail, we'll fall back to opening
+			// the channels individually if that's the case. If any
+			// of these succeed, then we'll receive a new state
+			// update, taking us back to the top of our controller
+			// loop.
+			if len(directives) > 1 {
+				go func(directives []AttachmentDirective) {
+					err := a.cfg.ChanController.OpenChannels(
+						directives,
 					)
-					if err != nil {
-						log.Warnf("Unable to open "+
-							"channel to %x of %v: %v",
-							pub.SerializeCompressed(),
-							directive.ChanAmt, err)
-
-						// As the attempt failed, we'll
-						// clear it from the set of
-						// pending channels.
-						pendingMtx.Lock()
-						nID := NewNodeID(directive.PeerKey)
-						delete(pendingOpens, nID)
-
-						// Mark this node as failed so we don't
-						// attempt it again.
-						failedNodes[nID] = struct{}{}
-						pendingMtx.Unlock()
-
-						// Trigger the autopilot controller to
-						// re-evaluate everything and possibly
-						// retry with a different node.
-						a.OnChannelOpenFailure()
+					if err == nil {
+						return
 					}
 
-				}(chanCandidate)
+					log.Warnf("Unable to open batch of %v "+
+						"channels, opening them "+
+						"individually: %v",
+						len(directives), err)
+
+					for _, directive := range directives {
+						go openChan(directive)
+					}
+				}(directives)
+			} else {
+				for _, directive := range directives {
+					go openChan(directive)
+				}
 			}
-			pendingMtx.Unlock()
 
 		// The agent has been signalled to exit, so we'll bail out
 		// immediately.
@@ -95,6 +95,18 @@ func (m *mockChanController) OpenChannel(target *btcec.PublicKey, amt btcutil.Am
 	return nil
 }
 
+func (m *mockChanController) OpenChannels(directives []AttachmentDirective) error {
+	for _, directive := range directives {
+		err := m.OpenChannel(
+			directive.PeerKey, directive.ChanAmt, directive.Addrs,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *mockChanController) CloseChannel(chanPoint *wire.OutPoint) error {
 	return nil
 }
@@ -231,6 +243,12 @@ func (m *mockFailingChanController) OpenChannel(target *btcec.PublicKey, amt btc
 	return errors.New("failure")
 }
 
+func (m *mockFailingChanController) OpenChannels(
+	directives []AttachmentDirective) error {
+
+	return errors.New("failure")
+}
+
 func (m *mockFailingChanController) CloseChannel(chanPoint *wire.OutPoint) error {
 	return nil
 }
@@ -880,3 +898,111 @@ func TestAgentPendingChannelState(t *testing.T) {
 		t.Fatalf("select wasn't queried in time")
 	}
 }
+
+// mockBatchFailingChanController fails to open any batch of channels, while
+// opening channels individually succeeds.
+type mockBatchFailingChanController struct {
+	mockChanController
+}
+
+func (m *mockBatchFailingChanController) OpenChannels(
+	directives []AttachmentDirective) error {
+
+	return errors.New("failure")
+}
+
+var _ ChannelController = (*mockBatchFailingChanController)(nil)
+
+// TestAgentBatchOpenFallback ensures that if the agent is unable to open
+// several channels within a single funding transaction, then it falls back to
+// opening each of them individually.
+func TestAgentBatchOpenFallback(t *testing.T) {
+	t.Parallel()
+
+	self, err := randKey()
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	heuristic := &mockHeuristic{
+		moreChansResps: make(chan moreChansResp),
+		directiveResps: make(chan []AttachmentDirective),
+	}
+	chanController := &mockBatchFailingChanController{
+		mockChanController: mockChanController{
+			openChanSignals: make(chan openChanIntent),
+		},
+	}
+	memGraph, _, _ := newMemChanGraph()
+
+	testCfg := Config{
+		Self:           self,
+		Heuristic:      heuristic,
+		ChanController: chanController,
+		WalletBalance: func() (btcutil.Amount, error) {
+			return btcutil.SatoshiPerBitcoin * 10, nil
+		},
+		Graph:           memGraph,
+		MaxPendingOpens: 10,
+	}
+	agent, err := New(testCfg, nil)
+	if err != nil {
+		t.Fatalf("unable to create agent: %v", err)
+	}
+	if err := agent.Start(); err != nil {
+		t.Fatalf("unable to start agent: %v", err)
+	}
+	defer agent.Stop()
+
+	const numChans = 3
+
+	// We'll indicate that more channels should be opened, then hand the
+	// agent a directive for each of them.
+	select {
+	case heuristic.moreChansResps <- moreChansResp{true, numChans, 5 * btcutil.SatoshiPerBitcoin}:
+	case <-time.After(time.Second * 10):
+		t.Fatalf("heuristic wasn't queried in time")
+	}
+
+	directives := make([]AttachmentDirective, numChans)
+	for i := 0; i < numChans; i++ {
+		peerKey, err := randKey()
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+
+		directives[i] = AttachmentDirective{
+			PeerKey: peerKey,
+			ChanAmt: btcutil.SatoshiPerBitcoin,
+			Addrs: []net.Addr{
+				&net.TCPAddr{
+					IP: bytes.Repeat([]byte("a"), 16),
+				},
+			},
+		}
+	}
+
+	select {
+	case heuristic.directiveResps <- directives:
+	case <-time.After(time.Second * 10):
+		t.Fatalf("heuristic wasn't queried in time")
+	}
+
+	// As the batch fails to open, we should receive a call to OpenChannel
+	// for each of the directives.
+	opened := make(map[NodeID]struct{})
+	for i := 0; i < numChans; i++ {
+		select {
+		case openChan := <-chanController.openChanSignals:
+			opened[NewNodeID(openChan.target)] = struct{}{}
+		case <-time.After(time.Second * 10):
+			t.Fatalf("channel not opened in time")
+		}
+	}
+
+	for _, directive := range directives {
+		if _, ok := opened[NewNodeID(directive.PeerKey)]; !ok {
+			t.Fatalf("channel to %x wasn't opened",
+				directive.PeerKey.SerializeCompressed())
+		}
+	}
+}
@@ -139,6 +139,13 @@ type ChannelController interface {
 	OpenChannel(target *btcec.PublicKey, amt btcutil.Amount,
 		addrs []net.Addr) error
 
+	// OpenChannels opens a channel for each of the passed attachment
+	// directives within a single funding transaction. This function
+	// should un-block immediately after the funding transaction has been
+	// broadcast. If any of the channels can't be opened, then none of
+	// them are.
+	OpenChannels(directives []AttachmentDirective) error
+
 	// CloseChannel attempts to close out the target channel.
 	//
 	// TODO(roasbeef): add force option?
@@ -21,7 +21,7 @@ const defaultHandshakes = 1000
 type Listener struct {
 	localStatic *btcec.PrivateKey
 
-	tcp *net.TCPListener
+	listener net.Listener
 
 	handshakeSema chan struct{}
 	conns         chan maybeConn
@@ -45,9 +45,17 @@ func NewListener(localStatic *btcec.PrivateKey, listenAddr string) (*Listener,
 		return nil, err
 	}
 
+	return WrapListener(localStatic, l), nil
+}
+
+// WrapListener returns a new net.Listener which enforces the Brontide scheme
+// on all connections accepted by the passed listener. This allows the scheme
+// to be used on top of transports other than TCP, such as the in-memory
+// connections used within tests.
+func WrapListener(localStatic *btcec.PrivateKey, l net.Listener) *Listener {
 	brontideListener := &Listener{
 		localStatic:   localStatic,
-		tcp:           l,
+		listener:      l,
 		handshakeSema: make(chan struct{}, defaultHandshakes),
 		conns:         make(chan maybeConn),
 		quit:          make(chan struct{}),
@@ -59,10 +67,10 @@ func NewListener(localStatic *btcec.PrivateKey, listenAddr string) (*Listener,
 
 	go brontideListener.listen()
 
-	return brontideListener, nil
+	return brontideListener
 }
 
-// listen accepts connection from the underlying tcp conn, then performs
+// listen accepts connection from the underlying listener, then performs
 // the brontinde handshake procedure asynchronously. A maximum of
 // defaultHandshakes will be active at any given time.
 //
@@ -75,7 +83,7 @@ func (l *Listener) listen() {
 			return
 		}
 
-		conn, err := l.tcp.Accept()
+		conn, err := l.listener.Accept()
 		if err != nil {
 			l.rejectConn(err)
 			l.handshakeSema <- struct{}{}
@@ -220,12 +228,12 @@ func (l *Listener) Close() error {
 		close(l.quit)
 	}
 
-	return l.tcp.Close()
+	return l.listener.Close()
 }
 
 // Addr returns the listener's network address.
 //
 // Part of the net.Listener interface.
 func (l *Listener) Addr() net.Addr {
-	return l.tcp.Addr()
+	return l.listener.Addr()
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// chanStatusConfig houses the set of functions and parameters the
+// chanStatusManager requires to carry out its duties.
+type chanStatusConfig struct {
+	// DisableTimeout is the duration a peer must be offline for before we
+	// broadcast an update disabling all channels we have with it.
+	DisableTimeout time.Duration
+
+	// FetchAllOpenChannels returns all channels that are currently open.
+	FetchAllOpenChannels func() ([]*channeldb.OpenChannel, error)
+
+	// FetchOpenChannels returns the set of channels that are currently
+	// open with the target peer.
+	FetchOpenChannels func(*btcec.PublicKey) ([]*channeldb.OpenChannel,
+		error)
+
+	// PropagateChanStatus signs, commits and broadcasts a new channel
+	// update for the passed channel point, with the disabled bit set
+	// according to the passed boolean.
+	PropagateChanStatus func(wire.OutPoint, bool) error
+}
+
+// chanStatusManager is responsible for letting the network know when our
+// channels are unusable because the remote peer has gone offline. Once a peer
+// has been offline for longer than the configured timeout, an update
+// disabling each of our channels with that peer is broadcast. Once the peer
+// reconnects, the channels are re-enabled.
+type chanStatusManager struct {
+	started uint32
+	stopped uint32
+
+	cfg *chanStatusConfig
+
+	// offlineTimers tracks, for each peer that's currently offline, the
+	// timer that'll disable our channels with it once it fires.
+	offlineTimers map[routing.Vertex]*time.Timer
+
+	mu   sync.Mutex
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newChanStatusManager creates a new chanStatusManager from the passed
+// config.
+func newChanStatusManager(cfg *chanStatusConfig) *chanStatusManager {
+	return &chanStatusManager{
+		cfg:           cfg,
+		offlineTimers: make(map[routing.Vertex]*time.Timer),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start kicks off the chanStatusManager. As none of our peers are connected
+// on startup, we'll consider all peers we have open channels with offline
+// until they reconnect.
+func (m *chanStatusManager) Start() error {
+	if !atomic.CompareAndSwapUint32(&m.started, 0, 1) {
+		return nil
+	}
+
+	channels, err := m.cfg.FetchAllOpenChannels()
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		m.PeerOffline(channel.IdentityPub)
+	}
+
+	return nil
+}
+
+// Stop signals the chanStatusManager to exit, cancelling any pending timers.
+func (m *chanStatusManager) Stop() error {
+	if !atomic.CompareAndSwapUint32(&m.stopped, 0, 1) {
+		return nil
+	}
+
+	close(m.quit)
+
+	m.mu.Lock()
+	for vertex, timer := range m.offlineTimers {
+		timer.Stop()
+		delete(m.offlineTimers, vertex)
+	}
+	m.mu.Unlock()
+
+	m.wg.Wait()
+
+	return nil
+}
+
+// PeerOffline should be called once the connection with the target peer has
+// been lost. If the peer doesn't reconnect before the configured timeout, all
+// our channels with it will be disabled.
+//
+// NOTE: This method MUST NOT block.
+func (m *chanStatusManager) PeerOffline(peerKey *btcec.PublicKey) {
+	vertex := routing.NewVertex(peerKey)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// If we're already waiting on this peer, then there's nothing to do.
+	if _, ok := m.offlineTimers[vertex]; ok {
+		return
+	}
+
+	select {
+	case <-m.quit:
+		return
+	default:
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(m.cfg.DisableTimeout, func() {
+		// We'll only disable the channels if this timer is still the
+		// active one for the peer, as the peer may have reconnected
+		// in the meantime.
+		m.mu.Lock()
+		if m.offlineTimers[vertex] != timer {
+			m.mu.Unlock()
+			return
+		}
+		m.wg.Add(1)
+		m.mu.Unlock()
+		defer m.wg.Done()
+
+		srvrLog.Infof("Peer %x has been offline for %v, disabling "+
+			"channels", vertex[:], m.cfg.DisableTimeout)
+
+		m.setChanStatus(peerKey, true)
+	})
+	m.offlineTimers[vertex] = timer
+}
+
+// PeerOnline should be called once a connection with the target peer has
+// been established. Any pending disable is cancelled, and any of our channels
+// with the peer that were disabled are re-enabled.
+//
+// NOTE: This method MUST NOT block.
+func (m *chanStatusManager) PeerOnline(peerKey *btcec.PublicKey) {
+	vertex := routing.NewVertex(peerKey)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if timer, ok := m.offlineTimers[vertex]; ok {
+		timer.Stop()
+		delete(m.offlineTimers, vertex)
+	}
+
+	select {
+	case <-m.quit:
+		return
+	default:
+	}
+
+	// Re-enabling the channels requires a round trip to the gossiper, so
+	// we'll do so in a goroutine. Channels that aren't disabled will be
+	// skipped by the gossiper.
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.setChanStatus(peerKey, false)
+	}()
+}
+
+// setChanStatus enables or disables all channels we currently have open with
+// the target peer.
+func (m *chanStatusManager) setChanStatus(peerKey *btcec.PublicKey,
+	disabled bool) {
+
+	channels, err := m.cfg.FetchOpenChannels(peerKey)
+	if err != nil {
+		srvrLog.Errorf("Unable to fetch channels with peer %x: %v",
+			peerKey.SerializeCompressed(), err)
+		return
+	}
+
+	for _, channel := range channels {
+		// Channels that are still pending confirmation haven't been
+		// announced yet, so they don't need a status update.
+		if channel.IsPending {
+			continue
+		}
+
+		select {
+		case <-m.quit:
+			return
+		default:
+		}
+
+		chanPoint := channel.FundingOutpoint
+		err := m.cfg.PropagateChanStatus(chanPoint, disabled)
+		if err != nil {
+			srvrLog.Errorf("Unable to set disabled=%v for "+
+				"ChannelPoint(%v): %v", disabled, chanPoint, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// chanStatusUpdate records a status update requested by the
+// chanStatusManager.
+type chanStatusUpdate struct {
+	chanPoint wire.OutPoint
+	disabled  bool
+}
+
+// newTestChanStatusManager creates a chanStatusManager with a single channel
+// open with the returned peer. All status updates are delivered over the
+// returned channel.
+func newTestChanStatusManager(t *testing.T, timeout time.Duration) (
+	*chanStatusManager, *btcec.PublicKey, chan chanStatusUpdate) {
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peerKey := priv.PubKey()
+
+	channel := &channeldb.OpenChannel{
+		IdentityPub:     peerKey,
+		FundingOutpoint: wire.OutPoint{Index: 1},
+	}
+
+	updates := make(chan chanStatusUpdate, 10)
+	mgr := newChanStatusManager(&chanStatusConfig{
+		DisableTimeout: timeout,
+		FetchAllOpenChannels: func() ([]*channeldb.OpenChannel, error) {
+			return nil, nil
+		},
+		FetchOpenChannels: func(*btcec.PublicKey) (
+			[]*channeldb.OpenChannel, error) {
+
+			return []*channeldb.OpenChannel{channel}, nil
+		},
+		PropagateChanStatus: func(op wire.OutPoint, disabled bool) error {
+			updates <- chanStatusUpdate{op, disabled}
+			return nil
+		},
+	})
+	if err := mgr.Start(); err != nil {
+		t.Fatalf("unable to start chan status manager: %v", err)
+	}
+
+	return mgr, peerKey, updates
+}
+
+// assertStatusUpdate asserts that a status update with the given disabled
+// flag is received.
+func assertStatusUpdate(t *testing.T, updates chan chanStatusUpdate,
+	disabled bool) {
+
+	select {
+	case update := <-updates:
+		if update.disabled != disabled {
+			t.Fatalf("expected disabled=%v, got disabled=%v",
+				disabled, update.disabled)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("status update not received")
+	}
+}
+
+// TestChanStatusManagerDisableAfterTimeout tests that channels are disabled
+// once their peer has been offline for the configured timeout, and
+// re-enabled once the peer reconnects.
+func TestChanStatusManagerDisableAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	mgr, peerKey, updates := newTestChanStatusManager(
+		t, time.Millisecond*50,
+	)
+	defer mgr.Stop()
+
+	mgr.PeerOffline(peerKey)
+	assertStatusUpdate(t, updates, true)
+
+	mgr.PeerOnline(peerKey)
+	assertStatusUpdate(t, updates, false)
+}
+
+// TestChanStatusManagerReconnectBeforeTimeout tests that channels aren't
+// disabled if the peer reconnects before the timeout expires.
+func TestChanStatusManagerReconnectBeforeTimeout(t *testing.T) {
+	t.Parallel()
+
+	mgr, peerKey, updates := newTestChanStatusManager(
+		t, time.Millisecond*200,
+	)
+	defer mgr.Stop()
+
+	mgr.PeerOffline(peerKey)
+	mgr.PeerOnline(peerKey)
+
+	// The reconnection results in a request to enable the channel, which
+	// is a no-op if it was never disabled.
+	assertStatusUpdate(t, updates, false)
+
+	// No disable should follow, as the timer has been cancelled.
+	select {
+	case update := <-updates:
+		t.Fatalf("unexpected status update: %v", update)
+	case <-time.After(time.Millisecond * 400):
+	}
+}
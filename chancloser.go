@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/davecgh/go-spew/spew"
@@ -27,6 +28,12 @@ var (
 	// ErrInvalidState is returned when the closing state machine receives
 	// a message while it is in an unknown state.
 	ErrInvalidState = fmt.Errorf("invalid state")
+
+	// ErrUpfrontShutdownScriptMismatch is returned when the remote party
+	// attempts to cooperatively close a channel to a script other than
+	// the one it committed to when opening the channel.
+	ErrUpfrontShutdownScriptMismatch = fmt.Errorf("shutdown script does " +
+		"not match upfront shutdown script")
 )
 
 // closeState represents all the possible states the channel closer state
@@ -213,6 +220,26 @@ func (c *channelCloser) initChanShutdown() (*lnwire.Shutdown, error) {
 	return shutdown, nil
 }
 
+// validateRemoteDeliveryScript ensures that the delivery script sent by the
+// remote party within its shutdown message matches the script it committed
+// to when opening the channel, if any.
+func (c *channelCloser) validateRemoteDeliveryScript(script []byte) error {
+	upfrontScript := c.cfg.channel.State().RemoteShutdownScript
+	if len(upfrontScript) == 0 {
+		return nil
+	}
+
+	if !bytes.Equal(upfrontScript, script) {
+		peerLog.Warnf("ChannelPoint(%v): remote party attempted to "+
+			"close to %x, but committed to %x", c.chanPoint, script,
+			upfrontScript)
+
+		return ErrUpfrontShutdownScriptMismatch
+	}
+
+	return nil
+}
+
 // ShutdownChan is the first method that's to be called by the initiator of the
 // cooperative channel closure. This message returns the shutdown message to
 // send to the remote party. Upon completion, we enter the
@@ -284,6 +311,13 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				"instead have %v", spew.Sdump(msg))
 		}
 
+		// If the other party committed to a delivery address when
+		// opening the channel, then we'll ensure that they're using it.
+		err := c.validateRemoteDeliveryScript(shutDownMsg.Address)
+		if err != nil {
+			return nil, false, err
+		}
+
 		// Next, we'll note the other party's preference for their
 		// delivery address. We'll use this when we craft the closure
 		// transaction.
@@ -334,6 +368,13 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				"instead have %v", spew.Sdump(msg))
 		}
 
+		// If the other party committed to a delivery address when
+		// opening the channel, then we'll ensure that they're using it.
+		err := c.validateRemoteDeliveryScript(shutDownMsg.Address)
+		if err != nil {
+			return nil, false, err
+		}
+
 		// Now that we know this is a valid shutdown message, we'll
 		// record their preferred delivery closing script.
 		c.remoteDeliveryScript = shutDownMsg.Address
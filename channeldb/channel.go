@@ -8,7 +8,7 @@ import (
 	"net"
 	"sync"
 
-	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/shachain"
@@ -60,6 +60,13 @@ var (
 	// TODO(roasbeef): rename to commit chain?
 	commitDiffKey = []byte("commit-diff-key")
 
+	// localUpfrontShutdownKey and remoteUpfrontShutdownKey store the
+	// scripts each party committed to paying its funds out to upon a
+	// cooperative close when opening the channel. These keys are only
+	// present if the respective party committed to a script.
+	localUpfrontShutdownKey  = []byte("local-upfront-shutdown-key")
+	remoteUpfrontShutdownKey = []byte("remote-upfront-shutdown-key")
+
 	// revocationLogBucket is dedicated for storing the necessary delta
 	// state between channel updates required to re-construct a past state
 	// in order to punish a counterparty attempting a non-cooperative
@@ -442,6 +449,16 @@ type OpenChannel struct {
 	// for which we are the initiator.
 	FundingTxn *wire.MsgTx
 
+	// LocalShutdownScript is the script that we committed to paying our
+	// funds out to upon a cooperative close when opening the channel. If
+	// empty, we didn't commit to a script.
+	LocalShutdownScript lnwire.DeliveryAddress
+
+	// RemoteShutdownScript is the script that the remote party committed
+	// to paying its funds out to upon a cooperative close when opening
+	// the channel. If empty, the remote party didn't commit to a script.
+	RemoteShutdownScript lnwire.DeliveryAddress
+
 	// TODO(roasbeef): eww
 	Db *DB
 
@@ -463,8 +480,8 @@ func (c *OpenChannel) FullSync() error {
 // updateChanBucket is a helper function that returns a writable bucket that a
 // channel's data resides in given: the public key for the node, the outpoint,
 // and the chainhash that the channel resides on.
-func updateChanBucket(tx *bolt.Tx, nodeKey *btcec.PublicKey,
-	outPoint *wire.OutPoint, chainHash chainhash.Hash) (*bolt.Bucket, error) {
+func updateChanBucket(tx kvdb.Tx, nodeKey *btcec.PublicKey,
+	outPoint *wire.OutPoint, chainHash chainhash.Hash) (kvdb.Bucket, error) {
 
 	// First fetch the top level bucket which stores all data related to
 	// current, active channels.
@@ -509,8 +526,8 @@ func updateChanBucket(tx *bolt.Tx, nodeKey *btcec.PublicKey,
 // readChanBucket is a helper function that returns a readable bucket that a
 // channel's data resides in given: the public key for the node, the outpoint,
 // and the chainhash that the channel resides on.
-func readChanBucket(tx *bolt.Tx, nodeKey *btcec.PublicKey,
-	outPoint *wire.OutPoint, chainHash chainhash.Hash) (*bolt.Bucket, error) {
+func readChanBucket(tx kvdb.Tx, nodeKey *btcec.PublicKey,
+	outPoint *wire.OutPoint, chainHash chainhash.Hash) (kvdb.Bucket, error) {
 
 	// First fetch the top level bucket which stores all data related to
 	// current, active channels.
@@ -553,7 +570,7 @@ func readChanBucket(tx *bolt.Tx, nodeKey *btcec.PublicKey,
 // fullSync is an internal version of the FullSync method which allows callers
 // to sync the contents of an OpenChannel while re-using an existing database
 // transaction.
-func (c *OpenChannel) fullSync(tx *bolt.Tx) error {
+func (c *OpenChannel) fullSync(tx kvdb.Tx) error {
 	chanBucket, err := updateChanBucket(tx, c.IdentityPub,
 		&c.FundingOutpoint, c.ChainHash)
 	if err != nil {
@@ -569,7 +586,7 @@ func (c *OpenChannel) MarkAsOpen(openLoc lnwire.ShortChannelID) error {
 	c.Lock()
 	defer c.Unlock()
 
-	if err := c.Db.Update(func(tx *bolt.Tx) error {
+	if err := c.Db.Update(func(tx kvdb.Tx) error {
 		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -616,7 +633,7 @@ func (c *OpenChannel) MarkCommitmentBroadcasted() error {
 }
 
 func (c *OpenChannel) putChanStatus(status ChannelStatus) error {
-	if err := c.Db.Update(func(tx *bolt.Tx) error {
+	if err := c.Db.Update(func(tx kvdb.Tx) error {
 		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -643,7 +660,7 @@ func (c *OpenChannel) putChanStatus(status ChannelStatus) error {
 
 // putChannel serializes, and stores the current state of the channel in its
 // entirety.
-func putOpenChannel(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func putOpenChannel(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 	// First, we'll write out all the relatively static fields, that are
 	// decided upon initial channel creation.
 	if err := putChanInfo(chanBucket, channel); err != nil {
@@ -667,7 +684,7 @@ func putOpenChannel(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 
 // fetchOpenChannel retrieves, and deserializes (including decrypting
 // sensitive) the complete channel currently active with the passed nodeID.
-func fetchOpenChannel(chanBucket *bolt.Bucket,
+func fetchOpenChannel(chanBucket kvdb.Bucket,
 	chanPoint *wire.OutPoint) (*OpenChannel, error) {
 
 	channel := &OpenChannel{
@@ -714,33 +731,70 @@ func (c *OpenChannel) SyncPending(addr net.Addr, pendingHeight uint32) error {
 
 	c.FundingBroadcastHeight = pendingHeight
 
-	return c.Db.Update(func(tx *bolt.Tx) error {
-		// First, sync all the persistent channel state to disk.
-		if err := c.fullSync(tx); err != nil {
-			return err
-		}
+	return c.Db.Update(func(tx kvdb.Tx) error {
+		return c.syncPending(tx, addr)
+	})
+}
 
-		nodeInfoBucket, err := tx.CreateBucketIfNotExists(nodeInfoBucket)
-		if err != nil {
-			return err
-		}
+// syncPending writes the contents of the channel to the database within the
+// passed transaction, along with a LinkNode for the remote party if one
+// doesn't exist yet.
+func (c *OpenChannel) syncPending(tx kvdb.Tx, addr net.Addr) error {
+	// First, sync all the persistent channel state to disk.
+	if err := c.fullSync(tx); err != nil {
+		return err
+	}
 
-		// If a LinkNode for this identity public key already exists,
-		// then we can exit early.
-		nodePub := c.IdentityPub.SerializeCompressed()
-		if nodeInfoBucket.Get(nodePub) != nil {
-			return nil
-		}
+	nodeInfoBucket, err := tx.CreateBucketIfNotExists(nodeInfoBucket)
+	if err != nil {
+		return err
+	}
+
+	// If a LinkNode for this identity public key already exists, then we
+	// can exit early.
+	nodePub := c.IdentityPub.SerializeCompressed()
+	if nodeInfoBucket.Get(nodePub) != nil {
+		return nil
+	}
 
-		// Next, we need to establish a (possibly) new LinkNode
-		// relationship for this channel. The LinkNode metadata
-		// contains reachability, up-time, and service bits related
-		// information.
-		linkNode := c.Db.NewLinkNode(wire.MainNet, c.IdentityPub, addr)
+	// Next, we need to establish a (possibly) new LinkNode relationship
+	// for this channel. The LinkNode metadata contains reachability,
+	// up-time, and service bits related information.
+	linkNode := c.Db.NewLinkNode(wire.MainNet, c.IdentityPub, addr)
 
-		// TODO(roasbeef): do away with link node all together?
+	// TODO(roasbeef): do away with link node all together?
 
-		return putLinkNode(nodeInfoBucket, linkNode)
+	return putLinkNode(nodeInfoBucket, linkNode)
+}
+
+// SyncPendingChannels is the batch equivalent of SyncPending. The passed
+// channels, which share a single funding transaction broadcast at the given
+// height, are written to the database within a single transaction, so either
+// all or none of them are persisted. The remote party of the i-th channel is
+// reachable at the i-th address.
+func (d *DB) SyncPendingChannels(channels []*OpenChannel, addrs []net.Addr,
+	pendingHeight uint32) error {
+
+	if len(channels) != len(addrs) {
+		return fmt.Errorf("got %v addresses for %v channels",
+			len(addrs), len(channels))
+	}
+
+	for _, c := range channels {
+		c.Lock()
+		defer c.Unlock()
+	}
+
+	return d.Update(func(tx kvdb.Tx) error {
+		for i, c := range channels {
+			c.FundingBroadcastHeight = pendingHeight
+
+			if err := c.syncPending(tx, addrs[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
@@ -753,7 +807,7 @@ func (c *OpenChannel) UpdateCommitment(newCommitment *ChannelCommitment) error {
 	c.Lock()
 	defer c.Unlock()
 
-	err := c.Db.Update(func(tx *bolt.Tx) error {
+	err := c.Db.Update(func(tx kvdb.Tx) error {
 		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -901,6 +955,114 @@ func (h *HTLC) Copy() HTLC {
 	return clone
 }
 
+// RevocationLogEntry is the compact record of a revoked remote commitment
+// stored within a channel's revocation log. Rather than the full commitment,
+// only the data needed to construct a justice transaction, should the revoked
+// commitment ever be broadcast, is retained.
+type RevocationLogEntry struct {
+	// CommitHeight is the update number of the revoked commitment.
+	CommitHeight uint64
+
+	// CommitTxHash is the txid of the revoked commitment transaction.
+	CommitTxHash chainhash.Hash
+
+	// LocalBalance is our balance within the revoked commitment.
+	LocalBalance lnwire.MilliSatoshi
+
+	// RemoteBalance is the remote party's balance within the revoked
+	// commitment.
+	RemoteBalance lnwire.MilliSatoshi
+
+	// FeePerKw is the fee rate of the revoked commitment, which determines
+	// the HTLCs that were trimmed as dust.
+	FeePerKw btcutil.Amount
+
+	// Htlcs is the set of HTLCs active within the revoked commitment. Only
+	// the RHash, Amt, RefundTimeout, OutputIndex and Incoming fields of
+	// each HTLC are retained, as the remaining fields aren't required to
+	// sweep their outputs.
+	Htlcs []HTLC
+}
+
+// newRevocationLogEntry creates the revocation log entry for the passed
+// revoked remote commitment, discarding all data not needed for retribution.
+func newRevocationLogEntry(c *ChannelCommitment) *RevocationLogEntry {
+	entry := &RevocationLogEntry{
+		CommitHeight:  c.CommitHeight,
+		LocalBalance:  c.LocalBalance,
+		RemoteBalance: c.RemoteBalance,
+		FeePerKw:      c.FeePerKw,
+	}
+	if c.CommitTx != nil {
+		entry.CommitTxHash = c.CommitTx.TxHash()
+	}
+
+	for _, htlc := range c.Htlcs {
+		entry.Htlcs = append(entry.Htlcs, HTLC{
+			RHash:         htlc.RHash,
+			Amt:           htlc.Amt,
+			RefundTimeout: htlc.RefundTimeout,
+			OutputIndex:   htlc.OutputIndex,
+			Incoming:      htlc.Incoming,
+		})
+	}
+
+	return entry
+}
+
+// serializeRevocationLogEntry writes the passed revocation log entry to w.
+func serializeRevocationLogEntry(w io.Writer, e *RevocationLogEntry) error {
+	numHtlcs := uint16(len(e.Htlcs))
+	if err := writeElements(w,
+		e.CommitHeight, e.CommitTxHash, e.LocalBalance, e.RemoteBalance,
+		e.FeePerKw, numHtlcs,
+	); err != nil {
+		return err
+	}
+
+	for _, htlc := range e.Htlcs {
+		if err := writeElements(w,
+			htlc.RHash, htlc.Amt, htlc.RefundTimeout,
+			htlc.OutputIndex, htlc.Incoming,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deserializeRevocationLogEntry reads a revocation log entry from r.
+func deserializeRevocationLogEntry(r io.Reader) (*RevocationLogEntry, error) {
+	var (
+		e        RevocationLogEntry
+		numHtlcs uint16
+	)
+	if err := readElements(r,
+		&e.CommitHeight, &e.CommitTxHash, &e.LocalBalance,
+		&e.RemoteBalance, &e.FeePerKw, &numHtlcs,
+	); err != nil {
+		return nil, err
+	}
+
+	if numHtlcs == 0 {
+		return &e, nil
+	}
+
+	e.Htlcs = make([]HTLC, numHtlcs)
+	for i := uint16(0); i < numHtlcs; i++ {
+		htlc := &e.Htlcs[i]
+		if err := readElements(r,
+			&htlc.RHash, &htlc.Amt, &htlc.RefundTimeout,
+			&htlc.OutputIndex, &htlc.Incoming,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return &e, nil
+}
+
 // LogUpdate represents a pending update to the remote commitment chain. The
 // log update may be an add, fail, or settle entry. We maintain this data in
 // order to be able to properly retransmit our proposed
@@ -1171,7 +1333,7 @@ func (c *OpenChannel) AppendRemoteCommitChain(diff *CommitDiff) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return c.Db.Update(func(tx *bolt.Tx) error {
+	return c.Db.Update(func(tx kvdb.Tx) error {
 		// First, we'll grab the writable bucket where this channel's
 		// data resides.
 		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
@@ -1220,7 +1382,7 @@ func (c *OpenChannel) AppendRemoteCommitChain(diff *CommitDiff) error {
 // these pointers, causing the tip and the tail to point to the same entry.
 func (c *OpenChannel) RemoteCommitChainTip() (*CommitDiff, error) {
 	var cd *CommitDiff
-	err := c.Db.View(func(tx *bolt.Tx) error {
+	err := c.Db.View(func(tx kvdb.Tx) error {
 		chanBucket, err := readChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1261,7 +1423,7 @@ func (c *OpenChannel) InsertNextRevocation(revKey *btcec.PublicKey) error {
 
 	c.RemoteNextRevocation = revKey
 
-	err := c.Db.Update(func(tx *bolt.Tx) error {
+	err := c.Db.Update(func(tx kvdb.Tx) error {
 		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1290,7 +1452,7 @@ func (c *OpenChannel) AdvanceCommitChainTail(fwdPkg *FwdPkg) error {
 
 	var newRemoteCommit *ChannelCommitment
 
-	err := c.Db.Update(func(tx *bolt.Tx) error {
+	err := c.Db.Update(func(tx kvdb.Tx) error {
 		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1307,10 +1469,6 @@ func (c *OpenChannel) AdvanceCommitChainTail(fwdPkg *FwdPkg) error {
 		// With the current preimage producer/store state updated,
 		// append a new log entry recording this the delta of this
 		// state transition.
-		//
-		// TODO(roasbeef): could make the deltas relative, would save
-		// space, but then tradeoff for more disk-seeks to recover the
-		// full state.
 		logKey := revocationLogBucket
 		logBucket, err := chanBucket.CreateBucketIfNotExists(logKey)
 		if err != nil {
@@ -1335,10 +1493,11 @@ func (c *OpenChannel) AdvanceCommitChainTail(fwdPkg *FwdPkg) error {
 		}
 
 		// With the commitment pointer swapped, we can now add the
-		// revoked (prior) state to the revocation log.
-		//
-		// TODO(roasbeef): store less
-		err = appendChannelLogEntry(logBucket, &c.RemoteCommitment)
+		// revoked (prior) state to the revocation log. Only the data
+		// required to punish a breach of this state is retained.
+		err = appendChannelLogEntry(
+			logBucket, newRevocationLogEntry(&c.RemoteCommitment),
+		)
 		if err != nil {
 			return err
 		}
@@ -1371,7 +1530,7 @@ func (c *OpenChannel) AdvanceCommitChainTail(fwdPkg *FwdPkg) error {
 // remote commitment height at which the updates were locked in.
 func (c *OpenChannel) LoadFwdPkgs() ([]*FwdPkg, error) {
 	var fwdPkgs []*FwdPkg
-	if err := c.Db.View(func(tx *bolt.Tx) error {
+	if err := c.Db.View(func(tx kvdb.Tx) error {
 		var err error
 		fwdPkgs, err = c.Packager.LoadFwdPkgs(tx)
 		return err
@@ -1385,27 +1544,35 @@ func (c *OpenChannel) LoadFwdPkgs() ([]*FwdPkg, error) {
 // SetFwdFilter atomically sets the forwarding filter for the forwarding package
 // identified by `height`.
 func (c *OpenChannel) SetFwdFilter(height uint64, fwdFilter *PkgFilter) error {
-	return c.Db.Update(func(tx *bolt.Tx) error {
+	return c.Db.Update(func(tx kvdb.Tx) error {
 		return c.Packager.SetFwdFilter(tx, height, fwdFilter)
 	})
 }
 
-// RemoveFwdPkg atomically removes a forwarding package specified by the remote
-// commitment height.
+// RemoveFwdPkgs atomically removes the forwarding packages specified by the
+// remote commitment heights, allowing a batch of packages to be garbage
+// collected within a single transaction.
 //
 // NOTE: This method should only be called on packages marked FwdStateCompleted.
-func (c *OpenChannel) RemoveFwdPkg(height uint64) error {
-	return c.Db.Update(func(tx *bolt.Tx) error {
-		return c.Packager.RemovePkg(tx, height)
+func (c *OpenChannel) RemoveFwdPkgs(heights ...uint64) error {
+	return c.Db.Update(func(tx kvdb.Tx) error {
+		for _, height := range heights {
+			err := c.Packager.RemovePkg(tx, height)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
 // RevocationLogTail returns the "tail", or the end of the current revocation
 // log. This entry represents the last previous state for the remote node's
-// commitment chain. The ChannelDelta returned by this method will always lag
-// one state behind the most current (unrevoked) state of the remote node's
+// commitment chain. The entry returned by this method will always lag one
+// state behind the most current (unrevoked) state of the remote node's
 // commitment chain.
-func (c *OpenChannel) RevocationLogTail() (*ChannelCommitment, error) {
+func (c *OpenChannel) RevocationLogTail() (*RevocationLogEntry, error) {
 	c.RLock()
 	defer c.RUnlock()
 
@@ -1415,8 +1582,8 @@ func (c *OpenChannel) RevocationLogTail() (*ChannelCommitment, error) {
 		return nil, nil
 	}
 
-	var commit ChannelCommitment
-	if err := c.Db.View(func(tx *bolt.Tx) error {
+	var entry *RevocationLogEntry
+	if err := c.Db.View(func(tx kvdb.Tx) error {
 		chanBucket, err := readChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1436,10 +1603,10 @@ func (c *OpenChannel) RevocationLogTail() (*ChannelCommitment, error) {
 		_, tailLogEntry := cursor.Last()
 		logEntryReader := bytes.NewReader(tailLogEntry)
 
-		// Once we have the entry, we'll decode it into the channel
-		// delta pointer we created above.
+		// Once we have the entry, we'll decode it into the entry
+		// pointer we created above.
 		var dbErr error
-		commit, dbErr = deserializeChanCommit(logEntryReader)
+		entry, dbErr = deserializeRevocationLogEntry(logEntryReader)
 		if dbErr != nil {
 			return dbErr
 		}
@@ -1449,7 +1616,7 @@ func (c *OpenChannel) RevocationLogTail() (*ChannelCommitment, error) {
 		return nil, err
 	}
 
-	return &commit, nil
+	return entry, nil
 }
 
 // CommitmentHeight returns the current commitment height. The commitment
@@ -1462,7 +1629,7 @@ func (c *OpenChannel) CommitmentHeight() (uint64, error) {
 	defer c.RUnlock()
 
 	var height uint64
-	err := c.Db.View(func(tx *bolt.Tx) error {
+	err := c.Db.View(func(tx kvdb.Tx) error {
 		// Get the bucket dedicated to storing the metadata for open
 		// channels.
 		chanBucket, err := readChanBucket(tx, c.IdentityPub,
@@ -1491,12 +1658,12 @@ func (c *OpenChannel) CommitmentHeight() (uint64, error) {
 // intended to be used for obtaining the relevant data needed to claim all
 // funds rightfully spendable in the case of an on-chain broadcast of the
 // commitment transaction.
-func (c *OpenChannel) FindPreviousState(updateNum uint64) (*ChannelCommitment, error) {
+func (c *OpenChannel) FindPreviousState(updateNum uint64) (*RevocationLogEntry, error) {
 	c.RLock()
 	defer c.RUnlock()
 
-	var commit ChannelCommitment
-	err := c.Db.View(func(tx *bolt.Tx) error {
+	var entry *RevocationLogEntry
+	err := c.Db.View(func(tx kvdb.Tx) error {
 		chanBucket, err := readChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1508,19 +1675,14 @@ func (c *OpenChannel) FindPreviousState(updateNum uint64) (*ChannelCommitment, e
 			return ErrNoPastDeltas
 		}
 
-		c, err := fetchChannelLogEntry(logBucket, updateNum)
-		if err != nil {
-			return err
-		}
-
-		commit = c
-		return nil
+		entry, err = fetchChannelLogEntry(logBucket, updateNum)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &commit, nil
+	return entry, nil
 }
 
 // ClosureType is an enum like structure that details exactly _how_ a channel
@@ -1624,7 +1786,7 @@ func (c *OpenChannel) CloseChannel(summary *ChannelCloseSummary) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return c.Db.Update(func(tx *bolt.Tx) error {
+	return c.Db.Update(func(tx kvdb.Tx) error {
 		openChanBucket := tx.Bucket(openChannelBucket)
 		if openChanBucket == nil {
 			return ErrNoChanDBExists
@@ -1757,7 +1919,7 @@ func (c *OpenChannel) Snapshot() *ChannelSnapshot {
 // latest fully committed state is returned. The first commitment returned is
 // the local commitment, and the second returned is the remote commitment.
 func (c *OpenChannel) LatestCommitments() (*ChannelCommitment, *ChannelCommitment, error) {
-	err := c.Db.View(func(tx *bolt.Tx) error {
+	err := c.Db.View(func(tx kvdb.Tx) error {
 		chanBucket, err := readChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1778,7 +1940,7 @@ func (c *OpenChannel) LatestCommitments() (*ChannelCommitment, *ChannelCommitmen
 // acting on a possible contract breach to ensure, that the caller has the most
 // up to date information required to deliver justice.
 func (c *OpenChannel) RemoteRevocationStore() (shachain.Store, error) {
-	err := c.Db.View(func(tx *bolt.Tx) error {
+	err := c.Db.View(func(tx kvdb.Tx) error {
 		chanBucket, err := readChanBucket(tx, c.IdentityPub,
 			&c.FundingOutpoint, c.ChainHash)
 		if err != nil {
@@ -1794,7 +1956,7 @@ func (c *OpenChannel) RemoteRevocationStore() (shachain.Store, error) {
 	return c.RevocationStore, nil
 }
 
-func putChannelCloseSummary(tx *bolt.Tx, chanID []byte,
+func putChannelCloseSummary(tx kvdb.Tx, chanID []byte,
 	summary *ChannelCloseSummary) error {
 
 	closedChanBucket, err := tx.CreateBucketIfNotExists(closedChannelBucket)
@@ -1818,7 +1980,7 @@ func serializeChannelCloseSummary(w io.Writer, cs *ChannelCloseSummary) error {
 	)
 }
 
-func fetchChannelCloseSummary(tx *bolt.Tx,
+func fetchChannelCloseSummary(tx kvdb.Tx,
 	chanID []byte) (*ChannelCloseSummary, error) {
 
 	closedChanBucket, err := tx.CreateBucketIfNotExists(closedChannelBucket)
@@ -1850,7 +2012,7 @@ func deserializeCloseChannelSummary(r io.Reader) (*ChannelCloseSummary, error) {
 	return c, nil
 }
 
-func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func putChanInfo(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 	var w bytes.Buffer
 	if err := writeElements(&w,
 		channel.ChanType, channel.ChainHash, channel.FundingOutpoint,
@@ -1863,8 +2025,9 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
-	// For single funder channels that we initiated, write the funding txn.
-	if channel.ChanType == SingleFunder && channel.IsInitiator {
+	// For single funder channels that we initiated, and all dual funder
+	// channels, write the funding txn.
+	if channel.ChanType == DualFunder || channel.IsInitiator {
 		if err := writeElement(&w, channel.FundingTxn); err != nil {
 			return err
 		}
@@ -1885,7 +2048,52 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
-	return chanBucket.Put(chanInfoKey, w.Bytes())
+	if err := chanBucket.Put(chanInfoKey, w.Bytes()); err != nil {
+		return err
+	}
+
+	// Finally, we'll write out the upfront shutdown scripts, if either
+	// party committed to one. These are stored under distinct keys to
+	// remain compatible with channels created before their introduction.
+	err := putOptionalShutdownScript(
+		chanBucket, localUpfrontShutdownKey, channel.LocalShutdownScript,
+	)
+	if err != nil {
+		return err
+	}
+	return putOptionalShutdownScript(
+		chanBucket, remoteUpfrontShutdownKey, channel.RemoteShutdownScript,
+	)
+}
+
+// putOptionalShutdownScript stores the passed upfront shutdown script under
+// the given key, if it's non-empty.
+func putOptionalShutdownScript(chanBucket kvdb.Bucket, key []byte,
+	script lnwire.DeliveryAddress) error {
+
+	if len(script) == 0 {
+		return nil
+	}
+
+	return chanBucket.Put(key, script)
+}
+
+// fetchOptionalShutdownScript retrieves the upfront shutdown script stored
+// under the given key, returning nil if no script was committed to.
+func fetchOptionalShutdownScript(chanBucket kvdb.Bucket,
+	key []byte) lnwire.DeliveryAddress {
+
+	scriptBytes := chanBucket.Get(key)
+	if scriptBytes == nil {
+		return nil
+	}
+
+	// As the returned slice is only valid for the lifetime of the
+	// transaction, we'll make a copy of it.
+	script := make(lnwire.DeliveryAddress, len(scriptBytes))
+	copy(script, scriptBytes)
+
+	return script
 }
 
 func serializeChanCommit(w io.Writer, c *ChannelCommitment) error {
@@ -1901,7 +2109,7 @@ func serializeChanCommit(w io.Writer, c *ChannelCommitment) error {
 	return SerializeHtlcs(w, c.Htlcs...)
 }
 
-func putChanCommitment(chanBucket *bolt.Bucket, c *ChannelCommitment,
+func putChanCommitment(chanBucket kvdb.Bucket, c *ChannelCommitment,
 	local bool) error {
 
 	var commitKey []byte
@@ -1919,7 +2127,7 @@ func putChanCommitment(chanBucket *bolt.Bucket, c *ChannelCommitment,
 	return chanBucket.Put(commitKey, b.Bytes())
 }
 
-func putChanCommitments(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func putChanCommitments(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 	err := putChanCommitment(chanBucket, &channel.LocalCommitment, true)
 	if err != nil {
 		return err
@@ -1928,7 +2136,7 @@ func putChanCommitments(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 	return putChanCommitment(chanBucket, &channel.RemoteCommitment, false)
 }
 
-func putChanRevocationState(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func putChanRevocationState(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 
 	var b bytes.Buffer
 	err := writeElements(
@@ -1953,7 +2161,7 @@ func putChanRevocationState(chanBucket *bolt.Bucket, channel *OpenChannel) error
 	return chanBucket.Put(revocationStateKey, b.Bytes())
 }
 
-func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func fetchChanInfo(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 	infoBytes := chanBucket.Get(chanInfoKey)
 	if infoBytes == nil {
 		return ErrNoChanInfoFound
@@ -1971,8 +2179,9 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
-	// For single funder channels that we initiated, read the funding txn.
-	if channel.ChanType == SingleFunder && channel.IsInitiator {
+	// For single funder channels that we initiated, and all dual funder
+	// channels, read the funding txn.
+	if channel.ChanType == DualFunder || channel.IsInitiator {
 		if err := readElement(r, &channel.FundingTxn); err != nil {
 			return err
 		}
@@ -1994,6 +2203,13 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	channel.LocalShutdownScript = fetchOptionalShutdownScript(
+		chanBucket, localUpfrontShutdownKey,
+	)
+	channel.RemoteShutdownScript = fetchOptionalShutdownScript(
+		chanBucket, remoteUpfrontShutdownKey,
+	)
+
 	channel.Packager = NewChannelPackager(channel.ShortChanID)
 
 	return nil
@@ -2019,7 +2235,7 @@ func deserializeChanCommit(r io.Reader) (ChannelCommitment, error) {
 	return c, nil
 }
 
-func fetchChanCommitment(chanBucket *bolt.Bucket, local bool) (ChannelCommitment, error) {
+func fetchChanCommitment(chanBucket kvdb.Bucket, local bool) (ChannelCommitment, error) {
 	var commitKey []byte
 	if local {
 		commitKey = append(chanCommitmentKey, byte(0x00))
@@ -2036,7 +2252,7 @@ func fetchChanCommitment(chanBucket *bolt.Bucket, local bool) (ChannelCommitment
 	return deserializeChanCommit(r)
 }
 
-func fetchChanCommitments(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func fetchChanCommitments(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 	var err error
 
 	channel.LocalCommitment, err = fetchChanCommitment(chanBucket, true)
@@ -2051,7 +2267,7 @@ func fetchChanCommitments(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 	return nil
 }
 
-func fetchChanRevocationState(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+func fetchChanRevocationState(chanBucket kvdb.Bucket, channel *OpenChannel) error {
 	revBytes := chanBucket.Get(revocationStateKey)
 	if revBytes == nil {
 		return ErrNoRevocationsFound
@@ -2077,7 +2293,7 @@ func fetchChanRevocationState(chanBucket *bolt.Bucket, channel *OpenChannel) err
 	return readElements(r, &channel.RemoteNextRevocation)
 }
 
-func deleteOpenChannel(chanBucket *bolt.Bucket, chanPointBytes []byte) error {
+func deleteOpenChannel(chanBucket kvdb.Bucket, chanPointBytes []byte) error {
 
 	if err := chanBucket.Delete(chanInfoKey); err != nil {
 		return err
@@ -2110,32 +2326,32 @@ func makeLogKey(updateNum uint64) [8]byte {
 	return key
 }
 
-func appendChannelLogEntry(log *bolt.Bucket,
-	commit *ChannelCommitment) error {
+func appendChannelLogEntry(log kvdb.Bucket,
+	entry *RevocationLogEntry) error {
 
 	var b bytes.Buffer
-	if err := serializeChanCommit(&b, commit); err != nil {
+	if err := serializeRevocationLogEntry(&b, entry); err != nil {
 		return err
 	}
 
-	logEntrykey := makeLogKey(commit.CommitHeight)
+	logEntrykey := makeLogKey(entry.CommitHeight)
 	return log.Put(logEntrykey[:], b.Bytes())
 }
 
-func fetchChannelLogEntry(log *bolt.Bucket,
-	updateNum uint64) (ChannelCommitment, error) {
+func fetchChannelLogEntry(log kvdb.Bucket,
+	updateNum uint64) (*RevocationLogEntry, error) {
 
 	logEntrykey := makeLogKey(updateNum)
-	commitBytes := log.Get(logEntrykey[:])
-	if commitBytes == nil {
-		return ChannelCommitment{}, fmt.Errorf("log entry not found")
+	entryBytes := log.Get(logEntrykey[:])
+	if entryBytes == nil {
+		return nil, fmt.Errorf("log entry not found")
 	}
 
-	commitReader := bytes.NewReader(commitBytes)
-	return deserializeChanCommit(commitReader)
+	entryReader := bytes.NewReader(entryBytes)
+	return deserializeRevocationLogEntry(entryReader)
 }
 
-func wipeChannelLogEntries(log *bolt.Bucket) error {
+func wipeChannelLogEntries(log kvdb.Bucket) error {
 	// TODO(roasbeef): comment
 
 	logCursor := log.Cursor()
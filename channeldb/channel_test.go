@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/shachain"
@@ -97,14 +98,26 @@ func makeTestDB() (*DB, func(), error) {
 		return nil, nil, err
 	}
 
-	// Next, create channeldb for the first time.
-	cdb, err := Open(tempDirName)
+	// Next, create channeldb for the first time on top of the backend
+	// selected for testing, which is either bolt or an embedded etcd
+	// instance when built with the kvdb_etcd build tag.
+	backend, backendCleanUp, err := kvdb.GetTestBackend(
+		tempDirName, "cdb",
+	)
 	if err != nil {
+		os.RemoveAll(tempDirName)
+		return nil, nil, err
+	}
+
+	cdb, err := CreateWithBackend(backend)
+	if err != nil {
+		backendCleanUp()
+		os.RemoveAll(tempDirName)
 		return nil, nil, err
 	}
 
 	cleanUp := func() {
-		cdb.Close()
+		backendCleanUp()
 		os.RemoveAll(tempDirName)
 	}
 
@@ -221,6 +234,7 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 		Db:                      cdb,
 		Packager:                NewChannelPackager(chanID),
 		FundingTxn:              testTx,
+		LocalShutdownScript:     bytes.Repeat([]byte{2}, 22),
 	}, nil
 }
 
@@ -344,6 +358,42 @@ func assertCommitmentEqual(t *testing.T, a, b *ChannelCommitment) {
 	}
 }
 
+// assertRevocationLogEntry asserts that the passed revocation log entry only
+// retains the data of the revoked commitment that's required for retribution.
+func assertRevocationLogEntry(t *testing.T, commit *ChannelCommitment,
+	entry *RevocationLogEntry) {
+
+	_, _, line, _ := runtime.Caller(1)
+
+	if entry.CommitHeight != commit.CommitHeight ||
+		entry.CommitTxHash != commit.CommitTx.TxHash() ||
+		entry.LocalBalance != commit.LocalBalance ||
+		entry.RemoteBalance != commit.RemoteBalance ||
+		entry.FeePerKw != commit.FeePerKw {
+
+		t.Fatalf("line %v: entry doesn't match commitment: %v vs %v",
+			line, spew.Sdump(commit), spew.Sdump(entry))
+	}
+
+	if len(entry.Htlcs) != len(commit.Htlcs) {
+		t.Fatalf("line %v: expected %v htlcs, got %v", line,
+			len(commit.Htlcs), len(entry.Htlcs))
+	}
+	for i, htlc := range commit.Htlcs {
+		expected := HTLC{
+			RHash:         htlc.RHash,
+			Amt:           htlc.Amt,
+			RefundTimeout: htlc.RefundTimeout,
+			OutputIndex:   htlc.OutputIndex,
+			Incoming:      htlc.Incoming,
+		}
+		if !reflect.DeepEqual(expected, entry.Htlcs[i]) {
+			t.Fatalf("line %v: htlcs don't match: %v vs %v", line,
+				spew.Sdump(expected), spew.Sdump(entry.Htlcs[i]))
+		}
+	}
+}
+
 func TestChannelStateTransition(t *testing.T) {
 	t.Parallel()
 
@@ -537,9 +587,9 @@ func TestChannelStateTransition(t *testing.T) {
 		t.Fatalf("unable to fetch past delta: %v", err)
 	}
 
-	// The two deltas (the original vs the on-disk version) should
-	// identical, and all HTLC data should properly be retained.
-	assertCommitmentEqual(t, &oldRemoteCommit, diskPrevCommit)
+	// The on-disk entry should retain all data of the original commitment
+	// that's required for retribution.
+	assertRevocationLogEntry(t, &oldRemoteCommit, diskPrevCommit)
 
 	// The state number recovered from the tail of the revocation log
 	// should be identical to this current state.
@@ -575,7 +625,7 @@ func TestChannelStateTransition(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch past delta: %v", err)
 	}
-	assertCommitmentEqual(t, &oldRemoteCommit, prevCommit)
+	assertRevocationLogEntry(t, &oldRemoteCommit, prevCommit)
 
 	// Once again, state number recovered from the tail of the revocation
 	// log should be identical to this current state.
@@ -163,6 +163,21 @@ func writeElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case ResolverType:
+		if err := binary.Write(w, byteOrder, e); err != nil {
+			return err
+		}
+
+	case ResolverOutcome:
+		if err := binary.Write(w, byteOrder, e); err != nil {
+			return err
+		}
+
+	case PaymentStatus:
+		if err := binary.Write(w, byteOrder, e); err != nil {
+			return err
+		}
+
 	default:
 		return fmt.Errorf("Unknown type in writeElement: %T", e)
 	}
@@ -342,6 +357,21 @@ func readElement(r io.Reader, element interface{}) error {
 			return err
 		}
 
+	case *ResolverType:
+		if err := binary.Read(r, byteOrder, e); err != nil {
+			return err
+		}
+
+	case *ResolverOutcome:
+		if err := binary.Read(r, byteOrder, e); err != nil {
+			return err
+		}
+
+	case *PaymentStatus:
+		if err := binary.Read(r, byteOrder, e); err != nil {
+			return err
+		}
+
 	default:
 		return fmt.Errorf("Unknown type in readElement: %T", e)
 	}
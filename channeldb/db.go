@@ -7,9 +7,11 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/wire"
 )
@@ -17,12 +19,17 @@ import (
 const (
 	dbName           = "channel.db"
 	dbFilePermission = 0600
+
+	// dbOpenTimeout is the maximum duration we'll wait to obtain the file
+	// lock of the database when opening it read-only. If it's still held,
+	// the database is most likely in use by a running lnd instance.
+	dbOpenTimeout = 5 * time.Second
 )
 
 // migration is a function which takes a prior outdated version of the database
 // instances and mutates the key/bucket structure to arrive at a more
 // up-to-date version of the database.
-type migration func(tx *bolt.Tx) error
+type migration func(tx kvdb.Tx) error
 
 type version struct {
 	number    uint32
@@ -40,6 +47,18 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version of the database where edge policies
+			// gained the optional max_htlc field.
+			number:    1,
+			migration: migrateEdgePolicyMaxHTLC,
+		},
+		{
+			// The version of the database where revocation logs
+			// only retain the data required for retribution.
+			number:    2,
+			migration: migrateCompactRevocationLogs,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -55,37 +74,145 @@ var bufPool = &sync.Pool{
 // information related to nodes, routing data, open/closed channels, fee
 // schedules, and reputation data.
 type DB struct {
-	*bolt.DB
+	kvdb.Backend
 	dbPath string
+
+	// graphCache is the in-memory copy of the channel graph used for path
+	// finding. It's nil if the cache is disabled, or the database was
+	// opened in read-only mode.
+	graphCache *graphCache
 }
 
 // Open opens an existing channeldb. Any necessary schemas migrations due to
 // updates will take place as necessary.
-func Open(dbPath string) (*DB, error) {
+func Open(dbPath string, modifiers ...OptionModifier) (*DB, error) {
+	if !fileExists(dbPath) {
+		if err := os.MkdirAll(dbPath, 0700); err != nil {
+			return nil, err
+		}
+	}
+
 	path := filepath.Join(dbPath, dbName)
+	backend, err := kvdb.OpenBolt(path, dbFilePermission, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	chanDB, err := CreateWithBackend(backend, modifiers...)
+	if err != nil {
+		backend.Close()
+		return nil, err
+	}
+	chanDB.dbPath = dbPath
 
+	return chanDB, nil
+}
+
+// OpenReadOnly opens the bolt channeldb within the passed directory in
+// read-only mode, allowing it to be inspected by offline tooling. As no schema
+// migrations can be applied, an error is returned if the database isn't at the
+// latest version. ErrDBInUse is returned if the database is held open by a
+// running lnd instance.
+func OpenReadOnly(dbPath string) (*DB, error) {
+	path := filepath.Join(dbPath, dbName)
 	if !fileExists(path) {
-		if err := createChannelDB(dbPath); err != nil {
-			return nil, err
-		}
+		return nil, fmt.Errorf("channeldb not found at %v", path)
 	}
 
-	bdb, err := bolt.Open(path, dbFilePermission, nil)
+	backend, err := kvdb.OpenBolt(path, dbFilePermission, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  dbOpenTimeout,
+	})
+	switch {
+	// bolt only takes a shared lock on the file in read-only mode, which
+	// can't be obtained while lnd holds its exclusive one.
+	case err == bolt.ErrTimeout:
+		return nil, ErrDBInUse
+
+	case err != nil:
+		return nil, err
+	}
+
+	chanDB := &DB{
+		Backend: backend,
+		dbPath:  dbPath,
+	}
+
+	meta, err := chanDB.FetchMeta(nil)
 	if err != nil {
+		backend.Close()
+		return nil, err
+	}
+
+	latestVersion := getLatestDBVersion(dbVersions)
+	if meta.DbVersionNumber != latestVersion {
+		backend.Close()
+		return nil, fmt.Errorf("channeldb is at version %v, while the "+
+			"latest version is %v, it must be migrated by lnd "+
+			"first", meta.DbVersionNumber, latestVersion)
+	}
+
+	return chanDB, nil
+}
+
+// Compact rewrites the bolt channeldb within the passed directory into a fresh
+// file, reclaiming the space left unused by deleted data. The database MUST
+// NOT be open while compacting.
+func Compact(dbPath string) error {
+	path := filepath.Join(dbPath, dbName)
+	if !fileExists(path) {
+		return nil
+	}
+
+	// We'll compact into a temporary file first, only replacing the
+	// original once the copy has been completed successfully.
+	tempPath := path + ".compact"
+	if err := os.RemoveAll(tempPath); err != nil {
+		return err
+	}
+
+	if err := kvdb.CompactBolt(path, tempPath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
+
+// CreateWithBackend creates a channeldb instance using the passed kvdb
+// backend. If the backend hasn't been initialized yet, all required top-level
+// buckets are created. Any necessary schema migrations due to updates will
+// take place as necessary. Unless disabled, the graph cache is populated
+// before returning.
+func CreateWithBackend(backend kvdb.Backend,
+	modifiers ...OptionModifier) (*DB, error) {
+
+	opts := DefaultOptions()
+	for _, modifier := range modifiers {
+		modifier(&opts)
+	}
+
+	if err := initChannelDB(backend); err != nil {
 		return nil, err
 	}
 
 	chanDB := &DB{
-		DB:     bdb,
-		dbPath: dbPath,
+		Backend: backend,
 	}
 
 	// Synchronize the version of database and apply migrations if needed.
 	if err := chanDB.syncVersions(dbVersions); err != nil {
-		bdb.Close()
 		return nil, err
 	}
 
+	if !opts.NoGraphCache {
+		cache := newGraphCache()
+		if err := cache.populate(chanDB.ChannelGraph()); err != nil {
+			return nil, err
+		}
+		chanDB.graphCache = cache
+	}
+
 	return chanDB, nil
 }
 
@@ -98,66 +225,69 @@ func (d *DB) Path() string {
 // database. The deletion is done in a single transaction, therefore this
 // operation is fully atomic.
 func (d *DB) Wipe() error {
-	return d.Update(func(tx *bolt.Tx) error {
+	err := d.Update(func(tx kvdb.Tx) error {
 		err := tx.DeleteBucket(openChannelBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 
 		err = tx.DeleteBucket(closedChannelBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 
 		err = tx.DeleteBucket(invoiceBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 
 		err = tx.DeleteBucket(nodeInfoBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 
 		err = tx.DeleteBucket(nodeBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 		err = tx.DeleteBucket(edgeBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 		err = tx.DeleteBucket(edgeIndexBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 		err = tx.DeleteBucket(graphMetaBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 
 		return nil
 	})
-}
-
-// createChannelDB creates and initializes a fresh version of channeldb. In
-// the case that the target path has not yet been created or doesn't yet exist,
-// then the path is created. Additionally, all required top-level buckets used
-// within the database are created.
-func createChannelDB(dbPath string) error {
-	if !fileExists(dbPath) {
-		if err := os.MkdirAll(dbPath, 0700); err != nil {
-			return err
-		}
-	}
-
-	path := filepath.Join(dbPath, dbName)
-	bdb, err := bolt.Open(path, dbFilePermission, nil)
 	if err != nil {
 		return err
 	}
 
-	err = bdb.Update(func(tx *bolt.Tx) error {
+	if d.graphCache != nil {
+		d.graphCache.reset()
+	}
+
+	return nil
+}
+
+// initChannelDB initializes a fresh version of channeldb within the passed
+// backend, creating all required top-level buckets used within the database.
+// If the backend has already been initialized, then this is a no-op.
+func initChannelDB(backend kvdb.Backend) error {
+	err := backend.Update(func(tx kvdb.Tx) error {
+		// The meta bucket is the last one created below, so its
+		// existence signals that the database was already
+		// initialized.
+		if tx.Bucket(metaBucket) != nil {
+			return nil
+		}
+
 		if _, err := tx.CreateBucket(openChannelBucket); err != nil {
 			return err
 		}
@@ -197,10 +327,10 @@ func createChannelDB(dbPath string) error {
 		return putMeta(meta, tx)
 	})
 	if err != nil {
-		return fmt.Errorf("unable to create new channeldb")
+		return fmt.Errorf("unable to create new channeldb: %v", err)
 	}
 
-	return bdb.Close()
+	return nil
 }
 
 // fileExists returns true if the file exists, and false otherwise.
@@ -220,7 +350,7 @@ func fileExists(path string) bool {
 // returned.
 func (d *DB) FetchOpenChannels(nodeID *btcec.PublicKey) ([]*OpenChannel, error) {
 	var channels []*OpenChannel
-	err := d.View(func(tx *bolt.Tx) error {
+	err := d.View(func(tx kvdb.Tx) error {
 		// Get the bucket dedicated to storing the metadata for open
 		// channels.
 		openChanBucket := tx.Bucket(openChannelBucket)
@@ -272,7 +402,7 @@ func (d *DB) FetchOpenChannels(nodeID *btcec.PublicKey) ([]*OpenChannel, error)
 // fetchNodeChannels retrieves all active channels from the target chainBucket
 // which is under a node's dedicated channel bucket. This function is typically
 // used to fetch all the active channels related to a particular node.
-func (d *DB) fetchNodeChannels(chainBucket *bolt.Bucket) ([]*OpenChannel, error) {
+func (d *DB) fetchNodeChannels(chainBucket kvdb.Bucket) ([]*OpenChannel, error) {
 
 	var channels []*OpenChannel
 
@@ -368,7 +498,7 @@ func (d *DB) FetchWaitingCloseChannels() ([]*OpenChannel, error) {
 func fetchChannels(d *DB, pending, waitingClose bool) ([]*OpenChannel, error) {
 	var channels []*OpenChannel
 
-	err := d.View(func(tx *bolt.Tx) error {
+	err := d.View(func(tx kvdb.Tx) error {
 		// Get the bucket dedicated to storing the metadata for open
 		// channels.
 		openChanBucket := tx.Bucket(openChannelBucket)
@@ -455,7 +585,7 @@ func fetchChannels(d *DB, pending, waitingClose bool) ([]*OpenChannel, error) {
 func (d *DB) FetchClosedChannels(pendingOnly bool) ([]*ChannelCloseSummary, error) {
 	var chanSummaries []*ChannelCloseSummary
 
-	if err := d.View(func(tx *bolt.Tx) error {
+	if err := d.View(func(tx kvdb.Tx) error {
 		closeBucket := tx.Bucket(closedChannelBucket)
 		if closeBucket == nil {
 			return ErrNoClosedChannels
@@ -493,7 +623,7 @@ var ErrClosedChannelNotFound = errors.New("unable to find closed channel summary
 // point of the channel in question.
 func (d *DB) FetchClosedChannel(chanID *wire.OutPoint) (*ChannelCloseSummary, error) {
 	var chanSummary *ChannelCloseSummary
-	if err := d.View(func(tx *bolt.Tx) error {
+	if err := d.View(func(tx kvdb.Tx) error {
 		closeBucket := tx.Bucket(closedChannelBucket)
 		if closeBucket == nil {
 			return ErrClosedChannelNotFound
@@ -526,7 +656,7 @@ func (d *DB) FetchClosedChannel(chanID *wire.OutPoint) (*ChannelCloseSummary, er
 // cooperatively closed and it's reached a single confirmation, or after all the
 // pending funds in a channel that has been forcibly closed have been swept.
 func (d *DB) MarkChanFullyClosed(chanPoint *wire.OutPoint) error {
-	return d.Update(func(tx *bolt.Tx) error {
+	return d.Update(func(tx kvdb.Tx) error {
 		var b bytes.Buffer
 		if err := writeOutpoint(&b, chanPoint); err != nil {
 			return err
@@ -596,7 +726,7 @@ func (d *DB) syncVersions(versions []version) error {
 	// the migration is atomic.
 	migrations, migrationVersions := getMigrationsToApply(versions,
 		meta.DbVersionNumber)
-	return d.Update(func(tx *bolt.Tx) error {
+	return d.Update(func(tx kvdb.Tx) error {
 		for i, migration := range migrations {
 			if migration == nil {
 				continue
@@ -1,10 +1,15 @@
 package channeldb
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
 )
 
 func TestOpenWithCreate(t *testing.T) {
@@ -33,3 +38,106 @@ func TestOpenWithCreate(t *testing.T) {
 		t.Fatalf("channeldb failed to create data directory")
 	}
 }
+
+// TestPruneFwdPkgs tests that pruning the forwarding packages removes all
+// completed packages, along with all packages of fully closed channels.
+func TestPruneFwdPkgs(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	openChanID := lnwire.NewShortChanIDFromInt(1)
+	closedChanID := lnwire.NewShortChanIDFromInt(2)
+
+	// addFwdPkg writes a processed forwarding package containing the
+	// passed adds, none of which have been acked yet.
+	addFwdPkg := func(source lnwire.ShortChannelID, height uint64,
+		adds []LogUpdate) {
+
+		packager := NewChannelPackager(source)
+		fwdPkg := NewFwdPkg(source, height, adds, nil)
+		err := cdb.Update(func(tx kvdb.Tx) error {
+			if err := packager.AddFwdPkg(tx, fwdPkg); err != nil {
+				return err
+			}
+			return packager.SetFwdFilter(
+				tx, height, fwdPkg.FwdFilter,
+			)
+		})
+		if err != nil {
+			t.Fatalf("unable to add fwd pkg: %v", err)
+		}
+	}
+
+	adds := []LogUpdate{
+		{
+			LogIndex: 0,
+			UpdateMsg: &lnwire.UpdateAddHTLC{
+				Amount: 100,
+				Expiry: 1000,
+			},
+		},
+	}
+
+	// The open channel will have a completed package without any
+	// updates, followed by one which still has an unresolved add.
+	addFwdPkg(openChanID, 0, nil)
+	addFwdPkg(openChanID, 1, adds)
+
+	// The closed channel will also have a package with an unresolved add,
+	// but as the channel has been fully closed, it's no longer needed.
+	addFwdPkg(closedChanID, 0, adds)
+	err = cdb.Update(func(tx kvdb.Tx) error {
+		var b bytes.Buffer
+		chanPoint := wire.OutPoint{Index: 2}
+		if err := writeOutpoint(&b, &chanPoint); err != nil {
+			return err
+		}
+
+		return putChannelCloseSummary(tx, b.Bytes(), &ChannelCloseSummary{
+			ChanPoint:   chanPoint,
+			ShortChanID: closedChanID,
+			RemotePub:   pubKey,
+			CloseType:   CooperativeClose,
+			IsPending:   false,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to add close summary: %v", err)
+	}
+
+	numPruned, err := cdb.PruneFwdPkgs()
+	if err != nil {
+		t.Fatalf("unable to prune fwd pkgs: %v", err)
+	}
+	if numPruned != 2 {
+		t.Fatalf("expected 2 pruned fwd pkgs, got %v", numPruned)
+	}
+
+	// Only the open channel's unresolved package should remain.
+	loadFwdPkgs := func(source lnwire.ShortChannelID) []*FwdPkg {
+		var fwdPkgs []*FwdPkg
+		err := cdb.View(func(tx kvdb.Tx) error {
+			var err error
+			fwdPkgs, err = loadChannelFwdPkgs(tx, source)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to load fwd pkgs: %v", err)
+		}
+		return fwdPkgs
+	}
+
+	fwdPkgs := loadFwdPkgs(openChanID)
+	if len(fwdPkgs) != 1 || fwdPkgs[0].Height != 1 {
+		t.Fatalf("expected fwd pkg at height 1, got %v", fwdPkgs)
+	}
+	if fwdPkgs := loadFwdPkgs(closedChanID); len(fwdPkgs) != 0 {
+		t.Fatalf("expected no fwd pkgs for closed channel, got %v",
+			fwdPkgs)
+	}
+}
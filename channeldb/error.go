@@ -71,6 +71,14 @@ var (
 	// can't be found.
 	ErrEdgeNotFound = fmt.Errorf("edge not found")
 
+	// ErrZombieEdge is returned when a channel being looked up has been
+	// pruned from the graph and marked as a zombie.
+	ErrZombieEdge = fmt.Errorf("edge marked as zombie")
+
+	// ErrZombieEdgeNotFound is returned when a channel being marked live
+	// isn't a zombie.
+	ErrZombieEdgeNotFound = fmt.Errorf("zombie edge not found")
+
 	// ErrEdgeAlreadyExist is returned when edge with specific
 	// channel id can't be added because it already exist.
 	ErrEdgeAlreadyExist = fmt.Errorf("edge already exist")
@@ -89,4 +97,8 @@ var (
 	// ErrNoForwardingEvents is returned in the case that a query fails due
 	// to the log not having any recorded events.
 	ErrNoForwardingEvents = fmt.Errorf("no recorded forwarding events")
+
+	// ErrDBInUse is returned when the database can't be opened read-only
+	// as its file lock is held, most likely by a running lnd instance.
+	ErrDBInUse = fmt.Errorf("channeldb is in use by another process")
 )
@@ -10,7 +10,7 @@ import (
 	"net"
 	"time"
 
-	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -82,6 +82,16 @@ var (
 	// maps: outPoint -> chanID
 	channelPointBucket = []byte("chan-index")
 
+	// zombieBucket is a sub-bucket of the edgeBucket that indexes the
+	// channels considered zombies: channels we've pruned from the graph
+	// as neither of their directions has been updated in a long time. We
+	// keep the public keys of the nodes of each zombie channel, so the
+	// channel can be resurrected once either of them sends us a fresh
+	// update for it.
+	//
+	// maps: chanID -> pubKey1 || pubKey2
+	zombieBucket = []byte("zombie-index")
+
 	// graphMetaBucket is a top-level bucket which stores various meta-deta
 	// related to the on-disk channel graph. Data stored in this bucket
 	// includes the block to which the graph has been synced to, the total
@@ -127,6 +137,60 @@ func (c *ChannelGraph) Database() *DB {
 	return c.db
 }
 
+// update executes the passed closure within a database transaction, and if
+// the transaction commits successfully, applies the matching change to the
+// graph cache through the passed cache update. Writes are serialized while
+// the cache is enabled, so it's updated in the same order as the database.
+func (c *ChannelGraph) update(f func(tx kvdb.Tx) error,
+	updateCache func(cache *graphCache)) error {
+
+	cache := c.db.graphCache
+	if cache == nil {
+		return c.db.Update(f)
+	}
+
+	cache.writeMtx.Lock()
+	defer cache.writeMtx.Unlock()
+
+	if err := c.db.Update(f); err != nil {
+		return err
+	}
+
+	updateCache(cache)
+	return nil
+}
+
+// ForEachNodeChannel iterates through all the channels of the given node with
+// a known outgoing policy, executing the passed callback with the channel's
+// information, the outgoing policy of the node, and the incoming policy from
+// the connecting node, which may be nil. If the callback returns an error,
+// then the iteration is halted with the error propagated back up to the
+// caller.
+//
+// Unless disabled, the channels are read from the in-memory graph cache, in
+// which case the passed transaction is ignored, and neither the channel
+// information nor the nodes the policies point to carry their signatures. The
+// channel information is shared with the cache, so it MUST NOT be modified.
+// Otherwise, the passed transaction is used to read the channels from disk,
+// or a fresh one is created if it's nil.
+func (c *ChannelGraph) ForEachNodeChannel(tx kvdb.Tx, node [33]byte,
+	cb func(*ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
+
+	if c.db.graphCache != nil {
+		return c.db.graphCache.forEachNodeChannel(c.db, node, cb)
+	}
+
+	lightningNode := &LightningNode{
+		PubKeyBytes: node,
+		db:          c.db,
+	}
+	return lightningNode.ForEachChannel(tx, func(_ kvdb.Tx,
+		info *ChannelEdgeInfo, out, in *ChannelEdgePolicy) error {
+
+		return cb(info, out, in)
+	})
+}
+
 // ForEachChannel iterates through all the channel edges stored within the
 // graph and invokes the passed callback for each edge. The callback takes two
 // edges as since this is a directed graph, both the in/out edges are visited.
@@ -139,7 +203,7 @@ func (c *ChannelGraph) Database() *DB {
 func (c *ChannelGraph) ForEachChannel(cb func(*ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
 	// TODO(roasbeef): ptr map to reduce # of allocs? no duplicates
 
-	return c.db.View(func(tx *bolt.Tx) error {
+	return c.db.View(func(tx kvdb.Tx) error {
 		// First, grab the node bucket. This will be used to populate
 		// the Node pointers in each edge read from disk.
 		nodes := tx.Bucket(nodeBucket)
@@ -227,8 +291,8 @@ func (c *ChannelGraph) ForEachChannel(cb func(*ChannelEdgeInfo, *ChannelEdgePoli
 //
 // TODO(roasbeef): add iterator interface to allow for memory efficient graph
 // traversal when graph gets mega
-func (c *ChannelGraph) ForEachNode(tx *bolt.Tx, cb func(*bolt.Tx, *LightningNode) error) error {
-	traversal := func(tx *bolt.Tx) error {
+func (c *ChannelGraph) ForEachNode(tx kvdb.Tx, cb func(kvdb.Tx, *LightningNode) error) error {
+	traversal := func(tx kvdb.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes := tx.Bucket(nodeBucket)
@@ -274,7 +338,7 @@ func (c *ChannelGraph) ForEachNode(tx *bolt.Tx, cb func(*bolt.Tx, *LightningNode
 // node based off the source node.
 func (c *ChannelGraph) SourceNode() (*LightningNode, error) {
 	var source *LightningNode
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes := tx.Bucket(nodeBucket)
@@ -311,7 +375,7 @@ func (c *ChannelGraph) SourceNode() (*LightningNode, error) {
 func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 	nodePubBytes := node.PubKeyBytes[:]
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	return c.update(func(tx kvdb.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes, err := tx.CreateBucketIfNotExists(nodeBucket)
@@ -328,6 +392,8 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 		// Finally, we commit the information of the lightning node
 		// itself.
 		return addLightningNode(tx, node)
+	}, func(cache *graphCache) {
+		cache.updateNode(node)
 	})
 }
 
@@ -340,12 +406,14 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 //
 // TODO(roasbeef): also need sig of announcement
 func (c *ChannelGraph) AddLightningNode(node *LightningNode) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
+	return c.update(func(tx kvdb.Tx) error {
 		return addLightningNode(tx, node)
+	}, func(cache *graphCache) {
+		cache.updateNode(node)
 	})
 }
 
-func addLightningNode(tx *bolt.Tx, node *LightningNode) error {
+func addLightningNode(tx kvdb.Tx, node *LightningNode) error {
 	nodes, err := tx.CreateBucketIfNotExists(nodeBucket)
 	if err != nil {
 		return err
@@ -364,7 +432,7 @@ func addLightningNode(tx *bolt.Tx, node *LightningNode) error {
 func (c *ChannelGraph) LookupAlias(pub *btcec.PublicKey) (string, error) {
 	var alias string
 
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		nodes := tx.Bucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNodesNotFound
@@ -399,7 +467,7 @@ func (c *ChannelGraph) DeleteLightningNode(nodePub *btcec.PublicKey) error {
 	pub := nodePub.SerializeCompressed()
 
 	// TODO(roasbeef): ensure dangling edges are removed...
-	return c.db.Update(func(tx *bolt.Tx) error {
+	return c.update(func(tx kvdb.Tx) error {
 		nodes, err := tx.CreateBucketIfNotExists(nodeBucket)
 		if err != nil {
 			return err
@@ -414,6 +482,10 @@ func (c *ChannelGraph) DeleteLightningNode(nodePub *btcec.PublicKey) error {
 			return err
 		}
 		return nodes.Delete(pub)
+	}, func(cache *graphCache) {
+		var node [33]byte
+		copy(node[:], pub)
+		cache.removeNode(node)
 	})
 }
 
@@ -428,7 +500,7 @@ func (c *ChannelGraph) AddChannelEdge(edge *ChannelEdgeInfo) error {
 	var chanKey [8]byte
 	binary.BigEndian.PutUint64(chanKey[:], edge.ChannelID)
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	return c.update(func(tx kvdb.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -463,6 +535,8 @@ func (c *ChannelGraph) AddChannelEdge(edge *ChannelEdgeInfo) error {
 			return err
 		}
 		return chanIndex.Put(b.Bytes(), chanKey[:])
+	}, func(cache *graphCache) {
+		cache.addChannel(edge)
 	})
 }
 
@@ -479,7 +553,7 @@ func (c *ChannelGraph) HasChannelEdge(chanID uint64) (time.Time, time.Time, bool
 		exists          bool
 	)
 
-	if err := c.db.View(func(tx *bolt.Tx) error {
+	if err := c.db.View(func(tx kvdb.Tx) error {
 		edges := tx.Bucket(edgeBucket)
 		if edges == nil {
 			return ErrGraphNoEdgesFound
@@ -539,7 +613,7 @@ func (c *ChannelGraph) UpdateChannelEdge(edge *ChannelEdgeInfo) error {
 	var chanKey [8]byte
 	binary.BigEndian.PutUint64(chanKey[:], edge.ChannelID)
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	return c.update(func(tx kvdb.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -554,6 +628,8 @@ func (c *ChannelGraph) UpdateChannelEdge(edge *ChannelEdgeInfo) error {
 		}
 
 		return putChanEdgeInfo(edgeIndex, edge, chanKey)
+	}, func(cache *graphCache) {
+		cache.addChannel(edge)
 	})
 }
 
@@ -578,7 +654,7 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 
 	var chansClosed []*ChannelEdgeInfo
 
-	err := c.db.Update(func(tx *bolt.Tx) error {
+	err := c.update(func(tx kvdb.Tx) error {
 		// First grab the edges bucket which houses the information
 		// we'd like to delete
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
@@ -655,6 +731,10 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 		copy(newTip[:], blockHash[:])
 
 		return pruneBucket.Put(blockHeightBytes[:], newTip[:])
+	}, func(cache *graphCache) {
+		for _, edgeInfo := range chansClosed {
+			cache.removeChannels(edgeInfo.ChannelID)
+		}
 	})
 	if err != nil {
 		return nil, err
@@ -694,7 +774,7 @@ func (c *ChannelGraph) DisconnectBlockAtHeight(height uint32) ([]*ChannelEdgeInf
 	// Keep track of the channels that are removed from the graph.
 	var removedChans []*ChannelEdgeInfo
 
-	if err := c.db.Update(func(tx *bolt.Tx) error {
+	err := c.update(func(tx kvdb.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -757,7 +837,12 @@ func (c *ChannelGraph) DisconnectBlockAtHeight(height uint32) ([]*ChannelEdgeInf
 		}
 
 		return nil
-	}); err != nil {
+	}, func(cache *graphCache) {
+		for _, edgeInfo := range removedChans {
+			cache.removeChannels(edgeInfo.ChannelID)
+		}
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -774,7 +859,7 @@ func (c *ChannelGraph) PruneTip() (*chainhash.Hash, uint32, error) {
 		tipHeight uint32
 	)
 
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		graphMeta := tx.Bucket(graphMetaBucket)
 		if graphMeta == nil {
 			return ErrGraphNotFound
@@ -809,13 +894,17 @@ func (c *ChannelGraph) PruneTip() (*chainhash.Hash, uint32, error) {
 
 // DeleteChannelEdge removes an edge from the database as identified by its
 // funding outpoint. If the edge does not exist within the database, then
-// ErrEdgeNotFound will be returned.
-func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
+// ErrEdgeNotFound will be returned. If markZombie is set, the channel is
+// recorded within the zombie index in the same transaction, so it's never
+// left deleted without being marked as a zombie.
+func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint,
+	markZombie bool) error {
 	// TODO(roasbeef): possibly delete from node bucket if node has no more
 	// channels
 	// TODO(roasbeef): don't delete both edges?
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	var chanID uint64
+	return c.update(func(tx kvdb.Tx) error {
 		// First grab the edges bucket which houses the information
 		// we'd like to delete
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
@@ -832,10 +921,186 @@ func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
 			return err
 		}
 
-		return delChannelByEdge(edges, edgeIndex, chanIndex, chanPoint)
+		// We'll note the ID of the channel before deleting it, so it
+		// can be removed from the cache as well.
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, chanPoint); err != nil {
+			return err
+		}
+		chanIDBytes := chanIndex.Get(b.Bytes())
+		if chanIDBytes == nil {
+			return ErrEdgeNotFound
+		}
+		chanID = byteOrder.Uint64(chanIDBytes)
+
+		// If the channel is to be marked as a zombie, we'll need the
+		// keys of its nodes, so we fetch them before deleting it.
+		var edgeInfo ChannelEdgeInfo
+		if markZombie {
+			edgeInfo, err = fetchChanEdgeInfo(edgeIndex, chanIDBytes)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = delChannelByEdge(edges, edgeIndex, chanIndex, chanPoint)
+		if err != nil {
+			return err
+		}
+
+		if !markZombie {
+			return nil
+		}
+
+		zombieIndex, err := edges.CreateBucketIfNotExists(zombieBucket)
+		if err != nil {
+			return err
+		}
+
+		return markEdgeZombie(
+			zombieIndex, chanID, edgeInfo.NodeKey1Bytes,
+			edgeInfo.NodeKey2Bytes,
+		)
+	}, func(cache *graphCache) {
+		cache.removeChannels(chanID)
 	})
 }
 
+// MarkEdgeZombie marks the channel with the given ID as a zombie, recording
+// the public keys of its two nodes. Announcements for a zombie channel are
+// ignored until it's marked live again through MarkEdgeLive.
+func (c *ChannelGraph) MarkEdgeZombie(chanID uint64,
+	pubKey1, pubKey2 [33]byte) error {
+
+	return c.db.Update(func(tx kvdb.Tx) error {
+		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
+		if err != nil {
+			return err
+		}
+		zombieIndex, err := edges.CreateBucketIfNotExists(zombieBucket)
+		if err != nil {
+			return err
+		}
+
+		return markEdgeZombie(zombieIndex, chanID, pubKey1, pubKey2)
+	})
+}
+
+// markEdgeZombie records the channel with the given ID and node keys within
+// the passed zombie index.
+func markEdgeZombie(zombieIndex kvdb.Bucket, chanID uint64,
+	pubKey1, pubKey2 [33]byte) error {
+
+	var k [8]byte
+	byteOrder.PutUint64(k[:], chanID)
+
+	var v [66]byte
+	copy(v[:33], pubKey1[:])
+	copy(v[33:], pubKey2[:])
+
+	return zombieIndex.Put(k[:], v[:])
+}
+
+// MarkEdgeLive removes the channel with the given ID from the zombie index,
+// allowing it to be added to the graph again. If the channel isn't a zombie,
+// ErrZombieEdgeNotFound is returned.
+func (c *ChannelGraph) MarkEdgeLive(chanID uint64) error {
+	return c.db.Update(func(tx kvdb.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrZombieEdgeNotFound
+		}
+		zombieIndex := edges.Bucket(zombieBucket)
+		if zombieIndex == nil {
+			return ErrZombieEdgeNotFound
+		}
+
+		var k [8]byte
+		byteOrder.PutUint64(k[:], chanID)
+		if zombieIndex.Get(k[:]) == nil {
+			return ErrZombieEdgeNotFound
+		}
+
+		return zombieIndex.Delete(k[:])
+	})
+}
+
+// IsZombieEdge returns whether the channel with the given ID is a zombie. If
+// it is, the public keys of its two nodes are returned as well.
+func (c *ChannelGraph) IsZombieEdge(chanID uint64) (bool, [33]byte,
+	[33]byte, error) {
+
+	var (
+		isZombie         bool
+		pubKey1, pubKey2 [33]byte
+	)
+	err := c.db.View(func(tx kvdb.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+
+		isZombie, pubKey1, pubKey2 = isZombieEdge(edges, chanID)
+		return nil
+	})
+	if err != nil {
+		return false, pubKey1, pubKey2, err
+	}
+
+	return isZombie, pubKey1, pubKey2, nil
+}
+
+// NumZombies returns the number of channels currently marked as zombies.
+func (c *ChannelGraph) NumZombies() (uint64, error) {
+	var numZombies uint64
+	err := c.db.View(func(tx kvdb.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		zombieIndex := edges.Bucket(zombieBucket)
+		if zombieIndex == nil {
+			return nil
+		}
+
+		return zombieIndex.ForEach(func(_, _ []byte) error {
+			numZombies++
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numZombies, nil
+}
+
+// isZombieEdge looks up the channel with the given ID within the zombie index
+// nested in the passed edge bucket, returning the public keys of its nodes if
+// found.
+func isZombieEdge(edges kvdb.Bucket, chanID uint64) (bool, [33]byte,
+	[33]byte) {
+
+	var pubKey1, pubKey2 [33]byte
+
+	zombieIndex := edges.Bucket(zombieBucket)
+	if zombieIndex == nil {
+		return false, pubKey1, pubKey2
+	}
+
+	var k [8]byte
+	byteOrder.PutUint64(k[:], chanID)
+
+	v := zombieIndex.Get(k[:])
+	if len(v) != 66 {
+		return false, pubKey1, pubKey2
+	}
+
+	copy(pubKey1[:], v[:33])
+	copy(pubKey2[:], v[33:])
+	return true, pubKey1, pubKey2
+}
+
 // ChannelID attempt to lookup the 8-byte compact channel ID which maps to the
 // passed channel point (outpoint). If the passed channel doesn't exist within
 // the database, then ErrEdgeNotFound is returned.
@@ -847,7 +1112,7 @@ func (c *ChannelGraph) ChannelID(chanPoint *wire.OutPoint) (uint64, error) {
 		return 0, nil
 	}
 
-	if err := c.db.View(func(tx *bolt.Tx) error {
+	if err := c.db.View(func(tx kvdb.Tx) error {
 		edges := tx.Bucket(edgeBucket)
 		if edges == nil {
 			return ErrGraphNoEdgesFound
@@ -872,8 +1137,8 @@ func (c *ChannelGraph) ChannelID(chanPoint *wire.OutPoint) (uint64, error) {
 	return chanID, nil
 }
 
-func delChannelByEdge(edges *bolt.Bucket, edgeIndex *bolt.Bucket,
-	chanIndex *bolt.Bucket, chanPoint *wire.OutPoint) error {
+func delChannelByEdge(edges kvdb.Bucket, edgeIndex kvdb.Bucket,
+	chanIndex kvdb.Bucket, chanPoint *wire.OutPoint) error {
 	var b bytes.Buffer
 	if err := writeOutpoint(&b, chanPoint); err != nil {
 		return err
@@ -933,7 +1198,9 @@ func delChannelByEdge(edges *bolt.Bucket, edgeIndex *bolt.Bucket,
 // determined by the lexicographical ordering of the identity public keys of
 // the nodes on either side of the channel.
 func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
+	isNode1 := edge.ChannelFlags&lnwire.ChanUpdateDirection == 0
+
+	return c.update(func(tx kvdb.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -958,7 +1225,7 @@ func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
 		// Depending on the flags value passed above, either the first
 		// or second edge policy is being updated.
 		var fromNode, toNode []byte
-		if edge.Flags&lnwire.ChanUpdateDirection == 0 {
+		if isNode1 {
 			fromNode = nodeInfo[:33]
 			toNode = nodeInfo[33:67]
 		} else {
@@ -969,6 +1236,8 @@ func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
 		// Finally, with the direction of the edge being updated
 		// identified, we update the on-disk edge representation.
 		return putChanEdgePolicy(edges, edge, fromNode, toNode)
+	}, func(cache *graphCache) {
+		cache.updatePolicy(edge, isNode1)
 	})
 }
 
@@ -1057,7 +1326,7 @@ func (l *LightningNode) AddPubKey(key *btcec.PublicKey) {
 func (c *ChannelGraph) FetchLightningNode(pub *btcec.PublicKey) (*LightningNode, error) {
 	var node *LightningNode
 	nodePub := pub.SerializeCompressed()
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes := tx.Bucket(nodeBucket)
@@ -1103,7 +1372,7 @@ func (c *ChannelGraph) HasLightningNode(nodePub [33]byte) (time.Time, bool, erro
 		exists     bool
 	)
 
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes := tx.Bucket(nodeBucket)
@@ -1150,12 +1419,12 @@ func (c *ChannelGraph) HasLightningNode(nodePub [33]byte) (time.Time, bool, erro
 // should be passed as the first argument.  Otherwise the first argument should
 // be nil and a fresh transaction will be created to execute the graph
 // traversal.
-func (l *LightningNode) ForEachChannel(tx *bolt.Tx,
-	cb func(*bolt.Tx, *ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
+func (l *LightningNode) ForEachChannel(tx kvdb.Tx,
+	cb func(kvdb.Tx, *ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
 
 	nodePub := l.PubKeyBytes[:]
 
-	traversal := func(tx *bolt.Tx) error {
+	traversal := func(tx kvdb.Tx) error {
 		nodes := tx.Bucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNotFound
@@ -1547,9 +1816,13 @@ type ChannelEdgePolicy struct {
 	// was received.
 	LastUpdate time.Time
 
-	// Flags is a bitfield which signals the capabilities of the channel as
-	// well as the directed edge this update applies to.
-	Flags lnwire.ChanUpdateFlag
+	// MessageFlags is a bitfield which indicates the presence of optional
+	// fields (like max_htlc) in the policy.
+	MessageFlags lnwire.ChanUpdateMsgFlags
+
+	// ChannelFlags is a bitfield which signals the capabilities of the
+	// channel as well as the directed edge this update applies to.
+	ChannelFlags lnwire.ChanUpdateChanFlags
 
 	// TimeLockDelta is the number of blocks this node will subtract from
 	// the expiry of an incoming HTLC. This value expresses the time buffer
@@ -1560,6 +1833,11 @@ type ChannelEdgePolicy struct {
 	// in millisatoshi.
 	MinHTLC lnwire.MilliSatoshi
 
+	// MaxHTLC is the largest value HTLC this node will accept, expressed
+	// in millisatoshi. This value is only meaningful if the
+	// ChanUpdateOptionMaxHtlc bit is set within MessageFlags.
+	MaxHTLC lnwire.MilliSatoshi
+
 	// FeeBaseMSat is the base HTLC fee that will be charged for forwarding
 	// ANY HTLC, expressed in mSAT's.
 	FeeBaseMSat lnwire.MilliSatoshi
@@ -1608,7 +1886,7 @@ func (c *ChannelGraph) FetchChannelEdgesByOutpoint(op *wire.OutPoint) (*ChannelE
 		policy2  *ChannelEdgePolicy
 	)
 
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		// First, grab the node bucket. This will be used to populate
 		// the Node pointers in each edge read from disk.
 		nodes := tx.Bucket(nodeBucket)
@@ -1676,6 +1954,9 @@ func (c *ChannelGraph) FetchChannelEdgesByOutpoint(op *wire.OutPoint) (*ChannelE
 // ErrEdgeNotFound is returned. A struct which houses the general information
 // for the channel itself is returned as well as two structs that contain the
 // routing policies for the channel in either direction.
+//
+// If the channel is a zombie, ErrZombieEdge is returned along with a struct
+// populated only with the channel ID and the public keys of its nodes.
 func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy, error) {
 
 	var (
@@ -1685,7 +1966,7 @@ func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdgeInfo, *
 		channelID [8]byte
 	)
 
-	err := c.db.View(func(tx *bolt.Tx) error {
+	err := c.db.View(func(tx kvdb.Tx) error {
 		// First, grab the node bucket. This will be used to populate
 		// the Node pointers in each edge read from disk.
 		nodes := tx.Bucket(nodeBucket)
@@ -1708,7 +1989,24 @@ func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdgeInfo, *
 		byteOrder.PutUint64(channelID[:], chanID)
 
 		edge, err := fetchChanEdgeInfo(edgeIndex, channelID[:])
-		if err != nil {
+		switch {
+		// If the channel isn't in the graph, it may have been pruned as
+		// a zombie, in which case we'll hand back its nodes so the
+		// caller can authenticate updates for it.
+		case err == ErrEdgeNotFound:
+			isZombie, pubKey1, pubKey2 := isZombieEdge(edges, chanID)
+			if !isZombie {
+				return ErrEdgeNotFound
+			}
+
+			edgeInfo = &ChannelEdgeInfo{
+				ChannelID:     chanID,
+				NodeKey1Bytes: pubKey1,
+				NodeKey2Bytes: pubKey2,
+			}
+			return ErrZombieEdge
+
+		case err != nil:
 			return err
 		}
 		edgeInfo = &edge
@@ -1723,7 +2021,10 @@ func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdgeInfo, *
 		policy2 = e2
 		return nil
 	})
-	if err != nil {
+	switch {
+	case err == ErrZombieEdge:
+		return edgeInfo, nil, nil, err
+	case err != nil:
 		return nil, nil, nil, err
 	}
 
@@ -1736,7 +2037,7 @@ func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdgeInfo, *
 // blockchain.
 func (c *ChannelGraph) ChannelView() ([]wire.OutPoint, error) {
 	var chanPoints []wire.OutPoint
-	if err := c.db.View(func(tx *bolt.Tx) error {
+	if err := c.db.View(func(tx kvdb.Tx) error {
 		// We're going to iterate over the entire channel index, so
 		// we'll need to fetch the edgeBucket to get to the index as
 		// it's a sub-bucket.
@@ -1776,7 +2077,7 @@ func (c *ChannelGraph) NewChannelEdgePolicy() *ChannelEdgePolicy {
 	return &ChannelEdgePolicy{db: c.db}
 }
 
-func putLightningNode(nodeBucket *bolt.Bucket, aliasBucket *bolt.Bucket, node *LightningNode) error {
+func putLightningNode(nodeBucket kvdb.Bucket, aliasBucket kvdb.Bucket, node *LightningNode) error {
 	var (
 		scratch [16]byte
 		b       bytes.Buffer
@@ -1864,7 +2165,7 @@ func putLightningNode(nodeBucket *bolt.Bucket, aliasBucket *bolt.Bucket, node *L
 
 }
 
-func fetchLightningNode(nodeBucket *bolt.Bucket,
+func fetchLightningNode(nodeBucket kvdb.Bucket,
 	nodePub []byte) (LightningNode, error) {
 
 	nodeBytes := nodeBucket.Get(nodePub)
@@ -1958,7 +2259,7 @@ func deserializeLightningNode(r io.Reader) (LightningNode, error) {
 	return node, nil
 }
 
-func putChanEdgeInfo(edgeIndex *bolt.Bucket, edgeInfo *ChannelEdgeInfo, chanID [8]byte) error {
+func putChanEdgeInfo(edgeIndex kvdb.Bucket, edgeInfo *ChannelEdgeInfo, chanID [8]byte) error {
 	var b bytes.Buffer
 
 	if _, err := b.Write(edgeInfo.NodeKey1Bytes[:]); err != nil {
@@ -2016,7 +2317,7 @@ func putChanEdgeInfo(edgeIndex *bolt.Bucket, edgeInfo *ChannelEdgeInfo, chanID [
 	return edgeIndex.Put(chanID[:], b.Bytes())
 }
 
-func fetchChanEdgeInfo(edgeIndex *bolt.Bucket,
+func fetchChanEdgeInfo(edgeIndex kvdb.Bucket,
 	chanID []byte) (ChannelEdgeInfo, error) {
 
 	edgeInfoBytes := edgeIndex.Get(chanID)
@@ -2093,7 +2394,7 @@ func deserializeChanEdgeInfo(r io.Reader) (ChannelEdgeInfo, error) {
 	return edgeInfo, nil
 }
 
-func putChanEdgePolicy(edges *bolt.Bucket, edge *ChannelEdgePolicy, from, to []byte) error {
+func putChanEdgePolicy(edges kvdb.Bucket, edge *ChannelEdgePolicy, from, to []byte) error {
 	var edgeKey [33 + 8]byte
 	copy(edgeKey[:], from)
 	byteOrder.PutUint64(edgeKey[33:], edge.ChannelID)
@@ -2116,7 +2417,10 @@ func putChanEdgePolicy(edges *bolt.Bucket, edge *ChannelEdgePolicy, from, to []b
 		return err
 	}
 
-	if err := binary.Write(&b, byteOrder, edge.Flags); err != nil {
+	if err := binary.Write(&b, byteOrder, edge.MessageFlags); err != nil {
+		return err
+	}
+	if err := binary.Write(&b, byteOrder, edge.ChannelFlags); err != nil {
 		return err
 	}
 	if err := binary.Write(&b, byteOrder, edge.TimeLockDelta); err != nil {
@@ -2136,11 +2440,15 @@ func putChanEdgePolicy(edges *bolt.Bucket, edge *ChannelEdgePolicy, from, to []b
 		return err
 	}
 
+	if err := binary.Write(&b, byteOrder, uint64(edge.MaxHTLC)); err != nil {
+		return err
+	}
+
 	return edges.Put(edgeKey[:], b.Bytes()[:])
 }
 
-func fetchChanEdgePolicy(edges *bolt.Bucket, chanID []byte,
-	nodePub []byte, nodes *bolt.Bucket) (*ChannelEdgePolicy, error) {
+func fetchChanEdgePolicy(edges kvdb.Bucket, chanID []byte,
+	nodePub []byte, nodes kvdb.Bucket) (*ChannelEdgePolicy, error) {
 
 	var edgeKey [33 + 8]byte
 	copy(edgeKey[:], nodePub)
@@ -2156,8 +2464,8 @@ func fetchChanEdgePolicy(edges *bolt.Bucket, chanID []byte,
 	return deserializeChanEdgePolicy(edgeReader, nodes)
 }
 
-func fetchChanEdgePolicies(edgeIndex *bolt.Bucket, edges *bolt.Bucket,
-	nodes *bolt.Bucket, chanID []byte,
+func fetchChanEdgePolicies(edgeIndex kvdb.Bucket, edges kvdb.Bucket,
+	nodes kvdb.Bucket, chanID []byte,
 	db *DB) (*ChannelEdgePolicy, *ChannelEdgePolicy, error) {
 
 	edgeInfo := edgeIndex.Get(chanID)
@@ -2198,7 +2506,7 @@ func fetchChanEdgePolicies(edgeIndex *bolt.Bucket, edges *bolt.Bucket,
 }
 
 func deserializeChanEdgePolicy(r io.Reader,
-	nodes *bolt.Bucket) (*ChannelEdgePolicy, error) {
+	nodes kvdb.Bucket) (*ChannelEdgePolicy, error) {
 
 	edge := &ChannelEdgePolicy{}
 
@@ -2219,7 +2527,10 @@ func deserializeChanEdgePolicy(r io.Reader,
 	unix := int64(byteOrder.Uint64(scratch[:]))
 	edge.LastUpdate = time.Unix(unix, 0)
 
-	if err := binary.Read(r, byteOrder, &edge.Flags); err != nil {
+	if err := binary.Read(r, byteOrder, &edge.MessageFlags); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, byteOrder, &edge.ChannelFlags); err != nil {
 		return nil, err
 	}
 	if err := binary.Read(r, byteOrder, &edge.TimeLockDelta); err != nil {
@@ -2247,6 +2558,11 @@ func deserializeChanEdgePolicy(r io.Reader,
 		return nil, err
 	}
 
+	if err := binary.Read(r, byteOrder, &n); err != nil {
+		return nil, err
+	}
+	edge.MaxHTLC = lnwire.MilliSatoshi(n)
+
 	node, err := fetchLightningNode(nodes, pub[:])
 	if err != nil {
 		return nil, err
@@ -0,0 +1,315 @@
+package channeldb
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+)
+
+// cachedChannel is a channel held within the graph cache, along with the
+// latest known policies of both of its directions. The policies don't carry
+// their signature, nor the node they point to.
+type cachedChannel struct {
+	info    *ChannelEdgeInfo
+	policy1 *ChannelEdgePolicy
+	policy2 *ChannelEdgePolicy
+}
+
+// graphCache is an in-memory copy of the nodes, channels and policies of the
+// graph, with the channels indexed by the nodes they connect. It allows path
+// finding to traverse the graph without opening a database transaction and
+// deserializing every edge it relaxes.
+//
+// The cache is populated from the database once at startup, after which the
+// ChannelGraph applies each of its writes to the cache once they've been
+// committed. As writes by other processes sharing a remote database would go
+// unnoticed, the cache must only be enabled if we're the sole writer. Entries
+// are never mutated in place, only replaced, so readers may hold on to them
+// after releasing the lock.
+type graphCache struct {
+	// writeMtx serializes the writes to the graph, ensuring the cache is
+	// updated in the same order as the database.
+	writeMtx sync.Mutex
+
+	mtx sync.RWMutex
+
+	// nodes maps the public key of each node to its cached information,
+	// which lacks the signature of its announcement.
+	nodes map[[33]byte]*LightningNode
+
+	// channels maps the ID of each channel to its cached information.
+	channels map[uint64]*cachedChannel
+
+	// nodeChannels maps each node to the sorted IDs of its channels. The
+	// IDs are kept sorted so the cache is traversed in the same order as
+	// the database.
+	nodeChannels map[[33]byte][]uint64
+}
+
+// newGraphCache returns an empty graph cache.
+func newGraphCache() *graphCache {
+	return &graphCache{
+		nodes:        make(map[[33]byte]*LightningNode),
+		channels:     make(map[uint64]*cachedChannel),
+		nodeChannels: make(map[[33]byte][]uint64),
+	}
+}
+
+// populate loads all nodes, channels and policies of the passed graph into
+// the cache.
+func (g *graphCache) populate(graph *ChannelGraph) error {
+	err := graph.ForEachNode(nil, func(_ kvdb.Tx, node *LightningNode) error {
+		g.updateNode(node)
+		return nil
+	})
+	if err != nil && err != ErrGraphNotFound {
+		return err
+	}
+
+	err = graph.ForEachChannel(func(info *ChannelEdgeInfo,
+		p1, p2 *ChannelEdgePolicy) error {
+
+		g.addChannel(info)
+		if p1 != nil {
+			g.updatePolicy(p1, true)
+		}
+		if p2 != nil {
+			g.updatePolicy(p2, false)
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound &&
+		err != ErrGraphNotFound {
+
+		return err
+	}
+
+	return nil
+}
+
+// reset removes all nodes and channels from the cache.
+func (g *graphCache) reset() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.nodes = make(map[[33]byte]*LightningNode)
+	g.channels = make(map[uint64]*cachedChannel)
+	g.nodeChannels = make(map[[33]byte][]uint64)
+}
+
+// updateNode adds the node to the cache, replacing any previous version.
+func (g *graphCache) updateNode(node *LightningNode) {
+	nodeCopy := *node
+	nodeCopy.AuthSigBytes = nil
+	nodeCopy.db = nil
+
+	g.mtx.Lock()
+	g.nodes[node.PubKeyBytes] = &nodeCopy
+	g.mtx.Unlock()
+}
+
+// removeNode removes the node from the cache. Its channels are unaffected.
+func (g *graphCache) removeNode(node [33]byte) {
+	g.mtx.Lock()
+	delete(g.nodes, node)
+	g.mtx.Unlock()
+}
+
+// lightningNode returns a fresh copy of the cached node with the passed public
+// key, or a node populated only with the public key if it isn't known. The
+// copy is attached to the passed database.
+//
+// NOTE: This method MUST be called with the read lock held.
+func (g *graphCache) lightningNode(db *DB, pubKey [33]byte) *LightningNode {
+	node, ok := g.nodes[pubKey]
+	if !ok {
+		return &LightningNode{
+			PubKeyBytes: pubKey,
+			db:          db,
+		}
+	}
+
+	nodeCopy := *node
+	nodeCopy.db = db
+	return &nodeCopy
+}
+
+// addChannel adds a channel without any known policies to the cache. If the
+// channel is already known, its information is replaced while its policies
+// are retained.
+func (g *graphCache) addChannel(info *ChannelEdgeInfo) {
+	// The authentication proof isn't needed for path finding, so we
+	// won't hold it in memory.
+	infoCopy := *info
+	infoCopy.AuthProof = nil
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if c, ok := g.channels[info.ChannelID]; ok {
+		g.channels[info.ChannelID] = &cachedChannel{
+			info:    &infoCopy,
+			policy1: c.policy1,
+			policy2: c.policy2,
+		}
+		return
+	}
+
+	g.channels[info.ChannelID] = &cachedChannel{
+		info: &infoCopy,
+	}
+	g.addNodeChannel(info.NodeKey1Bytes, info.ChannelID)
+	g.addNodeChannel(info.NodeKey2Bytes, info.ChannelID)
+}
+
+// updatePolicy replaces the policy of one direction of a channel, which is
+// the first node's if isNode1 is set, and the second node's otherwise. The
+// update is ignored if the channel isn't known.
+func (g *graphCache) updatePolicy(policy *ChannelEdgePolicy, isNode1 bool) {
+	policyCopy := *policy
+	policyCopy.SigBytes = nil
+	policyCopy.Node = nil
+	policyCopy.db = nil
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	c, ok := g.channels[policy.ChannelID]
+	if !ok {
+		return
+	}
+
+	updated := *c
+	if isNode1 {
+		updated.policy1 = &policyCopy
+	} else {
+		updated.policy2 = &policyCopy
+	}
+	g.channels[policy.ChannelID] = &updated
+}
+
+// removeChannels removes the channels with the passed IDs from the cache.
+func (g *graphCache) removeChannels(chanIDs ...uint64) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	for _, chanID := range chanIDs {
+		c, ok := g.channels[chanID]
+		if !ok {
+			continue
+		}
+
+		delete(g.channels, chanID)
+		g.removeNodeChannel(c.info.NodeKey1Bytes, chanID)
+		g.removeNodeChannel(c.info.NodeKey2Bytes, chanID)
+	}
+}
+
+// addNodeChannel adds the channel ID to the sorted set of channels of the
+// node.
+//
+// NOTE: This method MUST be called with the write lock held.
+func (g *graphCache) addNodeChannel(node [33]byte, chanID uint64) {
+	chanIDs := g.nodeChannels[node]
+	i := sort.Search(len(chanIDs), func(i int) bool {
+		return chanIDs[i] >= chanID
+	})
+	if i < len(chanIDs) && chanIDs[i] == chanID {
+		return
+	}
+
+	chanIDs = append(chanIDs, 0)
+	copy(chanIDs[i+1:], chanIDs[i:])
+	chanIDs[i] = chanID
+	g.nodeChannels[node] = chanIDs
+}
+
+// removeNodeChannel removes the channel ID from the sorted set of channels of
+// the node.
+//
+// NOTE: This method MUST be called with the write lock held.
+func (g *graphCache) removeNodeChannel(node [33]byte, chanID uint64) {
+	chanIDs := g.nodeChannels[node]
+	i := sort.Search(len(chanIDs), func(i int) bool {
+		return chanIDs[i] >= chanID
+	})
+	if i == len(chanIDs) || chanIDs[i] != chanID {
+		return
+	}
+
+	if len(chanIDs) == 1 {
+		delete(g.nodeChannels, node)
+		return
+	}
+
+	g.nodeChannels[node] = append(chanIDs[:i], chanIDs[i+1:]...)
+}
+
+// cachedEdge is a channel of a node along with copies of its policies, as
+// handed out to the callback of forEachNodeChannel.
+type cachedEdge struct {
+	info *ChannelEdgeInfo
+	out  *ChannelEdgePolicy
+	in   *ChannelEdgePolicy
+}
+
+// forEachNodeChannel executes the passed callback for each channel of the
+// node with a known outgoing policy. The callback is passed the channel's
+// information, the node's outgoing policy and the incoming policy from the
+// other node, if known. The policies are fresh copies pointing to fresh
+// copies of the nodes they lead to, attached to the passed database.
+func (g *graphCache) forEachNodeChannel(db *DB, node [33]byte,
+	cb func(*ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
+
+	// We'll gather the channels while holding the read lock, so the
+	// callback is free to access, or even modify, the graph.
+	g.mtx.RLock()
+	chanIDs := g.nodeChannels[node]
+	edges := make([]cachedEdge, 0, len(chanIDs))
+	for _, chanID := range chanIDs {
+		c := g.channels[chanID]
+
+		outPolicy, inPolicy := c.policy1, c.policy2
+		peer := c.info.NodeKey2Bytes
+		if c.info.NodeKey2Bytes == node {
+			outPolicy, inPolicy = c.policy2, c.policy1
+			peer = c.info.NodeKey1Bytes
+		}
+
+		// Only channels we know the outgoing policy of are
+		// traversable, which matches the set of channels found on
+		// disk.
+		if outPolicy == nil {
+			continue
+		}
+
+		out := *outPolicy
+		out.Node = g.lightningNode(db, peer)
+		out.db = db
+
+		var in *ChannelEdgePolicy
+		if inPolicy != nil {
+			inCopy := *inPolicy
+			inCopy.Node = g.lightningNode(db, node)
+			inCopy.db = db
+			in = &inCopy
+		}
+
+		edges = append(edges, cachedEdge{
+			info: c.info,
+			out:  &out,
+			in:   in,
+		})
+	}
+	g.mtx.RUnlock()
+
+	for _, edge := range edges {
+		if err := cb(edge.info, edge.out, edge.in); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
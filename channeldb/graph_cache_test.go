@@ -0,0 +1,238 @@
+package channeldb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// addCacheTestChannel adds a channel confirmed at the given height between the
+// two nodes to the graph, along with a policy for each of the directions
+// selected.
+func addCacheTestChannel(t *testing.T, graph *ChannelGraph, height uint32,
+	node1, node2 *LightningNode, policy1, policy2 bool) *ChannelEdgeInfo {
+
+	if bytes.Compare(node1.PubKeyBytes[:], node2.PubKeyBytes[:]) > 0 {
+		node1, node2 = node2, node1
+		policy1, policy2 = policy2, policy1
+	}
+
+	chanID := lnwire.ShortChannelID{BlockHeight: height}
+	edgeInfo := &ChannelEdgeInfo{
+		ChannelID: chanID.ToUint64(),
+		ChainHash: key,
+		AuthProof: &ChannelAuthProof{
+			NodeSig1Bytes:    testSig.Serialize(),
+			NodeSig2Bytes:    testSig.Serialize(),
+			BitcoinSig1Bytes: testSig.Serialize(),
+			BitcoinSig2Bytes: testSig.Serialize(),
+		},
+		NodeKey1Bytes:    node1.PubKeyBytes,
+		NodeKey2Bytes:    node2.PubKeyBytes,
+		BitcoinKey1Bytes: node1.PubKeyBytes,
+		BitcoinKey2Bytes: node2.PubKeyBytes,
+		ChannelPoint: wire.OutPoint{
+			Hash:  rev,
+			Index: height,
+		},
+		Capacity: 9000,
+	}
+	if err := graph.AddChannelEdge(edgeInfo); err != nil {
+		t.Fatalf("unable to add channel: %v", err)
+	}
+
+	if policy1 {
+		policy := randEdgePolicy(edgeInfo.ChannelID, edgeInfo.ChannelPoint,
+			graph.db)
+		policy.SigBytes = testSig.Serialize()
+		if err := graph.UpdateEdgePolicy(policy); err != nil {
+			t.Fatalf("unable to update policy: %v", err)
+		}
+	}
+	if policy2 {
+		policy := randEdgePolicy(edgeInfo.ChannelID, edgeInfo.ChannelPoint,
+			graph.db)
+		policy.SigBytes = testSig.Serialize()
+		policy.ChannelFlags = lnwire.ChanUpdateDirection
+		if err := graph.UpdateEdgePolicy(policy); err != nil {
+			t.Fatalf("unable to update policy: %v", err)
+		}
+	}
+
+	return edgeInfo
+}
+
+// assertCacheConsistent asserts that traversing the channels of each of the
+// passed nodes through the graph cache yields the same channels and policies
+// as traversing them on disk.
+func assertCacheConsistent(t *testing.T, graph *ChannelGraph,
+	nodes []*LightningNode) {
+
+	t.Helper()
+
+	type edge struct {
+		chanID uint64
+		out    *ChannelEdgePolicy
+		in     *ChannelEdgePolicy
+	}
+
+	for _, node := range nodes {
+		var diskEdges []edge
+		err := node.ForEachChannel(nil, func(_ kvdb.Tx,
+			info *ChannelEdgeInfo, out, in *ChannelEdgePolicy) error {
+
+			diskEdges = append(diskEdges, edge{info.ChannelID, out, in})
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse channels on disk: %v", err)
+		}
+
+		var cacheEdges []edge
+		err = graph.ForEachNodeChannel(nil, node.PubKeyBytes,
+			func(info *ChannelEdgeInfo, out,
+				in *ChannelEdgePolicy) error {
+
+				cacheEdges = append(
+					cacheEdges, edge{info.ChannelID, out, in},
+				)
+				return nil
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to traverse cached channels: %v", err)
+		}
+
+		if len(diskEdges) != len(cacheEdges) {
+			t.Fatalf("expected %d channels for node %x, got %d",
+				len(diskEdges), node.PubKeyBytes[:],
+				len(cacheEdges))
+		}
+
+		for i := range diskEdges {
+			disk, cached := diskEdges[i], cacheEdges[i]
+			if disk.chanID != cached.chanID {
+				t.Fatalf("expected channel %v, got %v",
+					disk.chanID, cached.chanID)
+			}
+
+			err := compareEdgePolicies(disk.out, cached.out)
+			if err != nil {
+				t.Fatalf("outgoing policy mismatch: %v", err)
+			}
+
+			switch {
+			case disk.in == nil && cached.in == nil:
+			case disk.in == nil || cached.in == nil:
+				t.Fatalf("expected incoming policy %v, got %v",
+					disk.in, cached.in)
+			default:
+				err := compareEdgePolicies(disk.in, cached.in)
+				if err != nil {
+					t.Fatalf("incoming policy mismatch: %v",
+						err)
+				}
+			}
+		}
+	}
+}
+
+// TestGraphCacheConsistency asserts that the graph cache reflects every write
+// to the channel graph, and that a freshly populated cache matches the graph
+// on disk.
+func TestGraphCacheConsistency(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	const numNodes = 5
+	nodes := make([]*LightningNode, numNodes)
+	for i := 0; i < numNodes; i++ {
+		node, err := createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+		nodes[i] = node
+	}
+
+	// We'll connect the nodes in a ring, with the last channel only
+	// having a policy for a single direction.
+	var channels []*ChannelEdgeInfo
+	for i := 0; i < numNodes; i++ {
+		channel := addCacheTestChannel(
+			t, graph, uint32(100+i), nodes[i],
+			nodes[(i+1)%numNodes], true, i != numNodes-1,
+		)
+		channels = append(channels, channel)
+	}
+	assertCacheConsistent(t, graph, nodes)
+
+	// Updating a node and a policy should be reflected in the cache.
+	nodes[0].Alias = "updated"
+	if err := graph.AddLightningNode(nodes[0]); err != nil {
+		t.Fatalf("unable to update node: %v", err)
+	}
+	policy := randEdgePolicy(channels[1].ChannelID, channels[1].ChannelPoint,
+		db)
+	policy.SigBytes = testSig.Serialize()
+	if err := graph.UpdateEdgePolicy(policy); err != nil {
+		t.Fatalf("unable to update policy: %v", err)
+	}
+	assertCacheConsistent(t, graph, nodes)
+
+	// Deleting a channel, pruning one, and disconnecting the block of
+	// another should remove them from the cache.
+	err = graph.DeleteChannelEdge(&channels[0].ChannelPoint, false)
+	if err != nil {
+		t.Fatalf("unable to delete channel: %v", err)
+	}
+	assertCacheConsistent(t, graph, nodes)
+
+	blockHash := chainhash.Hash(rev)
+	_, err = graph.PruneGraph(
+		[]*wire.OutPoint{&channels[1].ChannelPoint}, &blockHash, 200,
+	)
+	if err != nil {
+		t.Fatalf("unable to prune graph: %v", err)
+	}
+	assertCacheConsistent(t, graph, nodes)
+
+	if _, err := graph.DisconnectBlockAtHeight(104); err != nil {
+		t.Fatalf("unable to disconnect block: %v", err)
+	}
+	assertCacheConsistent(t, graph, nodes)
+
+	// Finally, a cache populated from scratch should match the graph on
+	// disk as well.
+	freshDB, err := CreateWithBackend(db.Backend)
+	if err != nil {
+		t.Fatalf("unable to create database: %v", err)
+	}
+	freshGraph := freshDB.ChannelGraph()
+	for _, node := range nodes {
+		node.db = freshDB
+	}
+	assertCacheConsistent(t, freshGraph, nodes)
+
+	var numChannels int
+	for _, chanIDs := range freshDB.graphCache.nodeChannels {
+		numChannels += len(chanIDs)
+	}
+	if numChannels != 4 {
+		t.Fatalf("expected 2 cached channels, got %d",
+			numChannels/2)
+	}
+}
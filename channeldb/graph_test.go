@@ -14,8 +14,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -352,7 +352,7 @@ func TestEdgeInsertionDeletion(t *testing.T) {
 
 	// Next, attempt to delete the edge from the database, again this
 	// should proceed without any issues.
-	if err := graph.DeleteChannelEdge(&outpoint); err != nil {
+	if err := graph.DeleteChannelEdge(&outpoint, false); err != nil {
 		t.Fatalf("unable to delete edge: %v", err)
 	}
 
@@ -367,7 +367,7 @@ func TestEdgeInsertionDeletion(t *testing.T) {
 
 	// Finally, attempt to delete a (now) non-existent edge within the
 	// database, this should result in an error.
-	err = graph.DeleteChannelEdge(&outpoint)
+	err = graph.DeleteChannelEdge(&outpoint, false)
 	if err != ErrEdgeNotFound {
 		t.Fatalf("deleting a non-existent edge should fail!")
 	}
@@ -672,9 +672,11 @@ func TestEdgeInfoUpdates(t *testing.T) {
 		SigBytes:                  testSig.Serialize(),
 		ChannelID:                 chanID,
 		LastUpdate:                time.Unix(433453, 0),
-		Flags:                     0,
+		MessageFlags:              1,
+		ChannelFlags:              0,
 		TimeLockDelta:             99,
 		MinHTLC:                   2342135,
+		MaxHTLC:                   13928598,
 		FeeBaseMSat:               4352345,
 		FeeProportionalMillionths: 3452352,
 		Node: secondNode,
@@ -684,9 +686,11 @@ func TestEdgeInfoUpdates(t *testing.T) {
 		SigBytes:                  testSig.Serialize(),
 		ChannelID:                 chanID,
 		LastUpdate:                time.Unix(124234, 0),
-		Flags:                     1,
+		MessageFlags:              1,
+		ChannelFlags:              1,
 		TimeLockDelta:             99,
 		MinHTLC:                   2342135,
+		MaxHTLC:                   13928598,
 		FeeBaseMSat:               4352345,
 		FeeProportionalMillionths: 90392423,
 		Node: firstNode,
@@ -802,7 +806,7 @@ func TestGraphTraversal(t *testing.T) {
 
 	// Iterate over each node as returned by the graph, if all nodes are
 	// reached, then the map created above should be empty.
-	err = graph.ForEachNode(nil, func(_ *bolt.Tx, node *LightningNode) error {
+	err = graph.ForEachNode(nil, func(_ kvdb.Tx, node *LightningNode) error {
 		delete(nodeIndex, node.Alias)
 		return nil
 	})
@@ -859,7 +863,7 @@ func TestGraphTraversal(t *testing.T) {
 		// Create and add an edge with random data that points from
 		// node1 -> node2.
 		edge := randEdgePolicy(chanID, op, db)
-		edge.Flags = 0
+		edge.ChannelFlags = 0
 		edge.Node = secondNode
 		edge.SigBytes = testSig.Serialize()
 		if err := graph.UpdateEdgePolicy(edge); err != nil {
@@ -869,7 +873,7 @@ func TestGraphTraversal(t *testing.T) {
 		// Create another random edge that points from node2 -> node1
 		// this time.
 		edge = randEdgePolicy(chanID, op, db)
-		edge.Flags = 1
+		edge.ChannelFlags = 1
 		edge.Node = firstNode
 		edge.SigBytes = testSig.Serialize()
 		if err := graph.UpdateEdgePolicy(edge); err != nil {
@@ -898,7 +902,7 @@ func TestGraphTraversal(t *testing.T) {
 	// Finally, we want to test the ability to iterate over all the
 	// outgoing channels for a particular node.
 	numNodeChans := 0
-	err = firstNode.ForEachChannel(nil, func(_ *bolt.Tx, _ *ChannelEdgeInfo,
+	err = firstNode.ForEachChannel(nil, func(_ kvdb.Tx, _ *ChannelEdgeInfo,
 		outEdge, inEdge *ChannelEdgePolicy) error {
 
 		// Each should indicate that it's outgoing (pointed
@@ -1048,7 +1052,7 @@ func TestGraphPruning(t *testing.T) {
 		// Create and add an edge with random data that points from
 		// node_i -> node_i+1
 		edge := randEdgePolicy(chanID, op, db)
-		edge.Flags = 0
+		edge.ChannelFlags = 0
 		edge.Node = graphNodes[i]
 		edge.SigBytes = testSig.Serialize()
 		if err := graph.UpdateEdgePolicy(edge); err != nil {
@@ -1058,7 +1062,7 @@ func TestGraphPruning(t *testing.T) {
 		// Create another random edge that points from node_i+1 ->
 		// node_i this time.
 		edge = randEdgePolicy(chanID, op, db)
-		edge.Flags = 1
+		edge.ChannelFlags = 1
 		edge.Node = graphNodes[i]
 		edge.SigBytes = testSig.Serialize()
 		if err := graph.UpdateEdgePolicy(edge); err != nil {
@@ -1211,9 +1215,13 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 		return fmt.Errorf("LastUpdate doesn't match: expected %#v, \n "+
 			"got %#v", a.LastUpdate, b.LastUpdate)
 	}
-	if a.Flags != b.Flags {
-		return fmt.Errorf("Flags doesn't match: expected %v, "+
-			"got %v", a.Flags, b.Flags)
+	if a.MessageFlags != b.MessageFlags {
+		return fmt.Errorf("MessageFlags doesn't match: expected %v, "+
+			"got %v", a.MessageFlags, b.MessageFlags)
+	}
+	if a.ChannelFlags != b.ChannelFlags {
+		return fmt.Errorf("ChannelFlags doesn't match: expected %v, "+
+			"got %v", a.ChannelFlags, b.ChannelFlags)
 	}
 	if a.TimeLockDelta != b.TimeLockDelta {
 		return fmt.Errorf("TimeLockDelta doesn't match: expected %v, "+
@@ -1223,6 +1231,10 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 		return fmt.Errorf("MinHTLC doesn't match: expected %v, "+
 			"got %v", a.MinHTLC, b.MinHTLC)
 	}
+	if a.MaxHTLC != b.MaxHTLC {
+		return fmt.Errorf("MaxHTLC doesn't match: expected %v, "+
+			"got %v", a.MaxHTLC, b.MaxHTLC)
+	}
 	if a.FeeBaseMSat != b.FeeBaseMSat {
 		return fmt.Errorf("FeeBaseMSat doesn't match: expected %v, "+
 			"got %v", a.FeeBaseMSat, b.FeeBaseMSat)
@@ -1241,3 +1253,97 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 	}
 	return nil
 }
+
+// TestGraphZombieIndex asserts that channels can be marked as zombies and live
+// again, and that looking up a zombie channel yields the keys of its nodes.
+func TestGraphZombieIndex(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	for _, node := range []*LightningNode{node1, node2} {
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	edge := addCacheTestChannel(t, graph, 100, node1, node2, true, true)
+
+	isZombie, _, _, err := graph.IsZombieEdge(edge.ChannelID)
+	if err != nil {
+		t.Fatalf("unable to query zombie index: %v", err)
+	}
+	if isZombie {
+		t.Fatal("expected edge to not be marked as zombie")
+	}
+	if err := graph.MarkEdgeLive(edge.ChannelID); err != ErrZombieEdgeNotFound {
+		t.Fatalf("expected ErrZombieEdgeNotFound, got %v", err)
+	}
+
+	// We'll now prune the channel and mark it as a zombie, after which it
+	// should be reported as such along with the keys of its nodes.
+	err = graph.DeleteChannelEdge(&edge.ChannelPoint, true)
+	if err != nil {
+		t.Fatalf("unable to delete channel: %v", err)
+	}
+
+	isZombie, pubKey1, pubKey2, err := graph.IsZombieEdge(edge.ChannelID)
+	if err != nil {
+		t.Fatalf("unable to query zombie index: %v", err)
+	}
+	if !isZombie {
+		t.Fatal("expected edge to be marked as zombie")
+	}
+	if pubKey1 != edge.NodeKey1Bytes || pubKey2 != edge.NodeKey2Bytes {
+		t.Fatalf("expected zombie keys %x and %x, got %x and %x",
+			edge.NodeKey1Bytes, edge.NodeKey2Bytes, pubKey1, pubKey2)
+	}
+
+	info, _, _, err := graph.FetchChannelEdgesByID(edge.ChannelID)
+	if err != ErrZombieEdge {
+		t.Fatalf("expected ErrZombieEdge, got %v", err)
+	}
+	if info.NodeKey1Bytes != edge.NodeKey1Bytes ||
+		info.NodeKey2Bytes != edge.NodeKey2Bytes {
+
+		t.Fatal("zombie edge info doesn't carry the node keys")
+	}
+
+	numZombies, err := graph.NumZombies()
+	if err != nil {
+		t.Fatalf("unable to count zombies: %v", err)
+	}
+	if numZombies != 1 {
+		t.Fatalf("expected 1 zombie, got %d", numZombies)
+	}
+
+	// Finally, marking the channel live should remove it from the index.
+	if err := graph.MarkEdgeLive(edge.ChannelID); err != nil {
+		t.Fatalf("unable to mark edge as live: %v", err)
+	}
+	isZombie, _, _, err = graph.IsZombieEdge(edge.ChannelID)
+	if err != nil {
+		t.Fatalf("unable to query zombie index: %v", err)
+	}
+	if isZombie {
+		t.Fatal("expected edge to not be marked as zombie")
+	}
+	_, _, _, err = graph.FetchChannelEdgesByID(edge.ChannelID)
+	if err != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, got %v", err)
+	}
+}
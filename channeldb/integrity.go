@@ -0,0 +1,349 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// IntegrityError describes a structural inconsistency found within the
+// database by CheckIntegrity.
+type IntegrityError struct {
+	// Bucket is a human readable path to the bucket the inconsistency was
+	// found within.
+	Bucket string
+
+	// Key is the key of the inconsistent item, if any.
+	Key []byte
+
+	// Reason describes the inconsistency.
+	Reason string
+}
+
+// Error returns a human readable description of the inconsistency.
+//
+// NOTE: Part of the error interface.
+func (e *IntegrityError) Error() string {
+	if e.Key == nil {
+		return fmt.Sprintf("%v: %v", e.Bucket, e.Reason)
+	}
+
+	return fmt.Sprintf("%v[%x]: %v", e.Bucket, e.Key, e.Reason)
+}
+
+// integrityChecker accumulates the inconsistencies found while checking the
+// database.
+type integrityChecker struct {
+	errs []*IntegrityError
+}
+
+// fail records a new inconsistency.
+func (c *integrityChecker) fail(bucket string, key []byte, format string,
+	args ...interface{}) {
+
+	c.errs = append(c.errs, &IntegrityError{
+		Bucket: bucket,
+		Key:    key,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// CheckIntegrity verifies the structure of every top-level bucket within the
+// database, ensuring that all expected buckets exist, that all keys have the
+// expected format, that all records can be deserialized, and that the indexes
+// are consistent with the data they index. All inconsistencies found are
+// returned, while a non-nil error is only returned if the check itself
+// couldn't be carried out.
+func (d *DB) CheckIntegrity() ([]*IntegrityError, error) {
+	checker := &integrityChecker{}
+
+	err := d.View(func(tx kvdb.Tx) error {
+		requiredBuckets := []struct {
+			name []byte
+			desc string
+		}{
+			{openChannelBucket, "open channels"},
+			{closedChannelBucket, "closed channels"},
+			{invoiceBucket, "invoices"},
+			{nodeInfoBucket, "node info"},
+			{nodeBucket, "graph nodes"},
+			{edgeBucket, "graph edges"},
+			{graphMetaBucket, "graph meta"},
+			{metaBucket, "meta"},
+		}
+		for _, bucket := range requiredBuckets {
+			if tx.Bucket(bucket.name) == nil {
+				checker.fail(bucket.desc, nil, "bucket missing")
+			}
+		}
+
+		checker.checkOpenChannels(tx)
+		checker.checkGraphEdges(tx)
+		checker.checkInvoices(tx)
+		checker.checkForwardingLog(tx)
+		checker.checkForwardingPackages(tx)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return checker.errs, nil
+}
+
+// checkOpenChannels ensures that every channel within the open channel bucket
+// is nested under a valid node and chain, and can be fully deserialized.
+func (c *integrityChecker) checkOpenChannels(tx kvdb.Tx) {
+	const desc = "open channels"
+
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return
+	}
+
+	openChanBucket.ForEach(func(nodePub, v []byte) error {
+		if v != nil || len(nodePub) != 33 {
+			c.fail(desc, nodePub, "expected node bucket")
+			return nil
+		}
+
+		nodeBucket := openChanBucket.Bucket(nodePub)
+		nodeDesc := fmt.Sprintf("%v/%x", desc, nodePub)
+
+		return nodeBucket.ForEach(func(chainHash, v []byte) error {
+			if v != nil || len(chainHash) != 32 {
+				c.fail(nodeDesc, chainHash, "expected chain "+
+					"bucket")
+				return nil
+			}
+
+			chainBucket := nodeBucket.Bucket(chainHash)
+			chainDesc := fmt.Sprintf("%v/%x", nodeDesc, chainHash)
+
+			return chainBucket.ForEach(func(chanPoint, v []byte) error {
+				if v != nil {
+					c.fail(chainDesc, chanPoint, "expected "+
+						"channel bucket")
+					return nil
+				}
+
+				var outPoint wire.OutPoint
+				err := readOutpoint(
+					bytes.NewReader(chanPoint), &outPoint,
+				)
+				if err != nil {
+					c.fail(chainDesc, chanPoint, "invalid "+
+						"channel point: %v", err)
+					return nil
+				}
+
+				_, err = fetchOpenChannel(
+					chainBucket.Bucket(chanPoint), &outPoint,
+				)
+				if err != nil {
+					c.fail(chainDesc, chanPoint, "%v", err)
+				}
+
+				return nil
+			})
+		})
+	})
+}
+
+// checkGraphEdges ensures that every edge within the edge index can be
+// deserialized along with its policies, that its channel point is indexed,
+// and that every policy belongs to an indexed edge.
+func (c *integrityChecker) checkGraphEdges(tx kvdb.Tx) {
+	const desc = "graph edges"
+
+	edges := tx.Bucket(edgeBucket)
+	if edges == nil {
+		return
+	}
+	nodes := tx.Bucket(nodeBucket)
+	if nodes == nil {
+		return
+	}
+
+	// The indexes are only created once the first edge is added, so if
+	// they don't exist, there's nothing to check.
+	edgeIndex := edges.Bucket(edgeIndexBucket)
+	chanIndex := edges.Bucket(channelPointBucket)
+	if edgeIndex == nil || chanIndex == nil {
+		return
+	}
+
+	indexDesc := desc + "/edge index"
+	edgeIndex.ForEach(func(chanID, edgeInfoBytes []byte) error {
+		if len(chanID) != 8 {
+			c.fail(indexDesc, chanID, "invalid channel ID")
+			return nil
+		}
+
+		edgeInfo, err := deserializeChanEdgeInfo(
+			bytes.NewReader(edgeInfoBytes),
+		)
+		if err != nil {
+			c.fail(indexDesc, chanID, "invalid edge info: %v", err)
+			return nil
+		}
+
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, &edgeInfo.ChannelPoint); err != nil {
+			return err
+		}
+		if !bytes.Equal(chanIndex.Get(b.Bytes()), chanID) {
+			c.fail(indexDesc, chanID, "channel point %v not "+
+				"indexed", edgeInfo.ChannelPoint)
+		}
+
+		_, _, err = fetchChanEdgePolicies(
+			edgeIndex, edges, nodes, chanID, nil,
+		)
+		if err != nil {
+			c.fail(indexDesc, chanID, "invalid edge policy: %v",
+				err)
+		}
+
+		return nil
+	})
+
+	edges.ForEach(func(k, v []byte) error {
+		// Nested buckets are the indexes we've checked above.
+		if v == nil {
+			return nil
+		}
+
+		if len(k) != 33+8 {
+			c.fail(desc, k, "unexpected key")
+			return nil
+		}
+		if edgeIndex.Get(k[33:]) == nil {
+			c.fail(desc, k, "policy for unknown edge")
+		}
+
+		return nil
+	})
+}
+
+// checkInvoices ensures that every invoice can be deserialized, and that the
+// payment hash index only references existing invoices.
+func (c *integrityChecker) checkInvoices(tx kvdb.Tx) {
+	const desc = "invoices"
+
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return
+	}
+
+	invoices.ForEach(func(k, v []byte) error {
+		// The payment hash index is the only nested bucket.
+		if v == nil {
+			return nil
+		}
+
+		if len(k) != 4 {
+			c.fail(desc, k, "invalid invoice ID")
+			return nil
+		}
+		if _, err := deserializeInvoice(bytes.NewReader(v)); err != nil {
+			c.fail(desc, k, "invalid invoice: %v", err)
+		}
+
+		return nil
+	})
+
+	invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+	if invoiceIndex == nil {
+		return
+	}
+
+	indexDesc := desc + "/payment hash index"
+	invoiceIndex.ForEach(func(k, v []byte) error {
+		if bytes.Equal(k, numInvoicesKey) {
+			return nil
+		}
+
+		if len(k) != 32 {
+			c.fail(indexDesc, k, "invalid payment hash")
+			return nil
+		}
+		if invoices.Get(v) == nil {
+			c.fail(indexDesc, k, "unknown invoice ID %x", v)
+		}
+
+		return nil
+	})
+}
+
+// checkForwardingLog ensures that every event within the forwarding log is
+// keyed by a timestamp and has the expected size.
+func (c *integrityChecker) checkForwardingLog(tx kvdb.Tx) {
+	const desc = "forwarding log"
+
+	logBucket := tx.Bucket(forwardingLogBucket)
+	if logBucket == nil {
+		return
+	}
+
+	logBucket.ForEach(func(k, v []byte) error {
+		switch {
+		case len(k) != 8:
+			c.fail(desc, k, "invalid timestamp")
+		case len(v) != forwardingEventSize:
+			c.fail(desc, k, "invalid event size %v", len(v))
+		}
+
+		return nil
+	})
+}
+
+// checkForwardingPackages ensures that every forwarding package is nested
+// under a valid source and height, and contains all its required parts.
+func (c *integrityChecker) checkForwardingPackages(tx kvdb.Tx) {
+	const desc = "forwarding packages"
+
+	fwdPkgBkt := tx.Bucket(fwdPackagesKey)
+	if fwdPkgBkt == nil {
+		return
+	}
+
+	fwdPkgBkt.ForEach(func(source, v []byte) error {
+		if v != nil || len(source) != 8 {
+			c.fail(desc, source, "expected source bucket")
+			return nil
+		}
+
+		sourceBkt := fwdPkgBkt.Bucket(source)
+		sourceDesc := fmt.Sprintf("%v/%x", desc, source)
+
+		return sourceBkt.ForEach(func(height, v []byte) error {
+			if v != nil || len(height) != 8 {
+				c.fail(sourceDesc, height, "expected height "+
+					"bucket")
+				return nil
+			}
+
+			heightBkt := sourceBkt.Bucket(height)
+			if heightBkt.Bucket(addBucketKey) == nil {
+				c.fail(sourceDesc, height, "add updates missing")
+			}
+			if heightBkt.Bucket(failSettleBucketKey) == nil {
+				c.fail(sourceDesc, height, "settle/fail "+
+					"updates missing")
+			}
+			if heightBkt.Get(ackFilterKey) == nil {
+				c.fail(sourceDesc, height, "ack filter missing")
+			}
+			if heightBkt.Get(settleFailFilterKey) == nil {
+				c.fail(sourceDesc, height, "settle/fail filter "+
+					"missing")
+			}
+
+			return nil
+		})
+	})
+}
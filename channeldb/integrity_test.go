@@ -0,0 +1,89 @@
+package channeldb
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestCheckIntegrity tests that a consistent database passes the integrity
+// check, and that inconsistencies introduced afterwards are reported.
+func TestCheckIntegrity(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// We'll populate the database with a channel, an invoice and a
+	// forwarding event, which should all pass the check.
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	if err := state.SyncPending(addr, 101); err != nil {
+		t.Fatalf("unable to save channel state: %v", err)
+	}
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := cdb.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	err = cdb.ForwardingLog().AddForwardingEvents([]ForwardingEvent{
+		{Timestamp: time.Unix(1000, 0)},
+	})
+	if err != nil {
+		t.Fatalf("unable to add forwarding event: %v", err)
+	}
+
+	errs, err := cdb.CheckIntegrity()
+	if err != nil {
+		t.Fatalf("unable to check integrity: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no inconsistencies, got %v", errs)
+	}
+
+	// Next, we'll introduce an index entry pointing to a missing invoice,
+	// along with a truncated forwarding event.
+	err = cdb.Update(func(tx kvdb.Tx) error {
+		invoiceIndex := tx.Bucket(invoiceBucket).Bucket(
+			invoiceIndexBucket,
+		)
+		var paymentHash [32]byte
+		err := invoiceIndex.Put(paymentHash[:], []byte{0, 0, 0, 99})
+		if err != nil {
+			return err
+		}
+
+		var timestamp [8]byte
+		byteOrder.PutUint64(timestamp[:], 2000)
+		return tx.Bucket(forwardingLogBucket).Put(
+			timestamp[:], []byte{0x01},
+		)
+	})
+	if err != nil {
+		t.Fatalf("unable to corrupt database: %v", err)
+	}
+
+	errs, err = cdb.CheckIntegrity()
+	if err != nil {
+		t.Fatalf("unable to check integrity: %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 inconsistencies, got %v", errs)
+	}
+}
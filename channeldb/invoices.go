@@ -8,7 +8,7 @@ import (
 	"io"
 	"time"
 
-	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/wire"
 )
@@ -138,7 +138,7 @@ func (d *DB) AddInvoice(i *Invoice) error {
 	if err := validateInvoice(i); err != nil {
 		return err
 	}
-	return d.Update(func(tx *bolt.Tx) error {
+	return d.Update(func(tx kvdb.Tx) error {
 		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
 		if err != nil {
 			return err
@@ -182,7 +182,7 @@ func (d *DB) AddInvoice(i *Invoice) error {
 // terms of the payment.
 func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 	var invoice *Invoice
-	err := d.View(func(tx *bolt.Tx) error {
+	err := d.View(func(tx kvdb.Tx) error {
 		invoices := tx.Bucket(invoiceBucket)
 		if invoices == nil {
 			return ErrNoInvoicesCreated
@@ -222,7 +222,7 @@ func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	var invoices []*Invoice
 
-	err := d.View(func(tx *bolt.Tx) error {
+	err := d.View(func(tx kvdb.Tx) error {
 		invoiceB := tx.Bucket(invoiceBucket)
 		if invoiceB == nil {
 			return ErrNoInvoicesCreated
@@ -263,7 +263,7 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 // hash doesn't existing within the database, then the action will fail with a
 // "not found" error.
 func (d *DB) SettleInvoice(paymentHash [32]byte) error {
-	return d.Update(func(tx *bolt.Tx) error {
+	return d.Update(func(tx kvdb.Tx) error {
 		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
 		if err != nil {
 			return err
@@ -284,7 +284,7 @@ func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 	})
 }
 
-func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
+func putInvoice(invoices kvdb.Bucket, invoiceIndex kvdb.Bucket,
 	i *Invoice, invoiceNum uint32) error {
 
 	// Create the invoice key which is just the big-endian representation
@@ -364,7 +364,7 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 	return nil
 }
 
-func fetchInvoice(invoiceNum []byte, invoices *bolt.Bucket) (*Invoice, error) {
+func fetchInvoice(invoiceNum []byte, invoices kvdb.Bucket) (*Invoice, error) {
 	invoiceBytes := invoices.Get(invoiceNum)
 	if invoiceBytes == nil {
 		return nil, ErrInvoiceNotFound
@@ -426,7 +426,7 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	return invoice, nil
 }
 
-func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
+func settleInvoice(invoices kvdb.Bucket, invoiceNum []byte) error {
 	invoice, err := fetchInvoice(invoiceNum, invoices)
 	if err != nil {
 		return err
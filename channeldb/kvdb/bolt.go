@@ -0,0 +1,331 @@
+package kvdb
+
+import (
+	"os"
+
+	"github.com/coreos/bbolt"
+)
+
+// boltBackend is a Backend backed by a local bbolt database file.
+type boltBackend struct {
+	db *bolt.DB
+}
+
+// A compile-time check to ensure boltBackend implements the Backend
+// interface.
+var _ Backend = (*boltBackend)(nil)
+
+// OpenBolt opens, creating it if it doesn't yet exist, the bbolt database at
+// the given path and returns it as a Backend.
+func OpenBolt(path string, mode os.FileMode, opts *bolt.Options) (Backend,
+	error) {
+
+	db, err := bolt.Open(path, mode, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltBackend{db: db}, nil
+}
+
+// Begin starts a new transaction.
+//
+// NOTE: Part of the Backend interface.
+func (b *boltBackend) Begin(writable bool) (Tx, error) {
+	tx, err := b.db.Begin(writable)
+	if err != nil {
+		return nil, mapBoltErr(err)
+	}
+
+	return &boltTx{tx: tx}, nil
+}
+
+// View executes the passed function within a managed read-only transaction.
+//
+// NOTE: Part of the Backend interface.
+func (b *boltBackend) View(f func(tx Tx) error) error {
+	return mapBoltErr(b.db.View(func(tx *bolt.Tx) error {
+		return f(&boltTx{tx: tx})
+	}))
+}
+
+// Update executes the passed function within a managed read-write
+// transaction.
+//
+// NOTE: Part of the Backend interface.
+func (b *boltBackend) Update(f func(tx Tx) error) error {
+	return mapBoltErr(b.db.Update(func(tx *bolt.Tx) error {
+		return f(&boltTx{tx: tx})
+	}))
+}
+
+// Batch executes the passed function within a read-write transaction that
+// may be shared with other concurrent callers.
+//
+// NOTE: Part of the Backend interface.
+func (b *boltBackend) Batch(f func(tx Tx) error) error {
+	return mapBoltErr(b.db.Batch(func(tx *bolt.Tx) error {
+		return f(&boltTx{tx: tx})
+	}))
+}
+
+// Close closes the underlying database file.
+//
+// NOTE: Part of the Backend interface.
+func (b *boltBackend) Close() error {
+	return mapBoltErr(b.db.Close())
+}
+
+// Path returns the path of the underlying database file.
+func (b *boltBackend) Path() string {
+	return b.db.Path()
+}
+
+// boltTx wraps a bbolt transaction to implement the Tx interface.
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+// Bucket returns the top-level bucket with the given name.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) Bucket(name []byte) Bucket {
+	return wrapBoltBucket(t.tx.Bucket(name))
+}
+
+// CreateBucket creates a new top-level bucket with the given name.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) CreateBucket(name []byte) (Bucket, error) {
+	bucket, err := t.tx.CreateBucket(name)
+	if err != nil {
+		return nil, mapBoltErr(err)
+	}
+
+	return wrapBoltBucket(bucket), nil
+}
+
+// CreateBucketIfNotExists creates the top-level bucket with the given name if
+// it doesn't already exist.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	bucket, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, mapBoltErr(err)
+	}
+
+	return wrapBoltBucket(bucket), nil
+}
+
+// DeleteBucket deletes the top-level bucket with the given name.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) DeleteBucket(name []byte) error {
+	return mapBoltErr(t.tx.DeleteBucket(name))
+}
+
+// Writable returns true if the transaction permits writes.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) Writable() bool {
+	return t.tx.Writable()
+}
+
+// Commit writes all changes made within the transaction to disk.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) Commit() error {
+	return mapBoltErr(t.tx.Commit())
+}
+
+// Rollback closes the transaction, discarding any changes.
+//
+// NOTE: Part of the Tx interface.
+func (t *boltTx) Rollback() error {
+	return mapBoltErr(t.tx.Rollback())
+}
+
+// boltBucket wraps a bbolt bucket to implement the Bucket interface.
+type boltBucket struct {
+	bucket *bolt.Bucket
+}
+
+// wrapBoltBucket wraps the passed bbolt bucket. A nil interface is returned
+// for a nil bucket, so callers can keep checking for missing buckets with a
+// simple nil comparison.
+func wrapBoltBucket(bucket *bolt.Bucket) Bucket {
+	if bucket == nil {
+		return nil
+	}
+
+	return &boltBucket{bucket: bucket}
+}
+
+// Bucket returns the nested bucket with the given name.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) Bucket(name []byte) Bucket {
+	return wrapBoltBucket(b.bucket.Bucket(name))
+}
+
+// CreateBucket creates a new nested bucket with the given name.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) CreateBucket(name []byte) (Bucket, error) {
+	bucket, err := b.bucket.CreateBucket(name)
+	if err != nil {
+		return nil, mapBoltErr(err)
+	}
+
+	return wrapBoltBucket(bucket), nil
+}
+
+// CreateBucketIfNotExists creates the nested bucket with the given name if it
+// doesn't already exist.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	bucket, err := b.bucket.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, mapBoltErr(err)
+	}
+
+	return wrapBoltBucket(bucket), nil
+}
+
+// DeleteBucket deletes the nested bucket with the given name.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) DeleteBucket(name []byte) error {
+	return mapBoltErr(b.bucket.DeleteBucket(name))
+}
+
+// Get returns the value stored under the given key.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) Get(key []byte) []byte {
+	return b.bucket.Get(key)
+}
+
+// Put sets the value for the given key.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) Put(key, value []byte) error {
+	return mapBoltErr(b.bucket.Put(key, value))
+}
+
+// Delete removes the given key.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) Delete(key []byte) error {
+	return mapBoltErr(b.bucket.Delete(key))
+}
+
+// ForEach executes the passed function for each key/value pair in the bucket.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) ForEach(f func(k, v []byte) error) error {
+	return mapBoltErr(b.bucket.ForEach(f))
+}
+
+// Cursor returns a cursor over the bucket's key/value pairs.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) Cursor() Cursor {
+	return &boltCursor{cursor: b.bucket.Cursor()}
+}
+
+// NextSequence increments and returns the bucket's sequence number.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) NextSequence() (uint64, error) {
+	seq, err := b.bucket.NextSequence()
+	return seq, mapBoltErr(err)
+}
+
+// Sequence returns the bucket's current sequence number.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) Sequence() uint64 {
+	return b.bucket.Sequence()
+}
+
+// SetSequence sets the bucket's sequence number.
+//
+// NOTE: Part of the Bucket interface.
+func (b *boltBucket) SetSequence(v uint64) error {
+	return mapBoltErr(b.bucket.SetSequence(v))
+}
+
+// boltCursor wraps a bbolt cursor to implement the Cursor interface.
+type boltCursor struct {
+	cursor *bolt.Cursor
+}
+
+// First moves the cursor to the first item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *boltCursor) First() ([]byte, []byte) {
+	return c.cursor.First()
+}
+
+// Last moves the cursor to the last item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *boltCursor) Last() ([]byte, []byte) {
+	return c.cursor.Last()
+}
+
+// Next moves the cursor to the next item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *boltCursor) Next() ([]byte, []byte) {
+	return c.cursor.Next()
+}
+
+// Prev moves the cursor to the previous item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *boltCursor) Prev() ([]byte, []byte) {
+	return c.cursor.Prev()
+}
+
+// Seek moves the cursor to the given key, or the next key after it.
+//
+// NOTE: Part of the Cursor interface.
+func (c *boltCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.cursor.Seek(seek)
+}
+
+// Delete removes the key/value pair the cursor currently points to.
+//
+// NOTE: Part of the Cursor interface.
+func (c *boltCursor) Delete() error {
+	return mapBoltErr(c.cursor.Delete())
+}
+
+// mapBoltErr translates the errors returned by bbolt into their kvdb
+// equivalents, so callers can match on them regardless of the backend in use.
+func mapBoltErr(err error) error {
+	switch err {
+	case bolt.ErrBucketNotFound:
+		return ErrBucketNotFound
+	case bolt.ErrBucketExists:
+		return ErrBucketExists
+	case bolt.ErrBucketNameRequired:
+		return ErrBucketNameRequired
+	case bolt.ErrKeyRequired:
+		return ErrKeyRequired
+	case bolt.ErrIncompatibleValue:
+		return ErrIncompatibleValue
+	case bolt.ErrTxNotWritable:
+		return ErrTxNotWritable
+	case bolt.ErrTxClosed:
+		return ErrTxClosed
+	case bolt.ErrDatabaseNotOpen:
+		return ErrDatabaseNotOpen
+	default:
+		return err
+	}
+}
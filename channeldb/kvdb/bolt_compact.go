@@ -0,0 +1,148 @@
+package kvdb
+
+import (
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+const (
+	// compactTxMaxSize is the maximum number of bytes copied within a
+	// single transaction while compacting a bolt database, which bounds
+	// the memory used when compacting large databases.
+	compactTxMaxSize = 64 * 1024 * 1024
+
+	// compactOpenTimeout is the maximum duration we'll wait to obtain the
+	// file lock of the database being compacted. If it's still held, the
+	// database is most likely in use by a running process.
+	compactOpenTimeout = 5 * time.Second
+)
+
+// CompactBolt copies the contents of the bolt database at srcPath into a fresh
+// database at dstPath. As the copy is written sequentially with fully packed
+// pages, the resulting file no longer contains the free pages left behind by
+// deleted data, and is generally much smaller than the original. The source
+// database MUST NOT be open for writing while compacting.
+func CompactBolt(srcPath, dstPath string) error {
+	src, err := bolt.Open(srcPath, 0600, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  compactOpenTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := bolt.Open(dstPath, 0600, &bolt.Options{
+		Timeout: compactOpenTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	var txSize int64
+	err = walkBolt(src, func(path [][]byte, k, v []byte, seq uint64) error {
+		// If this item would push the current transaction over its
+		// size limit, then we'll commit it and carry on within a fresh
+		// one.
+		itemSize := int64(len(k) + len(v))
+		if txSize+itemSize > compactTxMaxSize {
+			if err := tx.Commit(); err != nil {
+				return err
+			}
+
+			tx, err = dst.Begin(true)
+			if err != nil {
+				return err
+			}
+			txSize = 0
+		}
+		txSize += itemSize
+
+		// Top-level items are always buckets.
+		if len(path) == 0 {
+			bucket, err := tx.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+			return bucket.SetSequence(seq)
+		}
+
+		// Otherwise, we'll locate the parent bucket of the item
+		// within the destination database.
+		parent := tx.Bucket(path[0])
+		for _, name := range path[1:] {
+			parent = parent.Bucket(name)
+		}
+
+		// As items are inserted in order, the pages can be filled up
+		// completely.
+		parent.FillPercent = 1.0
+
+		if v == nil {
+			bucket, err := parent.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+			return bucket.SetSequence(seq)
+		}
+
+		return parent.Put(k, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// boltWalkFunc is called for each bucket and key/value pair visited while
+// walking a bolt database. The path contains the names of all the buckets
+// leading to the item, the value is nil for buckets, and the sequence number
+// is only set for buckets.
+type boltWalkFunc func(path [][]byte, k, v []byte, seq uint64) error
+
+// walkBolt visits every bucket and key/value pair within the database in
+// depth-first order, such that each bucket is visited before its contents.
+func walkBolt(db *bolt.DB, f boltWalkFunc) error {
+	return db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			return walkBoltBucket(b, nil, name, nil, b.Sequence(), f)
+		})
+	})
+}
+
+// walkBoltBucket visits the passed item, descending into it if it's a bucket.
+func walkBoltBucket(b *bolt.Bucket, path [][]byte, k, v []byte, seq uint64,
+	f boltWalkFunc) error {
+
+	if err := f(path, k, v, seq); err != nil {
+		return err
+	}
+
+	// If this item isn't a bucket, then there's nothing to descend into.
+	if v != nil {
+		return nil
+	}
+
+	path = append(path, k)
+	return b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			child := b.Bucket(k)
+			return walkBoltBucket(
+				child, path, k, nil, child.Sequence(), f,
+			)
+		}
+
+		return walkBoltBucket(b, path, k, v, 0, f)
+	})
+}
@@ -0,0 +1,129 @@
+package kvdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCompactBolt tests that compacting a bolt database preserves all nested
+// buckets, key/value pairs and sequence numbers.
+func TestCompactBolt(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "compact")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	srcPath := filepath.Join(tempDir, "src.db")
+	dstPath := filepath.Join(tempDir, "dst.db")
+
+	src, err := OpenBolt(srcPath, 0600, nil)
+	if err != nil {
+		t.Fatalf("unable to open database: %v", err)
+	}
+
+	// We'll populate the source database with a few nested buckets, then
+	// delete a bucket full of data in order to leave free pages behind.
+	value := bytes.Repeat([]byte{0xaa}, 1000)
+	err = src.Update(func(tx Tx) error {
+		top, err := tx.CreateBucket([]byte("top"))
+		if err != nil {
+			return err
+		}
+		if err := top.SetSequence(42); err != nil {
+			return err
+		}
+		if err := top.Put([]byte("key"), []byte("value")); err != nil {
+			return err
+		}
+
+		nested, err := top.CreateBucket([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		if err := nested.Put([]byte("nested-key"), value); err != nil {
+			return err
+		}
+
+		garbage, err := tx.CreateBucket([]byte("garbage"))
+		if err != nil {
+			return err
+		}
+		for i := 0; i < 1000; i++ {
+			key := []byte{byte(i >> 8), byte(i)}
+			if err := garbage.Put(key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to populate database: %v", err)
+	}
+	err = src.Update(func(tx Tx) error {
+		return tx.DeleteBucket([]byte("garbage"))
+	})
+	if err != nil {
+		t.Fatalf("unable to delete bucket: %v", err)
+	}
+	src.Close()
+
+	if err := CompactBolt(srcPath, dstPath); err != nil {
+		t.Fatalf("unable to compact database: %v", err)
+	}
+
+	srcInfo, err := os.Stat(srcPath)
+	if err != nil {
+		t.Fatalf("unable to stat source: %v", err)
+	}
+	dstInfo, err := os.Stat(dstPath)
+	if err != nil {
+		t.Fatalf("unable to stat destination: %v", err)
+	}
+	if dstInfo.Size() >= srcInfo.Size() {
+		t.Fatalf("compacted size %v not below original size %v",
+			dstInfo.Size(), srcInfo.Size())
+	}
+
+	dst, err := OpenBolt(dstPath, 0600, nil)
+	if err != nil {
+		t.Fatalf("unable to open compacted database: %v", err)
+	}
+	defer dst.Close()
+
+	err = dst.View(func(tx Tx) error {
+		if tx.Bucket([]byte("garbage")) != nil {
+			t.Fatalf("deleted bucket present after compaction")
+		}
+
+		top := tx.Bucket([]byte("top"))
+		if top == nil {
+			t.Fatalf("top-level bucket missing")
+		}
+		if top.Sequence() != 42 {
+			t.Fatalf("expected sequence 42, got %v", top.Sequence())
+		}
+		if !bytes.Equal(top.Get([]byte("key")), []byte("value")) {
+			t.Fatalf("value mismatch for key")
+		}
+
+		nested := top.Bucket([]byte("nested"))
+		if nested == nil {
+			t.Fatalf("nested bucket missing")
+		}
+		if !bytes.Equal(nested.Get([]byte("nested-key")), value) {
+			t.Fatalf("value mismatch for nested key")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to view compacted database: %v", err)
+	}
+}
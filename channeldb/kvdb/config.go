@@ -0,0 +1,70 @@
+package kvdb
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// BoltBackendName is the name of the default backend, which stores
+	// all data within local bbolt database files.
+	BoltBackendName = "bolt"
+
+	// EtcdBackendName is the name of the backend storing all data within
+	// a remote etcd cluster.
+	EtcdBackendName = "etcd"
+
+	// EtcdMaxTxnOps is the minimum value the --max-txn-ops setting of the
+	// etcd cluster must be raised to. Each key read or written by a
+	// transaction becomes a separate etcd comparison or operation, and
+	// channeldb transactions such as pruning the graph or storing a
+	// channel's state may touch far more keys than etcd's default limit
+	// of 128.
+	EtcdMaxTxnOps = 16384
+)
+
+// ErrEtcdNotAvailable is returned when attempting to use the etcd backend
+// with a binary that was built without the kvdb_etcd build tag.
+var ErrEtcdNotAvailable = errors.New("etcd backend not available, lnd " +
+	"must be built with the kvdb_etcd build tag")
+
+// ErrEtcdTxnTooLarge is returned when committing a write transaction that
+// exceeds the number of operations the etcd cluster accepts within a single
+// transaction.
+var ErrEtcdTxnTooLarge = fmt.Errorf("transaction exceeds the etcd "+
+	"cluster's operation limit, its --max-txn-ops setting must be at "+
+	"least %d", EtcdMaxTxnOps)
+
+// ErrLeadershipLost is returned when committing a write transaction to a
+// fenced backend after this instance has lost the leadership of its cluster.
+var ErrLeadershipLost = errors.New("cluster leadership lost")
+
+// LeaderFencer is implemented by backends that are able to condition every
+// write transaction on this instance still being the leader of its cluster.
+// This prevents an instance that's slow to notice it lost its leadership from
+// clobbering the state written by its successor.
+type LeaderFencer interface {
+	// FenceWrites conditions all subsequent write transactions on the
+	// passed election key still existing with the passed creation
+	// revision. Once it doesn't, commits fail with ErrLeadershipLost.
+	FenceWrites(leaderKey string, leaderRev int64)
+}
+
+// EtcdConfig holds the parameters required to connect to a remote etcd
+// cluster. The members of the cluster must be started with a --max-txn-ops
+// setting of at least EtcdMaxTxnOps.
+type EtcdConfig struct {
+	Host string `long:"host" description:"Etcd database host."`
+
+	User string `long:"user" description:"Etcd database user."`
+
+	Pass string `long:"pass" description:"Password for the database user."`
+
+	Namespace string `long:"namespace" description:"The key prefix under which all of lnd's data is stored, allowing several nodes to share a cluster."`
+
+	CertFile string `long:"cert_file" description:"Path to the TLS certificate for etcd RPC."`
+
+	KeyFile string `long:"key_file" description:"Path to the TLS private key for etcd RPC."`
+
+	InsecureSkipVerify bool `long:"insecure_skip_verify" description:"Whether we intend to skip TLS verification"`
+}
@@ -0,0 +1,905 @@
+// +build kvdb_etcd
+
+package kvdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/pkg/transport"
+)
+
+// The etcd backend flattens the bucket tree into etcd's single key space.
+// Each bucket is identified by a 32-byte ID, derived from the ID of its
+// parent and its name, the root of the tree having an all-zero ID. The keys
+// stored within a bucket are laid out as:
+//
+//   <namespace>k/<bucket id><key> -> 'b'<child bucket id> | 'v'<value>
+//
+// while the sequence number of each bucket is stored under:
+//
+//   <namespace>s/<bucket id> -> uint64
+//
+// The bare entry prefix of each bucket, <namespace>k/<bucket id>, which can't
+// collide with any key as those can't be empty, is rewritten by every
+// transaction modifying the entries of the bucket. As deleting a key leaves
+// no revision behind, this allows a single comparison on the revisions of the
+// prefix to detect any concurrent change to the bucket's entries.
+const (
+	// bucketIDLen is the length of the ID identifying each bucket.
+	bucketIDLen = sha256.Size
+
+	// bucketMarker prefixes the stored value of keys pointing to a
+	// nested bucket.
+	bucketMarker = 'b'
+
+	// valueMarker prefixes the stored value of regular keys.
+	valueMarker = 'v'
+)
+
+var (
+	// rootBucketID is the ID of the implicit bucket holding all top-level
+	// buckets.
+	rootBucketID [bucketIDLen]byte
+
+	// errTxConflict is returned when committing a transaction that read
+	// keys which have since been modified by another transaction. The
+	// transaction is then retried by Update and Batch.
+	errTxConflict = errors.New("etcd transaction conflict")
+)
+
+// etcdBackend is a Backend storing all data within a remote etcd cluster.
+type etcdBackend struct {
+	cli       *clientv3.Client
+	namespace string
+
+	ctx    context.Context
+	cancel func()
+
+	// writeMtx ensures only a single writable transaction is open at a
+	// time, mirroring the semantics of the bolt backend.
+	writeMtx sync.Mutex
+
+	// leaderKey and leaderRev identify the election key this instance
+	// holds as the leader of its cluster, if writes are fenced. They're
+	// protected by the writeMtx.
+	leaderKey string
+	leaderRev int64
+}
+
+// A compile-time check to ensure etcdBackend implements the Backend
+// interface.
+var _ Backend = (*etcdBackend)(nil)
+
+// A compile-time check to ensure etcdBackend implements the LeaderFencer
+// interface.
+var _ LeaderFencer = (*etcdBackend)(nil)
+
+// OpenEtcd connects to the etcd cluster described by the passed config and
+// returns it as a Backend.
+func OpenEtcd(cfg *EtcdConfig) (Backend, error) {
+	clientCfg := clientv3.Config{
+		Endpoints: []string{cfg.Host},
+		Username:  cfg.User,
+		Password:  cfg.Pass,
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		tlsInfo := transport.TLSInfo{
+			CertFile:           cfg.CertFile,
+			KeyFile:            cfg.KeyFile,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientCfg.TLS = tlsConfig
+	}
+
+	cli, err := clientv3.New(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &etcdBackend{
+		cli:       cli,
+		namespace: cfg.Namespace,
+		ctx:       ctx,
+		cancel:    cancel,
+	}, nil
+}
+
+// Begin starts a new transaction. All reads made within the transaction
+// observe the state of the database at the time the transaction was started.
+//
+// NOTE: Part of the Backend interface.
+func (e *etcdBackend) Begin(writable bool) (Tx, error) {
+	if writable {
+		e.writeMtx.Lock()
+	}
+
+	// We'll fetch an arbitrary key in order to learn the current revision
+	// of the database, which all reads of the transaction will be pinned
+	// to.
+	resp, err := e.cli.Get(e.ctx, e.namespace)
+	if err != nil {
+		if writable {
+			e.writeMtx.Unlock()
+		}
+		return nil, err
+	}
+
+	return &etcdTx{
+		backend:  e,
+		writable: writable,
+		rev:      resp.Header.Revision,
+		reads:    make(map[string]int64),
+		ranges:   make(map[string][]*etcdKV),
+		writes:   make(map[string]*etcdWrite),
+	}, nil
+}
+
+// View executes the passed function within a managed read-only transaction.
+//
+// NOTE: Part of the Backend interface.
+func (e *etcdBackend) View(f func(tx Tx) error) error {
+	tx, err := e.Begin(false)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := f(tx); err != nil {
+		return err
+	}
+
+	return tx.(*etcdTx).err
+}
+
+// Update executes the passed function within a managed read-write
+// transaction. If the transaction conflicts with a concurrent writer, it's
+// retried.
+//
+// NOTE: Part of the Backend interface.
+func (e *etcdBackend) Update(f func(tx Tx) error) error {
+	for {
+		tx, err := e.Begin(true)
+		if err != nil {
+			return err
+		}
+
+		if err := f(tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Commit()
+		if err == errTxConflict {
+			continue
+		}
+
+		return err
+	}
+}
+
+// Batch executes the passed function within a read-write transaction. As
+// writable transactions are already serialized, this is equivalent to
+// Update.
+//
+// NOTE: Part of the Backend interface.
+func (e *etcdBackend) Batch(f func(tx Tx) error) error {
+	return e.Update(f)
+}
+
+// FenceWrites conditions all subsequent write transactions on the passed
+// election key still existing with the passed creation revision. As the key
+// is deleted once the lease of its owner expires, no writes can be committed
+// after this instance has lost its leadership.
+//
+// NOTE: Part of the LeaderFencer interface.
+func (e *etcdBackend) FenceWrites(leaderKey string, leaderRev int64) {
+	e.writeMtx.Lock()
+	defer e.writeMtx.Unlock()
+
+	e.leaderKey = leaderKey
+	e.leaderRev = leaderRev
+}
+
+// Close closes the connection to the etcd cluster.
+//
+// NOTE: Part of the Backend interface.
+func (e *etcdBackend) Close() error {
+	e.cancel()
+	return e.cli.Close()
+}
+
+// etcdKV is a key/value pair as seen by a transaction.
+type etcdKV struct {
+	key   []byte
+	value []byte
+}
+
+// etcdWrite is a buffered write within a transaction, which is either a put
+// or a deletion.
+type etcdWrite struct {
+	value   []byte
+	deleted bool
+}
+
+// etcdTx is a transaction against the etcd backend. Reads are served from a
+// snapshot of the database at the revision the transaction started at, while
+// writes are buffered until commit. On commit, the writes are applied within
+// a single etcd transaction that's conditioned on none of the keys read
+// having been modified in the meantime.
+type etcdTx struct {
+	backend  *etcdBackend
+	writable bool
+	closed   bool
+
+	// rev is the database revision all reads are pinned to.
+	rev int64
+
+	// reads maps every key read individually to the revision it was last
+	// modified at, or zero if it didn't exist.
+	reads map[string]int64
+
+	// ranges caches the result of each prefix range read. Rather than
+	// tracking the revision of every key within them, ranges are checked
+	// for concurrent changes as a whole on commit.
+	ranges map[string][]*etcdKV
+
+	// writes holds the buffered writes, keyed by etcd key.
+	writes map[string]*etcdWrite
+
+	// err holds the first error encountered while reading from etcd
+	// through a method that can't return it, such as Bucket or Get. It's
+	// returned on commit.
+	err error
+}
+
+// root returns the implicit bucket holding all top-level buckets.
+func (t *etcdTx) root() *etcdBucket {
+	return &etcdBucket{tx: t, id: rootBucketID[:]}
+}
+
+// Bucket returns the top-level bucket with the given name.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) Bucket(name []byte) Bucket {
+	return t.root().Bucket(name)
+}
+
+// CreateBucket creates a new top-level bucket with the given name.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) CreateBucket(name []byte) (Bucket, error) {
+	return t.root().CreateBucket(name)
+}
+
+// CreateBucketIfNotExists creates the top-level bucket with the given name if
+// it doesn't already exist.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	return t.root().CreateBucketIfNotExists(name)
+}
+
+// DeleteBucket deletes the top-level bucket with the given name.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) DeleteBucket(name []byte) error {
+	return t.root().DeleteBucket(name)
+}
+
+// Writable returns true if the transaction permits writes.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) Writable() bool {
+	return t.writable
+}
+
+// Commit applies all buffered writes to the database, failing with
+// errTxConflict if any of the keys read by the transaction have been
+// modified since it started, and with ErrEtcdTxnTooLarge if the transaction
+// touches more keys than the cluster's --max-txn-ops setting allows.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) Commit() error {
+	if t.closed {
+		return ErrTxClosed
+	}
+	if !t.writable {
+		return ErrTxNotWritable
+	}
+	defer t.close()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	if len(t.writes) == 0 {
+		return nil
+	}
+
+	cmps := make([]clientv3.Cmp, 0, len(t.reads)+len(t.ranges))
+	for key, modRev := range t.reads {
+		cmps = append(cmps, clientv3.Compare(
+			clientv3.ModRevision(key), "=", modRev,
+		))
+	}
+
+	// For each range we iterated over, we'll require that no key under
+	// its prefix was modified after our snapshot. As every transaction
+	// modifying the entries of a bucket also rewrites its marker, this
+	// catches deleted keys as well.
+	for prefix := range t.ranges {
+		cmps = append(cmps, clientv3.Compare(
+			clientv3.ModRevision(prefix).WithPrefix(), "<", t.rev+1,
+		))
+	}
+
+	// If writes are fenced, then we'll only commit if we're still the
+	// leader of the cluster.
+	leaderKey := t.backend.leaderKey
+	if leaderKey != "" {
+		cmps = append(cmps, clientv3.Compare(
+			clientv3.CreateRevision(leaderKey), "=",
+			t.backend.leaderRev,
+		))
+	}
+
+	t.markModifiedBuckets()
+
+	ops := make([]clientv3.Op, 0, len(t.writes))
+	for key, write := range t.writes {
+		if write.deleted {
+			ops = append(ops, clientv3.OpDelete(key))
+			continue
+		}
+		ops = append(ops, clientv3.OpPut(key, string(write.value)))
+	}
+
+	txn := t.backend.cli.Txn(t.backend.ctx).If(cmps...).Then(ops...)
+
+	// Should the transaction fail, we'll fetch the leader key in order to
+	// tell whether it failed due to a conflict or us losing leadership.
+	if leaderKey != "" {
+		txn = txn.Else(clientv3.OpGet(leaderKey))
+	}
+
+	resp, err := txn.Commit()
+	switch {
+	case err == rpctypes.ErrTooManyOps:
+		return ErrEtcdTxnTooLarge
+
+	case err != nil:
+		return err
+	}
+	if !resp.Succeeded {
+		if leaderKey != "" && !t.backend.isLeader(resp) {
+			return ErrLeadershipLost
+		}
+
+		return errTxConflict
+	}
+
+	return nil
+}
+
+// markModifiedBuckets buffers a write of the marker of every bucket whose
+// entries are modified by the buffered writes. The marker of a deleted bucket
+// is left deleted, unless entries are put into the bucket after re-creating
+// it.
+func (t *etcdTx) markModifiedBuckets() {
+	entriesPrefix := t.backend.namespace + "k/"
+	markerLen := len(entriesPrefix) + bucketIDLen
+
+	markers := make(map[string]struct{})
+	for key, write := range t.writes {
+		if len(key) <= markerLen ||
+			key[:len(entriesPrefix)] != entriesPrefix {
+
+			continue
+		}
+
+		marker := key[:markerLen]
+		if w, ok := t.writes[marker]; ok && w.deleted && write.deleted {
+			continue
+		}
+		markers[marker] = struct{}{}
+	}
+
+	for marker := range markers {
+		t.writes[marker] = &etcdWrite{}
+	}
+}
+
+// isLeader returns whether the leader key fetched by a failed transaction
+// shows that we're still the leader of the cluster.
+//
+// NOTE: This MUST be called with the writeMtx held.
+func (e *etcdBackend) isLeader(resp *clientv3.TxnResponse) bool {
+	if len(resp.Responses) == 0 {
+		return false
+	}
+
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	return len(kvs) == 1 && kvs[0].CreateRevision == e.leaderRev
+}
+
+// Rollback closes the transaction, discarding any buffered writes.
+//
+// NOTE: Part of the Tx interface.
+func (t *etcdTx) Rollback() error {
+	if t.closed {
+		return ErrTxClosed
+	}
+	t.close()
+
+	return nil
+}
+
+// close marks the transaction as closed, releasing the write lock if held.
+func (t *etcdTx) close() {
+	t.closed = true
+	if t.writable {
+		t.backend.writeMtx.Unlock()
+	}
+}
+
+// get returns the raw stored value of the given etcd key as seen by the
+// transaction, or nil if it doesn't exist.
+func (t *etcdTx) get(key string) []byte {
+	if write, ok := t.writes[key]; ok {
+		if write.deleted {
+			return nil
+		}
+		return write.value
+	}
+
+	resp, err := t.backend.cli.Get(
+		t.backend.ctx, key, clientv3.WithRev(t.rev),
+	)
+	if err != nil {
+		t.setErr(err)
+		return nil
+	}
+
+	if len(resp.Kvs) == 0 {
+		t.reads[key] = 0
+		return nil
+	}
+
+	t.reads[key] = resp.Kvs[0].ModRevision
+	return resp.Kvs[0].Value
+}
+
+// put buffers a write of the given raw value under the given etcd key.
+func (t *etcdTx) put(key string, value []byte) {
+	valueCopy := make([]byte, len(value))
+	copy(valueCopy, value)
+
+	t.writes[key] = &etcdWrite{value: valueCopy}
+}
+
+// del buffers the deletion of the given etcd key.
+func (t *etcdTx) del(key string) {
+	t.writes[key] = &etcdWrite{deleted: true}
+}
+
+// rangePrefix returns all key/value pairs stored under the given prefix as
+// seen by the transaction, sorted by key. The prefix is stripped from the
+// returned keys.
+func (t *etcdTx) rangePrefix(prefix string) []*etcdKV {
+	snapshot, ok := t.ranges[prefix]
+	if !ok {
+		resp, err := t.backend.cli.Get(
+			t.backend.ctx, prefix, clientv3.WithPrefix(),
+			clientv3.WithRev(t.rev),
+		)
+		if err != nil {
+			t.setErr(err)
+			return nil
+		}
+
+		snapshot = make([]*etcdKV, 0, len(resp.Kvs))
+		for _, kv := range resp.Kvs {
+			// The marker of the bucket isn't one of its entries.
+			if len(kv.Key) == len(prefix) {
+				continue
+			}
+
+			snapshot = append(snapshot, &etcdKV{
+				key:   kv.Key[len(prefix):],
+				value: kv.Value,
+			})
+		}
+		t.ranges[prefix] = snapshot
+	}
+
+	// With the snapshot fetched, we'll now overlay any writes we've
+	// buffered within the range.
+	merged := make(map[string][]byte, len(snapshot))
+	for _, kv := range snapshot {
+		merged[string(kv.key)] = kv.value
+	}
+	for key, write := range t.writes {
+		if len(key) <= len(prefix) || key[:len(prefix)] != prefix {
+			continue
+		}
+
+		subKey := key[len(prefix):]
+		if write.deleted {
+			delete(merged, subKey)
+			continue
+		}
+		merged[subKey] = write.value
+	}
+
+	kvs := make([]*etcdKV, 0, len(merged))
+	for key, value := range merged {
+		kvs = append(kvs, &etcdKV{key: []byte(key), value: value})
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return bytes.Compare(kvs[i].key, kvs[j].key) < 0
+	})
+
+	return kvs
+}
+
+// setErr records the first error encountered by the transaction.
+func (t *etcdTx) setErr(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// etcdBucket is a bucket within an etcd transaction.
+type etcdBucket struct {
+	tx *etcdTx
+	id []byte
+}
+
+// entryPrefix returns the etcd key prefix of all keys stored within the
+// bucket.
+func (b *etcdBucket) entryPrefix() string {
+	return b.tx.backend.namespace + "k/" + string(b.id)
+}
+
+// entryKey returns the etcd key of the given key within the bucket.
+func (b *etcdBucket) entryKey(key []byte) string {
+	return b.entryPrefix() + string(key)
+}
+
+// sequenceKey returns the etcd key holding the bucket's sequence number.
+func (b *etcdBucket) sequenceKey() string {
+	return b.tx.backend.namespace + "s/" + string(b.id)
+}
+
+// childID derives the ID of the nested bucket with the given name.
+func (b *etcdBucket) childID(name []byte) []byte {
+	h := sha256.New()
+	h.Write(b.id)
+	h.Write(name)
+	return h.Sum(nil)
+}
+
+// Bucket returns the nested bucket with the given name.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) Bucket(name []byte) Bucket {
+	value := b.tx.get(b.entryKey(name))
+	if len(value) == 0 || value[0] != bucketMarker {
+		return nil
+	}
+
+	return &etcdBucket{tx: b.tx, id: value[1:]}
+}
+
+// CreateBucket creates a new nested bucket with the given name.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) CreateBucket(name []byte) (Bucket, error) {
+	switch {
+	case !b.tx.writable:
+		return nil, ErrTxNotWritable
+	case len(name) == 0:
+		return nil, ErrBucketNameRequired
+	}
+
+	key := b.entryKey(name)
+	value := b.tx.get(key)
+	switch {
+	case len(value) == 0:
+	case value[0] == bucketMarker:
+		return nil, ErrBucketExists
+	default:
+		return nil, ErrIncompatibleValue
+	}
+
+	id := b.childID(name)
+	b.tx.put(key, append([]byte{bucketMarker}, id...))
+
+	return &etcdBucket{tx: b.tx, id: id}, nil
+}
+
+// CreateBucketIfNotExists creates the nested bucket with the given name if it
+// doesn't already exist.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	bucket, err := b.CreateBucket(name)
+	if err == ErrBucketExists {
+		return b.Bucket(name), nil
+	}
+
+	return bucket, err
+}
+
+// DeleteBucket deletes the nested bucket with the given name, along with all
+// its contents.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) DeleteBucket(name []byte) error {
+	if !b.tx.writable {
+		return ErrTxNotWritable
+	}
+
+	key := b.entryKey(name)
+	value := b.tx.get(key)
+	switch {
+	case len(value) == 0:
+		return ErrBucketNotFound
+	case value[0] != bucketMarker:
+		return ErrIncompatibleValue
+	}
+
+	child := &etcdBucket{tx: b.tx, id: value[1:]}
+	child.deleteContents()
+	b.tx.del(key)
+
+	return nil
+}
+
+// deleteContents deletes all keys and nested buckets within the bucket, along
+// with its sequence number and marker.
+func (b *etcdBucket) deleteContents() {
+	prefix := b.entryPrefix()
+	for _, kv := range b.tx.rangePrefix(prefix) {
+		if kv.value[0] == bucketMarker {
+			child := &etcdBucket{tx: b.tx, id: kv.value[1:]}
+			child.deleteContents()
+		}
+		b.tx.del(prefix + string(kv.key))
+	}
+
+	b.tx.del(b.sequenceKey())
+	b.tx.del(prefix)
+}
+
+// Get returns the value stored under the given key.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) Get(key []byte) []byte {
+	value := b.tx.get(b.entryKey(key))
+	if len(value) == 0 || value[0] != valueMarker {
+		return nil
+	}
+
+	return value[1:]
+}
+
+// Put sets the value for the given key.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) Put(key, value []byte) error {
+	switch {
+	case !b.tx.writable:
+		return ErrTxNotWritable
+	case len(key) == 0:
+		return ErrKeyRequired
+	}
+
+	entryKey := b.entryKey(key)
+	existing := b.tx.get(entryKey)
+	if len(existing) != 0 && existing[0] == bucketMarker {
+		return ErrIncompatibleValue
+	}
+
+	b.tx.put(entryKey, append([]byte{valueMarker}, value...))
+
+	return nil
+}
+
+// Delete removes the given key.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) Delete(key []byte) error {
+	if !b.tx.writable {
+		return ErrTxNotWritable
+	}
+
+	entryKey := b.entryKey(key)
+	existing := b.tx.get(entryKey)
+	switch {
+	case len(existing) == 0:
+		return nil
+	case existing[0] == bucketMarker:
+		return ErrIncompatibleValue
+	}
+
+	b.tx.del(entryKey)
+
+	return nil
+}
+
+// ForEach executes the passed function for each key/value pair in the bucket.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) ForEach(f func(k, v []byte) error) error {
+	for _, kv := range b.tx.rangePrefix(b.entryPrefix()) {
+		if err := f(kv.key, entryValue(kv.value)); err != nil {
+			return err
+		}
+	}
+
+	return b.tx.err
+}
+
+// Cursor returns a cursor over the bucket's key/value pairs. The cursor
+// iterates over the contents of the bucket at the time it was created.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) Cursor() Cursor {
+	return &etcdCursor{
+		bucket: b,
+		kvs:    b.tx.rangePrefix(b.entryPrefix()),
+		pos:    -1,
+	}
+}
+
+// NextSequence increments and returns the bucket's sequence number.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) NextSequence() (uint64, error) {
+	seq := b.Sequence() + 1
+	if err := b.SetSequence(seq); err != nil {
+		return 0, err
+	}
+
+	return seq, nil
+}
+
+// Sequence returns the bucket's current sequence number.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) Sequence() uint64 {
+	value := b.tx.get(b.sequenceKey())
+	if len(value) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// SetSequence sets the bucket's sequence number.
+//
+// NOTE: Part of the Bucket interface.
+func (b *etcdBucket) SetSequence(v uint64) error {
+	if !b.tx.writable {
+		return ErrTxNotWritable
+	}
+
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], v)
+	b.tx.put(b.sequenceKey(), seq[:])
+
+	return nil
+}
+
+// entryValue returns the value of a stored entry as exposed to callers, which
+// is nil for nested buckets.
+func entryValue(raw []byte) []byte {
+	if raw[0] == bucketMarker {
+		return nil
+	}
+
+	return raw[1:]
+}
+
+// etcdCursor iterates over a snapshot of a bucket's contents.
+type etcdCursor struct {
+	bucket *etcdBucket
+	kvs    []*etcdKV
+	pos    int
+}
+
+// current returns the key/value pair the cursor points to, or nils if it's
+// out of bounds.
+func (c *etcdCursor) current() ([]byte, []byte) {
+	if c.pos < 0 || c.pos >= len(c.kvs) {
+		return nil, nil
+	}
+
+	kv := c.kvs[c.pos]
+	return kv.key, entryValue(kv.value)
+}
+
+// First moves the cursor to the first item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *etcdCursor) First() ([]byte, []byte) {
+	c.pos = 0
+	return c.current()
+}
+
+// Last moves the cursor to the last item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *etcdCursor) Last() ([]byte, []byte) {
+	c.pos = len(c.kvs) - 1
+	return c.current()
+}
+
+// Next moves the cursor to the next item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *etcdCursor) Next() ([]byte, []byte) {
+	if c.pos < len(c.kvs) {
+		c.pos++
+	}
+	return c.current()
+}
+
+// Prev moves the cursor to the previous item in the bucket.
+//
+// NOTE: Part of the Cursor interface.
+func (c *etcdCursor) Prev() ([]byte, []byte) {
+	if c.pos >= 0 {
+		c.pos--
+	}
+	return c.current()
+}
+
+// Seek moves the cursor to the given key, or the next key after it.
+//
+// NOTE: Part of the Cursor interface.
+func (c *etcdCursor) Seek(seek []byte) ([]byte, []byte) {
+	c.pos = sort.Search(len(c.kvs), func(i int) bool {
+		return bytes.Compare(c.kvs[i].key, seek) >= 0
+	})
+	return c.current()
+}
+
+// Delete removes the key/value pair the cursor currently points to.
+//
+// NOTE: Part of the Cursor interface.
+func (c *etcdCursor) Delete() error {
+	key, value := c.current()
+	if key == nil {
+		return nil
+	}
+	if value == nil {
+		return ErrIncompatibleValue
+	}
+
+	if err := c.bucket.Delete(key); err != nil {
+		return err
+	}
+
+	// Remove the item from our snapshot while keeping the cursor
+	// positioned such that Next returns the item that followed it.
+	c.kvs = append(c.kvs[:c.pos], c.kvs[c.pos+1:]...)
+	c.pos--
+
+	return nil
+}
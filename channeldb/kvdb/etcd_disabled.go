@@ -0,0 +1,9 @@
+// +build !kvdb_etcd
+
+package kvdb
+
+// OpenEtcd returns ErrEtcdNotAvailable, as this binary was built without
+// etcd support.
+func OpenEtcd(cfg *EtcdConfig) (Backend, error) {
+	return nil, ErrEtcdNotAvailable
+}
@@ -0,0 +1,136 @@
+// +build kvdb_etcd
+
+package kvdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// TestEtcdRangeConflict tests that a transaction which iterated over a bucket
+// fails to commit if another instance modified the bucket's entries in the
+// meantime, including by deleting one of them.
+func TestEtcdRangeConflict(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "kvdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	etcdCfg, stopEtcd, err := StartTestEtcd(tempDir, "kvdb")
+	if err != nil {
+		t.Fatalf("unable to start etcd: %v", err)
+	}
+	defer stopEtcd()
+
+	// We'll connect two backends to the same database, acting as two
+	// separate instances writing to it.
+	alice, err := OpenEtcd(etcdCfg)
+	if err != nil {
+		t.Fatalf("unable to open backend: %v", err)
+	}
+	defer alice.Close()
+
+	bob, err := OpenEtcd(etcdCfg)
+	if err != nil {
+		t.Fatalf("unable to open backend: %v", err)
+	}
+	defer bob.Close()
+
+	err = alice.Update(func(tx Tx) error {
+		bucket, err := tx.CreateBucket([]byte("bucket"))
+		if err != nil {
+			return err
+		}
+		for _, key := range []byte{0x01, 0x02, 0x03} {
+			err := bucket.Put([]byte{key}, []byte{key})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.CreateBucket([]byte("sink"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to populate bucket: %v", err)
+	}
+
+	// storeCount iterates over the bucket, and stores the number of
+	// entries found within another one.
+	storeCount := func(tx Tx) error {
+		var numEntries byte
+		bucket := tx.Bucket([]byte("bucket"))
+		err := bucket.ForEach(func(_, _ []byte) error {
+			numEntries++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket([]byte("sink")).Put(
+			[]byte("count"), []byte{numEntries},
+		)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(bucket Bucket) error
+	}{
+		{
+			name: "insert",
+			modify: func(bucket Bucket) error {
+				return bucket.Put([]byte{0x04}, []byte{0x04})
+			},
+		},
+		{
+			name: "update",
+			modify: func(bucket Bucket) error {
+				return bucket.Put([]byte{0x01}, []byte{0xff})
+			},
+		},
+		{
+			name: "delete",
+			modify: func(bucket Bucket) error {
+				return bucket.Delete([]byte{0x02})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tx, err := alice.Begin(true)
+		if err != nil {
+			t.Fatalf("%v: unable to begin transaction: %v",
+				test.name, err)
+		}
+		if err := storeCount(tx); err != nil {
+			t.Fatalf("%v: unable to store count: %v", test.name,
+				err)
+		}
+
+		// Before she commits, Bob modifies the bucket, which should
+		// cause her transaction to conflict.
+		err = bob.Update(func(tx Tx) error {
+			return test.modify(tx.Bucket([]byte("bucket")))
+		})
+		if err != nil {
+			t.Fatalf("%v: unable to modify bucket: %v", test.name,
+				err)
+		}
+
+		if err := tx.Commit(); err != errTxConflict {
+			t.Fatalf("%v: expected errTxConflict, got %v",
+				test.name, err)
+		}
+	}
+
+	// Without any concurrent modification, Alice's transaction should
+	// commit.
+	if err := alice.Update(storeCount); err != nil {
+		t.Fatalf("unable to update backend: %v", err)
+	}
+}
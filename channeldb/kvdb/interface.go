@@ -0,0 +1,182 @@
+// Package kvdb defines the key-value database abstraction that backs all of
+// lnd's persistent state, along with the drivers implementing it. bbolt is
+// used by default, and a remote etcd cluster may be used instead when built
+// with the kvdb_etcd build tag.
+package kvdb
+
+import "errors"
+
+var (
+	// ErrBucketNotFound is returned when trying to access a bucket that
+	// has not been created yet.
+	ErrBucketNotFound = errors.New("bucket not found")
+
+	// ErrBucketExists is returned when creating a bucket that already
+	// exists.
+	ErrBucketExists = errors.New("bucket already exists")
+
+	// ErrBucketNameRequired is returned when creating a bucket with a
+	// blank name.
+	ErrBucketNameRequired = errors.New("bucket name required")
+
+	// ErrKeyRequired is returned when inserting a zero-length key.
+	ErrKeyRequired = errors.New("key required")
+
+	// ErrIncompatibleValue is returned when trying to create or delete a
+	// bucket on an existing non-bucket key or when trying to create or
+	// delete a non-bucket key on an existing bucket key.
+	ErrIncompatibleValue = errors.New("incompatible value")
+
+	// ErrTxNotWritable is returned when performing a write operation on a
+	// read-only transaction.
+	ErrTxNotWritable = errors.New("tx not writable")
+
+	// ErrTxClosed is returned when committing or rolling back a
+	// transaction that has already been committed or rolled back.
+	ErrTxClosed = errors.New("tx closed")
+
+	// ErrDatabaseNotOpen is returned when a database instance is accessed
+	// before it is opened or after it is closed.
+	ErrDatabaseNotOpen = errors.New("database not open")
+)
+
+// Backend is a key-value store with ACID transactions, structured as a tree
+// of nested buckets. All of lnd's persistent state is accessed through this
+// interface, allowing the node to run against different storage engines.
+type Backend interface {
+	// Begin starts a new transaction. Only a single writable transaction
+	// may be open at a time. The caller MUST either commit or roll back
+	// the returned transaction.
+	Begin(writable bool) (Tx, error)
+
+	// View executes the passed function within the context of a managed
+	// read-only transaction. Any error returned from the function is
+	// returned from View.
+	View(f func(tx Tx) error) error
+
+	// Update executes the passed function within the context of a
+	// managed read-write transaction. If the function returns nil, the
+	// transaction is committed, otherwise it's rolled back and the error
+	// is returned.
+	Update(f func(tx Tx) error) error
+
+	// Batch is similar to Update, but may combine the passed function
+	// with those of other concurrent callers into a single transaction.
+	// As a result, the passed function may be executed several times, so
+	// it MUST be idempotent.
+	Batch(f func(tx Tx) error) error
+
+	// Close releases all resources held by the backend. All transactions
+	// must be closed before calling Close.
+	Close() error
+}
+
+// Tx is a transaction against a Backend. The top level of the key space only
+// holds buckets.
+type Tx interface {
+	// Bucket returns the top-level bucket with the given name, or nil if
+	// the bucket doesn't exist.
+	Bucket(name []byte) Bucket
+
+	// CreateBucket creates a new top-level bucket with the given name. An
+	// error is returned if the bucket already exists.
+	CreateBucket(name []byte) (Bucket, error)
+
+	// CreateBucketIfNotExists creates a new top-level bucket with the
+	// given name if it doesn't already exist, and returns it.
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+
+	// DeleteBucket deletes the top-level bucket with the given name,
+	// along with all its nested buckets and keys.
+	DeleteBucket(name []byte) error
+
+	// Writable returns true if the transaction permits writes.
+	Writable() bool
+
+	// Commit writes all changes made within the transaction to the
+	// backend. Committing a managed transaction isn't permitted.
+	Commit() error
+
+	// Rollback closes the transaction, discarding any changes.
+	Rollback() error
+}
+
+// Bucket is a collection of key/value pairs and nested buckets within the
+// database. Keys are kept in byte-sorted order.
+type Bucket interface {
+	// Bucket returns the nested bucket with the given name, or nil if the
+	// bucket doesn't exist.
+	Bucket(name []byte) Bucket
+
+	// CreateBucket creates a new nested bucket with the given name. An
+	// error is returned if the bucket already exists.
+	CreateBucket(name []byte) (Bucket, error)
+
+	// CreateBucketIfNotExists creates a new nested bucket with the given
+	// name if it doesn't already exist, and returns it.
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+
+	// DeleteBucket deletes the nested bucket with the given name, along
+	// with all its nested buckets and keys.
+	DeleteBucket(name []byte) error
+
+	// Get returns the value stored under the given key, or nil if the key
+	// doesn't exist or is a nested bucket. The returned value is only
+	// valid for the life of the transaction.
+	Get(key []byte) []byte
+
+	// Put sets the value for the given key, overwriting any existing
+	// value.
+	Put(key, value []byte) error
+
+	// Delete removes the given key. Deleting a key that doesn't exist is
+	// not an error.
+	Delete(key []byte) error
+
+	// ForEach executes the passed function for each key/value pair in the
+	// bucket, in key order. Nested buckets are passed with a nil value.
+	// The bucket MUST NOT be modified from within the function.
+	ForEach(f func(k, v []byte) error) error
+
+	// Cursor returns a cursor over the bucket's key/value pairs.
+	Cursor() Cursor
+
+	// NextSequence increments and returns the bucket's sequence number.
+	NextSequence() (uint64, error)
+
+	// Sequence returns the bucket's current sequence number.
+	Sequence() uint64
+
+	// SetSequence sets the bucket's sequence number.
+	SetSequence(v uint64) error
+}
+
+// Cursor iterates over the key/value pairs of a bucket in key order. Nested
+// buckets are returned with a nil value. All returned keys and values are
+// only valid for the life of the transaction.
+type Cursor interface {
+	// First moves the cursor to the first item in the bucket, returning
+	// its key and value. A nil key is returned if the bucket is empty.
+	First() (key, value []byte)
+
+	// Last moves the cursor to the last item in the bucket, returning its
+	// key and value. A nil key is returned if the bucket is empty.
+	Last() (key, value []byte)
+
+	// Next moves the cursor to the next item in the bucket, returning its
+	// key and value. A nil key is returned at the end of the bucket.
+	Next() (key, value []byte)
+
+	// Prev moves the cursor to the previous item in the bucket, returning
+	// its key and value. A nil key is returned at the start of the
+	// bucket.
+	Prev() (key, value []byte)
+
+	// Seek moves the cursor to the given key, or the next key after it if
+	// the key doesn't exist, returning its key and value. A nil key is
+	// returned if no such key exists.
+	Seek(seek []byte) (key, value []byte)
+
+	// Delete removes the key/value pair the cursor currently points to.
+	Delete() error
+}
@@ -0,0 +1,259 @@
+package kvdb
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// makeTestBackend creates a fresh test backend, returning it along with a
+// closure that tears it down.
+func makeTestBackend(t *testing.T) (Backend, func()) {
+	tempDir, err := ioutil.TempDir("", "kvdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	backend, cleanUp, err := GetTestBackend(tempDir, "kvdb")
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to create %v backend: %v", TestBackend, err)
+	}
+
+	return backend, func() {
+		cleanUp()
+		os.RemoveAll(tempDir)
+	}
+}
+
+// TestBucketOperations tests the creation, lookup and deletion of nested
+// buckets, along with the errors returned on invalid operations.
+func TestBucketOperations(t *testing.T) {
+	t.Parallel()
+
+	backend, cleanUp := makeTestBackend(t)
+	defer cleanUp()
+
+	err := backend.Update(func(tx Tx) error {
+		if tx.Bucket([]byte("top")) != nil {
+			t.Fatalf("bucket found before creation")
+		}
+
+		top, err := tx.CreateBucket([]byte("top"))
+		if err != nil {
+			t.Fatalf("unable to create bucket: %v", err)
+		}
+		if _, err := tx.CreateBucket([]byte("top")); err != ErrBucketExists {
+			t.Fatalf("expected ErrBucketExists, got %v", err)
+		}
+		if _, err := top.CreateBucket(nil); err != ErrBucketNameRequired {
+			t.Fatalf("expected ErrBucketNameRequired, got %v", err)
+		}
+
+		nested, err := top.CreateBucketIfNotExists([]byte("nested"))
+		if err != nil {
+			t.Fatalf("unable to create nested bucket: %v", err)
+		}
+		if err := nested.Put([]byte("key"), []byte("value")); err != nil {
+			t.Fatalf("unable to put key: %v", err)
+		}
+
+		// Values and buckets can't be used interchangeably.
+		if err := top.Put([]byte("nested"), nil); err != ErrIncompatibleValue {
+			t.Fatalf("expected ErrIncompatibleValue, got %v", err)
+		}
+		if top.Get([]byte("nested")) != nil {
+			t.Fatalf("expected nil value for nested bucket")
+		}
+		if err := top.Put([]byte("value"), []byte("v")); err != nil {
+			t.Fatalf("unable to put key: %v", err)
+		}
+		_, err = top.CreateBucket([]byte("value"))
+		if err != ErrIncompatibleValue {
+			t.Fatalf("expected ErrIncompatibleValue, got %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update backend: %v", err)
+	}
+
+	// Deleting the top-level bucket should remove all its contents, such
+	// that re-creating it yields an empty bucket.
+	err = backend.Update(func(tx Tx) error {
+		if err := tx.DeleteBucket([]byte("top")); err != nil {
+			t.Fatalf("unable to delete bucket: %v", err)
+		}
+		err := tx.DeleteBucket([]byte("top"))
+		if err != ErrBucketNotFound {
+			t.Fatalf("expected ErrBucketNotFound, got %v", err)
+		}
+
+		top, err := tx.CreateBucket([]byte("top"))
+		if err != nil {
+			t.Fatalf("unable to create bucket: %v", err)
+		}
+		return top.ForEach(func(k, v []byte) error {
+			t.Fatalf("unexpected key %x in re-created bucket", k)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to update backend: %v", err)
+	}
+}
+
+// TestTransactionRollback tests that writes made within a failed update are
+// discarded, and that read-only transactions can't write.
+func TestTransactionRollback(t *testing.T) {
+	t.Parallel()
+
+	backend, cleanUp := makeTestBackend(t)
+	defer cleanUp()
+
+	errFail := errors.New("fail")
+	err := backend.Update(func(tx Tx) error {
+		if _, err := tx.CreateBucket([]byte("bucket")); err != nil {
+			t.Fatalf("unable to create bucket: %v", err)
+		}
+		return errFail
+	})
+	if err != errFail {
+		t.Fatalf("expected %v, got %v", errFail, err)
+	}
+
+	err = backend.View(func(tx Tx) error {
+		if tx.Bucket([]byte("bucket")) != nil {
+			t.Fatalf("bucket found after rollback")
+		}
+		if tx.Writable() {
+			t.Fatalf("read-only transaction is writable")
+		}
+		_, err := tx.CreateBucket([]byte("bucket"))
+		if err != ErrTxNotWritable {
+			t.Fatalf("expected ErrTxNotWritable, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to view backend: %v", err)
+	}
+}
+
+// TestCursor tests that cursors iterate over a bucket's contents in key order
+// and are able to delete items while iterating.
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	backend, cleanUp := makeTestBackend(t)
+	defer cleanUp()
+
+	keys := [][]byte{{0x01}, {0x02}, {0x03}, {0x04}}
+	err := backend.Update(func(tx Tx) error {
+		bucket, err := tx.CreateBucket([]byte("bucket"))
+		if err != nil {
+			return err
+		}
+
+		// Insert the keys in reverse order, to ensure the cursor
+		// returns them sorted.
+		for i := len(keys) - 1; i >= 0; i-- {
+			if err := bucket.Put(keys[i], keys[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to populate bucket: %v", err)
+	}
+
+	err = backend.Update(func(tx Tx) error {
+		c := tx.Bucket([]byte("bucket")).Cursor()
+
+		i := 0
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if !bytes.Equal(k, keys[i]) || !bytes.Equal(v, keys[i]) {
+				t.Fatalf("expected key %x, got %x", keys[i], k)
+			}
+			i++
+		}
+		if i != len(keys) {
+			t.Fatalf("expected %v keys, got %v", len(keys), i)
+		}
+
+		if k, _ := c.Last(); !bytes.Equal(k, keys[3]) {
+			t.Fatalf("expected last key %x, got %x", keys[3], k)
+		}
+		if k, _ := c.Prev(); !bytes.Equal(k, keys[2]) {
+			t.Fatalf("expected key %x, got %x", keys[2], k)
+		}
+
+		// Seeking to a missing key should land on the one following
+		// it.
+		k, _ := c.Seek([]byte{0x01, 0x00})
+		if !bytes.Equal(k, keys[1]) {
+			t.Fatalf("expected key %x, got %x", keys[1], k)
+		}
+
+		return c.Delete()
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate bucket: %v", err)
+	}
+
+	err = backend.View(func(tx Tx) error {
+		if tx.Bucket([]byte("bucket")).Get(keys[1]) != nil {
+			t.Fatalf("deleted key still present")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to view backend: %v", err)
+	}
+}
+
+// TestSequence tests the persistence of bucket sequence numbers.
+func TestSequence(t *testing.T) {
+	t.Parallel()
+
+	backend, cleanUp := makeTestBackend(t)
+	defer cleanUp()
+
+	err := backend.Update(func(tx Tx) error {
+		bucket, err := tx.CreateBucket([]byte("bucket"))
+		if err != nil {
+			return err
+		}
+
+		for i := uint64(1); i <= 3; i++ {
+			seq, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			if seq != i {
+				t.Fatalf("expected sequence %v, got %v", i, seq)
+			}
+		}
+
+		return bucket.SetSequence(10)
+	})
+	if err != nil {
+		t.Fatalf("unable to update sequence: %v", err)
+	}
+
+	err = backend.View(func(tx Tx) error {
+		seq := tx.Bucket([]byte("bucket")).Sequence()
+		if seq != 10 {
+			t.Fatalf("expected sequence 10, got %v", seq)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to view backend: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// +build !kvdb_etcd
+
+package kvdb
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// TestBackend is the name of the backend used by GetTestBackend. Building
+// with the kvdb_etcd build tag switches all tests using it to etcd.
+const TestBackend = BoltBackendName
+
+// GetTestBackend opens a fresh backend for use within tests, storing any data
+// within the passed directory. The returned closure closes the backend and
+// removes all its data.
+func GetTestBackend(dir, name string) (Backend, func(), error) {
+	path := filepath.Join(dir, name+".db")
+	backend, err := OpenBolt(path, 0600, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		backend.Close()
+		os.Remove(path)
+	}
+
+	return backend, cleanUp, nil
+}
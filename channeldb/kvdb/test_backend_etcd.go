@@ -0,0 +1,106 @@
+// +build kvdb_etcd
+
+package kvdb
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/etcd/embed"
+)
+
+const (
+	// TestBackend is the name of the backend used by GetTestBackend.
+	// Building without the kvdb_etcd build tag switches all tests using
+	// it to bolt.
+	TestBackend = EtcdBackendName
+
+	// testEtcdStartupTimeout is the maximum duration we'll wait for the
+	// embedded etcd server to become ready.
+	testEtcdStartupTimeout = 10 * time.Second
+)
+
+// GetTestBackend starts a fresh embedded etcd server for use within tests,
+// storing its data within the passed directory. The returned closure stops
+// the server and removes all its data.
+func GetTestBackend(dir, name string) (Backend, func(), error) {
+	etcdCfg, stopEtcd, err := StartTestEtcd(dir, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backend, err := OpenEtcd(etcdCfg)
+	if err != nil {
+		stopEtcd()
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		backend.Close()
+		stopEtcd()
+	}
+
+	return backend, cleanUp, nil
+}
+
+// StartTestEtcd starts a fresh embedded etcd server for use within tests,
+// storing its data within the passed directory. The config required to
+// connect to it is returned, along with a closure that stops the server and
+// removes all its data.
+func StartTestEtcd(dir, name string) (*EtcdConfig, func(), error) {
+	clientURL, err := freeLocalURL()
+	if err != nil {
+		return nil, nil, err
+	}
+	peerURL, err := freeLocalURL()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := embed.NewConfig()
+	cfg.Dir = filepath.Join(dir, name+".etcd")
+	// The embedded server is configured like the clusters lnd is meant to
+	// be run against, see EtcdMaxTxnOps.
+	cfg.MaxTxnOps = EtcdMaxTxnOps
+	cfg.LCUrls = []url.URL{*clientURL}
+	cfg.ACUrls = []url.URL{*clientURL}
+	cfg.LPUrls = []url.URL{*peerURL}
+	cfg.APUrls = []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	etcd, err := embed.StartEtcd(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case <-etcd.Server.ReadyNotify():
+	case <-time.After(testEtcdStartupTimeout):
+		etcd.Close()
+		return nil, nil, fmt.Errorf("etcd failed to start after %v",
+			testEtcdStartupTimeout)
+	}
+
+	stop := func() {
+		etcd.Close()
+		os.RemoveAll(cfg.Dir)
+	}
+
+	return &EtcdConfig{Host: clientURL.Host}, stop, nil
+}
+
+// freeLocalURL returns a URL on the loopback interface using a port that's
+// currently free.
+func freeLocalURL() (*url.URL, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	return &url.URL{Scheme: "http", Host: l.Addr().String()}, nil
+}
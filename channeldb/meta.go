@@ -1,7 +1,7 @@
 package channeldb
 
 import (
-	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 )
 
 var (
@@ -22,10 +22,10 @@ type Meta struct {
 
 // FetchMeta fetches the meta data from boltdb and returns filled meta
 // structure.
-func (d *DB) FetchMeta(tx *bolt.Tx) (*Meta, error) {
+func (d *DB) FetchMeta(tx kvdb.Tx) (*Meta, error) {
 	meta := &Meta{}
 
-	err := d.View(func(tx *bolt.Tx) error {
+	err := d.View(func(tx kvdb.Tx) error {
 		return fetchMeta(meta, tx)
 	})
 	if err != nil {
@@ -38,7 +38,7 @@ func (d *DB) FetchMeta(tx *bolt.Tx) (*Meta, error) {
 // fetchMeta is an internal helper function used in order to allow callers to
 // re-use a database transaction. See the publicly exported FetchMeta method
 // for more information.
-func fetchMeta(meta *Meta, tx *bolt.Tx) error {
+func fetchMeta(meta *Meta, tx kvdb.Tx) error {
 	metaBucket := tx.Bucket(metaBucket)
 	if metaBucket == nil {
 		return ErrMetaNotFound
@@ -56,7 +56,7 @@ func fetchMeta(meta *Meta, tx *bolt.Tx) error {
 
 // PutMeta writes the passed instance of the database met-data struct to disk.
 func (d *DB) PutMeta(meta *Meta) error {
-	return d.Update(func(tx *bolt.Tx) error {
+	return d.Update(func(tx kvdb.Tx) error {
 		return putMeta(meta, tx)
 	})
 }
@@ -64,7 +64,7 @@ func (d *DB) PutMeta(meta *Meta) error {
 // putMeta is an internal helper function used in order to allow callers to
 // re-use a database transaction. See the publicly exported PutMeta method for
 // more information.
-func putMeta(meta *Meta, tx *bolt.Tx) error {
+func putMeta(meta *Meta, tx kvdb.Tx) error {
 	metaBucket, err := tx.CreateBucketIfNotExists(metaBucket)
 	if err != nil {
 		return err
@@ -73,7 +73,7 @@ func putMeta(meta *Meta, tx *bolt.Tx) error {
 	return putDbVersion(metaBucket, meta)
 }
 
-func putDbVersion(metaBucket *bolt.Bucket, meta *Meta) error {
+func putDbVersion(metaBucket kvdb.Bucket, meta *Meta) error {
 	scratch := make([]byte, 4)
 	byteOrder.PutUint32(scratch, meta.DbVersionNumber)
 	return metaBucket.Put(dbVersionKey, scratch)
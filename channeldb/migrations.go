@@ -1 +1,149 @@
 package channeldb
+
+import (
+	"bytes"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+)
+
+// migrateEdgePolicyMaxHTLC is a migration function that appends a zero
+// max_htlc value to every channel edge policy within the database. Prior to
+// this version, edge policies didn't store the optional max_htlc field,
+// which is now always serialized after the rest of the policy.
+func migrateEdgePolicyMaxHTLC(tx kvdb.Tx) error {
+	edges := tx.Bucket(edgeBucket)
+	if edges == nil {
+		return nil
+	}
+
+	// First, we'll gather all the edge policies stored within the edge
+	// bucket. We can't modify the bucket while iterating over it, so we
+	// collect the updated values first.
+	var (
+		keys   [][]byte
+		values [][]byte
+	)
+	err := edges.ForEach(func(k, v []byte) error {
+		// Only the edge policies are keyed by the 33-byte node public
+		// key followed by the 8-byte channel ID. Nested buckets, such
+		// as the edge index, will have a nil value.
+		if v == nil || len(k) != 33+8 {
+			return nil
+		}
+
+		var b bytes.Buffer
+		b.Write(v)
+
+		var maxHTLC [8]byte
+		b.Write(maxHTLC[:])
+
+		keys = append(keys, append([]byte(nil), k...))
+		values = append(values, b.Bytes())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// With all the policies collected, we'll now write them back with the
+	// max_htlc field appended.
+	for i, k := range keys {
+		if err := edges.Put(k, values[i]); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Migration of edge policies to include max_htlc complete, "+
+		"%v policies updated", len(keys))
+
+	return nil
+}
+
+// migrateCompactRevocationLogs is a migration function that rewrites the
+// revocation log of every open channel into the compact format. Prior to this
+// version, each entry within the log stored the full revoked commitment,
+// including the commitment transaction, its signature and the onion blob of
+// every HTLC, while only the data required for retribution is now retained.
+func migrateCompactRevocationLogs(tx kvdb.Tx) error {
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return nil
+	}
+
+	// First, we'll gather the revocation log of every channel, which is
+	// nested under the channel's node and chain buckets.
+	var logBuckets []kvdb.Bucket
+	err := openChanBucket.ForEach(func(nodePub, v []byte) error {
+		if v != nil {
+			return nil
+		}
+		nodeBucket := openChanBucket.Bucket(nodePub)
+
+		return nodeBucket.ForEach(func(chainHash, v []byte) error {
+			if v != nil {
+				return nil
+			}
+			chainBucket := nodeBucket.Bucket(chainHash)
+
+			return chainBucket.ForEach(func(chanPoint, v []byte) error {
+				if v != nil {
+					return nil
+				}
+				chanBucket := chainBucket.Bucket(chanPoint)
+
+				logBucket := chanBucket.Bucket(revocationLogBucket)
+				if logBucket != nil {
+					logBuckets = append(logBuckets, logBucket)
+				}
+
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// With all logs found, we'll convert each of their entries. As we
+	// can't modify a bucket while iterating over it, the entries of each
+	// log are collected before being written back.
+	var numEntries int
+	for _, logBucket := range logBuckets {
+		var (
+			keys   [][]byte
+			values [][]byte
+		)
+		err := logBucket.ForEach(func(k, v []byte) error {
+			commit, err := deserializeChanCommit(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			entry := newRevocationLogEntry(&commit)
+			if err := serializeRevocationLogEntry(&b, entry); err != nil {
+				return err
+			}
+
+			keys = append(keys, append([]byte(nil), k...))
+			values = append(values, b.Bytes())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, k := range keys {
+			if err := logBucket.Put(k, values[i]); err != nil {
+				return err
+			}
+		}
+
+		numEntries += len(keys)
+	}
+
+	log.Infof("Migration of revocation logs to compact format complete, "+
+		"%v entries updated", numEntries)
+
+	return nil
+}
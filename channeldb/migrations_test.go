@@ -0,0 +1,109 @@
+package channeldb
+
+import (
+	"bytes"
+	prand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestMigrateEdgePolicyMaxHTLC tests that edge policies written without the
+// max_htlc field can be read back after the migration has been applied.
+func TestMigrateEdgePolicyMaxHTLC(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// We'll start by adding two nodes and a channel edge between them,
+	// along with a policy for the first direction of the edge.
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node1); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node2); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	firstNode, secondNode := node1, node2
+	if bytes.Compare(node2.PubKeyBytes[:], node1.PubKeyBytes[:]) == -1 {
+		firstNode, secondNode = node2, node1
+	}
+
+	chanID := uint64(prand.Int63())
+	edgeInfo := &ChannelEdgeInfo{
+		ChannelID:    chanID,
+		ChannelPoint: wire.OutPoint{Index: 1},
+		Capacity:     1000,
+	}
+	copy(edgeInfo.NodeKey1Bytes[:], firstNode.PubKeyBytes[:])
+	copy(edgeInfo.NodeKey2Bytes[:], secondNode.PubKeyBytes[:])
+	copy(edgeInfo.BitcoinKey1Bytes[:], firstNode.PubKeyBytes[:])
+	copy(edgeInfo.BitcoinKey2Bytes[:], secondNode.PubKeyBytes[:])
+	if err := graph.AddChannelEdge(edgeInfo); err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+
+	policy := &ChannelEdgePolicy{
+		SigBytes:                  testSig.Serialize(),
+		ChannelID:                 chanID,
+		LastUpdate:                time.Unix(433453, 0),
+		TimeLockDelta:             99,
+		MinHTLC:                   2342135,
+		FeeBaseMSat:               4352345,
+		FeeProportionalMillionths: 3452352,
+		Node:                      secondNode,
+		db:                        db,
+	}
+	if err := graph.UpdateEdgePolicy(policy); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+
+	// Next, we'll strip the max_htlc field from the stored policy in
+	// order to mimic a policy written by a prior version, then apply the
+	// migration.
+	err = db.Update(func(tx kvdb.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+
+		var edgeKey [33 + 8]byte
+		copy(edgeKey[:], firstNode.PubKeyBytes[:])
+		byteOrder.PutUint64(edgeKey[33:], chanID)
+
+		edgeBytes := edges.Get(edgeKey[:])
+		oldBytes := make([]byte, len(edgeBytes)-8)
+		copy(oldBytes, edgeBytes)
+		if err := edges.Put(edgeKey[:], oldBytes); err != nil {
+			return err
+		}
+
+		return migrateEdgePolicyMaxHTLC(tx)
+	})
+	if err != nil {
+		t.Fatalf("unable to migrate edge policies: %v", err)
+	}
+
+	// The policy should now be readable again, with all its fields
+	// intact.
+	_, dbPolicy, _, err := graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel edges: %v", err)
+	}
+	if err := compareEdgePolicies(dbPolicy, policy); err != nil {
+		t.Fatalf("policy mismatch after migration: %v", err)
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"net"
 	"time"
 
-	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/wire"
 )
@@ -103,7 +103,7 @@ func (l *LinkNode) Sync() error {
 
 	// Finally update the database by storing the link node and updating
 	// any relevant indexes.
-	return l.db.Update(func(tx *bolt.Tx) error {
+	return l.db.Update(func(tx kvdb.Tx) error {
 		nodeMetaBucket := tx.Bucket(nodeInfoBucket)
 		if nodeMetaBucket == nil {
 			return ErrLinkNodesNotFound
@@ -116,7 +116,7 @@ func (l *LinkNode) Sync() error {
 // putLinkNode serializes then writes the encoded version of the passed link
 // node into the nodeMetaBucket. This function is provided in order to allow
 // the ability to re-use a database transaction across many operations.
-func putLinkNode(nodeMetaBucket *bolt.Bucket, l *LinkNode) error {
+func putLinkNode(nodeMetaBucket kvdb.Bucket, l *LinkNode) error {
 	// First serialize the LinkNode into its raw-bytes encoding.
 	var b bytes.Buffer
 	if err := serializeLinkNode(&b, l); err != nil {
@@ -138,7 +138,7 @@ func (db *DB) FetchLinkNode(identity *btcec.PublicKey) (*LinkNode, error) {
 		err  error
 	)
 
-	err = db.View(func(tx *bolt.Tx) error {
+	err = db.View(func(tx kvdb.Tx) error {
 		// First fetch the bucket for storing node metadata, bailing
 		// out early if it hasn't been created yet.
 		nodeMetaBucket := tx.Bucket(nodeInfoBucket)
@@ -173,7 +173,7 @@ func (db *DB) FetchLinkNode(identity *btcec.PublicKey) (*LinkNode, error) {
 func (db *DB) FetchAllLinkNodes() ([]*LinkNode, error) {
 	var linkNodes []*LinkNode
 
-	err := db.View(func(tx *bolt.Tx) error {
+	err := db.View(func(tx kvdb.Tx) error {
 		nodeMetaBucket := tx.Bucket(nodeInfoBucket)
 		if nodeMetaBucket == nil {
 			return ErrLinkNodesNotFound
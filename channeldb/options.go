@@ -0,0 +1,24 @@
+package channeldb
+
+// Options holds parameters for tuning and customizing a channeldb.DB.
+type Options struct {
+	// NoGraphCache, if set, disables the in-memory cache of the channel
+	// graph, causing path finding to read the graph straight from the
+	// database instead.
+	NoGraphCache bool
+}
+
+// DefaultOptions returns an Options populated with default values.
+func DefaultOptions() Options {
+	return Options{}
+}
+
+// OptionModifier is a function signature for modifying the default Options.
+type OptionModifier func(*Options)
+
+// OptionNoGraphCache disables the in-memory cache of the channel graph.
+func OptionNoGraphCache() OptionModifier {
+	return func(o *Options) {
+		o.NoGraphCache = true
+	}
+}
@@ -0,0 +1,294 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// paymentControlBucket is the top-level bucket that tracks the status
+	// of each of our outgoing payments, keyed by payment hash. It's used
+	// to ensure that we never pay the same payment hash twice.
+	//
+	// maps: paymentHash -> status || attemptID || amount || preimage ||
+	//       failureReason
+	paymentControlBucket = []byte("payment-control")
+
+	// ErrPaymentInFlight is returned when attempting to initiate a payment
+	// whose payment hash already has an HTLC in flight.
+	ErrPaymentInFlight = errors.New("payment is in transition")
+
+	// ErrAlreadyPaid is returned when attempting to initiate a payment
+	// whose payment hash has already been paid successfully.
+	ErrAlreadyPaid = errors.New("payment is already completed")
+
+	// ErrPaymentNotInitiated is returned when no payment has been
+	// initiated for a payment hash.
+	ErrPaymentNotInitiated = errors.New("payment isn't initiated")
+
+	// ErrPaymentNotInFlight is returned when attempting to settle or fail
+	// a payment that doesn't have an HTLC in flight.
+	ErrPaymentNotInFlight = errors.New("payment isn't in flight")
+)
+
+// PaymentStatus represents the current status of an outgoing payment.
+type PaymentStatus byte
+
+const (
+	// StatusGrounded is the status of a payment that has never been
+	// initiated.
+	StatusGrounded PaymentStatus = 0
+
+	// StatusInFlight is the status of a payment that has an HTLC
+	// outstanding, whose fate is yet to be determined.
+	StatusInFlight PaymentStatus = 1
+
+	// StatusSucceeded is the status of a payment whose HTLC has been
+	// settled by the recipient.
+	StatusSucceeded PaymentStatus = 2
+
+	// StatusFailed is the status of a payment whose latest HTLC has been
+	// failed. Such a payment may be attempted again.
+	StatusFailed PaymentStatus = 3
+)
+
+// String returns a human readable version of the payment status.
+func (ps PaymentStatus) String() string {
+	switch ps {
+	case StatusGrounded:
+		return "Grounded"
+
+	case StatusInFlight:
+		return "In Flight"
+
+	case StatusSucceeded:
+		return "Succeeded"
+
+	case StatusFailed:
+		return "Failed"
+
+	default:
+		return fmt.Sprintf("Unknown(%d)", ps)
+	}
+}
+
+// PaymentAttempt tracks the status of an outgoing payment, along with the
+// latest HTLC sent for it.
+type PaymentAttempt struct {
+	// PaymentHash is the payment hash of the payment.
+	PaymentHash [32]byte
+
+	// Status is the current status of the payment.
+	Status PaymentStatus
+
+	// AttemptID is the identifier the htlcswitch assigned to the latest
+	// HTLC sent for the payment.
+	AttemptID uint64
+
+	// Amount is the amount of the latest HTLC sent for the payment,
+	// including fees.
+	Amount lnwire.MilliSatoshi
+
+	// Preimage is the preimage revealed by the recipient once the payment
+	// has succeeded.
+	Preimage [32]byte
+
+	// FailureReason describes why the latest HTLC sent for the payment
+	// failed, if it did.
+	FailureReason string
+}
+
+// InitPayment marks the payment identified by the passed payment hash as in
+// flight, recording the HTLC that's about to be sent for it. If the payment
+// already has an HTLC in flight, ErrPaymentInFlight is returned, while if it
+// has already succeeded, ErrAlreadyPaid is returned. Payments that have failed
+// may be initiated again.
+func (d *DB) InitPayment(paymentHash [32]byte, attemptID uint64,
+	amt lnwire.MilliSatoshi) error {
+
+	return d.Update(func(tx kvdb.Tx) error {
+		payments, err := tx.CreateBucketIfNotExists(
+			paymentControlBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		attempt, err := fetchPaymentAttempt(payments, paymentHash)
+		switch {
+		case err == ErrPaymentNotInitiated:
+
+		case err != nil:
+			return err
+
+		case attempt.Status == StatusInFlight:
+			return ErrPaymentInFlight
+
+		case attempt.Status == StatusSucceeded:
+			return ErrAlreadyPaid
+		}
+
+		return putPaymentAttempt(payments, &PaymentAttempt{
+			PaymentHash: paymentHash,
+			Status:      StatusInFlight,
+			AttemptID:   attemptID,
+			Amount:      amt,
+		})
+	})
+}
+
+// SucceedPayment marks the in flight payment identified by the passed payment
+// hash as succeeded, recording the preimage revealed by the recipient. If the
+// payment isn't in flight, ErrPaymentNotInFlight is returned.
+func (d *DB) SucceedPayment(paymentHash, preimage [32]byte) error {
+	return d.updatePaymentAttempt(paymentHash, func(attempt *PaymentAttempt) {
+		attempt.Status = StatusSucceeded
+		attempt.Preimage = preimage
+	})
+}
+
+// FailPayment marks the in flight payment identified by the passed payment
+// hash as failed for the passed reason, allowing it to be attempted again. If
+// the payment isn't in flight, ErrPaymentNotInFlight is returned.
+func (d *DB) FailPayment(paymentHash [32]byte, reason string) error {
+	return d.updatePaymentAttempt(paymentHash, func(attempt *PaymentAttempt) {
+		attempt.Status = StatusFailed
+		attempt.FailureReason = reason
+	})
+}
+
+// updatePaymentAttempt applies the passed modification to the in flight
+// payment identified by the passed payment hash.
+func (d *DB) updatePaymentAttempt(paymentHash [32]byte,
+	modify func(*PaymentAttempt)) error {
+
+	return d.Update(func(tx kvdb.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return ErrPaymentNotInFlight
+		}
+
+		attempt, err := fetchPaymentAttempt(payments, paymentHash)
+		switch {
+		case err == ErrPaymentNotInitiated:
+			return ErrPaymentNotInFlight
+
+		case err != nil:
+			return err
+
+		case attempt.Status != StatusInFlight:
+			return ErrPaymentNotInFlight
+		}
+
+		modify(attempt)
+
+		return putPaymentAttempt(payments, attempt)
+	})
+}
+
+// FetchPaymentAttempt returns the status of the payment identified by the
+// passed payment hash. If no payment has been initiated for it,
+// ErrPaymentNotInitiated is returned.
+func (d *DB) FetchPaymentAttempt(paymentHash [32]byte) (*PaymentAttempt, error) {
+	var attempt *PaymentAttempt
+	err := d.View(func(tx kvdb.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		var err error
+		attempt, err = fetchPaymentAttempt(payments, paymentHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// FetchInFlightPayments returns all payments that currently have an HTLC in
+// flight.
+func (d *DB) FetchInFlightPayments() ([]*PaymentAttempt, error) {
+	var inFlight []*PaymentAttempt
+	err := d.View(func(tx kvdb.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return nil
+		}
+
+		return payments.ForEach(func(k, v []byte) error {
+			var paymentHash [32]byte
+			copy(paymentHash[:], k)
+
+			attempt, err := deserializePaymentAttempt(
+				paymentHash, bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			if attempt.Status == StatusInFlight {
+				inFlight = append(inFlight, attempt)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inFlight, nil
+}
+
+func fetchPaymentAttempt(payments kvdb.Bucket,
+	paymentHash [32]byte) (*PaymentAttempt, error) {
+
+	v := payments.Get(paymentHash[:])
+	if v == nil {
+		return nil, ErrPaymentNotInitiated
+	}
+
+	return deserializePaymentAttempt(paymentHash, bytes.NewReader(v))
+}
+
+func putPaymentAttempt(payments kvdb.Bucket, attempt *PaymentAttempt) error {
+	var b bytes.Buffer
+	if err := serializePaymentAttempt(&b, attempt); err != nil {
+		return err
+	}
+
+	return payments.Put(attempt.PaymentHash[:], b.Bytes())
+}
+
+func serializePaymentAttempt(w io.Writer, attempt *PaymentAttempt) error {
+	return writeElements(w,
+		attempt.Status, attempt.AttemptID, attempt.Amount,
+		attempt.Preimage, []byte(attempt.FailureReason),
+	)
+}
+
+func deserializePaymentAttempt(paymentHash [32]byte,
+	r io.Reader) (*PaymentAttempt, error) {
+
+	var reason []byte
+	attempt := &PaymentAttempt{
+		PaymentHash: paymentHash,
+	}
+	err := readElements(r,
+		&attempt.Status, &attempt.AttemptID, &attempt.Amount,
+		&attempt.Preimage, &reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	attempt.FailureReason = string(reason)
+
+	return attempt, nil
+}
@@ -0,0 +1,101 @@
+package channeldb
+
+import (
+	"testing"
+)
+
+// TestPaymentControl asserts that the payment control state machine only
+// permits valid transitions, and that a payment hash can't be paid twice.
+func TestPaymentControl(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	var paymentHash, preimage [32]byte
+	paymentHash[0], preimage[0] = 1, 2
+
+	assertStatus := func(status PaymentStatus) *PaymentAttempt {
+		t.Helper()
+
+		attempt, err := cdb.FetchPaymentAttempt(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch payment attempt: %v", err)
+		}
+		if attempt.Status != status {
+			t.Fatalf("expected status %v, got %v", status,
+				attempt.Status)
+		}
+
+		return attempt
+	}
+
+	// A payment that was never initiated can't be settled or failed.
+	if _, err := cdb.FetchPaymentAttempt(paymentHash); err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+	if err := cdb.SucceedPayment(paymentHash, preimage); err != ErrPaymentNotInFlight {
+		t.Fatalf("expected ErrPaymentNotInFlight, got %v", err)
+	}
+	if err := cdb.FailPayment(paymentHash, "fail"); err != ErrPaymentNotInFlight {
+		t.Fatalf("expected ErrPaymentNotInFlight, got %v", err)
+	}
+
+	// Once initiated, the payment can't be initiated again while in
+	// flight.
+	if err := cdb.InitPayment(paymentHash, 1, 1000); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	assertStatus(StatusInFlight)
+	if err := cdb.InitPayment(paymentHash, 2, 1000); err != ErrPaymentInFlight {
+		t.Fatalf("expected ErrPaymentInFlight, got %v", err)
+	}
+
+	inFlight, err := cdb.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in flight payments: %v", err)
+	}
+	if len(inFlight) != 1 || inFlight[0].AttemptID != 1 {
+		t.Fatalf("unexpected in flight payments: %v", inFlight)
+	}
+
+	// A failed payment may be attempted again.
+	if err := cdb.FailPayment(paymentHash, "no route"); err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+	attempt := assertStatus(StatusFailed)
+	if attempt.FailureReason != "no route" {
+		t.Fatalf("unexpected failure reason: %v", attempt.FailureReason)
+	}
+	if err := cdb.FailPayment(paymentHash, "fail"); err != ErrPaymentNotInFlight {
+		t.Fatalf("expected ErrPaymentNotInFlight, got %v", err)
+	}
+	if err := cdb.InitPayment(paymentHash, 2, 1100); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	// Once it succeeds, the payment can't be initiated again.
+	if err := cdb.SucceedPayment(paymentHash, preimage); err != nil {
+		t.Fatalf("unable to succeed payment: %v", err)
+	}
+	attempt = assertStatus(StatusSucceeded)
+	if attempt.Preimage != preimage || attempt.AttemptID != 2 ||
+		attempt.Amount != 1100 {
+
+		t.Fatalf("unexpected payment attempt: %v", attempt)
+	}
+	if err := cdb.InitPayment(paymentHash, 3, 1000); err != ErrAlreadyPaid {
+		t.Fatalf("expected ErrAlreadyPaid, got %v", err)
+	}
+
+	inFlight, err = cdb.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in flight payments: %v", err)
+	}
+	if len(inFlight) != 0 {
+		t.Fatalf("expected no in flight payments, got %v", inFlight)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"encoding/binary"
 	"io"
 
-	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -60,7 +60,7 @@ func (db *DB) AddPayment(payment *OutgoingPayment) error {
 	}
 	paymentBytes := b.Bytes()
 
-	return db.Batch(func(tx *bolt.Tx) error {
+	return db.Batch(func(tx kvdb.Tx) error {
 		payments, err := tx.CreateBucketIfNotExists(paymentBucket)
 		if err != nil {
 			return err
@@ -86,7 +86,7 @@ func (db *DB) AddPayment(payment *OutgoingPayment) error {
 func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	var payments []*OutgoingPayment
 
-	err := db.View(func(tx *bolt.Tx) error {
+	err := db.View(func(tx kvdb.Tx) error {
 		bucket := tx.Bucket(paymentBucket)
 		if bucket == nil {
 			return ErrNoPaymentsCreated
@@ -118,9 +118,9 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 
 // DeleteAllPayments deletes all payments from DB.
 func (db *DB) DeleteAllPayments() error {
-	return db.Update(func(tx *bolt.Tx) error {
+	return db.Update(func(tx kvdb.Tx) error {
 		err := tx.DeleteBucket(paymentBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		if err != nil && err != kvdb.ErrBucketNotFound {
 			return err
 		}
 
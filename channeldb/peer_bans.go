@@ -0,0 +1,189 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+)
+
+var (
+	// peerBanBucket is the top-level bucket that stores the misbehavior
+	// score and ban status of Lightning peers, keyed by their public key.
+	//
+	// maps: pubKey -> score || bannedUntil || lastUpdate || reason
+	peerBanBucket = []byte("peer-bans")
+
+	// ErrPeerBanNotFound is returned when no misbehavior has been
+	// recorded for a peer.
+	ErrPeerBanNotFound = errors.New("peer ban not found")
+)
+
+// PeerBan tracks the misbehavior of a Lightning peer, such as sending invalid
+// gossip or violating the protocol. Once a peer's score crosses the ban
+// threshold it's banned until BannedUntil, during which we refuse any
+// connections with it.
+type PeerBan struct {
+	// PubKey is the identity public key of the peer.
+	PubKey [33]byte
+
+	// Score is the misbehavior score the peer has accumulated since it
+	// was last banned.
+	Score uint32
+
+	// BannedUntil is the time at which the peer's ban expires. It's the
+	// zero time if the peer has never been banned.
+	BannedUntil time.Time
+
+	// LastUpdate is the time at which the score or ban was last updated.
+	LastUpdate time.Time
+
+	// Reason describes the most recent misbehavior of the peer.
+	Reason string
+}
+
+// IsBanned returns true if the peer's ban hasn't expired at the passed time.
+func (p *PeerBan) IsBanned(now time.Time) bool {
+	return now.Before(p.BannedUntil)
+}
+
+// PutPeerBan stores the passed peer ban, overwriting any prior one for the
+// same peer.
+func (d *DB) PutPeerBan(ban *PeerBan) error {
+	return d.Update(func(tx kvdb.Tx) error {
+		bans, err := tx.CreateBucketIfNotExists(peerBanBucket)
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializePeerBan(&b, ban); err != nil {
+			return err
+		}
+
+		return bans.Put(ban.PubKey[:], b.Bytes())
+	})
+}
+
+// FetchPeerBan returns the ban record of the peer with the passed public key.
+// If no misbehavior has been recorded for the peer, ErrPeerBanNotFound is
+// returned.
+func (d *DB) FetchPeerBan(pubKey [33]byte) (*PeerBan, error) {
+	var ban *PeerBan
+	err := d.View(func(tx kvdb.Tx) error {
+		bans := tx.Bucket(peerBanBucket)
+		if bans == nil {
+			return ErrPeerBanNotFound
+		}
+
+		v := bans.Get(pubKey[:])
+		if v == nil {
+			return ErrPeerBanNotFound
+		}
+
+		var err error
+		ban, err = deserializePeerBan(pubKey, bytes.NewReader(v))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+// FetchPeerBans returns the ban records of all peers for which misbehavior
+// has been recorded, ordered by public key.
+func (d *DB) FetchPeerBans() ([]*PeerBan, error) {
+	var peerBans []*PeerBan
+	err := d.View(func(tx kvdb.Tx) error {
+		bans := tx.Bucket(peerBanBucket)
+		if bans == nil {
+			return nil
+		}
+
+		return bans.ForEach(func(k, v []byte) error {
+			var pubKey [33]byte
+			copy(pubKey[:], k)
+
+			ban, err := deserializePeerBan(pubKey, bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			peerBans = append(peerBans, ban)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return peerBans, nil
+}
+
+// DeletePeerBan removes the ban record of the peer with the passed public key,
+// lifting any active ban and clearing its score. If no misbehavior has been
+// recorded for the peer, ErrPeerBanNotFound is returned.
+func (d *DB) DeletePeerBan(pubKey [33]byte) error {
+	return d.Update(func(tx kvdb.Tx) error {
+		bans := tx.Bucket(peerBanBucket)
+		if bans == nil {
+			return ErrPeerBanNotFound
+		}
+
+		if bans.Get(pubKey[:]) == nil {
+			return ErrPeerBanNotFound
+		}
+
+		return bans.Delete(pubKey[:])
+	})
+}
+
+// unixOrZero returns the unix timestamp of the passed time, or zero if it's
+// the zero time.
+func unixOrZero(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return uint64(t.Unix())
+}
+
+// timeOrZero is the inverse of unixOrZero.
+func timeOrZero(unix uint64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(unix), 0)
+}
+
+func serializePeerBan(w io.Writer, ban *PeerBan) error {
+	return writeElements(w,
+		ban.Score, unixOrZero(ban.BannedUntil),
+		unixOrZero(ban.LastUpdate), []byte(ban.Reason),
+	)
+}
+
+func deserializePeerBan(pubKey [33]byte, r io.Reader) (*PeerBan, error) {
+	var (
+		bannedUntil, lastUpdate uint64
+		reason                  []byte
+	)
+	ban := &PeerBan{
+		PubKey: pubKey,
+	}
+	err := readElements(r, &ban.Score, &bannedUntil, &lastUpdate, &reason)
+	if err != nil {
+		return nil, err
+	}
+
+	ban.BannedUntil = timeOrZero(bannedUntil)
+	ban.LastUpdate = timeOrZero(lastUpdate)
+	ban.Reason = string(reason)
+
+	return ban, nil
+}
@@ -0,0 +1,107 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPeerBans tests that peer ban records can be stored, overwritten,
+// listed and deleted.
+func TestPeerBans(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	var pubKey1, pubKey2 [33]byte
+	pubKey1[0], pubKey2[0] = 2, 3
+
+	// Before any bans are added, none should be found.
+	if _, err := cdb.FetchPeerBan(pubKey1); err != ErrPeerBanNotFound {
+		t.Fatalf("expected ErrPeerBanNotFound, got %v", err)
+	}
+	if err := cdb.DeletePeerBan(pubKey1); err != ErrPeerBanNotFound {
+		t.Fatalf("expected ErrPeerBanNotFound, got %v", err)
+	}
+	bans, err := cdb.FetchPeerBans()
+	if err != nil {
+		t.Fatalf("unable to fetch bans: %v", err)
+	}
+	if len(bans) != 0 {
+		t.Fatalf("expected no bans, got %v", len(bans))
+	}
+
+	now := time.Unix(1500000000, 0)
+	scored := &PeerBan{
+		PubKey:     pubKey1,
+		Score:      10,
+		LastUpdate: now,
+		Reason:     "invalid channel update",
+	}
+	banned := &PeerBan{
+		PubKey:      pubKey2,
+		Score:       0,
+		BannedUntil: now.Add(time.Hour),
+		LastUpdate:  now,
+		Reason:      "invalid channel announcement",
+	}
+	for _, ban := range []*PeerBan{scored, banned} {
+		if err := cdb.PutPeerBan(ban); err != nil {
+			t.Fatalf("unable to put ban: %v", err)
+		}
+	}
+
+	// Overwriting a record should replace it.
+	scored.Score = 20
+	if err := cdb.PutPeerBan(scored); err != nil {
+		t.Fatalf("unable to put ban: %v", err)
+	}
+
+	assertBan := func(expected, ban *PeerBan) {
+		t.Helper()
+
+		if ban.PubKey != expected.PubKey || ban.Score != expected.Score ||
+			!ban.BannedUntil.Equal(expected.BannedUntil) ||
+			!ban.LastUpdate.Equal(expected.LastUpdate) ||
+			ban.Reason != expected.Reason {
+
+			t.Fatalf("expected ban %v, got %v", expected, ban)
+		}
+	}
+
+	ban, err := cdb.FetchPeerBan(pubKey1)
+	if err != nil {
+		t.Fatalf("unable to fetch ban: %v", err)
+	}
+	assertBan(scored, ban)
+	if ban.IsBanned(now) {
+		t.Fatal("peer without a ban reported as banned")
+	}
+
+	bans, err = cdb.FetchPeerBans()
+	if err != nil {
+		t.Fatalf("unable to fetch bans: %v", err)
+	}
+	if len(bans) != 2 {
+		t.Fatalf("expected 2 bans, got %v", len(bans))
+	}
+	assertBan(scored, bans[0])
+	assertBan(banned, bans[1])
+	if !bans[1].IsBanned(now) {
+		t.Fatal("banned peer not reported as banned")
+	}
+	if bans[1].IsBanned(now.Add(time.Hour)) {
+		t.Fatal("expired ban reported as banned")
+	}
+
+	// Finally, deleting a record should remove it.
+	if err := cdb.DeletePeerBan(pubKey2); err != nil {
+		t.Fatalf("unable to delete ban: %v", err)
+	}
+	if _, err := cdb.FetchPeerBan(pubKey2); err != ErrPeerBanNotFound {
+		t.Fatalf("expected ErrPeerBanNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+var (
+	// persistentPeerBucket is the top-level bucket that stores the peers
+	// the user asked us to always stay connected to, keyed by their
+	// public key.
+	//
+	// maps: pubKey -> numAddrs || addr_1 || ... || addr_n
+	persistentPeerBucket = []byte("persistent-peers")
+
+	// ErrPersistentPeerNotFound is returned when the target peer isn't
+	// marked as persistent.
+	ErrPersistentPeerNotFound = errors.New("persistent peer not found")
+)
+
+// PersistentPeer is a peer that the user asked us to maintain a connection
+// with regardless of whether we have any channels open with it. Connections
+// to persistent peers are re-established across restarts.
+type PersistentPeer struct {
+	// IdentityPub is the identity public key of the peer.
+	IdentityPub *btcec.PublicKey
+
+	// Addresses are the addresses the peer was last reached at, in
+	// addition to those known from its link node and node announcement.
+	Addresses []net.Addr
+}
+
+// AddPersistentPeer marks the passed peer as persistent. The passed addresses
+// are merged with any that were previously stored for the peer.
+func (d *DB) AddPersistentPeer(pub *btcec.PublicKey, addrs []net.Addr) error {
+	return d.Update(func(tx kvdb.Tx) error {
+		peers, err := tx.CreateBucketIfNotExists(persistentPeerBucket)
+		if err != nil {
+			return err
+		}
+
+		peer := &PersistentPeer{
+			IdentityPub: pub,
+		}
+
+		key := pub.SerializeCompressed()
+		if v := peers.Get(key); v != nil {
+			peer, err = deserializePersistentPeer(
+				pub, bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, addr := range addrs {
+			// Only TCP addresses can be stored, so we'll skip any
+			// others.
+			if _, ok := addr.(*net.TCPAddr); !ok {
+				continue
+			}
+
+			var known bool
+			for _, a := range peer.Addresses {
+				if a.String() == addr.String() {
+					known = true
+					break
+				}
+			}
+			if !known {
+				peer.Addresses = append(peer.Addresses, addr)
+			}
+		}
+
+		var b bytes.Buffer
+		if err := serializePersistentPeer(&b, peer); err != nil {
+			return err
+		}
+
+		return peers.Put(key, b.Bytes())
+	})
+}
+
+// FetchPersistentPeers returns all peers that are marked as persistent.
+func (d *DB) FetchPersistentPeers() ([]*PersistentPeer, error) {
+	var persistentPeers []*PersistentPeer
+	err := d.View(func(tx kvdb.Tx) error {
+		peers := tx.Bucket(persistentPeerBucket)
+		if peers == nil {
+			return nil
+		}
+
+		return peers.ForEach(func(k, v []byte) error {
+			pub, err := btcec.ParsePubKey(k, btcec.S256())
+			if err != nil {
+				return err
+			}
+
+			peer, err := deserializePersistentPeer(
+				pub, bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			persistentPeers = append(persistentPeers, peer)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return persistentPeers, nil
+}
+
+// DeletePersistentPeer stops treating the passed peer as persistent. If the
+// peer isn't marked as persistent, ErrPersistentPeerNotFound is returned.
+func (d *DB) DeletePersistentPeer(pub *btcec.PublicKey) error {
+	return d.Update(func(tx kvdb.Tx) error {
+		peers := tx.Bucket(persistentPeerBucket)
+		if peers == nil {
+			return ErrPersistentPeerNotFound
+		}
+
+		key := pub.SerializeCompressed()
+		if peers.Get(key) == nil {
+			return ErrPersistentPeerNotFound
+		}
+
+		return peers.Delete(key)
+	})
+}
+
+func serializePersistentPeer(w io.Writer, peer *PersistentPeer) error {
+	numAddrs := uint32(len(peer.Addresses))
+	if err := writeElements(w, numAddrs); err != nil {
+		return err
+	}
+
+	for _, addr := range peer.Addresses {
+		if err := serializeAddr(w, addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializePersistentPeer(pub *btcec.PublicKey,
+	r io.Reader) (*PersistentPeer, error) {
+
+	var numAddrs uint32
+	if err := readElements(r, &numAddrs); err != nil {
+		return nil, err
+	}
+
+	peer := &PersistentPeer{
+		IdentityPub: pub,
+		Addresses:   make([]net.Addr, numAddrs),
+	}
+	for i := uint32(0); i < numAddrs; i++ {
+		addr, err := deserializeAddr(r)
+		if err != nil {
+			return nil, err
+		}
+		peer.Addresses[i] = addr
+	}
+
+	return peer, nil
+}
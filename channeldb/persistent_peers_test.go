@@ -0,0 +1,82 @@
+package channeldb
+
+import (
+	"net"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestPersistentPeers tests that persistent peers can be added, have their
+// addresses merged, be listed and be deleted.
+func TestPersistentPeers(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), key[:])
+	addr1, err := net.ResolveTCPAddr("tcp", "10.0.0.1:9735")
+	if err != nil {
+		t.Fatalf("unable to create test addr: %v", err)
+	}
+	addr2, err := net.ResolveTCPAddr("tcp", "10.0.0.2:9735")
+	if err != nil {
+		t.Fatalf("unable to create test addr: %v", err)
+	}
+
+	// Before any peers are added, none should be found.
+	peers, err := cdb.FetchPersistentPeers()
+	if err != nil {
+		t.Fatalf("unable to fetch persistent peers: %v", err)
+	}
+	if len(peers) != 0 {
+		t.Fatalf("expected no persistent peers, got %v", len(peers))
+	}
+	if err := cdb.DeletePersistentPeer(pub); err != ErrPersistentPeerNotFound {
+		t.Fatalf("expected ErrPersistentPeerNotFound, got %v", err)
+	}
+
+	// Adding the same peer twice should merge its addresses, without
+	// storing duplicates.
+	if err := cdb.AddPersistentPeer(pub, []net.Addr{addr1}); err != nil {
+		t.Fatalf("unable to add persistent peer: %v", err)
+	}
+	err = cdb.AddPersistentPeer(pub, []net.Addr{addr1, addr2})
+	if err != nil {
+		t.Fatalf("unable to add persistent peer: %v", err)
+	}
+
+	peers, err = cdb.FetchPersistentPeers()
+	if err != nil {
+		t.Fatalf("unable to fetch persistent peers: %v", err)
+	}
+	if len(peers) != 1 {
+		t.Fatalf("expected 1 persistent peer, got %v", len(peers))
+	}
+	if !peers[0].IdentityPub.IsEqual(pub) {
+		t.Fatalf("unexpected persistent peer %x",
+			peers[0].IdentityPub.SerializeCompressed())
+	}
+	if len(peers[0].Addresses) != 2 ||
+		peers[0].Addresses[0].String() != addr1.String() ||
+		peers[0].Addresses[1].String() != addr2.String() {
+
+		t.Fatalf("unexpected addresses: %v", peers[0].Addresses)
+	}
+
+	// Finally, deleting the peer should remove it.
+	if err := cdb.DeletePersistentPeer(pub); err != nil {
+		t.Fatalf("unable to delete persistent peer: %v", err)
+	}
+	peers, err = cdb.FetchPersistentPeers()
+	if err != nil {
+		t.Fatalf("unable to fetch persistent peers: %v", err)
+	}
+	if len(peers) != 0 {
+		t.Fatalf("expected no persistent peers, got %v", len(peers))
+	}
+}
@@ -0,0 +1,215 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// closeReportsBucket is the top-level bucket that stores the reports
+	// of all outputs resolved on-chain after a channel was closed. It's
+	// further subdivided into a bucket per channel, keyed by the channel
+	// point, within which each report is keyed by the resolved outpoint.
+	closeReportsBucket = []byte("close-reports")
+)
+
+// ResolverType describes the type of output a resolver resolved.
+type ResolverType uint8
+
+const (
+	// ResolverTypeCommit is the output paying to us on a commitment
+	// transaction.
+	ResolverTypeCommit ResolverType = 0
+
+	// ResolverTypeIncomingHtlc is an HTLC offered to us.
+	ResolverTypeIncomingHtlc ResolverType = 1
+
+	// ResolverTypeOutgoingHtlc is an HTLC we offered.
+	ResolverTypeOutgoingHtlc ResolverType = 2
+)
+
+// String returns a human readable description of the resolver type.
+func (r ResolverType) String() string {
+	switch r {
+	case ResolverTypeCommit:
+		return "Commit"
+	case ResolverTypeIncomingHtlc:
+		return "IncomingHtlc"
+	case ResolverTypeOutgoingHtlc:
+		return "OutgoingHtlc"
+	default:
+		return "Unknown"
+	}
+}
+
+// ResolverOutcome describes how an output was resolved.
+type ResolverOutcome uint8
+
+const (
+	// ResolverOutcomeClaimed indicates that the output was claimed
+	// on-chain. For commitment outputs, we've swept it into our wallet,
+	// while for HTLCs, it was claimed using the preimage, either by us or
+	// by the remote party.
+	ResolverOutcomeClaimed ResolverOutcome = 0
+
+	// ResolverOutcomeTimeout indicates that we reclaimed an outgoing HTLC
+	// once it timed out.
+	ResolverOutcomeTimeout ResolverOutcome = 1
+
+	// ResolverOutcomeAbandoned indicates that an incoming HTLC timed out
+	// before we learned of its preimage, leaving it to the remote party.
+	ResolverOutcomeAbandoned ResolverOutcome = 2
+)
+
+// String returns a human readable description of the resolver outcome.
+func (r ResolverOutcome) String() string {
+	switch r {
+	case ResolverOutcomeClaimed:
+		return "Claimed"
+	case ResolverOutcomeTimeout:
+		return "Timeout"
+	case ResolverOutcomeAbandoned:
+		return "Abandoned"
+	default:
+		return "Unknown"
+	}
+}
+
+// ResolverReport describes the on-chain resolution of a single output of a
+// closed channel's commitment transaction.
+type ResolverReport struct {
+	// OutPoint is the output on the commitment transaction that was
+	// resolved.
+	OutPoint wire.OutPoint
+
+	// Amount is the value of the resolved output.
+	Amount btcutil.Amount
+
+	// ResolverType is the type of the resolved output.
+	ResolverType ResolverType
+
+	// ResolverOutcome describes how the output was resolved.
+	ResolverOutcome ResolverOutcome
+
+	// SpendTxID is the transaction that finally resolved the output, or
+	// nil if the output was abandoned.
+	SpendTxID *chainhash.Hash
+}
+
+// PutResolverReport records the resolution of an output of the commitment
+// transaction of the channel identified by the passed channel point. Any
+// prior report for the same output is overwritten.
+func (d *DB) PutResolverReport(chanPoint *wire.OutPoint,
+	report *ResolverReport) error {
+
+	return d.Update(func(tx kvdb.Tx) error {
+		reportsBucket, err := tx.CreateBucketIfNotExists(
+			closeReportsBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var chanPointBuf bytes.Buffer
+		if err := writeOutpoint(&chanPointBuf, chanPoint); err != nil {
+			return err
+		}
+		chanBucket, err := reportsBucket.CreateBucketIfNotExists(
+			chanPointBuf.Bytes(),
+		)
+		if err != nil {
+			return err
+		}
+
+		var outPointBuf bytes.Buffer
+		err = writeOutpoint(&outPointBuf, &report.OutPoint)
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializeResolverReport(&b, report); err != nil {
+			return err
+		}
+
+		return chanBucket.Put(outPointBuf.Bytes(), b.Bytes())
+	})
+}
+
+// FetchResolverReports returns the reports of all outputs resolved on-chain
+// for the channel identified by the passed channel point, ordered by
+// outpoint.
+func (d *DB) FetchResolverReports(chanPoint *wire.OutPoint) (
+	[]*ResolverReport, error) {
+
+	var reports []*ResolverReport
+	err := d.View(func(tx kvdb.Tx) error {
+		reportsBucket := tx.Bucket(closeReportsBucket)
+		if reportsBucket == nil {
+			return nil
+		}
+
+		var chanPointBuf bytes.Buffer
+		if err := writeOutpoint(&chanPointBuf, chanPoint); err != nil {
+			return err
+		}
+		chanBucket := reportsBucket.Bucket(chanPointBuf.Bytes())
+		if chanBucket == nil {
+			return nil
+		}
+
+		return chanBucket.ForEach(func(_, v []byte) error {
+			report, err := deserializeResolverReport(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			reports = append(reports, report)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func serializeResolverReport(w io.Writer, r *ResolverReport) error {
+	var spendTxID chainhash.Hash
+	if r.SpendTxID != nil {
+		spendTxID = *r.SpendTxID
+	}
+
+	return writeElements(w,
+		r.OutPoint, r.Amount, r.ResolverType, r.ResolverOutcome,
+		spendTxID,
+	)
+}
+
+func deserializeResolverReport(r io.Reader) (*ResolverReport, error) {
+	var (
+		report    ResolverReport
+		spendTxID chainhash.Hash
+	)
+	err := readElements(r,
+		&report.OutPoint, &report.Amount, &report.ResolverType,
+		&report.ResolverOutcome, &spendTxID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if spendTxID != (chainhash.Hash{}) {
+		report.SpendTxID = &spendTxID
+	}
+
+	return &report, nil
+}
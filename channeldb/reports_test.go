@@ -0,0 +1,97 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestResolverReports tests that resolver reports are stored per channel, and
+// that a report for an output that's already been reported overwrites the
+// prior one.
+func TestResolverReports(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	chanPoint1 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	chanPoint2 := wire.OutPoint{Hash: chainhash.Hash{2}, Index: 1}
+
+	// Before any reports are added, none should be found.
+	reports, err := cdb.FetchResolverReports(&chanPoint1)
+	if err != nil {
+		t.Fatalf("unable to fetch reports: %v", err)
+	}
+	if len(reports) != 0 {
+		t.Fatalf("expected no reports, got %v", len(reports))
+	}
+
+	spendTxID := chainhash.Hash{3}
+	commitReport := &ResolverReport{
+		OutPoint:        wire.OutPoint{Hash: chainhash.Hash{4}, Index: 0},
+		Amount:          1000,
+		ResolverType:    ResolverTypeCommit,
+		ResolverOutcome: ResolverOutcomeClaimed,
+		SpendTxID:       &spendTxID,
+	}
+	htlcReport := &ResolverReport{
+		OutPoint:        wire.OutPoint{Hash: chainhash.Hash{4}, Index: 1},
+		Amount:          500,
+		ResolverType:    ResolverTypeIncomingHtlc,
+		ResolverOutcome: ResolverOutcomeAbandoned,
+	}
+	otherReport := &ResolverReport{
+		OutPoint:        wire.OutPoint{Hash: chainhash.Hash{5}, Index: 0},
+		Amount:          2000,
+		ResolverType:    ResolverTypeOutgoingHtlc,
+		ResolverOutcome: ResolverOutcomeTimeout,
+		SpendTxID:       &spendTxID,
+	}
+
+	// The first report will be added twice, with its amount modified in
+	// between, to ensure that it's overwritten.
+	commitReport.Amount = 999
+	if err := cdb.PutResolverReport(&chanPoint1, commitReport); err != nil {
+		t.Fatalf("unable to add report: %v", err)
+	}
+	commitReport.Amount = 1000
+
+	puts := []struct {
+		chanPoint *wire.OutPoint
+		report    *ResolverReport
+	}{
+		{&chanPoint1, commitReport},
+		{&chanPoint1, htlcReport},
+		{&chanPoint2, otherReport},
+	}
+	for _, put := range puts {
+		err := cdb.PutResolverReport(put.chanPoint, put.report)
+		if err != nil {
+			t.Fatalf("unable to add report: %v", err)
+		}
+	}
+
+	assertReports := func(chanPoint *wire.OutPoint,
+		expected []*ResolverReport) {
+
+		reports, err := cdb.FetchResolverReports(chanPoint)
+		if err != nil {
+			t.Fatalf("unable to fetch reports: %v", err)
+		}
+		if !reflect.DeepEqual(reports, expected) {
+			t.Fatalf("reports for %v don't match: expected %v, "+
+				"got %v", chanPoint, spew.Sdump(expected),
+				spew.Sdump(reports))
+		}
+	}
+
+	assertReports(&chanPoint1, []*ResolverReport{commitReport, htlcReport})
+	assertReports(&chanPoint2, []*ResolverReport{otherReport})
+}
@@ -7,8 +7,8 @@ import (
 
 	"bytes"
 
-	"github.com/coreos/bbolt"
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -60,7 +60,7 @@ func (s *WaitingProofStore) Add(proof *WaitingProof) error {
 		return ErrWaitingProofAlreadyExist
 	}
 
-	return s.db.Batch(func(tx *bolt.Tx) error {
+	return s.db.Batch(func(tx kvdb.Tx) error {
 		var err error
 		var b bytes.Buffer
 
@@ -91,7 +91,7 @@ func (s *WaitingProofStore) Remove(key WaitingProofKey) error {
 		return ErrWaitingProofNotFound
 	}
 
-	return s.db.Batch(func(tx *bolt.Tx) error {
+	return s.db.Batch(func(tx kvdb.Tx) error {
 		// Get or create the top bucket.
 		bucket := tx.Bucket(waitingProofsBucketKey)
 		if bucket == nil {
@@ -110,7 +110,7 @@ func (s *WaitingProofStore) Remove(key WaitingProofKey) error {
 // ForAll iterates thought all waiting proofs and passing the waiting proof
 // in the given callback.
 func (s *WaitingProofStore) ForAll(cb func(*WaitingProof) error) error {
-	return s.db.View(func(tx *bolt.Tx) error {
+	return s.db.View(func(tx kvdb.Tx) error {
 		bucket := tx.Bucket(waitingProofsBucketKey)
 		if bucket == nil {
 			return ErrWaitingProofNotFound
@@ -142,7 +142,7 @@ func (s *WaitingProofStore) Get(key WaitingProofKey) (*WaitingProof, error) {
 		return nil, ErrWaitingProofNotFound
 	}
 
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx kvdb.Tx) error {
 		bucket := tx.Bucket(waitingProofsBucketKey)
 		if bucket == nil {
 			return ErrWaitingProofNotFound
@@ -0,0 +1,28 @@
+package clock
+
+import (
+	"time"
+)
+
+// DefaultClock implements Clock interface by simply calling the appropriate
+// time functions.
+type DefaultClock struct{}
+
+// A compile time check to ensure DefaultClock implements the Clock interface.
+var _ Clock = (*DefaultClock)(nil)
+
+// NewDefaultClock constructs a new DefaultClock.
+func NewDefaultClock() Clock {
+	return &DefaultClock{}
+}
+
+// Now returns the current local time.
+func (DefaultClock) Now() time.Time {
+	return time.Now()
+}
+
+// TickAfter returns a channel that will receive a tick after the specified
+// duration has passed.
+func (DefaultClock) TickAfter(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
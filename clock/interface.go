@@ -0,0 +1,17 @@
+package clock
+
+import (
+	"time"
+)
+
+// Clock is an interface that provides a time function for LND packages. This
+// is useful during testing when a concrete time reference is needed, and
+// allows tests to advance time explicitly rather than waiting for it to pass.
+type Clock interface {
+	// Now returns the current local time (as defined by the Clock).
+	Now() time.Time
+
+	// TickAfter returns a channel that will receive a tick after the
+	// specified duration has passed.
+	TickAfter(duration time.Duration) <-chan time.Time
+}
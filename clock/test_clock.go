@@ -0,0 +1,84 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// TestClock can be used in tests to mock time. Time only moves forward when
+// SetTime is called, at which point any tickers that have expired are fired.
+type TestClock struct {
+	currentTime time.Time
+	timeChanMap map[time.Time][]chan time.Time
+	timeLock    sync.Mutex
+}
+
+// A compile time check to ensure TestClock implements the Clock interface.
+var _ Clock = (*TestClock)(nil)
+
+// NewTestClock returns a new test clock, starting at the passed time.
+func NewTestClock(startTime time.Time) *TestClock {
+	return &TestClock{
+		currentTime: startTime,
+		timeChanMap: make(map[time.Time][]chan time.Time),
+	}
+}
+
+// Now returns the current (test) time.
+func (c *TestClock) Now() time.Time {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+
+	return c.currentTime
+}
+
+// TickAfter returns a channel that will receive a tick once the test time has
+// been advanced by at least the specified duration. A non-positive duration
+// results in an immediate tick.
+func (c *TestClock) TickAfter(duration time.Duration) <-chan time.Time {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+
+	triggerTime := c.currentTime.Add(duration)
+	ch := make(chan time.Time, 1)
+
+	// If the trigger time is not in the future, we'll tick right away.
+	if !triggerTime.After(c.currentTime) {
+		ch <- c.currentTime
+		return ch
+	}
+
+	c.timeChanMap[triggerTime] = append(c.timeChanMap[triggerTime], ch)
+
+	return ch
+}
+
+// SetTime sets the (test) time and fires all tickers that have expired as a
+// result.
+func (c *TestClock) SetTime(now time.Time) {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+
+	c.currentTime = now
+	for triggerTime, chans := range c.timeChanMap {
+		if triggerTime.After(now) {
+			continue
+		}
+
+		// The channels are buffered, so sending to them never blocks.
+		for _, ch := range chans {
+			ch <- now
+		}
+		delete(c.timeChanMap, triggerTime)
+	}
+}
+
+// Advance moves the (test) time forward by the passed duration, firing all
+// tickers that have expired as a result.
+func (c *TestClock) Advance(d time.Duration) {
+	c.timeLock.Lock()
+	now := c.currentTime.Add(d)
+	c.timeLock.Unlock()
+
+	c.SetTime(now)
+}
@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var testTime = time.Date(2009, time.January, 3, 12, 0, 0, 0, time.UTC)
+
+// TestNow asserts that the test clock only moves when told to.
+func TestNow(t *testing.T) {
+	c := NewTestClock(testTime)
+
+	if now := c.Now(); !now.Equal(testTime) {
+		t.Fatalf("expected time %v, got %v", testTime, now)
+	}
+
+	c.Advance(time.Hour)
+	if now := c.Now(); !now.Equal(testTime.Add(time.Hour)) {
+		t.Fatalf("expected time %v, got %v", testTime.Add(time.Hour),
+			now)
+	}
+
+	c.SetTime(testTime)
+	if now := c.Now(); !now.Equal(testTime) {
+		t.Fatalf("expected time %v, got %v", testTime, now)
+	}
+}
+
+// TestTickAfter asserts that the tickers of the test clock only fire once
+// the test time has been advanced past their trigger time.
+func TestTickAfter(t *testing.T) {
+	c := NewTestClock(testTime)
+
+	expectTick := func(ch <-chan time.Time, expect bool) {
+		t.Helper()
+
+		select {
+		case <-ch:
+			if !expect {
+				t.Fatalf("unexpected tick")
+			}
+		default:
+			if expect {
+				t.Fatalf("expected tick")
+			}
+		}
+	}
+
+	// A ticker without a duration should fire right away.
+	expectTick(c.TickAfter(0), true)
+
+	ticker1 := c.TickAfter(time.Minute)
+	ticker2 := c.TickAfter(time.Hour)
+	expectTick(ticker1, false)
+	expectTick(ticker2, false)
+
+	c.Advance(time.Second * 59)
+	expectTick(ticker1, false)
+	expectTick(ticker2, false)
+
+	c.Advance(time.Second)
+	expectTick(ticker1, true)
+	expectTick(ticker2, false)
+
+	c.SetTime(testTime.Add(time.Hour * 2))
+	expectTick(ticker2, true)
+}
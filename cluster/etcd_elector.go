@@ -0,0 +1,141 @@
+// +build kvdb_etcd
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/concurrency"
+	"github.com/coreos/etcd/pkg/transport"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+)
+
+const (
+	// etcdElectionPrefix is the key prefix, within the configured
+	// namespace, under which the election is held.
+	etcdElectionPrefix = "leader/"
+)
+
+// etcdLeaderElector is a LeaderElector using etcd's lease based election.
+// The leader keeps its lease alive for as long as it's running, so once it
+// fails, its lease expires after the configured TTL and one of the followers
+// is elected in its place.
+type etcdLeaderElector struct {
+	id       string
+	cli      *clientv3.Client
+	session  *concurrency.Session
+	election *concurrency.Election
+}
+
+// A compile-time check to ensure etcdLeaderElector implements the
+// LeaderElector interface.
+var _ LeaderElector = (*etcdLeaderElector)(nil)
+
+// NewEtcdLeaderElector creates a new LeaderElector identifying this instance
+// by the passed ID, holding the election within the etcd cluster described by
+// the passed config. The leader's lease expires once it hasn't been refreshed
+// for ttl seconds.
+func NewEtcdLeaderElector(ctx context.Context, id string, ttl int,
+	cfg *kvdb.EtcdConfig) (LeaderElector, error) {
+
+	clientCfg := clientv3.Config{
+		Context:   ctx,
+		Endpoints: []string{cfg.Host},
+		Username:  cfg.User,
+		Password:  cfg.Pass,
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		tlsInfo := transport.TLSInfo{
+			CertFile:           cfg.CertFile,
+			KeyFile:            cfg.KeyFile,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientCfg.TLS = tlsConfig
+	}
+
+	cli, err := clientv3.New(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := concurrency.NewSession(
+		cli, concurrency.WithTTL(ttl),
+	)
+	if err != nil {
+		cli.Close()
+		return nil, err
+	}
+
+	election := concurrency.NewElection(
+		session, cfg.Namespace+etcdElectionPrefix,
+	)
+
+	return &etcdLeaderElector{
+		id:       id,
+		cli:      cli,
+		session:  session,
+		election: election,
+	}, nil
+}
+
+// Campaign blocks until this instance is elected leader, or the passed
+// context is cancelled.
+//
+// NOTE: Part of the LeaderElector interface.
+func (e *etcdLeaderElector) Campaign(ctx context.Context) error {
+	return e.election.Campaign(ctx, e.id)
+}
+
+// Resign gives up leadership, allowing another instance to be elected.
+//
+// NOTE: Part of the LeaderElector interface.
+func (e *etcdLeaderElector) Resign() error {
+	return e.election.Resign(e.cli.Ctx())
+}
+
+// Leader returns the ID of the current leader.
+//
+// NOTE: Part of the LeaderElector interface.
+func (e *etcdLeaderElector) Leader(ctx context.Context) (string, error) {
+	resp, err := e.election.Leader(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return string(resp.Kvs[0].Value), nil
+}
+
+// LeaderKey returns the election key held by this instance, along with its
+// creation revision.
+//
+// NOTE: Part of the LeaderElector interface.
+func (e *etcdLeaderElector) LeaderKey() (string, int64) {
+	return e.election.Key(), e.election.Rev()
+}
+
+// Done returns a channel that's closed once this instance's lease has been
+// lost.
+//
+// NOTE: Part of the LeaderElector interface.
+func (e *etcdLeaderElector) Done() <-chan struct{} {
+	return e.session.Done()
+}
+
+// Close revokes this instance's lease, resigning if elected, and closes the
+// connection to etcd.
+//
+// NOTE: Part of the LeaderElector interface.
+func (e *etcdLeaderElector) Close() error {
+	if err := e.session.Close(); err != nil {
+		e.cli.Close()
+		return err
+	}
+
+	return e.cli.Close()
+}
@@ -0,0 +1,17 @@
+// +build !kvdb_etcd
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+)
+
+// NewEtcdLeaderElector returns kvdb.ErrEtcdNotAvailable, as this binary was
+// built without etcd support.
+func NewEtcdLeaderElector(ctx context.Context, id string, ttl int,
+	cfg *kvdb.EtcdConfig) (LeaderElector, error) {
+
+	return nil, kvdb.ErrEtcdNotAvailable
+}
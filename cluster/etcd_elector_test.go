@@ -0,0 +1,224 @@
+// +build kvdb_etcd
+
+package cluster
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+)
+
+const (
+	// testElectorTTL is the lease TTL, in seconds, used by the electors
+	// created within tests.
+	testElectorTTL = 2
+
+	// testElectionTimeout is the maximum duration we'll wait for an
+	// election to be won within tests.
+	testElectionTimeout = 10 * time.Second
+)
+
+// startTestEtcd starts an embedded etcd server for the duration of the test,
+// returning the config required to connect to it.
+func startTestEtcd(t *testing.T) (*kvdb.EtcdConfig, func()) {
+	t.Helper()
+
+	tempDir, err := ioutil.TempDir("", "etcdelector")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	cfg, stopEtcd, err := kvdb.StartTestEtcd(tempDir, "cluster")
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to start etcd: %v", err)
+	}
+	cfg.Namespace = "lnd/"
+
+	cleanUp := func() {
+		stopEtcd()
+		os.RemoveAll(tempDir)
+	}
+
+	return cfg, cleanUp
+}
+
+// newTestElector creates a new elector identified by the passed ID, holding
+// the election within the passed etcd cluster.
+func newTestElector(t *testing.T, id string,
+	cfg *kvdb.EtcdConfig) LeaderElector {
+
+	t.Helper()
+
+	elector, err := NewEtcdLeaderElector(
+		context.Background(), id, testElectorTTL, cfg,
+	)
+	if err != nil {
+		t.Fatalf("unable to create elector %v: %v", id, err)
+	}
+
+	return elector
+}
+
+// campaign campaigns for leadership in the background, returning a channel
+// that's sent the result once the campaign completes.
+func campaign(elector LeaderElector) <-chan error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- elector.Campaign(context.Background())
+	}()
+
+	return errChan
+}
+
+// assertElected asserts that the campaign behind the passed channel is won
+// in time.
+func assertElected(t *testing.T, errChan <-chan error) {
+	t.Helper()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("unable to campaign: %v", err)
+		}
+	case <-time.After(testElectionTimeout):
+		t.Fatalf("elector not elected in time")
+	}
+}
+
+// assertLeader asserts that the passed elector sees the instance with the
+// given ID as the leader.
+func assertLeader(t *testing.T, elector LeaderElector, id string) {
+	t.Helper()
+
+	leader, err := elector.Leader(context.Background())
+	if err != nil {
+		t.Fatalf("unable to fetch leader: %v", err)
+	}
+	if leader != id {
+		t.Fatalf("expected leader %v, got %v", id, leader)
+	}
+}
+
+// TestEtcdLeaderElection tests that only a single instance is elected leader
+// at a time, and that a follower takes over once the leader steps down.
+func TestEtcdLeaderElection(t *testing.T) {
+	cfg, cleanUp := startTestEtcd(t)
+	defer cleanUp()
+
+	alice := newTestElector(t, "alice", cfg)
+	defer alice.Close()
+
+	bob := newTestElector(t, "bob", cfg)
+	defer bob.Close()
+
+	// Alice campaigns first, so she should be elected leader.
+	assertElected(t, campaign(alice))
+	assertLeader(t, alice, "alice")
+	assertLeader(t, bob, "alice")
+
+	// Bob's campaign must block for as long as Alice remains the leader.
+	bobElected := campaign(bob)
+	select {
+	case err := <-bobElected:
+		t.Fatalf("bob elected while alice is leader: %v", err)
+	case <-time.After(time.Second):
+	}
+
+	// Once Alice resigns, Bob should take over.
+	if err := alice.Resign(); err != nil {
+		t.Fatalf("unable to resign: %v", err)
+	}
+	assertElected(t, bobElected)
+	assertLeader(t, alice, "bob")
+	assertLeader(t, bob, "bob")
+
+	// Alice campaigns again, and should only be elected once Bob's lease
+	// has been revoked.
+	aliceElected := campaign(alice)
+	select {
+	case err := <-aliceElected:
+		t.Fatalf("alice elected while bob is leader: %v", err)
+	case <-time.After(time.Second):
+	}
+
+	if err := bob.Close(); err != nil {
+		t.Fatalf("unable to close elector: %v", err)
+	}
+	assertElected(t, aliceElected)
+	assertLeader(t, alice, "alice")
+}
+
+// TestEtcdLeaderFencing tests that writes to a backend fenced on the leader
+// key fail once the instance has lost its leadership.
+func TestEtcdLeaderFencing(t *testing.T) {
+	cfg, cleanUp := startTestEtcd(t)
+	defer cleanUp()
+
+	alice := newTestElector(t, "alice", cfg)
+	defer alice.Close()
+
+	assertElected(t, campaign(alice))
+
+	db, err := kvdb.OpenEtcd(cfg)
+	if err != nil {
+		t.Fatalf("unable to open backend: %v", err)
+	}
+	defer db.Close()
+
+	fencer, ok := db.(kvdb.LeaderFencer)
+	if !ok {
+		t.Fatalf("etcd backend doesn't support fencing")
+	}
+	fencer.FenceWrites(alice.LeaderKey())
+
+	bucketKey := []byte("bucket")
+	put := func(value string) error {
+		return db.Update(func(tx kvdb.Tx) error {
+			bucket, err := tx.CreateBucketIfNotExists(bucketKey)
+			if err != nil {
+				return err
+			}
+
+			return bucket.Put([]byte("key"), []byte(value))
+		})
+	}
+
+	// As long as Alice is the leader, her writes should go through.
+	if err := put("leader"); err != nil {
+		t.Fatalf("unable to write as leader: %v", err)
+	}
+
+	// Once her lease is gone, all further writes must be rejected, even
+	// though the backend itself is still connected.
+	if err := alice.Close(); err != nil {
+		t.Fatalf("unable to close elector: %v", err)
+	}
+
+	err = put("stale leader")
+	if err != kvdb.ErrLeadershipLost {
+		t.Fatalf("expected ErrLeadershipLost, got %v", err)
+	}
+
+	// The value written while leader must be left untouched.
+	err = db.View(func(tx kvdb.Tx) error {
+		bucket := tx.Bucket(bucketKey)
+		if bucket == nil {
+			t.Fatalf("bucket not found")
+		}
+
+		value := bucket.Get([]byte("key"))
+		if string(value) != "leader" {
+			t.Fatalf("expected value %q, got %q", "leader", value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read value: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+// Package cluster implements the leader election used to run several lnd
+// instances sharing a single replicated database in an active/passive
+// configuration. Only the elected leader runs the node, while the followers
+// wait, ready to take over once the leader's lease lapses.
+package cluster
+
+import "context"
+
+// LeaderElector is implemented by all leader election backends.
+type LeaderElector interface {
+	// Campaign blocks until this instance is elected leader, or the
+	// passed context is cancelled.
+	Campaign(ctx context.Context) error
+
+	// Resign gives up leadership, allowing another instance to be
+	// elected.
+	Resign() error
+
+	// Leader returns the ID of the current leader.
+	Leader(ctx context.Context) (string, error)
+
+	// LeaderKey returns the election key held by this instance, along
+	// with its creation revision. The key exists for as long as this
+	// instance remains the leader, allowing writes to the database to be
+	// conditioned on it.
+	//
+	// NOTE: This MUST only be called once elected.
+	LeaderKey() (string, int64)
+
+	// Done returns a channel that's closed once this instance's lease
+	// has been lost, after which it MUST NOT act as the leader anymore.
+	Done() <-chan struct{}
+
+	// Close releases all resources held by the elector, resigning if
+	// elected.
+	Close() error
+}
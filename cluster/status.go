@@ -0,0 +1,69 @@
+package cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// Status tracks whether this instance is currently the leader of the
+// cluster.
+type Status struct {
+	// leader is 1 if this instance is the leader, and 0 otherwise. It
+	// MUST be used atomically.
+	leader int32
+
+	id string
+}
+
+// NewStatus creates a Status for the instance with the passed ID, which
+// starts out as a follower.
+func NewStatus(id string) *Status {
+	return &Status{id: id}
+}
+
+// ID returns the ID of this instance within the cluster.
+func (s *Status) ID() string {
+	return s.id
+}
+
+// SetLeader records whether this instance is currently the leader.
+func (s *Status) SetLeader(leader bool) {
+	var v int32
+	if leader {
+		v = 1
+	}
+	atomic.StoreInt32(&s.leader, v)
+}
+
+// IsLeader returns true if this instance is currently the leader.
+func (s *Status) IsLeader() bool {
+	return atomic.LoadInt32(&s.leader) == 1
+}
+
+// HealthResponse is the body returned by the health endpoint.
+type HealthResponse struct {
+	// ID is the ID of this instance within the cluster.
+	ID string `json:"id"`
+
+	// Leader is true if this instance is currently the leader.
+	Leader bool `json:"leader"`
+}
+
+// ServeHTTP serves the health endpoint, reporting this instance's status
+// within the cluster. The leader responds with 200 OK, while followers
+// respond with 503 Service Unavailable, allowing load balancers and
+// orchestrators to route all traffic to the leader.
+func (s *Status) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := HealthResponse{
+		ID:     s.id,
+		Leader: s.IsLeader(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Leader {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(resp)
+}
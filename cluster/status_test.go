@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestStatusHealthEndpoint tests that the health endpoint reflects whether
+// the instance is currently the leader.
+func TestStatusHealthEndpoint(t *testing.T) {
+	t.Parallel()
+
+	status := NewStatus("node-1")
+
+	testCases := []struct {
+		leader     bool
+		statusCode int
+	}{
+		{
+			leader:     false,
+			statusCode: http.StatusServiceUnavailable,
+		},
+		{
+			leader:     true,
+			statusCode: http.StatusOK,
+		},
+		{
+			leader:     false,
+			statusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for i, test := range testCases {
+		status.SetLeader(test.leader)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/health", nil)
+		status.ServeHTTP(rec, req)
+
+		if rec.Code != test.statusCode {
+			t.Fatalf("test #%v: expected status code %v, got %v",
+				i, test.statusCode, rec.Code)
+		}
+
+		var resp HealthResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("test #%v: unable to decode response: %v",
+				i, err)
+		}
+		if resp.ID != "node-1" || resp.Leader != test.leader {
+			t.Fatalf("test #%v: unexpected response: %+v", i, resp)
+		}
+	}
+}
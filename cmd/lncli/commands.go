@@ -16,11 +16,13 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/awalterschulze/gographviz"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
 	"github.com/urfave/cli"
@@ -28,6 +30,7 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	macaroon "gopkg.in/macaroon.v2"
 )
 
 // TODO(roasbeef): cli logic for supporting both positional and unix style
@@ -229,6 +232,91 @@ func sendCoins(ctx *cli.Context) error {
 	return nil
 }
 
+var walletCommand = cli.Command{
+	Name:  "wallet",
+	Usage: "Interact with the on-chain wallet",
+	Subcommands: []cli.Command{
+		bumpFeeCommand,
+	},
+}
+
+var bumpFeeCommand = cli.Command{
+	Name:      "bumpfee",
+	Usage:     "Bump the fee of an unconfirmed transaction",
+	ArgsUsage: "outpoint",
+	Description: `
+	Speed up the confirmation of an unconfirmed transaction, such as one
+	sweeping the outputs of a force closed channel, by broadcasting a
+	child-pays-for-parent transaction spending the specified output.
+
+	The output must be in the format txid:index, and must either pay to
+	the wallet, or be our immediately spendable output on a commitment
+	transaction. The fee paid by the child is such that the parent and
+	child together reach the target fee rate, which can be specified via
+	the --conf_target, or --sat_per_byte optional flags. If the fee of the
+	output was already bumped, then the pending child is replaced, as long
+	as the new fee rate is higher.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the " +
+				"transaction *should* confirm in, will be " +
+				"used for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in " +
+				"sat/byte that the parent and child " +
+				"transaction should pay",
+		},
+	},
+	Action: actionDecorator(bumpFee),
+}
+
+func bumpFee(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		cli.ShowCommandHelp(ctx, "bumpfee")
+		return nil
+	}
+
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
+	split := strings.Split(ctx.Args().First(), ":")
+	if len(split) != 2 {
+		return fmt.Errorf("expecting outpoint to be in format of: " +
+			"txid:index")
+	}
+
+	index, err := strconv.ParseUint(split[1], 10, 32)
+	if err != nil {
+		return fmt.Errorf("unable to decode output index: %v", err)
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.BumpFeeRequest{
+		Outpoint: &lnrpc.OutPoint{
+			TxidStr:     split[0],
+			OutputIndex: uint32(index),
+		},
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+	}
+	resp, err := client.BumpFee(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var sendManyCommand = cli.Command{
 	Name:      "sendmany",
 	Usage:     "Send bitcoin on-chain to multiple addresses.",
@@ -294,7 +382,8 @@ var connectCommand = cli.Command{
 		cli.BoolFlag{
 			Name: "perm",
 			Usage: "If set, the daemon will attempt to persistently " +
-				"connect to the target peer.\n" +
+				"connect to the target peer, also across " +
+				"restarts, until it's disconnected.\n" +
 				"           If not, the call will be synchronous.",
 		},
 	},
@@ -448,6 +537,13 @@ var openChannelCommand = cli.Command{
 				"not set, we will scale the value according to the " +
 				"channel size",
 		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "(optional) an address to commit to as the " +
+				"cooperative close address of the channel. " +
+				"Requires the remote peer to support upfront " +
+				"shutdown scripts",
+		},
 	},
 	Action: actionDecorator(openChannel),
 }
@@ -472,6 +568,7 @@ func openChannel(ctx *cli.Context) error {
 		SatPerByte:     ctx.Int64("sat_per_byte"),
 		MinHtlcMsat:    ctx.Int64("min_htlc_msat"),
 		RemoteCsvDelay: uint32(ctx.Uint64("remote_csv_delay")),
+		CloseAddress:   ctx.String("close_address"),
 	}
 
 	switch {
@@ -607,6 +704,103 @@ func openChannel(ctx *cli.Context) error {
 	}
 }
 
+var batchOpenChannelCommand = cli.Command{
+	Name:      "batchopenchannel",
+	Usage:     "Open several channels within a single funding transaction.",
+	ArgsUsage: "channels-json-string [--conf_target=N] [--sat_per_byte=P]",
+	Description: `
+	Attempt to open a channel with each of the specified peers, funding all
+	of them within a single transaction. The funding transaction is only
+	broadcast once every peer has accepted its channel, if any of them
+	fails, none of the channels are opened. All peers must be connected.
+
+	The channels-json-string param decodes the channels to open in the
+	following format:
+
+	    '[{"node_pubkey": "<pubkey>", "local_amt": 1000000, "push_amt": 0,
+	       "private": false, "min_htlc_msat": 1000, "remote_csv_delay": 144}]'
+
+	Only node_pubkey and local_amt are required for each channel.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the " +
+				"transaction *should* confirm in, will be used " +
+				"for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in " +
+				"sat/byte that should be used when crafting " +
+				"the transaction",
+		},
+	},
+	Action: actionDecorator(batchOpenChannel),
+}
+
+// batchChannel is the JSON representation of a channel passed to the
+// batchopenchannel command.
+type batchChannel struct {
+	NodePubkey     string `json:"node_pubkey"`
+	LocalAmt       int64  `json:"local_amt"`
+	PushAmt        int64  `json:"push_amt"`
+	Private        bool   `json:"private"`
+	MinHtlcMsat    int64  `json:"min_htlc_msat"`
+	RemoteCsvDelay uint32 `json:"remote_csv_delay"`
+}
+
+func batchOpenChannel(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		cli.ShowCommandHelp(ctx, "batchopenchannel")
+		return nil
+	}
+
+	var channels []batchChannel
+	jsonChannels := ctx.Args().First()
+	if err := json.Unmarshal([]byte(jsonChannels), &channels); err != nil {
+		return fmt.Errorf("unable to decode channels: %v", err)
+	}
+
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
+	req := &lnrpc.BatchOpenChannelRequest{
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+	}
+	for _, channel := range channels {
+		nodePubHex, err := hex.DecodeString(channel.NodePubkey)
+		if err != nil {
+			return fmt.Errorf("unable to decode node public key: "+
+				"%v", err)
+		}
+
+		req.Channels = append(req.Channels, &lnrpc.BatchOpenChannel{
+			NodePubkey:         nodePubHex,
+			LocalFundingAmount: channel.LocalAmt,
+			PushSat:            channel.PushAmt,
+			Private:            channel.Private,
+			MinHtlcMsat:        channel.MinHtlcMsat,
+			RemoteCsvDelay:     channel.RemoteCsvDelay,
+		})
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.BatchOpenChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 // TODO(roasbeef): also allow short relative channel ID.
 
 var closeChannelCommand = cli.Command{
@@ -1019,6 +1213,67 @@ func listPeers(ctx *cli.Context) error {
 	return nil
 }
 
+var listBannedPeersCommand = cli.Command{
+	Name:   "listbannedpeers",
+	Usage:  "List all peers that are currently banned.",
+	Action: actionDecorator(listBannedPeers),
+}
+
+func listBannedPeers(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ListBannedPeersRequest{}
+	resp, err := client.ListBannedPeers(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var unbanPeerCommand = cli.Command{
+	Name:      "unbanpeer",
+	Usage:     "Lift the ban of a peer identified by public key",
+	ArgsUsage: "<pubkey>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node_key",
+			Usage: "The hex-encoded compressed public key of the peer",
+		},
+	},
+	Action: actionDecorator(unbanPeer),
+}
+
+func unbanPeer(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var pubKey string
+	switch {
+	case ctx.IsSet("node_key"):
+		pubKey = ctx.String("node_key")
+	case ctx.Args().Present():
+		pubKey = ctx.Args().First()
+	default:
+		return fmt.Errorf("must specify target public key")
+	}
+
+	req := &lnrpc.UnbanPeerRequest{
+		PubKey: pubKey,
+	}
+	resp, err := client.UnbanPeer(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var createCommand = cli.Command{
 	Name: "create",
 	Description: `
@@ -1418,6 +1673,76 @@ func getInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var updateNodeAnnouncementCommand = cli.Command{
+	Name:  "updatenodeannouncement",
+	Usage: "Update and broadcast our node announcement.",
+	Description: `
+	Update the alias, color, advertised addresses or optional feature bits
+	of our node announcement, then re-sign it and broadcast it to the
+	network. Broadcasts are rate limited, so several updates made in quick
+	succession are coalesced into one. The changes last until the next
+	restart.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "alias",
+			Usage: "the new alias of the node",
+		},
+		cli.StringFlag{
+			Name:  "color",
+			Usage: "the new color of the node, in the format #RRGGBB",
+		},
+		cli.StringSliceFlag{
+			Name: "address",
+			Usage: "an address to advertise, in the format " +
+				"host:port, replacing all current ones; " +
+				"may be specified multiple times",
+		},
+		cli.BoolFlag{
+			Name:  "clear_addresses",
+			Usage: "stop advertising any addresses",
+		},
+		cli.IntSliceFlag{
+			Name: "set_feature",
+			Usage: "an optional feature bit to start advertising; " +
+				"may be specified multiple times",
+		},
+		cli.IntSliceFlag{
+			Name: "unset_feature",
+			Usage: "an optional feature bit to stop advertising; " +
+				"may be specified multiple times",
+		},
+	},
+	Action: actionDecorator(updateNodeAnnouncement),
+}
+
+func updateNodeAnnouncement(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.UpdateNodeAnnouncementRequest{
+		Alias:     ctx.String("alias"),
+		Color:     ctx.String("color"),
+		Addresses: ctx.StringSlice("address"),
+		UpdateAddresses: ctx.IsSet("address") ||
+			ctx.Bool("clear_addresses"),
+	}
+	for _, bit := range ctx.IntSlice("set_feature") {
+		req.SetFeatures = append(req.SetFeatures, uint32(bit))
+	}
+	for _, bit := range ctx.IntSlice("unset_feature") {
+		req.UnsetFeatures = append(req.UnsetFeatures, uint32(bit))
+	}
+
+	resp, err := client.UpdateNodeAnnouncement(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var pendingChannelsCommand = cli.Command{
 	Name:   "pendingchannels",
 	Usage:  "Display information pertaining to pending channels",
@@ -1488,6 +1813,65 @@ func listChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var closedChannelsCommand = cli.Command{
+	Name:  "closedchannels",
+	Usage: "List all closed channels",
+	Description: `
+	List all channels that have been closed, along with the on-chain
+	resolution of each of their outputs. If no close types are specified,
+	then channels of all close types are listed.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "cooperative",
+			Usage: "list channels that were closed cooperatively",
+		},
+		cli.BoolFlag{
+			Name: "local_force",
+			Usage: "list channels that were force-closed " +
+				"by the local node",
+		},
+		cli.BoolFlag{
+			Name: "remote_force",
+			Usage: "list channels that were force-closed " +
+				"by the remote node",
+		},
+		cli.BoolFlag{
+			Name: "breach",
+			Usage: "list channels for which the remote node " +
+				"attempted to broadcast a prior " +
+				"revoked channel state",
+		},
+		cli.BoolFlag{
+			Name:  "funding_canceled",
+			Usage: "list channels that were never fully opened",
+		},
+	},
+	Action: actionDecorator(closedChannels),
+}
+
+func closedChannels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ClosedChannelsRequest{
+		Cooperative:     ctx.Bool("cooperative"),
+		LocalForce:      ctx.Bool("local_force"),
+		RemoteForce:     ctx.Bool("remote_force"),
+		Breach:          ctx.Bool("breach"),
+		FundingCanceled: ctx.Bool("funding_canceled"),
+	}
+
+	resp, err := client.ClosedChannels(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var sendPaymentCommand = cli.Command{
 	Name:  "sendpayment",
 	Usage: "Send a payment over lightning",
@@ -1539,6 +1923,11 @@ var sendPaymentCommand = cli.Command{
 			Name:  "final_cltv_delta",
 			Usage: "the number of blocks the last hop has to reveal the preimage",
 		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "(optional) maximum number of satoshis to pay " +
+				"as fees for the payment",
+		},
 	},
 	Action: sendPayment,
 }
@@ -1641,6 +2030,8 @@ func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
+	req.FeeLimit = ctx.Int64("fee_limit")
+
 	paymentStream, err := client.SendPayment(context.Background())
 	if err != nil {
 		return err
@@ -1684,6 +2075,11 @@ var payInvoiceCommand = cli.Command{
 			Usage: "(optional) number of satoshis to fulfill the " +
 				"invoice",
 		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "(optional) maximum number of satoshis to pay " +
+				"as fees for the payment",
+		},
 	},
 	Action: actionDecorator(payInvoice),
 }
@@ -1710,53 +2106,216 @@ func payInvoice(ctx *cli.Context) error {
 	return sendPaymentRequest(ctx, req)
 }
 
-var addInvoiceCommand = cli.Command{
-	Name:  "addinvoice",
-	Usage: "Add a new invoice.",
+var trackPaymentCommand = cli.Command{
+	Name:  "trackpayment",
+	Usage: "Follow the state transitions of an outgoing payment.",
 	Description: `
-	Add a new invoice, expressing intent for a future payment.
-
-	Invoices without an amount can be created by not supplying any
-	parameters or providing an amount of 0. These invoices allow the payee
-	to specify the amount of satoshis they wish to send.`,
-	ArgsUsage: "value preimage",
+	Stream the state transitions of the outgoing payment with the target
+	payment hash: each failed attempt along with its route and failure
+	reason, and the final outcome of the payment. If the payment has
+	already completed, only its final state is printed.`,
+	ArgsUsage: "payment_hash",
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name: "memo",
-			Usage: "a description of the payment to attach along " +
-				"with the invoice (default=\"\")",
-		},
-		cli.StringFlag{
-			Name:  "receipt",
-			Usage: "an optional cryptographic receipt of payment",
-		},
-		cli.StringFlag{
-			Name: "preimage",
-			Usage: "the hex-encoded preimage (32 byte) which will " +
-				"allow settling an incoming HTLC payable to this " +
-				"preimage. If not set, a random preimage will be " +
-				"created.",
-		},
-		cli.Int64Flag{
-			Name:  "amt",
-			Usage: "the amt of satoshis in this invoice",
-		},
-		cli.StringFlag{
-			Name: "description_hash",
-			Usage: "SHA-256 hash of the description of the payment. " +
-				"Used if the purpose of payment cannot naturally " +
-				"fit within the memo. If provided this will be " +
-				"used instead of the description(memo) field in " +
-				"the encoded invoice.",
-		},
-		cli.StringFlag{
-			Name: "fallback_addr",
-			Usage: "fallback on-chain address that can be used in " +
-				"case the lightning payment fails",
+			Name: "payment_hash",
+			Usage: "the 32 byte payment hash of the payment to " +
+				"track, the hash should be a hex-encoded string",
 		},
-		cli.Int64Flag{
-			Name: "expiry",
-			Usage: "the invoice's expiry time in seconds. If not " +
+	},
+	Action: actionDecorator(trackPayment),
+}
+
+func trackPayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		paymentHash []byte
+		err         error
+	)
+
+	switch {
+	case ctx.IsSet("payment_hash"):
+		paymentHash, err = hex.DecodeString(ctx.String("payment_hash"))
+	case ctx.Args().Present():
+		paymentHash, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("payment_hash argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to decode payment_hash argument: %v",
+			err)
+	}
+
+	req := &lnrpc.TrackPaymentRequest{
+		PaymentHash: paymentHash,
+	}
+
+	stream, err := client.TrackPayment(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJSON(update)
+	}
+}
+
+var sendToRouteCommand = cli.Command{
+	Name:  "sendtoroute",
+	Usage: "Send a payment over a predefined route.",
+	Description: `
+	Send a payment over the Lightning Network along the passed routes
+	rather than ones found by the channel router. The routes are tried in
+	order until the payment succeeds or a terminal error is encountered.
+
+	The routes are expected to be the JSON output of either queryroutes
+	or buildroute, and can be passed either directly via the --routes
+	flag, or read from stdin by passing "-":
+
+	    lncli queryroutes --dest=<dest> --amt=<amt> | lncli sendtoroute --payment_hash=<hash> --routes=-
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "payment_hash",
+			Usage: "the hash to use within the payment's HTLC, " +
+				"the hash should be a hex-encoded string",
+		},
+		cli.StringFlag{
+			Name: "routes",
+			Usage: "the JSON encoded routes to attempt, or \"-\" " +
+				"to read them from stdin",
+		},
+	},
+	Action: actionDecorator(sendToRoute),
+}
+
+func sendToRoute(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if !ctx.IsSet("payment_hash") {
+		return fmt.Errorf("payment_hash argument missing")
+	}
+	paymentHash, err := hex.DecodeString(ctx.String("payment_hash"))
+	if err != nil {
+		return fmt.Errorf("unable to decode payment_hash argument: %v",
+			err)
+	}
+
+	var jsonRoutes string
+	switch {
+	case ctx.String("routes") == "-":
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		jsonRoutes = string(b)
+	case ctx.IsSet("routes"):
+		jsonRoutes = ctx.String("routes")
+	default:
+		return fmt.Errorf("routes argument missing")
+	}
+
+	// The routes may either be the output of queryroutes, or the single
+	// route returned by buildroute.
+	var routes []*lnrpc.Route
+	queryResp := &lnrpc.QueryRoutesResponse{}
+	if err := jsonpb.UnmarshalString(jsonRoutes, queryResp); err == nil {
+		routes = queryResp.Routes
+	} else {
+		buildResp := &lnrpc.BuildRouteResponse{}
+		err := jsonpb.UnmarshalString(jsonRoutes, buildResp)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal routes: %v", err)
+		}
+		if buildResp.Route != nil {
+			routes = []*lnrpc.Route{buildResp.Route}
+		}
+	}
+
+	if len(routes) == 0 {
+		return fmt.Errorf("no routes provided")
+	}
+
+	req := &lnrpc.SendToRouteRequest{
+		PaymentHash: paymentHash,
+		Routes:      routes,
+	}
+
+	resp, err := client.SendToRoute(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		E string       `json:"payment_error"`
+		P string       `json:"payment_preimage"`
+		R *lnrpc.Route `json:"payment_route"`
+	}{
+		E: resp.PaymentError,
+		P: hex.EncodeToString(resp.PaymentPreimage),
+		R: resp.PaymentRoute,
+	})
+
+	return nil
+}
+
+var addInvoiceCommand = cli.Command{
+	Name:  "addinvoice",
+	Usage: "Add a new invoice.",
+	Description: `
+	Add a new invoice, expressing intent for a future payment.
+
+	Invoices without an amount can be created by not supplying any
+	parameters or providing an amount of 0. These invoices allow the payee
+	to specify the amount of satoshis they wish to send.`,
+	ArgsUsage: "value preimage",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "memo",
+			Usage: "a description of the payment to attach along " +
+				"with the invoice (default=\"\")",
+		},
+		cli.StringFlag{
+			Name:  "receipt",
+			Usage: "an optional cryptographic receipt of payment",
+		},
+		cli.StringFlag{
+			Name: "preimage",
+			Usage: "the hex-encoded preimage (32 byte) which will " +
+				"allow settling an incoming HTLC payable to this " +
+				"preimage. If not set, a random preimage will be " +
+				"created.",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amt of satoshis in this invoice",
+		},
+		cli.StringFlag{
+			Name: "description_hash",
+			Usage: "SHA-256 hash of the description of the payment. " +
+				"Used if the purpose of payment cannot naturally " +
+				"fit within the memo. If provided this will be " +
+				"used instead of the description(memo) field in " +
+				"the encoded invoice.",
+		},
+		cli.StringFlag{
+			Name: "fallback_addr",
+			Usage: "fallback on-chain address that can be used in " +
+				"case the lightning payment fails",
+		},
+		cli.Int64Flag{
+			Name: "expiry",
+			Usage: "the invoice's expiry time in seconds. If not " +
 				"specified an expiry of 3600 seconds (1 hour) " +
 				"is implied.",
 		},
@@ -1964,6 +2523,127 @@ func describeGraph(ctx *cli.Context) error {
 	return nil
 }
 
+var exportGraphCommand = cli.Command{
+	Name:  "exportgraph",
+	Usage: "Export the channel graph for visualization or analysis.",
+	Description: `
+	Export the nodes and channels of the graph in the DOT language of
+	graphviz, as a GraphML document, or as newline-delimited JSON with one
+	object per node and channel. The export is streamed from lnd in
+	chunks and written out as they arrive.
+
+	The export can be restricted to a set of nodes, to the neighborhood of
+	our own node, and to channels matching the given capacity, age and
+	policy filters. Channels are only exported if both of their endpoints
+	are.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Value: "dot",
+			Usage: "the format of the export, one of: dot, " +
+				"graphml, json",
+		},
+		cli.StringFlag{
+			Name: "out",
+			Usage: "the path the export should be written to, " +
+				"defaults to stdout",
+		},
+		cli.StringSliceFlag{
+			Name: "node",
+			Usage: "the hex encoded public key of a node to " +
+				"restrict the export to, may be specified " +
+				"multiple times",
+		},
+		cli.Uint64Flag{
+			Name: "hops",
+			Usage: "if set, only export the nodes at most this " +
+				"many hops away from our own node",
+		},
+		cli.Int64Flag{
+			Name:  "min_capacity",
+			Usage: "the minimum capacity in satoshis of channels",
+		},
+		cli.Uint64Flag{
+			Name:  "min_age",
+			Usage: "the minimum age in blocks of channels",
+		},
+		cli.Uint64Flag{
+			Name:  "max_age",
+			Usage: "the maximum age in blocks of channels",
+		},
+		cli.Int64Flag{
+			Name: "max_base_fee_msat",
+			Usage: "the maximum base fee in millisatoshis of " +
+				"channels",
+		},
+		cli.Int64Flag{
+			Name: "max_fee_rate",
+			Usage: "the maximum fee rate in millionths of " +
+				"channels",
+		},
+		cli.Uint64Flag{
+			Name:  "max_time_lock_delta",
+			Usage: "the maximum time lock delta of channels",
+		},
+		cli.BoolFlag{
+			Name:  "exclude_disabled",
+			Usage: "exclude channels disabled in both directions",
+		},
+	},
+	Action: actionDecorator(exportGraph),
+}
+
+func exportGraph(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ExportGraphRequest{
+		Format:           ctx.String("format"),
+		Nodes:            ctx.StringSlice("node"),
+		Hops:             uint32(ctx.Uint64("hops")),
+		MinCapacity:      ctx.Int64("min_capacity"),
+		MinAge:           uint32(ctx.Uint64("min_age")),
+		MaxAge:           uint32(ctx.Uint64("max_age")),
+		MaxBaseFeeMsat:   ctx.Int64("max_base_fee_msat"),
+		MaxFeeRate:       ctx.Int64("max_fee_rate"),
+		MaxTimeLockDelta: uint32(ctx.Uint64("max_time_lock_delta")),
+		ExcludeDisabled:  ctx.Bool("exclude_disabled"),
+	}
+
+	stream, err := client.ExportGraph(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if ctx.IsSet("out") {
+		path := cleanAndExpandPath(ctx.String("out"))
+		f, err := os.OpenFile(
+			path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644,
+		)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
 // normalizeFunc is a factory function which returns a function that normalizes
 // the capacity of edges within the graph. The value of the returned
 // function can be used to either plot the capacities, or to use a weight in a
@@ -2257,6 +2937,39 @@ var queryRoutesCommand = cli.Command{
 			Usage: "the max number of routes to be returned (default: 10)",
 			Value: 10,
 		},
+		cli.Int64Flag{
+			Name: "final_cltv_delta",
+			Usage: "the number of blocks the destination has to " +
+				"reveal the preimage (default: 9)",
+		},
+		cli.StringFlag{
+			Name: "source",
+			Usage: "the hex-encoded public key of the node the " +
+				"routes should start from, if not set the routes " +
+				"start from our own node",
+		},
+		cli.StringSliceFlag{
+			Name: "ignore_node",
+			Usage: "the hex-encoded public key of a node the routes " +
+				"must not pass through; may be specified " +
+				"multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "ignore_edge",
+			Usage: "a channel the routes must not traverse in one " +
+				"direction, in the format chan_id:from_pubkey; " +
+				"may be specified multiple times",
+		},
+		cli.Int64Flag{
+			Name: "cltv_limit",
+			Usage: "the maximum number of blocks the total time " +
+				"lock of a route may exceed the current height by",
+		},
+		cli.StringFlag{
+			Name: "route_hints",
+			Usage: "the JSON encoded route hints to reach the " +
+				"destination, as returned by decodepayreq",
+		},
 	},
 	Action: actionDecorator(queryRoutes),
 }
@@ -2297,9 +3010,48 @@ func queryRoutes(ctx *cli.Context) error {
 	}
 
 	req := &lnrpc.QueryRoutesRequest{
-		PubKey:    dest,
-		Amt:       amt,
-		NumRoutes: int32(ctx.Int("num_max_routes")),
+		PubKey:         dest,
+		Amt:            amt,
+		NumRoutes:      int32(ctx.Int("num_max_routes")),
+		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
+		SourcePubKey:   ctx.String("source"),
+		IgnoredNodes:   ctx.StringSlice("ignore_node"),
+		CltvLimit:      uint32(ctx.Int64("cltv_limit")),
+	}
+
+	for _, edge := range ctx.StringSlice("ignore_edge") {
+		parts := strings.Split(edge, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("expected ignored edge in the format "+
+				"chan_id:from_pubkey, got %v", edge)
+		}
+
+		chanID, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode channel id of "+
+				"ignored edge: %v", err)
+		}
+
+		req.IgnoredEdges = append(req.IgnoredEdges, &lnrpc.DirectedEdge{
+			ChanId:   chanID,
+			FromNode: parts[1],
+		})
+	}
+
+	// The route hints are expected in the same format as they're
+	// returned by decodepayreq, so we'll decode them as part of a
+	// request.
+	if ctx.IsSet("route_hints") {
+		hintsReq := &lnrpc.QueryRoutesRequest{}
+		err := jsonpb.UnmarshalString(
+			`{"route_hints": `+ctx.String("route_hints")+`}`,
+			hintsReq,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode route hints: %v",
+				err)
+		}
+		req.RouteHints = hintsReq.RouteHints
 	}
 
 	route, err := client.QueryRoutes(ctxb, req)
@@ -2311,6 +3063,160 @@ func queryRoutes(ctx *cli.Context) error {
 	return nil
 }
 
+var buildRouteCommand = cli.Command{
+	Name:  "buildroute",
+	Usage: "Build a route through a list of hops.",
+	Description: `
+	Construct a route that pays the amount to the last of the passed hops,
+	traveling through each of them in order. The fees and time-locks of
+	the route are computed from the current policies of the channels
+	between the hops. The resulting route can be passed to sendtoroute.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to send expressed in satoshis",
+		},
+		cli.StringFlag{
+			Name: "hops",
+			Usage: "a comma separated list of the hex-encoded " +
+				"public keys of the hops, ending with the " +
+				"destination",
+		},
+		cli.StringFlag{
+			Name: "chan_ids",
+			Usage: "an optional comma separated list of the " +
+				"channels to use to reach each of the hops, if " +
+				"not set the cheapest channel is selected",
+		},
+		cli.Int64Flag{
+			Name: "final_cltv_delta",
+			Usage: "the number of blocks the last hop has to " +
+				"reveal the preimage (default: 9)",
+		},
+	},
+	Action: actionDecorator(buildRoute),
+}
+
+func buildRoute(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if !ctx.IsSet("amt") {
+		return fmt.Errorf("amt argument missing")
+	}
+	if !ctx.IsSet("hops") {
+		return fmt.Errorf("hops argument missing")
+	}
+
+	req := &lnrpc.BuildRouteRequest{
+		AmtMsat:        ctx.Int64("amt") * 1000,
+		HopPubkeys:     strings.Split(ctx.String("hops"), ","),
+		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
+	}
+
+	if ctx.IsSet("chan_ids") {
+		for _, c := range strings.Split(ctx.String("chan_ids"), ",") {
+			chanID, err := strconv.ParseUint(c, 10, 64)
+			if err != nil {
+				return fmt.Errorf("unable to decode chan_ids "+
+					"argument: %v", err)
+			}
+			req.ChanIds = append(req.ChanIds, chanID)
+		}
+	}
+
+	resp, err := client.BuildRoute(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var estimateRouteFeeCommand = cli.Command{
+	Name:  "estimateroutefee",
+	Usage: "Probe the routes to a destination to estimate the fee of a payment.",
+	Description: `
+	Probe the candidate routes to the destination, cheapest first, by
+	sending HTLCs that pay to a random payment hash along them. The first
+	route whose probe makes it all the way to the destination is returned
+	along with its fee and time lock. No funds are spent by the probes.`,
+	ArgsUsage: "dest amt",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "dest",
+			Usage: "the 33-byte hex-encoded public key for the payment " +
+				"destination",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to send expressed in satoshis",
+		},
+		cli.Int64Flag{
+			Name:  "num_max_routes",
+			Usage: "the max number of routes to be probed (default: 10)",
+			Value: 10,
+		},
+		cli.Int64Flag{
+			Name: "final_cltv_delta",
+			Usage: "the number of blocks the destination has to " +
+				"reveal the preimage (default: 9)",
+		},
+	},
+	Action: actionDecorator(estimateRouteFee),
+}
+
+func estimateRouteFee(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		dest string
+		amt  int64
+		err  error
+	)
+
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("dest"):
+		dest = ctx.String("dest")
+	case args.Present():
+		dest = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("dest argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("amt"):
+		amt = ctx.Int64("amt")
+	case args.Present():
+		amt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amt argument: %v", err)
+		}
+	default:
+		return fmt.Errorf("amt argument missing")
+	}
+
+	req := &lnrpc.EstimateRouteFeeRequest{
+		PubKey:         dest,
+		Amt:            amt,
+		NumRoutes:      int32(ctx.Int64("num_max_routes")),
+		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
+	}
+
+	resp, err := client.EstimateRouteFee(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var getNetworkInfoCommand = cli.Command{
 	Name:  "getnetworkinfo",
 	Usage: "Getnetworkinfo",
@@ -2851,3 +3757,137 @@ func forwardingHistory(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var constrainMacaroonCommand = cli.Command{
+	Name:      "constrainmacaroon",
+	Usage:     "Add caveats to an existing macaroon file offline.",
+	ArgsUsage: "output_file",
+	Description: `
+	Derive a new, more restricted macaroon from an existing one by adding
+	first-party caveats to it, and write it to output_file. This command
+	doesn't contact lnd, so it can be used to hand out restricted macaroons
+	from a machine that only holds a copy of the original macaroon.
+
+	The macaroon can be restricted to a time window, a list of RPC methods
+	(either full gRPC names or short names such as "SendPayment"), an
+	IP address, a maximum amount per payment, on-chain send or channel
+	open, and a cumulative daily spending budget which lnd tracks per
+	macaroon.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "macaroonfile",
+			Usage: "the macaroon to constrain, defaults to the " +
+				"global --macaroonpath",
+		},
+		cli.StringFlag{
+			Name: "not_before",
+			Usage: "(optional) an RFC3339 timestamp before which " +
+				"the macaroon isn't valid",
+		},
+		cli.StringFlag{
+			Name: "not_after",
+			Usage: "(optional) an RFC3339 timestamp after which " +
+				"the macaroon isn't valid",
+		},
+		cli.Int64Flag{
+			Name: "timeout",
+			Usage: "(optional) the number of seconds from now " +
+				"after which the macaroon isn't valid",
+		},
+		cli.StringFlag{
+			Name: "methods",
+			Usage: "(optional) a comma separated list of RPC " +
+				"methods the macaroon is restricted to",
+		},
+		cli.StringFlag{
+			Name:  "ip",
+			Usage: "(optional) the IP address to lock the macaroon to",
+		},
+		cli.Int64Flag{
+			Name: "max_spend",
+			Usage: "(optional) the maximum number of satoshis a " +
+				"single call may spend",
+		},
+		cli.Int64Flag{
+			Name: "daily_budget",
+			Usage: "(optional) the maximum number of satoshis all " +
+				"calls may spend within a single UTC day",
+		},
+	},
+	Action: actionDecorator(constrainMacaroon),
+}
+
+func constrainMacaroon(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		cli.ShowCommandHelp(ctx, "constrainmacaroon")
+		return nil
+	}
+	outFile := cleanAndExpandPath(ctx.Args().First())
+
+	if ctx.IsSet("not_after") && ctx.IsSet("timeout") {
+		return fmt.Errorf("either not_after or timeout should be " +
+			"set, but not both")
+	}
+
+	macPath := cleanAndExpandPath(ctx.GlobalString("macaroonpath"))
+	if ctx.IsSet("macaroonfile") {
+		macPath = cleanAndExpandPath(ctx.String("macaroonfile"))
+	}
+	macBytes, err := ioutil.ReadFile(macPath)
+	if err != nil {
+		return err
+	}
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return fmt.Errorf("unable to decode macaroon: %v", err)
+	}
+
+	var notBefore, notAfter time.Time
+	if ctx.IsSet("not_before") {
+		notBefore, err = time.Parse(time.RFC3339, ctx.String("not_before"))
+		if err != nil {
+			return fmt.Errorf("unable to parse not_before: %v", err)
+		}
+	}
+	switch {
+	case ctx.IsSet("not_after"):
+		notAfter, err = time.Parse(time.RFC3339, ctx.String("not_after"))
+		if err != nil {
+			return fmt.Errorf("unable to parse not_after: %v", err)
+		}
+	case ctx.IsSet("timeout"):
+		timeout := time.Duration(ctx.Int64("timeout")) * time.Second
+		notAfter = time.Now().Add(timeout)
+	}
+
+	var methods []string
+	if ctx.IsSet("methods") {
+		for _, method := range strings.Split(ctx.String("methods"), ",") {
+			methods = append(methods, strings.TrimSpace(method))
+		}
+	}
+
+	constrainedMac, err := macaroons.AddConstraints(
+		mac,
+		macaroons.TimeWindowConstraint(notBefore, notAfter),
+		macaroons.MethodConstraint(methods...),
+		macaroons.IPLockConstraint(ctx.String("ip")),
+		macaroons.SpendLimitConstraint(
+			btcutil.Amount(ctx.Int64("max_spend")),
+		),
+		macaroons.DailySpendConstraint(
+			btcutil.Amount(ctx.Int64("daily_budget")),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	constrainedBytes, err := constrainedMac.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outFile, constrainedBytes, 0600)
+}
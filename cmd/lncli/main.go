@@ -197,27 +197,38 @@ func main() {
 		newAddressCommand,
 		sendManyCommand,
 		sendCoinsCommand,
+		walletCommand,
 		connectCommand,
 		disconnectCommand,
 		openChannelCommand,
+		batchOpenChannelCommand,
 		closeChannelCommand,
 		closeAllChannelsCommand,
 		listPeersCommand,
+		listBannedPeersCommand,
+		unbanPeerCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
 		getInfoCommand,
+		updateNodeAnnouncementCommand,
 		pendingChannelsCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
+		trackPaymentCommand,
+		sendToRouteCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
+		closedChannelsCommand,
 		listPaymentsCommand,
 		describeGraphCommand,
+		exportGraphCommand,
 		getChanInfoCommand,
 		getNodeInfoCommand,
 		queryRoutesCommand,
+		buildRouteCommand,
+		estimateRouteFeeCommand,
 		getNetworkInfoCommand,
 		debugLevelCommand,
 		decodePayReqCommand,
@@ -228,6 +239,7 @@ func main() {
 		feeReportCommand,
 		updateChannelPolicyCommand,
 		forwardingHistoryCommand,
+		constrainMacaroonCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
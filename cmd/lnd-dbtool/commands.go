@@ -0,0 +1,632 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"unicode"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/graphexport"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcutil"
+	"github.com/urfave/cli"
+)
+
+// openBolt opens the raw bolt database at the passed path in read-only mode.
+func openBolt(path string) (*bolt.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	return bolt.Open(path, 0600, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  dbOpenTimeout,
+	})
+}
+
+// fileSize returns the size of the file at the passed path in bytes.
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// bucketName returns a printable representation of a top-level bucket name.
+// Nearly all of channeldb's buckets have human readable names, so we only
+// fall back to hex for the rest.
+func bucketName(name []byte) string {
+	for _, r := range string(name) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return hex.EncodeToString(name)
+		}
+	}
+	return string(name)
+}
+
+var compactCommand = cli.Command{
+	Name:  "compact",
+	Usage: "Compact the channel database into a fresh file.",
+	Description: `
+	Copy the contents of the channel database into a fresh file, leaving
+	behind the free pages accumulated by deleted data. The original
+	database is left untouched, and once the copy has been verified, it
+	can be moved into place while lnd is stopped.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "out",
+			Usage: "the path the compacted database should be " +
+				"written to, defaults to channel.db.compact " +
+				"next to the original",
+		},
+	},
+	Action: compact,
+}
+
+func compact(ctx *cli.Context) error {
+	srcPath := dbFile(ctx)
+	dstPath := srcPath + ".compact"
+	if ctx.IsSet("out") {
+		dstPath = cleanAndExpandPath(ctx.String("out"))
+	}
+
+	if _, err := os.Stat(dstPath); err == nil {
+		return fmt.Errorf("output file %v already exists", dstPath)
+	}
+
+	srcSize, err := fileSize(srcPath)
+	if err != nil {
+		return err
+	}
+
+	if err := kvdb.CompactBolt(srcPath, dstPath); err != nil {
+		os.Remove(dstPath)
+		return fmt.Errorf("unable to compact database: %v", err)
+	}
+
+	dstSize, err := fileSize(dstPath)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		Source     string `json:"source"`
+		SourceSize int64  `json:"source_size"`
+		Output     string `json:"output"`
+		OutputSize int64  `json:"output_size"`
+	}{
+		Source:     srcPath,
+		SourceSize: srcSize,
+		Output:     dstPath,
+		OutputSize: dstSize,
+	})
+
+	return nil
+}
+
+var checkCommand = cli.Command{
+	Name:  "check",
+	Usage: "Verify the integrity of the channel database.",
+	Description: `
+	Verify the consistency of the database's pages, followed by the
+	structure of every top-level bucket: open channels, graph edges,
+	invoices, the forwarding log and forwarding packages. All issues found
+	are printed, and the command exits with a non-zero status if there are
+	any.
+	`,
+	Action: check,
+}
+
+func check(ctx *cli.Context) error {
+	// We'll start by checking the page structure of the bolt file
+	// itself, as the higher level checks can't be trusted otherwise.
+	db, err := openBolt(dbFile(ctx))
+	if err != nil {
+		return err
+	}
+
+	var issues []string
+	err = db.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			issues = append(issues, err.Error())
+		}
+		return nil
+	})
+	db.Close()
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		cdb, err := channeldb.OpenReadOnly(dbDir(ctx))
+		if err != nil {
+			return err
+		}
+		defer cdb.Close()
+
+		errs, err := cdb.CheckIntegrity()
+		if err != nil {
+			return err
+		}
+		for _, err := range errs {
+			issues = append(issues, err.Error())
+		}
+	}
+
+	printJSON(struct {
+		Issues []string `json:"issues"`
+	}{
+		Issues: issues,
+	})
+
+	if len(issues) != 0 {
+		return fmt.Errorf("found %v issues", len(issues))
+	}
+
+	return nil
+}
+
+var dumpCommand = cli.Command{
+	Name:  "dump",
+	Usage: "Dump the contents of the channel database as JSON.",
+	Subcommands: []cli.Command{
+		{
+			Name:   "channels",
+			Usage:  "Dump all open and pending channels.",
+			Action: dumpChannels,
+		},
+		{
+			Name:   "invoices",
+			Usage:  "Dump all invoices.",
+			Action: dumpInvoices,
+		},
+		{
+			Name:   "graph",
+			Usage:  "Dump all nodes and channels within the graph.",
+			Action: dumpGraph,
+		},
+	},
+}
+
+type dumpChannel struct {
+	ChannelPoint      string `json:"channel_point"`
+	ChanID            uint64 `json:"chan_id"`
+	RemotePubkey      string `json:"remote_pubkey"`
+	Status            string `json:"status"`
+	Pending           bool   `json:"pending"`
+	Initiator         bool   `json:"initiator"`
+	Capacity          int64  `json:"capacity"`
+	LocalBalance      int64  `json:"local_balance_msat"`
+	RemoteBalance     int64  `json:"remote_balance_msat"`
+	CommitHeight      uint64 `json:"commit_height"`
+	TotalMSatSent     int64  `json:"total_msat_sent"`
+	TotalMSatReceived int64  `json:"total_msat_received"`
+}
+
+func dumpChannels(ctx *cli.Context) error {
+	cdb, err := channeldb.OpenReadOnly(dbDir(ctx))
+	if err != nil {
+		return err
+	}
+	defer cdb.Close()
+
+	channels, err := cdb.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+
+	dump := make([]dumpChannel, 0, len(channels))
+	for _, c := range channels {
+		dump = append(dump, dumpChannel{
+			ChannelPoint: c.FundingOutpoint.String(),
+			ChanID:       c.ShortChanID.ToUint64(),
+			RemotePubkey: hex.EncodeToString(
+				c.IdentityPub.SerializeCompressed(),
+			),
+			Status:            c.ChanStatus.String(),
+			Pending:           c.IsPending,
+			Initiator:         c.IsInitiator,
+			Capacity:          int64(c.Capacity),
+			LocalBalance:      int64(c.LocalCommitment.LocalBalance),
+			RemoteBalance:     int64(c.LocalCommitment.RemoteBalance),
+			CommitHeight:      c.LocalCommitment.CommitHeight,
+			TotalMSatSent:     int64(c.TotalMSatSent),
+			TotalMSatReceived: int64(c.TotalMSatReceived),
+		})
+	}
+
+	printJSON(struct {
+		Channels []dumpChannel `json:"channels"`
+	}{
+		Channels: dump,
+	})
+
+	return nil
+}
+
+type dumpInvoice struct {
+	Memo           string `json:"memo"`
+	Receipt        string `json:"receipt"`
+	PaymentRequest string `json:"payment_request"`
+	PaymentHash    string `json:"payment_hash"`
+	Value          int64  `json:"value_msat"`
+	Settled        bool   `json:"settled"`
+	CreationDate   int64  `json:"creation_date"`
+	SettleDate     int64  `json:"settle_date"`
+}
+
+func dumpInvoices(ctx *cli.Context) error {
+	cdb, err := channeldb.OpenReadOnly(dbDir(ctx))
+	if err != nil {
+		return err
+	}
+	defer cdb.Close()
+
+	invoices, err := cdb.FetchAllInvoices(false)
+	if err != nil {
+		return err
+	}
+
+	dump := make([]dumpInvoice, 0, len(invoices))
+	for _, invoice := range invoices {
+		paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+
+		var settleDate int64
+		if invoice.Terms.Settled {
+			settleDate = invoice.SettleDate.Unix()
+		}
+
+		dump = append(dump, dumpInvoice{
+			Memo:           string(invoice.Memo),
+			Receipt:        hex.EncodeToString(invoice.Receipt),
+			PaymentRequest: string(invoice.PaymentRequest),
+			PaymentHash:    hex.EncodeToString(paymentHash[:]),
+			Value:          int64(invoice.Terms.Value),
+			Settled:        invoice.Terms.Settled,
+			CreationDate:   invoice.CreationDate.Unix(),
+			SettleDate:     settleDate,
+		})
+	}
+
+	printJSON(struct {
+		Invoices []dumpInvoice `json:"invoices"`
+	}{
+		Invoices: dump,
+	})
+
+	return nil
+}
+
+type dumpNode struct {
+	PubKey     string   `json:"pub_key"`
+	Alias      string   `json:"alias"`
+	Color      string   `json:"color"`
+	Addresses  []string `json:"addresses"`
+	LastUpdate int64    `json:"last_update"`
+}
+
+type dumpPolicy struct {
+	LastUpdate       int64  `json:"last_update"`
+	Disabled         bool   `json:"disabled"`
+	TimeLockDelta    uint16 `json:"time_lock_delta"`
+	MinHTLC          int64  `json:"min_htlc_msat"`
+	MaxHTLC          int64  `json:"max_htlc_msat"`
+	FeeBaseMsat      int64  `json:"fee_base_msat"`
+	FeeRateMilliMsat int64  `json:"fee_rate_milli_msat"`
+}
+
+type dumpEdge struct {
+	ChannelID    uint64      `json:"channel_id"`
+	ChannelPoint string      `json:"chan_point"`
+	Capacity     int64       `json:"capacity"`
+	Node1Pub     string      `json:"node1_pub"`
+	Node2Pub     string      `json:"node2_pub"`
+	Node1Policy  *dumpPolicy `json:"node1_policy"`
+	Node2Policy  *dumpPolicy `json:"node2_policy"`
+}
+
+func newDumpPolicy(policy *channeldb.ChannelEdgePolicy) *dumpPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &dumpPolicy{
+		LastUpdate:       policy.LastUpdate.Unix(),
+		Disabled:         policy.ChannelFlags&lnwire.ChanUpdateDisabled != 0,
+		TimeLockDelta:    policy.TimeLockDelta,
+		MinHTLC:          int64(policy.MinHTLC),
+		MaxHTLC:          int64(policy.MaxHTLC),
+		FeeBaseMsat:      int64(policy.FeeBaseMSat),
+		FeeRateMilliMsat: int64(policy.FeeProportionalMillionths),
+	}
+}
+
+func dumpGraph(ctx *cli.Context) error {
+	cdb, err := channeldb.OpenReadOnly(dbDir(ctx))
+	if err != nil {
+		return err
+	}
+	defer cdb.Close()
+
+	graph := cdb.ChannelGraph()
+
+	var nodes []dumpNode
+	err = graph.ForEachNode(nil, func(_ kvdb.Tx,
+		node *channeldb.LightningNode) error {
+
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+
+		addrs := make([]string, 0, len(node.Addresses))
+		for _, addr := range node.Addresses {
+			addrs = append(addrs, addr.String())
+		}
+
+		nodes = append(nodes, dumpNode{
+			PubKey: hex.EncodeToString(node.PubKeyBytes[:]),
+			Alias:  node.Alias,
+			Color: fmt.Sprintf("#%02x%02x%02x", node.Color.R,
+				node.Color.G, node.Color.B),
+			Addresses:  addrs,
+			LastUpdate: node.LastUpdate.Unix(),
+		})
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNotFound {
+		return err
+	}
+
+	var edges []dumpEdge
+	err = graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		p1, p2 *channeldb.ChannelEdgePolicy) error {
+
+		edges = append(edges, dumpEdge{
+			ChannelID:    info.ChannelID,
+			ChannelPoint: info.ChannelPoint.String(),
+			Capacity:     int64(info.Capacity),
+			Node1Pub:     hex.EncodeToString(info.NodeKey1Bytes[:]),
+			Node2Pub:     hex.EncodeToString(info.NodeKey2Bytes[:]),
+			Node1Policy:  newDumpPolicy(p1),
+			Node2Policy:  newDumpPolicy(p2),
+		})
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound &&
+		err != channeldb.ErrGraphNotFound {
+
+		return err
+	}
+
+	printJSON(struct {
+		Nodes []dumpNode `json:"nodes"`
+		Edges []dumpEdge `json:"edges"`
+	}{
+		Nodes: nodes,
+		Edges: edges,
+	})
+
+	return nil
+}
+
+var statsCommand = cli.Command{
+	Name:  "stats",
+	Usage: "Report the size of each top-level bucket.",
+	Description: `
+	Report the number of keys, nested buckets and bytes in use for every
+	top-level bucket of the channel database, along with the size of the
+	database file itself. Comparing the total bytes in use with the file
+	size gives an indication of how much space compaction would reclaim.
+	`,
+	Action: stats,
+}
+
+type bucketStats struct {
+	Name      string `json:"name"`
+	Keys      int    `json:"keys"`
+	Buckets   int    `json:"buckets"`
+	BytesUsed int    `json:"bytes_used"`
+}
+
+func stats(ctx *cli.Context) error {
+	path := dbFile(ctx)
+	db, err := openBolt(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	size, err := fileSize(path)
+	if err != nil {
+		return err
+	}
+
+	var (
+		buckets   []bucketStats
+		totalUsed int
+	)
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			s := b.Stats()
+			used := s.BranchInuse + s.LeafInuse
+
+			buckets = append(buckets, bucketStats{
+				Name:      bucketName(name),
+				Keys:      s.KeyN,
+				Buckets:   s.BucketN - 1,
+				BytesUsed: used,
+			})
+			totalUsed += used
+
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		FileSize  int64         `json:"file_size"`
+		BytesUsed int           `json:"bytes_used"`
+		Buckets   []bucketStats `json:"buckets"`
+	}{
+		FileSize:  size,
+		BytesUsed: totalUsed,
+		Buckets:   buckets,
+	})
+
+	return nil
+}
+
+var exportGraphCommand = cli.Command{
+	Name:  "exportgraph",
+	Usage: "Export the channel graph for visualization or analysis.",
+	Description: `
+	Export the nodes and channels of the graph in the DOT language of
+	graphviz, as a GraphML document, or as newline-delimited JSON with one
+	object per node and channel. The graph is streamed from the database,
+	so even large graphs can be exported with little memory.
+
+	The export can be restricted to a set of nodes, to the neighborhood of
+	our own node, and to channels matching the given capacity, age and
+	policy filters. Channels are only exported if both of their endpoints
+	are.
+
+	The database can't be opened while lnd is running, in which case the
+	graph should be exported over RPC with lncli's exportgraph command,
+	which accepts the same options.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Value: "dot",
+			Usage: "the format of the export, one of: dot, " +
+				"graphml, json",
+		},
+		cli.StringFlag{
+			Name: "out",
+			Usage: "the path the export should be written to, " +
+				"defaults to stdout",
+		},
+		cli.StringSliceFlag{
+			Name: "node",
+			Usage: "the hex encoded public key of a node to " +
+				"restrict the export to, may be specified " +
+				"multiple times",
+		},
+		cli.IntFlag{
+			Name: "hops",
+			Usage: "if set, only export the nodes at most this " +
+				"many hops away from our own node",
+		},
+		cli.Int64Flag{
+			Name:  "min_capacity",
+			Usage: "the minimum capacity in satoshis of channels",
+		},
+		cli.Uint64Flag{
+			Name:  "min_age",
+			Usage: "the minimum age in blocks of channels",
+		},
+		cli.Uint64Flag{
+			Name:  "max_age",
+			Usage: "the maximum age in blocks of channels",
+		},
+		cli.Uint64Flag{
+			Name: "max_base_fee_msat",
+			Usage: "the maximum base fee in millisatoshis of " +
+				"channels",
+		},
+		cli.Uint64Flag{
+			Name: "max_fee_rate",
+			Usage: "the maximum fee rate in millionths of " +
+				"channels",
+		},
+		cli.Uint64Flag{
+			Name:  "max_time_lock_delta",
+			Usage: "the maximum time lock delta of channels",
+		},
+		cli.BoolFlag{
+			Name:  "exclude_disabled",
+			Usage: "exclude channels disabled in both directions",
+		},
+	},
+	Action: exportGraph,
+}
+
+func exportGraph(ctx *cli.Context) error {
+	format, err := graphexport.ParseFormat(ctx.String("format"))
+	if err != nil {
+		return err
+	}
+
+	filter := &graphexport.Filter{
+		Hops:             ctx.Int("hops"),
+		MinCapacity:      btcutil.Amount(ctx.Int64("min_capacity")),
+		MinAge:           uint32(ctx.Uint64("min_age")),
+		MaxAge:           uint32(ctx.Uint64("max_age")),
+		MaxBaseFee:       lnwire.MilliSatoshi(ctx.Uint64("max_base_fee_msat")),
+		MaxFeeRate:       lnwire.MilliSatoshi(ctx.Uint64("max_fee_rate")),
+		MaxTimeLockDelta: uint16(ctx.Uint64("max_time_lock_delta")),
+		ExcludeDisabled:  ctx.Bool("exclude_disabled"),
+	}
+
+	filter.Nodes, err = graphexport.ParseNodes(ctx.StringSlice("node"))
+	if err != nil {
+		return err
+	}
+
+	cdb, err := channeldb.OpenReadOnly(dbDir(ctx))
+	switch {
+	case err == channeldb.ErrDBInUse:
+		return fmt.Errorf("%v, use `lncli exportgraph` to export the "+
+			"graph of a running lnd instance", err)
+
+	case err != nil:
+		return err
+	}
+	defer cdb.Close()
+
+	graph := cdb.ChannelGraph()
+
+	// The age of channels is relative to the height the graph was last
+	// pruned at, which tracks the best block lnd had processed.
+	if filter.MinAge != 0 || filter.MaxAge != 0 {
+		_, height, err := graph.PruneTip()
+		if err != nil {
+			return fmt.Errorf("unable to determine best height: %v",
+				err)
+		}
+		filter.BestHeight = height
+	}
+
+	var w io.Writer = os.Stdout
+	if ctx.IsSet("out") {
+		path := cleanAndExpandPath(ctx.String("out"))
+		f, err := os.OpenFile(
+			path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644,
+		)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := graphexport.Export(graph, bw, format, filter); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2015-2018 The Lightning Network Developers
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/roasbeef/btcutil"
+	"github.com/urfave/cli"
+)
+
+const (
+	defaultDataDirname     = "data"
+	defaultGraphSubDirname = "graph"
+	defaultNetwork         = "mainnet"
+	dbName                 = "channel.db"
+
+	// dbOpenTimeout is the maximum duration we'll wait to obtain the file
+	// lock of the database. If it's still held, lnd is most likely still
+	// running.
+	dbOpenTimeout = 5 * time.Second
+)
+
+var (
+	//Commit stores the current commit hash of this build. This should be
+	//set using -ldflags during compilation.
+	Commit string
+
+	defaultLndDir = btcutil.AppDataDir("lnd", false)
+)
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "[lnd-dbtool] %v\n", err)
+	os.Exit(1)
+}
+
+func printJSON(resp interface{}) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		fatal(err)
+	}
+
+	var out bytes.Buffer
+	json.Indent(&out, b, "", "\t")
+	out.WriteString("\n")
+	out.WriteTo(os.Stdout)
+}
+
+// dbDir returns the directory containing the channeldb to operate on. Unless
+// overridden by --dbpath, this is the graph directory of the selected network
+// within lnd's base directory.
+func dbDir(ctx *cli.Context) string {
+	if ctx.GlobalIsSet("dbpath") {
+		return cleanAndExpandPath(ctx.GlobalString("dbpath"))
+	}
+
+	lndDir := cleanAndExpandPath(ctx.GlobalString("lnddir"))
+	return filepath.Join(
+		lndDir, defaultDataDirname, defaultGraphSubDirname,
+		ctx.GlobalString("network"),
+	)
+}
+
+// dbFile returns the path to the channeldb file to operate on.
+func dbFile(ctx *cli.Context) string {
+	return filepath.Join(dbDir(ctx), dbName)
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "lnd-dbtool"
+	app.Version = fmt.Sprintf("%s commit=%s", "0.4.1", Commit)
+	app.Usage = "offline maintenance and inspection of lnd's channel " +
+		"database, lnd MUST NOT be running while using this tool"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "lnddir",
+			Value: defaultLndDir,
+			Usage: "path to lnd's base directory",
+		},
+		cli.StringFlag{
+			Name:  "network",
+			Value: defaultNetwork,
+			Usage: "the network lnd is running on, one of: " +
+				"mainnet, testnet, regtest, simnet",
+		},
+		cli.StringFlag{
+			Name: "dbpath",
+			Usage: "path to the directory containing channel.db, " +
+				"overrides --lnddir and --network",
+		},
+	}
+	app.Commands = []cli.Command{
+		compactCommand,
+		checkCommand,
+		dumpCommand,
+		statsCommand,
+		exportGraphCommand,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fatal(err)
+	}
+}
+
+// cleanAndExpandPath expands environment variables and leading ~ in the
+// passed path, cleans the result, and returns it.
+// This function is taken from https://github.com/btcsuite/btcd
+func cleanAndExpandPath(path string) string {
+	// Expand initial ~ to OS specific home directory.
+	if strings.HasPrefix(path, "~") {
+		var homeDir string
+
+		user, err := user.Current()
+		if err == nil {
+			homeDir = user.HomeDir
+		} else {
+			homeDir = os.Getenv("HOME")
+		}
+
+		path = strings.Replace(path, "~", homeDir, 1)
+	}
+
+	// NOTE: The os.ExpandEnv doesn't work with Windows-style %VARIABLE%,
+	// but the variables can still be expanded via POSIX-style $VARIABLE.
+	return filepath.Clean(os.ExpandEnv(path))
+}
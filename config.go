@@ -20,6 +20,7 @@ import (
 
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/torsvc"
 	"github.com/roasbeef/btcd/btcec"
@@ -51,6 +52,27 @@ const (
 
 	defaultBroadcastDelta = 10
 
+	// defaultChanDisableTimeout is the default duration a peer must be
+	// offline for before we'll disable all channels we have with it.
+	defaultChanDisableTimeout = 20 * time.Minute
+
+	// defaultBanDuration is the default duration for which a misbehaving
+	// Lightning peer is banned.
+	defaultBanDuration = 24 * time.Hour
+
+	// defaultBanThreshold is the default misbehavior score at which a
+	// Lightning peer gets banned.
+	defaultBanThreshold = 100
+
+	// defaultMaxBackoff is the default maximum duration we'll wait between
+	// attempts to reconnect to a persistent peer.
+	defaultMaxBackoff = time.Hour
+
+	// defaultLeaderSessionTTL is the default time in seconds after which
+	// the lease of a cluster leader that has stopped refreshing it
+	// expires.
+	defaultLeaderSessionTTL = 10
+
 	// minTimeLockDelta is the minimum timelock we require for incoming
 	// HTLCs on our channels.
 	minTimeLockDelta = 4
@@ -134,6 +156,26 @@ type autoPilotConfig struct {
 	MaxChannelSize int64   `long:"maxchansize" description:"The largest channel that the autopilot agent should create"`
 }
 
+type dualFundingConfig struct {
+	Active          bool    `long:"active" description:"If set, we'll advertise support for dual-funded channels and contribute funds to channels opened to us by peers that also support them."`
+	MatchFraction   float64 `long:"matchfraction" description:"The fraction of the remote party's funding amount that we should match when a dual-funded channel is opened to us, between 0 and 1"`
+	MaxContribution int64   `long:"maxcontribution" description:"The largest amount (in satoshis) that we should contribute to a single dual-funded channel opened to us"`
+}
+
+type dbConfig struct {
+	Backend string           `long:"backend" description:"The key-value database backend to store all of lnd's state within" choice:"bolt" choice:"etcd"`
+	Etcd    *kvdb.EtcdConfig `group:"etcd" namespace:"etcd"`
+
+	AutoCompact bool `long:"auto-compact" description:"Compact the bolt channel database on startup, reclaiming the space left unused by deleted data"`
+}
+
+type clusterConfig struct {
+	EnableLeaderElection bool   `long:"enable-leader-election" description:"Run this node as part of an active/passive cluster of nodes sharing a single etcd database. Only the elected leader runs the node, while the others wait to take over"`
+	ID                   string `long:"id" description:"The unique ID of this node within the cluster, which defaults to the hostname"`
+	LeaderSessionTTL     int    `long:"leader-session-ttl" description:"The time in seconds after which the leader's lease expires if it fails to refresh it, allowing a follower to take over"`
+	HealthListen         string `long:"healthlisten" description:"Add an interface/port to serve the cluster health endpoint on. The endpoint responds with 200 on the leader and 503 on followers"`
+}
+
 type torConfig struct {
 	Socks           string `long:"socks" description:"The port that Tor's exposed SOCKS5 proxy is listening on. Using Tor allows outbound-only connections (listening will be disabled) -- NOTE port must be between 1024 and 65535"`
 	DNS             string `long:"dns" description:"The DNS server as IP:PORT that Tor will use for SRV queries - NOTE must have TCP resolution enabled"`
@@ -190,8 +232,14 @@ type config struct {
 
 	Autopilot *autoPilotConfig `group:"autopilot" namespace:"autopilot"`
 
+	DualFunding *dualFundingConfig `group:"dualfunding" namespace:"dualfunding"`
+
 	Tor *torConfig `group:"Tor" namespace:"tor"`
 
+	DB *dbConfig `group:"db" namespace:"db"`
+
+	Cluster *clusterConfig `group:"cluster" namespace:"cluster"`
+
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
 
 	NoEncryptWallet bool `long:"noencryptwallet" description:"If set, wallet will be encrypted using the default passphrase."`
@@ -202,6 +250,17 @@ type config struct {
 	Color       string `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 	MinChanSize int64  `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected"`
 
+	LargeChannels bool `long:"largechannels" description:"If set, channels above the 2^24 satoshi limit may be opened to and accepted from peers that also signal support for them"`
+
+	EnableUpfrontShutdown bool `long:"enableupfrontshutdown" description:"If set, commit to a fresh wallet address as the cooperative close address for channels with peers that support upfront shutdown scripts"`
+
+	ChanDisableTimeout time.Duration `long:"chandisabletimeout" description:"The duration a peer must be offline for before all channels with it are announced as disabled to the network"`
+
+	BanDuration  time.Duration `long:"banduration" description:"How long to ban Lightning peers that send invalid gossip or violate the protocol.  Valid time units are {s, m, h}.  Minimum 1 second"`
+	BanThreshold uint32        `long:"banthreshold" description:"Maximum allowed misbehavior score before disconnecting and banning a Lightning peer."`
+
+	MaxBackoff time.Duration `long:"maxbackoff" description:"The maximum duration to wait between attempts to reconnect to a persistent peer. Valid time units are {s, m, h}.  Minimum 1 second"`
+
 	net torsvc.Net
 }
 
@@ -267,10 +326,28 @@ func loadConfig() (*config, error) {
 			MinChannelSize: int64(minChanFundingSize),
 			MaxChannelSize: int64(maxFundingAmount),
 		},
+		DualFunding: &dualFundingConfig{
+			MatchFraction:   1,
+			MaxContribution: int64(maxFundingAmount / 2),
+		},
+		DB: &dbConfig{
+			Backend: kvdb.BoltBackendName,
+			Etcd:    &kvdb.EtcdConfig{},
+		},
+		Cluster: &clusterConfig{
+			LeaderSessionTTL: defaultLeaderSessionTTL,
+		},
 		TrickleDelay: defaultTrickleDelay,
 		Alias:        defaultAlias,
 		Color:        defaultColor,
 		MinChanSize:  int64(minChanFundingSize),
+
+		ChanDisableTimeout: defaultChanDisableTimeout,
+
+		BanDuration:  defaultBanDuration,
+		BanThreshold: defaultBanThreshold,
+
+		MaxBackoff: defaultMaxBackoff,
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -378,13 +455,92 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// Ensure that the dual funding contribution policy is sane.
+	if cfg.DualFunding.MatchFraction < 0 || cfg.DualFunding.MatchFraction > 1 {
+		str := "%s: dualfunding.matchfraction must be between 0 and 1"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.DualFunding.MaxContribution < 0 {
+		str := "%s: dualfunding.maxcontribution must be non-negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Ensure that misbehaving peers are banned for a sane duration.
+	if cfg.BanDuration < time.Second {
+		str := "%s: banduration must be at least 1 second"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.BanThreshold == 0 {
+		str := "%s: banthreshold must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Ensure that the reconnection backoff can't drop below the initial
+	// backoff.
+	if cfg.MaxBackoff < defaultBackoff {
+		str := "%s: maxbackoff must be at least %v"
+		err := fmt.Errorf(str, funcName, defaultBackoff)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// If the etcd backend was selected, then we'll need to know where to
+	// find the cluster.
+	if cfg.DB.Backend == kvdb.EtcdBackendName && cfg.DB.Etcd.Host == "" {
+		str := "%s: db.etcd.host must be set when using the etcd " +
+			"backend"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Leader election requires all nodes within the cluster to share the
+	// same etcd database.
+	if cfg.Cluster.EnableLeaderElection {
+		if cfg.DB.Backend != kvdb.EtcdBackendName {
+			str := "%s: cluster.enable-leader-election requires " +
+				"the etcd db.backend"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+		if cfg.Cluster.LeaderSessionTTL <= 0 {
+			str := "%s: cluster.leader-session-ttl must be positive"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+		if cfg.Cluster.ID == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				str := "%s: unable to determine cluster.id: %v"
+				err := fmt.Errorf(str, funcName, err)
+				fmt.Fprintln(os.Stderr, err)
+				return nil, err
+			}
+			cfg.Cluster.ID = hostname
+		}
+	}
+
 	// Ensure that the specified values for the min and max channel size
 	// don't are within the bounds of the normal chan size constraints.
 	if cfg.Autopilot.MinChannelSize < int64(minChanFundingSize) {
 		cfg.Autopilot.MinChannelSize = int64(minChanFundingSize)
 	}
-	if cfg.Autopilot.MaxChannelSize > int64(maxFundingAmount) {
-		cfg.Autopilot.MaxChannelSize = int64(maxFundingAmount)
+	maxChanSize := maxFundingAmount
+	if cfg.LargeChannels {
+		maxChanSize = maxLargeFundingAmount
+	}
+	if cfg.Autopilot.MaxChannelSize > int64(maxChanSize) {
+		cfg.Autopilot.MaxChannelSize = int64(maxChanSize)
 	}
 
 	// Setup dial and DNS resolution functions depending on the specified
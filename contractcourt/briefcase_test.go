@@ -10,9 +10,9 @@ import (
 
 	prand "math/rand"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
@@ -95,7 +95,7 @@ var (
 	}
 )
 
-func makeTestDB() (*bolt.DB, func(), error) {
+func makeTestDB() (kvdb.Backend, func(), error) {
 	// First, create a temporary directory to be used for the duration of
 	// this test.
 	tempDirName, err := ioutil.TempDir("", "arblog")
@@ -103,7 +103,7 @@ func makeTestDB() (*bolt.DB, func(), error) {
 		return nil, nil, err
 	}
 
-	db, err := bolt.Open(tempDirName+"/test.db", 0600, nil)
+	db, err := kvdb.OpenBolt(tempDirName+"/test.db", 0600, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -101,6 +101,11 @@ type ChannelArbitratorConfig struct {
 	// TODO(roasbeef): need RPC's to combine for pendingchannels RPC
 	MarkChannelResolved func() error
 
+	// PutResolverReport records the final on-chain resolution of an output
+	// of this channel's commitment transaction, allowing the outcome to be
+	// reported once the channel has been fully closed.
+	PutResolverReport func(*channeldb.ResolverReport) error
+
 	ChainArbitratorConfig
 }
 
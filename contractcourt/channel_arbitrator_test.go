@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -77,6 +78,9 @@ func createTestChannelArbitrator() (*ChannelArbitrator, chan struct{}, func(), e
 		MarkCommitmentBroadcasted: func() error {
 			return nil
 		},
+		PutResolverReport: func(*channeldb.ResolverReport) error {
+			return nil
+		},
 
 		ChainArbitratorConfig: chainArbCfg,
 		ChainEvents:           chanEvents,
@@ -10,10 +10,13 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
 )
 
 var (
@@ -120,18 +123,20 @@ type htlcTimeoutResolver struct {
 // NOTE: Part of the ContractResolver interface.
 func (h *htlcTimeoutResolver) ResolverKey() []byte {
 	// The primary key for this resolver will be the outpoint of the HTLC
-	// on the commitment transaction itself. If this is our commitment,
-	// then the output can be found within the signed timeout tx,
-	// otherwise, it's just the ClaimOutpoint.
-	var op wire.OutPoint
+	// on the commitment transaction itself.
+	key := newResolverID(h.commitOutPoint())
+	return key[:]
+}
+
+// commitOutPoint returns the outpoint of the HTLC on the commitment
+// transaction. If this is our commitment, then the output can be found within
+// the signed timeout tx, otherwise, it's just the ClaimOutpoint.
+func (h *htlcTimeoutResolver) commitOutPoint() wire.OutPoint {
 	if h.htlcResolution.SignedTimeoutTx != nil {
-		op = h.htlcResolution.SignedTimeoutTx.TxIn[0].PreviousOutPoint
-	} else {
-		op = h.htlcResolution.ClaimOutpoint
+		return h.htlcResolution.SignedTimeoutTx.TxIn[0].PreviousOutPoint
 	}
 
-	key := newResolverID(op)
-	return key[:]
+	return h.htlcResolution.ClaimOutpoint
 }
 
 // Resolve kicks off full resolution of an outgoing HTLC output. If it's our
@@ -166,8 +171,9 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 
 	// waitForOutputResolution waits for the HTLC output to be fully
 	// resolved. The output is considered fully resolved once it has been
-	// spent, and the spending transaction has been fully confirmed.
-	waitForOutputResolution := func() error {
+	// spent, and the spending transaction has been fully confirmed. The
+	// txid of the spending transaction is returned.
+	waitForOutputResolution := func() (*chainhash.Hash, error) {
 		// We first need to register to see when the HTLC output itself
 		// has been spent so we can wait for the spending transaction
 		// to confirm.
@@ -176,20 +182,20 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 			h.broadcastHeight, true,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		var spendDetail *chainntnfs.SpendDetail
 		select {
 		case s, ok := <-spendNtfn.Spend:
 			if !ok {
-				return fmt.Errorf("notifier quit")
+				return nil, fmt.Errorf("notifier quit")
 			}
 
 			spendDetail = s
 
 		case <-h.Quit:
-			return fmt.Errorf("quitting")
+			return nil, fmt.Errorf("quitting")
 		}
 
 		// Now that the output has been spent, we'll also wait for the
@@ -199,7 +205,7 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 			uint32(spendDetail.SpendingHeight-1),
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		log.Infof("%T(%v): waiting for spending (txid=%v) to be fully "+
@@ -209,14 +215,14 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 		select {
 		case _, ok := <-confNtfn.Confirmed:
 			if !ok {
-				return fmt.Errorf("notifier quit")
+				return nil, fmt.Errorf("notifier quit")
 			}
 
 		case <-h.Quit:
-			return fmt.Errorf("quitting")
+			return nil, fmt.Errorf("quitting")
 		}
 
-		return nil
+		return spendDetail.SpenderTxHash, nil
 	}
 
 	// With the output sent to the nursery, we'll now wait until the output
@@ -227,12 +233,16 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 
 	// If we don't have a second layer transaction, then this is a remote
 	// party's commitment, so we'll watch for a direct spend.
+	var spendTxID *chainhash.Hash
 	if h.htlcResolution.SignedTimeoutTx == nil {
 		// We'll block until: the HTLC output has been spent, and the
 		// transaction spending that output is sufficiently confirmed.
 		log.Infof("%T(%v): waiting for nursery to spend CLTV-locked "+
 			"output", h, h.htlcResolution.ClaimOutpoint)
-		if err := waitForOutputResolution(); err != nil {
+
+		var err error
+		spendTxID, err = waitForOutputResolution()
+		if err != nil {
 			return nil, err
 		}
 	} else {
@@ -287,13 +297,29 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 	if h.htlcResolution.SignedTimeoutTx != nil {
 		log.Infof("%T(%v): waiting for nursery to spend CSV delayed "+
 			"output", h, h.htlcResolution.ClaimOutpoint)
-		if err := waitForOutputResolution(); err != nil {
+
+		var err error
+		spendTxID, err = waitForOutputResolution()
+		if err != nil {
 			return nil, err
 		}
 	}
 
-	// With the clean up message sent, we'll now mark the contract
-	// resolved, and wait.
+	// With the clean up message sent, we'll record the outcome of the
+	// contract, then mark it resolved.
+	err := h.PutResolverReport(&channeldb.ResolverReport{
+		OutPoint: h.commitOutPoint(),
+		Amount: btcutil.Amount(
+			h.htlcResolution.SweepSignDesc.Output.Value,
+		),
+		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
+		ResolverOutcome: channeldb.ResolverOutcomeTimeout,
+		SpendTxID:       spendTxID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	h.resolved = true
 	return nil, h.Checkpoint(h)
 }
@@ -431,18 +457,35 @@ type htlcSuccessResolver struct {
 // NOTE: Part of the ContractResolver interface.
 func (h *htlcSuccessResolver) ResolverKey() []byte {
 	// The primary key for this resolver will be the outpoint of the HTLC
-	// on the commitment transaction itself. If this is our commitment,
-	// then the output can be found within the signed success tx,
-	// otherwise, it's just the ClaimOutpoint.
-	var op wire.OutPoint
+	// on the commitment transaction itself.
+	key := newResolverID(h.commitOutPoint())
+	return key[:]
+}
+
+// commitOutPoint returns the outpoint of the HTLC on the commitment
+// transaction. If this is our commitment, then the output can be found within
+// the signed success tx, otherwise, it's just the ClaimOutpoint.
+func (h *htlcSuccessResolver) commitOutPoint() wire.OutPoint {
 	if h.htlcResolution.SignedSuccessTx != nil {
-		op = h.htlcResolution.SignedSuccessTx.TxIn[0].PreviousOutPoint
-	} else {
-		op = h.htlcResolution.ClaimOutpoint
+		return h.htlcResolution.SignedSuccessTx.TxIn[0].PreviousOutPoint
 	}
 
-	key := newResolverID(op)
-	return key[:]
+	return h.htlcResolution.ClaimOutpoint
+}
+
+// report records the outcome of the incoming HTLC.
+func (h *htlcSuccessResolver) report(outcome channeldb.ResolverOutcome,
+	spendTxID *chainhash.Hash) error {
+
+	return h.PutResolverReport(&channeldb.ResolverReport{
+		OutPoint: h.commitOutPoint(),
+		Amount: btcutil.Amount(
+			h.htlcResolution.SweepSignDesc.Output.Value,
+		),
+		ResolverType:    channeldb.ResolverTypeIncomingHtlc,
+		ResolverOutcome: outcome,
+		SpendTxID:       spendTxID,
+	})
 }
 
 // Resolve attempts to resolve an unresolved incoming HTLC that we know the
@@ -570,7 +613,13 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 		}
 
 		// Once the transaction has received a sufficient number of
-		// confirmations, we'll mark ourselves as fully resolved and exit.
+		// confirmations, we'll record the outcome, then mark ourselves
+		// as fully resolved and exit.
+		err = h.report(channeldb.ResolverOutcomeClaimed, &sweepTXID)
+		if err != nil {
+			return nil, err
+		}
+
 		h.resolved = true
 		return nil, h.Checkpoint(h)
 	}
@@ -617,16 +666,23 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 	log.Infof("%T(%x): waiting for second-level HTLC output to be spent "+
 		"after csv_delay=%v", h, h.payHash[:], h.htlcResolution.CsvDelay)
 
+	var spendTxID *chainhash.Hash
 	select {
-	case _, ok := <-spendNtfn.Spend:
+	case spend, ok := <-spendNtfn.Spend:
 		if !ok {
 			return nil, fmt.Errorf("quitting")
 		}
 
+		spendTxID = spend.SpenderTxHash
+
 	case <-h.Quit:
 		return nil, fmt.Errorf("quitting")
 	}
 
+	if err := h.report(channeldb.ResolverOutcomeClaimed, spendTxID); err != nil {
+		return nil, err
+	}
+
 	h.resolved = true
 	return nil, h.Checkpoint(h)
 }
@@ -789,8 +845,8 @@ func (h *htlcOutgoingContestResolver) Resolve() (ContractResolver, error) {
 				h, h.htlcResolution.ClaimOutpoint)
 		}
 
-		// Finally, we'll send the clean up message, mark ourselves as
-		// resolved, then exit.
+		// Finally, we'll send the clean up message, record the
+		// outcome, mark ourselves as resolved, then exit.
 		if err := h.DeliverResolutionMsg(ResolutionMsg{
 			SourceChan: h.ShortChanID,
 			HtlcIndex:  h.htlcIndex,
@@ -798,6 +854,20 @@ func (h *htlcOutgoingContestResolver) Resolve() (ContractResolver, error) {
 		}); err != nil {
 			return nil, err
 		}
+
+		err := h.PutResolverReport(&channeldb.ResolverReport{
+			OutPoint: h.commitOutPoint(),
+			Amount: btcutil.Amount(
+				h.htlcResolution.SweepSignDesc.Output.Value,
+			),
+			ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
+			ResolverOutcome: channeldb.ResolverOutcomeClaimed,
+			SpendTxID:       commitSpend.SpenderTxHash,
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		h.resolved = true
 		return nil, h.Checkpoint(h)
 	}
@@ -1010,6 +1080,12 @@ func (h *htlcIncomingContestResolver) Resolve() (ContractResolver, error) {
 		log.Infof("%T(%v): HTLC has timed out (expiry=%v, height=%v), "+
 			"abandoning", h, h.htlcResolution.ClaimOutpoint,
 			h.htlcExpiry, currentHeight)
+
+		err := h.report(channeldb.ResolverOutcomeAbandoned, nil)
+		if err != nil {
+			return nil, err
+		}
+
 		h.resolved = true
 		return nil, h.Checkpoint(h)
 	}
@@ -1097,6 +1173,14 @@ func (h *htlcIncomingContestResolver) Resolve() (ContractResolver, error) {
 					"(expiry=%v, height=%v), abandoning", h,
 					h.htlcResolution.ClaimOutpoint,
 					h.htlcExpiry, currentHeight)
+
+				err := h.report(
+					channeldb.ResolverOutcomeAbandoned, nil,
+				)
+				if err != nil {
+					return nil, err
+				}
+
 				h.resolved = true
 				return nil, h.Checkpoint(h)
 			}
@@ -1373,7 +1457,21 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	}
 
 	// Once the transaction has received a sufficient number of
-	// confirmations, we'll mark ourselves as fully resolved and exit.
+	// confirmations, we'll record the outcome, then mark ourselves as
+	// fully resolved and exit.
+	err = c.PutResolverReport(&channeldb.ResolverReport{
+		OutPoint: c.commitResolution.SelfOutPoint,
+		Amount: btcutil.Amount(
+			c.commitResolution.SelfOutputSignDesc.Output.Value,
+		),
+		ResolverType:    channeldb.ResolverTypeCommit,
+		ResolverOutcome: channeldb.ResolverOutcomeClaimed,
+		SpendTxID:       &sweepTXID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	c.resolved = true
 	return nil, c.Checkpoint(c)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcutil"
 )
 
 // ValidateChannelAnn validates the channel announcement message and checks
@@ -144,3 +145,31 @@ func ValidateChannelUpdateAnn(pubKey *btcec.PublicKey,
 
 	return nil
 }
+
+// ValidateChannelUpdateFields validates the optional fields of a channel
+// update against the capacity of the channel it applies to. If the max HTLC
+// field is present, it must be non-zero, no smaller than the min HTLC, and no
+// larger than the channel's capacity.
+func ValidateChannelUpdateFields(capacity btcutil.Amount,
+	a *lnwire.ChannelUpdate) error {
+
+	if !a.MessageFlags.HasMaxHtlc() {
+		return nil
+	}
+
+	maxHtlc := a.HtlcMaximumMsat
+	switch {
+	case maxHtlc == 0:
+		return errors.Errorf("htlc_maximum_msat of zero is invalid")
+
+	case maxHtlc < a.HtlcMinimumMsat:
+		return errors.Errorf("htlc_maximum_msat=%v is below "+
+			"htlc_minimum_msat=%v", maxHtlc, a.HtlcMinimumMsat)
+
+	case capacity != 0 && maxHtlc > lnwire.NewMSatFromSatoshis(capacity):
+		return errors.Errorf("htlc_maximum_msat=%v exceeds channel "+
+			"capacity of %v", maxHtlc, capacity)
+	}
+
+	return nil
+}
@@ -9,11 +9,12 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/multimutex"
@@ -32,6 +33,12 @@ var (
 	messageStoreKey = []byte("message-store")
 )
 
+const (
+	// DefaultNodeAnnUpdateInterval is the default minimum duration between
+	// broadcasts of our own node announcement.
+	DefaultNodeAnnUpdateInterval = time.Minute
+)
+
 // networkMsg couples a routing related wire message with the peer that
 // originally sent it.
 type networkMsg struct {
@@ -54,6 +61,17 @@ type chanPolicyUpdateRequest struct {
 	errResp chan error
 }
 
+// chanStatusUpdateRequest is a request that is sent to the gossiper when a
+// caller wishes to enable or disable one of our channels. A new ChannelUpdate
+// reflecting the new status will be crafted and sent out during the next
+// broadcast epoch.
+type chanStatusUpdateRequest struct {
+	chanPoint wire.OutPoint
+	disabled  bool
+
+	errResp chan error
+}
+
 // Config defines the configuration for the service. ALL elements within the
 // configuration MUST be non-nil for the service to carry out its duties.
 type Config struct {
@@ -105,6 +123,11 @@ type Config struct {
 	// the last trickle tick.
 	TrickleDelay time.Duration
 
+	// NodeAnnUpdateInterval is the minimum duration between broadcasts of
+	// our own node announcement. Updates made within it are coalesced,
+	// and only the latest one is broadcast once it has elapsed.
+	NodeAnnUpdateInterval time.Duration
+
 	// RetransmitDelay is the period of a timer which indicates that we
 	// should check if we need re-broadcast any of our personal channels.
 	RetransmitDelay time.Duration
@@ -121,6 +144,21 @@ type Config struct {
 	// TODO(roasbeef): extract ann crafting + sign from fundingMgr into
 	// here?
 	AnnSigner lnwallet.MessageSigner
+
+	// Clock is the time source used to drive the trickle and retransmit
+	// timers, and to timestamp our own channel updates.
+	Clock clock.Clock
+
+	// ChannelPruneExpiry is the duration after which the router prunes a
+	// channel as a zombie if neither of its directions has been updated.
+	// A zombie channel is only resurrected by a channel update newer than
+	// this.
+	ChannelPruneExpiry time.Duration
+
+	// PeerMisbehaved, if set, is called whenever a remote peer sends us
+	// an announcement that fails validation, along with the reason it
+	// was rejected.
+	PeerMisbehaved func(peer *btcec.PublicKey, reason string)
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -180,6 +218,14 @@ type AuthenticatedGossiper struct {
 	// forwarding policy of a set of channels is sent over.
 	chanPolicyUpdates chan *chanPolicyUpdateRequest
 
+	// chanStatusUpdates is a channel that requests to enable or disable
+	// one of our channels is sent over.
+	chanStatusUpdates chan *chanStatusUpdateRequest
+
+	// nodeAnnUpdates is a channel that updates of our own node
+	// announcement to be broadcast are sent over.
+	nodeAnnUpdates chan *lnwire.NodeAnnouncement
+
 	// bestHeight is the height of the block at the tip of the main chain
 	// as we know it.
 	bestHeight uint32
@@ -213,6 +259,8 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		networkMsgs:             make(chan *networkMsg),
 		quit:                    make(chan struct{}),
 		chanPolicyUpdates:       make(chan *chanPolicyUpdateRequest),
+		chanStatusUpdates:       make(chan *chanStatusUpdateRequest),
+		nodeAnnUpdates:          make(chan *lnwire.NodeAnnouncement),
 		prematureAnnouncements:  make(map[uint32][]*networkMsg),
 		prematureChannelUpdates: make(map[uint64][]*networkMsg),
 		waitingProofs:           storage,
@@ -354,6 +402,45 @@ func (d *AuthenticatedGossiper) PropagateChanPolicyUpdate(
 	}
 }
 
+// PropagateChanStatusUpdate signals the AuthenticatedGossiper to enable or
+// disable the outgoing direction of the channel identified by the passed
+// channel point. If the channel is already in the target state, then this is
+// a no-op. Otherwise, a new ChannelUpdate reflecting the status is signed,
+// committed to the graph, and broadcast to the network.
+func (d *AuthenticatedGossiper) PropagateChanStatusUpdate(
+	chanPoint wire.OutPoint, disabled bool) error {
+
+	errChan := make(chan error, 1)
+	statusUpdate := &chanStatusUpdateRequest{
+		chanPoint: chanPoint,
+		disabled:  disabled,
+		errResp:   errChan,
+	}
+
+	select {
+	case d.chanStatusUpdates <- statusUpdate:
+		return <-errChan
+	case <-d.quit:
+		return fmt.Errorf("AuthenticatedGossiper shutting down")
+	}
+}
+
+// PropagateNodeAnnouncement signals the AuthenticatedGossiper to broadcast an
+// update of our own node announcement, which must already be signed and
+// committed to the graph. To avoid flooding the network, broadcasts are rate
+// limited to one per NodeAnnUpdateInterval, with the latest update replacing
+// any that's still pending.
+func (d *AuthenticatedGossiper) PropagateNodeAnnouncement(
+	nodeAnn *lnwire.NodeAnnouncement) error {
+
+	select {
+	case d.nodeAnnUpdates <- nodeAnn:
+		return nil
+	case <-d.quit:
+		return fmt.Errorf("AuthenticatedGossiper shutting down")
+	}
+}
+
 // Start spawns network messages handler goroutine and registers on new block
 // notifications in order to properly handle the premature announcements.
 func (d *AuthenticatedGossiper) Start() error {
@@ -455,7 +542,7 @@ func (d *AuthenticatedGossiper) ProcessLocalAnnouncement(msg lnwire.Message,
 }
 
 // channelUpdateID is a unique identifier for ChannelUpdate messages, as
-// channel updates can be identified by the (ShortChannelID, Flags)
+// channel updates can be identified by the (ShortChannelID, ChannelFlags)
 // tuple.
 type channelUpdateID struct {
 	// channelID represents the set of data which is needed to
@@ -465,7 +552,7 @@ type channelUpdateID struct {
 	// Flags least-significant bit must be set to 0 if the creating node
 	// corresponds to the first node in the previously sent channel
 	// announcement and 1 otherwise.
-	flags lnwire.ChanUpdateFlag
+	flags lnwire.ChanUpdateChanFlags
 }
 
 // msgWithSenders is a wrapper struct around a message, and the set of peers
@@ -560,7 +647,7 @@ func (d *deDupedAnnouncements) addMsg(message networkMsg) {
 		sender := routing.NewVertex(message.peer)
 		deDupKey := channelUpdateID{
 			msg.ShortChannelID,
-			msg.Flags,
+			msg.ChannelFlags,
 		}
 
 		oldTimestamp := uint32(0)
@@ -711,7 +798,7 @@ func (d *AuthenticatedGossiper) resendAnnounceSignatures() error {
 	// TODO(halseth): database access should be abstracted
 	// behind interface.
 	var msgsResend []msgTuple
-	if err := d.cfg.DB.View(func(tx *bolt.Tx) error {
+	if err := d.cfg.DB.View(func(tx kvdb.Tx) error {
 		bucket := tx.Bucket(messageStoreKey)
 		if bucket == nil {
 			return nil
@@ -753,7 +840,7 @@ func (d *AuthenticatedGossiper) resendAnnounceSignatures() error {
 	deleteMsg := func(t msgTuple) error {
 		log.Debugf("Deleting message for chanID=%v from "+
 			"messageStore", t.msg.ChannelID)
-		if err := d.cfg.DB.Update(func(tx *bolt.Tx) error {
+		if err := d.cfg.DB.Update(func(tx kvdb.Tx) error {
 			bucket := tx.Bucket(messageStoreKey)
 			if bucket == nil {
 				return fmt.Errorf("bucket " +
@@ -836,11 +923,15 @@ func (d *AuthenticatedGossiper) networkHandler() {
 	announcements := deDupedAnnouncements{}
 	announcements.Reset()
 
-	retransmitTimer := time.NewTicker(d.cfg.RetransmitDelay)
-	defer retransmitTimer.Stop()
+	retransmitTimer := d.cfg.Clock.TickAfter(d.cfg.RetransmitDelay)
+	trickleTimer := d.cfg.Clock.TickAfter(d.cfg.TrickleDelay)
 
-	trickleTimer := time.NewTicker(d.cfg.TrickleDelay)
-	defer trickleTimer.Stop()
+	// pendingNodeAnn is the latest update of our own node announcement
+	// that has yet to be broadcast, as these are rate limited.
+	var (
+		pendingNodeAnn       *lnwire.NodeAnnouncement
+		lastNodeAnnBroadcast time.Time
+	)
 
 	// To start, we'll first check to see if there are any stale channels
 	// that we need to re-transmit.
@@ -879,6 +970,30 @@ func (d *AuthenticatedGossiper) networkHandler() {
 
 			policyUpdate.errResp <- nil
 
+		// A request to enable or disable one of our channels has
+		// arrived. We'll craft, sign and commit the new ChannelUpdate,
+		// then add it to the batch to be broadcast.
+		case statusUpdate := <-d.chanStatusUpdates:
+			newChanUpdates, err := d.processChanStatusUpdate(
+				statusUpdate,
+			)
+			if err != nil {
+				log.Errorf("Unable to craft status update: %v",
+					err)
+				statusUpdate.errResp <- err
+				continue
+			}
+
+			announcements.AddMsgs(newChanUpdates...)
+
+			statusUpdate.errResp <- nil
+
+		// An update of our own node announcement has arrived. We'll
+		// hold on to it until the next trickle tick at which we're
+		// allowed to broadcast it, replacing any prior pending one.
+		case nodeAnn := <-d.nodeAnnUpdates:
+			pendingNodeAnn = nodeAnn
+
 		case announcement := <-d.networkMsgs:
 			// Channel announcement signatures are the only message
 			// that we'll process serially.
@@ -979,7 +1094,25 @@ func (d *AuthenticatedGossiper) networkHandler() {
 		// The trickle timer has ticked, which indicates we should
 		// flush to the network the pending batch of new announcements
 		// we've received since the last trickle tick.
-		case <-trickleTimer.C:
+		case <-trickleTimer:
+			trickleTimer = d.cfg.Clock.TickAfter(d.cfg.TrickleDelay)
+
+			// If enough time has passed since we last broadcast our
+			// own node announcement, we'll add the pending update
+			// to the batch.
+			now := d.cfg.Clock.Now()
+			sinceLast := now.Sub(lastNodeAnnBroadcast)
+			if pendingNodeAnn != nil &&
+				sinceLast >= d.cfg.NodeAnnUpdateInterval {
+
+				announcements.AddMsgs(networkMsg{
+					msg:  pendingNodeAnn,
+					peer: d.selfKey,
+				})
+				pendingNodeAnn = nil
+				lastNodeAnnBroadcast = now
+			}
+
 			// Emit the current batch of announcements from
 			// deDupedAnnouncements.
 			announcementBatch := announcements.Emit()
@@ -1011,7 +1144,11 @@ func (d *AuthenticatedGossiper) networkHandler() {
 		// personal channels. This addresses the case of "zombie" channels and
 		// channel advertisements that have been dropped, or not properly
 		// propagated through the network.
-		case <-retransmitTimer.C:
+		case <-retransmitTimer:
+			retransmitTimer = d.cfg.Clock.TickAfter(
+				d.cfg.RetransmitDelay,
+			)
+
 			if err := d.retransmitStaleChannels(); err != nil {
 				log.Errorf("unable to rebroadcast stale "+
 					"channels: %v", err)
@@ -1074,7 +1211,7 @@ func (d *AuthenticatedGossiper) retransmitStaleChannels() error {
 
 		const broadcastInterval = time.Hour * 24
 
-		timeElapsed := time.Since(edge.LastUpdate)
+		timeElapsed := d.cfg.Clock.Now().Sub(edge.LastUpdate)
 
 		// If it's been a full day since we've re-broadcasted the
 		// channel, add the channel to the set of edges we need to
@@ -1217,6 +1354,74 @@ func (d *AuthenticatedGossiper) processChanPolicyUpdate(
 	return chanUpdates, nil
 }
 
+// processChanStatusUpdate generates a new channel update for the channel
+// identified by the request's channel point, with the disabled bit set
+// according to the request. If the channel is already in the requested state,
+// or isn't meant to be announced to the greater network, then no update is
+// generated.
+func (d *AuthenticatedGossiper) processChanStatusUpdate(
+	statusUpdate *chanStatusUpdateRequest) ([]networkMsg, error) {
+
+	var (
+		chanInfo *channeldb.ChannelEdgeInfo
+		chanEdge *channeldb.ChannelEdgePolicy
+	)
+	err := d.cfg.Router.ForAllOutgoingChannels(func(
+		info *channeldb.ChannelEdgeInfo,
+		edge *channeldb.ChannelEdgePolicy) error {
+
+		if info.ChannelPoint == statusUpdate.chanPoint {
+			chanInfo = info
+			chanEdge = edge
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// If we don't know of the channel yet, e.g. because it hasn't been
+	// fully confirmed, then there's nothing to update.
+	if chanInfo == nil || chanEdge == nil {
+		log.Debugf("Skipping status update for unknown "+
+			"chan_point=%v", statusUpdate.chanPoint)
+		return nil, nil
+	}
+
+	// Private channels aren't announced to the network, so there's no
+	// need to let the network know of their status.
+	if chanInfo.AuthProof == nil {
+		return nil, nil
+	}
+
+	isDisabled := chanEdge.ChannelFlags&lnwire.ChanUpdateDisabled != 0
+	if isDisabled == statusUpdate.disabled {
+		return nil, nil
+	}
+
+	if statusUpdate.disabled {
+		chanEdge.ChannelFlags |= lnwire.ChanUpdateDisabled
+	} else {
+		chanEdge.ChannelFlags &^= lnwire.ChanUpdateDisabled
+	}
+
+	log.Infof("Setting disabled=%v for chan_point=%v",
+		statusUpdate.disabled, statusUpdate.chanPoint)
+
+	_, chanUpdate, err := d.updateChannel(chanInfo, chanEdge)
+	if err != nil {
+		return nil, err
+	}
+
+	// We set ourselves as the source of this message to indicate that we
+	// shouldn't skip any peers when sending this message.
+	return []networkMsg{{
+		peer: d.selfKey,
+		msg:  chanUpdate,
+	}}, nil
+}
+
 // processRejectedEdge examines a rejected edge to see if we can extract any
 // new announcements from it.  An edge will get rejected if we already added
 // the same edge without AuthProof to the graph. If the received announcement
@@ -1338,6 +1543,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 				err := errors.Errorf("unable to validate "+
 					"node announcement: %v", err)
 				log.Error(err)
+				d.reportMisbehavior(nMsg, err)
 				nMsg.err <- err
 				return nil
 			}
@@ -1436,6 +1642,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 				d.rejectMtx.Unlock()
 
 				log.Error(err)
+				d.reportMisbehavior(nMsg, err)
 				nMsg.err <- err
 				return nil
 			}
@@ -1632,7 +1839,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 		// announcement for this edge.
 		timestamp := time.Unix(int64(msg.Timestamp), 0)
 		if d.cfg.Router.IsStaleEdgePolicy(
-			msg.ShortChannelID, timestamp, msg.Flags,
+			msg.ShortChannelID, timestamp, msg.ChannelFlags,
 		) {
 
 			nMsg.err <- nil
@@ -1652,6 +1859,19 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 		chanInfo, _, _, err := d.cfg.Router.GetChannelByID(msg.ShortChannelID)
 		if err != nil {
 			switch err {
+			// If the channel was pruned as a zombie, we'll only
+			// resurrect it if the update is fresh and signed by the
+			// node of the direction being updated. Once
+			// resurrected, the update is held until we receive the
+			// channel's announcement once again.
+			case channeldb.ErrZombieEdge:
+				err := d.processZombieUpdate(chanInfo, msg)
+				if err != nil {
+					log.Debug(err)
+					nMsg.err <- err
+					return nil
+				}
+				fallthrough
 			case channeldb.ErrGraphNotFound:
 				fallthrough
 			case channeldb.ErrGraphNoEdgesFound:
@@ -1700,9 +1920,9 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 		// edge is being updated.
 		var pubKey *btcec.PublicKey
 		switch {
-		case msg.Flags&lnwire.ChanUpdateDirection == 0:
+		case msg.ChannelFlags&lnwire.ChanUpdateDirection == 0:
 			pubKey, _ = chanInfo.NodeKey1()
-		case msg.Flags&lnwire.ChanUpdateDirection == 1:
+		case msg.ChannelFlags&lnwire.ChanUpdateDirection == 1:
 			pubKey, _ = chanInfo.NodeKey2()
 		}
 
@@ -1715,6 +1935,21 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 				spew.Sdump(msg.ShortChannelID), err)
 
 			log.Error(rErr)
+			d.reportMisbehavior(nMsg, rErr)
+			nMsg.err <- rErr
+			return nil
+		}
+
+		// We'll also ensure that the optional fields of the update,
+		// such as the max HTLC, are sane for this channel.
+		err = ValidateChannelUpdateFields(chanInfo.Capacity, msg)
+		if err != nil {
+			rErr := errors.Errorf("invalid channel update for "+
+				"short_chan_id=%v: %v",
+				spew.Sdump(msg.ShortChannelID), err)
+
+			log.Error(rErr)
+			d.reportMisbehavior(nMsg, rErr)
 			nMsg.err <- rErr
 			return nil
 		}
@@ -1723,9 +1958,11 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			SigBytes:                  msg.Signature.ToSignatureBytes(),
 			ChannelID:                 shortChanID,
 			LastUpdate:                timestamp,
-			Flags:                     msg.Flags,
+			MessageFlags:              msg.MessageFlags,
+			ChannelFlags:              msg.ChannelFlags,
 			TimeLockDelta:             msg.TimeLockDelta,
 			MinHTLC:                   msg.HtlcMinimumMsat,
+			MaxHTLC:                   msg.HtlcMaximumMsat,
 			FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
 			FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
 		}
@@ -1753,9 +1990,9 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			// Get our peer's public key.
 			var remotePeer *btcec.PublicKey
 			switch {
-			case msg.Flags&lnwire.ChanUpdateDirection == 0:
+			case msg.ChannelFlags&lnwire.ChanUpdateDirection == 0:
 				remotePeer, _ = chanInfo.NodeKey2()
-			case msg.Flags&lnwire.ChanUpdateDirection == 1:
+			case msg.ChannelFlags&lnwire.ChanUpdateDirection == 1:
 				remotePeer, _ = chanInfo.NodeKey1()
 			}
 
@@ -1998,6 +2235,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 				shortChanID, err)
 
 			log.Error(err)
+			d.reportMisbehavior(nMsg, err)
 			nMsg.err <- err
 			return nil
 		}
@@ -2075,7 +2313,7 @@ func (d *AuthenticatedGossiper) sendAnnSigReliably(
 	copy(key[:33], remotePeer.SerializeCompressed())
 	binary.BigEndian.PutUint64(key[33:], msg.ShortChannelID.ToUint64())
 
-	err := d.cfg.DB.Update(func(tx *bolt.Tx) error {
+	err := d.cfg.DB.Update(func(tx kvdb.Tx) error {
 		bucket, err := tx.CreateBucketIfNotExists(messageStoreKey)
 		if err != nil {
 			return err
@@ -2153,7 +2391,7 @@ func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 
 	// Make sure timestamp is always increased, such that our update gets
 	// propagated.
-	timestamp := time.Now().Unix()
+	timestamp := d.cfg.Clock.Now().Unix()
 	if timestamp <= edge.LastUpdate.Unix() {
 		timestamp = edge.LastUpdate.Unix() + 1
 	}
@@ -2162,9 +2400,11 @@ func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 		ChainHash:       info.ChainHash,
 		ShortChannelID:  lnwire.NewShortChanIDFromInt(edge.ChannelID),
 		Timestamp:       uint32(timestamp),
-		Flags:           edge.Flags,
+		MessageFlags:    edge.MessageFlags,
+		ChannelFlags:    edge.ChannelFlags,
 		TimeLockDelta:   edge.TimeLockDelta,
 		HtlcMinimumMsat: edge.MinHTLC,
+		HtlcMaximumMsat: edge.MaxHTLC,
 		BaseFee:         uint32(edge.FeeBaseMSat),
 		FeeRate:         uint32(edge.FeeProportionalMillionths),
 	}
@@ -2244,3 +2484,57 @@ func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 
 	return chanAnn, chanUpdate, err
 }
+
+// processZombieUpdate determines whether the given channel update for a zombie
+// channel should resurrect it, which is the case if the update was signed by
+// the node of the direction being updated, and is recent enough that the
+// channel wouldn't be pruned again. If so, the channel's zombie mark is
+// cleared, allowing its announcement to be accepted again.
+func (d *AuthenticatedGossiper) processZombieUpdate(
+	chanInfo *channeldb.ChannelEdgeInfo, msg *lnwire.ChannelUpdate) error {
+
+	// The least-significant bit in the flag on the channel update tells us
+	// which node of the channel it should be signed by.
+	pubKeyBytes := chanInfo.NodeKey1Bytes
+	if msg.ChannelFlags&lnwire.ChanUpdateDirection == 1 {
+		pubKeyBytes = chanInfo.NodeKey2Bytes
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes[:], btcec.S256())
+	if err != nil {
+		return fmt.Errorf("unable to parse node key of zombie "+
+			"channel %v: %v", msg.ShortChannelID, err)
+	}
+
+	if err := ValidateChannelUpdateAnn(pubKey, msg); err != nil {
+		return fmt.Errorf("unable to validate channel update for "+
+			"zombie channel %v: %v", msg.ShortChannelID, err)
+	}
+
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+	if d.cfg.Clock.Now().Sub(timestamp) >= d.cfg.ChannelPruneExpiry {
+		return fmt.Errorf("ignoring stale channel update for zombie "+
+			"channel %v", msg.ShortChannelID)
+	}
+
+	err = d.cfg.Router.MarkEdgeLive(msg.ShortChannelID)
+	if err != nil && err != channeldb.ErrZombieEdgeNotFound {
+		return fmt.Errorf("unable to resurrect zombie channel %v: %v",
+			msg.ShortChannelID, err)
+	}
+
+	log.Infof("Resurrected zombie channel %v", msg.ShortChannelID)
+
+	return nil
+}
+
+// reportMisbehavior reports the sender of the passed message as having sent
+// us an invalid announcement, unless the message originated locally.
+func (d *AuthenticatedGossiper) reportMisbehavior(nMsg *networkMsg,
+	err error) {
+
+	if !nMsg.isRemote || d.cfg.PeerMisbehaved == nil {
+		return
+	}
+
+	d.cfg.PeerMisbehaved(nMsg.peer, err.Error())
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/roasbeef/btcd/btcec"
@@ -111,6 +112,7 @@ type mockGraphSource struct {
 	nodes      []*channeldb.LightningNode
 	infos      map[uint64]*channeldb.ChannelEdgeInfo
 	edges      map[uint64][]*channeldb.ChannelEdgePolicy
+	zombies    map[uint64][2][33]byte
 	bestHeight uint32
 }
 
@@ -119,6 +121,7 @@ func newMockRouter(height uint32) *mockGraphSource {
 		bestHeight: height,
 		infos:      make(map[uint64]*channeldb.ChannelEdgeInfo),
 		edges:      make(map[uint64][]*channeldb.ChannelEdgePolicy),
+		zombies:    make(map[uint64][2][33]byte),
 	}
 }
 
@@ -184,7 +187,16 @@ func (r *mockGraphSource) GetChannelByID(chanID lnwire.ShortChannelID) (
 
 	chanInfo, ok := r.infos[chanID.ToUint64()]
 	if !ok {
-		return nil, nil, nil, channeldb.ErrEdgeNotFound
+		pubKeys, isZombie := r.zombies[chanID.ToUint64()]
+		if !isZombie {
+			return nil, nil, nil, channeldb.ErrEdgeNotFound
+		}
+
+		return &channeldb.ChannelEdgeInfo{
+			ChannelID:     chanID.ToUint64(),
+			NodeKey1Bytes: pubKeys[0],
+			NodeKey2Bytes: pubKeys[1],
+		}, nil, nil, channeldb.ErrZombieEdge
 	}
 
 	edges := r.edges[chanID.ToUint64()]
@@ -216,13 +228,24 @@ func (r *mockGraphSource) IsStaleNode(nodePub routing.Vertex, timestamp time.Tim
 // channel ID.
 func (r *mockGraphSource) IsKnownEdge(chanID lnwire.ShortChannelID) bool {
 	_, ok := r.infos[chanID.ToUint64()]
-	return ok
+	_, isZombie := r.zombies[chanID.ToUint64()]
+	return ok || isZombie
+}
+
+// MarkEdgeLive clears the zombie mark of the channel with the given ID.
+func (r *mockGraphSource) MarkEdgeLive(chanID lnwire.ShortChannelID) error {
+	if _, ok := r.zombies[chanID.ToUint64()]; !ok {
+		return channeldb.ErrZombieEdgeNotFound
+	}
+
+	delete(r.zombies, chanID.ToUint64())
+	return nil
 }
 
 // IsStaleEdgePolicy returns true if the graph source has a channel edge for
 // the passed channel ID (and flags) that have a more recent timestamp.
 func (r *mockGraphSource) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
-	timestamp time.Time, flags lnwire.ChanUpdateFlag) bool {
+	timestamp time.Time, flags lnwire.ChanUpdateChanFlags) bool {
 
 	edges, ok := r.edges[chanID.ToUint64()]
 	if !ok {
@@ -231,10 +254,10 @@ func (r *mockGraphSource) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
 
 	switch {
 
-	case len(edges) >= 1 && edges[0].Flags == flags:
+	case len(edges) >= 1 && edges[0].ChannelFlags == flags:
 		return !edges[0].LastUpdate.Before(timestamp)
 
-	case len(edges) >= 2 && edges[1].Flags == flags:
+	case len(edges) >= 2 && edges[1].ChannelFlags == flags:
 		return !edges[1].LastUpdate.Before(timestamp)
 
 	default:
@@ -401,7 +424,8 @@ func createNodeAnnouncement(priv *btcec.PrivateKey,
 	return a, nil
 }
 
-func createUpdateAnnouncement(blockHeight uint32, flags lnwire.ChanUpdateFlag,
+func createUpdateAnnouncement(blockHeight uint32,
+	flags lnwire.ChanUpdateChanFlags,
 	nodeKey *btcec.PrivateKey, timestamp uint32) (*lnwire.ChannelUpdate,
 	error) {
 
@@ -413,7 +437,7 @@ func createUpdateAnnouncement(blockHeight uint32, flags lnwire.ChanUpdateFlag,
 		},
 		Timestamp:       timestamp,
 		TimeLockDelta:   uint16(prand.Int63()),
-		Flags:           flags,
+		ChannelFlags:    flags,
 		HtlcMinimumMsat: lnwire.MilliSatoshi(prand.Int63()),
 		FeeRate:         uint32(prand.Int31()),
 		BaseFee:         uint32(prand.Int31()),
@@ -540,6 +564,10 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 		RetransmitDelay:  retransmitDelay,
 		ProofMatureDelta: proofMatureDelta,
 		DB:               db,
+		Clock:            clock.NewDefaultClock(),
+
+		ChannelPruneExpiry:    routing.DefaultChannelPruneExpiry,
+		NodeAnnUpdateInterval: time.Hour,
 	}, nodeKeyPub1)
 	if err != nil {
 		cleanUpDb()
@@ -1424,6 +1452,10 @@ func TestSignatureAnnouncementRetryAtStartup(t *testing.T) {
 		RetransmitDelay:  retransmitDelay,
 		ProofMatureDelta: proofMatureDelta,
 		DB:               ctx.gossiper.cfg.DB,
+		Clock:            ctx.gossiper.cfg.Clock,
+
+		ChannelPruneExpiry:    ctx.gossiper.cfg.ChannelPruneExpiry,
+		NodeAnnUpdateInterval: ctx.gossiper.cfg.NodeAnnUpdateInterval,
 	}, ctx.gossiper.selfKey)
 	if err != nil {
 		t.Fatalf("unable to recreate gossiper: %v", err)
@@ -1757,7 +1789,7 @@ func TestDeDuplicatedAnnouncements(t *testing.T) {
 	assertChannelUpdate := func(channelUpdate *lnwire.ChannelUpdate) {
 		channelKey := channelUpdateID{
 			ua3.ShortChannelID,
-			ua3.Flags,
+			ua3.ChannelFlags,
 		}
 
 		mws, ok := announcements.channelUpdates[channelKey]
@@ -2069,3 +2101,186 @@ func TestReceiveRemoteChannelUpdateFirst(t *testing.T) {
 		t.Fatal("waiting proof should be removed from storage")
 	}
 }
+
+// TestZombieEdgeResurrection asserts that announcements for a zombie channel
+// are ignored, and that the channel is only resurrected by a fresh channel
+// update signed by the node of the direction being updated.
+func TestZombieEdgeResurrection(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	chanAnn, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	chanID := chanAnn.ShortChannelID.ToUint64()
+
+	// We'll start by marking the channel as a zombie, as the router would
+	// once it prunes it.
+	var pubKeys [2][33]byte
+	copy(pubKeys[0][:], nodeKeyPub1.SerializeCompressed())
+	copy(pubKeys[1][:], nodeKeyPub2.SerializeCompressed())
+	ctx.router.zombies[chanID] = pubKeys
+
+	assertZombie := func(isZombie bool) {
+		t.Helper()
+
+		if _, ok := ctx.router.zombies[chanID]; ok != isZombie {
+			t.Fatalf("expected zombie=%v, got %v", isZombie, ok)
+		}
+	}
+
+	// The announcement of the zombie channel should be ignored.
+	select {
+	case err = <-ctx.gossiper.ProcessRemoteAnnouncement(chanAnn, nodeKeyPub2):
+	case <-time.After(2 * time.Second):
+		t.Fatal("remote announcement not processed")
+	}
+	if err != nil {
+		t.Fatalf("unable to process announcement: %v", err)
+	}
+	if _, ok := ctx.router.infos[chanID]; ok {
+		t.Fatal("zombie channel was added to the graph")
+	}
+
+	// Neither a stale update, nor an update signed by the wrong node,
+	// should resurrect the channel.
+	now := uint32(time.Now().Unix())
+	staleTimestamp := now - uint32(
+		routing.DefaultChannelPruneExpiry/time.Second,
+	) - 1
+
+	staleUpdate, err := createUpdateAnnouncement(
+		0, 1, nodeKeyPriv2, staleTimestamp,
+	)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	forgedUpdate, err := createUpdateAnnouncement(0, 1, nodeKeyPriv1, now)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	for _, update := range []*lnwire.ChannelUpdate{
+		staleUpdate, forgedUpdate,
+	} {
+		select {
+		case err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+			update, nodeKeyPub2,
+		):
+		case <-time.After(2 * time.Second):
+			t.Fatal("remote announcement not processed")
+		}
+		if err == nil {
+			t.Fatal("expected update for zombie channel to be " +
+				"rejected")
+		}
+		assertZombie(true)
+	}
+
+	// A fresh update signed by the second node should resurrect the
+	// channel, and be held until the channel is announced once again.
+	update, err := createUpdateAnnouncement(0, 1, nodeKeyPriv2, now)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	select {
+	case err = <-ctx.gossiper.ProcessRemoteAnnouncement(update, nodeKeyPub2):
+	case <-time.After(2 * time.Second):
+		t.Fatal("remote announcement not processed")
+	}
+	if err != nil {
+		t.Fatalf("unable to process update: %v", err)
+	}
+	assertZombie(false)
+
+	select {
+	case err = <-ctx.gossiper.ProcessRemoteAnnouncement(chanAnn, nodeKeyPub2):
+	case <-time.After(2 * time.Second):
+		t.Fatal("remote announcement not processed")
+	}
+	if err != nil {
+		t.Fatalf("unable to process announcement: %v", err)
+	}
+	if _, ok := ctx.router.infos[chanID]; !ok {
+		t.Fatal("resurrected channel wasn't added to the graph")
+	}
+
+	// The held update should be applied and broadcast once the
+	// announcement has been processed.
+	timeout := time.After(2 * time.Second)
+	for {
+		var msg msgWithSenders
+		select {
+		case msg = <-ctx.broadcastedMessage:
+		case <-timeout:
+			t.Fatal("channel update of resurrected channel wasn't " +
+				"broadcast")
+		}
+
+		if msg.msg == update {
+			break
+		}
+	}
+
+	if len(ctx.router.edges[chanID]) != 1 {
+		t.Fatal("channel update of resurrected channel wasn't applied")
+	}
+}
+
+// TestPropagateNodeAnnouncement asserts that updates of our own node
+// announcement are broadcast, but no more than once per update interval.
+func TestPropagateNodeAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	timestamp := uint32(time.Now().Unix())
+	nodeAnns := make([]*lnwire.NodeAnnouncement, 3)
+	for i := range nodeAnns {
+		nodeAnns[i], err = createNodeAnnouncement(
+			nodeKeyPriv1, timestamp+uint32(i),
+		)
+		if err != nil {
+			t.Fatalf("can't create node announcement: %v", err)
+		}
+	}
+
+	// As we haven't broadcast our node announcement before, the first
+	// update should be broadcast right away.
+	if err := ctx.gossiper.PropagateNodeAnnouncement(nodeAnns[0]); err != nil {
+		t.Fatalf("unable to propagate node announcement: %v", err)
+	}
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		if msg.msg != nodeAnns[0] {
+			t.Fatalf("unexpected message broadcast: %v", msg.msg)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("node announcement wasn't broadcast")
+	}
+
+	// Any further updates within the update interval should be held back.
+	for _, nodeAnn := range nodeAnns[1:] {
+		err := ctx.gossiper.PropagateNodeAnnouncement(nodeAnn)
+		if err != nil {
+			t.Fatalf("unable to propagate node announcement: %v",
+				err)
+		}
+	}
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("node announcement update was broadcast before the "+
+			"update interval elapsed: %v", msg.msg)
+	case <-time.After(2 * trickleDelay):
+	}
+}
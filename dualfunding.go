@@ -0,0 +1,536 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// interactiveTxMsg couples one of the messages used to construct the funding
+// transaction of a dual funded channel with the peer who sent it. This allows
+// the message to be processed by the fundingManager's reservationCoordinator.
+type interactiveTxMsg struct {
+	msg         lnwire.Message
+	peerAddress *lnwire.NetAddress
+}
+
+// processInteractiveTxMsg sends a message used to construct the funding
+// transaction of a dual funded channel to the fundingManager.
+func (f *fundingManager) processInteractiveTxMsg(msg lnwire.Message,
+	peerAddress *lnwire.NetAddress) {
+
+	select {
+	case f.fundingMsgs <- &interactiveTxMsg{msg, peerAddress}:
+	case <-f.quit:
+		return
+	}
+}
+
+// fundingPkScript returns the script of the funding output of a channel
+// between the two passed multi-sig keys.
+func fundingPkScript(localKey, remoteKey *btcec.PublicKey) ([]byte, error) {
+	// The script itself doesn't depend on the value of the output, so
+	// we'll use a placeholder amount.
+	_, fundingOutput, err := lnwallet.GenFundingPkScript(
+		localKey.SerializeCompressed(), remoteKey.SerializeCompressed(),
+		1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return fundingOutput.PkScript, nil
+}
+
+// startInteractiveTx kicks off the interactive construction of a dual funded
+// channel's funding transaction once both parties have exchanged their
+// channel parameters. We'll queue our own inputs, and if we're the initiator,
+// send the first message.
+func (f *fundingManager) startInteractiveTx(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
+	// Only the initiator of the channel has a channel for status updates.
+	initiator := resCtx.updates != nil
+
+	ourContribution := resCtx.reservation.OurContribution()
+	fundingScript, err := fundingPkScript(
+		ourContribution.MultiSigKey.PubKey,
+		resCtx.remoteContribution.MultiSigKey.PubKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	resCtx.txBuilder = lnwallet.NewInteractiveTxBuilder(
+		lnwallet.InteractiveTxConfig{
+			ChanID:          lnwire.ChannelID(pendingChanID),
+			Initiator:       initiator,
+			FundingPkScript: fundingScript,
+			FetchInput: func(op *wire.OutPoint) (*wire.TxOut, error) {
+				return f.cfg.Wallet.Cfg.ChainIO.GetUtxo(op, 0)
+			},
+		},
+	)
+
+	for _, txIn := range ourContribution.Inputs {
+		prevOut, err := f.cfg.Wallet.FetchInputInfo(
+			&txIn.PreviousOutPoint,
+		)
+		if err != nil {
+			return err
+		}
+
+		// The remote party won't accept inputs whose witness weight
+		// it's unable to verify up front, so we can only contribute
+		// native P2WKH outputs.
+		if !txscript.IsPayToWitnessPubKeyHash(prevOut.PkScript) {
+			return fmt.Errorf("input %v can't be contributed to a "+
+				"dual funded channel, only native P2WKH "+
+				"outputs are supported", txIn.PreviousOutPoint)
+		}
+
+		resCtx.txBuilder.QueueInput(txIn, prevOut)
+	}
+
+	// The initiator only adds its outputs once the responder has added
+	// all of its own, as it needs to know their size to pay the fees of
+	// the transaction.
+	if !initiator {
+		for _, txOut := range ourContribution.ChangeOutputs {
+			resCtx.txBuilder.QueueOutput(txOut)
+		}
+
+		return nil
+	}
+
+	return f.sendNextInteractiveTxMsg(resCtx, pendingChanID)
+}
+
+// sendNextInteractiveTxMsg sends the next message of the interactive
+// construction to the remote party, finalizing the funding transaction if
+// the construction is complete afterwards.
+func (f *fundingManager) sendNextInteractiveTxMsg(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
+	msg, err := resCtx.txBuilder.NextMessage()
+	if err != nil {
+		return err
+	}
+
+	peerKey := resCtx.peerAddress.IdentityKey
+	if err := f.cfg.SendToPeer(peerKey, msg); err != nil {
+		return err
+	}
+
+	if resCtx.txBuilder.Complete() {
+		return f.finalizeInteractiveTx(resCtx, pendingChanID)
+	}
+
+	return nil
+}
+
+// handleInteractiveTxMsg processes a message used to construct the funding
+// transaction of a dual funded channel.
+func (f *fundingManager) handleInteractiveTxMsg(fmsg *interactiveTxMsg) {
+	peerKey := fmsg.peerAddress.IdentityKey
+
+	var pendingChanID [32]byte
+	switch msg := fmsg.msg.(type) {
+	case *lnwire.TxAddInput:
+		pendingChanID = msg.ChanID
+	case *lnwire.TxAddOutput:
+		pendingChanID = msg.ChanID
+	case *lnwire.TxComplete:
+		pendingChanID = msg.ChanID
+	case *lnwire.TxSignatures:
+		pendingChanID = msg.ChanID
+	}
+
+	resCtx, err := f.getReservationCtx(peerKey, pendingChanID)
+	if err != nil {
+		fndgLog.Warnf("Unable to find reservation (peerID:%v, "+
+			"chanID:%x)", peerKey, pendingChanID[:])
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+
+	if !resCtx.dualFunded || resCtx.txBuilder == nil {
+		err := fmt.Errorf("unexpected %v for pendingID(%x)",
+			fmsg.msg.MsgType(), pendingChanID[:])
+		fndgLog.Errorf(err.Error())
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+
+	if msg, ok := fmsg.msg.(*lnwire.TxSignatures); ok {
+		f.handleTxSignatures(resCtx, pendingChanID, msg)
+		return
+	}
+
+	err = f.processInteractiveTx(resCtx, pendingChanID, fmsg.msg)
+	if err != nil {
+		fndgLog.Errorf("Unable to construct funding transaction for "+
+			"pendingID(%x): %v", pendingChanID[:], err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		if resCtx.txBuilder.Initiator() {
+			resCtx.err <- err
+		}
+	}
+}
+
+// processInteractiveTx applies a message of the remote party to the funding
+// transaction under construction, then responds with our next message.
+func (f *fundingManager) processInteractiveTx(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, msg lnwire.Message) error {
+
+	builder := resCtx.txBuilder
+	if resCtx.txResult != nil {
+		return lnwallet.ErrInteractiveTxComplete
+	}
+
+	// The responder must add all of its inputs and outputs before we add
+	// the funding output, as we're paying the fees for them.
+	_, isComplete := msg.(*lnwire.TxComplete)
+	if builder.Initiator() && builder.HasFundingOutput() && !isComplete {
+		return fmt.Errorf("responder added to funding transaction " +
+			"after funding output")
+	}
+
+	if err := builder.ReceiveMessage(msg); err != nil {
+		return err
+	}
+
+	// If we're the initiator and the responder has nothing left to add,
+	// then we'll add our change along with the funding output.
+	if builder.Initiator() && isComplete && !builder.HasFundingOutput() {
+		err := f.queueFundingOutputs(resCtx, pendingChanID)
+		if err != nil {
+			return err
+		}
+
+		return f.sendNextInteractiveTxMsg(resCtx, pendingChanID)
+	}
+
+	if builder.Complete() {
+		return f.finalizeInteractiveTx(resCtx, pendingChanID)
+	}
+
+	return f.sendNextInteractiveTxMsg(resCtx, pendingChanID)
+}
+
+// queueFundingOutputs queues our change outputs along with the funding output
+// of a dual funded channel we initiated. As the initiator pays the entire fee
+// of the funding transaction, the fee for the inputs and outputs added by the
+// responder is deducted from our change, or if that isn't possible, from our
+// contribution to the channel.
+func (f *fundingManager) queueFundingOutputs(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
+	builder := resCtx.txBuilder
+
+	remoteAmt, err := builder.RemoteAmount()
+	if err != nil {
+		return err
+	}
+
+	remoteVSize := int64((builder.RemoteWeight() + 3) / 4)
+	extraFee := resCtx.fundingFeeRate.FeeForVSize(remoteVSize)
+
+	dustLimit := lnwallet.DefaultDustLimit()
+	ourContribution := resCtx.reservation.OurContribution()
+	for _, change := range ourContribution.ChangeOutputs {
+		changeAmt := btcutil.Amount(change.Value)
+
+		// If paying the fee would leave the change output as dust, then
+		// we'll drop it entirely, with its value going towards the fee.
+		if changeAmt-extraFee <= dustLimit {
+			extraFee -= changeAmt
+			if extraFee < 0 {
+				extraFee = 0
+			}
+			continue
+		}
+
+		changeAmt -= extraFee
+		extraFee = 0
+
+		builder.QueueOutput(wire.NewTxOut(
+			int64(changeAmt), change.PkScript,
+		))
+	}
+
+	localAmt := resCtx.chanAmt - extraFee
+	capacity := localAmt + remoteAmt
+	maxChanSize := f.maxChanSize(resCtx.peerAddress.IdentityKey)
+	if capacity > maxChanSize {
+		return fmt.Errorf("channel capacity of %v exceeds maximum "+
+			"of %v", capacity, maxChanSize)
+	}
+
+	fndgLog.Debugf("Adding funding output for pendingID(%x): "+
+		"local_amt=%v, remote_amt=%v", pendingChanID[:], localAmt,
+		remoteAmt)
+
+	builder.QueueOutput(wire.NewTxOut(
+		int64(capacity), builder.FundingPkScript(),
+	))
+
+	return nil
+}
+
+// finalizeInteractiveTx applies the completed funding transaction to the
+// reservation, allowing both commitment transactions to be created. If we're
+// the initiator, we'll then send our signature for the remote party's
+// commitment transaction.
+func (f *fundingManager) finalizeInteractiveTx(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
+	builder := resCtx.txBuilder
+	res, err := builder.Result()
+	if err != nil {
+		return err
+	}
+
+	maxChanSize := f.maxChanSize(resCtx.peerAddress.IdentityKey)
+	if res.Capacity > maxChanSize {
+		return fmt.Errorf("channel capacity of %v exceeds maximum "+
+			"of %v", res.Capacity, maxChanSize)
+	}
+
+	err = resCtx.reservation.ApplyInteractiveTx(res, builder.Initiator())
+	if err != nil {
+		return err
+	}
+
+	remoteContribution := resCtx.remoteContribution
+	remoteContribution.Inputs = res.RemoteInputs
+	remoteContribution.ChangeOutputs = res.RemoteChange
+	remoteContribution.FundingAmount = res.RemoteAmount
+
+	err = resCtx.reservation.ProcessContribution(remoteContribution)
+	if err != nil {
+		return err
+	}
+	resCtx.txResult = res
+
+	fndgLog.Infof("Constructed funding transaction for pendingID(%x): "+
+		"capacity=%v, local_amt=%v, remote_amt=%v", pendingChanID[:],
+		res.Capacity, res.LocalAmount, res.RemoteAmount)
+
+	if builder.Initiator() {
+		f.sendFundingCreated(resCtx, pendingChanID)
+	}
+
+	return nil
+}
+
+// newTxSignatures creates a TxSignatures message carrying the witnesses for
+// our inputs to the passed funding transaction.
+func newTxSignatures(pendingChanID [32]byte, fundingTx *wire.MsgTx,
+	inputScripts []*lnwallet.InputScript) *lnwire.TxSignatures {
+
+	witnesses := make([]wire.TxWitness, 0, len(inputScripts))
+	for _, inputScript := range inputScripts {
+		witnesses = append(witnesses, inputScript.Witness)
+	}
+
+	return &lnwire.TxSignatures{
+		ChanID:    lnwire.ChannelID(pendingChanID),
+		TxHash:    fundingTx.TxHash(),
+		Witnesses: witnesses,
+	}
+}
+
+// handleDualFundingCreated processes the initiator's signature for our
+// commitment transaction of a dual funded channel. Once verified, we'll send
+// our own commitment signature, followed by our signatures for the funding
+// transaction.
+func (f *fundingManager) handleDualFundingCreated(resCtx *reservationWithCtx,
+	fmsg *fundingCreatedMsg) {
+
+	peerKey := fmsg.peerAddress.IdentityKey
+	pendingChanID := fmsg.msg.PendingChannelID
+
+	if resCtx.txResult == nil {
+		err := fmt.Errorf("funding transaction for pendingID(%x) not "+
+			"yet constructed", pendingChanID[:])
+		fndgLog.Errorf(err.Error())
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+
+	// As we've constructed the funding transaction ourselves, the funding
+	// outpoint sent by the initiator must match our own.
+	fundingOut := resCtx.reservation.FundingOutpoint()
+	if fmsg.msg.FundingPoint != *fundingOut {
+		err := fmt.Errorf("funding outpoint mismatch: expected %v, "+
+			"got %v", fundingOut, fmsg.msg.FundingPoint)
+		fndgLog.Errorf(err.Error())
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+
+	// Before releasing any of our signatures, we must ensure that we hold
+	// a valid commitment transaction, and that the channel is persisted,
+	// as the funding transaction may be broadcast as soon as we do.
+	commitSig := fmsg.msg.CommitSig.ToSignatureBytes()
+	channel, err := resCtx.reservation.SyncPending(commitSig)
+	if err != nil {
+		fndgLog.Errorf("Unable to persist pending channel for "+
+			"pendingID(%x): %v", pendingChanID[:], err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+	resCtx.remoteCommitSig = commitSig
+	resCtx.pendingChannel = channel
+
+	inputScripts, sig := resCtx.reservation.OurSignatures()
+	ourCommitSig, err := lnwire.NewSigFromRawSignature(sig)
+	if err != nil {
+		fndgLog.Errorf("unable to parse signature: %v", err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+
+	channelID := lnwire.NewChanIDFromOutPoint(fundingOut)
+	fundingSigned := &lnwire.FundingSigned{
+		ChanID:    channelID,
+		CommitSig: ourCommitSig,
+	}
+	txSigs := newTxSignatures(
+		pendingChanID, resCtx.reservation.FinalFundingTx(),
+		inputScripts,
+	)
+	if err := f.cfg.SendToPeer(peerKey, fundingSigned, txSigs); err != nil {
+		fndgLog.Errorf("unable to send FundingSigned message: %v", err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+}
+
+// watchReleasedChannel is called when the funding flow of a dual funded
+// channel is canceled after we've released our signatures for its funding
+// transaction. As the remote party is still able to broadcast it, we'll keep
+// the pending channel around and resume the opening process should the
+// funding transaction confirm.
+func (f *fundingManager) watchReleasedChannel(resCtx *reservationWithCtx) {
+	channel := resCtx.pendingChannel
+	chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
+
+	fndgLog.Infof("Funding flow for ChannelPoint(%v) canceled after "+
+		"releasing our signatures, watching for funding transaction",
+		channel.FundingOutpoint)
+
+	err := f.cfg.WatchNewChannel(channel, resCtx.peerAddress)
+	if err != nil {
+		fndgLog.Errorf("Unable to send new ChannelPoint(%v) for "+
+			"arbitration: %v", channel.FundingOutpoint, err)
+	}
+
+	f.barrierMtx.Lock()
+	if _, ok := f.newChanBarriers[chanID]; !ok {
+		f.newChanBarriers[chanID] = make(chan struct{})
+	}
+	f.barrierMtx.Unlock()
+
+	f.localDiscoveryMtx.Lock()
+	if _, ok := f.localDiscoverySignals[chanID]; !ok {
+		f.localDiscoverySignals[chanID] = make(chan struct{})
+	}
+	f.localDiscoveryMtx.Unlock()
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		f.watchPendingChannel(channel)
+	}()
+}
+
+// handleTxSignatures processes the remote party's signatures for their inputs
+// to the funding transaction of a dual funded channel. With these, we're able
+// to complete the reservation and broadcast the funding transaction. If we're
+// the initiator, we'll then send our own signatures to the responder.
+func (f *fundingManager) handleTxSignatures(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, msg *lnwire.TxSignatures) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
+	initiator := resCtx.txBuilder.Initiator()
+
+	fail := func(err error) {
+		fndgLog.Errorf("Unable to complete dual funded reservation for "+
+			"pendingID(%x): %v", pendingChanID[:], err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		if initiator {
+			resCtx.err <- err
+		}
+	}
+
+	if resCtx.txResult == nil || resCtx.remoteCommitSig == nil {
+		fail(fmt.Errorf("received funding transaction signatures " +
+			"before commitment signature"))
+		return
+	}
+
+	fundingTx := resCtx.reservation.FinalFundingTx()
+	if msg.TxHash != fundingTx.TxHash() {
+		fail(fmt.Errorf("signatures are for transaction %v, expected "+
+			"%v", msg.TxHash, fundingTx.TxHash()))
+		return
+	}
+
+	inputScripts, err := resCtx.txResult.RemoteInputScripts(
+		fundingTx, msg.Witnesses,
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	fundingPoint := resCtx.reservation.FundingOutpoint()
+	channelID := lnwire.NewChanIDFromOutPoint(fundingPoint)
+
+	// The initiator already created the channel barrier when sending
+	// FundingCreated, while the responder creates its local discovery
+	// signal once it starts waiting for the funding transaction.
+	if initiator {
+		f.localDiscoveryMtx.Lock()
+		f.localDiscoverySignals[channelID] = make(chan struct{})
+		f.localDiscoveryMtx.Unlock()
+	} else {
+		f.barrierMtx.Lock()
+		fndgLog.Debugf("Creating chan barrier for ChanID(%v)", channelID)
+		f.newChanBarriers[channelID] = make(chan struct{})
+		f.barrierMtx.Unlock()
+	}
+
+	// With all signatures at hand, we can now complete the reservation,
+	// which will also broadcast the funding transaction.
+	completeChan, err := resCtx.reservation.CompleteReservation(
+		inputScripts, resCtx.remoteCommitSig,
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	if !initiator {
+		f.waitForResponderFunding(resCtx, pendingChanID, completeChan)
+		return
+	}
+
+	// As the funding transaction has already been broadcast, we'll only
+	// log a failure to send our signatures to the responder.
+	ourScripts, _ := resCtx.reservation.OurSignatures()
+	txSigs := newTxSignatures(pendingChanID, fundingTx, ourScripts)
+	if err := f.cfg.SendToPeer(peerKey, txSigs); err != nil {
+		fndgLog.Errorf("Unable to send funding transaction signatures "+
+			"for ChannelPoint(%v): %v", fundingPoint, err)
+	}
+
+	f.waitForInitiatorFunding(resCtx, pendingChanID, completeChan)
+}
@@ -0,0 +1,624 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// ErrParentNotFound is returned when the transaction the output to
+	// bump belongs to is unknown to the wallet.
+	ErrParentNotFound = fmt.Errorf("transaction not found within wallet")
+
+	// ErrParentConfirmed is returned when the transaction the output to
+	// bump belongs to has already confirmed, so there's no need to bump its
+	// fee.
+	ErrParentConfirmed = fmt.Errorf("transaction is already confirmed")
+
+	// ErrAlreadyBumped is returned when a child transaction bumping the
+	// fee of an output to an equal or higher fee rate is still pending.
+	ErrAlreadyBumped = fmt.Errorf("output fee already bumped to an " +
+		"equal or higher fee rate by unconfirmed transaction")
+)
+
+const (
+	// replaceableSequence is the sequence number of the inputs of child
+	// transactions, signaling that they can be replaced by a child paying
+	// a higher fee as per BIP 125.
+	replaceableSequence = wire.MaxTxInSequenceNum - 2
+
+	// incrementalRelayFeeRate is the fee rate a replacement child
+	// transaction must pay on top of the fee of the child it replaces, in
+	// order to be relayed.
+	incrementalRelayFeeRate = lnwallet.SatPerVByte(1)
+)
+
+// CommitOutput describes our output on a commitment transaction. As these
+// outputs don't pay to the wallet, they're spent using the sign descriptor of
+// their resolution rather than by the wallet.
+type CommitOutput struct {
+	// CommitTx is the commitment transaction the output belongs to.
+	CommitTx *wire.MsgTx
+
+	// CommitFee is the fee paid by the commitment transaction.
+	CommitFee btcutil.Amount
+
+	// Resolution holds the sign descriptor required to spend our output
+	// on the commitment transaction, or nil if we have no such output.
+	Resolution *lnwallet.CommitOutputResolution
+}
+
+// FeeBumperConfig houses the subsystems required by the feeBumper to craft,
+// broadcast and track child-pays-for-parent transactions.
+type FeeBumperConfig struct {
+	// Wallet is used to locate the transactions to bump, to select
+	// additional inputs when the output to bump doesn't carry enough
+	// value to pay for the fee, and to generate the change address the
+	// child transaction pays to.
+	Wallet lnwallet.WalletController
+
+	// Signer is used to sign all the inputs of the child transaction.
+	Signer lnwallet.Signer
+
+	// ChainIO is used to determine the current block height, which is used
+	// as the height hint when waiting for a child transaction to confirm.
+	ChainIO lnwallet.BlockChainIO
+
+	// Notifier is used to track the confirmation of the child
+	// transactions.
+	Notifier chainntnfs.ChainNotifier
+
+	// FetchCommitOutput returns our output on the commitment transaction
+	// the passed outpoint belongs to, allowing the fee of commitment
+	// transactions unknown to the wallet to be bumped. ErrParentNotFound
+	// is returned if the outpoint doesn't belong to the commitment
+	// transaction of any of our channels.
+	FetchCommitOutput func(wire.OutPoint) (*CommitOutput, error)
+}
+
+// bumpInput is an input of a child transaction.
+type bumpInput struct {
+	outPoint wire.OutPoint
+	output   *wire.TxOut
+
+	// signDesc and witnessType are used to sign for the input if it
+	// doesn't belong to the wallet. If signDesc is nil, the input is
+	// signed by the wallet.
+	signDesc    *lnwallet.SignDescriptor
+	witnessType lnwallet.WitnessType
+}
+
+// pendingBump is an unconfirmed child transaction bumping the fee of an
+// output.
+type pendingBump struct {
+	child   *wire.MsgTx
+	feeRate lnwallet.SatPerVByte
+	fee     btcutil.Amount
+
+	// inputs are the inputs spent by the child, starting with the output
+	// whose fee it bumps. A replacement child spends the same inputs.
+	inputs []*bumpInput
+
+	// replaced is closed once the child has been replaced by a child
+	// paying a higher fee.
+	replaced chan struct{}
+}
+
+// feeBumper speeds up the confirmation of transactions that are stuck at a
+// stale fee rate, such as those sweeping the outputs of force closed channels.
+// It does so by crafting a child-pays-for-parent (CPFP) transaction that
+// spends an unconfirmed output paying to our wallet, or our output on an
+// unconfirmed commitment transaction, attaching a fee high enough for the
+// parent and child, taken as a package, to reach the target fee rate. As
+// miners consider the fee rate of the whole package of unconfirmed ancestors,
+// this also speeds up the confirmation of a commitment transaction whose
+// outputs are being swept. Child transactions signal replaceability, so the
+// fee of an output can be bumped again to a higher fee rate while its child is
+// still pending.
+//
+// NOTE: Outputs that are still time-locked, such as our delayed output on our
+// own commitment transaction, can't be spent until their parent confirms, and
+// thus can't be used to bump its fee.
+type feeBumper struct {
+	started uint32
+	stopped uint32
+
+	cfg *FeeBumperConfig
+
+	// pending tracks the unconfirmed child transactions we've broadcast,
+	// keyed by the output they bump the fee of.
+	pending map[wire.OutPoint]*pendingBump
+	mu      sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newFeeBumper creates a new instance of the feeBumper backed by the passed
+// config.
+func newFeeBumper(cfg *FeeBumperConfig) *feeBumper {
+	return &feeBumper{
+		cfg:     cfg,
+		pending: make(map[wire.OutPoint]*pendingBump),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start launches the feeBumper.
+func (f *feeBumper) Start() error {
+	if !atomic.CompareAndSwapUint32(&f.started, 0, 1) {
+		return nil
+	}
+
+	bumpLog.Tracef("Starting fee bumper")
+
+	return nil
+}
+
+// Stop gracefully shuts down the feeBumper, ceasing to track any pending
+// child transactions.
+func (f *feeBumper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&f.stopped, 0, 1) {
+		return nil
+	}
+
+	bumpLog.Infof("Fee bumper shutting down")
+
+	close(f.quit)
+	f.wg.Wait()
+
+	return nil
+}
+
+// BumpFee crafts, signs and broadcasts a child transaction spending the passed
+// output, such that the unconfirmed transaction the output belongs to and the
+// child transaction together pay the target fee rate. If the output doesn't
+// carry enough value to pay for the required fee, then additional confirmed
+// wallet outputs are added as inputs. All value not paid as fees is sent back
+// to the wallet. If the fee of the output has already been bumped by a child
+// that's still pending, then that child is replaced, as long as the target
+// fee rate is higher. The child transaction is returned along with the fee it
+// pays.
+func (f *feeBumper) BumpFee(op wire.OutPoint,
+	feeRate lnwallet.SatPerVByte) (*wire.MsgTx, btcutil.Amount, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prevBump, ok := f.pending[op]
+	if ok && feeRate <= prevBump.feeRate {
+		return nil, 0, ErrAlreadyBumped
+	}
+
+	// First, we'll locate the parent transaction, in order to ensure it's
+	// still unconfirmed, and to determine its size and fee, along with
+	// the input spending the output to bump.
+	parentTx, parentFee, parentInput, err := f.fetchParent(op)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	parentWeight := blockchain.GetTransactionWeight(
+		btcutil.NewTx(parentTx),
+	)
+	parentVSize := (parentWeight + blockchain.WitnessScaleFactor - 1) /
+		blockchain.WitnessScaleFactor
+
+	// We'll now select the inputs of the child transaction, starting with
+	// the output to bump, and adding confirmed wallet outputs until we can
+	// pay for the required fee while leaving a non-dust change output. A
+	// replacement child spends all inputs of the child it replaces, as
+	// they're no longer available to the wallet.
+	inputs := []*bumpInput{parentInput}
+	if prevBump != nil {
+		inputs = append(inputs, prevBump.inputs[1:]...)
+	}
+
+	var walletCoins []*lnwallet.Utxo
+	defer func() {
+		for _, coin := range walletCoins {
+			f.cfg.Wallet.UnlockOutpoint(coin.OutPoint)
+		}
+	}()
+
+	var (
+		candidates []*lnwallet.Utxo
+		fee        btcutil.Amount
+		changeAmt  btcutil.Amount
+	)
+	for {
+		childVSize, err := cpfpVSize(inputs)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		fee = cpfpFee(feeRate, int64(parentVSize), childVSize, parentFee)
+
+		// A replacement must pay for its own relay on top of the fee
+		// of the child it replaces.
+		if prevBump != nil {
+			minFee := prevBump.fee +
+				incrementalRelayFeeRate.FeeForVSize(childVSize)
+			if fee < minFee {
+				fee = minFee
+			}
+		}
+
+		var inputAmt btcutil.Amount
+		for _, input := range inputs {
+			inputAmt += btcutil.Amount(input.output.Value)
+		}
+
+		changeAmt = inputAmt - fee
+		if changeAmt > lnwallet.DefaultDustLimit() {
+			break
+		}
+
+		// We'll need to add another wallet output, so we'll fetch the
+		// candidates if we haven't yet.
+		if candidates == nil {
+			candidates, err = f.cfg.Wallet.ListUnspentWitness(1)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		if len(walletCoins) == len(candidates) {
+			return nil, 0, fmt.Errorf("insufficient funds to bump "+
+				"fee: need %v, only have %v available",
+				fee+lnwallet.DefaultDustLimit(), inputAmt)
+		}
+
+		coin := candidates[len(walletCoins)]
+		f.cfg.Wallet.LockOutpoint(coin.OutPoint)
+		walletCoins = append(walletCoins, coin)
+
+		inputs = append(inputs, &bumpInput{
+			outPoint: coin.OutPoint,
+			output: &wire.TxOut{
+				Value:    int64(coin.Value),
+				PkScript: coin.PkScript,
+			},
+		})
+	}
+
+	changeAddr, err := f.cfg.Wallet.NewAddress(lnwallet.WitnessPubKey, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	changeScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	child := wire.NewMsgTx(2)
+	for _, input := range inputs {
+		txIn := wire.NewTxIn(&input.outPoint, nil, nil)
+		txIn.Sequence = replaceableSequence
+		child.AddTxIn(txIn)
+	}
+	child.AddTxOut(&wire.TxOut{
+		Value:    int64(changeAmt),
+		PkScript: changeScript,
+	})
+
+	// With the transaction fully assembled, we can now sign each of its
+	// inputs.
+	if err := f.signChild(child, inputs); err != nil {
+		return nil, 0, err
+	}
+
+	bumpLog.Infof("Bumping fee of %v to %v sat/vbyte with child tx %v",
+		op, int64(feeRate), child.TxHash())
+	bumpLog.Debugf("Child tx bumping fee of %v: %v", op,
+		newLogClosure(func() string {
+			return spew.Sdump(child)
+		}),
+	)
+
+	if err := f.cfg.Wallet.PublishTransaction(child); err != nil {
+		return nil, 0, err
+	}
+
+	// Now that the child has been broadcast, we'll track it until it
+	// confirms.
+	_, bestHeight, err := f.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, 0, err
+	}
+	childHash := child.TxHash()
+	confNtfn, err := f.cfg.Notifier.RegisterConfirmationsNtfn(
+		&childHash, 1, uint32(bestHeight),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The child we replaced, if any, can no longer confirm.
+	if prevBump != nil {
+		bumpLog.Infof("Child tx %v replaced by %v",
+			prevBump.child.TxHash(), childHash)
+		close(prevBump.replaced)
+	}
+
+	bump := &pendingBump{
+		child:    child,
+		feeRate:  feeRate,
+		fee:      fee,
+		inputs:   inputs,
+		replaced: make(chan struct{}),
+	}
+	f.pending[op] = bump
+
+	f.wg.Add(1)
+	go f.waitForConf(op, bump, confNtfn)
+
+	return child, fee, nil
+}
+
+// fetchParent locates the unconfirmed transaction the passed output belongs
+// to, returning it along with the fee it pays and the input spending the
+// output. The transaction is either a wallet transaction, or the commitment
+// transaction of one of our channels.
+func (f *feeBumper) fetchParent(op wire.OutPoint) (*wire.MsgTx,
+	btcutil.Amount, *bumpInput, error) {
+
+	parent, err := f.fetchWalletParent(&op.Hash)
+	switch {
+	case err == ErrParentNotFound:
+		return f.fetchCommitParent(op)
+
+	case err != nil:
+		return nil, 0, nil, err
+	}
+
+	if op.Index >= uint32(len(parent.RawTx.TxOut)) {
+		return nil, 0, nil, fmt.Errorf("transaction %v has no output "+
+			"%v", op.Hash, op.Index)
+	}
+
+	// The output must pay to our wallet, as otherwise we can't spend it
+	// before the parent confirms.
+	output, err := f.cfg.Wallet.FetchInputInfo(&op)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("output %v isn't spendable "+
+			"by the wallet: %v", op, err)
+	}
+
+	input := &bumpInput{
+		outPoint: op,
+		output:   output,
+	}
+
+	// If the wallet doesn't know the value of all inputs of the parent,
+	// such as for sweep transactions, then the fee it pays is unknown. In
+	// this case, we'll conservatively have the child pay for the entire
+	// package.
+	return parent.RawTx, btcutil.Amount(parent.TotalFees), input, nil
+}
+
+// fetchCommitParent locates the commitment transaction the passed output
+// belongs to, returning it along with the fee it pays and the input spending
+// our output on it.
+func (f *feeBumper) fetchCommitParent(op wire.OutPoint) (*wire.MsgTx,
+	btcutil.Amount, *bumpInput, error) {
+
+	commitOutput, err := f.cfg.FetchCommitOutput(op)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	resolution := commitOutput.Resolution
+	if resolution == nil || resolution.SelfOutPoint != op {
+		return nil, 0, nil, fmt.Errorf("output %v of commitment "+
+			"transaction doesn't pay to us", op)
+	}
+	if resolution.MaturityDelay != 0 {
+		return nil, 0, nil, fmt.Errorf("output %v is time-locked for "+
+			"%v blocks, so it can't be spent before its "+
+			"commitment transaction confirms", op,
+			resolution.MaturityDelay)
+	}
+
+	signDesc := resolution.SelfOutputSignDesc
+	input := &bumpInput{
+		outPoint:    op,
+		output:      signDesc.Output,
+		signDesc:    &signDesc,
+		witnessType: lnwallet.CommitmentNoDelay,
+	}
+	return commitOutput.CommitTx, commitOutput.CommitFee, input, nil
+}
+
+// signChild signs all inputs of the passed child transaction. Inputs that
+// belong to the wallet are signed by the wallet, while all others are signed
+// using their sign descriptor.
+func (f *feeBumper) signChild(child *wire.MsgTx, inputs []*bumpInput) error {
+	sigHashes := txscript.NewTxSigHashes(child)
+	for i, txIn := range child.TxIn {
+		input := inputs[i]
+
+		if input.signDesc != nil {
+			witnessFunc := input.witnessType.GenWitnessFunc(
+				f.cfg.Signer, input.signDesc,
+			)
+			witness, err := witnessFunc(child, sigHashes, i)
+			if err != nil {
+				return err
+			}
+
+			txIn.Witness = witness
+			continue
+		}
+
+		signDesc := lnwallet.SignDescriptor{
+			Output:     input.output,
+			HashType:   txscript.SigHashAll,
+			SigHashes:  sigHashes,
+			InputIndex: i,
+		}
+		inputScript, err := f.cfg.Signer.ComputeInputScript(
+			child, &signDesc,
+		)
+		if err != nil {
+			return err
+		}
+
+		txIn.SignatureScript = inputScript.ScriptSig
+		txIn.Witness = inputScript.Witness
+	}
+
+	return nil
+}
+
+// fetchWalletParent locates the unconfirmed wallet transaction with the
+// passed hash.
+func (f *feeBumper) fetchWalletParent(
+	txid *chainhash.Hash) (*lnwallet.TransactionDetail, error) {
+
+	txns, err := f.cfg.Wallet.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txns {
+		if tx.Hash != *txid {
+			continue
+		}
+
+		if tx.NumConfirmations > 0 {
+			return nil, ErrParentConfirmed
+		}
+		if tx.RawTx == nil {
+			return nil, ErrParentNotFound
+		}
+
+		return tx, nil
+	}
+
+	return nil, ErrParentNotFound
+}
+
+// waitForConf waits for the passed child transaction to confirm, after which
+// the output it bumped the fee of is no longer tracked. If the child is
+// replaced in the meantime, then its replacement is tracked instead.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *feeBumper) waitForConf(op wire.OutPoint, bump *pendingBump,
+	confNtfn *chainntnfs.ConfirmationEvent) {
+
+	defer f.wg.Done()
+
+	select {
+	case conf, ok := <-confNtfn.Confirmed:
+		if !ok {
+			return
+		}
+
+		bumpLog.Infof("Child tx %v bumping fee of %v confirmed at "+
+			"height %v", bump.child.TxHash(), op, conf.BlockHeight)
+
+	case <-bump.replaced:
+		return
+
+	case <-f.quit:
+		return
+	}
+
+	f.mu.Lock()
+	if f.pending[op] == bump {
+		delete(f.pending, op)
+	}
+	f.mu.Unlock()
+}
+
+// cpfpVSize estimates the virtual size of a child transaction spending the
+// passed inputs into a single P2WKH change output.
+func cpfpVSize(inputs []*bumpInput) (int64, error) {
+	var weightEstimate lnwallet.TxWeightEstimator
+	for _, input := range inputs {
+		pkScript := input.output.PkScript
+		switch {
+		case txscript.IsPayToWitnessPubKeyHash(pkScript):
+			weightEstimate.AddP2WKHInput()
+
+		case txscript.IsPayToScriptHash(pkScript):
+			weightEstimate.AddNestedP2WKHInput()
+
+		default:
+			return 0, fmt.Errorf("unsupported input script %x",
+				pkScript)
+		}
+	}
+	weightEstimate.AddP2WKHOutput()
+
+	return int64(weightEstimate.VSize()), nil
+}
+
+// cpfpFee returns the fee a child transaction of the given size must pay for
+// it and its parent to reach the target fee rate as a package, taking into
+// account the fee already paid by the parent. The child always pays at least
+// the target fee rate for its own size.
+func cpfpFee(feeRate lnwallet.SatPerVByte, parentVSize, childVSize int64,
+	parentFee btcutil.Amount) btcutil.Amount {
+
+	fee := feeRate.FeeForVSize(parentVSize+childVSize) - parentFee
+
+	minFee := feeRate.FeeForVSize(childVSize)
+	if fee < minFee {
+		return minFee
+	}
+
+	return fee
+}
+
+// fetchCommitOutput returns our output on the commitment transaction, local or
+// remote, of any of the channels within the passed database that the passed
+// outpoint belongs to. ErrParentNotFound is returned if there's no such
+// commitment transaction.
+func fetchCommitOutput(db *channeldb.DB, op wire.OutPoint) (*CommitOutput,
+	error) {
+
+	channels, err := db.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		commits := []channeldb.ChannelCommitment{
+			channel.LocalCommitment, channel.RemoteCommitment,
+		}
+		for _, commit := range commits {
+			if commit.CommitTx == nil ||
+				commit.CommitTx.TxHash() != op.Hash {
+
+				continue
+			}
+
+			resolution, err := lnwallet.NewCommitOutputResolution(
+				channel, commit.CommitTx,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return &CommitOutput{
+				CommitTx:   commit.CommitTx,
+				CommitFee:  commit.CommitFee,
+				Resolution: resolution,
+			}, nil
+		}
+	}
+
+	return nil, ErrParentNotFound
+}
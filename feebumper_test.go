@@ -0,0 +1,421 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestCPFPFee asserts that the fee paid by a child transaction brings the
+// package to the target fee rate, and that the child always pays at least the
+// target fee rate for its own size.
+func TestCPFPFee(t *testing.T) {
+	t.Parallel()
+
+	const feeRate = lnwallet.SatPerVByte(10)
+
+	tests := []struct {
+		name        string
+		parentVSize int64
+		childVSize  int64
+		parentFee   btcutil.Amount
+		expectedFee btcutil.Amount
+	}{
+		{
+			name:        "unknown parent fee",
+			parentVSize: 200,
+			childVSize:  110,
+			parentFee:   0,
+			expectedFee: 3100,
+		},
+		{
+			name:        "parent pays part of package fee",
+			parentVSize: 200,
+			childVSize:  110,
+			parentFee:   1000,
+			expectedFee: 2100,
+		},
+		{
+			name:        "parent pays above target rate",
+			parentVSize: 200,
+			childVSize:  110,
+			parentFee:   5000,
+			expectedFee: 1100,
+		},
+	}
+
+	for _, test := range tests {
+		fee := cpfpFee(
+			feeRate, test.parentVSize, test.childVSize,
+			test.parentFee,
+		)
+		if fee != test.expectedFee {
+			t.Fatalf("%v: expected fee %v, got %v", test.name,
+				test.expectedFee, fee)
+		}
+	}
+}
+
+// mockBumpWallet is a mock wallet owning the outputs of a set of unconfirmed
+// parent transactions, along with a set of confirmed coins.
+type mockBumpWallet struct {
+	*mockWalletController
+
+	parents []*lnwallet.TransactionDetail
+	coins   []*lnwallet.Utxo
+}
+
+// ListTransactionDetails returns the parent transactions.
+func (m *mockBumpWallet) ListTransactionDetails() (
+	[]*lnwallet.TransactionDetail, error) {
+
+	return m.parents, nil
+}
+
+// ListUnspentWitness returns the confirmed coins of the wallet.
+func (m *mockBumpWallet) ListUnspentWitness(
+	int32) ([]*lnwallet.Utxo, error) {
+
+	return m.coins, nil
+}
+
+// feeBumperTestCtx houses a feeBumper along with the mocks backing it.
+type feeBumperTestCtx struct {
+	bumper    *feeBumper
+	wallet    *mockBumpWallet
+	notifier  *mockNotifier
+	published chan *wire.MsgTx
+
+	// commitOutput is returned when fetching an output that doesn't
+	// belong to a wallet transaction, if set.
+	commitOutput *CommitOutput
+
+	// prevOuts are all outputs that may be spent by child transactions.
+	prevOuts map[wire.OutPoint]*wire.TxOut
+
+	// bigOutput and smallOutput are the outputs of the parent, the former
+	// being able to pay for the fee of its child on its own.
+	bigOutput   wire.OutPoint
+	smallOutput wire.OutPoint
+
+	// coin is the confirmed wallet coin.
+	coin wire.OutPoint
+}
+
+// newFeeBumperTestCtx creates a feeBumper for a wallet owning both outputs of
+// an unconfirmed parent transaction, whose fee is unknown, along with a
+// single confirmed coin.
+func newFeeBumperTestCtx(t *testing.T) *feeBumperTestCtx {
+	pkScript := p2wkhScript(t, alicePubKey)
+
+	parent := wire.NewMsgTx(2)
+	parent.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: 7},
+	})
+	parent.AddTxOut(&wire.TxOut{Value: 50000, PkScript: pkScript})
+	parent.AddTxOut(&wire.TxOut{Value: 1000, PkScript: pkScript})
+
+	ctx := &feeBumperTestCtx{
+		published: make(chan *wire.MsgTx, 10),
+		prevOuts:  make(map[wire.OutPoint]*wire.TxOut),
+		bigOutput: wire.OutPoint{Hash: parent.TxHash(), Index: 0},
+		smallOutput: wire.OutPoint{
+			Hash:  parent.TxHash(),
+			Index: 1,
+		},
+		coin: wire.OutPoint{Hash: chainhash.Hash{1}},
+	}
+
+	coin := &lnwallet.Utxo{
+		AddressType: lnwallet.WitnessPubKey,
+		Value:       btcutil.SatoshiPerBitcoin,
+		PkScript:    pkScript,
+		OutPoint:    ctx.coin,
+	}
+	utxos := []*lnwallet.Utxo{coin}
+	for i, txOut := range parent.TxOut {
+		utxos = append(utxos, &lnwallet.Utxo{
+			AddressType: lnwallet.WitnessPubKey,
+			Value:       btcutil.Amount(txOut.Value),
+			PkScript:    txOut.PkScript,
+			OutPoint: wire.OutPoint{
+				Hash:  parent.TxHash(),
+				Index: uint32(i),
+			},
+		})
+	}
+	for _, utxo := range utxos {
+		ctx.prevOuts[utxo.OutPoint] = &wire.TxOut{
+			Value:    int64(utxo.Value),
+			PkScript: utxo.PkScript,
+		}
+	}
+
+	ctx.wallet = &mockBumpWallet{
+		mockWalletController: &mockWalletController{
+			rootKey:               alicePrivKey,
+			publishedTransactions: ctx.published,
+			utxos:                 utxos,
+		},
+		parents: []*lnwallet.TransactionDetail{{
+			Hash:  parent.TxHash(),
+			RawTx: parent,
+		}},
+		coins: []*lnwallet.Utxo{coin},
+	}
+	ctx.notifier = &mockNotifier{
+		oneConfChannel: make(chan *chainntnfs.TxConfirmation, 1),
+	}
+
+	ctx.bumper = newFeeBumper(&FeeBumperConfig{
+		Wallet:   ctx.wallet,
+		Signer:   &mockSigner{key: alicePrivKey},
+		ChainIO:  &mockChainIO{},
+		Notifier: ctx.notifier,
+		FetchCommitOutput: func(wire.OutPoint) (*CommitOutput, error) {
+			if ctx.commitOutput == nil {
+				return nil, ErrParentNotFound
+			}
+			return ctx.commitOutput, nil
+		},
+	})
+	if err := ctx.bumper.Start(); err != nil {
+		t.Fatalf("unable to start fee bumper: %v", err)
+	}
+
+	return ctx
+}
+
+// p2wkhScript returns the P2WKH output script paying to the passed key.
+func p2wkhScript(t *testing.T, pubKey *btcec.PublicKey) []byte {
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()),
+		activeNetParams.Params,
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create output script: %v", err)
+	}
+
+	return pkScript
+}
+
+// assertChildPublished asserts that the passed child transaction was
+// published, spending the expected outputs with valid signatures while
+// signaling replaceability.
+func (ctx *feeBumperTestCtx) assertChildPublished(t *testing.T,
+	child *wire.MsgTx, expectedInputs ...wire.OutPoint) {
+
+	t.Helper()
+
+	select {
+	case tx := <-ctx.published:
+		if tx.TxHash() != child.TxHash() {
+			t.Fatalf("expected child %v to be published, got %v",
+				child.TxHash(), tx.TxHash())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("child %v not published", child.TxHash())
+	}
+
+	if len(child.TxIn) != len(expectedInputs) {
+		t.Fatalf("expected child to have %v inputs, got %v",
+			len(expectedInputs), len(child.TxIn))
+	}
+
+	sigHashes := txscript.NewTxSigHashes(child)
+	for i, txIn := range child.TxIn {
+		if txIn.PreviousOutPoint != expectedInputs[i] {
+			t.Fatalf("expected input %v to spend %v, got %v", i,
+				expectedInputs[i], txIn.PreviousOutPoint)
+		}
+		if txIn.Sequence != replaceableSequence {
+			t.Fatalf("input %v doesn't signal replaceability", i)
+		}
+
+		prevOut := ctx.prevOuts[txIn.PreviousOutPoint]
+		vm, err := txscript.NewEngine(
+			prevOut.PkScript, child, i,
+			txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value,
+		)
+		if err != nil {
+			t.Fatalf("unable to create engine: %v", err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Fatalf("invalid signature for input %v: %v", i, err)
+		}
+	}
+}
+
+// TestFeeBumperBumpFee asserts that a child transaction bumping the fee of an
+// output can only be replaced by a child paying a higher fee rate while it's
+// pending, and that its output can be bumped again once it confirms.
+func TestFeeBumperBumpFee(t *testing.T) {
+	t.Parallel()
+
+	ctx := newFeeBumperTestCtx(t)
+	defer ctx.bumper.Stop()
+
+	op := ctx.bigOutput
+
+	// As the output is large enough to pay for the fee, the child should
+	// only spend that output.
+	child, fee, err := ctx.bumper.BumpFee(op, 10)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	ctx.assertChildPublished(t, child, op)
+
+	if fee != btcutil.Amount(50000-child.TxOut[0].Value) {
+		t.Fatalf("expected fee %v, child pays %v", fee,
+			50000-child.TxOut[0].Value)
+	}
+
+	// The child can't be replaced by one paying the same or a lower fee
+	// rate.
+	for _, feeRate := range []lnwallet.SatPerVByte{5, 10} {
+		_, _, err := ctx.bumper.BumpFee(op, feeRate)
+		if err != ErrAlreadyBumped {
+			t.Fatalf("expected ErrAlreadyBumped at %v sat/vbyte, "+
+				"got %v", feeRate, err)
+		}
+	}
+
+	// A higher fee rate should replace the child by one spending the same
+	// output. As the fee of the parent is unknown, doubling the fee rate
+	// doubles the fee paid by the package, which is well above the fee
+	// required to relay the replacement.
+	replacement, replacementFee, err := ctx.bumper.BumpFee(op, 20)
+	if err != nil {
+		t.Fatalf("unable to replace child: %v", err)
+	}
+	ctx.assertChildPublished(t, replacement, op)
+
+	if replacementFee != 2*fee {
+		t.Fatalf("expected replacement to pay fee %v, got %v", 2*fee,
+			replacementFee)
+	}
+
+	// Once the replacement confirms, the output is no longer tracked, so
+	// it can be bumped at any fee rate.
+	ctx.notifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		BlockHeight: 100,
+	}
+
+	var pending int
+	for i := 0; i < 100; i++ {
+		ctx.bumper.mu.Lock()
+		pending = len(ctx.bumper.pending)
+		ctx.bumper.mu.Unlock()
+
+		if pending == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if pending != 0 {
+		t.Fatalf("confirmed child still tracked")
+	}
+
+	child, _, err = ctx.bumper.BumpFee(op, 5)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	ctx.assertChildPublished(t, child, op)
+}
+
+// TestFeeBumperWalletInputs asserts that confirmed wallet coins are added to
+// the child transaction if the output to bump can't pay for the fee on its
+// own, and that outputs of unknown transactions can't be bumped.
+func TestFeeBumperWalletInputs(t *testing.T) {
+	t.Parallel()
+
+	ctx := newFeeBumperTestCtx(t)
+	defer ctx.bumper.Stop()
+
+	child, _, err := ctx.bumper.BumpFee(ctx.smallOutput, 10)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	ctx.assertChildPublished(t, child, ctx.smallOutput, ctx.coin)
+
+	unknown := wire.OutPoint{Hash: chainhash.Hash{2}}
+	if _, _, err := ctx.bumper.BumpFee(unknown, 10); err != ErrParentNotFound {
+		t.Fatalf("expected ErrParentNotFound, got %v", err)
+	}
+}
+
+// TestFeeBumperCommitOutput asserts that our immediately spendable output on
+// a commitment transaction can be used to bump its fee, using the sign
+// descriptor of the output, while time-locked outputs are rejected.
+func TestFeeBumperCommitOutput(t *testing.T) {
+	t.Parallel()
+
+	ctx := newFeeBumperTestCtx(t)
+	defer ctx.bumper.Stop()
+
+	// Our output on the remote party's commitment transaction pays to our
+	// payment base point, tweaked by their commitment point.
+	commitPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	tweak := lnwallet.SingleTweakBytes(commitPriv.PubKey(), alicePubKey)
+	pkScript := p2wkhScript(
+		t, lnwallet.TweakPubKeyWithTweak(alicePubKey, tweak),
+	)
+
+	commitTx := wire.NewMsgTx(2)
+	commitTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: 3},
+	})
+	commitTx.AddTxOut(&wire.TxOut{Value: 80000, PkScript: pkScript})
+
+	op := wire.OutPoint{Hash: commitTx.TxHash(), Index: 0}
+	ctx.prevOuts[op] = commitTx.TxOut[0]
+
+	resolution := &lnwallet.CommitOutputResolution{
+		SelfOutPoint: op,
+		SelfOutputSignDesc: lnwallet.SignDescriptor{
+			KeyDesc:       keychain.KeyDescriptor{PubKey: alicePubKey},
+			SingleTweak:   tweak,
+			WitnessScript: pkScript,
+			Output:        commitTx.TxOut[0],
+			HashType:      txscript.SigHashAll,
+		},
+	}
+	ctx.commitOutput = &CommitOutput{
+		CommitTx:   commitTx,
+		CommitFee:  1000,
+		Resolution: resolution,
+	}
+
+	child, _, err := ctx.bumper.BumpFee(op, 10)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	ctx.assertChildPublished(t, child, op)
+
+	// Our delayed output on our own commitment transaction can't be spent
+	// before the commitment transaction confirms.
+	resolution.MaturityDelay = 144
+	op.Index = 1
+	resolution.SelfOutPoint = op
+	if _, _, err := ctx.bumper.BumpFee(op, 10); err == nil {
+		t.Fatalf("expected bumping time-locked output to fail")
+	}
+}
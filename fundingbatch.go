@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// initBatchFundingMsg is sent by an outside subsystem to the funding manager
+// in order to open several channels, possibly with different peers, within a
+// single funding transaction.
+type initBatchFundingMsg struct {
+	// channels holds the parameters of each channel within the batch.
+	channels []*initFundingMsg
+
+	// fundingFeePerVSize is the fee rate the shared funding transaction
+	// pays.
+	fundingFeePerVSize lnwallet.SatPerVByte
+
+	// resp receives the funding outpoint of every channel, in the order
+	// they were requested, once the funding transaction is broadcast.
+	resp chan []wire.OutPoint
+
+	// err receives the error which caused the batch to fail, if any.
+	err chan error
+}
+
+// batchChannel tracks the state of a single channel within a batch.
+type batchChannel struct {
+	resCtx        *reservationWithCtx
+	pendingChanID [32]byte
+
+	// contribution is the remote party's contribution to the channel,
+	// set once they've accepted it.
+	contribution *lnwallet.ChannelContribution
+
+	// commitSig is the remote party's signature for our commitment
+	// transaction, set once we've received their FundingSigned message.
+	commitSig []byte
+}
+
+// fundingBatch houses the state of a set of channels that share a single
+// funding transaction. The funding transaction is only constructed once every
+// peer has accepted its channel, and only broadcast once every peer has
+// signed our commitment transaction. If the funding flow of any channel fails,
+// then the flows of all channels within the batch are failed.
+type fundingBatch struct {
+	feeRate lnwallet.SatPerVByte
+
+	resp chan []wire.OutPoint
+	err  chan error
+
+	// mu guards all fields below, as batches may be failed from outside
+	// the reservationCoordinator when a peer disconnects.
+	mu sync.Mutex
+
+	channels []*batchChannel
+
+	// failed is true once the batch has been failed.
+	failed bool
+
+	// published is true once the funding transaction has been broadcast,
+	// after which the batch can no longer be failed.
+	published bool
+}
+
+// addChannel adds the reservation of a newly initiated channel to the batch.
+func (b *fundingBatch) addChannel(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.channels = append(b.channels, &batchChannel{
+		resCtx:        resCtx,
+		pendingChanID: pendingChanID,
+	})
+}
+
+// isFailed returns true if the batch has been failed.
+func (b *fundingBatch) isFailed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failed
+}
+
+// channel returns the state of the channel with the passed reservation.
+//
+// NOTE: The batch's mutex MUST be held when calling this method.
+func (b *fundingBatch) channel(resCtx *reservationWithCtx) *batchChannel {
+	for _, c := range b.channels {
+		if c.resCtx == resCtx {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// initBatchFundingWorkflow sends a message to the funding manager instructing
+// it to open all channels of the passed batch request within a single funding
+// transaction.
+func (f *fundingManager) initBatchFundingWorkflow(req *initBatchFundingMsg) {
+	select {
+	case f.batchRequests <- req:
+	case <-f.quit:
+	}
+}
+
+// handleInitBatchFundingMsg creates a channel reservation for each channel
+// within the batch and kicks off its funding workflow. The inputs of each
+// reservation are selected separately, and are merged into the shared funding
+// transaction once all peers have accepted their channels.
+func (f *fundingManager) handleInitBatchFundingMsg(msg *initBatchFundingMsg) {
+	batch := &fundingBatch{
+		feeRate: msg.fundingFeePerVSize,
+		resp:    msg.resp,
+		err:     msg.err,
+	}
+
+	fndgLog.Infof("Initiating batch funding of %v channels",
+		len(msg.channels))
+
+	for _, chanMsg := range msg.channels {
+		// A peer within the batch may have disconnected in the
+		// meantime, in which case the batch has already been failed.
+		if batch.isFailed() {
+			return
+		}
+
+		// Status updates and errors of the individual channels aren't
+		// delivered to the caller, who's only notified once the batch
+		// as a whole either succeeds or fails.
+		chanMsg.batch = batch
+		chanMsg.fundingFeePerVSize = batch.feeRate
+		chanMsg.updates = make(chan *lnrpc.OpenStatusUpdate, 2)
+		chanMsg.err = make(chan error, 2)
+
+		if err := f.initFundingFlow(chanMsg); err != nil {
+			fndgLog.Errorf("Unable to initiate channel of batch "+
+				"with %x: %v", chanMsg.peerAddress.IdentityKey.
+				SerializeCompressed(), err)
+			f.failBatch(batch, err)
+			return
+		}
+	}
+}
+
+// handleBatchAccept records the contribution of a peer that accepted its
+// channel within a batch. Once every peer has done so, the shared funding
+// transaction is constructed and a FundingCreated message is sent to each
+// peer.
+func (f *fundingManager) handleBatchAccept(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, contribution *lnwallet.ChannelContribution) {
+
+	batch := resCtx.batch
+
+	batch.mu.Lock()
+	if batch.failed {
+		batch.mu.Unlock()
+		return
+	}
+	c := batch.channel(resCtx)
+	if c == nil {
+		batch.mu.Unlock()
+		return
+	}
+	c.contribution = contribution
+
+	var (
+		reservations  = make([]*lnwallet.ChannelReservation, 0, len(batch.channels))
+		contributions = make([]*lnwallet.ChannelContribution, 0, len(batch.channels))
+		channels      = make([]*batchChannel, len(batch.channels))
+	)
+	copy(channels, batch.channels)
+	for _, bc := range channels {
+		if bc.contribution == nil {
+			batch.mu.Unlock()
+
+			fndgLog.Debugf("Waiting for remaining peers to accept "+
+				"their channels before funding batch of "+
+				"pendingID(%x)", pendingChanID[:])
+			return
+		}
+
+		reservations = append(reservations, bc.resCtx.reservation)
+		contributions = append(contributions, bc.contribution)
+	}
+	batch.mu.Unlock()
+
+	// Every peer has accepted its channel, so we can now construct the
+	// shared funding transaction along with the commitment transactions
+	// of each channel.
+	err := f.cfg.Wallet.ProcessBatchContributions(
+		reservations, contributions, batch.feeRate,
+	)
+	if err != nil {
+		fndgLog.Errorf("Unable to process batch contributions: %v", err)
+		f.failBatch(batch, err)
+		return
+	}
+
+	for _, c := range channels {
+		if batch.isFailed() {
+			return
+		}
+
+		f.sendFundingCreated(c.resCtx, c.pendingChanID)
+	}
+}
+
+// handleBatchSigned records the signature of a peer for our commitment
+// transaction of its channel within a batch. Once every peer has sent us a
+// valid signature, all channels are committed to disk and the shared funding
+// transaction is broadcast.
+func (f *fundingManager) handleBatchSigned(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, commitSig []byte) {
+
+	batch := resCtx.batch
+	peerKey := resCtx.peerAddress.IdentityKey
+
+	// We'll verify the signature right away, so an invalid signature
+	// fails the batch without waiting for the remaining peers.
+	if err := resCtx.reservation.VerifyCommitSig(commitSig); err != nil {
+		fndgLog.Errorf("Invalid commitment signature for channel of "+
+			"batch with pendingID(%x): %v", pendingChanID[:], err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
+		return
+	}
+
+	batch.mu.Lock()
+	if batch.failed {
+		batch.mu.Unlock()
+		return
+	}
+	c := batch.channel(resCtx)
+	if c == nil {
+		batch.mu.Unlock()
+		return
+	}
+	c.commitSig = commitSig
+
+	var (
+		reservations = make([]*lnwallet.ChannelReservation, 0, len(batch.channels))
+		commitSigs   = make([][]byte, 0, len(batch.channels))
+		channels     = make([]*batchChannel, len(batch.channels))
+	)
+	copy(channels, batch.channels)
+	for _, bc := range channels {
+		if bc.commitSig == nil {
+			batch.mu.Unlock()
+
+			fndgLog.Debugf("Waiting for remaining peers to sign "+
+				"their channels before broadcasting batch of "+
+				"pendingID(%x)", pendingChanID[:])
+			return
+		}
+
+		reservations = append(reservations, bc.resCtx.reservation)
+		commitSigs = append(commitSigs, bc.commitSig)
+	}
+	batch.mu.Unlock()
+
+	// Create an entry in the local discovery map for each channel so we
+	// can ensure that we process the channel confirmation fully before
+	// we receive a funding locked message.
+	f.localDiscoveryMtx.Lock()
+	for _, c := range channels {
+		fundingPoint := c.resCtx.reservation.FundingOutpoint()
+		permChanID := lnwire.NewChanIDFromOutPoint(fundingPoint)
+		f.localDiscoverySignals[permChanID] = make(chan struct{})
+	}
+	f.localDiscoveryMtx.Unlock()
+
+	// If completing the batch fails, then none of its channels have been
+	// written to disk, so the batch can safely be failed. Once they have
+	// been, a failure to broadcast the funding transaction no longer fails
+	// the batch, as it's rebroadcast upon restart.
+	completeChans, err := f.cfg.Wallet.CompleteBatch(
+		reservations, commitSigs,
+	)
+	if err != nil {
+		fndgLog.Errorf("Unable to complete batch: %v", err)
+		f.failBatch(batch, err)
+		return
+	}
+
+	batch.mu.Lock()
+	batch.published = true
+	batch.mu.Unlock()
+
+	fundingPoints := make([]wire.OutPoint, 0, len(completeChans))
+	for i, c := range channels {
+		f.waitForInitiatorFunding(
+			c.resCtx, c.pendingChanID, completeChans[i],
+		)
+		fundingPoints = append(
+			fundingPoints, completeChans[i].FundingOutpoint,
+		)
+	}
+
+	batch.resp <- fundingPoints
+}
+
+// failBatch fails the funding flow of every channel within the batch that's
+// still pending, releasing the inputs selected for each of them, then
+// notifies the caller of the passed error. Batches whose funding transaction
+// has already been broadcast can't be failed.
+func (f *fundingManager) failBatch(batch *fundingBatch, batchErr error) {
+	batch.mu.Lock()
+	if batch.failed || batch.published {
+		batch.mu.Unlock()
+		return
+	}
+	batch.failed = true
+	channels := make([]*batchChannel, len(batch.channels))
+	copy(channels, batch.channels)
+	batch.mu.Unlock()
+
+	fndgLog.Errorf("Failing batch of %v channels: %v", len(channels),
+		batchErr)
+
+	for _, c := range channels {
+		peerKey := c.resCtx.peerAddress.IdentityKey
+
+		// Channels whose flow already failed have been removed from
+		// the set of active reservations.
+		if _, err := f.getReservationCtx(peerKey, c.pendingChanID); err != nil {
+			continue
+		}
+
+		f.failFundingFlow(
+			peerKey, c.pendingChanID,
+			fmt.Errorf("batch funding failed: %v", batchErr),
+		)
+	}
+
+	select {
+	case batch.err <- batchErr:
+	default:
+	}
+}
@@ -12,11 +12,11 @@ import (
 
 	"golang.org/x/crypto/salsa20"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -25,6 +25,7 @@ import (
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -42,6 +43,16 @@ const (
 	// TODO(roasbeef): add command line param to modify
 	maxFundingAmount = btcutil.Amount(1 << 24)
 
+	// maxLargeFundingAmount is the maximum channel size accepted with peers
+	// that signal support for large channels, lifting the BOLT-0002 limit
+	// above.
+	maxLargeFundingAmount = btcutil.Amount(10 * btcutil.SatoshiPerBitcoin)
+
+	// maxLargeChanConfs is the maximum number of confirmations we'll
+	// require for a large channel. The number of confirmations scales
+	// linearly with the size of the channel up to this value.
+	maxLargeChanConfs = 144
+
 	// minBtcRemoteDelay and maxBtcRemoteDelay is the extremes of the
 	// Bitcoin CSV delay we will require the remote to use for its
 	// commitment transaction. The actual delay we will require will be
@@ -85,6 +96,41 @@ type reservationWithCtx struct {
 	remoteCsvDelay uint16
 	remoteMinHtlc  lnwire.MilliSatoshi
 
+	// dualFunded is true if both parties contribute funds to the channel,
+	// constructing the funding transaction interactively.
+	dualFunded bool
+
+	// fundingFeeRate is the fee rate the funding transaction pays. It's
+	// only set if we opened the channel.
+	fundingFeeRate lnwallet.SatPerVByte
+
+	// txBuilder drives our side of the interactive construction of the
+	// funding transaction of a dual funded channel.
+	txBuilder *lnwallet.InteractiveTxBuilder
+
+	// txResult is the outcome of the interactive construction, set once
+	// it completes.
+	txResult *lnwallet.InteractiveTxResult
+
+	// remoteContribution is the remote party's contribution to a dual
+	// funded channel, held until the funding transaction is negotiated.
+	remoteContribution *lnwallet.ChannelContribution
+
+	// remoteCommitSig is the remote party's signature for our commitment
+	// transaction of a dual funded channel, held until we receive their
+	// signatures for the funding transaction.
+	remoteCommitSig []byte
+
+	// pendingChannel is the dual funded channel we persisted before
+	// releasing our signatures for its funding transaction. As the funding
+	// transaction may confirm from that point on, we'll keep watching for
+	// it even if the funding flow is canceled.
+	pendingChannel *channeldb.OpenChannel
+
+	// batch is the batch of channels sharing a funding transaction that
+	// this channel is part of, if any.
+	batch *fundingBatch
+
 	updateMtx   sync.RWMutex
 	lastUpdated time.Time
 
@@ -126,6 +172,10 @@ func (r *reservationWithCtx) updateTimestamp() {
 type initFundingMsg struct {
 	peerAddress *lnwire.NetAddress
 	*openChanReq
+
+	// batch is the batch the channel is funded in, if it's opened as part
+	// of a BatchOpenChannel request.
+	batch *fundingBatch
 }
 
 // fundingOpenMsg couples an lnwire.OpenChannel message with the peer who sent
@@ -325,6 +375,30 @@ type fundingConfig struct {
 	// flood us with very small channels that would never really be usable
 	// due to fees.
 	MinChanSize btcutil.Amount
+
+	// SupportsDualFunding returns true if both we and the passed peer
+	// support dual funded channels. If so, all channels opened between
+	// us and the peer will have their funding transaction constructed
+	// interactively.
+	SupportsDualFunding func(peer *btcec.PublicKey) bool
+
+	// DualFundingContribution is a function closure that, given the
+	// amount a remote peer is funding a dual funded channel with, returns
+	// the amount that we should contribute to the channel ourselves.
+	DualFundingContribution func(remoteAmt btcutil.Amount) btcutil.Amount
+
+	// UpfrontShutdownScript returns the script that we should commit to
+	// paying our funds out to upon a cooperative close of a channel with
+	// the passed peer. The requested script is the one specified by the
+	// user when opening a channel, if any. An empty script is returned if
+	// we shouldn't commit to a script, and an error if a script was
+	// requested but the peer doesn't support upfront shutdown scripts.
+	UpfrontShutdownScript func(peer *btcec.PublicKey,
+		requested lnwire.DeliveryAddress) (lnwire.DeliveryAddress, error)
+
+	// SupportsLargeChannels returns true if both we and the passed peer
+	// support channels above the regular channel size limit.
+	SupportsLargeChannels func(peer *btcec.PublicKey) bool
 }
 
 // fundingManager acts as an orchestrator/bridge between the wallet's
@@ -381,6 +455,10 @@ type fundingManager struct {
 	// requests from a local subsystem within the daemon.
 	fundingRequests chan *initFundingMsg
 
+	// batchRequests is a channel used to receive requests to open several
+	// channels within a single funding transaction.
+	batchRequests chan *initBatchFundingMsg
+
 	// newChanBarriers is a map from a channel ID to a 'barrier' which will
 	// be signalled once the channel is fully open. This barrier acts as a
 	// synchronization point for any incoming/outgoing HTLCs before the
@@ -444,6 +522,7 @@ func newFundingManager(cfg fundingConfig) (*fundingManager, error) {
 		newChanBarriers:             make(map[lnwire.ChannelID]chan struct{}),
 		fundingMsgs:                 make(chan interface{}, msgBufferSize),
 		fundingRequests:             make(chan *initFundingMsg, msgBufferSize),
+		batchRequests:               make(chan *initBatchFundingMsg, msgBufferSize),
 		localDiscoverySignals:       make(map[lnwire.ChannelID]chan struct{}),
 		handleFundingLockedBarriers: make(map[lnwire.ChannelID]struct{}),
 		queries:                     make(chan interface{}, 1),
@@ -486,12 +565,12 @@ func (f *fundingManager) Start() error {
 		f.localDiscoverySignals[chanID] = make(chan struct{})
 
 		// Rebroadcast the funding transaction for any pending channel
-		// that we initiated. If this operation fails due to a reported
-		// double spend, we treat this as an indicator that we have
-		// already broadcast this transaction. Otherwise, we simply log
-		// the error as there isn't anything we can currently do to
-		// recover.
-		if channel.ChanType == channeldb.SingleFunder &&
+		// that we initiated or contributed funds to. If this operation
+		// fails due to a reported double spend, we treat this as an
+		// indicator that we have already broadcast this transaction.
+		// Otherwise, we simply log the error as there isn't anything
+		// we can currently do to recover.
+		if channel.ChanType == channeldb.DualFunder ||
 			channel.IsInitiator {
 
 			err := f.cfg.PublishTransaction(channel.FundingTxn)
@@ -501,50 +580,7 @@ func (f *fundingManager) Start() error {
 			}
 		}
 
-		confChan := make(chan *lnwire.ShortChannelID)
-		timeoutChan := make(chan struct{})
-
-		go func(ch *channeldb.OpenChannel) {
-			go f.waitForFundingWithTimeout(ch, confChan, timeoutChan)
-
-			select {
-			case <-timeoutChan:
-				// Timeout channel will be triggered if the number of blocks
-				// mined since the channel was initiated reaches
-				// maxWaitNumBlocksFundingConf and we are not the channel
-				// initiator.
-
-				closeInfo := &channeldb.ChannelCloseSummary{
-					ChainHash: ch.ChainHash,
-					ChanPoint: ch.FundingOutpoint,
-					RemotePub: ch.IdentityPub,
-					CloseType: channeldb.FundingCanceled,
-				}
-
-				if err := ch.CloseChannel(closeInfo); err != nil {
-					fndgLog.Errorf("Failed closing channel "+
-						"%v: %v", ch.FundingOutpoint, err)
-				}
-
-			case <-f.quit:
-				// The fundingManager is shutting down, and will
-				// resume wait on startup.
-			case shortChanID, ok := <-confChan:
-				if !ok {
-					fndgLog.Errorf("waiting for funding" +
-						"confirmation failed")
-					return
-				}
-
-				// Success, funding transaction was confirmed.
-				err := f.handleFundingConfirmation(ch, shortChanID)
-				if err != nil {
-					fndgLog.Errorf("failed to handle funding"+
-						"confirmation: %v", err)
-					return
-				}
-			}
-		}(channel)
+		go f.watchPendingChannel(channel)
 	}
 
 	// Fetch all our open channels, and make sure they all finalized the
@@ -732,6 +768,16 @@ func (f *fundingManager) CancelPeerReservations(nodePub [33]byte) {
 
 	fndgLog.Debugf("Cancelling all reservations for peer %x", nodePub[:])
 
+	// Any batches the peer's reservations are part of are failed once
+	// we've released the reservation mutex, as failing them requires
+	// access to the reservations of other peers.
+	var failedBatches []*fundingBatch
+	defer func() {
+		for _, batch := range failedBatches {
+			f.failBatch(batch, fmt.Errorf("peer disconnected"))
+		}
+	}()
+
 	f.resMtx.Lock()
 	defer f.resMtx.Unlock()
 
@@ -760,6 +806,10 @@ func (f *fundingManager) CancelPeerReservations(nodePub [33]byte) {
 			}
 		}
 
+		if resCtx.batch != nil {
+			failedBatches = append(failedBatches, resCtx.batch)
+		}
+
 		delete(nodeReservations, pendingID)
 	}
 
@@ -803,17 +853,21 @@ func (f *fundingManager) failFundingFlow(peer *btcec.PublicKey,
 	fndgLog.Errorf("Failing funding flow: %v (%v)", fundingErr,
 		spew.Sdump(errMsg))
 
-	if _, err := f.cancelReservationCtx(peer, tempChanID); err != nil {
+	resCtx, err := f.cancelReservationCtx(peer, tempChanID)
+	if err != nil {
 		fndgLog.Errorf("unable to cancel reservation: %v", err)
 	}
 
-	err := f.cfg.SendToPeer(peer, errMsg)
+	err = f.cfg.SendToPeer(peer, errMsg)
 	if err != nil {
 		fndgLog.Errorf("unable to send error message to peer %v", err)
-		return
 	}
 
-	return
+	// If the channel was part of a batch, then the funding flows of all
+	// other channels within the batch must be failed as well.
+	if resCtx != nil && resCtx.batch != nil {
+		f.failBatch(resCtx.batch, fundingErr)
+	}
 }
 
 // reservationCoordinator is the primary goroutine tasked with progressing the
@@ -844,9 +898,13 @@ func (f *fundingManager) reservationCoordinator() {
 				go f.handleFundingLocked(fmsg)
 			case *fundingErrorMsg:
 				f.handleErrorMsg(fmsg)
+			case *interactiveTxMsg:
+				f.handleInteractiveTxMsg(fmsg)
 			}
 		case req := <-f.fundingRequests:
 			f.handleInitFundingMsg(req)
+		case req := <-f.batchRequests:
+			f.handleInitBatchFundingMsg(req)
 
 		case <-zombieSweepTicker.C:
 			f.pruneZombieReservations()
@@ -903,6 +961,105 @@ func (f *fundingManager) processFundingOpen(msg *lnwire.OpenChannel,
 	}
 }
 
+// validateUpfrontShutdown ensures that the upfront shutdown script committed
+// to by the remote party, if any, is of one of the standard script types
+// accepted within a Shutdown message.
+func validateUpfrontShutdown(script lnwire.DeliveryAddress) error {
+	if len(script) == 0 {
+		return nil
+	}
+
+	switch txscript.GetScriptClass(script) {
+	case txscript.PubKeyHashTy, txscript.ScriptHashTy,
+		txscript.WitnessV0PubKeyHashTy, txscript.WitnessV0ScriptHashTy:
+
+		return nil
+	}
+
+	return fmt.Errorf("upfront shutdown script %x is non-standard", script)
+}
+
+// maxChanSize returns the maximum size of a channel with the passed peer.
+// This is only above the BOLT-0002 limit if both we and the peer support large
+// channels.
+func (f *fundingManager) maxChanSize(peer *btcec.PublicKey) btcutil.Amount {
+	if f.cfg.SupportsLargeChannels(peer) {
+		return maxLargeFundingAmount
+	}
+
+	return maxFundingAmount
+}
+
+// scaleRemoteDelay returns the CSV delay we require the remote party to use
+// for a channel of the passed size. The delay scales linearly from minDelay
+// for small channels to maxDelay for channels of size maxFundingAmount, and
+// stays at maxDelay for large channels above it.
+func scaleRemoteDelay(chanAmt btcutil.Amount, minDelay,
+	maxDelay uint16) uint16 {
+
+	// The delay is scaled as an amount, since the product of the maximum
+	// delay and the size of a large channel doesn't fit a uint16.
+	delay := btcutil.Amount(maxDelay) * chanAmt / maxFundingAmount
+	if delay < btcutil.Amount(minDelay) {
+		return minDelay
+	}
+	if delay > btcutil.Amount(maxDelay) {
+		return maxDelay
+	}
+
+	return uint16(delay)
+}
+
+// watchPendingChannel waits for the funding transaction of a pending channel
+// to confirm, after which the channel opening process is resumed. If we're
+// not the initiator of the channel and the funding transaction doesn't
+// confirm in time, then the channel is forgotten.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) watchPendingChannel(ch *channeldb.OpenChannel) {
+	confChan := make(chan *lnwire.ShortChannelID)
+	timeoutChan := make(chan struct{})
+
+	go f.waitForFundingWithTimeout(ch, confChan, timeoutChan)
+
+	select {
+	case <-timeoutChan:
+		// Timeout channel will be triggered if the number of blocks
+		// mined since the channel was initiated reaches
+		// maxWaitNumBlocksFundingConf and we are not the channel
+		// initiator.
+		closeInfo := &channeldb.ChannelCloseSummary{
+			ChainHash: ch.ChainHash,
+			ChanPoint: ch.FundingOutpoint,
+			RemotePub: ch.IdentityPub,
+			CloseType: channeldb.FundingCanceled,
+		}
+
+		if err := ch.CloseChannel(closeInfo); err != nil {
+			fndgLog.Errorf("Failed closing channel %v: %v",
+				ch.FundingOutpoint, err)
+		}
+
+	case <-f.quit:
+		// The fundingManager is shutting down, and will resume wait on
+		// startup.
+	case shortChanID, ok := <-confChan:
+		if !ok {
+			fndgLog.Errorf("waiting for funding confirmation " +
+				"failed")
+			return
+		}
+
+		// Success, funding transaction was confirmed.
+		err := f.handleFundingConfirmation(ch, shortChanID)
+		if err != nil {
+			fndgLog.Errorf("failed to handle funding "+
+				"confirmation: %v", err)
+			return
+		}
+	}
+}
+
 // handleFundingOpen creates an initial 'ChannelReservation' within the wallet,
 // then responds to the source peer with an accept channel message progressing
 // the funding workflow.
@@ -945,8 +1102,9 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	}
 
 	// We'll reject any request to create a channel that's above the
-	// current soft-limit for channel size.
-	if msg.FundingAmount > maxFundingAmount {
+	// current soft-limit for channel size with this peer.
+	maxChanSize := f.maxChanSize(fmsg.peerAddress.IdentityKey)
+	if msg.FundingAmount > maxChanSize {
 		f.failFundingFlow(
 			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
 			lnwire.ErrChanTooLarge,
@@ -969,18 +1127,72 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		msg.CsvDelay, msg.PendingChannelID,
 		fmsg.peerAddress.IdentityKey.SerializeCompressed())
 
+	// If the initiator committed to a delivery address for cooperative
+	// closes, then we'll ensure it's one we'd accept within a Shutdown
+	// message.
+	if err := validateUpfrontShutdown(msg.UpfrontShutdownScript); err != nil {
+		f.failFundingFlow(
+			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
+			err,
+		)
+		return
+	}
+
+	// We'll also determine whether we should commit to a delivery address
+	// of our own.
+	shutdownScript, err := f.cfg.UpfrontShutdownScript(
+		fmsg.peerAddress.IdentityKey, nil,
+	)
+	if err != nil {
+		fndgLog.Errorf("Unable to generate upfront shutdown script: "+
+			"%v", err)
+		f.failFundingFlow(
+			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
+			err,
+		)
+		return
+	}
+
+	// If both we and the remote peer support dual funded channels, then
+	// we'll consult our policy to determine how much we should contribute
+	// to the channel ourselves.
+	var localAmt btcutil.Amount
+	dualFunded := f.cfg.SupportsDualFunding(fmsg.peerAddress.IdentityKey)
+	if dualFunded {
+		localAmt = f.cfg.DualFundingContribution(amt)
+		if amt+localAmt > maxChanSize {
+			localAmt = maxChanSize - amt
+		}
+	}
+
 	// Attempt to initialize a reservation within the wallet. If the wallet
 	// has insufficient resources to create the channel, then the
-	// reservation attempt may be rejected. Note that since we're on the
-	// responding side of a single funder workflow, we don't commit any
-	// funds to the channel ourselves.
+	// reservation attempt may be rejected. Unless this is a dual funded
+	// channel, we don't commit any funds to the channel ourselves. As the
+	// initiator pays the fees of a dual funded channel's funding
+	// transaction, we perform coin selection for our contribution without
+	// any fee.
 	chainHash := chainhash.Hash(msg.ChainHash)
 	reservation, err := f.cfg.Wallet.InitChannelReservation(
-		amt, 0, msg.PushAmount,
+		amt+localAmt, localAmt, msg.PushAmount,
 		lnwallet.SatPerKWeight(msg.FeePerKiloWeight), 0,
 		fmsg.peerAddress.IdentityKey, fmsg.peerAddress.Address,
 		&chainHash, msg.ChannelFlags,
 	)
+	if err != nil && localAmt != 0 {
+		// If we're unable to contribute to the channel, we'll still
+		// accept it, leaving the remote party to fund it entirely.
+		fndgLog.Warnf("Unable to contribute %v to pendingChan(%x): %v",
+			localAmt, msg.PendingChannelID, err)
+
+		localAmt = 0
+		reservation, err = f.cfg.Wallet.InitChannelReservation(
+			amt, 0, msg.PushAmount,
+			lnwallet.SatPerKWeight(msg.FeePerKiloWeight), 0,
+			fmsg.peerAddress.IdentityKey, fmsg.peerAddress.Address,
+			&chainHash, msg.ChannelFlags,
+		)
+	}
 	if err != nil {
 		fndgLog.Errorf("Unable to initialize reservation: %v", err)
 		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
@@ -988,6 +1200,8 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		return
 	}
 
+	reservation.SetOurUpfrontShutdown(shutdownScript)
+
 	// As we're the responder, we get to specify the number of
 	// confirmations that we require before both of us consider the channel
 	// open. We'll use out mapping to derive the proper number of
@@ -1016,12 +1230,13 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 
 	// Using the RequiredRemoteDelay closure, we'll compute the remote CSV
 	// delay we require given the total amount of funds within the channel.
-	remoteCsvDelay := f.cfg.RequiredRemoteDelay(amt)
+	capacity := amt + localAmt
+	remoteCsvDelay := f.cfg.RequiredRemoteDelay(capacity)
 
 	// We'll also generate our required constraints for the remote party,
-	chanReserve := f.cfg.RequiredRemoteChanReserve(amt)
-	maxValue := f.cfg.RequiredRemoteMaxValue(amt)
-	maxHtlcs := f.cfg.RequiredRemoteMaxHTLCs(amt)
+	chanReserve := f.cfg.RequiredRemoteChanReserve(capacity)
+	maxValue := f.cfg.RequiredRemoteMaxValue(capacity)
+	maxHtlcs := f.cfg.RequiredRemoteMaxHTLCs(capacity)
 	minHtlc := f.cfg.DefaultRoutingPolicy.MinHTLC
 
 	// Once the reservation has been created successfully, we add it to
@@ -1036,6 +1251,7 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		chanAmt:        amt,
 		remoteCsvDelay: remoteCsvDelay,
 		remoteMinHtlc:  minHtlc,
+		dualFunded:     dualFunded,
 		err:            make(chan error, 1),
 		peerAddress:    fmsg.peerAddress,
 	}
@@ -1050,6 +1266,7 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	remoteContribution := &lnwallet.ChannelContribution{
 		FundingAmount:        amt,
 		FirstCommitmentPoint: msg.FirstCommitmentPoint,
+		UpfrontShutdown:      msg.UpfrontShutdownScript,
 		ChannelConfig: &channeldb.ChannelConfig{
 			ChannelConstraints: channeldb.ChannelConstraints{
 				DustLimit:        msg.DustLimit,
@@ -1076,12 +1293,20 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 			},
 		},
 	}
-	err = reservation.ProcessSingleContribution(remoteContribution)
-	if err != nil {
-		fndgLog.Errorf("unable to add contribution reservation: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, err)
-		return
+
+	// If this is a dual funded channel, then their contribution is only
+	// processed once the funding transaction has been negotiated.
+	if dualFunded {
+		resCtx.remoteContribution = remoteContribution
+	} else {
+		err = reservation.ProcessSingleContribution(remoteContribution)
+		if err != nil {
+			fndgLog.Errorf("unable to add contribution "+
+				"reservation: %v", err)
+			f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+				msg.PendingChannelID, err)
+			return
+		}
 	}
 
 	fndgLog.Infof("Sending fundingResp for pendingID(%x)",
@@ -1093,20 +1318,21 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// contribution in the next message of the workflow.
 	ourContribution := reservation.OurContribution()
 	fundingAccept := lnwire.AcceptChannel{
-		PendingChannelID:     msg.PendingChannelID,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		MinAcceptDepth:       uint32(numConfsReq),
-		HtlcMinimum:          minHtlc,
-		CsvDelay:             remoteCsvDelay,
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey.PubKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint.PubKey,
-		PaymentPoint:         ourContribution.PaymentBasePoint.PubKey,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint.PubKey,
-		HtlcPoint:            ourContribution.HtlcBasePoint.PubKey,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
+		PendingChannelID:      msg.PendingChannelID,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		MinAcceptDepth:        uint32(numConfsReq),
+		HtlcMinimum:           minHtlc,
+		CsvDelay:              remoteCsvDelay,
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey.PubKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint.PubKey,
+		PaymentPoint:          ourContribution.PaymentBasePoint.PubKey,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint.PubKey,
+		HtlcPoint:             ourContribution.HtlcBasePoint.PubKey,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		UpfrontShutdownScript: ourContribution.UpfrontShutdown,
 	}
 	err = f.cfg.SendToPeer(fmsg.peerAddress.IdentityKey, &fundingAccept)
 	if err != nil {
@@ -1115,6 +1341,19 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 			msg.PendingChannelID, err)
 		return
 	}
+
+	// For dual funded channels, we'll now wait for the initiator to start
+	// the construction of the funding transaction.
+	if dualFunded {
+		err := f.startInteractiveTx(resCtx, msg.PendingChannelID)
+		if err != nil {
+			fndgLog.Errorf("unable to start funding transaction "+
+				"construction: %v", err)
+			f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+				msg.PendingChannelID, err)
+			return
+		}
+	}
 }
 
 // processFundingAccept sends a message to the fundingManager allowing it to
@@ -1164,6 +1403,17 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		return
 	}
 
+	// If the responder committed to a delivery address for cooperative
+	// closes, then we'll ensure it's one we'd accept within a Shutdown
+	// message.
+	if err := validateUpfrontShutdown(msg.UpfrontShutdownScript); err != nil {
+		fndgLog.Warnf("Unacceptable upfront shutdown script: %v", err)
+		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+			fmsg.msg.PendingChannelID, err)
+		resCtx.err <- err
+		return
+	}
+
 	// As they've accepted our channel constraints, we'll regenerate them
 	// here so we can properly commit their accepted constraints to the
 	// reservation.
@@ -1177,6 +1427,7 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 	// the funding transaction.
 	remoteContribution := &lnwallet.ChannelContribution{
 		FirstCommitmentPoint: msg.FirstCommitmentPoint,
+		UpfrontShutdown:      msg.UpfrontShutdownScript,
 		ChannelConfig: &channeldb.ChannelConfig{
 			ChannelConstraints: channeldb.ChannelConstraints{
 				DustLimit:        msg.DustLimit,
@@ -1203,6 +1454,36 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 			},
 		},
 	}
+
+	fndgLog.Infof("pendingChan(%x): remote party proposes num_confs=%v, "+
+		"csv_delay=%v", pendingChanID[:], msg.MinAcceptDepth, msg.CsvDelay)
+	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
+		spew.Sdump(remoteContribution.ChannelConfig.ChannelConstraints))
+
+	// If this is a dual funded channel, then we'll kick off the
+	// construction of the funding transaction. Their contribution will be
+	// processed once it has completed.
+	if resCtx.dualFunded {
+		resCtx.remoteContribution = remoteContribution
+		err := f.startInteractiveTx(resCtx, pendingChanID)
+		if err != nil {
+			fndgLog.Errorf("Unable to start funding transaction "+
+				"construction: %v", err)
+			f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+				msg.PendingChannelID, err)
+			resCtx.err <- err
+		}
+		return
+	}
+
+	// If the channel is funded as part of a batch, then the funding
+	// transaction can only be constructed once every peer within the
+	// batch has accepted its channel.
+	if resCtx.batch != nil {
+		f.handleBatchAccept(resCtx, pendingChanID, remoteContribution)
+		return
+	}
+
 	err = resCtx.reservation.ProcessContribution(remoteContribution)
 	if err != nil {
 		fndgLog.Errorf("Unable to process contribution from %v: %v",
@@ -1213,10 +1494,16 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		return
 	}
 
-	fndgLog.Infof("pendingChan(%x): remote party proposes num_confs=%v, "+
-		"csv_delay=%v", pendingChanID[:], msg.MinAcceptDepth, msg.CsvDelay)
-	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
-		spew.Sdump(remoteContribution.ChannelConfig.ChannelConstraints))
+	f.sendFundingCreated(resCtx, pendingChanID)
+}
+
+// sendFundingCreated sends the funding outpoint and our signature for the
+// remote party's version of the commitment transaction to the remote party,
+// once we've processed their contribution to a channel we initiated.
+func (f *fundingManager) sendFundingCreated(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
 
 	// Now that we have their contribution, we can extract, then send over
 	// both the funding out point and our signature for their version of
@@ -1249,19 +1536,18 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		PendingChannelID: pendingChanID,
 		FundingPoint:     *outPoint,
 	}
+	var err error
 	fundingCreated.CommitSig, err = lnwire.NewSigFromRawSignature(sig)
 	if err != nil {
 		fndgLog.Errorf("Unable to parse signature: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
 		resCtx.err <- err
 		return
 	}
-	err = f.cfg.SendToPeer(fmsg.peerAddress.IdentityKey, fundingCreated)
+	err = f.cfg.SendToPeer(peerKey, fundingCreated)
 	if err != nil {
 		fndgLog.Errorf("Unable to send funding complete message: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, err)
+		f.failFundingFlow(peerKey, pendingChanID, err)
 		resCtx.err <- err
 		return
 	}
@@ -1294,6 +1580,14 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 		return
 	}
 
+	// For dual funded channels, we've already assembled the funding
+	// transaction ourselves, so we'll hold onto their signature until
+	// they've sent their signatures for the funding transaction.
+	if resCtx.dualFunded {
+		f.handleDualFundingCreated(resCtx, fmsg)
+		return
+	}
+
 	// The channel initiator has responded with the funding outpoint of the
 	// final funding transaction, as well as a signature for our version of
 	// the commitment transaction. So at this point, we can validate the
@@ -1319,23 +1613,6 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 		return
 	}
 
-	// If something goes wrong before the funding transaction is confirmed,
-	// we use this convenience method to delete the pending OpenChannel
-	// from the database.
-	deleteFromDatabase := func() {
-		closeInfo := &channeldb.ChannelCloseSummary{
-			ChanPoint: completeChan.FundingOutpoint,
-			ChainHash: completeChan.ChainHash,
-			RemotePub: completeChan.IdentityPub,
-			CloseType: channeldb.FundingCanceled,
-		}
-
-		if err := completeChan.CloseChannel(closeInfo); err != nil {
-			fndgLog.Errorf("Failed closing channel %v: %v",
-				completeChan.FundingOutpoint, err)
-		}
-	}
-
 	// A new channel has almost finished the funding process. In order to
 	// properly synchronize with the writeHandler goroutine, we add a new
 	// channel to the barriers map which will be closed once the channel is
@@ -1357,7 +1634,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 		fndgLog.Errorf("unable to parse signature: %v", err)
 		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
 			pendingChanID, err)
-		deleteFromDatabase()
+		deletePendingChannel(completeChan)
 		return
 	}
 
@@ -1369,10 +1646,41 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 		fndgLog.Errorf("unable to send FundingSigned message: %v", err)
 		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
 			pendingChanID, err)
-		deleteFromDatabase()
+		deletePendingChannel(completeChan)
 		return
 	}
 
+	f.waitForResponderFunding(resCtx, pendingChanID, completeChan)
+}
+
+// deletePendingChannel deletes a pending channel from the database. It's used
+// if something goes wrong before the channel's funding transaction is
+// confirmed.
+func deletePendingChannel(completeChan *channeldb.OpenChannel) {
+	closeInfo := &channeldb.ChannelCloseSummary{
+		ChanPoint: completeChan.FundingOutpoint,
+		ChainHash: completeChan.ChainHash,
+		RemotePub: completeChan.IdentityPub,
+		CloseType: channeldb.FundingCanceled,
+	}
+
+	if err := completeChan.CloseChannel(closeInfo); err != nil {
+		fndgLog.Errorf("Failed closing channel %v: %v",
+			completeChan.FundingOutpoint, err)
+	}
+}
+
+// waitForResponderFunding hands a channel we responded to off to the
+// ChainArbitrator, then waits for its funding transaction to confirm before
+// starting normal operations. This is called once we've sent our final
+// signatures for the channel to the initiator.
+func (f *fundingManager) waitForResponderFunding(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, completeChan *channeldb.OpenChannel) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
+	fundingOut := completeChan.FundingOutpoint
+	channelID := lnwire.NewChanIDFromOutPoint(&fundingOut)
+
 	// Now that we've sent over our final signature for this channel, we'll
 	// send it to the ChainArbitrator so it can watch for any on-chain
 	// actions during this final confirmation stage.
@@ -1424,7 +1732,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 		case <-timeoutChan:
 			// We did not see the funding confirmation before
 			// timeout, so we forget the channel.
-			deleteFromDatabase()
+			deletePendingChannel(completeChan)
 			return
 		case <-f.quit:
 			// The fundingManager is shutting down, will resume
@@ -1440,7 +1748,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 		}
 
 		// Success, funding transaction was confirmed.
-		f.deleteReservationCtx(peerKey, fmsg.msg.PendingChannelID)
+		f.deleteReservationCtx(peerKey, pendingChanID)
 
 		err := f.handleFundingConfirmation(completeChan,
 			shortChanID)
@@ -1499,6 +1807,22 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 		return
 	}
 
+	// For dual funded channels, the reservation can only be completed once
+	// we've also received their signatures for the funding transaction,
+	// so we'll hold onto their commitment signature until then.
+	if resCtx.dualFunded {
+		resCtx.remoteCommitSig = fmsg.msg.CommitSig.ToSignatureBytes()
+		return
+	}
+
+	// The funding transaction of a batch is only broadcast once we hold
+	// a valid commitment transaction for every channel within it.
+	if resCtx.batch != nil {
+		commitSig := fmsg.msg.CommitSig.ToSignatureBytes()
+		f.handleBatchSigned(resCtx, pendingChanID, commitSig)
+		return
+	}
+
 	// Create an entry in the local discovery map so we can ensure that we
 	// process the channel confirmation fully before we receive a funding
 	// locked message.
@@ -1521,6 +1845,19 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 		return
 	}
 
+	f.waitForInitiatorFunding(resCtx, pendingChanID, completeChan)
+}
+
+// waitForInitiatorFunding hands a channel we initiated off to the
+// ChainArbitrator, then waits for its funding transaction to confirm before
+// announcing the channel. This is called once the funding transaction has
+// been broadcast.
+func (f *fundingManager) waitForInitiatorFunding(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, completeChan *channeldb.OpenChannel) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
+	fundingPoint := &completeChan.FundingOutpoint
+
 	// Now that we have a finalized reservation for this funding flow,
 	// we'll send the to be active channel to the ChainArbitrator so it can
 	// watch for any on-chin actions before the channel has fully
@@ -1973,11 +2310,13 @@ func (f *fundingManager) addToRouterGraph(completeChan *channeldb.OpenChannel,
 	// will be the one that's carrying the HTLC towards us.
 	remoteMinHTLC := completeChan.RemoteChanCfg.MinHTLC
 
+	maxHTLC := chanMaxHTLC(completeChan)
+
 	ann, err := f.newChanAnnouncement(
 		f.cfg.IDKey, completeChan.IdentityPub,
 		completeChan.LocalChanCfg.MultiSigKey.PubKey,
 		completeChan.RemoteChanCfg.MultiSigKey.PubKey, *shortChanID,
-		chanID, remoteMinHTLC,
+		chanID, remoteMinHTLC, maxHTLC,
 	)
 	if err != nil {
 		return fmt.Errorf("error generating channel "+
@@ -2080,6 +2419,7 @@ func (f *fundingManager) annAfterSixConfs(completeChan *channeldb.OpenChannel,
 		// ChannelUpdate. We use this value isn't of ours, as the remote party
 		// will be the one that's carrying the HTLC towards us.
 		remoteMinHTLC := completeChan.RemoteChanCfg.MinHTLC
+		maxHTLC := chanMaxHTLC(completeChan)
 
 		// Create and broadcast the proofs required to make this channel
 		// public and usable for other nodes for routing.
@@ -2087,7 +2427,7 @@ func (f *fundingManager) annAfterSixConfs(completeChan *channeldb.OpenChannel,
 			f.cfg.IDKey, completeChan.IdentityPub,
 			completeChan.LocalChanCfg.MultiSigKey.PubKey,
 			completeChan.RemoteChanCfg.MultiSigKey.PubKey,
-			*shortChanID, chanID, remoteMinHTLC,
+			*shortChanID, chanID, remoteMinHTLC, maxHTLC,
 		)
 		if err != nil {
 			return fmt.Errorf("channel announcement failed: %v", err)
@@ -2275,6 +2615,21 @@ type chanAnnouncement struct {
 	chanProof     *lnwire.AnnounceSignatures
 }
 
+// chanMaxHTLC returns the max HTLC we'll advertise within our ChannelUpdate
+// for the given channel. This is bounded by the max value in flight the
+// remote party permits us to have outstanding, as well as the capacity of the
+// channel itself.
+func chanMaxHTLC(channel *channeldb.OpenChannel) lnwire.MilliSatoshi {
+	maxHTLC := channel.LocalChanCfg.MaxPendingAmount
+
+	capacity := lnwire.NewMSatFromSatoshis(channel.Capacity)
+	if maxHTLC > capacity {
+		maxHTLC = capacity
+	}
+
+	return maxHTLC
+}
+
 // newChanAnnouncement creates the authenticated channel announcement messages
 // required to broadcast a newly created channel to the network. The
 // announcement is two part: the first part authenticates the existence of the
@@ -2285,7 +2640,7 @@ type chanAnnouncement struct {
 func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.PublicKey,
 	localFundingKey, remoteFundingKey *btcec.PublicKey,
 	shortChanID lnwire.ShortChannelID, chanID lnwire.ChannelID,
-	remoteMinHTLC lnwire.MilliSatoshi) (*chanAnnouncement, error) {
+	remoteMinHTLC, maxHTLC lnwire.MilliSatoshi) (*chanAnnouncement, error) {
 
 	chainHash := *f.cfg.Wallet.Cfg.NetParams.GenesisHash
 
@@ -2302,7 +2657,7 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.Pu
 	// being updated within the ChannelUpdateAnnouncement announcement
 	// below. A value of zero means it's the edge of the "first" node and 1
 	// being the other node.
-	var chanFlags lnwire.ChanUpdateFlag
+	var chanFlags lnwire.ChanUpdateChanFlags
 
 	// The lexicographical ordering of the two identity public keys of the
 	// nodes indicates which of the nodes is "first". If our serialized
@@ -2336,12 +2691,14 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.Pu
 		ShortChannelID: shortChanID,
 		ChainHash:      chainHash,
 		Timestamp:      uint32(time.Now().Unix()),
-		Flags:          chanFlags,
+		MessageFlags:   lnwire.ChanUpdateOptionMaxHtlc,
+		ChannelFlags:   chanFlags,
 		TimeLockDelta:  uint16(f.cfg.DefaultRoutingPolicy.TimeLockDelta),
 
 		// We use the *remote* party's HtlcMinimumMsat, as they'll be
 		// the ones carrying the HTLC routed *towards* us.
 		HtlcMinimumMsat: remoteMinHTLC,
+		HtlcMaximumMsat: maxHTLC,
 
 		BaseFee: uint32(f.cfg.DefaultRoutingPolicy.BaseFee),
 		FeeRate: uint32(f.cfg.DefaultRoutingPolicy.FeeRate),
@@ -2420,7 +2777,7 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.Pu
 // finish, either successfully or with an error.
 func (f *fundingManager) announceChannel(localIDKey, remoteIDKey, localFundingKey,
 	remoteFundingKey *btcec.PublicKey, shortChanID lnwire.ShortChannelID,
-	chanID lnwire.ChannelID, remoteMinHTLC lnwire.MilliSatoshi) error {
+	chanID lnwire.ChannelID, remoteMinHTLC, maxHTLC lnwire.MilliSatoshi) error {
 
 	// First, we'll create the batch of announcements to be sent upon
 	// initial channel creation. This includes the channel announcement
@@ -2428,7 +2785,7 @@ func (f *fundingManager) announceChannel(localIDKey, remoteIDKey, localFundingKe
 	// proof needed to fully authenticate the channel.
 	ann, err := f.newChanAnnouncement(localIDKey, remoteIDKey,
 		localFundingKey, remoteFundingKey, shortChanID, chanID,
-		remoteMinHTLC,
+		remoteMinHTLC, maxHTLC,
 	)
 	if err != nil {
 		fndgLog.Errorf("can't generate channel announcement: %v", err)
@@ -2487,6 +2844,14 @@ func (f *fundingManager) initFundingWorkflow(peerAddress *lnwire.NetAddress,
 // wallet, then sends a funding request to the remote peer kicking off the
 // funding workflow.
 func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
+	if err := f.initFundingFlow(msg); err != nil {
+		msg.err <- err
+	}
+}
+
+// initFundingFlow creates and indexes a channel reservation for the passed
+// request, then sends the OpenChannel message to the remote peer.
+func (f *fundingManager) initFundingFlow(msg *initFundingMsg) error {
 	var (
 		peerKey        = msg.peerAddress.IdentityKey
 		localAmt       = msg.localFundingAmt
@@ -2502,14 +2867,20 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.pushAmt, capacity, msg.chainHash, msg.peerAddress.Address,
 		ourDustLimit)
 
+	// Channels above the regular size limit may only be opened with peers
+	// that signal support for them.
+	if maxChanSize := f.maxChanSize(peerKey); capacity > maxChanSize {
+		return fmt.Errorf("funding amount is too large, the max "+
+			"channel size with this peer is: %v", maxChanSize)
+	}
+
 	// First, we'll query the fee estimator for a fee that should get the
 	// commitment transaction confirmed by the next few blocks (conf target
 	// of 3). We target the near blocks here to ensure that we'll be able
 	// to execute a timely unilateral channel closure if needed.
 	feePerVSize, err := f.cfg.FeeEstimator.EstimateFeePerVSize(3)
 	if err != nil {
-		msg.err <- err
-		return
+		return err
 	}
 
 	// The protocol currently operates on the basis of fee-per-kw, so we'll
@@ -2524,6 +2895,16 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		channelFlags = lnwire.FFAnnounceChannel
 	}
 
+	// Determine the delivery address we'll commit to for cooperative
+	// closes, if any. This fails if the user requested one, but the peer
+	// doesn't support upfront shutdown scripts.
+	shutdownScript, err := f.cfg.UpfrontShutdownScript(
+		peerKey, msg.openChanReq.shutdownScript,
+	)
+	if err != nil {
+		return err
+	}
+
 	// Initialize a funding reservation with the local wallet. If the
 	// wallet doesn't have enough funds to commit to this channel, then the
 	// request will fail, and be aborted.
@@ -2533,9 +2914,9 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		&msg.chainHash, channelFlags,
 	)
 	if err != nil {
-		msg.err <- err
-		return
+		return err
 	}
+	reservation.SetOurUpfrontShutdown(shutdownScript)
 
 	// Obtain a new pending channel ID which is used to track this
 	// reservation throughout its lifetime.
@@ -2565,18 +2946,27 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		f.activeReservations[peerIDKey] = make(pendingChannels)
 	}
 
+	// Channels funded as part of a batch share their funding transaction
+	// with the other channels in the batch, so they can't be dual funded.
 	resCtx := &reservationWithCtx{
 		chanAmt:        capacity,
 		remoteCsvDelay: remoteCsvDelay,
 		remoteMinHtlc:  minHtlc,
 		reservation:    reservation,
 		peerAddress:    msg.peerAddress,
+		dualFunded:     msg.batch == nil && f.cfg.SupportsDualFunding(peerKey),
+		fundingFeeRate: msg.fundingFeePerVSize,
+		batch:          msg.batch,
 		updates:        msg.updates,
 		err:            msg.err,
 	}
 	f.activeReservations[peerIDKey][chanID] = resCtx
 	f.resMtx.Unlock()
 
+	if msg.batch != nil {
+		msg.batch.addChannel(resCtx, chanID)
+	}
+
 	// Update the timestamp once the initFundingMsg has been handled.
 	defer resCtx.updateTimestamp()
 
@@ -2595,24 +2985,25 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.peerAddress.Address, chanID)
 
 	fundingOpen := lnwire.OpenChannel{
-		ChainHash:            *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
-		PendingChannelID:     chanID,
-		FundingAmount:        capacity,
-		PushAmount:           msg.pushAmt,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		HtlcMinimum:          minHtlc,
-		FeePerKiloWeight:     uint32(commitFeePerKw),
-		CsvDelay:             remoteCsvDelay,
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey.PubKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint.PubKey,
-		PaymentPoint:         ourContribution.PaymentBasePoint.PubKey,
-		HtlcPoint:            ourContribution.HtlcBasePoint.PubKey,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint.PubKey,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
-		ChannelFlags:         channelFlags,
+		ChainHash:             *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
+		PendingChannelID:      chanID,
+		FundingAmount:         capacity,
+		PushAmount:            msg.pushAmt,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		HtlcMinimum:           minHtlc,
+		FeePerKiloWeight:      uint32(commitFeePerKw),
+		CsvDelay:              remoteCsvDelay,
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey.PubKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint.PubKey,
+		PaymentPoint:          ourContribution.PaymentBasePoint.PubKey,
+		HtlcPoint:             ourContribution.HtlcBasePoint.PubKey,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint.PubKey,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		ChannelFlags:          channelFlags,
+		UpfrontShutdownScript: ourContribution.UpfrontShutdown,
 	}
 	if err := f.cfg.SendToPeer(peerKey, &fundingOpen); err != nil {
 		e := fmt.Errorf("Unable to send funding request message: %v",
@@ -2625,9 +3016,10 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 			fndgLog.Errorf("unable to cancel reservation: %v", err)
 		}
 
-		msg.err <- e
-		return
+		return e
 	}
+
+	return nil
 }
 
 // waitUntilChannelOpen is designed to prevent other lnd subsystems from
@@ -2691,22 +3083,30 @@ func (f *fundingManager) handleErrorMsg(fmsg *fundingErrorMsg) {
 
 	// If this isn't a simple error code, then we'll display the entire
 	// thing.
+	var fundingErr error
 	if len(protocolErr.Data) > 1 {
-		resCtx.err <- grpc.Errorf(
+		fundingErr = grpc.Errorf(
 			lnErr.ToGrpcCode(), string(protocolErr.Data),
 		)
 	} else {
 		// Otherwise, we'll attempt to display just the error code
 		// itself.
-		resCtx.err <- grpc.Errorf(
+		fundingErr = grpc.Errorf(
 			lnErr.ToGrpcCode(), lnErr.String(),
 		)
 	}
+	resCtx.err <- fundingErr
 
 	if _, err := f.cancelReservationCtx(peerKey, chanID); err != nil {
 		fndgLog.Warnf("unable to delete reservation: %v", err)
 		return
 	}
+
+	// A batch can't be funded without this channel, so we'll fail the
+	// remaining channels within it.
+	if resCtx.batch != nil {
+		f.failBatch(resCtx.batch, fundingErr)
+	}
 }
 
 // pruneZombieReservations loops through all pending reservations and fails the
@@ -2757,6 +3157,14 @@ func (f *fundingManager) cancelReservationCtx(peerKey *btcec.PublicKey,
 	}
 
 	f.deleteReservationCtx(peerKey, pendingChanID)
+
+	// If we've already released our signatures for the funding
+	// transaction of a dual funded channel, then the remote party is able
+	// to broadcast it regardless, so we'll keep watching for it.
+	if ctx.pendingChannel != nil {
+		f.watchReleasedChannel(ctx)
+	}
+
 	return ctx, nil
 }
 
@@ -2819,7 +3227,7 @@ func copyPubKey(pub *btcec.PublicKey) *btcec.PublicKey {
 // chanPoint to the channelOpeningStateBucket.
 func (f *fundingManager) saveChannelOpeningState(chanPoint *wire.OutPoint,
 	state channelOpeningState, shortChanID *lnwire.ShortChannelID) error {
-	return f.cfg.Wallet.Cfg.Database.Update(func(tx *bolt.Tx) error {
+	return f.cfg.Wallet.Cfg.Database.Update(func(tx kvdb.Tx) error {
 
 		bucket, err := tx.CreateBucketIfNotExists(channelOpeningStateBucket)
 		if err != nil {
@@ -2849,7 +3257,7 @@ func (f *fundingManager) getChannelOpeningState(chanPoint *wire.OutPoint) (
 
 	var state channelOpeningState
 	var shortChanID lnwire.ShortChannelID
-	err := f.cfg.Wallet.Cfg.Database.View(func(tx *bolt.Tx) error {
+	err := f.cfg.Wallet.Cfg.Database.View(func(tx kvdb.Tx) error {
 
 		bucket := tx.Bucket(channelOpeningStateBucket)
 		if bucket == nil {
@@ -2881,7 +3289,7 @@ func (f *fundingManager) getChannelOpeningState(chanPoint *wire.OutPoint) (
 
 // deleteChannelOpeningState removes any state for chanPoint from the database.
 func (f *fundingManager) deleteChannelOpeningState(chanPoint *wire.OutPoint) error {
-	return f.cfg.Wallet.Cfg.Database.Update(func(tx *bolt.Tx) error {
+	return f.cfg.Wallet.Cfg.Database.Update(func(tx kvdb.Tx) error {
 		bucket := tx.Bucket(channelOpeningStateBucket)
 		if bucket == nil {
 			return fmt.Errorf("Bucket not found")
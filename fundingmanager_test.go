@@ -23,6 +23,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
 	_ "github.com/roasbeef/btcwallet/walletdb/bdb"
 
 	"github.com/roasbeef/btcd/btcec"
@@ -252,10 +253,12 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 			return lnwire.NodeAnnouncement{}, nil
 		},
 		SendToPeer: func(target *btcec.PublicKey, msgs ...lnwire.Message) error {
-			select {
-			case sentMessages <- msgs[0]:
-			case <-shutdownChan:
-				return fmt.Errorf("shutting down")
+			for _, msg := range msgs {
+				select {
+				case sentMessages <- msg:
+				case <-shutdownChan:
+					return fmt.Errorf("shutting down")
+				}
 			}
 			return nil
 		},
@@ -315,6 +318,20 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		},
 		ZombieSweeperInterval: 1 * time.Hour,
 		ReservationTimeout:    1 * time.Nanosecond,
+		SupportsDualFunding: func(*btcec.PublicKey) bool {
+			return false
+		},
+		DualFundingContribution: func(btcutil.Amount) btcutil.Amount {
+			return 0
+		},
+		SupportsLargeChannels: func(*btcec.PublicKey) bool {
+			return false
+		},
+		UpfrontShutdownScript: func(*btcec.PublicKey,
+			lnwire.DeliveryAddress) (lnwire.DeliveryAddress, error) {
+
+			return nil, nil
+		},
 	})
 	if err != nil {
 		t.Fatalf("failed creating fundingManager: %v", err)
@@ -374,10 +391,12 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		},
 		SendToPeer: func(target *btcec.PublicKey,
 			msgs ...lnwire.Message) error {
-			select {
-			case aliceMsgChan <- msgs[0]:
-			case <-shutdownChan:
-				return fmt.Errorf("shutting down")
+			for _, msg := range msgs {
+				select {
+				case aliceMsgChan <- msg:
+				case <-shutdownChan:
+					return fmt.Errorf("shutting down")
+				}
 			}
 			return nil
 		},
@@ -392,8 +411,12 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 			publishChan <- txn
 			return nil
 		},
-		ZombieSweeperInterval: oldCfg.ZombieSweeperInterval,
-		ReservationTimeout:    oldCfg.ReservationTimeout,
+		ZombieSweeperInterval:   oldCfg.ZombieSweeperInterval,
+		ReservationTimeout:      oldCfg.ReservationTimeout,
+		SupportsDualFunding:     oldCfg.SupportsDualFunding,
+		DualFundingContribution: oldCfg.DualFundingContribution,
+		SupportsLargeChannels:   oldCfg.SupportsLargeChannels,
+		UpfrontShutdownScript:   oldCfg.UpfrontShutdownScript,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -589,6 +612,8 @@ func assertFundingMsgSent(t *testing.T, msgChan chan lnwire.Message,
 		ok      bool
 	)
 	switch msgType {
+	case "OpenChannel":
+		sentMsg, ok = msg.(*lnwire.OpenChannel)
 	case "AcceptChannel":
 		sentMsg, ok = msg.(*lnwire.AcceptChannel)
 	case "FundingCreated":
@@ -2140,3 +2165,684 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// setupDualFundingManagers creates two funding managers that open dual funded
+// channels with each other, each owning a single P2WKH output to fund them
+// with. Bob contributes bobAmt to any channel opened by Alice.
+func setupDualFundingManagers(t *testing.T,
+	bobAmt btcutil.Amount) (*testNode, *testNode) {
+
+	alice, bob := setupFundingManagers(t)
+
+	// Both wallets use the same key, so they can share the script of
+	// their outputs, while spending distinct outpoints.
+	pkScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(alicePubKey.SerializeCompressed())).
+		Script()
+	if err != nil {
+		t.Fatalf("unable to create output script: %v", err)
+	}
+
+	// The chain backends of both nodes share a single utxo set, as each
+	// has to look up the inputs added by the other.
+	utxos := make(map[wire.OutPoint]*wire.TxOut)
+	for i, node := range []*testNode{alice, bob} {
+		utxo := &lnwallet.Utxo{
+			AddressType: lnwallet.WitnessPubKey,
+			Value:       btcutil.Amount(10 * btcutil.SatoshiPerBitcoin),
+			PkScript:    pkScript,
+			OutPoint: wire.OutPoint{
+				Hash: chainhash.Hash{byte(i + 1)},
+			},
+		}
+		utxos[utxo.OutPoint] = wire.NewTxOut(
+			int64(utxo.Value), utxo.PkScript,
+		)
+
+		wallet := node.fundingMgr.cfg.Wallet
+		wc := wallet.Cfg.WalletController.(*mockWalletController)
+		wc.utxos = []*lnwallet.Utxo{utxo}
+		wallet.Cfg.ChainIO.(*mockChainIO).utxos = utxos
+
+		node.fundingMgr.cfg.SupportsDualFunding = func(
+			*btcec.PublicKey) bool {
+
+			return true
+		}
+	}
+
+	bob.fundingMgr.cfg.DualFundingContribution = func(
+		btcutil.Amount) btcutil.Amount {
+
+		return bobAmt
+	}
+
+	return alice, bob
+}
+
+// startDualFundedChannel runs through the opening of a dual funded channel by
+// Alice, up until Bob has sent his signatures for both Alice's commitment
+// transaction and the funding transaction. The pending channel ID is
+// returned, along with Bob's signatures.
+func startDualFundedChannel(t *testing.T, alice, bob *testNode,
+	localAmt btcutil.Amount, updateChan chan *lnrpc.OpenStatusUpdate) (
+	[32]byte, *lnwire.FundingSigned, *lnwire.TxSignatures) {
+
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: localAmt,
+		updates:         updateChan,
+		err:             errChan,
+	}
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	var openChannelReq *lnwire.OpenChannel
+	select {
+	case msg := <-alice.msgChan:
+		var ok bool
+		openChannelReq, ok = msg.(*lnwire.OpenChannel)
+		if !ok {
+			t.Fatalf("expected OpenChannel to be sent from "+
+				"alice, instead got %T", msg)
+		}
+	case err := <-errChan:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenChannel message")
+	}
+	pendingChanID := openChannelReq.PendingChannelID
+
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+	acceptChannelResponse := assertFundingMsgSent(
+		t, bob.msgChan, "AcceptChannel",
+	).(*lnwire.AcceptChannel)
+	alice.fundingMgr.processFundingAccept(acceptChannelResponse, bobAddr)
+
+	// Relay the messages constructing the funding transaction between
+	// both parties, until Alice sends FundingCreated once it's complete.
+	var fundingCreated *lnwire.FundingCreated
+	for fundingCreated == nil {
+		select {
+		case msg := <-alice.msgChan:
+			switch m := msg.(type) {
+			case *lnwire.FundingCreated:
+				fundingCreated = m
+			case *lnwire.Error:
+				t.Fatalf("alice sent error: %v", string(m.Data))
+			default:
+				bob.fundingMgr.processInteractiveTxMsg(
+					msg, aliceAddr,
+				)
+			}
+
+		case msg := <-bob.msgChan:
+			if m, ok := msg.(*lnwire.Error); ok {
+				t.Fatalf("bob sent error: %v", string(m.Data))
+			}
+			alice.fundingMgr.processInteractiveTxMsg(msg, bobAddr)
+
+		case err := <-errChan:
+			t.Fatalf("error constructing funding transaction: %v",
+				err)
+
+		case <-time.After(time.Second * 5):
+			t.Fatalf("funding transaction construction stalled")
+		}
+	}
+
+	// Bob must have persisted the channel before releasing any of his
+	// signatures.
+	bob.fundingMgr.processFundingCreated(fundingCreated, aliceAddr)
+	fundingSigned := assertFundingMsgSent(
+		t, bob.msgChan, "FundingSigned",
+	).(*lnwire.FundingSigned)
+	assertNumPendingChannelsBecomes(t, bob, 1)
+
+	var txSigs *lnwire.TxSignatures
+	select {
+	case msg := <-bob.msgChan:
+		var ok bool
+		txSigs, ok = msg.(*lnwire.TxSignatures)
+		if !ok {
+			t.Fatalf("expected TxSignatures to be sent from bob, "+
+				"instead got %T", msg)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not send TxSignatures message")
+	}
+
+	return pendingChanID, fundingSigned, txSigs
+}
+
+// TestFundingManagerDualFunding tests that a dual funded channel can be
+// opened, with both parties contributing inputs to the funding transaction,
+// and both ending up with the channel persisted and the transaction
+// broadcast.
+func TestFundingManagerDualFunding(t *testing.T) {
+	const (
+		aliceAmt = btcutil.Amount(500000)
+		bobAmt   = btcutil.Amount(300000)
+	)
+
+	alice, bob := setupDualFundingManagers(t, bobAmt)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	_, fundingSigned, bobTxSigs := startDualFundedChannel(
+		t, alice, bob, aliceAmt, updateChan,
+	)
+
+	// Once Alice holds Bob's signatures, she'll broadcast the funding
+	// transaction and hand over her own signatures for it.
+	alice.fundingMgr.processFundingSigned(fundingSigned, bobAddr)
+	alice.fundingMgr.processInteractiveTxMsg(bobTxSigs, bobAddr)
+
+	var aliceTxSigs *lnwire.TxSignatures
+	select {
+	case msg := <-alice.msgChan:
+		var ok bool
+		aliceTxSigs, ok = msg.(*lnwire.TxSignatures)
+		if !ok {
+			t.Fatalf("expected TxSignatures to be sent from "+
+				"alice, instead got %T", msg)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send TxSignatures message")
+	}
+
+	var alicePubl, bobPubl *wire.MsgTx
+	select {
+	case alicePubl = <-alice.publTxChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not publish funding tx")
+	}
+
+	select {
+	case update := <-updateChan:
+		_, ok := update.Update.(*lnrpc.OpenStatusUpdate_ChanPending)
+		if !ok {
+			t.Fatalf("expected OpenStatusUpdate_ChanPending, "+
+				"got %T", update.Update)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenStatusUpdate_ChanPending")
+	}
+
+	// With Alice's signatures, Bob is able to broadcast the funding
+	// transaction as well.
+	bob.fundingMgr.processInteractiveTxMsg(aliceTxSigs, aliceAddr)
+	select {
+	case bobPubl = <-bob.publTxChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not publish funding tx")
+	}
+
+	if alicePubl.TxHash() != bobPubl.TxHash() {
+		t.Fatalf("alice published %v, while bob published %v",
+			alicePubl.TxHash(), bobPubl.TxHash())
+	}
+	if len(alicePubl.TxIn) != 2 {
+		t.Fatalf("expected funding transaction to spend an input "+
+			"of each party, got %v inputs", len(alicePubl.TxIn))
+	}
+
+	// Both parties should now have the channel persisted, with a capacity
+	// reflecting both of their contributions.
+	assertNumPendingChannelsBecomes(t, alice, 1)
+	assertNumPendingChannelsBecomes(t, bob, 1)
+
+	for _, node := range []*testNode{alice, bob} {
+		pendingChans, err := node.fundingMgr.cfg.Wallet.Cfg.Database.
+			FetchPendingChannels()
+		if err != nil {
+			t.Fatalf("unable to fetch pending channels: %v", err)
+		}
+
+		channel := pendingChans[0]
+		if channel.ChanType != channeldb.DualFunder {
+			t.Fatalf("expected dual funder channel, got %v",
+				channel.ChanType)
+		}
+		if channel.Capacity < aliceAmt+bobAmt-10000 ||
+			channel.Capacity > aliceAmt+bobAmt {
+
+			t.Fatalf("unexpected channel capacity %v",
+				channel.Capacity)
+		}
+	}
+}
+
+// TestFundingManagerDualFundingAbortAfterSigs tests that the responder of a
+// dual funded channel keeps the channel persisted if the funding flow is
+// aborted after it has released its signatures for the funding transaction,
+// as the initiator is still able to broadcast it.
+func TestFundingManagerDualFundingAbortAfterSigs(t *testing.T) {
+	alice, bob := setupDualFundingManagers(t, 300000)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	pendingChanID, _, _ := startDualFundedChannel(
+		t, alice, bob, 500000, updateChan,
+	)
+
+	// Rather than sending her signatures, Alice aborts the funding flow.
+	bob.fundingMgr.processFundingError(&lnwire.Error{
+		ChanID: pendingChanID,
+		Data:   lnwire.ErrorData("funding aborted"),
+	}, aliceAddr)
+
+	// The channel should remain persisted as pending, while Bob's
+	// reservation is canceled.
+	assertNumPendingChannelsRemains(t, bob, 1)
+	assertNumPendingReservations(t, bob, alicePubKey, 0)
+
+	// If Alice does broadcast the funding transaction after all, then Bob
+	// resumes the opening of the channel.
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{}
+	assertFundingMsgSent(t, bob.msgChan, "FundingLocked")
+}
+
+// setupBatchFundingManagers creates funding managers for Alice, Bob and Carol,
+// with Alice owning a P2WKH output for each of the two channels she opens
+// within a batch.
+func setupBatchFundingManagers(t *testing.T) (*testNode, *testNode,
+	*testNode) {
+
+	alice, bob := setupFundingManagers(t)
+
+	carolPrivKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create carol's key: %v", err)
+	}
+	carolTestDir, err := ioutil.TempDir("", "carollnwallet")
+	if err != nil {
+		t.Fatalf("unable to create temp directory: %v", err)
+	}
+	carol, err := createTestFundingManager(t, carolPrivKey, carolTestDir)
+	if err != nil {
+		t.Fatalf("failed creating fundingManager: %v", err)
+	}
+
+	pkScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(alicePubKey.SerializeCompressed())).
+		Script()
+	if err != nil {
+		t.Fatalf("unable to create output script: %v", err)
+	}
+
+	var utxos []*lnwallet.Utxo
+	for i := 0; i < 2; i++ {
+		utxos = append(utxos, &lnwallet.Utxo{
+			AddressType: lnwallet.WitnessPubKey,
+			Value:       btcutil.Amount(10 * btcutil.SatoshiPerBitcoin),
+			PkScript:    pkScript,
+			OutPoint: wire.OutPoint{
+				Hash: chainhash.Hash{byte(i + 1)},
+			},
+		})
+	}
+	wallet := alice.fundingMgr.cfg.Wallet
+	wallet.Cfg.WalletController.(*mockWalletController).utxos = utxos
+
+	return alice, bob, carol
+}
+
+func tearDownBatchFundingManagers(t *testing.T, alice, bob,
+	carol *testNode) {
+
+	tearDownFundingManagers(t, alice, bob)
+
+	close(carol.shutdownChannel)
+	if err := carol.fundingMgr.Stop(); err != nil {
+		t.Fatalf("unable to stop fundingManager: %v", err)
+	}
+	os.RemoveAll(carol.testDir)
+}
+
+// startBatch has Alice initiate a batch opening a channel with each of the
+// passed peers, and returns the batch request along with the OpenChannel
+// message sent to each peer.
+func startBatch(t *testing.T, alice *testNode, peers []*lnwire.NetAddress,
+	amts []btcutil.Amount) (*initBatchFundingMsg, []*lnwire.OpenChannel) {
+
+	batchReq := &initBatchFundingMsg{
+		fundingFeePerVSize: 10,
+		resp:               make(chan []wire.OutPoint, 1),
+		err:                make(chan error, 1),
+	}
+	for i, peerAddr := range peers {
+		batchReq.channels = append(batchReq.channels, &initFundingMsg{
+			peerAddress: peerAddr,
+			openChanReq: &openChanReq{
+				targetPubkey:    peerAddr.IdentityKey,
+				chainHash:       *activeNetParams.GenesisHash,
+				localFundingAmt: amts[i],
+			},
+		})
+	}
+	alice.fundingMgr.initBatchFundingWorkflow(batchReq)
+
+	// The channels are initiated in order, so the OpenChannel messages
+	// are sent in the order of the peers.
+	openMsgs := make([]*lnwire.OpenChannel, 0, len(peers))
+	for range peers {
+		select {
+		case msg := <-alice.msgChan:
+			openMsg, ok := msg.(*lnwire.OpenChannel)
+			if !ok {
+				t.Fatalf("expected OpenChannel to be sent "+
+					"from alice, instead got %T", msg)
+			}
+			openMsgs = append(openMsgs, openMsg)
+
+		case err := <-batchReq.err:
+			t.Fatalf("error init batch funding workflow: %v", err)
+
+		case <-time.After(time.Second * 5):
+			t.Fatalf("alice did not send OpenChannel message")
+		}
+	}
+
+	return batchReq, openMsgs
+}
+
+// assertBatchFailed asserts that Alice failed the passed batch, sending an
+// Error for each of the numErrors channels whose flow was still active, and
+// that none of its channels were persisted, nor its funding transaction
+// broadcast.
+func assertBatchFailed(t *testing.T, alice *testNode,
+	batchReq *initBatchFundingMsg, numErrors int,
+	peers []*lnwire.NetAddress) {
+
+	for i := 0; i < numErrors; i++ {
+		assertErrorSent(t, alice.msgChan)
+	}
+
+	select {
+	case <-batchReq.err:
+	case <-batchReq.resp:
+		t.Fatalf("batch should have failed")
+	case <-time.After(time.Second * 5):
+		t.Fatalf("batch was not failed")
+	}
+
+	select {
+	case tx := <-alice.publTxChan:
+		t.Fatalf("batch funding tx %v broadcast", tx.TxHash())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assertNumPendingChannelsRemains(t, alice, 0)
+	for _, peerAddr := range peers {
+		assertNumPendingReservations(t, alice, peerAddr.IdentityKey, 0)
+	}
+}
+
+// TestFundingManagerBatchPeerRejects tests that a batch is failed as a whole
+// if a single peer rejects its channel, canceling the channels of the peers
+// that already accepted theirs.
+func TestFundingManagerBatchPeerRejects(t *testing.T) {
+	alice, bob, carol := setupBatchFundingManagers(t)
+	defer tearDownBatchFundingManagers(t, alice, bob, carol)
+
+	carolAddr := &lnwire.NetAddress{
+		IdentityKey: carol.privKey.PubKey(),
+		Address:     bobTCPAddr,
+	}
+	peers := []*lnwire.NetAddress{bobAddr, carolAddr}
+
+	batchReq, openMsgs := startBatch(
+		t, alice, peers, []btcutil.Amount{500000, 600000},
+	)
+
+	// Bob accepts his channel, so Alice waits for Carol.
+	bob.fundingMgr.processFundingOpen(openMsgs[0], aliceAddr)
+	acceptMsg := assertFundingMsgSent(
+		t, bob.msgChan, "AcceptChannel",
+	).(*lnwire.AcceptChannel)
+	alice.fundingMgr.processFundingAccept(acceptMsg, bobAddr)
+
+	// Carol rejects her channel instead, which should fail Bob's channel
+	// as well.
+	alice.fundingMgr.processFundingError(&lnwire.Error{
+		ChanID: openMsgs[1].PendingChannelID,
+		Data:   lnwire.ErrorData("channel rejected"),
+	}, carolAddr)
+
+	assertBatchFailed(t, alice, batchReq, 1, peers)
+}
+
+// TestFundingManagerBatchInvalidSig tests that the funding transaction of a
+// batch isn't broadcast, nor any of its channels persisted, if a single peer
+// sends an invalid signature for our commitment transaction.
+func TestFundingManagerBatchInvalidSig(t *testing.T) {
+	alice, bob, carol := setupBatchFundingManagers(t)
+	defer tearDownBatchFundingManagers(t, alice, bob, carol)
+
+	carolAddr := &lnwire.NetAddress{
+		IdentityKey: carol.privKey.PubKey(),
+		Address:     bobTCPAddr,
+	}
+	peers := []*lnwire.NetAddress{bobAddr, carolAddr}
+	nodes := []*testNode{bob, carol}
+
+	batchReq, openMsgs := startBatch(
+		t, alice, peers, []btcutil.Amount{500000, 600000},
+	)
+
+	// Both peers accept their channels, after which Alice sends each of
+	// them a FundingCreated message spending the shared funding
+	// transaction.
+	for i, node := range nodes {
+		node.fundingMgr.processFundingOpen(openMsgs[i], aliceAddr)
+		acceptMsg := assertFundingMsgSent(
+			t, node.msgChan, "AcceptChannel",
+		).(*lnwire.AcceptChannel)
+		alice.fundingMgr.processFundingAccept(acceptMsg, peers[i])
+	}
+
+	fundingSigned := make([]*lnwire.FundingSigned, len(nodes))
+	for i, node := range nodes {
+		fundingCreated := assertFundingMsgSent(
+			t, alice.msgChan, "FundingCreated",
+		).(*lnwire.FundingCreated)
+
+		node.fundingMgr.processFundingCreated(fundingCreated, aliceAddr)
+		fundingSigned[i] = assertFundingMsgSent(
+			t, node.msgChan, "FundingSigned",
+		).(*lnwire.FundingSigned)
+	}
+
+	// Bob's signature is valid, so Alice waits for Carol's.
+	alice.fundingMgr.processFundingSigned(fundingSigned[0], bobAddr)
+
+	// Carol's signature doesn't sign her commitment transaction, which
+	// should fail her channel, and with it Bob's.
+	invalidSig, err := lnwire.NewSigFromSignature(testSig)
+	if err != nil {
+		t.Fatalf("unable to create signature: %v", err)
+	}
+	fundingSigned[1].CommitSig = invalidSig
+	alice.fundingMgr.processFundingSigned(fundingSigned[1], carolAddr)
+
+	assertBatchFailed(t, alice, batchReq, 2, peers)
+}
+
+// TestFundingManagerRejectLargeChannel checks that channels above the regular
+// size limit are refused, both by the initiator and the responder, unless
+// large channels have been negotiated with the peer.
+func TestFundingManagerRejectLargeChannel(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const largeChanAmt = btcutil.Amount(2 * btcutil.SatoshiPerBitcoin)
+
+	// As Alice hasn't negotiated large channels with Bob, she should
+	// refuse to initiate the channel herself.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: largeChanAmt,
+		updates:         updateChan,
+		err:             errChan,
+	}
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	select {
+	case err := <-errChan:
+		if err == nil {
+			t.Fatalf("expected large channel to be refused")
+		}
+	case msg := <-alice.msgChan:
+		t.Fatalf("expected large channel to be refused, instead "+
+			"alice sent %T", msg)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not refuse large channel")
+	}
+	assertNumPendingReservations(t, alice, bobPubKey, 0)
+
+	// Even if Alice believes large channels to be supported, Bob should
+	// reject her OpenChannel, as he didn't negotiate them with her.
+	alice.fundingMgr.cfg.SupportsLargeChannels = func(*btcec.PublicKey) bool {
+		return true
+	}
+	initReq.err = make(chan error, 1)
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	openChannelReq := assertFundingMsgSent(
+		t, alice.msgChan, "OpenChannel",
+	).(*lnwire.OpenChannel)
+
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+
+	var bobMsg lnwire.Message
+	select {
+	case bobMsg = <-bob.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not send Error message")
+	}
+	errorMsg, ok := bobMsg.(*lnwire.Error)
+	if !ok {
+		t.Fatalf("expected Error to be sent from bob, instead got %T",
+			bobMsg)
+	}
+	if lnwire.ErrorCode(errorMsg.Data[0]) != lnwire.ErrChanTooLarge {
+		t.Fatalf("expected ErrChanTooLarge, got %v",
+			lnwire.ErrorCode(errorMsg.Data[0]))
+	}
+	assertNumPendingReservations(t, bob, alicePubKey, 0)
+}
+
+// TestFundingManagerLargeChannel checks that a channel above the regular size
+// limit can be opened once large channels have been negotiated with the peer.
+func TestFundingManagerLargeChannel(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	supportsLargeChannels := func(*btcec.PublicKey) bool {
+		return true
+	}
+	alice.fundingMgr.cfg.SupportsLargeChannels = supportsLargeChannels
+	bob.fundingMgr.cfg.SupportsLargeChannels = supportsLargeChannels
+
+	const largeChanAmt = btcutil.Amount(2 * btcutil.SatoshiPerBitcoin)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	openChannel(t, alice, bob, largeChanAmt, 0, 1, updateChan, true)
+
+	// Both should now consider the large channel pending.
+	assertNumPendingChannelsBecomes(t, alice, 1)
+	assertNumPendingChannelsBecomes(t, bob, 1)
+
+	pendingChannels, err := bob.fundingMgr.cfg.Wallet.Cfg.Database.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch pending channels: %v", err)
+	}
+	if pendingChannels[0].Capacity != largeChanAmt {
+		t.Fatalf("expected capacity %v, got %v", largeChanAmt,
+			pendingChannels[0].Capacity)
+	}
+}
+
+// TestScaleRemoteDelay tests that the CSV delay required of the remote party
+// scales with the size of the channel, and is capped at the maximum delay for
+// large channels whose scaled delay doesn't fit a uint16.
+func TestScaleRemoteDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		chanAmt       btcutil.Amount
+		minDelay      uint16
+		maxDelay      uint16
+		expectedDelay uint16
+	}{
+		{
+			name:          "small channel",
+			chanAmt:       maxFundingAmount / 100,
+			minDelay:      minBtcRemoteDelay,
+			maxDelay:      maxBtcRemoteDelay,
+			expectedDelay: minBtcRemoteDelay,
+		},
+		{
+			name:          "half of regular limit",
+			chanAmt:       maxFundingAmount / 2,
+			minDelay:      minBtcRemoteDelay,
+			maxDelay:      maxBtcRemoteDelay,
+			expectedDelay: maxBtcRemoteDelay / 2,
+		},
+		{
+			name:          "regular limit",
+			chanAmt:       maxFundingAmount,
+			minDelay:      minBtcRemoteDelay,
+			maxDelay:      maxBtcRemoteDelay,
+			expectedDelay: maxBtcRemoteDelay,
+		},
+		{
+			name:          "just above regular limit",
+			chanAmt:       maxFundingAmount + 1,
+			minDelay:      minBtcRemoteDelay,
+			maxDelay:      maxBtcRemoteDelay,
+			expectedDelay: maxBtcRemoteDelay,
+		},
+		{
+			// The scaled delay of this channel is 66535 blocks,
+			// which would wrap around to 999 blocks as a uint16.
+			name:          "scaled delay above uint16",
+			chanAmt:       553714704,
+			minDelay:      minBtcRemoteDelay,
+			maxDelay:      maxBtcRemoteDelay,
+			expectedDelay: maxBtcRemoteDelay,
+		},
+		{
+			name:          "large channel limit",
+			chanAmt:       maxLargeFundingAmount,
+			minDelay:      minBtcRemoteDelay,
+			maxDelay:      maxBtcRemoteDelay,
+			expectedDelay: maxBtcRemoteDelay,
+		},
+		{
+			name:          "litecoin large channel limit",
+			chanAmt:       maxLargeFundingAmount,
+			minDelay:      minLtcRemoteDelay,
+			maxDelay:      maxLtcRemoteDelay,
+			expectedDelay: maxLtcRemoteDelay,
+		},
+	}
+
+	for _, test := range tests {
+		delay := scaleRemoteDelay(
+			test.chanAmt, test.minDelay, test.maxDelay,
+		)
+		if delay != test.expectedDelay {
+			t.Fatalf("%v: expected delay %v, got %v", test.name,
+				test.expectedDelay, delay)
+		}
+	}
+}
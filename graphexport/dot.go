@@ -0,0 +1,71 @@
+package graphexport
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// dotEscaper escapes the characters which can't appear verbatim within a
+// quoted DOT identifier.
+var dotEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", "",
+)
+
+// dotEncoder writes the graph as an undirected graph in the DOT language. As
+// DOT has no notion of typed attributes, the channel policies aren't
+// included.
+type dotEncoder struct {
+	w io.Writer
+}
+
+// begin writes the header of the export.
+//
+// NOTE: This is part of the encoder interface.
+func (e *dotEncoder) begin() error {
+	_, err := io.WriteString(e.w, "graph lightning {\n")
+	return err
+}
+
+// node writes a single node.
+//
+// NOTE: This is part of the encoder interface.
+func (e *dotEncoder) node(node *channeldb.LightningNode) error {
+	pubKey := hex.EncodeToString(node.PubKeyBytes[:])
+
+	label := pubKey
+	if node.HaveNodeAnnouncement && node.Alias != "" {
+		label = node.Alias
+	}
+
+	_, err := fmt.Fprintf(e.w, "\t\"%s\" [label=\"%s\"];\n", pubKey,
+		dotEscaper.Replace(label))
+	return err
+}
+
+// channel writes a single channel along with its policies, either of which
+// may be nil.
+//
+// NOTE: This is part of the encoder interface.
+func (e *dotEncoder) channel(info *channeldb.ChannelEdgeInfo,
+	_, _ *channeldb.ChannelEdgePolicy) error {
+
+	_, err := fmt.Fprintf(e.w, "\t\"%x\" -- \"%x\" [label=\"%d\", "+
+		"capacity=%d];\n", info.NodeKey1Bytes[:], info.NodeKey2Bytes[:],
+		info.ChannelID, int64(info.Capacity))
+	return err
+}
+
+// end writes the trailer of the export.
+//
+// NOTE: This is part of the encoder interface.
+func (e *dotEncoder) end() error {
+	_, err := io.WriteString(e.w, "}\n")
+	return err
+}
@@ -0,0 +1,371 @@
+package graphexport
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcutil"
+)
+
+// Format is the output format of a graph export.
+type Format uint8
+
+const (
+	// FormatDOT exports the graph in the DOT language of graphviz.
+	FormatDOT Format = iota
+
+	// FormatGraphML exports the graph as a GraphML document.
+	FormatGraphML
+
+	// FormatJSON exports the graph as newline-delimited JSON, with one
+	// object per node and channel.
+	FormatJSON
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatDOT:
+		return "dot"
+	case FormatGraphML:
+		return "graphml"
+	case FormatJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFormat returns the format with the passed name.
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "dot":
+		return FormatDOT, nil
+	case "graphml":
+		return FormatGraphML, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return 0, fmt.Errorf("unknown graph export format %q, must be "+
+			"one of: dot, graphml, json", name)
+	}
+}
+
+// ParseNodes parses the passed hex encoded public keys into the set of nodes
+// an export is restricted to. A nil set is returned if no keys are passed.
+func ParseNodes(pubKeys []string) (map[[33]byte]struct{}, error) {
+	if len(pubKeys) == 0 {
+		return nil, nil
+	}
+
+	nodes := make(map[[33]byte]struct{}, len(pubKeys))
+	for _, pubKeyStr := range pubKeys {
+		pubKey, err := hex.DecodeString(pubKeyStr)
+		if err != nil || len(pubKey) != 33 {
+			return nil, fmt.Errorf("invalid node public key: %v",
+				pubKeyStr)
+		}
+
+		var key [33]byte
+		copy(key[:], pubKey)
+		nodes[key] = struct{}{}
+	}
+
+	return nodes, nil
+}
+
+// Filter restricts the nodes and channels of an export. The zero value
+// exports the entire graph.
+type Filter struct {
+	// Nodes, if non-empty, restricts the export to the given nodes and
+	// the channels between them.
+	Nodes map[[33]byte]struct{}
+
+	// Hops, if positive, restricts the export to the nodes at most this
+	// many hops away from the source node of the graph, and the channels
+	// between them.
+	Hops int
+
+	// MinCapacity is the minimum capacity of the exported channels.
+	MinCapacity btcutil.Amount
+
+	// MinAge and MaxAge, if non-zero, bound the age in blocks of the
+	// exported channels, relative to BestHeight. The age of a channel is
+	// derived from the block height encoded in its short channel ID.
+	MinAge uint32
+	MaxAge uint32
+
+	// BestHeight is the height the age of channels is computed against.
+	// It must be set if either MinAge or MaxAge is.
+	BestHeight uint32
+
+	// MaxBaseFee, MaxFeeRate and MaxTimeLockDelta, if non-zero, restrict
+	// the export to channels with at least one policy that doesn't exceed
+	// them.
+	MaxBaseFee       lnwire.MilliSatoshi
+	MaxFeeRate       lnwire.MilliSatoshi
+	MaxTimeLockDelta uint16
+
+	// ExcludeDisabled, if set, restricts the export to channels with at
+	// least one policy that isn't disabled.
+	ExcludeDisabled bool
+}
+
+// hasPolicyFilter returns true if any of the policy fields of the filter are
+// set.
+func (f *Filter) hasPolicyFilter() bool {
+	return f.MaxBaseFee != 0 || f.MaxFeeRate != 0 ||
+		f.MaxTimeLockDelta != 0 || f.ExcludeDisabled
+}
+
+// matchPolicy returns true if the passed policy passes the policy fields of
+// the filter.
+func (f *Filter) matchPolicy(policy *channeldb.ChannelEdgePolicy) bool {
+	switch {
+	case policy == nil:
+		return false
+	case f.MaxBaseFee != 0 && policy.FeeBaseMSat > f.MaxBaseFee:
+		return false
+	case f.MaxFeeRate != 0 &&
+		policy.FeeProportionalMillionths > f.MaxFeeRate:
+		return false
+	case f.MaxTimeLockDelta != 0 &&
+		policy.TimeLockDelta > f.MaxTimeLockDelta:
+		return false
+	case f.ExcludeDisabled && policyDisabled(policy):
+		return false
+	}
+
+	return true
+}
+
+// matchChannel returns true if the passed channel passes the channel fields
+// of the filter. The endpoints of the channel aren't considered.
+func (f *Filter) matchChannel(info *channeldb.ChannelEdgeInfo,
+	p1, p2 *channeldb.ChannelEdgePolicy) bool {
+
+	if info.Capacity < f.MinCapacity {
+		return false
+	}
+
+	if f.MinAge != 0 || f.MaxAge != 0 {
+		scid := lnwire.NewShortChanIDFromInt(info.ChannelID)
+
+		var age uint32
+		if f.BestHeight > scid.BlockHeight {
+			age = f.BestHeight - scid.BlockHeight
+		}
+
+		if age < f.MinAge || (f.MaxAge != 0 && age > f.MaxAge) {
+			return false
+		}
+	}
+
+	if f.hasPolicyFilter() && !f.matchPolicy(p1) && !f.matchPolicy(p2) {
+		return false
+	}
+
+	return true
+}
+
+// encoder writes the nodes and channels of a graph in a particular format.
+// Nodes are always written before the channels referring to them.
+type encoder interface {
+	// begin writes the header of the export.
+	begin() error
+
+	// node writes a single node.
+	node(node *channeldb.LightningNode) error
+
+	// channel writes a single channel along with its policies, either of
+	// which may be nil.
+	channel(info *channeldb.ChannelEdgeInfo,
+		p1, p2 *channeldb.ChannelEdgePolicy) error
+
+	// end writes the trailer of the export.
+	end() error
+}
+
+// newEncoder returns an encoder for the given format writing to w.
+func newEncoder(format Format, w io.Writer) (encoder, error) {
+	switch format {
+	case FormatDOT:
+		return &dotEncoder{w: w}, nil
+	case FormatGraphML:
+		return &graphMLEncoder{w: w}, nil
+	case FormatJSON:
+		return newJSONEncoder(w), nil
+	default:
+		return nil, fmt.Errorf("unknown graph export format: %v",
+			format)
+	}
+}
+
+// Export writes the nodes and channels of the graph that pass the filter to
+// w in the given format. The graph is streamed straight from the database,
+// so only the public keys of the exported nodes are held in memory. Channels
+// are only exported if both of their endpoints are.
+func Export(graph *channeldb.ChannelGraph, w io.Writer, format Format,
+	filter *Filter) error {
+
+	if filter == nil {
+		filter = &Filter{}
+	}
+
+	enc, err := newEncoder(format, w)
+	if err != nil {
+		return err
+	}
+
+	// First, we'll determine the set of nodes the export is restricted
+	// to, if any.
+	allowed, err := allowedNodes(graph, filter)
+	if err != nil {
+		return err
+	}
+
+	if err := enc.begin(); err != nil {
+		return err
+	}
+
+	// With the set of nodes determined, we'll write out each of them,
+	// noting which ones were written so we only write channels between
+	// them.
+	exported := make(map[[33]byte]struct{})
+	err = graph.ForEachNode(nil, func(_ kvdb.Tx,
+		node *channeldb.LightningNode) error {
+
+		if allowed != nil {
+			if _, ok := allowed[node.PubKeyBytes]; !ok {
+				return nil
+			}
+		}
+
+		exported[node.PubKeyBytes] = struct{}{}
+		return enc.node(node)
+	})
+	if err != nil && err != channeldb.ErrGraphNotFound {
+		return err
+	}
+
+	err = graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		p1, p2 *channeldb.ChannelEdgePolicy) error {
+
+		if _, ok := exported[info.NodeKey1Bytes]; !ok {
+			return nil
+		}
+		if _, ok := exported[info.NodeKey2Bytes]; !ok {
+			return nil
+		}
+		if !filter.matchChannel(info, p1, p2) {
+			return nil
+		}
+
+		return enc.channel(info, p1, p2)
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound &&
+		err != channeldb.ErrGraphNotFound {
+
+		return err
+	}
+
+	return enc.end()
+}
+
+// allowedNodes returns the set of nodes the export is restricted to by the
+// passed filter, or nil if all nodes may be exported.
+func allowedNodes(graph *channeldb.ChannelGraph,
+	filter *Filter) (map[[33]byte]struct{}, error) {
+
+	var allowed map[[33]byte]struct{}
+	if len(filter.Nodes) > 0 {
+		allowed = make(map[[33]byte]struct{}, len(filter.Nodes))
+		for node := range filter.Nodes {
+			allowed[node] = struct{}{}
+		}
+	}
+
+	if filter.Hops <= 0 {
+		return allowed, nil
+	}
+
+	neighborhood, err := neighborhood(graph, filter.Hops)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the export was already restricted to a set of nodes, only those
+	// within the neighborhood remain.
+	if allowed == nil {
+		return neighborhood, nil
+	}
+	for node := range allowed {
+		if _, ok := neighborhood[node]; !ok {
+			delete(allowed, node)
+		}
+	}
+
+	return allowed, nil
+}
+
+// neighborhood returns the set of nodes at most the given number of hops away
+// from the source node of the graph. Rather than holding the adjacency of the
+// entire graph in memory, a pass over all channels is made for every hop.
+func neighborhood(graph *channeldb.ChannelGraph,
+	hops int) (map[[33]byte]struct{}, error) {
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[[33]byte]struct{}{
+		sourceNode.PubKeyBytes: {},
+	}
+	for i := 0; i < hops; i++ {
+		var next [][33]byte
+		err := graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+			_, _ *channeldb.ChannelEdgePolicy) error {
+
+			_, ok1 := nodes[info.NodeKey1Bytes]
+			_, ok2 := nodes[info.NodeKey2Bytes]
+			switch {
+			case ok1 && !ok2:
+				next = append(next, info.NodeKey2Bytes)
+			case ok2 && !ok1:
+				next = append(next, info.NodeKey1Bytes)
+			}
+
+			return nil
+		})
+		if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+			return nil, err
+		}
+
+		// If no new nodes were reached, then further passes won't
+		// reach any either.
+		if len(next) == 0 {
+			break
+		}
+		for _, node := range next {
+			nodes[node] = struct{}{}
+		}
+	}
+
+	return nodes, nil
+}
+
+// policyDisabled returns true if the passed policy has the disabled bit set.
+func policyDisabled(p *channeldb.ChannelEdgePolicy) bool {
+	return p.ChannelFlags&lnwire.ChanUpdateDisabled != 0
+}
+
+// nodeColor returns the hex encoded color of the passed node.
+func nodeColor(node *channeldb.LightningNode) string {
+	return fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G,
+		node.Color.B)
+}
@@ -0,0 +1,383 @@
+package graphexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// testGraph is a channel graph populated with a line of four nodes,
+// source - a - b - c, along with the information about its channels used to
+// assert the output of exports.
+type testGraph struct {
+	graph   *channeldb.ChannelGraph
+	nodes   []*channeldb.LightningNode
+	cleanUp func()
+}
+
+// pubKey returns the hex encoded public key of the i-th node of the graph.
+func (g *testGraph) pubKey(i int) string {
+	return fmt.Sprintf("%x", g.nodes[i].PubKeyBytes[:])
+}
+
+// newTestGraph creates a fresh channel graph containing the nodes source, a,
+// b and c, connected by the channels source-a, a-b and b-c. The channels are
+// confirmed at heights 100, 200 and 300, with capacities of 1, 2 and 3 BTC
+// respectively. The policies of the b-c channel are both disabled.
+func newTestGraph(t *testing.T) *testGraph {
+	tempDir, err := ioutil.TempDir("", "graphexport")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+
+	g := &testGraph{
+		graph: db.ChannelGraph(),
+		cleanUp: func() {
+			db.Close()
+			os.RemoveAll(tempDir)
+		},
+	}
+
+	for i, alias := range []string{"source", "a", "b", "c"} {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+
+		node := &channeldb.LightningNode{
+			HaveNodeAnnouncement: true,
+			LastUpdate:           time.Unix(1500000000, 0),
+			Color:                color.RGBA{0xff, 0, uint8(i), 0},
+			Alias:                alias,
+			Features: lnwire.NewFeatureVector(
+				nil, lnwire.GlobalFeatures,
+			),
+		}
+		copy(node.PubKeyBytes[:], priv.PubKey().SerializeCompressed())
+
+		if i == 0 {
+			err = g.graph.SetSourceNode(node)
+		} else {
+			err = g.graph.AddLightningNode(node)
+		}
+		if err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+
+		g.nodes = append(g.nodes, node)
+	}
+
+	for i := 0; i < 3; i++ {
+		node1, node2 := g.nodes[i], g.nodes[i+1]
+		if bytes.Compare(node1.PubKeyBytes[:], node2.PubKeyBytes[:]) > 0 {
+			node1, node2 = node2, node1
+		}
+
+		chanID := lnwire.ShortChannelID{
+			BlockHeight: uint32(i+1) * 100,
+		}
+		edge := &channeldb.ChannelEdgeInfo{
+			ChannelID:     chanID.ToUint64(),
+			NodeKey1Bytes: node1.PubKeyBytes,
+			NodeKey2Bytes: node2.PubKeyBytes,
+			ChannelPoint: wire.OutPoint{
+				Index: uint32(i),
+			},
+			Capacity: btcutil.Amount(i+1) * btcutil.SatoshiPerBitcoin,
+		}
+		if err := g.graph.AddChannelEdge(edge); err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+
+		for _, direction := range []lnwire.ChanUpdateChanFlags{
+			0, lnwire.ChanUpdateDirection,
+		} {
+			flags := direction
+			if i == 2 {
+				flags |= lnwire.ChanUpdateDisabled
+			}
+
+			policy := &channeldb.ChannelEdgePolicy{
+				ChannelID:                 edge.ChannelID,
+				LastUpdate:                time.Unix(1500000000, 0),
+				ChannelFlags:              flags,
+				TimeLockDelta:             uint16(i+1) * 10,
+				MinHTLC:                   1000,
+				FeeBaseMSat:               lnwire.MilliSatoshi(i+1) * 1000,
+				FeeProportionalMillionths: lnwire.MilliSatoshi(i + 1),
+			}
+			if err := g.graph.UpdateEdgePolicy(policy); err != nil {
+				t.Fatalf("unable to update policy: %v", err)
+			}
+		}
+	}
+
+	return g
+}
+
+// exportJSON exports the graph as JSON using the passed filter, and returns
+// the set of exported nodes and channel capacities.
+func exportJSON(t *testing.T, g *testGraph,
+	filter *Filter) (map[string]jsonNode, map[int64]jsonEdge) {
+
+	t.Helper()
+
+	var b bytes.Buffer
+	if err := Export(g.graph, &b, FormatJSON, filter); err != nil {
+		t.Fatalf("unable to export graph: %v", err)
+	}
+
+	nodes := make(map[string]jsonNode)
+	edges := make(map[int64]jsonEdge)
+
+	scanner := bufio.NewScanner(&b)
+	for scanner.Scan() {
+		var obj struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &obj); err != nil {
+			t.Fatalf("unable to decode line: %v", err)
+		}
+
+		switch obj.Type {
+		case "node":
+			var node jsonNode
+			err := json.Unmarshal(scanner.Bytes(), &node)
+			if err != nil {
+				t.Fatalf("unable to decode node: %v", err)
+			}
+			nodes[node.Alias] = node
+
+		case "edge":
+			var edge jsonEdge
+			err := json.Unmarshal(scanner.Bytes(), &edge)
+			if err != nil {
+				t.Fatalf("unable to decode edge: %v", err)
+			}
+			edges[edge.Capacity/btcutil.SatoshiPerBitcoin] = edge
+
+		default:
+			t.Fatalf("unknown object type: %v", obj.Type)
+		}
+	}
+
+	return nodes, edges
+}
+
+// TestExportFilters asserts that the nodes and channels of an export are
+// restricted according to the filter.
+func TestExportFilters(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGraph(t)
+	defer g.cleanUp()
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		nodes  []string
+		edges  []int64
+	}{
+		{
+			name:   "no filter",
+			filter: nil,
+			nodes:  []string{"source", "a", "b", "c"},
+			edges:  []int64{1, 2, 3},
+		},
+		{
+			name: "node subset",
+			filter: &Filter{
+				Nodes: map[[33]byte]struct{}{
+					g.nodes[1].PubKeyBytes: {},
+					g.nodes[2].PubKeyBytes: {},
+				},
+			},
+			nodes: []string{"a", "b"},
+			edges: []int64{2},
+		},
+		{
+			name:   "neighborhood",
+			filter: &Filter{Hops: 2},
+			nodes:  []string{"source", "a", "b"},
+			edges:  []int64{1, 2},
+		},
+		{
+			name: "neighborhood and node subset",
+			filter: &Filter{
+				Hops: 1,
+				Nodes: map[[33]byte]struct{}{
+					g.nodes[1].PubKeyBytes: {},
+					g.nodes[2].PubKeyBytes: {},
+				},
+			},
+			nodes: []string{"a"},
+		},
+		{
+			name: "min capacity",
+			filter: &Filter{
+				MinCapacity: 2 * btcutil.SatoshiPerBitcoin,
+			},
+			nodes: []string{"source", "a", "b", "c"},
+			edges: []int64{2, 3},
+		},
+		{
+			name: "channel age",
+			filter: &Filter{
+				BestHeight: 400,
+				MinAge:     150,
+				MaxAge:     250,
+			},
+			nodes: []string{"source", "a", "b", "c"},
+			edges: []int64{2},
+		},
+		{
+			name: "policy",
+			filter: &Filter{
+				MaxBaseFee:       2000,
+				MaxFeeRate:       2,
+				MaxTimeLockDelta: 10,
+			},
+			nodes: []string{"source", "a", "b", "c"},
+			edges: []int64{1},
+		},
+		{
+			name:   "exclude disabled",
+			filter: &Filter{ExcludeDisabled: true},
+			nodes:  []string{"source", "a", "b", "c"},
+			edges:  []int64{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		nodes, edges := exportJSON(t, g, test.filter)
+
+		if len(nodes) != len(test.nodes) {
+			t.Fatalf("%s: expected %d nodes, got %d", test.name,
+				len(test.nodes), len(nodes))
+		}
+		for _, alias := range test.nodes {
+			if _, ok := nodes[alias]; !ok {
+				t.Fatalf("%s: node %v not exported", test.name,
+					alias)
+			}
+		}
+
+		if len(edges) != len(test.edges) {
+			t.Fatalf("%s: expected %d edges, got %d", test.name,
+				len(test.edges), len(edges))
+		}
+		for _, capacity := range test.edges {
+			edge, ok := edges[capacity]
+			if !ok {
+				t.Fatalf("%s: edge with capacity %d BTC not "+
+					"exported", test.name, capacity)
+			}
+			if edge.Node1Policy == nil || edge.Node2Policy == nil {
+				t.Fatalf("%s: edge policies not exported",
+					test.name)
+			}
+		}
+	}
+}
+
+// TestExportFormats asserts that the DOT and GraphML exports contain every
+// node and channel of the graph, and that the GraphML document is well
+// formed.
+func TestExportFormats(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGraph(t)
+	defer g.cleanUp()
+
+	var dot bytes.Buffer
+	if err := Export(g.graph, &dot, FormatDOT, nil); err != nil {
+		t.Fatalf("unable to export graph: %v", err)
+	}
+
+	if !strings.HasPrefix(dot.String(), "graph lightning {\n") {
+		t.Fatalf("unexpected DOT header: %v", dot.String())
+	}
+	if strings.Count(dot.String(), " -- ") != 3 {
+		t.Fatalf("expected 3 edges in DOT export: %v", dot.String())
+	}
+	for i := range g.nodes {
+		node := fmt.Sprintf("\t%q [label=%q];\n", g.pubKey(i),
+			g.nodes[i].Alias)
+		if !strings.Contains(dot.String(), node) {
+			t.Fatalf("node %v missing from DOT export: %v",
+				g.nodes[i].Alias, dot.String())
+		}
+	}
+
+	var graphML bytes.Buffer
+	if err := Export(g.graph, &graphML, FormatGraphML, nil); err != nil {
+		t.Fatalf("unable to export graph: %v", err)
+	}
+
+	var doc struct {
+		Keys []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"key"`
+		Graph struct {
+			EdgeDefault string `xml:"edgedefault,attr"`
+			Nodes       []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+				Data   []struct {
+					Key string `xml:"key,attr"`
+				} `xml:"data"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(graphML.Bytes(), &doc); err != nil {
+		t.Fatalf("unable to decode GraphML export: %v", err)
+	}
+
+	if doc.Graph.EdgeDefault != "undirected" {
+		t.Fatalf("expected undirected graph, got %v",
+			doc.Graph.EdgeDefault)
+	}
+	if len(doc.Graph.Nodes) != len(g.nodes) {
+		t.Fatalf("expected %d nodes, got %d", len(g.nodes),
+			len(doc.Graph.Nodes))
+	}
+	if len(doc.Graph.Edges) != 3 {
+		t.Fatalf("expected 3 edges, got %d", len(doc.Graph.Edges))
+	}
+
+	// Every data element must refer to a declared key.
+	keys := make(map[string]struct{})
+	for _, key := range doc.Keys {
+		keys[key.ID] = struct{}{}
+	}
+	for _, edge := range doc.Graph.Edges {
+		for _, data := range edge.Data {
+			if _, ok := keys[data.Key]; !ok {
+				t.Fatalf("undeclared key %v", data.Key)
+			}
+		}
+	}
+}
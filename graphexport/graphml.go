@@ -0,0 +1,148 @@
+package graphexport
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// graphMLKey describes a single data attribute of a GraphML document.
+type graphMLKey struct {
+	id       string
+	domain   string
+	attrType string
+}
+
+// graphMLPolicyKeys are the per-direction policy attributes of an edge. Each
+// is declared once for each direction, prefixed by node1_ and node2_.
+var graphMLPolicyKeys = []graphMLKey{
+	{"time_lock_delta", "edge", "int"},
+	{"min_htlc_msat", "edge", "long"},
+	{"max_htlc_msat", "edge", "long"},
+	{"fee_base_msat", "edge", "long"},
+	{"fee_rate_milli_msat", "edge", "long"},
+	{"disabled", "edge", "boolean"},
+	{"last_update", "edge", "long"},
+}
+
+// graphMLKeys are the attributes of the nodes and edges of the document,
+// excluding the policy attributes.
+var graphMLKeys = []graphMLKey{
+	{"alias", "node", "string"},
+	{"color", "node", "string"},
+	{"last_update", "node", "long"},
+	{"channel_id", "edge", "long"},
+	{"chan_point", "edge", "string"},
+	{"capacity", "edge", "long"},
+}
+
+// graphMLEncoder writes the graph as an undirected GraphML document.
+type graphMLEncoder struct {
+	w io.Writer
+}
+
+// begin writes the header of the export.
+//
+// NOTE: This is part of the encoder interface.
+func (e *graphMLEncoder) begin() error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	b.WriteString("\n")
+
+	writeKey := func(id string, key graphMLKey) {
+		fmt.Fprintf(&b, "  <key id=%q for=%q attr.name=%q "+
+			"attr.type=%q/>\n", key.domain+"_"+id, key.domain, id,
+			key.attrType)
+	}
+	for _, key := range graphMLKeys {
+		writeKey(key.id, key)
+	}
+	for _, prefix := range []string{"node1_", "node2_"} {
+		for _, key := range graphMLPolicyKeys {
+			writeKey(prefix+key.id, key)
+		}
+	}
+
+	b.WriteString(`  <graph id="lightning" edgedefault="undirected">`)
+	b.WriteString("\n")
+
+	_, err := b.WriteTo(e.w)
+	return err
+}
+
+// writeData writes a single data element with the passed key and value.
+func writeData(b *bytes.Buffer, key string, value interface{}) {
+	fmt.Fprintf(b, "      <data key=%q>", key)
+	xml.EscapeText(b, []byte(fmt.Sprint(value)))
+	b.WriteString("</data>\n")
+}
+
+// node writes a single node.
+//
+// NOTE: This is part of the encoder interface.
+func (e *graphMLEncoder) node(node *channeldb.LightningNode) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "    <node id=\"%x\">\n", node.PubKeyBytes[:])
+	if node.HaveNodeAnnouncement {
+		writeData(&b, "node_alias", node.Alias)
+		writeData(&b, "node_color", nodeColor(node))
+		writeData(&b, "node_last_update", node.LastUpdate.Unix())
+	}
+	b.WriteString("    </node>\n")
+
+	_, err := b.WriteTo(e.w)
+	return err
+}
+
+// channel writes a single channel along with its policies, either of which
+// may be nil.
+//
+// NOTE: This is part of the encoder interface.
+func (e *graphMLEncoder) channel(info *channeldb.ChannelEdgeInfo,
+	p1, p2 *channeldb.ChannelEdgePolicy) error {
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "    <edge id=\"%d\" source=%q target=%q>\n",
+		info.ChannelID, hex.EncodeToString(info.NodeKey1Bytes[:]),
+		hex.EncodeToString(info.NodeKey2Bytes[:]))
+	writeData(&b, "edge_channel_id", info.ChannelID)
+	writeData(&b, "edge_chan_point", info.ChannelPoint.String())
+	writeData(&b, "edge_capacity", int64(info.Capacity))
+
+	writePolicy := func(prefix string, p *channeldb.ChannelEdgePolicy) {
+		if p == nil {
+			return
+		}
+
+		writeData(&b, prefix+"time_lock_delta", p.TimeLockDelta)
+		writeData(&b, prefix+"min_htlc_msat", uint64(p.MinHTLC))
+		writeData(&b, prefix+"max_htlc_msat", uint64(p.MaxHTLC))
+		writeData(&b, prefix+"fee_base_msat", uint64(p.FeeBaseMSat))
+		writeData(
+			&b, prefix+"fee_rate_milli_msat",
+			uint64(p.FeeProportionalMillionths),
+		)
+		writeData(&b, prefix+"disabled", policyDisabled(p))
+		writeData(&b, prefix+"last_update", p.LastUpdate.Unix())
+	}
+	writePolicy("edge_node1_", p1)
+	writePolicy("edge_node2_", p2)
+
+	b.WriteString("    </edge>\n")
+
+	_, err := b.WriteTo(e.w)
+	return err
+}
+
+// end writes the trailer of the export.
+//
+// NOTE: This is part of the encoder interface.
+func (e *graphMLEncoder) end() error {
+	_, err := io.WriteString(e.w, "  </graph>\n</graphml>\n")
+	return err
+}
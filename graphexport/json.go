@@ -0,0 +1,126 @@
+package graphexport
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// jsonNode is the JSON representation of a node.
+type jsonNode struct {
+	Type       string   `json:"type"`
+	PubKey     string   `json:"pub_key"`
+	Alias      string   `json:"alias,omitempty"`
+	Color      string   `json:"color,omitempty"`
+	Addresses  []string `json:"addresses,omitempty"`
+	LastUpdate int64    `json:"last_update,omitempty"`
+}
+
+// jsonPolicy is the JSON representation of a channel policy.
+type jsonPolicy struct {
+	TimeLockDelta    uint16 `json:"time_lock_delta"`
+	MinHTLC          uint64 `json:"min_htlc_msat"`
+	MaxHTLC          uint64 `json:"max_htlc_msat"`
+	FeeBaseMsat      uint64 `json:"fee_base_msat"`
+	FeeRateMilliMsat uint64 `json:"fee_rate_milli_msat"`
+	Disabled         bool   `json:"disabled"`
+	LastUpdate       int64  `json:"last_update"`
+}
+
+// jsonEdge is the JSON representation of a channel.
+type jsonEdge struct {
+	Type        string      `json:"type"`
+	ChannelID   uint64      `json:"channel_id"`
+	ChanPoint   string      `json:"chan_point"`
+	Node1Pub    string      `json:"node1_pub"`
+	Node2Pub    string      `json:"node2_pub"`
+	Capacity    int64       `json:"capacity"`
+	Node1Policy *jsonPolicy `json:"node1_policy,omitempty"`
+	Node2Policy *jsonPolicy `json:"node2_policy,omitempty"`
+}
+
+// jsonEncoder writes the graph as newline-delimited JSON, with one object per
+// node and channel distinguished by their type field.
+type jsonEncoder struct {
+	enc *json.Encoder
+}
+
+// newJSONEncoder returns a jsonEncoder writing to w.
+func newJSONEncoder(w io.Writer) *jsonEncoder {
+	return &jsonEncoder{
+		enc: json.NewEncoder(w),
+	}
+}
+
+// begin writes the header of the export.
+//
+// NOTE: This is part of the encoder interface.
+func (e *jsonEncoder) begin() error {
+	return nil
+}
+
+// node writes a single node.
+//
+// NOTE: This is part of the encoder interface.
+func (e *jsonEncoder) node(node *channeldb.LightningNode) error {
+	n := &jsonNode{
+		Type:   "node",
+		PubKey: hex.EncodeToString(node.PubKeyBytes[:]),
+	}
+	if node.HaveNodeAnnouncement {
+		n.Alias = node.Alias
+		n.Color = nodeColor(node)
+		n.LastUpdate = node.LastUpdate.Unix()
+		for _, addr := range node.Addresses {
+			n.Addresses = append(n.Addresses, addr.String())
+		}
+	}
+
+	return e.enc.Encode(n)
+}
+
+// channel writes a single channel along with its policies, either of which
+// may be nil.
+//
+// NOTE: This is part of the encoder interface.
+func (e *jsonEncoder) channel(info *channeldb.ChannelEdgeInfo,
+	p1, p2 *channeldb.ChannelEdgePolicy) error {
+
+	return e.enc.Encode(&jsonEdge{
+		Type:        "edge",
+		ChannelID:   info.ChannelID,
+		ChanPoint:   info.ChannelPoint.String(),
+		Node1Pub:    hex.EncodeToString(info.NodeKey1Bytes[:]),
+		Node2Pub:    hex.EncodeToString(info.NodeKey2Bytes[:]),
+		Capacity:    int64(info.Capacity),
+		Node1Policy: marshalPolicy(p1),
+		Node2Policy: marshalPolicy(p2),
+	})
+}
+
+// end writes the trailer of the export.
+//
+// NOTE: This is part of the encoder interface.
+func (e *jsonEncoder) end() error {
+	return nil
+}
+
+// marshalPolicy returns the JSON representation of the passed policy, or nil
+// if it's unknown.
+func marshalPolicy(p *channeldb.ChannelEdgePolicy) *jsonPolicy {
+	if p == nil {
+		return nil
+	}
+
+	return &jsonPolicy{
+		TimeLockDelta:    p.TimeLockDelta,
+		MinHTLC:          uint64(p.MinHTLC),
+		MaxHTLC:          uint64(p.MaxHTLC),
+		FeeBaseMsat:      uint64(p.FeeBaseMSat),
+		FeeRateMilliMsat: uint64(p.FeeProportionalMillionths),
+		Disabled:         policyDisabled(p),
+		LastUpdate:       p.LastUpdate.Unix(),
+	}
+}
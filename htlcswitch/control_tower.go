@@ -0,0 +1,78 @@
+package htlcswitch
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ControlTower tracks all outgoing payments made by the switch, whose primary
+// purpose is to prevent duplicate payments to the same payment hash, and to
+// ensure that the outcome of each payment is recorded even if lnd restarts
+// while an HTLC for it is in flight.
+type ControlTower interface {
+	// ClearForTakeoff atomically checks that no in flight or completed
+	// payment exists for the payment hash of the passed HTLC. If none is
+	// found, the payment is marked as in flight with the passed attempt
+	// ID.
+	ClearForTakeoff(htlc *lnwire.UpdateAddHTLC, attemptID uint64) error
+
+	// Success transitions an in flight payment into the succeeded state,
+	// recording the preimage revealed by the recipient.
+	Success(paymentHash, preimage [32]byte) error
+
+	// Fail transitions an in flight payment into the failed state,
+	// recording the reason it failed. Failed payments may be attempted
+	// again.
+	Fail(paymentHash [32]byte, reason string) error
+
+	// InFlight returns all payments that currently have an HTLC in
+	// flight.
+	InFlight() ([]*channeldb.PaymentAttempt, error)
+}
+
+// paymentControl is an implementation of the ControlTower interface backed by
+// the channeldb.
+type paymentControl struct {
+	db *channeldb.DB
+}
+
+// NewPaymentControl creates a new ControlTower backed by the passed database.
+func NewPaymentControl(db *channeldb.DB) ControlTower {
+	return &paymentControl{
+		db: db,
+	}
+}
+
+// ClearForTakeoff atomically checks that no in flight or completed payment
+// exists for the payment hash of the passed HTLC. If none is found, the
+// payment is marked as in flight with the passed attempt ID.
+//
+// NOTE: Part of the ControlTower interface.
+func (p *paymentControl) ClearForTakeoff(htlc *lnwire.UpdateAddHTLC,
+	attemptID uint64) error {
+
+	return p.db.InitPayment(htlc.PaymentHash, attemptID, htlc.Amount)
+}
+
+// Success transitions an in flight payment into the succeeded state,
+// recording the preimage revealed by the recipient.
+//
+// NOTE: Part of the ControlTower interface.
+func (p *paymentControl) Success(paymentHash, preimage [32]byte) error {
+	return p.db.SucceedPayment(paymentHash, preimage)
+}
+
+// Fail transitions an in flight payment into the failed state, recording the
+// reason it failed.
+//
+// NOTE: Part of the ControlTower interface.
+func (p *paymentControl) Fail(paymentHash [32]byte, reason string) error {
+	return p.db.FailPayment(paymentHash, reason)
+}
+
+// InFlight returns all payments that currently have an HTLC in flight.
+//
+// NOTE: Part of the ControlTower interface.
+func (p *paymentControl) InFlight() ([]*channeldb.PaymentAttempt, error) {
+	return p.db.FetchInFlightPayments()
+}
@@ -627,7 +627,7 @@ func (l *channelLink) resolveFwdPkg(fwdPkg *channeldb.FwdPkg) (bool, error) {
 		l.debugf("removing completed fwd pkg for height=%d",
 			fwdPkg.Height)
 
-		err := l.channel.RemoveFwdPkg(fwdPkg.Height)
+		err := l.channel.RemoveFwdPkgs(fwdPkg.Height)
 		if err != nil {
 			l.errorf("unable to remove fwd pkg for height=%d: %v",
 				fwdPkg.Height, err)
@@ -686,18 +686,24 @@ func (l *channelLink) fwdPkgGarbager() {
 				continue
 			}
 
-			// TODO(conner): batch removal of forward packages.
+			// We'll remove all completed packages within a
+			// single batch.
+			var completed []uint64
 			for _, fwdPkg := range fwdPkgs {
 				if fwdPkg.State != channeldb.FwdStateCompleted {
 					continue
 				}
 
-				err = l.channel.RemoveFwdPkg(fwdPkg.Height)
-				if err != nil {
-					l.warnf("unable to remove fwd pkg "+
-						"for height=%d: %v",
-						fwdPkg.Height, err)
-				}
+				completed = append(completed, fwdPkg.Height)
+			}
+			if len(completed) == 0 {
+				continue
+			}
+
+			err = l.channel.RemoveFwdPkgs(completed...)
+			if err != nil {
+				l.warnf("unable to remove %d completed fwd "+
+					"pkgs: %v", len(completed), err)
 			}
 		case <-l.quit:
 			return
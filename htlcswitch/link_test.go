@@ -18,6 +18,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -1458,7 +1459,10 @@ func newSingleLinkTestHarness(chanAmt, chanReserve btcutil.Amount) (
 
 	aliceDb := aliceChannel.State().Db
 
-	aliceSwitch, err := New(Config{DB: aliceDb})
+	aliceSwitch, err := New(Config{
+		DB:    aliceDb,
+		Clock: clock.NewDefaultClock(),
+	})
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
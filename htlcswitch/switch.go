@@ -9,12 +9,13 @@ import (
 
 	"crypto/sha256"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/roasbeef/btcd/btcec"
 
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -135,6 +136,16 @@ type Config struct {
 	// error encrypters stored in the circuit map on restarts, since they
 	// are not stored directly within the database.
 	ExtractErrorEncrypter ErrorEncrypterExtracter
+
+	// Clock is the time source used to timestamp forwarding events.
+	Clock clock.Clock
+
+	// NotifyPaymentOutcome, if set, is called with the outcome of each
+	// payment that was still in flight when we last shut down once it's
+	// resolved, as nobody is waiting on its result anymore. A nil
+	// paymentErr signals that the payment succeeded.
+	NotifyPaymentOutcome func(paymentHash, preimage [32]byte,
+		paymentErr error)
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -164,6 +175,11 @@ type Switch struct {
 
 	paymentSequencer Sequencer
 
+	// control provides verification of sending htlc messages, preventing
+	// the same payment hash from being paid twice, and records the
+	// outcome of each payment.
+	control ControlTower
+
 	// circuits is storage for payment circuits which are used to
 	// forward the settle/fail htlc updates back to the add htlc initiator.
 	circuits CircuitMap
@@ -240,6 +256,7 @@ func New(cfg Config) (*Switch, error) {
 		cfg:               &cfg,
 		circuits:          circuitMap,
 		paymentSequencer:  sequencer,
+		control:           NewPaymentControl(cfg.DB),
 		linkIndex:         make(map[lnwire.ChannelID]ChannelLink),
 		mailboxes:         make(map[lnwire.ShortChannelID]MailBox),
 		forwardingIndex:   make(map[lnwire.ShortChannelID]ChannelLink),
@@ -293,6 +310,24 @@ func (s *Switch) ProcessContractResolution(msg contractcourt.ResolutionMsg) erro
 func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 	deobfuscator ErrorDecrypter) ([sha256.Size]byte, error) {
 
+	return s.sendHTLC(nextNode, htlc, deobfuscator, false)
+}
+
+// SendProbe sends an HTLC in the same manner as SendHTLC, but without
+// recording it as a payment with the control tower. It's intended for HTLCs
+// sent to probe a route, which pay to a payment hash the destination doesn't
+// know and thus can never be settled.
+func (s *Switch) SendProbe(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
+	deobfuscator ErrorDecrypter) ([sha256.Size]byte, error) {
+
+	return s.sendHTLC(nextNode, htlc, deobfuscator, true)
+}
+
+// sendHTLC carries out the dispatch of a locally initiated HTLC on behalf of
+// SendHTLC and SendProbe.
+func (s *Switch) sendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
+	deobfuscator ErrorDecrypter, probe bool) ([sha256.Size]byte, error) {
+
 	// Create payment and add to the map of payment in order later to be
 	// able to retrieve it and return response to the user.
 	payment := &pendingPayment{
@@ -309,6 +344,17 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 		return zeroPreimage, err
 	}
 
+	// Before sending, we'll ensure that the payment hash hasn't already
+	// been paid, and that no other HTLC for it is in flight. This also
+	// records the payment as in flight, so its outcome can be recovered
+	// if we restart before it's resolved. Probes are never recorded.
+	if !probe {
+		err := s.control.ClearForTakeoff(htlc, paymentID)
+		if err != nil {
+			return zeroPreimage, err
+		}
+	}
+
 	s.pendingMutex.Lock()
 	s.pendingPayments[paymentID] = payment
 	s.pendingMutex.Unlock()
@@ -325,6 +371,11 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 
 	if err := s.forward(packet); err != nil {
 		s.removePendingPayment(paymentID)
+		if !probe {
+			s.recordPaymentOutcome(
+				htlc.PaymentHash, zeroPreimage, err,
+			)
+		}
 		return zeroPreimage, err
 	}
 
@@ -357,6 +408,12 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 			"while waiting for payment result")
 	}
 
+	// With the payment resolved, we'll record its outcome before cleaning
+	// up after the HTLC, so it isn't lost if we restart in between.
+	if !probe {
+		s.recordPaymentOutcome(htlc.PaymentHash, preimage, err)
+	}
+
 	// Remove circuit since we are about to complete an add/fail of this
 	// HTLC.
 	if teardownErr := s.teardownCircuit(response); teardownErr != nil {
@@ -378,6 +435,39 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 	return preimage, err
 }
 
+// recordPaymentOutcome records the outcome of a payment with the control
+// tower, given the preimage or error it was resolved with.
+func (s *Switch) recordPaymentOutcome(paymentHash, preimage [32]byte,
+	paymentErr error) {
+
+	var err error
+	if paymentErr == nil {
+		err = s.control.Success(paymentHash, preimage)
+	} else {
+		err = s.control.Fail(paymentHash, paymentErr.Error())
+	}
+
+	// If the payment is no longer in flight, then its outcome has already
+	// been recorded before we restarted, or it was a probe that was never
+	// recorded at all.
+	if err != nil && err != channeldb.ErrPaymentNotInFlight {
+		log.Errorf("Unable to record outcome of payment %x: %v",
+			paymentHash[:], err)
+	}
+}
+
+// notifyPaymentOutcome reports the outcome of a payment that was sent before
+// a restart to the NotifyPaymentOutcome callback, if one is set.
+func (s *Switch) notifyPaymentOutcome(paymentHash, preimage [32]byte,
+	paymentErr error) {
+
+	if s.cfg.NotifyPaymentOutcome == nil {
+		return
+	}
+
+	s.cfg.NotifyPaymentOutcome(paymentHash, preimage, paymentErr)
+}
+
 // UpdateForwardingPolicies sends a message to the switch to update the
 // forwarding policies for the set of target channels. If the set of targeted
 // channels is nil, then the forwarding policies for all active channels with
@@ -693,6 +783,13 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 	// node as a special channel that also offers a sequence of HTLCs.
 	payment, err := s.findPayment(pkt.incomingHTLCID)
 	if err != nil {
+		// If no one is waiting for the response to this payment, then
+		// it was sent before we restarted. We'll still record its
+		// outcome, so that it isn't lost.
+		if _, ok := pkt.htlc.(*lnwire.UpdateAddHTLC); !ok {
+			return s.handleOrphanedResponse(pkt)
+		}
+
 		return err
 	}
 
@@ -788,6 +885,54 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 	return nil
 }
 
+// handleOrphanedResponse handles the settle or fail of a locally initiated
+// HTLC that was sent before we restarted, and thus has no one waiting for it.
+// We'll record the outcome of the payment with the control tower, then tear
+// down its circuit and ack the response, so that it isn't delivered again.
+func (s *Switch) handleOrphanedResponse(pkt *htlcPacket) error {
+	if pkt.circuit == nil {
+		return fmt.Errorf("unable to find circuit of payment %d",
+			pkt.incomingHTLCID)
+	}
+	paymentHash := pkt.circuit.PaymentHash
+
+	var (
+		preimage   [32]byte
+		paymentErr error
+	)
+	switch htlc := pkt.htlc.(type) {
+	case *lnwire.UpdateFulfillHTLC:
+		preimage = htlc.PaymentPreimage
+
+	case *lnwire.UpdateFailHTLC:
+		// As the shared secrets used to decrypt remote failures were
+		// lost when we restarted, only local failures can be parsed.
+		payment := &pendingPayment{
+			paymentHash: paymentHash,
+		}
+		paymentErr = s.parseFailedPayment(payment, pkt, htlc)
+
+	default:
+		return errors.New("wrong update type")
+	}
+
+	log.Infof("Payment %x sent before restart resolved: success=%v",
+		paymentHash[:], paymentErr == nil)
+
+	s.recordPaymentOutcome(paymentHash, preimage, paymentErr)
+	s.notifyPaymentOutcome(paymentHash, preimage, paymentErr)
+
+	if err := s.teardownCircuit(pkt); err != nil {
+		return err
+	}
+
+	if pkt.destRef != nil {
+		return s.ackSettleFail(*pkt.destRef)
+	}
+
+	return nil
+}
+
 // parseFailedPayment determines the appropriate failure message to return to
 // a user initiated payment. The three cases handled are:
 // 1) A local failure, which should already plaintext.
@@ -833,6 +978,18 @@ func (s *Switch) parseFailedPayment(payment *pendingPayment, pkt *htlcPacket,
 			FailureMessage: lnwire.FailPermanentChannelFailure{},
 		}
 
+	// A multi-hop payment error for a payment sent before we restarted,
+	// which we can't decrypt as its shared secrets have been lost.
+	case payment.deobfuscator == nil:
+		userErr := fmt.Sprintf("unable to de-obfuscate onion failure, "+
+			"htlc with hash(%x) was sent before restart",
+			payment.paymentHash[:])
+		failure = &ForwardingError{
+			ErrorSource:    s.cfg.SelfKey,
+			ExtraMsg:       userErr,
+			FailureMessage: lnwire.NewTemporaryChannelFailure(nil),
+		}
+
 	// A regular multi-hop payment error that we'll need to
 	// decrypt.
 	default:
@@ -1029,7 +1186,7 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				s.pendingFwdingEvents = append(
 					s.pendingFwdingEvents,
 					channeldb.ForwardingEvent{
-						Timestamp:      time.Now(),
+						Timestamp:      s.cfg.Clock.Now(),
 						IncomingChanID: circuit.Incoming.ChanID,
 						OutgoingChanID: circuit.Outgoing.ChanID,
 						AmtIn:          circuit.IncomingAmount,
@@ -1188,7 +1345,7 @@ func (s *Switch) closeCircuit(pkt *htlcPacket) (*PaymentCircuit, error) {
 // we're the originator of the payment, so the link stops attempting to
 // re-broadcast.
 func (s *Switch) ackSettleFail(settleFailRef channeldb.SettleFailRef) error {
-	return s.cfg.DB.Update(func(tx *bolt.Tx) error {
+	return s.cfg.DB.Update(func(tx kvdb.Tx) error {
 		return s.cfg.SwitchPackager.AckSettleFails(tx, settleFailRef)
 	})
 }
@@ -1497,6 +1654,11 @@ func (s *Switch) Start() error {
 
 	log.Infof("Starting HTLC Switch")
 
+	if err := s.reconcileInFlightPayments(); err != nil {
+		log.Errorf("unable to reconcile in flight payments: %v", err)
+		return err
+	}
+
 	s.wg.Add(1)
 	go s.htlcForwarder()
 
@@ -1509,6 +1671,52 @@ func (s *Switch) Start() error {
 	return nil
 }
 
+// reconcileInFlightPayments inspects all payments that were in flight when we
+// last shut down. Those whose HTLC never made it onto a commitment can no
+// longer be resolved, so they're marked as failed. The outcome of the others
+// will be recorded once their HTLC is settled or failed.
+func (s *Switch) reconcileInFlightPayments() error {
+	inFlight, err := s.control.InFlight()
+	if err != nil {
+		return err
+	}
+
+	for _, attempt := range inFlight {
+		inKey := CircuitKey{
+			ChanID: sourceHop,
+			HtlcID: attempt.AttemptID,
+		}
+
+		circuit := s.circuits.LookupCircuit(inKey)
+		if circuit != nil && circuit.HasKeystone() {
+			log.Infof("Awaiting outcome of payment %x sent before "+
+				"restart", attempt.PaymentHash[:])
+			continue
+		}
+
+		log.Infof("Failing payment %x whose HTLC wasn't sent before "+
+			"restart", attempt.PaymentHash[:])
+
+		if circuit != nil {
+			if err := s.circuits.DeleteCircuits(inKey); err != nil {
+				return err
+			}
+		}
+
+		paymentErr := errors.New("htlc wasn't sent before restart")
+		err := s.control.Fail(attempt.PaymentHash, paymentErr.Error())
+		if err != nil {
+			return err
+		}
+
+		s.notifyPaymentOutcome(
+			attempt.PaymentHash, zeroPreimage, paymentErr,
+		)
+	}
+
+	return nil
+}
+
 // reforwardResponses for every known, non-pending channel, loads all associated
 // forwarding packages and reforwards any Settle or Fail HTLCs found. This is
 // used to resurrect the switch's mailboxes after a restart.
@@ -1536,7 +1744,7 @@ func (s *Switch) reforwardResponses() error {
 func (s *Switch) loadChannelFwdPkgs(source lnwire.ShortChannelID) ([]*channeldb.FwdPkg, error) {
 
 	var fwdPkgs []*channeldb.FwdPkg
-	if err := s.cfg.DB.Update(func(tx *bolt.Tx) error {
+	if err := s.cfg.DB.Update(func(tx kvdb.Tx) error {
 		var err error
 		fwdPkgs, err = s.cfg.SwitchPackager.LoadChannelFwdPkgs(
 			tx, source,
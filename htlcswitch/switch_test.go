@@ -1508,14 +1508,6 @@ func TestSwitchSendPayment(t *testing.T) {
 		errChan <- err
 	}()
 
-	go func() {
-		// Send the payment with the same payment hash and same
-		// amount and check that it will be propagated successfully
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
-		errChan <- err
-	}()
-
 	select {
 	case packet := <-aliceChannelLink.packets:
 		if err := aliceChannelLink.completeCircuit(packet); err != nil {
@@ -1528,23 +1520,19 @@ func TestSwitchSendPayment(t *testing.T) {
 		t.Fatal("request was not propagated to destination")
 	}
 
-	select {
-	case packet := <-aliceChannelLink.packets:
-		if err := aliceChannelLink.completeCircuit(packet); err != nil {
-			t.Fatalf("unable to complete payment circuit: %v", err)
-		}
-
-	case err := <-errChan:
-		t.Fatalf("unable to send payment: %v", err)
-	case <-time.After(time.Second):
-		t.Fatal("request was not propagated to destination")
+	// Sending the payment with the same payment hash while the first is
+	// still in flight should be rejected.
+	_, err = s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
+		newMockDeobfuscator())
+	if err != channeldb.ErrPaymentInFlight {
+		t.Fatalf("expected ErrPaymentInFlight, got: %v", err)
 	}
 
-	if s.numPendingPayments() != 2 {
+	if s.numPendingPayments() != 1 {
 		t.Fatal("wrong amount of pending payments")
 	}
 
-	if s.circuits.NumOpen() != 2 {
+	if s.circuits.NumOpen() != 1 {
 		t.Fatal("wrong amount of circuits")
 	}
 
@@ -1580,6 +1568,35 @@ func TestSwitchSendPayment(t *testing.T) {
 		t.Fatal("err wasn't received")
 	}
 
+	// The failure should have been recorded, allowing the payment to be
+	// retried.
+	attempt, err := s.cfg.DB.FetchPaymentAttempt(rhash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment attempt: %v", err)
+	}
+	if attempt.Status != channeldb.StatusFailed {
+		t.Fatalf("expected payment to be failed, got %v",
+			attempt.Status)
+	}
+
+	go func() {
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
+			newMockDeobfuscator())
+		errChan <- err
+	}()
+
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if err := aliceChannelLink.completeCircuit(packet); err != nil {
+			t.Fatalf("unable to complete payment circuit: %v", err)
+		}
+
+	case err := <-errChan:
+		t.Fatalf("unable to send payment: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
 	packet = &htlcPacket{
 		outgoingChanID: aliceChannelLink.ShortChanID(),
 		outgoingHTLCID: 1,
@@ -1608,6 +1625,463 @@ func TestSwitchSendPayment(t *testing.T) {
 	}
 }
 
+// TestSwitchSendProbe tests that HTLCs sent as probes are dispatched like
+// regular payments, but aren't recorded with the control tower.
+func TestSwitchSendProbe(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(t, "alice", nil)
+	if err != nil {
+		t.Fatalf("unable to create alice server: %v", err)
+	}
+
+	s, err := initSwitchWithDB(nil)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+
+	chanID1, _, aliceChanID, _ := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	preimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rhash := fastsha256.Sum256(preimage[:])
+	update := &lnwire.UpdateAddHTLC{
+		PaymentHash: rhash,
+		Amount:      1,
+	}
+
+	errChan := make(chan error)
+	go func() {
+		_, err := s.SendProbe(aliceChannelLink.Peer().PubKey(), update,
+			newMockDeobfuscator())
+		errChan <- err
+	}()
+
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if err := aliceChannelLink.completeCircuit(packet); err != nil {
+			t.Fatalf("unable to complete payment circuit: %v", err)
+		}
+
+	case err := <-errChan:
+		t.Fatalf("unable to send probe: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	// The probe shouldn't have been recorded as a payment.
+	_, err = s.cfg.DB.FetchPaymentAttempt(rhash)
+	if err != channeldb.ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got: %v", err)
+	}
+
+	// We'll now fail the probe as the destination would, as it doesn't
+	// know the payment hash.
+	obfuscator := NewMockObfuscator()
+	failure := lnwire.FailUnknownPaymentHash{}
+	reason, err := obfuscator.EncryptFirstHop(failure)
+	if err != nil {
+		t.Fatalf("unable obfuscate failure: %v", err)
+	}
+
+	packet := &htlcPacket{
+		outgoingChanID: aliceChannelLink.ShortChanID(),
+		outgoingHTLCID: 0,
+		amount:         1,
+		htlc: &lnwire.UpdateFailHTLC{
+			Reason: reason,
+		},
+	}
+	if err := s.forward(packet); err != nil {
+		t.Fatalf("can't forward htlc packet: %v", err)
+	}
+
+	select {
+	case err := <-errChan:
+		if err.Error() != errors.New(lnwire.CodeUnknownPaymentHash).Error() {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("err wasn't received")
+	}
+
+	// Even after the probe has been resolved, no record of it should
+	// exist.
+	_, err = s.cfg.DB.FetchPaymentAttempt(rhash)
+	if err != channeldb.ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got: %v", err)
+	}
+
+	if s.numPendingPayments() != 0 {
+		t.Fatal("wrong amount of pending payments")
+	}
+}
+
+// paymentOutcome is the outcome of a payment sent before a restart, as
+// reported by the switch through its NotifyPaymentOutcome callback.
+type paymentOutcome struct {
+	paymentHash [32]byte
+	preimage    [32]byte
+	err         error
+}
+
+// restartSwitch stops the passed switch and closes its database, then reopens
+// the database at tempPath and starts a new switch on top of it. The outcomes
+// of the payments the new switch resolves are delivered over the returned
+// channel.
+func restartSwitch(t *testing.T, s *Switch, cdb *channeldb.DB,
+	tempPath string) (*Switch, *channeldb.DB, <-chan *paymentOutcome) {
+
+	if err := s.Stop(); err != nil {
+		t.Fatalf("unable to stop switch: %v", err)
+	}
+	if err := cdb.Close(); err != nil {
+		t.Fatalf("unable to close channeldb: %v", err)
+	}
+
+	cdb2, err := channeldb.Open(tempPath)
+	if err != nil {
+		t.Fatalf("unable to reopen channeldb: %v", err)
+	}
+	s2, err := initSwitchWithDB(cdb2)
+	if err != nil {
+		t.Fatalf("unable to reinit switch: %v", err)
+	}
+
+	outcomes := make(chan *paymentOutcome, 10)
+	s2.cfg.NotifyPaymentOutcome = func(paymentHash, preimage [32]byte,
+		paymentErr error) {
+
+		outcomes <- &paymentOutcome{
+			paymentHash: paymentHash,
+			preimage:    preimage,
+			err:         paymentErr,
+		}
+	}
+
+	if err := s2.Start(); err != nil {
+		t.Fatalf("unable to restart switch: %v", err)
+	}
+
+	return s2, cdb2, outcomes
+}
+
+// assertPaymentOutcome asserts that the switch reported the outcome of the
+// payment with the passed hash, and that it succeeded with the given preimage
+// if one is passed, or failed otherwise.
+func assertPaymentOutcome(t *testing.T, outcomes <-chan *paymentOutcome,
+	rhash [32]byte, preimage *[32]byte) {
+
+	t.Helper()
+
+	var outcome *paymentOutcome
+	select {
+	case outcome = <-outcomes:
+	case <-time.After(time.Second):
+		t.Fatalf("outcome of payment %x not reported", rhash[:])
+	}
+
+	if outcome.paymentHash != rhash {
+		t.Fatalf("expected outcome of payment %x, got %x", rhash[:],
+			outcome.paymentHash[:])
+	}
+
+	switch {
+	case preimage == nil && outcome.err == nil:
+		t.Fatalf("expected payment %x to fail", rhash[:])
+
+	case preimage != nil && outcome.err != nil:
+		t.Fatalf("expected payment %x to succeed, got: %v", rhash[:],
+			outcome.err)
+
+	case preimage != nil && outcome.preimage != *preimage:
+		t.Fatalf("expected preimage %x, got %x", preimage[:],
+			outcome.preimage[:])
+	}
+}
+
+// assertPaymentStatus asserts that the control tower recorded the payment with
+// the passed hash with the given status.
+func assertPaymentStatus(t *testing.T, cdb *channeldb.DB, rhash [32]byte,
+	status channeldb.PaymentStatus) *channeldb.PaymentAttempt {
+
+	t.Helper()
+
+	attempt, err := cdb.FetchPaymentAttempt(rhash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment attempt: %v", err)
+	}
+	if attempt.Status != status {
+		t.Fatalf("expected payment status %v, got %v", status,
+			attempt.Status)
+	}
+
+	return attempt
+}
+
+// TestSwitchPaymentResolvedAfterRestart tests that a payment whose HTLC was
+// locked in before the switch restarted stays in flight across the restart,
+// and that the control tower records its outcome once the HTLC is settled,
+// even though nobody is waiting for the result anymore.
+func TestSwitchPaymentResolvedAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(t, "alice", nil)
+	if err != nil {
+		t.Fatalf("unable to create alice server: %v", err)
+	}
+
+	tempPath, err := ioutil.TempDir("", "circuitdb")
+	if err != nil {
+		t.Fatalf("unable to temporary path: %v", err)
+	}
+	cdb, err := channeldb.Open(tempPath)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+
+	s, err := initSwitchWithDB(cdb)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+
+	chanID1, _, aliceChanID, _ := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	preimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rhash := fastsha256.Sum256(preimage[:])
+	update := &lnwire.UpdateAddHTLC{
+		PaymentHash: rhash,
+		Amount:      1,
+	}
+
+	// Send the payment, and lock in its HTLC by completing the circuit.
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
+			newMockDeobfuscator())
+		errChan <- err
+	}()
+
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if err := aliceChannelLink.completeCircuit(packet); err != nil {
+			t.Fatalf("unable to complete payment circuit: %v", err)
+		}
+
+	case err := <-errChan:
+		t.Fatalf("unable to send payment: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	// Restart the switch while the payment is still in flight. As its
+	// HTLC was locked in, the payment should remain in flight.
+	s2, cdb2, outcomes := restartSwitch(t, s, cdb, tempPath)
+	defer s2.Stop()
+
+	assertPaymentStatus(t, cdb2, rhash, channeldb.StatusInFlight)
+	if s2.circuits.NumOpen() != 1 {
+		t.Fatalf("wrong amount of circuits")
+	}
+
+	aliceChannelLink = newMockChannelLink(
+		s2, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s2.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	// Settle the HTLC. Even though nobody is waiting for the result of
+	// the payment anymore, the switch should record and report its
+	// success.
+	settle := &htlcPacket{
+		outgoingChanID: aliceChannelLink.ShortChanID(),
+		outgoingHTLCID: 0,
+		amount:         1,
+		htlc: &lnwire.UpdateFulfillHTLC{
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := s2.forward(settle); err != nil {
+		t.Fatalf("unable to forward settle: %v", err)
+	}
+
+	attempt := assertPaymentStatus(t, cdb2, rhash, channeldb.StatusSucceeded)
+	if attempt.Preimage != preimage {
+		t.Fatalf("expected preimage %x, got %x", preimage,
+			attempt.Preimage)
+	}
+	assertPaymentOutcome(t, outcomes, rhash, &preimage)
+
+	if s2.circuits.NumOpen() != 0 {
+		t.Fatalf("wrong amount of circuits")
+	}
+	if s2.numPendingPayments() != 0 {
+		t.Fatal("wrong amount of pending payments")
+	}
+}
+
+// TestSwitchPaymentFailedOnRestart tests that payments whose HTLC wasn't
+// locked in before the switch restarted, either because its circuit was never
+// committed or never completed, are failed when the switch starts, so that
+// they can be retried.
+func TestSwitchPaymentFailedOnRestart(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(t, "alice", nil)
+	if err != nil {
+		t.Fatalf("unable to create alice server: %v", err)
+	}
+
+	tempPath, err := ioutil.TempDir("", "circuitdb")
+	if err != nil {
+		t.Fatalf("unable to temporary path: %v", err)
+	}
+	cdb, err := channeldb.Open(tempPath)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+
+	s, err := initSwitchWithDB(cdb)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+
+	chanID1, _, aliceChanID, _ := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	// The first payment is cleared for takeoff, but we shut down before
+	// its circuit is committed, so it won't exist at restart.
+	noCircuitPreimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	noCircuitHash := fastsha256.Sum256(noCircuitPreimage[:])
+	err = s.control.ClearForTakeoff(&lnwire.UpdateAddHTLC{
+		PaymentHash: noCircuitHash,
+		Amount:      1,
+	}, 1000)
+	if err != nil {
+		t.Fatalf("unable to clear payment for takeoff: %v", err)
+	}
+
+	// The second payment reaches the link, but its circuit is never
+	// completed, so the HTLC was never locked in.
+	halfCircuitPreimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	halfCircuitHash := fastsha256.Sum256(halfCircuitPreimage[:])
+	update := &lnwire.UpdateAddHTLC{
+		PaymentHash: halfCircuitHash,
+		Amount:      1,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
+			newMockDeobfuscator())
+		errChan <- err
+	}()
+
+	select {
+	case <-aliceChannelLink.packets:
+	case err := <-errChan:
+		t.Fatalf("unable to send payment: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	if s.circuits.NumPending() != 1 {
+		t.Fatalf("wrong amount of half circuits")
+	}
+
+	// Both payments should be failed when the switch restarts, and the
+	// half circuit removed.
+	s2, cdb2, outcomes := restartSwitch(t, s, cdb, tempPath)
+	defer s2.Stop()
+
+	assertPaymentStatus(t, cdb2, noCircuitHash, channeldb.StatusFailed)
+	assertPaymentStatus(t, cdb2, halfCircuitHash, channeldb.StatusFailed)
+
+	// The failures should also be reported, in any order.
+	reported := make(map[[32]byte]struct{})
+	for i := 0; i < 2; i++ {
+		select {
+		case outcome := <-outcomes:
+			if outcome.err == nil {
+				t.Fatalf("expected payment %x to fail",
+					outcome.paymentHash[:])
+			}
+			reported[outcome.paymentHash] = struct{}{}
+
+		case <-time.After(time.Second):
+			t.Fatal("payment failure not reported")
+		}
+	}
+	for _, rhash := range [][32]byte{noCircuitHash, halfCircuitHash} {
+		if _, ok := reported[rhash]; !ok {
+			t.Fatalf("failure of payment %x not reported", rhash[:])
+		}
+	}
+
+	if s2.circuits.NumPending() != 0 {
+		t.Fatalf("wrong amount of half circuits")
+	}
+
+	inFlight, err := s2.control.InFlight()
+	if err != nil {
+		t.Fatalf("unable to fetch in flight payments: %v", err)
+	}
+	if len(inFlight) != 0 {
+		t.Fatalf("expected no payments in flight, got %v",
+			len(inFlight))
+	}
+
+	// As the payments failed, they may be attempted again.
+	err = s2.control.ClearForTakeoff(update, 2000)
+	if err != nil {
+		t.Fatalf("unable to retry failed payment: %v", err)
+	}
+}
+
 // TestLocalPaymentNoForwardingEvents tests that if we send a series of locally
 // initiated payments, then they aren't reflected in the forwarding log.
 func TestLocalPaymentNoForwardingEvents(t *testing.T) {
@@ -36,7 +36,10 @@ import (
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/cluster"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -45,6 +48,7 @@ import (
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/walletunlocker"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 	"github.com/roasbeef/btcwallet/wallet"
@@ -157,15 +161,6 @@ func lndMain() error {
 		defaultGraphSubDirname,
 		normalizeNetwork(activeNetParams.Name))
 
-	// Open the channeldb, which is dedicated to storing channel, and
-	// network related metadata.
-	chanDB, err := channeldb.Open(graphDir)
-	if err != nil {
-		ltndLog.Errorf("unable to open channeldb: %v", err)
-		return err
-	}
-	defer chanDB.Close()
-
 	// Only process macaroons if --no-macaroons isn't set.
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -198,12 +193,21 @@ func lndMain() error {
 	var macaroonService *macaroons.Service
 	if !cfg.NoMacaroons {
 		// Create the macaroon authentication/authorization service.
-		macaroonService, err = macaroons.NewService(macaroonDatabaseDir,
-			macaroons.IPLockChecker)
+		macaroonService, err = macaroons.NewService(
+			macaroonDatabaseDir, macaroons.IPLockChecker,
+			macaroons.TimeAfterChecker, macaroons.MethodChecker,
+			macaroons.SpendLimitChecker(rpcSpendAmount),
+		)
 		if err != nil {
 			srvrLog.Errorf("unable to create macaroon service: %v", err)
 			return err
 		}
+
+		// The daily budget checker needs access to the spend ledger
+		// within the macaroon database, so it's registered separately.
+		macaroonService.RegisterCheckers(
+			macaroonService.DailySpendChecker(rpcSpendAmount),
+		)
 		defer macaroonService.Close()
 	}
 
@@ -264,6 +268,41 @@ func lndMain() error {
 		}
 	}
 
+	// If we're running as part of a cluster, then we'll wait until we've
+	// been elected leader before opening the database. Until then, we
+	// remain a follower with our wallet unlocked, ready to take over as
+	// soon as the lease of the current leader lapses.
+	var (
+		clusterStatus *cluster.Status
+		leaderElector cluster.LeaderElector
+	)
+	if cfg.Cluster.EnableLeaderElection {
+		clusterStatus = cluster.NewStatus(cfg.Cluster.ID)
+
+		leaderElector, err = waitForLeadership(ctx, clusterStatus)
+		if err != nil {
+			ltndLog.Errorf("unable to become cluster leader: %v",
+				err)
+			return err
+		}
+
+		// Closing the elector on shutdown revokes our lease, allowing
+		// a follower to take over right away.
+		defer func() {
+			clusterStatus.SetLeader(false)
+			leaderElector.Close()
+		}()
+	}
+
+	// Open the channeldb, which is dedicated to storing channel, and
+	// network related metadata.
+	chanDB, err := openChannelDB(graphDir, leaderElector)
+	if err != nil {
+		ltndLog.Errorf("unable to open channeldb: %v", err)
+		return err
+	}
+	defer chanDB.Close()
+
 	// With the information parsed from the configuration, create valid
 	// instances of the pertinent interfaces required to operate the
 	// Lightning Network Daemon.
@@ -285,17 +324,6 @@ func lndMain() error {
 	primaryChain := registeredChains.PrimaryChain()
 	registeredChains.RegisterChain(primaryChain, activeChainControl)
 
-	// Select the configuration and furnding parameters for Bitcoin or
-	// Litecoin, depending on the primary registered chain.
-	chainCfg := cfg.Bitcoin
-	minRemoteDelay := minBtcRemoteDelay
-	maxRemoteDelay := maxBtcRemoteDelay
-	if primaryChain == litecoinChain {
-		chainCfg = cfg.Litecoin
-		minRemoteDelay = minLtcRemoteDelay
-		maxRemoteDelay = maxLtcRemoteDelay
-	}
-
 	// TODO(roasbeef): add rotation
 	idPrivKey, err := activeChainControl.wallet.DerivePrivKey(keychain.KeyDescriptor{
 		KeyLocator: keychain.KeyLocator{
@@ -308,6 +336,18 @@ func lndMain() error {
 	}
 	idPrivKey.Curve = btcec.S256()
 
+	// The wallet of every member of a cluster is local to it, so we'll
+	// make sure ours holds the identity key of the node stored within the
+	// shared database before taking over.
+	if cfg.Cluster.EnableLeaderElection {
+		err := checkClusterIdentity(chanDB, idPrivKey.PubKey())
+		if err != nil {
+			ltndLog.Errorf("unable to take over as cluster "+
+				"leader: %v", err)
+			return err
+		}
+	}
+
 	if cfg.Tor.Socks != "" && cfg.Tor.DNS != "" {
 		srvrLog.Infof("Proxying all network traffic via Tor "+
 			"(stream_isolation=%v)! NOTE: If running with a full-node "+
@@ -315,10 +355,36 @@ func lndMain() error {
 			cfg.Tor.StreamIsolation)
 	}
 
+	// Before starting any links, we'll garbage collect all forwarding
+	// packages that are no longer needed, such as those of closed
+	// channels. When running as part of a cluster, this must only be done
+	// once we've become the leader.
+	numPruned, err := chanDB.PruneFwdPkgs()
+	if err != nil {
+		ltndLog.Errorf("unable to prune forwarding packages: %v", err)
+		return err
+	}
+	ltndLog.Infof("Pruned %v forwarding packages", numPruned)
+
+	// Set up the listeners for incoming peer connections, each of which
+	// will carry out the brontide handshake before handing over the
+	// connection.
+	listeners := make([]net.Listener, len(cfg.Listeners))
+	for i, addr := range cfg.Listeners {
+		// Note: though brontide.NewListener uses ResolveTCPAddr, it
+		// doesn't need to call the general lndResolveTCP function
+		// since we are resolving a local address.
+		listeners[i], err = brontide.NewListener(idPrivKey, addr)
+		if err != nil {
+			srvrLog.Errorf("unable to create listener: %v\n", err)
+			return err
+		}
+	}
+
 	// Set up the core server which will listen for incoming peer
 	// connections.
 	server, err := newServer(
-		cfg.Listeners, chanDB, activeChainControl, idPrivKey,
+		listeners, chanDB, activeChainControl, idPrivKey,
 	)
 	if err != nil {
 		srvrLog.Errorf("unable to create server: %v\n", err)
@@ -327,12 +393,177 @@ func lndMain() error {
 
 	// Next, we'll initialize the funding manager itself so it can answer
 	// queries while the wallet+chain are still syncing.
+	fundingMgr, err := newServerFundingManager(
+		server, chanDB, activeChainControl, idPrivKey,
+	)
+	if err != nil {
+		return err
+	}
+	if err := fundingMgr.Start(); err != nil {
+		return err
+	}
+	server.fundingMgr = fundingMgr
+	server.clusterStatus = clusterStatus
+
+	// Check macaroon authentication if macaroons aren't disabled.
+	if macaroonService != nil {
+		serverOpts = append(serverOpts,
+			grpc.UnaryInterceptor(macaroonService.
+				UnaryServerInterceptor(permissions)),
+			grpc.StreamInterceptor(macaroonService.
+				StreamServerInterceptor(permissions)),
+		)
+	}
+
+	// Initialize, and register our implementation of the gRPC interface
+	// exported by the rpcServer.
+	rpcServer := newRPCServer(server)
+	if err := rpcServer.Start(); err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	lnrpc.RegisterLightningServer(grpcServer, rpcServer)
+
+	// Next, Start the gRPC server listening for HTTP/2 connections.
+	for _, listener := range cfg.RPCListeners {
+		lis, err := net.Listen("tcp", listener)
+		if err != nil {
+			ltndLog.Errorf("RPC server unable to listen on %s", listener)
+			return err
+		}
+		defer lis.Close()
+		go func() {
+			rpcsLog.Infof("RPC server listening on %s", lis.Addr())
+			grpcServer.Serve(lis)
+		}()
+	}
+
+	// Finally, start the REST proxy for our gRPC server above.
+	mux := proxy.NewServeMux()
+	err = lnrpc.RegisterLightningHandlerFromEndpoint(ctx, mux,
+		cfg.RPCListeners[0], proxyOpts)
+	if err != nil {
+		return err
+	}
+	for _, restEndpoint := range cfg.RESTListeners {
+		listener, err := tls.Listen("tcp", restEndpoint, tlsConf)
+		if err != nil {
+			ltndLog.Errorf("gRPC proxy unable to listen on %s", restEndpoint)
+			return err
+		}
+		defer listener.Close()
+		go func() {
+			rpcsLog.Infof("gRPC proxy started at %s", listener.Addr())
+			http.Serve(listener, mux)
+		}()
+	}
+
+	// If we're not in simnet mode, We'll wait until we're fully synced to
+	// continue the start up of the remainder of the daemon. This ensures
+	// that we don't accept any possibly invalid state transitions, or
+	// accept channels with spent funds.
+	if !(cfg.Bitcoin.SimNet || cfg.Litecoin.SimNet) {
+		_, bestHeight, err := activeChainControl.chainIO.GetBestBlock()
+		if err != nil {
+			return err
+		}
+
+		ltndLog.Infof("Waiting for chain backend to finish sync, "+
+			"start_height=%v", bestHeight)
+
+		for {
+			synced, _, err := activeChainControl.wallet.IsSynced()
+			if err != nil {
+				return err
+			}
+
+			if synced {
+				break
+			}
+
+			time.Sleep(time.Second * 1)
+		}
+
+		_, bestHeight, err = activeChainControl.chainIO.GetBestBlock()
+		if err != nil {
+			return err
+		}
+
+		ltndLog.Infof("Chain backend is fully synced (end_height=%v)!",
+			bestHeight)
+	}
+
+	// With all the relevant chains initialized, we can finally start the
+	// server itself.
+	if err := server.Start(); err != nil {
+		srvrLog.Errorf("unable to start server: %v\n", err)
+		return err
+	}
+
+	// Now that the server has started, if the autopilot mode is currently
+	// active, then we'll initialize a fresh instance of it and start it.
+	var pilot *autopilot.Agent
+	if cfg.Autopilot.Active {
+		pilot, err := initAutoPilot(server, cfg.Autopilot)
+		if err != nil {
+			ltndLog.Errorf("unable to create autopilot agent: %v",
+				err)
+			return err
+		}
+		if err := pilot.Start(); err != nil {
+			ltndLog.Errorf("unable to start autopilot agent: %v",
+				err)
+			return err
+		}
+	}
+
+	addInterruptHandler(func() {
+		ltndLog.Infof("Gracefully shutting down the server...")
+		rpcServer.Stop()
+		fundingMgr.Stop()
+		server.Stop()
+
+		if pilot != nil {
+			pilot.Stop()
+		}
+
+		server.WaitForShutdown()
+	})
+
+	// Wait for shutdown signal from either a graceful server stop or from
+	// the interrupt handler.
+	<-shutdownChannel
+	ltndLog.Info("Shutdown complete")
+	return nil
+}
+
+// newServerFundingManager creates the funding manager of the passed server,
+// using the funding parameters of the primary chain. The returned funding
+// manager still needs to be started.
+func newServerFundingManager(server *server, chanDB *channeldb.DB,
+	activeChainControl *chainControl,
+	idPrivKey *btcec.PrivateKey) (*fundingManager, error) {
+
+	// Select the configuration and furnding parameters for Bitcoin or
+	// Litecoin, depending on the primary registered chain.
+	primaryChain := registeredChains.PrimaryChain()
+	chainCfg := cfg.Bitcoin
+	minRemoteDelay := minBtcRemoteDelay
+	maxRemoteDelay := maxBtcRemoteDelay
+	if primaryChain == litecoinChain {
+		chainCfg = cfg.Litecoin
+		minRemoteDelay = minLtcRemoteDelay
+		maxRemoteDelay = maxLtcRemoteDelay
+	}
+
 	nodeSigner := newNodeSigner(idPrivKey)
 	var chanIDSeed [32]byte
 	if _, err := rand.Read(chanIDSeed[:]); err != nil {
-		return err
+		return nil, err
 	}
-	fundingMgr, err := newFundingManager(fundingConfig{
+
+	return newFundingManager(fundingConfig{
 		IDKey:              idPrivKey.PubKey(),
 		Wallet:             activeChainControl.wallet,
 		PublishTransaction: activeChainControl.wallet.PublishTransaction,
@@ -412,6 +643,14 @@ func lndMain() error {
 				lnwire.NewMSatFromSatoshis(maxFundingAmount))
 			stake := lnwire.NewMSatFromSatoshis(chanAmt) + pushAmt
 			conf := maxConf * uint64(stake) / maxChannelSize
+
+			// Large channels keep scaling beyond the regular
+			// channel size limit, as there's more at stake
+			// in the case of a reorg.
+			if uint64(stake) > maxChannelSize {
+				maxConf = maxLargeChanConfs
+			}
+
 			if conf < minConf {
 				conf = minConf
 			}
@@ -437,15 +676,9 @@ func lndMain() error {
 			}
 
 			// If not we scale according to channel size.
-			delay := uint16(btcutil.Amount(maxRemoteDelay) *
-				chanAmt / maxFundingAmount)
-			if delay < minRemoteDelay {
-				delay = minRemoteDelay
-			}
-			if delay > maxRemoteDelay {
-				delay = maxRemoteDelay
-			}
-			return delay
+			return scaleRemoteDelay(
+				chanAmt, minRemoteDelay, maxRemoteDelay,
+			)
 		},
 		WatchNewChannel: func(channel *channeldb.OpenChannel,
 			addr *lnwire.NetAddress) error {
@@ -488,158 +721,264 @@ func lndMain() error {
 		ZombieSweeperInterval: 1 * time.Minute,
 		ReservationTimeout:    10 * time.Minute,
 		MinChanSize:           btcutil.Amount(cfg.MinChanSize),
+		SupportsDualFunding: func(peerKey *btcec.PublicKey) bool {
+			if !cfg.DualFunding.Active {
+				return false
+			}
+
+			peer, err := server.FindPeer(peerKey)
+			if err != nil {
+				return false
+			}
+
+			return peer.supportsDualFunding()
+		},
+		SupportsLargeChannels: func(peerKey *btcec.PublicKey) bool {
+			peer, err := server.FindPeer(peerKey)
+			if err != nil {
+				return false
+			}
+
+			return peer.supportsLargeChannels()
+		},
+		UpfrontShutdownScript: func(peerKey *btcec.PublicKey,
+			requested lnwire.DeliveryAddress) (lnwire.DeliveryAddress, error) {
+
+			// If the peer doesn't understand upfront shutdown
+			// scripts, then we're unable to commit to one.
+			peer, err := server.FindPeer(peerKey)
+			if err != nil {
+				return nil, err
+			}
+			if !peer.supportsUpfrontShutdown() {
+				if len(requested) > 0 {
+					return nil, fmt.Errorf("peer %x does "+
+						"not support upfront shutdown "+
+						"scripts",
+						peerKey.SerializeCompressed())
+				}
+
+				return nil, nil
+			}
+
+			// An address requested by the user always takes
+			// precedence.
+			if len(requested) > 0 {
+				return requested, nil
+			}
+
+			// Otherwise, we'll only commit to a fresh address if
+			// we've been configured to do so.
+			if !cfg.EnableUpfrontShutdown {
+				return nil, nil
+			}
+
+			addr, err := server.cc.wallet.NewAddress(
+				lnwallet.WitnessPubKey, false,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return txscript.PayToAddrScript(addr)
+		},
+		DualFundingContribution: func(remoteAmt btcutil.Amount) btcutil.Amount {
+			// By default, we'll match the configured fraction of
+			// the remote party's funds, up to our configured
+			// maximum.
+			localAmt := btcutil.Amount(
+				float64(remoteAmt) * cfg.DualFunding.MatchFraction,
+			)
+			maxAmt := btcutil.Amount(cfg.DualFunding.MaxContribution)
+			if localAmt > maxAmt {
+				localAmt = maxAmt
+			}
+
+			return localAmt
+		},
 	})
-	if err != nil {
-		return err
-	}
-	if err := fundingMgr.Start(); err != nil {
-		return err
-	}
-	server.fundingMgr = fundingMgr
+}
 
-	// Check macaroon authentication if macaroons aren't disabled.
-	if macaroonService != nil {
-		serverOpts = append(serverOpts,
-			grpc.UnaryInterceptor(macaroonService.
-				UnaryServerInterceptor(permissions)),
-			grpc.StreamInterceptor(macaroonService.
-				StreamServerInterceptor(permissions)),
-		)
+func main() {
+	// Call the "real" main in a nested manner so the defers will properly
+	// be executed in the case of a graceful shutdown.
+	if err := lndMain(); err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
 	}
+}
 
-	// Initialize, and register our implementation of the gRPC interface
-	// exported by the rpcServer.
-	rpcServer := newRPCServer(server)
-	if err := rpcServer.Start(); err != nil {
-		return err
+// openChannelDB opens the channeldb using the key-value backend selected
+// within the config. When using the default bolt backend, the database is
+// stored within the passed directory. If a leader elector is passed, every
+// write to the database is conditioned on us still being the leader,
+// including those initializing and migrating it.
+func openChannelDB(graphDir string,
+	leaderElector cluster.LeaderElector) (*channeldb.DB, error) {
+
+	if cfg.DB.Backend != kvdb.EtcdBackendName {
+		if cfg.DB.AutoCompact {
+			ltndLog.Infof("Compacting channeldb within %v", graphDir)
+
+			if err := channeldb.Compact(graphDir); err != nil {
+				ltndLog.Errorf("Unable to compact channeldb: %v",
+					err)
+				return nil, err
+			}
+		}
+
+		return channeldb.Open(graphDir)
 	}
 
-	grpcServer := grpc.NewServer(serverOpts...)
-	lnrpc.RegisterLightningServer(grpcServer, rpcServer)
+	ltndLog.Infof("Opening channeldb within etcd cluster at %v",
+		cfg.DB.Etcd.Host)
 
-	// Next, Start the gRPC server listening for HTTP/2 connections.
-	for _, listener := range cfg.RPCListeners {
-		lis, err := net.Listen("tcp", listener)
-		if err != nil {
-			ltndLog.Errorf("RPC server unable to listen on %s", listener)
-			return err
+	backend, err := kvdb.OpenEtcd(cfg.DB.Etcd)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll condition every write to the database on us still being the
+	// leader, so we won't clobber the state of our successor should we be
+	// slow to notice our lease has lapsed.
+	if leaderElector != nil {
+		fencer, ok := backend.(kvdb.LeaderFencer)
+		if !ok {
+			backend.Close()
+			return nil, fmt.Errorf("database backend doesn't " +
+				"support leader election")
 		}
-		defer lis.Close()
-		go func() {
-			rpcsLog.Infof("RPC server listening on %s", lis.Addr())
-			grpcServer.Serve(lis)
-		}()
+		fencer.FenceWrites(leaderElector.LeaderKey())
 	}
 
-	// Finally, start the REST proxy for our gRPC server above.
-	mux := proxy.NewServeMux()
-	err = lnrpc.RegisterLightningHandlerFromEndpoint(ctx, mux,
-		cfg.RPCListeners[0], proxyOpts)
-	if err != nil {
-		return err
+	// The graph cache is only kept up to date with our own writes. Unless
+	// leader election guarantees we're the only instance writing to the
+	// database, we'll leave it disabled and read the graph straight from
+	// etcd instead.
+	var dbOptions []channeldb.OptionModifier
+	if leaderElector == nil {
+		dbOptions = append(dbOptions, channeldb.OptionNoGraphCache())
 	}
-	for _, restEndpoint := range cfg.RESTListeners {
-		listener, err := tls.Listen("tcp", restEndpoint, tlsConf)
-		if err != nil {
-			ltndLog.Errorf("gRPC proxy unable to listen on %s", restEndpoint)
-			return err
-		}
-		defer listener.Close()
-		go func() {
-			rpcsLog.Infof("gRPC proxy started at %s", listener.Addr())
-			http.Serve(listener, mux)
-		}()
+
+	chanDB, err := channeldb.CreateWithBackend(backend, dbOptions...)
+	if err != nil {
+		backend.Close()
+		return nil, err
 	}
 
-	// If we're not in simnet mode, We'll wait until we're fully synced to
-	// continue the start up of the remainder of the daemon. This ensures
-	// that we don't accept any possibly invalid state transitions, or
-	// accept channels with spent funds.
-	if !(cfg.Bitcoin.SimNet || cfg.Litecoin.SimNet) {
-		_, bestHeight, err := activeChainControl.chainIO.GetBestBlock()
-		if err != nil {
-			return err
-		}
+	return chanDB, nil
+}
 
-		ltndLog.Infof("Waiting for chain backend to finish sync, "+
-			"start_height=%v", bestHeight)
+// checkClusterIdentity returns an error if the passed identity key of our
+// wallet doesn't match the node stored within the database shared by the
+// cluster. As the wallet isn't part of the shared database, every member of
+// the cluster must hold a copy of the same wallet.
+func checkClusterIdentity(chanDB *channeldb.DB,
+	idPubKey *btcec.PublicKey) error {
 
-		for {
-			synced, _, err := activeChainControl.wallet.IsSynced()
-			if err != nil {
-				return err
-			}
+	sourceNode, err := chanDB.ChannelGraph().SourceNode()
+	switch {
+	// If no node has been stored yet, then we're the first member of the
+	// cluster to run, and our identity will become the node's.
+	case err == channeldb.ErrSourceNodeNotSet:
+		return nil
 
-			if synced {
-				break
-			}
+	case err != nil:
+		return err
+	}
 
-			time.Sleep(time.Second * 1)
-		}
+	var pubKey [33]byte
+	copy(pubKey[:], idPubKey.SerializeCompressed())
+	if sourceNode.PubKeyBytes != pubKey {
+		return fmt.Errorf("wallet identity key %x doesn't match "+
+			"node %x of the cluster, every member must use a "+
+			"copy of the same wallet", pubKey[:],
+			sourceNode.PubKeyBytes[:])
+	}
 
-		_, bestHeight, err = activeChainControl.chainIO.GetBestBlock()
+	return nil
+}
+
+// waitForLeadership campaigns to become the leader of the cluster configured
+// within the config, blocking until we've been elected or a shutdown is
+// requested. The cluster health endpoint is served throughout, if enabled.
+// Once elected, lnd is shut down if our lease is ever lost, as a follower
+// will have taken over by then.
+func waitForLeadership(ctx context.Context,
+	status *cluster.Status) (cluster.LeaderElector, error) {
+
+	if cfg.Cluster.HealthListen != "" {
+		lis, err := net.Listen("tcp", cfg.Cluster.HealthListen)
 		if err != nil {
-			return err
+			ltndLog.Errorf("Cluster health endpoint unable to "+
+				"listen on %s", cfg.Cluster.HealthListen)
+			return nil, err
 		}
 
-		ltndLog.Infof("Chain backend is fully synced (end_height=%v)!",
-			bestHeight)
+		mux := http.NewServeMux()
+		mux.Handle("/health", status)
+		go func() {
+			ltndLog.Infof("Cluster health endpoint listening on %s",
+				lis.Addr())
+			http.Serve(lis, mux)
+		}()
 	}
 
-	// With all the relevant chains initialized, we can finally start the
-	// server itself.
-	if err := server.Start(); err != nil {
-		srvrLog.Errorf("unable to start server: %v\n", err)
-		return err
+	leaderElector, err := cluster.NewEtcdLeaderElector(
+		ctx, cfg.Cluster.ID, cfg.Cluster.LeaderSessionTTL,
+		cfg.DB.Etcd,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	// Now that the server has started, if the autopilot mode is currently
-	// active, then we'll initialize a fresh instance of it and start it.
-	var pilot *autopilot.Agent
-	if cfg.Autopilot.Active {
-		pilot, err := initAutoPilot(server, cfg.Autopilot)
-		if err != nil {
-			ltndLog.Errorf("unable to create autopilot agent: %v",
-				err)
-			return err
-		}
-		if err := pilot.Start(); err != nil {
-			ltndLog.Errorf("unable to start autopilot agent: %v",
-				err)
-			return err
-		}
+	if leader, err := leaderElector.Leader(ctx); err == nil {
+		ltndLog.Infof("Current cluster leader is %v", leader)
 	}
+	ltndLog.Infof("Campaigning for cluster leadership as %v",
+		cfg.Cluster.ID)
 
-	addInterruptHandler(func() {
-		ltndLog.Infof("Gracefully shutting down the server...")
-		rpcServer.Stop()
-		fundingMgr.Stop()
-		server.Stop()
+	campaignCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		if pilot != nil {
-			pilot.Stop()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- leaderElector.Campaign(campaignCtx)
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			leaderElector.Close()
+			return nil, err
 		}
 
-		server.WaitForShutdown()
-	})
+	case <-shutdownChannel:
+		leaderElector.Close()
+		return nil, fmt.Errorf("shutting down")
+	}
 
-	// Wait for shutdown signal from either a graceful server stop or from
-	// the interrupt handler.
-	<-shutdownChannel
-	ltndLog.Info("Shutdown complete")
-	return nil
-}
+	ltndLog.Infof("Elected cluster leader as %v", cfg.Cluster.ID)
+	status.SetLeader(true)
 
-func main() {
-	// Call the "real" main in a nested manner so the defers will properly
-	// be executed in the case of a graceful shutdown.
-	if err := lndMain(); err != nil {
-		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
-		} else {
-			fmt.Fprintln(os.Stderr, err)
+	go func() {
+		<-leaderElector.Done()
+
+		// If we've already stepped down as part of a graceful
+		// shutdown, then there's nothing left to do.
+		if !status.IsLeader() {
+			return
 		}
-		os.Exit(1)
-	}
+		status.SetLeader(false)
+
+		ltndLog.Errorf("Lost cluster leadership, shutting down")
+		shutdownRequestChannel <- struct{}{}
+	}()
+
+	return leaderElector, nil
 }
 
 // fileExists reports whether the named file or directory exists.
@@ -19,12 +19,19 @@ It has these top-level messages:
 	TransactionDetails
 	SendRequest
 	SendResponse
+	SendToRouteRequest
+	TrackPaymentRequest
+	TrackPaymentsRequest
+	PaymentUpdate
 	ChannelPoint
 	LightningAddress
 	SendManyRequest
 	SendManyResponse
 	SendCoinsRequest
 	SendCoinsResponse
+	OutPoint
+	BumpFeeRequest
+	BumpFeeResponse
 	NewAddressRequest
 	NewWitnessAddressRequest
 	NewAddressResponse
@@ -40,11 +47,22 @@ It has these top-level messages:
 	Channel
 	ListChannelsRequest
 	ListChannelsResponse
+	Resolution
+	ChannelCloseSummary
+	ClosedChannelsRequest
+	ClosedChannelsResponse
 	Peer
 	ListPeersRequest
 	ListPeersResponse
+	BannedPeer
+	ListBannedPeersRequest
+	ListBannedPeersResponse
+	UnbanPeerRequest
+	UnbanPeerResponse
 	GetInfoRequest
 	GetInfoResponse
+	UpdateNodeAnnouncementRequest
+	UpdateNodeAnnouncementResponse
 	ConfirmationUpdate
 	ChannelOpenUpdate
 	ChannelCloseUpdate
@@ -52,6 +70,9 @@ It has these top-level messages:
 	CloseStatusUpdate
 	PendingUpdate
 	OpenChannelRequest
+	BatchOpenChannel
+	BatchOpenChannelRequest
+	BatchOpenChannelResponse
 	OpenStatusUpdate
 	PendingHTLC
 	PendingChannelsRequest
@@ -60,10 +81,15 @@ It has these top-level messages:
 	WalletBalanceResponse
 	ChannelBalanceRequest
 	ChannelBalanceResponse
+	DirectedEdge
 	QueryRoutesRequest
 	QueryRoutesResponse
 	Hop
 	Route
+	BuildRouteRequest
+	BuildRouteResponse
+	EstimateRouteFeeRequest
+	EstimateRouteFeeResponse
 	NodeInfoRequest
 	NodeInfo
 	LightningNode
@@ -71,6 +97,8 @@ It has these top-level messages:
 	RoutingPolicy
 	ChannelEdge
 	ChannelGraphRequest
+	ExportGraphRequest
+	ExportGraphChunk
 	ChannelGraph
 	ChanInfoRequest
 	NetworkInfoRequest
@@ -131,6 +159,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type PaymentUpdate_PaymentState int32
+
+const (
+	PaymentUpdate_IN_FLIGHT      PaymentUpdate_PaymentState = 0
+	PaymentUpdate_ATTEMPT_FAILED PaymentUpdate_PaymentState = 1
+	PaymentUpdate_SUCCEEDED      PaymentUpdate_PaymentState = 2
+	PaymentUpdate_FAILED         PaymentUpdate_PaymentState = 3
+)
+
+var PaymentUpdate_PaymentState_name = map[int32]string{
+	0: "IN_FLIGHT",
+	1: "ATTEMPT_FAILED",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var PaymentUpdate_PaymentState_value = map[string]int32{
+	"IN_FLIGHT":      0,
+	"ATTEMPT_FAILED": 1,
+	"SUCCEEDED":      2,
+	"FAILED":         3,
+}
+
+func (x PaymentUpdate_PaymentState) String() string {
+	return proto.EnumName(PaymentUpdate_PaymentState_name, int32(x))
+}
+func (PaymentUpdate_PaymentState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{14, 0}
+}
+
 type NewAddressRequest_AddressType int32
 
 const (
@@ -151,7 +208,91 @@ func (x NewAddressRequest_AddressType) String() string {
 	return proto.EnumName(NewAddressRequest_AddressType_name, int32(x))
 }
 func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{17, 0}
+	return fileDescriptor0, []int{24, 0}
+}
+
+type Resolution_ResolutionType int32
+
+const (
+	Resolution_COMMIT        Resolution_ResolutionType = 0
+	Resolution_INCOMING_HTLC Resolution_ResolutionType = 1
+	Resolution_OUTGOING_HTLC Resolution_ResolutionType = 2
+)
+
+var Resolution_ResolutionType_name = map[int32]string{
+	0: "COMMIT",
+	1: "INCOMING_HTLC",
+	2: "OUTGOING_HTLC",
+}
+var Resolution_ResolutionType_value = map[string]int32{
+	"COMMIT":        0,
+	"INCOMING_HTLC": 1,
+	"OUTGOING_HTLC": 2,
+}
+
+func (x Resolution_ResolutionType) String() string {
+	return proto.EnumName(Resolution_ResolutionType_name, int32(x))
+}
+func (Resolution_ResolutionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{39, 0}
+}
+
+type Resolution_ResolutionOutcome int32
+
+const (
+	Resolution_CLAIMED   Resolution_ResolutionOutcome = 0
+	Resolution_TIMEOUT   Resolution_ResolutionOutcome = 1
+	Resolution_ABANDONED Resolution_ResolutionOutcome = 2
+)
+
+var Resolution_ResolutionOutcome_name = map[int32]string{
+	0: "CLAIMED",
+	1: "TIMEOUT",
+	2: "ABANDONED",
+}
+var Resolution_ResolutionOutcome_value = map[string]int32{
+	"CLAIMED":   0,
+	"TIMEOUT":   1,
+	"ABANDONED": 2,
+}
+
+func (x Resolution_ResolutionOutcome) String() string {
+	return proto.EnumName(Resolution_ResolutionOutcome_name, int32(x))
+}
+func (Resolution_ResolutionOutcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{39, 1}
+}
+
+type ChannelCloseSummary_ClosureType int32
+
+const (
+	ChannelCloseSummary_COOPERATIVE_CLOSE  ChannelCloseSummary_ClosureType = 0
+	ChannelCloseSummary_LOCAL_FORCE_CLOSE  ChannelCloseSummary_ClosureType = 1
+	ChannelCloseSummary_REMOTE_FORCE_CLOSE ChannelCloseSummary_ClosureType = 2
+	ChannelCloseSummary_BREACH_CLOSE       ChannelCloseSummary_ClosureType = 3
+	ChannelCloseSummary_FUNDING_CANCELED   ChannelCloseSummary_ClosureType = 4
+)
+
+var ChannelCloseSummary_ClosureType_name = map[int32]string{
+	0: "COOPERATIVE_CLOSE",
+	1: "LOCAL_FORCE_CLOSE",
+	2: "REMOTE_FORCE_CLOSE",
+	3: "BREACH_CLOSE",
+	4: "FUNDING_CANCELED",
+}
+var ChannelCloseSummary_ClosureType_value = map[string]int32{
+	"COOPERATIVE_CLOSE":  0,
+	"LOCAL_FORCE_CLOSE":  1,
+	"REMOTE_FORCE_CLOSE": 2,
+	"BREACH_CLOSE":       3,
+	"FUNDING_CANCELED":   4,
+}
+
+func (x ChannelCloseSummary_ClosureType) String() string {
+	return proto.EnumName(ChannelCloseSummary_ClosureType_name, int32(x))
+}
+func (ChannelCloseSummary_ClosureType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{40, 0}
 }
 
 type GenSeedRequest struct {
@@ -447,6 +588,10 @@ type SendRequest struct {
 	PaymentRequest string `protobuf:"bytes,6,opt,name=payment_request,json=paymentRequest" json:"payment_request,omitempty"`
 	// / The CLTV delta from the current height that should be used to set the timelock for the final hop.
 	FinalCltvDelta int32 `protobuf:"varint,7,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	// *
+	// The maximum number of satoshis that will be paid as a fee of the payment.
+	// If unset, the fee of the payment isn't bounded.
+	FeeLimit int64 `protobuf:"varint,8,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
 }
 
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
@@ -503,6 +648,13 @@ func (m *SendRequest) GetFinalCltvDelta() int32 {
 	return 0
 }
 
+func (m *SendRequest) GetFeeLimit() int64 {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return 0
+}
+
 type SendResponse struct {
 	PaymentError    string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
 	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
@@ -535,6 +687,141 @@ func (m *SendResponse) GetPaymentRoute() *Route {
 	return nil
 }
 
+type SendToRouteRequest struct {
+	// / The hash to use within the payment's HTLC
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// / The hex-encoded hash to use within the payment's HTLC
+	PaymentHashString string `protobuf:"bytes,2,opt,name=payment_hash_string,json=paymentHashString" json:"payment_hash_string,omitempty"`
+	// / The routes to attempt, in order.
+	Routes []*Route `protobuf:"bytes,3,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
+func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendToRouteRequest) ProtoMessage()               {}
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *SendToRouteRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendToRouteRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
+
+func (m *SendToRouteRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+type TrackPaymentRequest struct {
+	// *
+	// The hex-encoded payment hash of the payment to track. The passed payment
+	// hash must be exactly 32 bytes, otherwise an error is returned.
+	PaymentHashStr string `protobuf:"bytes,1,opt,name=payment_hash_str" json:"payment_hash_str,omitempty"`
+	// / The payment hash of the payment to track.
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+}
+
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *TrackPaymentRequest) GetPaymentHashStr() string {
+	if m != nil {
+		return m.PaymentHashStr
+	}
+	return ""
+}
+
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+type TrackPaymentsRequest struct {
+}
+
+func (m *TrackPaymentsRequest) Reset()                    { *m = TrackPaymentsRequest{} }
+func (m *TrackPaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentsRequest) ProtoMessage()               {}
+func (*TrackPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+type PaymentUpdate struct {
+	// / The payment hash of the payment.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / The state the payment transitioned to.
+	State PaymentUpdate_PaymentState `protobuf:"varint,2,opt,name=state,enum=lnrpc.PaymentUpdate_PaymentState" json:"state,omitempty"`
+	// *
+	// The route of the failed attempt for an ATTEMPT_FAILED update, or the route
+	// the payment traversed for a SUCCEEDED update.
+	Route *Route `protobuf:"bytes,3,opt,name=route" json:"route,omitempty"`
+	// / The preimage of the payment, set for a SUCCEEDED update.
+	PaymentPreimage []byte `protobuf:"bytes,4,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
+	// / The total fees paid in millisatoshis, set for a SUCCEEDED update.
+	FeeMsat int64 `protobuf:"varint,5,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The reason the attempt or payment failed.
+	FailureReason string `protobuf:"bytes,6,opt,name=failure_reason" json:"failure_reason,omitempty"`
+}
+
+func (m *PaymentUpdate) Reset()                    { *m = PaymentUpdate{} }
+func (m *PaymentUpdate) String() string            { return proto.CompactTextString(m) }
+func (*PaymentUpdate) ProtoMessage()               {}
+func (*PaymentUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *PaymentUpdate) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetState() PaymentUpdate_PaymentState {
+	if m != nil {
+		return m.State
+	}
+	return PaymentUpdate_IN_FLIGHT
+}
+
+func (m *PaymentUpdate) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetPaymentPreimage() []byte {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+func (m *PaymentUpdate) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
+}
+
 type ChannelPoint struct {
 	// Types that are valid to be assigned to FundingTxid:
 	//	*ChannelPoint_FundingTxidBytes
@@ -547,7 +834,7 @@ type ChannelPoint struct {
 func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
 func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
 func (*ChannelPoint) ProtoMessage()               {}
-func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 type isChannelPoint_FundingTxid interface {
 	isChannelPoint_FundingTxid()
@@ -667,7 +954,7 @@ type LightningAddress struct {
 func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
 func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
 func (*LightningAddress) ProtoMessage()               {}
-func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *LightningAddress) GetPubkey() string {
 	if m != nil {
@@ -695,7 +982,7 @@ type SendManyRequest struct {
 func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
 func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendManyRequest) ProtoMessage()               {}
-func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
 	if m != nil {
@@ -726,7 +1013,7 @@ type SendManyResponse struct {
 func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
 func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendManyResponse) ProtoMessage()               {}
-func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *SendManyResponse) GetTxid() string {
 	if m != nil {
@@ -749,7 +1036,7 @@ type SendCoinsRequest struct {
 func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
 func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsRequest) ProtoMessage()               {}
-func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *SendCoinsRequest) GetAddr() string {
 	if m != nil {
@@ -787,7 +1074,7 @@ type SendCoinsResponse struct {
 func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
 func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsResponse) ProtoMessage()               {}
-func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *SendCoinsResponse) GetTxid() string {
 	if m != nil {
@@ -796,6 +1083,102 @@ func (m *SendCoinsResponse) GetTxid() string {
 	return ""
 }
 
+type OutPoint struct {
+	// / Raw bytes representing the transaction id
+	TxidBytes []byte `protobuf:"bytes,1,opt,name=txid_bytes,proto3" json:"txid_bytes,omitempty"`
+	// / Reversed, hex-encoded string representing the transaction id
+	TxidStr string `protobuf:"bytes,2,opt,name=txid_str" json:"txid_str,omitempty"`
+	// / The index of the output on the transaction
+	OutputIndex uint32 `protobuf:"varint,3,opt,name=output_index" json:"output_index,omitempty"`
+}
+
+func (m *OutPoint) Reset()                    { *m = OutPoint{} }
+func (m *OutPoint) String() string            { return proto.CompactTextString(m) }
+func (*OutPoint) ProtoMessage()               {}
+func (*OutPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *OutPoint) GetTxidBytes() []byte {
+	if m != nil {
+		return m.TxidBytes
+	}
+	return nil
+}
+
+func (m *OutPoint) GetTxidStr() string {
+	if m != nil {
+		return m.TxidStr
+	}
+	return ""
+}
+
+func (m *OutPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+type BumpFeeRequest struct {
+	// / The unconfirmed output paying to the wallet, or to us on a commitment transaction, that should be spent
+	Outpoint *OutPoint `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The target number of blocks that the transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the child transaction.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *BumpFeeRequest) Reset()                    { *m = BumpFeeRequest{} }
+func (m *BumpFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeRequest) ProtoMessage()               {}
+func (*BumpFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *BumpFeeRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *BumpFeeRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *BumpFeeRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type BumpFeeResponse struct {
+	// / The transaction ID of the child transaction
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	// / The fee in satoshis paid by the child transaction
+	FeeSat int64 `protobuf:"varint,2,opt,name=fee_sat" json:"fee_sat,omitempty"`
+}
+
+func (m *BumpFeeResponse) Reset()                    { *m = BumpFeeResponse{} }
+func (m *BumpFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeResponse) ProtoMessage()               {}
+func (*BumpFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *BumpFeeResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *BumpFeeResponse) GetFeeSat() int64 {
+	if m != nil {
+		return m.FeeSat
+	}
+	return 0
+}
+
 // *
 // `AddressType` has to be one of:
 //
@@ -810,7 +1193,7 @@ type NewAddressRequest struct {
 func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
 func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressRequest) ProtoMessage()               {}
-func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *NewAddressRequest) GetType() NewAddressRequest_AddressType {
 	if m != nil {
@@ -825,7 +1208,7 @@ type NewWitnessAddressRequest struct {
 func (m *NewWitnessAddressRequest) Reset()                    { *m = NewWitnessAddressRequest{} }
 func (m *NewWitnessAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewWitnessAddressRequest) ProtoMessage()               {}
-func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 type NewAddressResponse struct {
 	// / The newly generated wallet address
@@ -835,7 +1218,7 @@ type NewAddressResponse struct {
 func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
 func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressResponse) ProtoMessage()               {}
-func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *NewAddressResponse) GetAddress() string {
 	if m != nil {
@@ -852,7 +1235,7 @@ type SignMessageRequest struct {
 func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
 func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageRequest) ProtoMessage()               {}
-func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *SignMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -869,7 +1252,7 @@ type SignMessageResponse struct {
 func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
 func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageResponse) ProtoMessage()               {}
-func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *SignMessageResponse) GetSignature() string {
 	if m != nil {
@@ -888,7 +1271,7 @@ type VerifyMessageRequest struct {
 func (m *VerifyMessageRequest) Reset()                    { *m = VerifyMessageRequest{} }
 func (m *VerifyMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageRequest) ProtoMessage()               {}
-func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *VerifyMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -914,7 +1297,7 @@ type VerifyMessageResponse struct {
 func (m *VerifyMessageResponse) Reset()                    { *m = VerifyMessageResponse{} }
 func (m *VerifyMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageResponse) ProtoMessage()               {}
-func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *VerifyMessageResponse) GetValid() bool {
 	if m != nil {
@@ -934,14 +1317,15 @@ type ConnectPeerRequest struct {
 	// / Lightning address of the peer, in the format `<pubkey>@host`
 	Addr *LightningAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
 	// * If set, the daemon will attempt to persistently connect to the target
-	// peer.  Otherwise, the call will be synchronous.
+	// peer, also across restarts, until it's disconnected through
+	// DisconnectPeer.  Otherwise, the call will be synchronous.
 	Perm bool `protobuf:"varint,2,opt,name=perm" json:"perm,omitempty"`
 }
 
 func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
 func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerRequest) ProtoMessage()               {}
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
 	if m != nil {
@@ -963,7 +1347,7 @@ type ConnectPeerResponse struct {
 func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
 func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerResponse) ProtoMessage()               {}
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 type DisconnectPeerRequest struct {
 	// / The pubkey of the node to disconnect from
@@ -973,7 +1357,7 @@ type DisconnectPeerRequest struct {
 func (m *DisconnectPeerRequest) Reset()                    { *m = DisconnectPeerRequest{} }
 func (m *DisconnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerRequest) ProtoMessage()               {}
-func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *DisconnectPeerRequest) GetPubKey() string {
 	if m != nil {
@@ -988,7 +1372,7 @@ type DisconnectPeerResponse struct {
 func (m *DisconnectPeerResponse) Reset()                    { *m = DisconnectPeerResponse{} }
 func (m *DisconnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerResponse) ProtoMessage()               {}
-func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 type HTLC struct {
 	Incoming         bool   `protobuf:"varint,1,opt,name=incoming" json:"incoming,omitempty"`
@@ -1000,7 +1384,7 @@ type HTLC struct {
 func (m *HTLC) Reset()                    { *m = HTLC{} }
 func (m *HTLC) String() string            { return proto.CompactTextString(m) }
 func (*HTLC) ProtoMessage()               {}
-func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *HTLC) GetIncoming() bool {
 	if m != nil {
@@ -1090,7 +1474,7 @@ type Channel struct {
 func (m *Channel) Reset()                    { *m = Channel{} }
 func (m *Channel) String() string            { return proto.CompactTextString(m) }
 func (*Channel) ProtoMessage()               {}
-func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *Channel) GetActive() bool {
 	if m != nil {
@@ -1221,7 +1605,7 @@ type ListChannelsRequest struct {
 func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
 func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsRequest) ProtoMessage()               {}
-func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *ListChannelsRequest) GetActiveOnly() bool {
 	if m != nil {
@@ -1259,7 +1643,7 @@ type ListChannelsResponse struct {
 func (m *ListChannelsResponse) Reset()                    { *m = ListChannelsResponse{} }
 func (m *ListChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsResponse) ProtoMessage()               {}
-func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *ListChannelsResponse) GetChannels() []*Channel {
 	if m != nil {
@@ -1268,118 +1652,429 @@ func (m *ListChannelsResponse) GetChannels() []*Channel {
 	return nil
 }
 
-type Peer struct {
-	// / The identity pubkey of the peer
-	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
-	// / Network address of the peer; eg `127.0.0.1:10011`
-	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
-	// / Bytes of data transmitted to this peer
-	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent" json:"bytes_sent,omitempty"`
-	// / Bytes of data transmitted from this peer
-	BytesRecv uint64 `protobuf:"varint,5,opt,name=bytes_recv" json:"bytes_recv,omitempty"`
-	// / Satoshis sent to this peer
-	SatSent int64 `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
-	// / Satoshis received from this peer
-	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
-	// / A channel is inbound if the counterparty initiated the channel
-	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
-	// / Ping time to this peer
-	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+type Resolution struct {
+	// / The type of output that was resolved
+	ResolutionType Resolution_ResolutionType `protobuf:"varint,1,opt,name=resolution_type,enum=lnrpc.Resolution_ResolutionType" json:"resolution_type,omitempty"`
+	// / How the output was resolved
+	Outcome Resolution_ResolutionOutcome `protobuf:"varint,2,opt,name=outcome,enum=lnrpc.Resolution_ResolutionOutcome" json:"outcome,omitempty"`
+	// / The outpoint (txid:index) on the commitment transaction that was resolved
+	Outpoint string `protobuf:"bytes,3,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The value of the resolved output
+	AmountSat int64 `protobuf:"varint,4,opt,name=amount_sat" json:"amount_sat,omitempty"`
+	// / The txid of the transaction that resolved the output, if any
+	SweepTxid string `protobuf:"bytes,5,opt,name=sweep_txid" json:"sweep_txid,omitempty"`
 }
 
-func (m *Peer) Reset()                    { *m = Peer{} }
-func (m *Peer) String() string            { return proto.CompactTextString(m) }
-func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (m *Resolution) Reset()                    { *m = Resolution{} }
+func (m *Resolution) String() string            { return proto.CompactTextString(m) }
+func (*Resolution) ProtoMessage()               {}
+func (*Resolution) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
-func (m *Peer) GetPubKey() string {
+func (m *Resolution) GetResolutionType() Resolution_ResolutionType {
 	if m != nil {
-		return m.PubKey
+		return m.ResolutionType
 	}
-	return ""
+	return Resolution_COMMIT
 }
 
-func (m *Peer) GetAddress() string {
+func (m *Resolution) GetOutcome() Resolution_ResolutionOutcome {
 	if m != nil {
-		return m.Address
+		return m.Outcome
 	}
-	return ""
+	return Resolution_CLAIMED
 }
 
-func (m *Peer) GetBytesSent() uint64 {
+func (m *Resolution) GetOutpoint() string {
 	if m != nil {
-		return m.BytesSent
+		return m.Outpoint
 	}
-	return 0
+	return ""
 }
 
-func (m *Peer) GetBytesRecv() uint64 {
+func (m *Resolution) GetAmountSat() int64 {
 	if m != nil {
-		return m.BytesRecv
+		return m.AmountSat
 	}
 	return 0
 }
 
-func (m *Peer) GetSatSent() int64 {
+func (m *Resolution) GetSweepTxid() string {
 	if m != nil {
-		return m.SatSent
+		return m.SweepTxid
 	}
-	return 0
+	return ""
 }
 
-func (m *Peer) GetSatRecv() int64 {
-	if m != nil {
-		return m.SatRecv
-	}
-	return 0
+type ChannelCloseSummary struct {
+	// / The outpoint (txid:index) of the funding transaction
+	ChannelPoint string `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / The unique channel ID for the channel
+	ChanId uint64 `protobuf:"varint,2,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The hash of the genesis block that this channel resides within
+	ChainHash string `protobuf:"bytes,3,opt,name=chain_hash" json:"chain_hash,omitempty"`
+	// / The txid of the transaction which ultimately closed this channel
+	ClosingTxHash string `protobuf:"bytes,4,opt,name=closing_tx_hash" json:"closing_tx_hash,omitempty"`
+	// / Public key of the remote peer that we formerly had a channel with
+	RemotePubkey string `protobuf:"bytes,5,opt,name=remote_pubkey" json:"remote_pubkey,omitempty"`
+	// / Total capacity of the channel
+	Capacity int64 `protobuf:"varint,6,opt,name=capacity" json:"capacity,omitempty"`
+	// / Height at which the funding transaction was spent
+	CloseHeight uint32 `protobuf:"varint,7,opt,name=close_height" json:"close_height,omitempty"`
+	// / Settled balance at the time of channel closure
+	SettledBalance int64 `protobuf:"varint,8,opt,name=settled_balance" json:"settled_balance,omitempty"`
+	// / The sum of all the time-locked outputs at the time of channel closure
+	TimeLockedBalance int64 `protobuf:"varint,9,opt,name=time_locked_balance" json:"time_locked_balance,omitempty"`
+	// / Details on how the channel was closed
+	CloseType ChannelCloseSummary_ClosureType `protobuf:"varint,10,opt,name=close_type,enum=lnrpc.ChannelCloseSummary_ClosureType" json:"close_type,omitempty"`
+	// *
+	// The on-chain resolution of each output of the commitment transaction that
+	// belonged to us, as recorded by the contract resolvers.
+	Resolutions []*Resolution `protobuf:"bytes,11,rep,name=resolutions" json:"resolutions,omitempty"`
 }
 
-func (m *Peer) GetInbound() bool {
+func (m *ChannelCloseSummary) Reset()                    { *m = ChannelCloseSummary{} }
+func (m *ChannelCloseSummary) String() string            { return proto.CompactTextString(m) }
+func (*ChannelCloseSummary) ProtoMessage()               {}
+func (*ChannelCloseSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ChannelCloseSummary) GetChannelPoint() string {
 	if m != nil {
-		return m.Inbound
+		return m.ChannelPoint
 	}
-	return false
+	return ""
 }
 
-func (m *Peer) GetPingTime() int64 {
+func (m *ChannelCloseSummary) GetChanId() uint64 {
 	if m != nil {
-		return m.PingTime
+		return m.ChanId
 	}
 	return 0
 }
 
-type ListPeersRequest struct {
+func (m *ChannelCloseSummary) GetChainHash() string {
+	if m != nil {
+		return m.ChainHash
+	}
+	return ""
 }
 
-func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
-func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
-
-type ListPeersResponse struct {
-	// / The list of currently connected peers
-	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+func (m *ChannelCloseSummary) GetClosingTxHash() string {
+	if m != nil {
+		return m.ClosingTxHash
+	}
+	return ""
 }
 
-func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
-func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
-
-func (m *ListPeersResponse) GetPeers() []*Peer {
+func (m *ChannelCloseSummary) GetRemotePubkey() string {
 	if m != nil {
-		return m.Peers
+		return m.RemotePubkey
 	}
-	return nil
+	return ""
 }
 
+func (m *ChannelCloseSummary) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetCloseHeight() uint32 {
+	if m != nil {
+		return m.CloseHeight
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetSettledBalance() int64 {
+	if m != nil {
+		return m.SettledBalance
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetTimeLockedBalance() int64 {
+	if m != nil {
+		return m.TimeLockedBalance
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetCloseType() ChannelCloseSummary_ClosureType {
+	if m != nil {
+		return m.CloseType
+	}
+	return ChannelCloseSummary_COOPERATIVE_CLOSE
+}
+
+func (m *ChannelCloseSummary) GetResolutions() []*Resolution {
+	if m != nil {
+		return m.Resolutions
+	}
+	return nil
+}
+
+type ClosedChannelsRequest struct {
+	Cooperative     bool `protobuf:"varint,1,opt,name=cooperative" json:"cooperative,omitempty"`
+	LocalForce      bool `protobuf:"varint,2,opt,name=local_force,json=localForce" json:"local_force,omitempty"`
+	RemoteForce     bool `protobuf:"varint,3,opt,name=remote_force,json=remoteForce" json:"remote_force,omitempty"`
+	Breach          bool `protobuf:"varint,4,opt,name=breach" json:"breach,omitempty"`
+	FundingCanceled bool `protobuf:"varint,5,opt,name=funding_canceled,json=fundingCanceled" json:"funding_canceled,omitempty"`
+}
+
+func (m *ClosedChannelsRequest) Reset()                    { *m = ClosedChannelsRequest{} }
+func (m *ClosedChannelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClosedChannelsRequest) ProtoMessage()               {}
+func (*ClosedChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ClosedChannelsRequest) GetCooperative() bool {
+	if m != nil {
+		return m.Cooperative
+	}
+	return false
+}
+
+func (m *ClosedChannelsRequest) GetLocalForce() bool {
+	if m != nil {
+		return m.LocalForce
+	}
+	return false
+}
+
+func (m *ClosedChannelsRequest) GetRemoteForce() bool {
+	if m != nil {
+		return m.RemoteForce
+	}
+	return false
+}
+
+func (m *ClosedChannelsRequest) GetBreach() bool {
+	if m != nil {
+		return m.Breach
+	}
+	return false
+}
+
+func (m *ClosedChannelsRequest) GetFundingCanceled() bool {
+	if m != nil {
+		return m.FundingCanceled
+	}
+	return false
+}
+
+type ClosedChannelsResponse struct {
+	Channels []*ChannelCloseSummary `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *ClosedChannelsResponse) Reset()                    { *m = ClosedChannelsResponse{} }
+func (m *ClosedChannelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ClosedChannelsResponse) ProtoMessage()               {}
+func (*ClosedChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ClosedChannelsResponse) GetChannels() []*ChannelCloseSummary {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type Peer struct {
+	// / The identity pubkey of the peer
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / Network address of the peer; eg `127.0.0.1:10011`
+	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	// / Bytes of data transmitted to this peer
+	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent" json:"bytes_sent,omitempty"`
+	// / Bytes of data transmitted from this peer
+	BytesRecv uint64 `protobuf:"varint,5,opt,name=bytes_recv" json:"bytes_recv,omitempty"`
+	// / Satoshis sent to this peer
+	SatSent int64 `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
+	// / Satoshis received from this peer
+	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
+	// / A channel is inbound if the counterparty initiated the channel
+	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
+	// / Ping time to this peer
+	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+}
+
+func (m *Peer) Reset()                    { *m = Peer{} }
+func (m *Peer) String() string            { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()               {}
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *Peer) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *Peer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Peer) GetBytesSent() uint64 {
+	if m != nil {
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *Peer) GetBytesRecv() uint64 {
+	if m != nil {
+		return m.BytesRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetSatSent() int64 {
+	if m != nil {
+		return m.SatSent
+	}
+	return 0
+}
+
+func (m *Peer) GetSatRecv() int64 {
+	if m != nil {
+		return m.SatRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetInbound() bool {
+	if m != nil {
+		return m.Inbound
+	}
+	return false
+}
+
+func (m *Peer) GetPingTime() int64 {
+	if m != nil {
+		return m.PingTime
+	}
+	return 0
+}
+
+type ListPeersRequest struct {
+}
+
+func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
+func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersRequest) ProtoMessage()               {}
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+type ListPeersResponse struct {
+	// / The list of currently connected peers
+	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()               {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ListPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type BannedPeer struct {
+	// / The identity pubkey of the banned peer
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / The unix timestamp at which the ban expires
+	BannedUntil int64 `protobuf:"varint,2,opt,name=banned_until" json:"banned_until,omitempty"`
+	// / The misbehavior that caused the peer to be banned
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *BannedPeer) Reset()                    { *m = BannedPeer{} }
+func (m *BannedPeer) String() string            { return proto.CompactTextString(m) }
+func (*BannedPeer) ProtoMessage()               {}
+func (*BannedPeer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *BannedPeer) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *BannedPeer) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
+func (m *BannedPeer) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ListBannedPeersRequest struct {
+}
+
+func (m *ListBannedPeersRequest) Reset()                    { *m = ListBannedPeersRequest{} }
+func (m *ListBannedPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListBannedPeersRequest) ProtoMessage()               {}
+func (*ListBannedPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+type ListBannedPeersResponse struct {
+	// / The list of currently banned peers
+	Peers []*BannedPeer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ListBannedPeersResponse) Reset()                    { *m = ListBannedPeersResponse{} }
+func (m *ListBannedPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListBannedPeersResponse) ProtoMessage()               {}
+func (*ListBannedPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ListBannedPeersResponse) GetPeers() []*BannedPeer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type UnbanPeerRequest struct {
+	// / The pubkey of the node to unban
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+}
+
+func (m *UnbanPeerRequest) Reset()                    { *m = UnbanPeerRequest{} }
+func (m *UnbanPeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnbanPeerRequest) ProtoMessage()               {}
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *UnbanPeerRequest) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+type UnbanPeerResponse struct {
+}
+
+func (m *UnbanPeerResponse) Reset()                    { *m = UnbanPeerResponse{} }
+func (m *UnbanPeerResponse) String() string            { return proto.CompactTextString(m) }
+func (*UnbanPeerResponse) ProtoMessage()               {}
+func (*UnbanPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
 type GetInfoRequest struct {
 }
 
 func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
 func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 type GetInfoResponse struct {
 	// / The identity pubkey of the current node.
@@ -1408,12 +2103,16 @@ type GetInfoResponse struct {
 	BestHeaderTimestamp int64 `protobuf:"varint,13,opt,name=best_header_timestamp" json:"best_header_timestamp,omitempty"`
 	// / The version of the LND software that the node is running.
 	Version string `protobuf:"bytes,14,opt,name=version" json:"version,omitempty"`
+	// / Whether the node is running as part of an active/passive cluster.
+	ClusterEnabled bool `protobuf:"varint,15,opt,name=cluster_enabled" json:"cluster_enabled,omitempty"`
+	// / The ID of the node within its cluster, if clustering is enabled.
+	ClusterId string `protobuf:"bytes,16,opt,name=cluster_id" json:"cluster_id,omitempty"`
 }
 
 func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
 func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 func (m *GetInfoResponse) GetIdentityPubkey() string {
 	if m != nil {
@@ -1506,6 +2205,92 @@ func (m *GetInfoResponse) GetVersion() string {
 	return ""
 }
 
+func (m *GetInfoResponse) GetClusterEnabled() bool {
+	if m != nil {
+		return m.ClusterEnabled
+	}
+	return false
+}
+
+func (m *GetInfoResponse) GetClusterId() string {
+	if m != nil {
+		return m.ClusterId
+	}
+	return ""
+}
+
+type UpdateNodeAnnouncementRequest struct {
+	// / The new alias of the node. If empty, the alias is left unchanged.
+	Alias string `protobuf:"bytes,1,opt,name=alias" json:"alias,omitempty"`
+	// / The new color of the node, in the format `#RRGGBB`. If empty, the color is left unchanged.
+	Color string `protobuf:"bytes,2,opt,name=color" json:"color,omitempty"`
+	// / The new set of addresses to advertise, in the format `host:port`.
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses" json:"addresses,omitempty"`
+	// / If set, the advertised addresses are replaced by the ones above, even if none are given.
+	UpdateAddresses bool `protobuf:"varint,4,opt,name=update_addresses" json:"update_addresses,omitempty"`
+	// / The optional (odd) feature bits to start advertising.
+	SetFeatures []uint32 `protobuf:"varint,5,rep,packed,name=set_features" json:"set_features,omitempty"`
+	// / The optional (odd) feature bits to stop advertising.
+	UnsetFeatures []uint32 `protobuf:"varint,6,rep,packed,name=unset_features" json:"unset_features,omitempty"`
+}
+
+func (m *UpdateNodeAnnouncementRequest) Reset()                    { *m = UpdateNodeAnnouncementRequest{} }
+func (m *UpdateNodeAnnouncementRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeAnnouncementRequest) ProtoMessage()               {}
+func (*UpdateNodeAnnouncementRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *UpdateNodeAnnouncementRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetUpdateAddresses() bool {
+	if m != nil {
+		return m.UpdateAddresses
+	}
+	return false
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetSetFeatures() []uint32 {
+	if m != nil {
+		return m.SetFeatures
+	}
+	return nil
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetUnsetFeatures() []uint32 {
+	if m != nil {
+		return m.UnsetFeatures
+	}
+	return nil
+}
+
+type UpdateNodeAnnouncementResponse struct {
+}
+
+func (m *UpdateNodeAnnouncementResponse) Reset()         { *m = UpdateNodeAnnouncementResponse{} }
+func (m *UpdateNodeAnnouncementResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeAnnouncementResponse) ProtoMessage()    {}
+func (*UpdateNodeAnnouncementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{54}
+}
+
 type ConfirmationUpdate struct {
 	BlockSha     []byte `protobuf:"bytes,1,opt,name=block_sha,json=blockSha,proto3" json:"block_sha,omitempty"`
 	BlockHeight  int32  `protobuf:"varint,2,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
@@ -1515,7 +2300,7 @@ type ConfirmationUpdate struct {
 func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
 func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 func (m *ConfirmationUpdate) GetBlockSha() []byte {
 	if m != nil {
@@ -1545,7 +2330,7 @@ type ChannelOpenUpdate struct {
 func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
 func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1562,7 +2347,7 @@ type ChannelCloseUpdate struct {
 func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
 func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
 
 func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
 	if m != nil {
@@ -1595,7 +2380,7 @@ type CloseChannelRequest struct {
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
 func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1636,7 +2421,7 @@ type CloseStatusUpdate struct {
 func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
 func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 type isCloseStatusUpdate_Update interface {
 	isCloseStatusUpdate_Update()
@@ -1785,7 +2570,7 @@ type PendingUpdate struct {
 func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
 func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
 func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
 func (m *PendingUpdate) GetTxid() []byte {
 	if m != nil {
@@ -1820,12 +2605,18 @@ type OpenChannelRequest struct {
 	MinHtlcMsat int64 `protobuf:"varint,9,opt,name=min_htlc_msat" json:"min_htlc_msat,omitempty"`
 	// / The delay we require on the remote's commitment transaction. If this is not set, it will be scaled automatically with the channel size.
 	RemoteCsvDelay uint32 `protobuf:"varint,10,opt,name=remote_csv_delay" json:"remote_csv_delay,omitempty"`
+	// *
+	// An address that our funds must be paid out to upon a cooperative close of
+	// the channel. Committing to this address protects our funds even if our
+	// node's keys are later compromised. This requires the remote peer to
+	// support upfront shutdown scripts.
+	CloseAddress string `protobuf:"bytes,11,opt,name=close_address" json:"close_address,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
 func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
 
 func (m *OpenChannelRequest) GetNodePubkey() []byte {
 	if m != nil {
@@ -1890,6 +2681,127 @@ func (m *OpenChannelRequest) GetRemoteCsvDelay() uint32 {
 	return 0
 }
 
+func (m *OpenChannelRequest) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
+	}
+	return ""
+}
+
+type BatchOpenChannel struct {
+	// / The pubkey of the node to open a channel with
+	NodePubkey []byte `protobuf:"bytes,1,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
+	// / The number of satoshis the wallet should commit to the channel
+	LocalFundingAmount int64 `protobuf:"varint,2,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
+	// / The number of satoshis to push to the remote side as part of the initial commitment state
+	PushSat int64 `protobuf:"varint,3,opt,name=push_sat" json:"push_sat,omitempty"`
+	// / Whether this channel should be private, not announced to the greater network.
+	Private bool `protobuf:"varint,4,opt,name=private" json:"private,omitempty"`
+	// / The minimum value in millisatoshi we will require for incoming HTLCs on the channel.
+	MinHtlcMsat int64 `protobuf:"varint,5,opt,name=min_htlc_msat" json:"min_htlc_msat,omitempty"`
+	// / The delay we require on the remote's commitment transaction. If this is not set, it will be scaled automatically with the channel size.
+	RemoteCsvDelay uint32 `protobuf:"varint,6,opt,name=remote_csv_delay" json:"remote_csv_delay,omitempty"`
+}
+
+func (m *BatchOpenChannel) Reset()                    { *m = BatchOpenChannel{} }
+func (m *BatchOpenChannel) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannel) ProtoMessage()               {}
+func (*BatchOpenChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *BatchOpenChannel) GetNodePubkey() []byte {
+	if m != nil {
+		return m.NodePubkey
+	}
+	return nil
+}
+
+func (m *BatchOpenChannel) GetLocalFundingAmount() int64 {
+	if m != nil {
+		return m.LocalFundingAmount
+	}
+	return 0
+}
+
+func (m *BatchOpenChannel) GetPushSat() int64 {
+	if m != nil {
+		return m.PushSat
+	}
+	return 0
+}
+
+func (m *BatchOpenChannel) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *BatchOpenChannel) GetMinHtlcMsat() int64 {
+	if m != nil {
+		return m.MinHtlcMsat
+	}
+	return 0
+}
+
+func (m *BatchOpenChannel) GetRemoteCsvDelay() uint32 {
+	if m != nil {
+		return m.RemoteCsvDelay
+	}
+	return 0
+}
+
+type BatchOpenChannelRequest struct {
+	// / The channels to open within the funding transaction.
+	Channels []*BatchOpenChannel `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+	// / The target number of blocks that the funding transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the funding transaction.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *BatchOpenChannelRequest) Reset()                    { *m = BatchOpenChannelRequest{} }
+func (m *BatchOpenChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannelRequest) ProtoMessage()               {}
+func (*BatchOpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *BatchOpenChannelRequest) GetChannels() []*BatchOpenChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *BatchOpenChannelRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *BatchOpenChannelRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type BatchOpenChannelResponse struct {
+	// / The funding outpoint of each channel, in the order they were requested.
+	PendingChannels []*PendingUpdate `protobuf:"bytes,1,rep,name=pending_channels" json:"pending_channels,omitempty"`
+}
+
+func (m *BatchOpenChannelResponse) Reset()                    { *m = BatchOpenChannelResponse{} }
+func (m *BatchOpenChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannelResponse) ProtoMessage()               {}
+func (*BatchOpenChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *BatchOpenChannelResponse) GetPendingChannels() []*PendingUpdate {
+	if m != nil {
+		return m.PendingChannels
+	}
+	return nil
+}
+
 type OpenStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*OpenStatusUpdate_ChanPending
@@ -1901,7 +2813,7 @@ type OpenStatusUpdate struct {
 func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
 func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
 
 type isOpenStatusUpdate_Update interface {
 	isOpenStatusUpdate_Update()
@@ -2063,7 +2975,7 @@ type PendingHTLC struct {
 func (m *PendingHTLC) Reset()                    { *m = PendingHTLC{} }
 func (m *PendingHTLC) String() string            { return proto.CompactTextString(m) }
 func (*PendingHTLC) ProtoMessage()               {}
-func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
 
 func (m *PendingHTLC) GetIncoming() bool {
 	if m != nil {
@@ -2113,7 +3025,7 @@ type PendingChannelsRequest struct {
 func (m *PendingChannelsRequest) Reset()                    { *m = PendingChannelsRequest{} }
 func (m *PendingChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelsRequest) ProtoMessage()               {}
-func (*PendingChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*PendingChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
 
 type PendingChannelsResponse struct {
 	// / The balance in satoshis encumbered in pending channels
@@ -2131,7 +3043,7 @@ type PendingChannelsResponse struct {
 func (m *PendingChannelsResponse) Reset()                    { *m = PendingChannelsResponse{} }
 func (m *PendingChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse) ProtoMessage()               {}
-func (*PendingChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*PendingChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
 
 func (m *PendingChannelsResponse) GetTotalLimboBalance() int64 {
 	if m != nil {
@@ -2182,7 +3094,7 @@ func (m *PendingChannelsResponse_PendingChannel) Reset() {
 func (m *PendingChannelsResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse_PendingChannel) ProtoMessage()    {}
 func (*PendingChannelsResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 0}
+	return fileDescriptor0, []int{68, 0}
 }
 
 func (m *PendingChannelsResponse_PendingChannel) GetRemoteNodePub() string {
@@ -2249,7 +3161,7 @@ func (m *PendingChannelsResponse_PendingOpenChannel) String() string {
 }
 func (*PendingChannelsResponse_PendingOpenChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_PendingOpenChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 1}
+	return fileDescriptor0, []int{68, 1}
 }
 
 func (m *PendingChannelsResponse_PendingOpenChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2302,7 +3214,7 @@ func (m *PendingChannelsResponse_WaitingCloseChannel) String() string {
 }
 func (*PendingChannelsResponse_WaitingCloseChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_WaitingCloseChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 2}
+	return fileDescriptor0, []int{68, 2}
 }
 
 func (m *PendingChannelsResponse_WaitingCloseChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2330,7 +3242,7 @@ func (m *PendingChannelsResponse_ClosedChannel) Reset()         { *m = PendingCh
 func (m *PendingChannelsResponse_ClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse_ClosedChannel) ProtoMessage()    {}
 func (*PendingChannelsResponse_ClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 3}
+	return fileDescriptor0, []int{68, 3}
 }
 
 func (m *PendingChannelsResponse_ClosedChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2374,7 +3286,7 @@ func (m *PendingChannelsResponse_ForceClosedChannel) String() string {
 }
 func (*PendingChannelsResponse_ForceClosedChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_ForceClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 4}
+	return fileDescriptor0, []int{68, 4}
 }
 
 func (m *PendingChannelsResponse_ForceClosedChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2432,7 +3344,7 @@ type WalletBalanceRequest struct {
 func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
 func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
 
 type WalletBalanceResponse struct {
 	// / The balance of the wallet
@@ -2446,7 +3358,7 @@ type WalletBalanceResponse struct {
 func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 func (m *WalletBalanceResponse) GetTotalBalance() int64 {
 	if m != nil {
@@ -2475,7 +3387,7 @@ type ChannelBalanceRequest struct {
 func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
 func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 type ChannelBalanceResponse struct {
 	// / Sum of channels balances denominated in satoshis
@@ -2487,7 +3399,7 @@ type ChannelBalanceResponse struct {
 func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
 func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *ChannelBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -2503,6 +3415,34 @@ func (m *ChannelBalanceResponse) GetPendingOpenBalance() int64 {
 	return 0
 }
 
+type DirectedEdge struct {
+	// / The unique identifier of the channel.
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// *
+	// The hex-encoded public key of the node at the start of the channel in the
+	// direction the channel is traversed.
+	FromNode string `protobuf:"bytes,2,opt,name=from_node" json:"from_node,omitempty"`
+}
+
+func (m *DirectedEdge) Reset()                    { *m = DirectedEdge{} }
+func (m *DirectedEdge) String() string            { return proto.CompactTextString(m) }
+func (*DirectedEdge) ProtoMessage()               {}
+func (*DirectedEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *DirectedEdge) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *DirectedEdge) GetFromNode() string {
+	if m != nil {
+		return m.FromNode
+	}
+	return ""
+}
+
 type QueryRoutesRequest struct {
 	// / The 33-byte hex-encoded public key for the payment destination
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
@@ -2510,12 +3450,30 @@ type QueryRoutesRequest struct {
 	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
 	// / The max number of routes to return.
 	NumRoutes int32 `protobuf:"varint,3,opt,name=num_routes,json=numRoutes" json:"num_routes,omitempty"`
+	// *
+	// The CLTV delta from the current height that should be used to set the
+	// timelock for the final hop. If zero, the default delta is used.
+	FinalCltvDelta int32 `protobuf:"varint,4,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	// / The hex-encoded public keys of the nodes the routes must not pass through.
+	IgnoredNodes []string `protobuf:"bytes,5,rep,name=ignored_nodes,json=ignoredNodes" json:"ignored_nodes,omitempty"`
+	// / The directed edges the routes must not traverse.
+	IgnoredEdges []*DirectedEdge `protobuf:"bytes,6,rep,name=ignored_edges,json=ignoredEdges" json:"ignored_edges,omitempty"`
+	// *
+	// The hex-encoded public key of the node the routes should start from. If
+	// not set, the routes start from our own node.
+	SourcePubKey string `protobuf:"bytes,7,opt,name=source_pub_key,json=sourcePubKey" json:"source_pub_key,omitempty"`
+	// *
+	// The maximum number of blocks the total time lock of a route may exceed the
+	// current height by. If zero, the total time lock isn't limited.
+	CltvLimit uint32 `protobuf:"varint,8,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
+	// / Optional route hints to reach the destination through private channels.
+	RouteHints []*RouteHint `protobuf:"bytes,9,rep,name=route_hints,json=routeHints" json:"route_hints,omitempty"`
 }
 
 func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
 func (m *QueryRoutesRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesRequest) ProtoMessage()               {}
-func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *QueryRoutesRequest) GetPubKey() string {
 	if m != nil {
@@ -2538,6 +3496,48 @@ func (m *QueryRoutesRequest) GetNumRoutes() int32 {
 	return 0
 }
 
+func (m *QueryRoutesRequest) GetFinalCltvDelta() int32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetIgnoredNodes() []string {
+	if m != nil {
+		return m.IgnoredNodes
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetIgnoredEdges() []*DirectedEdge {
+	if m != nil {
+		return m.IgnoredEdges
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetSourcePubKey() string {
+	if m != nil {
+		return m.SourcePubKey
+	}
+	return ""
+}
+
+func (m *QueryRoutesRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetRouteHints() []*RouteHint {
+	if m != nil {
+		return m.RouteHints
+	}
+	return nil
+}
+
 type QueryRoutesResponse struct {
 	Routes []*Route `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
 }
@@ -2545,7 +3545,7 @@ type QueryRoutesResponse struct {
 func (m *QueryRoutesResponse) Reset()                    { *m = QueryRoutesResponse{} }
 func (m *QueryRoutesResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesResponse) ProtoMessage()               {}
-func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *QueryRoutesResponse) GetRoutes() []*Route {
 	if m != nil {
@@ -2566,12 +3566,16 @@ type Hop struct {
 	Expiry           uint32 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
 	AmtToForwardMsat int64  `protobuf:"varint,6,opt,name=amt_to_forward_msat" json:"amt_to_forward_msat,omitempty"`
 	FeeMsat          int64  `protobuf:"varint,7,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// *
+	// The hex-encoded public key of the node at the end of this hop. If not set
+	// when passing a route to SendToRoute, it's derived from the channel.
+	PubKey string `protobuf:"bytes,8,opt,name=pub_key" json:"pub_key,omitempty"`
 }
 
 func (m *Hop) Reset()                    { *m = Hop{} }
 func (m *Hop) String() string            { return proto.CompactTextString(m) }
 func (*Hop) ProtoMessage()               {}
-func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *Hop) GetChanId() uint64 {
 	if m != nil {
@@ -2612,95 +3616,249 @@ func (m *Hop) GetAmtToForwardMsat() int64 {
 	if m != nil {
 		return m.AmtToForwardMsat
 	}
-	return 0
+	return 0
+}
+
+func (m *Hop) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+// *
+// A path through the channel graph which runs over one or more channels in
+// succession. This struct carries all the information required to craft the
+// Sphinx onion packet, and send the payment along the first hop in the path. A
+// route is only selected as valid if all the channels have sufficient capacity to
+// carry the initial payment amount after fees are accounted for.
+type Route struct {
+	// *
+	// The cumulative (final) time lock across the entire route.  This is the CLTV
+	// value that should be extended to the first hop in the route. All other hops
+	// will decrement the time-lock as advertised, leaving enough time for all
+	// hops to wait for or present the payment preimage to complete the payment.
+	TotalTimeLock uint32 `protobuf:"varint,1,opt,name=total_time_lock" json:"total_time_lock,omitempty"`
+	// *
+	// The sum of the fees paid at each hop within the final route.  In the case
+	// of a one-hop payment, this value will be zero as we don't need to pay a fee
+	// it ourself.
+	TotalFees int64 `protobuf:"varint,2,opt,name=total_fees" json:"total_fees,omitempty"`
+	// *
+	// The total amount of funds required to complete a payment over this route.
+	// This value includes the cumulative fees at each hop. As a result, the HTLC
+	// extended to the first-hop in the route will need to have at least this many
+	// satoshis, otherwise the route will fail at an intermediate node due to an
+	// insufficient amount of fees.
+	TotalAmt int64 `protobuf:"varint,3,opt,name=total_amt" json:"total_amt,omitempty"`
+	// *
+	// Contains details concerning the specific forwarding details at each hop.
+	Hops []*Hop `protobuf:"bytes,4,rep,name=hops" json:"hops,omitempty"`
+	// *
+	// The total fees in millisatoshis.
+	TotalFeesMsat int64 `protobuf:"varint,5,opt,name=total_fees_msat" json:"total_fees_msat,omitempty"`
+	// *
+	// The total amount in millisatoshis.
+	TotalAmtMsat int64 `protobuf:"varint,6,opt,name=total_amt_msat" json:"total_amt_msat,omitempty"`
+}
+
+func (m *Route) Reset()                    { *m = Route{} }
+func (m *Route) String() string            { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()               {}
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+
+func (m *Route) GetTotalTimeLock() uint32 {
+	if m != nil {
+		return m.TotalTimeLock
+	}
+	return 0
+}
+
+func (m *Route) GetTotalFees() int64 {
+	if m != nil {
+		return m.TotalFees
+	}
+	return 0
+}
+
+func (m *Route) GetTotalAmt() int64 {
+	if m != nil {
+		return m.TotalAmt
+	}
+	return 0
+}
+
+func (m *Route) GetHops() []*Hop {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+func (m *Route) GetTotalFeesMsat() int64 {
+	if m != nil {
+		return m.TotalFeesMsat
+	}
+	return 0
+}
+
+func (m *Route) GetTotalAmtMsat() int64 {
+	if m != nil {
+		return m.TotalAmtMsat
+	}
+	return 0
+}
+
+type BuildRouteRequest struct {
+	// / The amount to send to the final hop in millisatoshis.
+	AmtMsat int64 `protobuf:"varint,1,opt,name=amt_msat" json:"amt_msat,omitempty"`
+	// *
+	// The CLTV delta from the current height that should be used to set the
+	// timelock for the final hop. If zero, the default delta is used.
+	FinalCltvDelta int32 `protobuf:"varint,2,opt,name=final_cltv_delta" json:"final_cltv_delta,omitempty"`
+	// / The hex-encoded public keys of the nodes to route through, in order.
+	HopPubkeys []string `protobuf:"bytes,3,rep,name=hop_pubkeys" json:"hop_pubkeys,omitempty"`
+	// *
+	// An optional list of the channels to use to reach each of the hops. If
+	// set, there must be exactly one per hop. Otherwise, the cheapest channel
+	// able to carry the payment is used.
+	ChanIds []uint64 `protobuf:"varint,4,rep,packed,name=chan_ids" json:"chan_ids,omitempty"`
+}
+
+func (m *BuildRouteRequest) Reset()                    { *m = BuildRouteRequest{} }
+func (m *BuildRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*BuildRouteRequest) ProtoMessage()               {}
+func (*BuildRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+func (m *BuildRouteRequest) GetAmtMsat() int64 {
+	if m != nil {
+		return m.AmtMsat
+	}
+	return 0
+}
+
+func (m *BuildRouteRequest) GetFinalCltvDelta() int32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *BuildRouteRequest) GetHopPubkeys() []string {
+	if m != nil {
+		return m.HopPubkeys
+	}
+	return nil
+}
+
+func (m *BuildRouteRequest) GetChanIds() []uint64 {
+	if m != nil {
+		return m.ChanIds
+	}
+	return nil
 }
 
-func (m *Hop) GetFeeMsat() int64 {
+type BuildRouteResponse struct {
+	// / The constructed route.
+	Route *Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+}
+
+func (m *BuildRouteResponse) Reset()                    { *m = BuildRouteResponse{} }
+func (m *BuildRouteResponse) String() string            { return proto.CompactTextString(m) }
+func (*BuildRouteResponse) ProtoMessage()               {}
+func (*BuildRouteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+func (m *BuildRouteResponse) GetRoute() *Route {
 	if m != nil {
-		return m.FeeMsat
+		return m.Route
 	}
-	return 0
+	return nil
 }
 
-// *
-// A path through the channel graph which runs over one or more channels in
-// succession. This struct carries all the information required to craft the
-// Sphinx onion packet, and send the payment along the first hop in the path. A
-// route is only selected as valid if all the channels have sufficient capacity to
-// carry the initial payment amount after fees are accounted for.
-type Route struct {
-	// *
-	// The cumulative (final) time lock across the entire route.  This is the CLTV
-	// value that should be extended to the first hop in the route. All other hops
-	// will decrement the time-lock as advertised, leaving enough time for all
-	// hops to wait for or present the payment preimage to complete the payment.
-	TotalTimeLock uint32 `protobuf:"varint,1,opt,name=total_time_lock" json:"total_time_lock,omitempty"`
-	// *
-	// The sum of the fees paid at each hop within the final route.  In the case
-	// of a one-hop payment, this value will be zero as we don't need to pay a fee
-	// it ourself.
-	TotalFees int64 `protobuf:"varint,2,opt,name=total_fees" json:"total_fees,omitempty"`
-	// *
-	// The total amount of funds required to complete a payment over this route.
-	// This value includes the cumulative fees at each hop. As a result, the HTLC
-	// extended to the first-hop in the route will need to have at least this many
-	// satoshis, otherwise the route will fail at an intermediate node due to an
-	// insufficient amount of fees.
-	TotalAmt int64 `protobuf:"varint,3,opt,name=total_amt" json:"total_amt,omitempty"`
-	// *
-	// Contains details concerning the specific forwarding details at each hop.
-	Hops []*Hop `protobuf:"bytes,4,rep,name=hops" json:"hops,omitempty"`
-	// *
-	// The total fees in millisatoshis.
-	TotalFeesMsat int64 `protobuf:"varint,5,opt,name=total_fees_msat" json:"total_fees_msat,omitempty"`
+type EstimateRouteFeeRequest struct {
+	// / The 33-byte hex-encoded public key for the payment destination
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
+	// / The amount to send expressed in satoshis
+	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	// / The max number of routes to probe, if zero, 10 routes are probed
+	NumRoutes int32 `protobuf:"varint,3,opt,name=num_routes,json=numRoutes" json:"num_routes,omitempty"`
 	// *
-	// The total amount in millisatoshis.
-	TotalAmtMsat int64 `protobuf:"varint,6,opt,name=total_amt_msat" json:"total_amt_msat,omitempty"`
+	// The CLTV delta from the current height that should be used to set the
+	// timelock for the final hop. If zero, the default delta is used.
+	FinalCltvDelta int32 `protobuf:"varint,4,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
 }
 
-func (m *Route) Reset()                    { *m = Route{} }
-func (m *Route) String() string            { return proto.CompactTextString(m) }
-func (*Route) ProtoMessage()               {}
-func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (m *EstimateRouteFeeRequest) Reset()                    { *m = EstimateRouteFeeRequest{} }
+func (m *EstimateRouteFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*EstimateRouteFeeRequest) ProtoMessage()               {}
+func (*EstimateRouteFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
-func (m *Route) GetTotalTimeLock() uint32 {
+func (m *EstimateRouteFeeRequest) GetPubKey() string {
 	if m != nil {
-		return m.TotalTimeLock
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *EstimateRouteFeeRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
 	}
 	return 0
 }
 
-func (m *Route) GetTotalFees() int64 {
+func (m *EstimateRouteFeeRequest) GetNumRoutes() int32 {
 	if m != nil {
-		return m.TotalFees
+		return m.NumRoutes
 	}
 	return 0
 }
 
-func (m *Route) GetTotalAmt() int64 {
+func (m *EstimateRouteFeeRequest) GetFinalCltvDelta() int32 {
 	if m != nil {
-		return m.TotalAmt
+		return m.FinalCltvDelta
 	}
 	return 0
 }
 
-func (m *Route) GetHops() []*Hop {
+type EstimateRouteFeeResponse struct {
+	// / The cheapest route that was able to carry the payment.
+	Route *Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+	// / The total fees of the route in millisatoshis.
+	FeeMsat int64 `protobuf:"varint,2,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The absolute time lock of the HTLC extended to the first hop.
+	TimeLock uint32 `protobuf:"varint,3,opt,name=time_lock" json:"time_lock,omitempty"`
+}
+
+func (m *EstimateRouteFeeResponse) Reset()                    { *m = EstimateRouteFeeResponse{} }
+func (m *EstimateRouteFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*EstimateRouteFeeResponse) ProtoMessage()               {}
+func (*EstimateRouteFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+
+func (m *EstimateRouteFeeResponse) GetRoute() *Route {
 	if m != nil {
-		return m.Hops
+		return m.Route
 	}
 	return nil
 }
 
-func (m *Route) GetTotalFeesMsat() int64 {
+func (m *EstimateRouteFeeResponse) GetFeeMsat() int64 {
 	if m != nil {
-		return m.TotalFeesMsat
+		return m.FeeMsat
 	}
 	return 0
 }
 
-func (m *Route) GetTotalAmtMsat() int64 {
+func (m *EstimateRouteFeeResponse) GetTimeLock() uint32 {
 	if m != nil {
-		return m.TotalAmtMsat
+		return m.TimeLock
 	}
 	return 0
 }
@@ -2713,7 +3871,7 @@ type NodeInfoRequest struct {
 func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
 func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfoRequest) ProtoMessage()               {}
-func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
 
 func (m *NodeInfoRequest) GetPubKey() string {
 	if m != nil {
@@ -2736,7 +3894,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
 func (m *NodeInfo) GetNode() *LightningNode {
 	if m != nil {
@@ -2775,7 +3933,7 @@ type LightningNode struct {
 func (m *LightningNode) Reset()                    { *m = LightningNode{} }
 func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
 func (*LightningNode) ProtoMessage()               {}
-func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
 
 func (m *LightningNode) GetLastUpdate() uint32 {
 	if m != nil {
@@ -2820,7 +3978,7 @@ type NodeAddress struct {
 func (m *NodeAddress) Reset()                    { *m = NodeAddress{} }
 func (m *NodeAddress) String() string            { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()               {}
-func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
 func (m *NodeAddress) GetNetwork() string {
 	if m != nil {
@@ -2841,12 +3999,14 @@ type RoutingPolicy struct {
 	MinHtlc          int64  `protobuf:"varint,2,opt,name=min_htlc" json:"min_htlc,omitempty"`
 	FeeBaseMsat      int64  `protobuf:"varint,3,opt,name=fee_base_msat" json:"fee_base_msat,omitempty"`
 	FeeRateMilliMsat int64  `protobuf:"varint,4,opt,name=fee_rate_milli_msat" json:"fee_rate_milli_msat,omitempty"`
+	Disabled         bool   `protobuf:"varint,5,opt,name=disabled" json:"disabled,omitempty"`
+	MaxHtlcMsat      uint64 `protobuf:"varint,6,opt,name=max_htlc_msat" json:"max_htlc_msat,omitempty"`
 }
 
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
 func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
 func (*RoutingPolicy) ProtoMessage()               {}
-func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
 
 func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
 	if m != nil {
@@ -2876,6 +4036,20 @@ func (m *RoutingPolicy) GetFeeRateMilliMsat() int64 {
 	return 0
 }
 
+func (m *RoutingPolicy) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
+func (m *RoutingPolicy) GetMaxHtlcMsat() uint64 {
+	if m != nil {
+		return m.MaxHtlcMsat
+	}
+	return 0
+}
+
 // *
 // A fully authenticated channel along with all its unique attributes.
 // Once an authenticated channel announcement has been processed on the network,
@@ -2900,7 +4074,7 @@ type ChannelEdge struct {
 func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
 func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdge) ProtoMessage()               {}
-func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
 
 func (m *ChannelEdge) GetChannelId() uint64 {
 	if m != nil {
@@ -2964,7 +4138,124 @@ type ChannelGraphRequest struct {
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
 func (m *ChannelGraphRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraphRequest) ProtoMessage()               {}
-func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+type ExportGraphRequest struct {
+	// / The format of the export, one of: dot, graphml, json.
+	Format string `protobuf:"bytes,1,opt,name=format" json:"format,omitempty"`
+	// *
+	// The hex encoded public keys of the nodes to restrict the export to. If
+	// empty, all nodes are exported.
+	Nodes []string `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	// / If set, only the nodes at most this many hops away from us are exported.
+	Hops uint32 `protobuf:"varint,3,opt,name=hops" json:"hops,omitempty"`
+	// / The minimum capacity in satoshis of the exported channels.
+	MinCapacity int64 `protobuf:"varint,4,opt,name=min_capacity" json:"min_capacity,omitempty"`
+	// / The minimum age in blocks of the exported channels.
+	MinAge uint32 `protobuf:"varint,5,opt,name=min_age" json:"min_age,omitempty"`
+	// / The maximum age in blocks of the exported channels.
+	MaxAge uint32 `protobuf:"varint,6,opt,name=max_age" json:"max_age,omitempty"`
+	// / The maximum base fee in millisatoshis of the exported channels.
+	MaxBaseFeeMsat int64 `protobuf:"varint,7,opt,name=max_base_fee_msat" json:"max_base_fee_msat,omitempty"`
+	// / The maximum fee rate in millionths of the exported channels.
+	MaxFeeRate int64 `protobuf:"varint,8,opt,name=max_fee_rate" json:"max_fee_rate,omitempty"`
+	// / The maximum time lock delta of the exported channels.
+	MaxTimeLockDelta uint32 `protobuf:"varint,9,opt,name=max_time_lock_delta" json:"max_time_lock_delta,omitempty"`
+	// / If set, channels disabled in both directions aren't exported.
+	ExcludeDisabled bool `protobuf:"varint,10,opt,name=exclude_disabled" json:"exclude_disabled,omitempty"`
+}
+
+func (m *ExportGraphRequest) Reset()                    { *m = ExportGraphRequest{} }
+func (m *ExportGraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportGraphRequest) ProtoMessage()               {}
+func (*ExportGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+
+func (m *ExportGraphRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *ExportGraphRequest) GetNodes() []string {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ExportGraphRequest) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetMinCapacity() int64 {
+	if m != nil {
+		return m.MinCapacity
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetMinAge() uint32 {
+	if m != nil {
+		return m.MinAge
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetMaxAge() uint32 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetMaxBaseFeeMsat() int64 {
+	if m != nil {
+		return m.MaxBaseFeeMsat
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetMaxFeeRate() int64 {
+	if m != nil {
+		return m.MaxFeeRate
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetMaxTimeLockDelta() uint32 {
+	if m != nil {
+		return m.MaxTimeLockDelta
+	}
+	return 0
+}
+
+func (m *ExportGraphRequest) GetExcludeDisabled() bool {
+	if m != nil {
+		return m.ExcludeDisabled
+	}
+	return false
+}
+
+type ExportGraphChunk struct {
+	// / The next chunk of the encoded graph.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *ExportGraphChunk) Reset()                    { *m = ExportGraphChunk{} }
+func (m *ExportGraphChunk) String() string            { return proto.CompactTextString(m) }
+func (*ExportGraphChunk) ProtoMessage()               {}
+func (*ExportGraphChunk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+func (m *ExportGraphChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
 
 // / Returns a new instance of the directed channel graph.
 type ChannelGraph struct {
@@ -2977,7 +4268,7 @@ type ChannelGraph struct {
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
 func (m *ChannelGraph) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraph) ProtoMessage()               {}
-func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
 
 func (m *ChannelGraph) GetNodes() []*LightningNode {
 	if m != nil {
@@ -3004,7 +4295,7 @@ type ChanInfoRequest struct {
 func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
 func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChanInfoRequest) ProtoMessage()               {}
-func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
 
 func (m *ChanInfoRequest) GetChanId() uint64 {
 	if m != nil {
@@ -3019,7 +4310,7 @@ type NetworkInfoRequest struct {
 func (m *NetworkInfoRequest) Reset()                    { *m = NetworkInfoRequest{} }
 func (m *NetworkInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfoRequest) ProtoMessage()               {}
-func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
 
 type NetworkInfo struct {
 	GraphDiameter        uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
@@ -3036,7 +4327,7 @@ type NetworkInfo struct {
 func (m *NetworkInfo) Reset()                    { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()               {}
-func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
 
 func (m *NetworkInfo) GetGraphDiameter() uint32 {
 	if m != nil {
@@ -3107,7 +4398,7 @@ type StopRequest struct {
 func (m *StopRequest) Reset()                    { *m = StopRequest{} }
 func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()               {}
-func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
 
 type StopResponse struct {
 }
@@ -3115,7 +4406,7 @@ type StopResponse struct {
 func (m *StopResponse) Reset()                    { *m = StopResponse{} }
 func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
 func (*StopResponse) ProtoMessage()               {}
-func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
 
 type GraphTopologySubscription struct {
 }
@@ -3123,7 +4414,7 @@ type GraphTopologySubscription struct {
 func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
 func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologySubscription) ProtoMessage()               {}
-func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
 
 type GraphTopologyUpdate struct {
 	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates,json=nodeUpdates" json:"node_updates,omitempty"`
@@ -3134,7 +4425,7 @@ type GraphTopologyUpdate struct {
 func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
 func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologyUpdate) ProtoMessage()               {}
-func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
 
 func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
 	if m != nil {
@@ -3167,7 +4458,7 @@ type NodeUpdate struct {
 func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
 func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*NodeUpdate) ProtoMessage()               {}
-func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
 
 func (m *NodeUpdate) GetAddresses() []string {
 	if m != nil {
@@ -3213,7 +4504,7 @@ type ChannelEdgeUpdate struct {
 func (m *ChannelEdgeUpdate) Reset()                    { *m = ChannelEdgeUpdate{} }
 func (m *ChannelEdgeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdgeUpdate) ProtoMessage()               {}
-func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
 
 func (m *ChannelEdgeUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -3271,7 +4562,7 @@ type ClosedChannelUpdate struct {
 func (m *ClosedChannelUpdate) Reset()                    { *m = ClosedChannelUpdate{} }
 func (m *ClosedChannelUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelUpdate) ProtoMessage()               {}
-func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
 
 func (m *ClosedChannelUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -3319,7 +4610,7 @@ type HopHint struct {
 func (m *HopHint) Reset()                    { *m = HopHint{} }
 func (m *HopHint) String() string            { return proto.CompactTextString(m) }
 func (*HopHint) ProtoMessage()               {}
-func (*HopHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+func (*HopHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
 
 func (m *HopHint) GetNodeId() string {
 	if m != nil {
@@ -3366,7 +4657,7 @@ type RouteHint struct {
 func (m *RouteHint) Reset()                    { *m = RouteHint{} }
 func (m *RouteHint) String() string            { return proto.CompactTextString(m) }
 func (*RouteHint) ProtoMessage()               {}
-func (*RouteHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+func (*RouteHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
 
 func (m *RouteHint) GetHopHints() []*HopHint {
 	if m != nil {
@@ -3425,7 +4716,7 @@ type Invoice struct {
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -3544,7 +4835,7 @@ type AddInvoiceResponse struct {
 func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
 func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
 
 func (m *AddInvoiceResponse) GetRHash() []byte {
 	if m != nil {
@@ -3572,7 +4863,7 @@ type PaymentHash struct {
 func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
 func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
 func (*PaymentHash) ProtoMessage()               {}
-func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
 
 func (m *PaymentHash) GetRHashStr() string {
 	if m != nil {
@@ -3596,7 +4887,7 @@ type ListInvoiceRequest struct {
 func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
 func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceRequest) ProtoMessage()               {}
-func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
 
 func (m *ListInvoiceRequest) GetPendingOnly() bool {
 	if m != nil {
@@ -3612,7 +4903,7 @@ type ListInvoiceResponse struct {
 func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
 func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceResponse) ProtoMessage()               {}
-func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
 
 func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
 	if m != nil {
@@ -3627,7 +4918,7 @@ type InvoiceSubscription struct {
 func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
 func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
 func (*InvoiceSubscription) ProtoMessage()               {}
-func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
 
 type Payment struct {
 	// / The payment hash
@@ -3647,7 +4938,7 @@ type Payment struct {
 func (m *Payment) Reset()                    { *m = Payment{} }
 func (m *Payment) String() string            { return proto.CompactTextString(m) }
 func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
 
 func (m *Payment) GetPaymentHash() string {
 	if m != nil {
@@ -3697,7 +4988,7 @@ type ListPaymentsRequest struct {
 func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
 func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
 
 type ListPaymentsResponse struct {
 	// / The list of payments
@@ -3707,7 +4998,7 @@ type ListPaymentsResponse struct {
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -3722,7 +5013,7 @@ type DeleteAllPaymentsRequest struct {
 func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
 func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
 
 type DeleteAllPaymentsResponse struct {
 }
@@ -3730,7 +5021,7 @@ type DeleteAllPaymentsResponse struct {
 func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
 func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
 
 type DebugLevelRequest struct {
 	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
@@ -3740,7 +5031,7 @@ type DebugLevelRequest struct {
 func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
 func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelRequest) ProtoMessage()               {}
-func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
 
 func (m *DebugLevelRequest) GetShow() bool {
 	if m != nil {
@@ -3763,7 +5054,7 @@ type DebugLevelResponse struct {
 func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
 func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelResponse) ProtoMessage()               {}
-func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
 
 func (m *DebugLevelResponse) GetSubSystems() string {
 	if m != nil {
@@ -3780,7 +5071,7 @@ type PayReqString struct {
 func (m *PayReqString) Reset()                    { *m = PayReqString{} }
 func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
 func (*PayReqString) ProtoMessage()               {}
-func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
 
 func (m *PayReqString) GetPayReq() string {
 	if m != nil {
@@ -3805,7 +5096,7 @@ type PayReq struct {
 func (m *PayReq) Reset()                    { *m = PayReq{} }
 func (m *PayReq) String() string            { return proto.CompactTextString(m) }
 func (*PayReq) ProtoMessage()               {}
-func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
 
 func (m *PayReq) GetDestination() string {
 	if m != nil {
@@ -3883,7 +5174,7 @@ type FeeReportRequest struct {
 func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
 func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportRequest) ProtoMessage()               {}
-func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
 
 type ChannelFeeReport struct {
 	// / The channel that this fee report belongs to.
@@ -3899,7 +5190,7 @@ type ChannelFeeReport struct {
 func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
 func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
 func (*ChannelFeeReport) ProtoMessage()               {}
-func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
 
 func (m *ChannelFeeReport) GetChanPoint() string {
 	if m != nil {
@@ -3943,7 +5234,7 @@ type FeeReportResponse struct {
 func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
 func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportResponse) ProtoMessage()               {}
-func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
 
 func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
 	if m != nil {
@@ -3989,7 +5280,7 @@ type PolicyUpdateRequest struct {
 func (m *PolicyUpdateRequest) Reset()                    { *m = PolicyUpdateRequest{} }
 func (m *PolicyUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateRequest) ProtoMessage()               {}
-func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
 
 type isPolicyUpdateRequest_Scope interface {
 	isPolicyUpdateRequest_Scope()
@@ -4126,7 +5417,7 @@ type PolicyUpdateResponse struct {
 func (m *PolicyUpdateResponse) Reset()                    { *m = PolicyUpdateResponse{} }
 func (m *PolicyUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateResponse) ProtoMessage()               {}
-func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
 
 type ForwardingHistoryRequest struct {
 	// / Start time is the starting point of the forwarding history request. All records beyond this point will be included, respecting the end time, and the index offset.
@@ -4142,7 +5433,7 @@ type ForwardingHistoryRequest struct {
 func (m *ForwardingHistoryRequest) Reset()                    { *m = ForwardingHistoryRequest{} }
 func (m *ForwardingHistoryRequest) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryRequest) ProtoMessage()               {}
-func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
 
 func (m *ForwardingHistoryRequest) GetStartTime() uint64 {
 	if m != nil {
@@ -4190,7 +5481,7 @@ type ForwardingEvent struct {
 func (m *ForwardingEvent) Reset()                    { *m = ForwardingEvent{} }
 func (m *ForwardingEvent) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingEvent) ProtoMessage()               {}
-func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
 
 func (m *ForwardingEvent) GetTimestamp() uint64 {
 	if m != nil {
@@ -4244,7 +5535,7 @@ type ForwardingHistoryResponse struct {
 func (m *ForwardingHistoryResponse) Reset()                    { *m = ForwardingHistoryResponse{} }
 func (m *ForwardingHistoryResponse) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryResponse) ProtoMessage()               {}
-func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
 
 func (m *ForwardingHistoryResponse) GetForwardingEvents() []*ForwardingEvent {
 	if m != nil {
@@ -4272,12 +5563,19 @@ func init() {
 	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
 	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
 	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*SendToRouteRequest)(nil), "lnrpc.SendToRouteRequest")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
+	proto.RegisterType((*TrackPaymentsRequest)(nil), "lnrpc.TrackPaymentsRequest")
+	proto.RegisterType((*PaymentUpdate)(nil), "lnrpc.PaymentUpdate")
 	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
 	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
 	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
 	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
 	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
 	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*OutPoint)(nil), "lnrpc.OutPoint")
+	proto.RegisterType((*BumpFeeRequest)(nil), "lnrpc.BumpFeeRequest")
+	proto.RegisterType((*BumpFeeResponse)(nil), "lnrpc.BumpFeeResponse")
 	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
 	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
 	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
@@ -4293,11 +5591,22 @@ func init() {
 	proto.RegisterType((*Channel)(nil), "lnrpc.Channel")
 	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
 	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
+	proto.RegisterType((*Resolution)(nil), "lnrpc.Resolution")
+	proto.RegisterType((*ChannelCloseSummary)(nil), "lnrpc.ChannelCloseSummary")
+	proto.RegisterType((*ClosedChannelsRequest)(nil), "lnrpc.ClosedChannelsRequest")
+	proto.RegisterType((*ClosedChannelsResponse)(nil), "lnrpc.ClosedChannelsResponse")
 	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
 	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
 	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
+	proto.RegisterType((*BannedPeer)(nil), "lnrpc.BannedPeer")
+	proto.RegisterType((*ListBannedPeersRequest)(nil), "lnrpc.ListBannedPeersRequest")
+	proto.RegisterType((*ListBannedPeersResponse)(nil), "lnrpc.ListBannedPeersResponse")
+	proto.RegisterType((*UnbanPeerRequest)(nil), "lnrpc.UnbanPeerRequest")
+	proto.RegisterType((*UnbanPeerResponse)(nil), "lnrpc.UnbanPeerResponse")
 	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
 	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
+	proto.RegisterType((*UpdateNodeAnnouncementRequest)(nil), "lnrpc.UpdateNodeAnnouncementRequest")
+	proto.RegisterType((*UpdateNodeAnnouncementResponse)(nil), "lnrpc.UpdateNodeAnnouncementResponse")
 	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
 	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
 	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
@@ -4305,6 +5614,9 @@ func init() {
 	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
 	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
 	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
+	proto.RegisterType((*BatchOpenChannel)(nil), "lnrpc.BatchOpenChannel")
+	proto.RegisterType((*BatchOpenChannelRequest)(nil), "lnrpc.BatchOpenChannelRequest")
+	proto.RegisterType((*BatchOpenChannelResponse)(nil), "lnrpc.BatchOpenChannelResponse")
 	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
 	proto.RegisterType((*PendingHTLC)(nil), "lnrpc.PendingHTLC")
 	proto.RegisterType((*PendingChannelsRequest)(nil), "lnrpc.PendingChannelsRequest")
@@ -4318,10 +5630,15 @@ func init() {
 	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
 	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
 	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
+	proto.RegisterType((*DirectedEdge)(nil), "lnrpc.DirectedEdge")
 	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
 	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
 	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
 	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*BuildRouteRequest)(nil), "lnrpc.BuildRouteRequest")
+	proto.RegisterType((*BuildRouteResponse)(nil), "lnrpc.BuildRouteResponse")
+	proto.RegisterType((*EstimateRouteFeeRequest)(nil), "lnrpc.EstimateRouteFeeRequest")
+	proto.RegisterType((*EstimateRouteFeeResponse)(nil), "lnrpc.EstimateRouteFeeResponse")
 	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
 	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
 	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
@@ -4329,6 +5646,8 @@ func init() {
 	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
 	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
 	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
+	proto.RegisterType((*ExportGraphRequest)(nil), "lnrpc.ExportGraphRequest")
+	proto.RegisterType((*ExportGraphChunk)(nil), "lnrpc.ExportGraphChunk")
 	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
 	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
 	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
@@ -4365,7 +5684,11 @@ func init() {
 	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
 	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
 	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
+	proto.RegisterEnum("lnrpc.PaymentUpdate_PaymentState", PaymentUpdate_PaymentState_name, PaymentUpdate_PaymentState_value)
 	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.Resolution_ResolutionType", Resolution_ResolutionType_name, Resolution_ResolutionType_value)
+	proto.RegisterEnum("lnrpc.Resolution_ResolutionOutcome", Resolution_ResolutionOutcome_name, Resolution_ResolutionOutcome_value)
+	proto.RegisterEnum("lnrpc.ChannelCloseSummary_ClosureType", ChannelCloseSummary_ClosureType_name, ChannelCloseSummary_ClosureType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4590,6 +5913,18 @@ type LightningClient interface {
 	// the internal wallet will consult its fee model to determine a fee for the
 	// default confirmation target.
 	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	// * lncli: `wallet bumpfee`
+	// BumpFee speeds up the confirmation of an unconfirmed transaction, such as
+	// one sweeping the outputs of a force closed channel, by broadcasting a
+	// child-pays-for-parent transaction spending one of its outputs that pays
+	// to the wallet, or our immediately spendable output on a commitment
+	// transaction. The child pays a fee such that the parent and child, taken
+	// as a package, reach the target fee rate. If the fee of the output was
+	// already bumped, then the pending child is replaced, as long as the new fee
+	// rate is higher. If neither target_conf, or sat_per_byte are set, then the
+	// internal wallet will consult its fee model to determine a fee for the
+	// default confirmation target.
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
 	// * lncli: `newaddress`
 	// NewAddress creates a new address under control of the local wallet.
 	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
@@ -4620,11 +5955,27 @@ type LightningClient interface {
 	// * lncli: `listpeers`
 	// ListPeers returns a verbose listing of all currently active peers.
 	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	// * lncli: `listbannedpeers`
+	// ListBannedPeers returns all peers that are currently banned for sending
+	// invalid gossip or violating the protocol.
+	ListBannedPeers(ctx context.Context, in *ListBannedPeersRequest, opts ...grpc.CallOption) (*ListBannedPeersResponse, error)
+	// * lncli: `unbanpeer`
+	// UnbanPeer lifts the ban of the target peer and clears its misbehavior
+	// score, allowing connections with it once again.
+	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerResponse, error)
 	// * lncli: `getinfo`
 	// GetInfo returns general information concerning the lightning node including
 	// it's identity pubkey, alias, the chains it is connected to, and information
 	// concerning the number of open+pending channels.
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// * lncli: `updatenodeannouncement`
+	// UpdateNodeAnnouncement modifies the alias, color, advertised addresses
+	// and optional feature bits of our node announcement, then re-signs it and
+	// broadcasts it to the network. Broadcasts are rate limited, so several
+	// updates made in quick succession are coalesced into one. The changes last
+	// until the next restart, after which the values from the configuration
+	// apply again.
+	UpdateNodeAnnouncement(ctx context.Context, in *UpdateNodeAnnouncementRequest, opts ...grpc.CallOption) (*UpdateNodeAnnouncementResponse, error)
 	// * lncli: `pendingchannels`
 	// PendingChannels returns a list of all the channels that are currently
 	// considered "pending". A channel is pending if it has finished the funding
@@ -4635,6 +5986,11 @@ type LightningClient interface {
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
 	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// * lncli: `closedchannels`
+	// ClosedChannels returns a description of all the closed channels that
+	// this node was a participant in, along with the on-chain resolution of
+	// each of their outputs.
+	ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error)
 	// *
 	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 	// call is meant to be consumed by clients to the REST proxy. As with all
@@ -4648,6 +6004,14 @@ type LightningClient interface {
 	// rate to us for the funding transaction. If neither are specified, then a
 	// lax block confirmation target is used.
 	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	// * lncli: `batchopenchannel`
+	// BatchOpenChannel attempts to open several singly funded channels, possibly
+	// with different peers, within a single funding transaction. The funding
+	// transaction is only broadcast once every peer has accepted and signed its
+	// channel. If the funding flow with any of the peers fails, then none of the
+	// channels are opened. The call returns once the funding transaction has
+	// been broadcast.
+	BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error)
 	// * lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
@@ -4669,6 +6033,23 @@ type LightningClient interface {
 	// Additionally, this RPC expects the destination's public key and the payment
 	// hash (if any) to be encoded as hex strings.
 	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `sendtoroute`
+	// SendToRoute is a synchronous call to send a payment through the network
+	// along the specified routes, which are attempted in order until one
+	// succeeds or a terminal error is encountered. Unlike SendPaymentSync, no
+	// path finding is carried out, allowing the caller to fully control the
+	// routes the payment takes.
+	SendToRoute(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment returns a uni-directional stream (server -> client) of the
+	// state transitions of the outgoing payment with the target payment hash. If
+	// the payment has already completed, its final state is sent and the stream
+	// is closed.
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
+	// *
+	// TrackPayments returns a uni-directional stream (server -> client) of the
+	// state transitions of all outgoing payments.
+	TrackPayments(ctx context.Context, in *TrackPaymentsRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentsClient, error)
 	// * lncli: `addinvoice`
 	// AddInvoice attempts to add a new invoice to the invoice database. Any
 	// duplicated invoices are rejected, therefore all invoices *must* have a
@@ -4706,6 +6087,12 @@ type LightningClient interface {
 	// the node directional specific routing policy which includes: the time lock
 	// delta, fee information, etc.
 	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
+	// * lncli: `exportgraph`
+	// ExportGraph returns a uni-directional stream (server -> client) of the
+	// channel graph encoded in the requested format, restricted to the nodes and
+	// channels matching the given filters. The export is split into chunks of
+	// raw bytes which are to be concatenated by the client.
+	ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (Lightning_ExportGraphClient, error)
 	// * lncli: `getchaninfo`
 	// GetChanInfo returns the latest authenticated network announcement for the
 	// given channel identified by its channel ID: an 8-byte integer which
@@ -4723,6 +6110,20 @@ type LightningClient interface {
 	// send an HTLC, also including the necessary information that should be
 	// present within the Sphinx packet encapsulated within the HTLC.
 	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
+	// * lncli: `buildroute`
+	// BuildRoute constructs a route through the specified ordered list of hops,
+	// computing the fees and time locks of each hop from the current routing
+	// policies of the channels used. The returned route can be passed to
+	// SendToRoute.
+	BuildRoute(ctx context.Context, in *BuildRouteRequest, opts ...grpc.CallOption) (*BuildRouteResponse, error)
+	// * lncli: `estimateroutefee`
+	// EstimateRouteFee probes the candidate routes to a destination, cheapest
+	// first, by sending HTLCs that pay to a random payment hash along them. The
+	// first route whose probe is rejected by the destination itself due to the
+	// unknown payment hash is returned along with its fee and time lock. The
+	// probes are never recorded as payments, though their failures are taken
+	// into account when routing later payments.
+	EstimateRouteFee(ctx context.Context, in *EstimateRouteFeeRequest, opts ...grpc.CallOption) (*EstimateRouteFeeResponse, error)
 	// * lncli: `getnetworkinfo`
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
@@ -4852,6 +6253,15 @@ func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opt
 	return out, nil
 }
 
+func (c *lightningClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BumpFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
 	out := new(NewAddressResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
@@ -4915,6 +6325,24 @@ func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, o
 	return out, nil
 }
 
+func (c *lightningClient) ListBannedPeers(ctx context.Context, in *ListBannedPeersRequest, opts ...grpc.CallOption) (*ListBannedPeersResponse, error) {
+	out := new(ListBannedPeersResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListBannedPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerResponse, error) {
+	out := new(UnbanPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UnbanPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
 	out := new(GetInfoResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
@@ -4924,6 +6352,15 @@ func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts
 	return out, nil
 }
 
+func (c *lightningClient) UpdateNodeAnnouncement(ctx context.Context, in *UpdateNodeAnnouncementRequest, opts ...grpc.CallOption) (*UpdateNodeAnnouncementResponse, error) {
+	out := new(UpdateNodeAnnouncementResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateNodeAnnouncement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error) {
 	out := new(PendingChannelsResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
@@ -4942,6 +6379,15 @@ func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequ
 	return out, nil
 }
 
+func (c *lightningClient) ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error) {
+	out := new(ClosedChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ClosedChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
 	out := new(ChannelPoint)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
@@ -4983,6 +6429,15 @@ func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
 	return m, nil
 }
 
+func (c *lightningClient) BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error) {
+	out := new(BatchOpenChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BatchOpenChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
 	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
 	if err != nil {
@@ -5003,58 +6458,131 @@ type Lightning_CloseChannelClient interface {
 	grpc.ClientStream
 }
 
-type lightningCloseChannelClient struct {
+type lightningCloseChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
+	m := new(CloseStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendPaymentClient{stream}
+	return x, nil
+}
+
+type Lightning_SendPaymentClient interface {
+	Send(*SendRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendToRoute(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendToRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*PaymentUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
 	grpc.ClientStream
 }
 
-func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
-	m := new(CloseStatusUpdate)
+func (x *lightningTrackPaymentClient) Recv() (*PaymentUpdate, error) {
+	m := new(PaymentUpdate)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+func (c *lightningClient) TrackPayments(ctx context.Context, in *TrackPaymentsRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/TrackPayments", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &lightningSendPaymentClient{stream}
+	x := &lightningTrackPaymentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
 	return x, nil
 }
 
-type Lightning_SendPaymentClient interface {
-	Send(*SendRequest) error
-	Recv() (*SendResponse, error)
+type Lightning_TrackPaymentsClient interface {
+	Recv() (*PaymentUpdate, error)
 	grpc.ClientStream
 }
 
-type lightningSendPaymentClient struct {
+type lightningTrackPaymentsClient struct {
 	grpc.ClientStream
 }
 
-func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
-	m := new(SendResponse)
+func (x *lightningTrackPaymentsClient) Recv() (*PaymentUpdate, error) {
+	m := new(PaymentUpdate)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
-	out := new(SendResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
 func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
 	out := new(AddInvoiceResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
@@ -5083,7 +6611,7 @@ func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, op
 }
 
 func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5150,6 +6678,38 @@ func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphReq
 	return out, nil
 }
 
+func (c *lightningClient) ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (Lightning_ExportGraphClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/ExportGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningExportGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_ExportGraphClient interface {
+	Recv() (*ExportGraphChunk, error)
+	grpc.ClientStream
+}
+
+type lightningExportGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningExportGraphClient) Recv() (*ExportGraphChunk, error) {
+	m := new(ExportGraphChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
 	out := new(ChannelEdge)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
@@ -5177,6 +6737,24 @@ func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesReques
 	return out, nil
 }
 
+func (c *lightningClient) BuildRoute(ctx context.Context, in *BuildRouteRequest, opts ...grpc.CallOption) (*BuildRouteResponse, error) {
+	out := new(BuildRouteResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BuildRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) EstimateRouteFee(ctx context.Context, in *EstimateRouteFeeRequest, opts ...grpc.CallOption) (*EstimateRouteFeeResponse, error) {
+	out := new(EstimateRouteFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/EstimateRouteFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
 	out := new(NetworkInfo)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
@@ -5196,7 +6774,7 @@ func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts
 }
 
 func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[8], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5297,6 +6875,18 @@ type LightningServer interface {
 	// the internal wallet will consult its fee model to determine a fee for the
 	// default confirmation target.
 	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	// * lncli: `wallet bumpfee`
+	// BumpFee speeds up the confirmation of an unconfirmed transaction, such as
+	// one sweeping the outputs of a force closed channel, by broadcasting a
+	// child-pays-for-parent transaction spending one of its outputs that pays
+	// to the wallet, or our immediately spendable output on a commitment
+	// transaction. The child pays a fee such that the parent and child, taken
+	// as a package, reach the target fee rate. If the fee of the output was
+	// already bumped, then the pending child is replaced, as long as the new fee
+	// rate is higher. If neither target_conf, or sat_per_byte are set, then the
+	// internal wallet will consult its fee model to determine a fee for the
+	// default confirmation target.
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
 	// * lncli: `newaddress`
 	// NewAddress creates a new address under control of the local wallet.
 	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
@@ -5327,11 +6917,27 @@ type LightningServer interface {
 	// * lncli: `listpeers`
 	// ListPeers returns a verbose listing of all currently active peers.
 	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	// * lncli: `listbannedpeers`
+	// ListBannedPeers returns all peers that are currently banned for sending
+	// invalid gossip or violating the protocol.
+	ListBannedPeers(context.Context, *ListBannedPeersRequest) (*ListBannedPeersResponse, error)
+	// * lncli: `unbanpeer`
+	// UnbanPeer lifts the ban of the target peer and clears its misbehavior
+	// score, allowing connections with it once again.
+	UnbanPeer(context.Context, *UnbanPeerRequest) (*UnbanPeerResponse, error)
 	// * lncli: `getinfo`
 	// GetInfo returns general information concerning the lightning node including
 	// it's identity pubkey, alias, the chains it is connected to, and information
 	// concerning the number of open+pending channels.
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// * lncli: `updatenodeannouncement`
+	// UpdateNodeAnnouncement modifies the alias, color, advertised addresses
+	// and optional feature bits of our node announcement, then re-signs it and
+	// broadcasts it to the network. Broadcasts are rate limited, so several
+	// updates made in quick succession are coalesced into one. The changes last
+	// until the next restart, after which the values from the configuration
+	// apply again.
+	UpdateNodeAnnouncement(context.Context, *UpdateNodeAnnouncementRequest) (*UpdateNodeAnnouncementResponse, error)
 	// * lncli: `pendingchannels`
 	// PendingChannels returns a list of all the channels that are currently
 	// considered "pending". A channel is pending if it has finished the funding
@@ -5342,6 +6948,11 @@ type LightningServer interface {
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
 	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// * lncli: `closedchannels`
+	// ClosedChannels returns a description of all the closed channels that
+	// this node was a participant in, along with the on-chain resolution of
+	// each of their outputs.
+	ClosedChannels(context.Context, *ClosedChannelsRequest) (*ClosedChannelsResponse, error)
 	// *
 	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 	// call is meant to be consumed by clients to the REST proxy. As with all
@@ -5355,6 +6966,14 @@ type LightningServer interface {
 	// rate to us for the funding transaction. If neither are specified, then a
 	// lax block confirmation target is used.
 	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	// * lncli: `batchopenchannel`
+	// BatchOpenChannel attempts to open several singly funded channels, possibly
+	// with different peers, within a single funding transaction. The funding
+	// transaction is only broadcast once every peer has accepted and signed its
+	// channel. If the funding flow with any of the peers fails, then none of the
+	// channels are opened. The call returns once the funding transaction has
+	// been broadcast.
+	BatchOpenChannel(context.Context, *BatchOpenChannelRequest) (*BatchOpenChannelResponse, error)
 	// * lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
@@ -5376,6 +6995,23 @@ type LightningServer interface {
 	// Additionally, this RPC expects the destination's public key and the payment
 	// hash (if any) to be encoded as hex strings.
 	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
+	// * lncli: `sendtoroute`
+	// SendToRoute is a synchronous call to send a payment through the network
+	// along the specified routes, which are attempted in order until one
+	// succeeds or a terminal error is encountered. Unlike SendPaymentSync, no
+	// path finding is carried out, allowing the caller to fully control the
+	// routes the payment takes.
+	SendToRoute(context.Context, *SendToRouteRequest) (*SendResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment returns a uni-directional stream (server -> client) of the
+	// state transitions of the outgoing payment with the target payment hash. If
+	// the payment has already completed, its final state is sent and the stream
+	// is closed.
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
+	// *
+	// TrackPayments returns a uni-directional stream (server -> client) of the
+	// state transitions of all outgoing payments.
+	TrackPayments(*TrackPaymentsRequest, Lightning_TrackPaymentsServer) error
 	// * lncli: `addinvoice`
 	// AddInvoice attempts to add a new invoice to the invoice database. Any
 	// duplicated invoices are rejected, therefore all invoices *must* have a
@@ -5413,6 +7049,12 @@ type LightningServer interface {
 	// the node directional specific routing policy which includes: the time lock
 	// delta, fee information, etc.
 	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
+	// * lncli: `exportgraph`
+	// ExportGraph returns a uni-directional stream (server -> client) of the
+	// channel graph encoded in the requested format, restricted to the nodes and
+	// channels matching the given filters. The export is split into chunks of
+	// raw bytes which are to be concatenated by the client.
+	ExportGraph(*ExportGraphRequest, Lightning_ExportGraphServer) error
 	// * lncli: `getchaninfo`
 	// GetChanInfo returns the latest authenticated network announcement for the
 	// given channel identified by its channel ID: an 8-byte integer which
@@ -5430,6 +7072,20 @@ type LightningServer interface {
 	// send an HTLC, also including the necessary information that should be
 	// present within the Sphinx packet encapsulated within the HTLC.
 	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
+	// * lncli: `buildroute`
+	// BuildRoute constructs a route through the specified ordered list of hops,
+	// computing the fees and time locks of each hop from the current routing
+	// policies of the channels used. The returned route can be passed to
+	// SendToRoute.
+	BuildRoute(context.Context, *BuildRouteRequest) (*BuildRouteResponse, error)
+	// * lncli: `estimateroutefee`
+	// EstimateRouteFee probes the candidate routes to a destination, cheapest
+	// first, by sending HTLCs that pay to a random payment hash along them. The
+	// first route whose probe is rejected by the destination itself due to the
+	// unknown payment hash is returned along with its fee and time lock. The
+	// probes are never recorded as payments, though their failures are taken
+	// into account when routing later payments.
+	EstimateRouteFee(context.Context, *EstimateRouteFeeRequest) (*EstimateRouteFeeResponse, error)
 	// * lncli: `getnetworkinfo`
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
@@ -5589,6 +7245,24 @@ func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BumpFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BumpFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewAddressRequest)
 	if err := dec(in); err != nil {
@@ -5715,6 +7389,42 @@ func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ListBannedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannedPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListBannedPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListBannedPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListBannedPeers(ctx, req.(*ListBannedPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UnbanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UnbanPeer(ctx, req.(*UnbanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInfoRequest)
 	if err := dec(in); err != nil {
@@ -5733,6 +7443,24 @@ func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_UpdateNodeAnnouncement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeAnnouncementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateNodeAnnouncement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateNodeAnnouncement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateNodeAnnouncement(ctx, req.(*UpdateNodeAnnouncementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PendingChannelsRequest)
 	if err := dec(in); err != nil {
@@ -5769,6 +7497,24 @@ func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ClosedChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosedChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ClosedChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ClosedChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ClosedChannels(ctx, req.(*ClosedChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OpenChannelRequest)
 	if err := dec(in); err != nil {
@@ -5808,6 +7554,24 @@ func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_BatchOpenChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOpenChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BatchOpenChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BatchOpenChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BatchOpenChannel(ctx, req.(*BatchOpenChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(CloseChannelRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -5873,6 +7637,66 @@ func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_SendToRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendToRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendToRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendToRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendToRoute(ctx, req.(*SendToRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
+}
+
+type Lightning_TrackPaymentServer interface {
+	Send(*PaymentUpdate) error
+	grpc.ServerStream
+}
+
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningTrackPaymentServer) Send(m *PaymentUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_TrackPayments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayments(m, &lightningTrackPaymentsServer{stream})
+}
+
+type Lightning_TrackPaymentsServer interface {
+	Send(*PaymentUpdate) error
+	grpc.ServerStream
+}
+
+type lightningTrackPaymentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningTrackPaymentsServer) Send(m *PaymentUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Invoice)
 	if err := dec(in); err != nil {
@@ -6020,6 +7844,27 @@ func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ExportGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportGraphRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).ExportGraph(m, &lightningExportGraphServer{stream})
+}
+
+type Lightning_ExportGraphServer interface {
+	Send(*ExportGraphChunk) error
+	grpc.ServerStream
+}
+
+type lightningExportGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningExportGraphServer) Send(m *ExportGraphChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChanInfoRequest)
 	if err := dec(in); err != nil {
@@ -6074,6 +7919,42 @@ func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_BuildRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BuildRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BuildRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BuildRoute(ctx, req.(*BuildRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_EstimateRouteFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateRouteFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).EstimateRouteFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/EstimateRouteFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).EstimateRouteFee(ctx, req.(*EstimateRouteFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NetworkInfoRequest)
 	if err := dec(in); err != nil {
@@ -6227,6 +8108,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendMany",
 			Handler:    _Lightning_SendMany_Handler,
 		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _Lightning_BumpFee_Handler,
+		},
 		{
 			MethodName: "NewAddress",
 			Handler:    _Lightning_NewAddress_Handler,
@@ -6255,10 +8140,22 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListPeers",
 			Handler:    _Lightning_ListPeers_Handler,
 		},
+		{
+			MethodName: "ListBannedPeers",
+			Handler:    _Lightning_ListBannedPeers_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _Lightning_UnbanPeer_Handler,
+		},
 		{
 			MethodName: "GetInfo",
 			Handler:    _Lightning_GetInfo_Handler,
 		},
+		{
+			MethodName: "UpdateNodeAnnouncement",
+			Handler:    _Lightning_UpdateNodeAnnouncement_Handler,
+		},
 		{
 			MethodName: "PendingChannels",
 			Handler:    _Lightning_PendingChannels_Handler,
@@ -6267,14 +8164,26 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListChannels",
 			Handler:    _Lightning_ListChannels_Handler,
 		},
+		{
+			MethodName: "ClosedChannels",
+			Handler:    _Lightning_ClosedChannels_Handler,
+		},
 		{
 			MethodName: "OpenChannelSync",
 			Handler:    _Lightning_OpenChannelSync_Handler,
 		},
+		{
+			MethodName: "BatchOpenChannel",
+			Handler:    _Lightning_BatchOpenChannel_Handler,
+		},
 		{
 			MethodName: "SendPaymentSync",
 			Handler:    _Lightning_SendPaymentSync_Handler,
 		},
+		{
+			MethodName: "SendToRoute",
+			Handler:    _Lightning_SendToRoute_Handler,
+		},
 		{
 			MethodName: "AddInvoice",
 			Handler:    _Lightning_AddInvoice_Handler,
@@ -6315,6 +8224,14 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryRoutes",
 			Handler:    _Lightning_QueryRoutes_Handler,
 		},
+		{
+			MethodName: "BuildRoute",
+			Handler:    _Lightning_BuildRoute_Handler,
+		},
+		{
+			MethodName: "EstimateRouteFee",
+			Handler:    _Lightning_EstimateRouteFee_Handler,
+		},
 		{
 			MethodName: "GetNetworkInfo",
 			Handler:    _Lightning_GetNetworkInfo_Handler,
@@ -6362,11 +8279,26 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TrackPayments",
+			Handler:       _Lightning_TrackPayments_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeInvoices",
 			Handler:       _Lightning_SubscribeInvoices_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportGraph",
+			Handler:       _Lightning_ExportGraph_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeChannelGraph",
 			Handler:       _Lightning_SubscribeChannelGraph_Handler,
@@ -6379,358 +8311,463 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 5640 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7c, 0x4d, 0x90, 0x1c, 0xc9,
-	0x55, 0xbf, 0xaa, 0xa7, 0xe7, 0xa3, 0x5f, 0xf7, 0xf4, 0xcc, 0xe4, 0x8c, 0x46, 0xad, 0x96, 0x56,
-	0xab, 0x2d, 0x6f, 0x58, 0xfa, 0xcf, 0x7f, 0xd1, 0x68, 0xc7, 0xf6, 0xb2, 0x5e, 0x81, 0x8d, 0xbe,
-	0x67, 0x6d, 0xad, 0x3c, 0xae, 0x91, 0x2c, 0xb0, 0x81, 0x76, 0x4d, 0x77, 0x4e, 0x4f, 0xad, 0xaa,
-	0xab, 0x6a, 0xab, 0xaa, 0x67, 0xd4, 0xbb, 0x28, 0x82, 0xaf, 0xe0, 0x84, 0x83, 0x03, 0x5c, 0x4c,
-	0x04, 0x41, 0x84, 0x7d, 0x81, 0x03, 0x47, 0x4e, 0x86, 0x1b, 0x27, 0x22, 0x08, 0x0e, 0x7b, 0x72,
-	0x70, 0xe3, 0xe3, 0x00, 0x0e, 0x2e, 0x44, 0x70, 0xe1, 0x40, 0x10, 0xef, 0xe5, 0x47, 0x65, 0x56,
-	0xd5, 0x48, 0xf2, 0x07, 0xdc, 0x3a, 0x7f, 0xf9, 0xea, 0xe5, 0xd7, 0x7b, 0x2f, 0xdf, 0x7b, 0x99,
-	0xd9, 0xd0, 0x4a, 0x93, 0xe1, 0xb5, 0x24, 0x8d, 0xf3, 0x98, 0xcd, 0x87, 0x51, 0x9a, 0x0c, 0xfb,
-	0x17, 0xc7, 0x71, 0x3c, 0x0e, 0xf9, 0xb6, 0x9f, 0x04, 0xdb, 0x7e, 0x14, 0xc5, 0xb9, 0x9f, 0x07,
-	0x71, 0x94, 0x09, 0x22, 0xf7, 0xdb, 0xd0, 0xbd, 0xcf, 0xa3, 0x7d, 0xce, 0x47, 0x1e, 0xff, 0x68,
-	0xca, 0xb3, 0x9c, 0xfd, 0x7f, 0x58, 0xf3, 0xf9, 0xc7, 0x9c, 0x8f, 0x06, 0x89, 0x9f, 0x65, 0xc9,
-	0x51, 0xea, 0x67, 0xbc, 0xe7, 0x5c, 0x76, 0xae, 0x76, 0xbc, 0x55, 0x51, 0xb1, 0xa7, 0x71, 0xf6,
-	0x06, 0x74, 0x32, 0x24, 0xe5, 0x51, 0x9e, 0xc6, 0xc9, 0xac, 0xd7, 0x20, 0xba, 0x36, 0x62, 0x77,
-	0x05, 0xe4, 0x86, 0xb0, 0xa2, 0x5b, 0xc8, 0x92, 0x38, 0xca, 0x38, 0xbb, 0x0e, 0x1b, 0xc3, 0x20,
-	0x39, 0xe2, 0xe9, 0x80, 0x3e, 0x9e, 0x44, 0x7c, 0x12, 0x47, 0xc1, 0xb0, 0xe7, 0x5c, 0x9e, 0xbb,
-	0xda, 0xf2, 0x98, 0xa8, 0xc3, 0x2f, 0x3e, 0x90, 0x35, 0xec, 0x0a, 0xac, 0xf0, 0x48, 0xe0, 0x7c,
-	0x44, 0x5f, 0xc9, 0xa6, 0xba, 0x05, 0x8c, 0x1f, 0xb8, 0x7f, 0xe3, 0xc0, 0xda, 0xfb, 0x51, 0x90,
-	0x3f, 0xf1, 0xc3, 0x90, 0xe7, 0x6a, 0x4c, 0x57, 0x60, 0xe5, 0x84, 0x00, 0x1a, 0xd3, 0x49, 0x9c,
-	0x8e, 0xe4, 0x88, 0xba, 0x02, 0xde, 0x93, 0xe8, 0xa9, 0x3d, 0x6b, 0x9c, 0xda, 0xb3, 0xda, 0xe9,
-	0x9a, 0x3b, 0x65, 0xba, 0xae, 0xc0, 0x4a, 0xca, 0x87, 0xf1, 0x31, 0x4f, 0x67, 0x83, 0x93, 0x20,
-	0x1a, 0xc5, 0x27, 0xbd, 0xe6, 0x65, 0xe7, 0xea, 0xbc, 0xd7, 0x55, 0xf0, 0x13, 0x42, 0xdd, 0x0d,
-	0x60, 0xe6, 0x28, 0xc4, 0xbc, 0xb9, 0x63, 0x58, 0x7f, 0x1c, 0x85, 0xf1, 0xf0, 0xe9, 0x4f, 0x38,
-	0xba, 0x9a, 0xe6, 0x1b, 0xb5, 0xcd, 0x6f, 0xc2, 0x86, 0xdd, 0x90, 0xec, 0xc0, 0x77, 0x1b, 0xd0,
-	0x7e, 0x94, 0xfa, 0x51, 0xe6, 0x0f, 0x51, 0x88, 0x58, 0x0f, 0x16, 0xf3, 0x67, 0x83, 0x23, 0x3f,
-	0x3b, 0xa2, 0x16, 0x5b, 0x9e, 0x2a, 0xb2, 0x4d, 0x58, 0xf0, 0x27, 0xf1, 0x34, 0xca, 0xa9, 0x85,
-	0x39, 0x4f, 0x96, 0xd8, 0x5b, 0xb0, 0x16, 0x4d, 0x27, 0x83, 0x61, 0x1c, 0x1d, 0x06, 0xe9, 0x44,
-	0x88, 0x22, 0x4d, 0xd7, 0xbc, 0x57, 0xad, 0x60, 0x97, 0x00, 0x0e, 0xb0, 0x1b, 0xa2, 0x89, 0x26,
-	0x35, 0x61, 0x20, 0xcc, 0x85, 0x8e, 0x2c, 0xf1, 0x60, 0x7c, 0x94, 0xf7, 0xe6, 0x89, 0x91, 0x85,
-	0x21, 0x8f, 0x3c, 0x98, 0xf0, 0x41, 0x96, 0xfb, 0x93, 0xa4, 0xb7, 0x40, 0xbd, 0x31, 0x10, 0xaa,
-	0x8f, 0x73, 0x3f, 0x1c, 0x1c, 0x72, 0x9e, 0xf5, 0x16, 0x65, 0xbd, 0x46, 0xd8, 0x67, 0xa1, 0x3b,
-	0xe2, 0x59, 0x3e, 0xf0, 0x47, 0xa3, 0x94, 0x67, 0x19, 0xcf, 0x7a, 0x4b, 0x24, 0x0c, 0x25, 0xd4,
-	0xed, 0xc1, 0xe6, 0x7d, 0x9e, 0x1b, 0xb3, 0x93, 0xc9, 0xf5, 0x71, 0x1f, 0x00, 0x33, 0xe0, 0x3b,
-	0x3c, 0xf7, 0x83, 0x30, 0x63, 0xef, 0x40, 0x27, 0x37, 0x88, 0x49, 0xf8, 0xdb, 0x3b, 0xec, 0x1a,
-	0x69, 0xed, 0x35, 0xe3, 0x03, 0xcf, 0xa2, 0x73, 0xff, 0xcb, 0x81, 0xf6, 0x3e, 0x8f, 0xb4, 0xbe,
-	0x32, 0x68, 0x62, 0x4f, 0xe4, 0x92, 0xd3, 0x6f, 0xf6, 0x3a, 0xb4, 0xa9, 0x77, 0x59, 0x9e, 0x06,
-	0xd1, 0x98, 0x96, 0xa0, 0xe5, 0x01, 0x42, 0xfb, 0x84, 0xb0, 0x55, 0x98, 0xf3, 0x27, 0x39, 0x4d,
-	0xfc, 0x9c, 0x87, 0x3f, 0x51, 0x93, 0x13, 0x7f, 0x36, 0xe1, 0x51, 0x5e, 0x4c, 0x76, 0xc7, 0x6b,
-	0x4b, 0x6c, 0x17, 0x67, 0xfb, 0x1a, 0xac, 0x9b, 0x24, 0x8a, 0xfb, 0x3c, 0x71, 0x5f, 0x33, 0x28,
-	0x65, 0x23, 0x57, 0x60, 0x45, 0xd1, 0xa7, 0xa2, 0xb3, 0x34, 0xfd, 0x2d, 0xaf, 0x2b, 0x61, 0x35,
-	0x84, 0xab, 0xb0, 0x7a, 0x18, 0x44, 0x7e, 0x38, 0x18, 0x86, 0xf9, 0xf1, 0x60, 0xc4, 0xc3, 0xdc,
-	0xa7, 0x85, 0x98, 0xf7, 0xba, 0x84, 0xdf, 0x0e, 0xf3, 0xe3, 0x3b, 0x88, 0xba, 0x7f, 0xe4, 0x40,
-	0x47, 0x0c, 0x5e, 0x9a, 0x92, 0x37, 0x61, 0x59, 0xb5, 0xc1, 0xd3, 0x34, 0x4e, 0xa5, 0x1c, 0xda,
-	0x20, 0xdb, 0x82, 0x55, 0x05, 0x24, 0x29, 0x0f, 0x26, 0xfe, 0x98, 0x4b, 0xfb, 0x51, 0xc1, 0xd9,
-	0x4e, 0xc1, 0x31, 0x8d, 0xa7, 0xb9, 0x50, 0xe6, 0xf6, 0x4e, 0x47, 0x2e, 0x8c, 0x87, 0x98, 0x67,
-	0x93, 0xb8, 0xdf, 0x73, 0xa0, 0x73, 0xfb, 0xc8, 0x8f, 0x22, 0x1e, 0xee, 0xc5, 0x41, 0x94, 0xb3,
-	0xeb, 0xc0, 0x0e, 0xa7, 0xd1, 0x28, 0x88, 0xc6, 0x83, 0xfc, 0x59, 0x30, 0x1a, 0x1c, 0xcc, 0x72,
-	0x9e, 0x89, 0x25, 0xda, 0x3d, 0xe3, 0xd5, 0xd4, 0xb1, 0xb7, 0x60, 0xd5, 0x42, 0xb3, 0x3c, 0x15,
-	0xeb, 0xb6, 0x7b, 0xc6, 0xab, 0xd4, 0xa0, 0xe0, 0xc7, 0xd3, 0x3c, 0x99, 0xe6, 0x83, 0x20, 0x1a,
-	0xf1, 0x67, 0xd4, 0xc7, 0x65, 0xcf, 0xc2, 0x6e, 0x75, 0xa1, 0x63, 0x7e, 0xe7, 0x7e, 0x09, 0x56,
-	0x1f, 0xa0, 0x46, 0x44, 0x41, 0x34, 0xbe, 0x29, 0xc4, 0x16, 0xd5, 0x34, 0x99, 0x1e, 0x3c, 0xe5,
-	0x33, 0x39, 0x6f, 0xb2, 0x84, 0x42, 0x75, 0x14, 0x67, 0xb9, 0x94, 0x1c, 0xfa, 0xed, 0xfe, 0x93,
-	0x03, 0x2b, 0x38, 0xf7, 0x1f, 0xf8, 0xd1, 0x4c, 0xad, 0xdc, 0x03, 0xe8, 0x20, 0xab, 0x47, 0xf1,
-	0x4d, 0xa1, 0xec, 0x42, 0x88, 0xaf, 0xca, 0xb9, 0x2a, 0x51, 0x5f, 0x33, 0x49, 0x71, 0x7b, 0x98,
-	0x79, 0xd6, 0xd7, 0x28, 0xb6, 0xb9, 0x9f, 0x8e, 0x79, 0x4e, 0x66, 0x40, 0x9a, 0x05, 0x10, 0xd0,
-	0xed, 0x38, 0x3a, 0x64, 0x97, 0xa1, 0x93, 0xf9, 0xf9, 0x20, 0xe1, 0x29, 0xcd, 0x1a, 0x89, 0xde,
-	0x9c, 0x07, 0x99, 0x9f, 0xef, 0xf1, 0xf4, 0xd6, 0x2c, 0xe7, 0xfd, 0x2f, 0xc3, 0x5a, 0xa5, 0x15,
-	0x94, 0xf6, 0x62, 0x88, 0xf8, 0x93, 0x6d, 0xc0, 0xfc, 0xb1, 0x1f, 0x4e, 0xb9, 0xb4, 0x4e, 0xa2,
-	0xf0, 0x5e, 0xe3, 0x5d, 0xc7, 0xfd, 0x2c, 0xac, 0x16, 0xdd, 0x96, 0x42, 0xc6, 0xa0, 0x89, 0x33,
-	0x28, 0x19, 0xd0, 0x6f, 0xf7, 0xb7, 0x1c, 0x41, 0x78, 0x3b, 0x0e, 0xb4, 0xa6, 0x23, 0x21, 0x1a,
-	0x04, 0x45, 0x88, 0xbf, 0x4f, 0xb5, 0x84, 0x3f, 0xfd, 0x60, 0xdd, 0x2b, 0xb0, 0x66, 0x74, 0xe1,
-	0x05, 0x9d, 0xfd, 0x8e, 0x03, 0x6b, 0x0f, 0xf9, 0x89, 0x5c, 0x75, 0xd5, 0xdb, 0x77, 0xa1, 0x99,
-	0xcf, 0x12, 0xb1, 0xb9, 0x77, 0x77, 0xde, 0x94, 0x8b, 0x56, 0xa1, 0xbb, 0x26, 0x8b, 0x8f, 0x66,
-	0x09, 0xf7, 0xe8, 0x0b, 0xf7, 0x4b, 0xd0, 0x36, 0x40, 0x76, 0x0e, 0xd6, 0x9f, 0xbc, 0xff, 0xe8,
-	0xe1, 0xdd, 0xfd, 0xfd, 0xc1, 0xde, 0xe3, 0x5b, 0x5f, 0xbd, 0xfb, 0x2b, 0x83, 0xdd, 0x9b, 0xfb,
-	0xbb, 0xab, 0x67, 0xd8, 0x26, 0xb0, 0x87, 0x77, 0xf7, 0x1f, 0xdd, 0xbd, 0x63, 0xe1, 0x8e, 0xdb,
-	0x87, 0xde, 0x43, 0x7e, 0xf2, 0x24, 0xc8, 0x23, 0x9e, 0x65, 0x76, 0x6b, 0xee, 0x35, 0x60, 0x66,
-	0x17, 0xe4, 0xa8, 0x7a, 0xb0, 0x28, 0x4d, 0xad, 0xda, 0x69, 0x64, 0xd1, 0xfd, 0x2c, 0xb0, 0xfd,
-	0x60, 0x1c, 0x7d, 0xc0, 0xb3, 0xcc, 0x1f, 0x73, 0x35, 0xb6, 0x55, 0x98, 0x9b, 0x64, 0x63, 0x69,
-	0x14, 0xf1, 0xa7, 0xfb, 0x39, 0x58, 0xb7, 0xe8, 0x24, 0xe3, 0x8b, 0xd0, 0xca, 0x82, 0x71, 0xe4,
-	0xe7, 0xd3, 0x94, 0x4b, 0xd6, 0x05, 0xe0, 0xde, 0x83, 0x8d, 0x6f, 0xf0, 0x34, 0x38, 0x9c, 0xbd,
-	0x8c, 0xbd, 0xcd, 0xa7, 0x51, 0xe6, 0x73, 0x17, 0xce, 0x96, 0xf8, 0xc8, 0xe6, 0x85, 0x20, 0xca,
-	0xe5, 0x5a, 0xf2, 0x44, 0xc1, 0x50, 0xcb, 0x86, 0xa9, 0x96, 0xee, 0x63, 0x60, 0xb7, 0xe3, 0x28,
-	0xe2, 0xc3, 0x7c, 0x8f, 0xf3, 0xb4, 0xf0, 0xd8, 0x0a, 0xa9, 0x6b, 0xef, 0x9c, 0x93, 0xeb, 0x58,
-	0xd6, 0x75, 0x29, 0x8e, 0x0c, 0x9a, 0x09, 0x4f, 0x27, 0xc4, 0x78, 0xc9, 0xa3, 0xdf, 0xee, 0x59,
-	0x58, 0xb7, 0xd8, 0xca, 0xdd, 0xfe, 0x6d, 0x38, 0x7b, 0x27, 0xc8, 0x86, 0xd5, 0x06, 0x7b, 0xb0,
-	0x98, 0x4c, 0x0f, 0x06, 0x85, 0x4e, 0xa9, 0x22, 0x6e, 0x82, 0xe5, 0x4f, 0x24, 0xb3, 0xdf, 0x73,
-	0xa0, 0xb9, 0xfb, 0xe8, 0xc1, 0x6d, 0xd6, 0x87, 0xa5, 0x20, 0x1a, 0xc6, 0x13, 0xdc, 0x3a, 0xc4,
-	0xa0, 0x75, 0xf9, 0x54, 0x5d, 0xb9, 0x08, 0x2d, 0xda, 0x71, 0x70, 0x5f, 0x97, 0xce, 0x55, 0x01,
-	0xa0, 0x4f, 0xc1, 0x9f, 0x25, 0x41, 0x4a, 0x4e, 0x83, 0x72, 0x05, 0x9a, 0x64, 0x11, 0xab, 0x15,
-	0xee, 0x7f, 0x37, 0x61, 0x51, 0xda, 0x6a, 0x6a, 0x6f, 0x98, 0x07, 0xc7, 0x5c, 0xf6, 0x44, 0x96,
-	0x70, 0x57, 0x49, 0xf9, 0x24, 0xce, 0xf9, 0xc0, 0x5a, 0x06, 0x1b, 0x44, 0xaa, 0xa1, 0x60, 0x34,
-	0x48, 0xd0, 0xea, 0x53, 0xcf, 0x5a, 0x9e, 0x0d, 0xe2, 0x64, 0x21, 0x30, 0x08, 0x46, 0xd4, 0xa7,
-	0xa6, 0xa7, 0x8a, 0x38, 0x13, 0x43, 0x3f, 0xf1, 0x87, 0x41, 0x3e, 0x93, 0xca, 0xad, 0xcb, 0xc8,
-	0x3b, 0x8c, 0x87, 0x7e, 0x38, 0x38, 0xf0, 0x43, 0x3f, 0x1a, 0x72, 0xe9, 0xb8, 0xd8, 0x20, 0xfa,
-	0x26, 0xb2, 0x4b, 0x8a, 0x4c, 0xf8, 0x2f, 0x25, 0x14, 0x7d, 0x9c, 0x61, 0x3c, 0x99, 0x04, 0x39,
-	0xba, 0x34, 0xbd, 0x25, 0x61, 0x48, 0x0a, 0x84, 0x46, 0x22, 0x4a, 0x27, 0x62, 0xf6, 0x5a, 0xa2,
-	0x35, 0x0b, 0x44, 0x2e, 0x87, 0x9c, 0x93, 0x41, 0x7a, 0x7a, 0xd2, 0x03, 0xc1, 0xa5, 0x40, 0x70,
-	0x1d, 0xa6, 0x51, 0xc6, 0xf3, 0x3c, 0xe4, 0x23, 0xdd, 0xa1, 0x36, 0x91, 0x55, 0x2b, 0xd8, 0x75,
-	0x58, 0x17, 0x5e, 0x56, 0xe6, 0xe7, 0x71, 0x76, 0x14, 0x64, 0x83, 0x8c, 0x47, 0x79, 0xaf, 0x43,
-	0xf4, 0x75, 0x55, 0xec, 0x5d, 0x38, 0x57, 0x82, 0x53, 0x3e, 0xe4, 0xc1, 0x31, 0x1f, 0xf5, 0x96,
-	0xe9, 0xab, 0xd3, 0xaa, 0xd9, 0x65, 0x68, 0xa3, 0x73, 0x39, 0x4d, 0x46, 0x3e, 0xee, 0xc3, 0x5d,
-	0x5a, 0x07, 0x13, 0x62, 0x6f, 0xc3, 0x72, 0xc2, 0xc5, 0x66, 0x79, 0x94, 0x87, 0xc3, 0xac, 0xb7,
-	0x42, 0x3b, 0x59, 0x5b, 0x2a, 0x13, 0x4a, 0xae, 0x67, 0x53, 0xa0, 0x50, 0x0e, 0x33, 0x72, 0x57,
-	0xfc, 0x59, 0x6f, 0x95, 0xc4, 0xad, 0x00, 0x48, 0x47, 0xd2, 0xe0, 0xd8, 0xcf, 0x79, 0x6f, 0x8d,
-	0x64, 0x4b, 0x15, 0xdd, 0x3f, 0x75, 0x60, 0xfd, 0x41, 0x90, 0xe5, 0x52, 0x08, 0xb5, 0x39, 0x7e,
-	0x1d, 0xda, 0x42, 0xfc, 0x06, 0x71, 0x14, 0xce, 0xa4, 0x44, 0x82, 0x80, 0xbe, 0x16, 0x85, 0x33,
-	0xf6, 0x19, 0x58, 0x0e, 0x22, 0x93, 0x44, 0xe8, 0x70, 0x47, 0x81, 0x44, 0xf4, 0x3a, 0xb4, 0x93,
-	0xe9, 0x41, 0x18, 0x0c, 0x05, 0xc9, 0x9c, 0xe0, 0x22, 0x20, 0x22, 0x40, 0x47, 0x4f, 0xf4, 0x44,
-	0x50, 0x34, 0x89, 0xa2, 0x2d, 0x31, 0x24, 0x71, 0x6f, 0xc1, 0x86, 0xdd, 0x41, 0x69, 0xac, 0xb6,
-	0x60, 0x49, 0xca, 0x76, 0xd6, 0x6b, 0xd3, 0xfc, 0x74, 0xe5, 0xfc, 0x48, 0x52, 0x4f, 0xd7, 0xbb,
-	0xff, 0xe6, 0x40, 0x13, 0x0d, 0xc0, 0xe9, 0xc6, 0xc2, 0xb4, 0xe9, 0x73, 0x96, 0x4d, 0x27, 0xbf,
-	0x1f, 0xbd, 0x22, 0x21, 0x12, 0x42, 0x6d, 0x0c, 0xa4, 0xa8, 0x4f, 0xf9, 0xf0, 0x98, 0x74, 0x47,
-	0xd7, 0x23, 0x82, 0x9a, 0x85, 0x5b, 0x27, 0x7d, 0x2d, 0x14, 0x47, 0x97, 0x55, 0x1d, 0x7d, 0xb9,
-	0x58, 0xd4, 0xd1, 0x77, 0x3d, 0x58, 0x0c, 0xa2, 0x83, 0x78, 0x1a, 0x8d, 0x48, 0x49, 0x96, 0x3c,
-	0x55, 0xc4, 0xc5, 0x4e, 0xc8, 0x93, 0x0a, 0x26, 0x5c, 0x6a, 0x47, 0x01, 0xb8, 0x0c, 0x5d, 0xab,
-	0x8c, 0x0c, 0x9e, 0xde, 0xc7, 0xde, 0x81, 0x35, 0x03, 0x93, 0x33, 0xf8, 0x06, 0xcc, 0x27, 0x08,
-	0x48, 0x47, 0x49, 0x89, 0x17, 0x59, 0x4a, 0x51, 0xe3, 0xae, 0x62, 0x44, 0x9e, 0xbf, 0x1f, 0x1d,
-	0xc6, 0x8a, 0xd3, 0x0f, 0xe7, 0x30, 0x84, 0x96, 0x90, 0x64, 0x74, 0x15, 0x56, 0x82, 0x11, 0x8f,
-	0xf2, 0x20, 0x9f, 0x0d, 0x2c, 0x0f, 0xae, 0x0c, 0xe3, 0x0e, 0xe3, 0x87, 0x81, 0x9f, 0x49, 0x1b,
-	0x26, 0x0a, 0x6c, 0x07, 0x36, 0x50, 0xfc, 0x95, 0x44, 0xeb, 0x65, 0x15, 0x8e, 0x64, 0x6d, 0x1d,
-	0x6a, 0x2c, 0xe2, 0x52, 0x02, 0xf5, 0x27, 0xc2, 0xd2, 0xd6, 0x55, 0xe1, 0xac, 0x09, 0x4e, 0x38,
-	0xe4, 0x79, 0xa1, 0x22, 0x1a, 0xa8, 0x44, 0x6f, 0x0b, 0xc2, 0x89, 0x2d, 0x47, 0x6f, 0x46, 0x04,
-	0xb8, 0x54, 0x89, 0x00, 0xaf, 0xc2, 0x4a, 0x36, 0x8b, 0x86, 0x7c, 0x34, 0xc8, 0x63, 0x6c, 0x37,
-	0x88, 0x68, 0x75, 0x96, 0xbc, 0x32, 0x4c, 0xb1, 0x2a, 0xcf, 0xf2, 0x88, 0xe7, 0x64, 0xba, 0x96,
-	0x3c, 0x55, 0xc4, 0x5d, 0x80, 0x48, 0x84, 0x50, 0xb7, 0x3c, 0x59, 0xc2, 0xad, 0x72, 0x9a, 0x06,
-	0x59, 0xaf, 0x43, 0x28, 0xfd, 0x66, 0x9f, 0x87, 0xb3, 0x07, 0x18, 0x59, 0x1d, 0x71, 0x7f, 0xc4,
-	0x53, 0x5a, 0x7d, 0x11, 0x58, 0x0a, 0x0b, 0x54, 0x5f, 0x89, 0x6d, 0x1f, 0xf3, 0x34, 0x0b, 0xe2,
-	0x88, 0x6c, 0x4f, 0xcb, 0x53, 0x45, 0xf7, 0x63, 0xda, 0xd1, 0x75, 0xc8, 0xfb, 0x98, 0xcc, 0x11,
-	0xbb, 0x00, 0x2d, 0x31, 0xc6, 0xec, 0xc8, 0x97, 0x4e, 0xc6, 0x12, 0x01, 0xfb, 0x47, 0x3e, 0x2a,
-	0xb0, 0x35, 0x6d, 0x22, 0x84, 0x6f, 0x13, 0xb6, 0x2b, 0x66, 0xed, 0x4d, 0xe8, 0xaa, 0x60, 0x3a,
-	0x1b, 0x84, 0xfc, 0x30, 0x57, 0x01, 0x42, 0x34, 0x9d, 0x60, 0x73, 0xd9, 0x03, 0x7e, 0x98, 0xbb,
-	0x0f, 0x61, 0x4d, 0xea, 0xed, 0xd7, 0x12, 0xae, 0x9a, 0xfe, 0x62, 0x79, 0x53, 0x13, 0x5e, 0xc5,
-	0xba, 0xad, 0xe8, 0x14, 0xe5, 0x94, 0x76, 0x3a, 0xd7, 0x03, 0x26, 0xab, 0x6f, 0x87, 0x71, 0xc6,
-	0x25, 0x43, 0x17, 0x3a, 0xc3, 0x30, 0xce, 0x54, 0x18, 0x22, 0x87, 0x63, 0x61, 0x38, 0x3f, 0xd9,
-	0x74, 0x38, 0x44, 0x4b, 0x20, 0x6c, 0x9a, 0x2a, 0xba, 0x7f, 0xe6, 0xc0, 0x3a, 0x71, 0x53, 0x16,
-	0x46, 0xfb, 0xae, 0xaf, 0xde, 0xcd, 0xce, 0xd0, 0x0c, 0xcd, 0x36, 0x60, 0xfe, 0x30, 0x4e, 0x87,
-	0x5c, 0xb6, 0x24, 0x0a, 0x3f, 0xbe, 0x37, 0xde, 0xac, 0x78, 0xe3, 0x3f, 0x74, 0x60, 0x8d, 0xba,
-	0xba, 0x9f, 0xfb, 0xf9, 0x34, 0x93, 0xc3, 0xff, 0x05, 0x58, 0xc6, 0xa1, 0x72, 0xa5, 0x4e, 0xb2,
-	0xa3, 0x1b, 0x5a, 0xf3, 0x09, 0x15, 0xc4, 0xbb, 0x67, 0x3c, 0x9b, 0x98, 0x7d, 0x19, 0x3a, 0x66,
-	0x46, 0x84, 0xfa, 0xdc, 0xde, 0x39, 0xaf, 0x46, 0x59, 0x91, 0x9c, 0xdd, 0x33, 0x9e, 0xf5, 0x01,
-	0xbb, 0x01, 0x40, 0xee, 0x06, 0xb1, 0x95, 0xa1, 0xec, 0x79, 0x7b, 0x92, 0x8c, 0xc5, 0xda, 0x3d,
-	0xe3, 0x19, 0xe4, 0xb7, 0x96, 0x60, 0x41, 0xec, 0x8f, 0xee, 0x7d, 0x58, 0xb6, 0x7a, 0x6a, 0x45,
-	0x19, 0x1d, 0x11, 0x65, 0x54, 0x82, 0xd2, 0x46, 0x35, 0x28, 0x75, 0xff, 0xa5, 0x01, 0x0c, 0xa5,
-	0xad, 0xb4, 0x9c, 0xb8, 0x41, 0xc7, 0x23, 0xcb, 0xdd, 0xea, 0x78, 0x26, 0xc4, 0xae, 0x01, 0x33,
-	0x8a, 0x2a, 0xf7, 0x20, 0xf6, 0x8d, 0x9a, 0x1a, 0x34, 0x70, 0xc2, 0x57, 0x52, 0x31, 0xb0, 0x74,
-	0x2c, 0xc5, 0xba, 0xd5, 0xd6, 0xe1, 0xd6, 0x90, 0x4c, 0xb3, 0x23, 0x74, 0x20, 0x94, 0x43, 0xa6,
-	0xca, 0x65, 0x01, 0x59, 0x78, 0xa9, 0x80, 0x2c, 0x96, 0x05, 0xc4, 0x74, 0x09, 0x96, 0x2c, 0x97,
-	0x00, 0xfd, 0xaf, 0x49, 0x10, 0x91, 0x5f, 0x31, 0x98, 0x60, 0xeb, 0xd2, 0xff, 0xb2, 0x40, 0xb6,
-	0x05, 0xab, 0xd2, 0xaf, 0x2b, 0xfc, 0x0e, 0xa0, 0x39, 0xae, 0xe0, 0xee, 0xa7, 0x0e, 0xac, 0xe2,
-	0x3c, 0x5b, 0xb2, 0xf8, 0x1e, 0x90, 0x2a, 0xbc, 0xa2, 0x28, 0x5a, 0xb4, 0x3f, 0xbd, 0x24, 0xbe,
-	0x0b, 0x2d, 0x62, 0x18, 0x27, 0x3c, 0x92, 0x82, 0xd8, 0xb3, 0x05, 0xb1, 0xb0, 0x42, 0xbb, 0x67,
-	0xbc, 0x82, 0xd8, 0x10, 0xc3, 0xbf, 0x77, 0xa0, 0x2d, 0xbb, 0xf9, 0x13, 0xc7, 0x12, 0x7d, 0x58,
-	0x42, 0x89, 0x34, 0x1c, 0x76, 0x5d, 0xc6, 0xdd, 0x64, 0x82, 0x01, 0x1b, 0x6e, 0x9f, 0x56, 0x1c,
-	0x51, 0x86, 0x71, 0x2f, 0x24, 0x83, 0x9b, 0x0d, 0xf2, 0x20, 0x1c, 0xa8, 0x5a, 0x99, 0x80, 0xac,
-	0xab, 0x42, 0xbb, 0x93, 0xe5, 0xfe, 0x98, 0xcb, 0x6d, 0x4e, 0x14, 0x30, 0x60, 0x92, 0x03, 0x2a,
-	0xb9, 0x83, 0xee, 0x5f, 0x77, 0xe0, 0x5c, 0xa5, 0x4a, 0x27, 0xd0, 0xa5, 0x83, 0x1c, 0x06, 0x93,
-	0x83, 0x58, 0xfb, 0xda, 0x8e, 0xe9, 0x3b, 0x5b, 0x55, 0x6c, 0x0c, 0x67, 0xd5, 0x7e, 0x8e, 0x73,
-	0x5a, 0xec, 0xde, 0x0d, 0x72, 0x44, 0xde, 0xb6, 0x65, 0xa0, 0xdc, 0xa0, 0xc2, 0x4d, 0xcd, 0xad,
-	0xe7, 0xc7, 0x8e, 0xa0, 0xa7, 0x1d, 0x07, 0x69, 0xe2, 0x0d, 0xe7, 0x02, 0xdb, 0x7a, 0xeb, 0x25,
-	0x6d, 0x91, 0x3d, 0x1a, 0xa9, 0x66, 0x4e, 0xe5, 0xc6, 0x66, 0x70, 0x49, 0xd5, 0x91, 0x0d, 0xaf,
-	0xb6, 0xd7, 0x7c, 0xa5, 0xb1, 0xdd, 0xc3, 0x8f, 0xed, 0x46, 0x5f, 0xc2, 0x98, 0x7d, 0x08, 0x9b,
-	0x27, 0x7e, 0x90, 0xab, 0x6e, 0x19, 0xce, 0xd0, 0x3c, 0x35, 0xb9, 0xf3, 0x92, 0x26, 0x9f, 0x88,
-	0x8f, 0xad, 0x8d, 0xed, 0x14, 0x8e, 0xfd, 0xbf, 0x75, 0xa0, 0x6b, 0xf3, 0x41, 0x31, 0x95, 0x0a,
-	0xaf, 0x0c, 0x9f, 0x72, 0xfe, 0x4a, 0x70, 0x35, 0x44, 0x6d, 0xd4, 0x85, 0xa8, 0x66, 0x20, 0x3a,
-	0xf7, 0xb2, 0x40, 0xb4, 0xf9, 0x6a, 0x81, 0xe8, 0x7c, 0x5d, 0x20, 0xda, 0xff, 0x4f, 0x07, 0x58,
-	0x55, 0x96, 0xd8, 0x7d, 0x11, 0x23, 0x47, 0x3c, 0x94, 0x36, 0xe9, 0xe7, 0x5e, 0x4d, 0x1e, 0xd5,
-	0xdc, 0xa9, 0xaf, 0x51, 0x31, 0x4c, 0xa3, 0x63, 0xba, 0x48, 0xcb, 0x5e, 0x5d, 0x55, 0x29, 0x34,
-	0x6e, 0xbe, 0x3c, 0x34, 0x9e, 0x7f, 0x79, 0x68, 0xbc, 0x50, 0x0e, 0x8d, 0xfb, 0xbf, 0xeb, 0xc0,
-	0x7a, 0xcd, 0xa2, 0xff, 0xec, 0x06, 0x8e, 0xcb, 0x64, 0xd9, 0x82, 0x86, 0x5c, 0x26, 0x13, 0xec,
-	0xff, 0x06, 0x2c, 0x5b, 0x82, 0xfe, 0xb3, 0x6b, 0xbf, 0xec, 0xe5, 0x09, 0x39, 0xb3, 0xb0, 0xfe,
-	0x8f, 0x1a, 0xc0, 0xaa, 0xca, 0xf6, 0x7f, 0xda, 0x87, 0xea, 0x3c, 0xcd, 0xd5, 0xcc, 0xd3, 0xff,
-	0xea, 0x3e, 0xf0, 0x16, 0xac, 0xc9, 0xd3, 0x36, 0x23, 0x4b, 0x22, 0x24, 0xa6, 0x5a, 0x81, 0x7e,
-	0xae, 0x9d, 0x97, 0x58, 0xb2, 0x8e, 0x89, 0x8c, 0xcd, 0xb0, 0x94, 0x9e, 0x70, 0x37, 0x61, 0x43,
-	0x9c, 0xde, 0xdd, 0x12, 0xac, 0xd4, 0xbe, 0xf2, 0x27, 0x0e, 0x9c, 0x2d, 0x55, 0x14, 0x67, 0x29,
-	0x62, 0xeb, 0xb0, 0xf7, 0x13, 0x1b, 0xc4, 0xfe, 0x4b, 0x3d, 0x32, 0xfa, 0x2f, 0xa4, 0xad, 0x5a,
-	0x81, 0xf3, 0x33, 0x8d, 0xaa, 0xf4, 0x62, 0xd6, 0xeb, 0xaa, 0xdc, 0x73, 0x70, 0x56, 0xae, 0x6c,
-	0xa9, 0xe3, 0x87, 0xb0, 0x59, 0xae, 0x28, 0x92, 0xc3, 0x76, 0x97, 0x55, 0x11, 0xbd, 0x40, 0x6b,
-	0x9b, 0xb2, 0xfb, 0x5b, 0x5b, 0xe7, 0xfe, 0x3a, 0xb0, 0xaf, 0x4f, 0x79, 0x3a, 0xa3, 0x93, 0x1e,
-	0x9d, 0x9d, 0x39, 0x57, 0x4e, 0x63, 0x2c, 0x24, 0xd3, 0x83, 0xaf, 0xf2, 0x99, 0x3a, 0x4a, 0x6b,
-	0x14, 0x47, 0x69, 0xaf, 0x01, 0x60, 0xf4, 0x45, 0x47, 0x43, 0xea, 0x70, 0x13, 0xc3, 0x5e, 0xc1,
-	0xd0, 0xbd, 0x01, 0xeb, 0x16, 0x7f, 0x3d, 0xfb, 0x0b, 0xf2, 0x0b, 0x91, 0x1b, 0xb0, 0x0f, 0x9c,
-	0x64, 0x9d, 0xfb, 0xef, 0x0e, 0xcc, 0xed, 0xc6, 0x89, 0x99, 0x55, 0x74, 0xec, 0xac, 0xa2, 0x34,
-	0xf9, 0x03, 0x6d, 0xd1, 0xa5, 0x25, 0xb0, 0x40, 0xb6, 0x05, 0x5d, 0x7f, 0x92, 0x63, 0x74, 0x7c,
-	0x18, 0xa7, 0x27, 0x7e, 0x3a, 0x12, 0x4b, 0x72, 0xab, 0xd1, 0x73, 0xbc, 0x52, 0x0d, 0xdb, 0x80,
-	0x39, 0x6d, 0x1b, 0x89, 0x00, 0x8b, 0xe8, 0x5f, 0x51, 0x72, 0x75, 0x26, 0x03, 0x7b, 0x59, 0xc2,
-	0x15, 0xb7, 0xbf, 0x17, 0x1e, 0xad, 0x90, 0xf0, 0xba, 0x2a, 0xdc, 0x7e, 0xd0, 0x54, 0x12, 0x99,
-	0xcc, 0xc8, 0xa8, 0xb2, 0xfb, 0xaf, 0x0e, 0xcc, 0xd3, 0x0c, 0xa0, 0x4e, 0x0a, 0x41, 0xa4, 0xb3,
-	0x5b, 0xca, 0x04, 0x3b, 0x42, 0x27, 0x4b, 0x30, 0x73, 0xad, 0x13, 0xdd, 0x86, 0xee, 0xb6, 0x79,
-	0xaa, 0x7b, 0x19, 0x5a, 0xa2, 0xa4, 0x8f, 0x41, 0x89, 0xa4, 0x00, 0xd9, 0x25, 0x68, 0x1e, 0xc5,
-	0x89, 0x72, 0x22, 0x40, 0x25, 0x02, 0xe3, 0xc4, 0x23, 0xbc, 0xe8, 0x0f, 0xf2, 0x13, 0x9d, 0x17,
-	0x5b, 0x43, 0x19, 0xc6, 0xcd, 0x51, 0xb3, 0x35, 0x27, 0xa3, 0x84, 0xba, 0x5b, 0xb0, 0xf2, 0x30,
-	0x1e, 0x71, 0x23, 0xf5, 0x73, 0xaa, 0xd4, 0xb9, 0xbf, 0xe9, 0xc0, 0x92, 0x22, 0x66, 0x57, 0xa1,
-	0x89, 0x3b, 0x7e, 0xc9, 0x9f, 0xd7, 0x07, 0x00, 0x48, 0xe7, 0x11, 0x05, 0x9a, 0x48, 0x4a, 0x0c,
-	0x14, 0xde, 0x9f, 0x4a, 0x0b, 0x14, 0xce, 0x8d, 0xee, 0x6e, 0xc9, 0x27, 0x28, 0xa1, 0xee, 0x9f,
-	0x3b, 0xb0, 0x6c, 0xb5, 0x81, 0x51, 0x5c, 0xe8, 0x67, 0xb9, 0x4c, 0xaa, 0xca, 0xe5, 0x31, 0x21,
-	0x33, 0x19, 0xd8, 0xb0, 0x93, 0x81, 0x3a, 0x4d, 0x35, 0x67, 0xa6, 0xa9, 0xae, 0x43, 0xab, 0x38,
-	0x77, 0x6f, 0x5a, 0xa6, 0x0f, 0x5b, 0x54, 0x47, 0x1b, 0x05, 0x11, 0xf2, 0x19, 0xc6, 0x61, 0x9c,
-	0xca, 0x63, 0x69, 0x51, 0x70, 0x6f, 0x40, 0xdb, 0xa0, 0xc7, 0x6e, 0x44, 0x3c, 0x3f, 0x89, 0xd3,
-	0xa7, 0x2a, 0x27, 0x29, 0x8b, 0xfa, 0x04, 0xaf, 0x51, 0x9c, 0xe0, 0xb9, 0x7f, 0xe1, 0xc0, 0x32,
-	0xca, 0x60, 0x10, 0x8d, 0xf7, 0xe2, 0x30, 0x18, 0xce, 0x68, 0xed, 0x95, 0xb8, 0xc9, 0xf3, 0x6a,
-	0x25, 0x8b, 0x36, 0x8c, 0xb2, 0xad, 0x82, 0x38, 0xa9, 0x88, 0xba, 0x8c, 0x9a, 0x8a, 0x72, 0x7e,
-	0xe0, 0x67, 0x52, 0xf8, 0xe5, 0x5e, 0x64, 0x81, 0xa8, 0x4f, 0x08, 0xa4, 0x7e, 0xce, 0x07, 0x93,
-	0x20, 0x0c, 0x03, 0x41, 0x2b, 0x3c, 0x95, 0xba, 0x2a, 0xf7, 0x07, 0x0d, 0x68, 0x4b, 0x4b, 0x79,
-	0x77, 0x34, 0x16, 0xd9, 0x7f, 0xe9, 0xef, 0x69, 0x73, 0x61, 0x20, 0xaa, 0xde, 0xf2, 0x10, 0x0d,
-	0xa4, 0xbc, 0xac, 0x73, 0xd5, 0x65, 0xbd, 0x08, 0x2d, 0x14, 0xaf, 0xb7, 0xc9, 0x15, 0x15, 0xd7,
-	0x34, 0x0a, 0x40, 0xd5, 0xee, 0x50, 0xed, 0x7c, 0x51, 0x4b, 0x80, 0xe5, 0x7c, 0x2e, 0x94, 0x9c,
-	0xcf, 0x77, 0xa1, 0x23, 0xd9, 0xd0, 0xbc, 0x93, 0x75, 0x28, 0x04, 0xdc, 0x5a, 0x13, 0xcf, 0xa2,
-	0x54, 0x5f, 0xee, 0xa8, 0x2f, 0x97, 0x5e, 0xf6, 0xa5, 0xa2, 0xa4, 0xc3, 0x30, 0x31, 0x37, 0xf7,
-	0x53, 0x3f, 0x39, 0x52, 0xbb, 0xcf, 0x48, 0x9f, 0xf0, 0x13, 0xcc, 0xb6, 0x60, 0x1e, 0x3f, 0x53,
-	0xd6, 0xba, 0x5e, 0xe9, 0x04, 0x09, 0xbb, 0x0a, 0xf3, 0x7c, 0x34, 0xe6, 0x2a, 0xd8, 0x62, 0x76,
-	0xd8, 0x8b, 0x6b, 0xe4, 0x09, 0x02, 0x34, 0x01, 0x88, 0x96, 0x4c, 0x80, 0x6d, 0xe9, 0x17, 0xb0,
-	0xf8, 0xfe, 0xc8, 0xdd, 0x00, 0xf6, 0x50, 0x48, 0xad, 0x99, 0x2c, 0xfe, 0x9d, 0x39, 0x68, 0x1b,
-	0x30, 0x6a, 0xf3, 0x18, 0x3b, 0x3c, 0x18, 0x05, 0xfe, 0x84, 0xe7, 0x3c, 0x95, 0x92, 0x5a, 0x42,
-	0x91, 0xce, 0x3f, 0x1e, 0x0f, 0xe2, 0x69, 0x3e, 0x18, 0xf1, 0x71, 0xca, 0xc5, 0x1e, 0x89, 0x9b,
-	0x81, 0x85, 0x22, 0xdd, 0xc4, 0x7f, 0x66, 0xd2, 0x09, 0x79, 0x28, 0xa1, 0x2a, 0xf5, 0x2b, 0xe6,
-	0xa8, 0x59, 0xa4, 0x7e, 0xc5, 0x8c, 0x94, 0xed, 0xd0, 0x7c, 0x8d, 0x1d, 0x7a, 0x07, 0x36, 0x85,
-	0xc5, 0x91, 0xba, 0x39, 0x28, 0x89, 0xc9, 0x29, 0xb5, 0x6c, 0x0b, 0x56, 0xb1, 0xcf, 0x4a, 0xc0,
-	0xb3, 0xe0, 0x63, 0x91, 0x8c, 0x71, 0xbc, 0x0a, 0x8e, 0xb4, 0xa8, 0x8e, 0x16, 0xad, 0x38, 0x1e,
-	0xab, 0xe0, 0x44, 0xeb, 0x3f, 0xb3, 0x69, 0x5b, 0x92, 0xb6, 0x84, 0xbb, 0xcb, 0xd0, 0xde, 0xcf,
-	0xe3, 0x44, 0x2d, 0x4a, 0x17, 0x3a, 0xa2, 0x28, 0x0f, 0x43, 0x2f, 0xc0, 0x79, 0x92, 0xa2, 0x47,
-	0x71, 0x12, 0x87, 0xf1, 0x78, 0xb6, 0x3f, 0x3d, 0xc8, 0x86, 0x69, 0x90, 0x60, 0x60, 0xe2, 0xfe,
-	0x9d, 0x03, 0xeb, 0x56, 0xad, 0xcc, 0xde, 0x7c, 0x5e, 0x88, 0xb4, 0x3e, 0xc5, 0x12, 0x82, 0xb7,
-	0x66, 0x98, 0x43, 0x41, 0x28, 0xf2, 0x66, 0x8f, 0xe5, 0xc1, 0xd6, 0x4d, 0x58, 0x51, 0x3d, 0x53,
-	0x1f, 0x0a, 0x29, 0xec, 0x55, 0xa5, 0x50, 0x7e, 0xdf, 0x95, 0x1f, 0x28, 0x16, 0xbf, 0x28, 0xfc,
-	0x6a, 0x3e, 0xa2, 0x31, 0xaa, 0x30, 0xbe, 0xaf, 0xbe, 0x37, 0x9d, 0x79, 0xd5, 0x83, 0xa1, 0x06,
-	0x33, 0xf7, 0xf7, 0x1d, 0x80, 0xa2, 0x77, 0x28, 0x18, 0x85, 0x49, 0x17, 0x37, 0xfe, 0x0c, 0xf3,
-	0xfd, 0x06, 0x74, 0xf4, 0x01, 0x46, 0xb1, 0x4b, 0xb4, 0x15, 0x86, 0x0e, 0xd7, 0x15, 0x58, 0x19,
-	0x87, 0xf1, 0x01, 0x6d, 0xb1, 0x74, 0xba, 0x9e, 0xc9, 0x23, 0xe1, 0xae, 0x80, 0xef, 0x49, 0xb4,
-	0xd8, 0x52, 0x9a, 0xc6, 0x96, 0xe2, 0x7e, 0xa7, 0xa1, 0xd3, 0xde, 0xc5, 0x98, 0x4f, 0xd5, 0x32,
-	0xb6, 0x53, 0x31, 0x8e, 0xa7, 0x64, 0x99, 0x29, 0x61, 0xb5, 0xf7, 0xd2, 0x78, 0xfa, 0x06, 0x74,
-	0x53, 0x61, 0x7d, 0x94, 0x69, 0x6a, 0xbe, 0xc0, 0x34, 0x2d, 0xa7, 0xd6, 0xbe, 0xf3, 0xff, 0x60,
-	0xd5, 0x1f, 0x1d, 0xf3, 0x34, 0x0f, 0x28, 0xa2, 0xa1, 0x4d, 0x5f, 0x18, 0xd4, 0x15, 0x03, 0xa7,
-	0xbd, 0xf8, 0x0a, 0xac, 0xc8, 0x63, 0x78, 0x4d, 0x29, 0x2f, 0x5f, 0x15, 0x30, 0x12, 0xba, 0xdf,
-	0x57, 0x19, 0x76, 0x7b, 0x0d, 0x4f, 0x9f, 0x11, 0x73, 0x74, 0x8d, 0xd2, 0xe8, 0x3e, 0x23, 0xb3,
-	0xdd, 0x23, 0x15, 0x36, 0xc9, 0x73, 0x07, 0x01, 0xca, 0xd3, 0x09, 0x7b, 0x4a, 0x9b, 0xaf, 0x32,
-	0xa5, 0xee, 0xa7, 0x0e, 0x2c, 0xee, 0xc6, 0xc9, 0xae, 0x3c, 0x51, 0x27, 0x45, 0xd0, 0x97, 0x5c,
-	0x54, 0xd1, 0xf4, 0x8a, 0x1b, 0x15, 0xaf, 0xb8, 0xba, 0xd7, 0x2e, 0x97, 0xf7, 0xda, 0x5f, 0x82,
-	0x0b, 0x14, 0xb4, 0xa7, 0x71, 0x12, 0xa7, 0xa8, 0x8c, 0x7e, 0x28, 0x36, 0xd6, 0x38, 0xca, 0x8f,
-	0x94, 0x19, 0x7b, 0x11, 0x09, 0x45, 0x47, 0x61, 0x7e, 0x3c, 0x10, 0xce, 0xb0, 0xf4, 0x0d, 0x84,
-	0x75, 0xab, 0x56, 0xb8, 0x5f, 0x84, 0x16, 0x39, 0xb7, 0x34, 0xac, 0xb7, 0xa0, 0x75, 0x14, 0x27,
-	0x83, 0xa3, 0x20, 0xca, 0x95, 0x72, 0x77, 0x0b, 0xaf, 0x73, 0x97, 0x26, 0x44, 0x13, 0xb8, 0x3f,
-	0x9a, 0x83, 0xc5, 0xf7, 0xa3, 0xe3, 0x38, 0x18, 0x52, 0x32, 0x7e, 0xc2, 0x27, 0xb1, 0xba, 0xf2,
-	0x83, 0xbf, 0x71, 0x2a, 0xe8, 0xf8, 0x3b, 0xc9, 0x65, 0x36, 0x5d, 0x15, 0x71, 0xbb, 0x4f, 0x8b,
-	0x6b, 0x70, 0x42, 0x75, 0x0c, 0x04, 0x1d, 0xfb, 0xd4, 0xbc, 0x03, 0x28, 0x4b, 0xc5, 0x9d, 0xa9,
-	0x79, 0xe3, 0xce, 0x14, 0x1d, 0xdd, 0x88, 0x93, 0x7d, 0x92, 0xaf, 0x25, 0x4f, 0x15, 0x29, 0x10,
-	0x49, 0xb9, 0x48, 0xb6, 0x90, 0xe3, 0xb0, 0x28, 0x03, 0x11, 0x13, 0x44, 0xe7, 0x42, 0x7c, 0x20,
-	0x68, 0x84, 0xf1, 0x35, 0x21, 0x74, 0xb6, 0xca, 0xd7, 0x08, 0x5b, 0x42, 0xe6, 0x4b, 0x30, 0x5a,
-	0xe8, 0x11, 0xd7, 0x86, 0x54, 0x8c, 0x01, 0xc4, 0x35, 0xbf, 0x32, 0x6e, 0x84, 0x2f, 0xe2, 0x86,
-	0x82, 0x0a, 0x5f, 0x50, 0x50, 0xfc, 0x30, 0x3c, 0xf0, 0x87, 0x4f, 0xe9, 0x72, 0x27, 0x5d, 0x48,
-	0x68, 0x79, 0x36, 0x88, 0xbd, 0x36, 0x56, 0x93, 0x0e, 0xff, 0x9a, 0x9e, 0x09, 0xb1, 0x1d, 0x68,
-	0x53, 0xc8, 0x26, 0xd7, 0xb3, 0x4b, 0xeb, 0xb9, 0x6a, 0xc6, 0x74, 0xb4, 0xa2, 0x26, 0x91, 0x79,
-	0x40, 0xb0, 0x62, 0xdf, 0x19, 0xf8, 0x06, 0xb0, 0x9b, 0xa3, 0x91, 0x5c, 0x6f, 0x1d, 0x32, 0x16,
-	0x2b, 0xe5, 0x58, 0x2b, 0x55, 0x33, 0x63, 0x8d, 0xda, 0x19, 0x73, 0xef, 0x42, 0x7b, 0xcf, 0xb8,
-	0xe1, 0x49, 0xa2, 0xa1, 0xee, 0x76, 0x4a, 0x71, 0x32, 0x10, 0xa3, 0xc1, 0x86, 0xd9, 0xa0, 0xfb,
-	0xf3, 0xc0, 0x1e, 0x04, 0x59, 0xae, 0xfb, 0x27, 0x96, 0xe3, 0x0d, 0xe8, 0xe8, 0x00, 0xbb, 0xb8,
-	0xd1, 0xd0, 0x96, 0x18, 0xdd, 0x34, 0xb8, 0x29, 0xae, 0x42, 0x94, 0x07, 0xb6, 0x05, 0x4b, 0x81,
-	0x80, 0xca, 0x9a, 0xa0, 0x28, 0x75, 0x3d, 0xfa, 0x6b, 0x12, 0xb4, 0x76, 0xd1, 0x1f, 0x38, 0xb0,
-	0x28, 0x87, 0x86, 0xde, 0x86, 0x75, 0xb7, 0x55, 0x0c, 0xcc, 0xc2, 0xea, 0x6f, 0x04, 0x56, 0x65,
-	0x78, 0xae, 0x4e, 0x86, 0x19, 0x34, 0x13, 0x3f, 0x3f, 0xa2, 0x00, 0xa5, 0xe5, 0xd1, 0x6f, 0xb6,
-	0x2a, 0x82, 0x66, 0xa1, 0x2b, 0x14, 0x30, 0xd7, 0x5d, 0x42, 0x15, 0x26, 0xb9, 0x82, 0xe3, 0xa0,
-	0xe8, 0xf2, 0x80, 0xc0, 0xf5, 0x99, 0x80, 0xbc, 0x98, 0x51, 0xc0, 0xc5, 0x7c, 0x49, 0x16, 0xe5,
-	0xf9, 0x92, 0xa4, 0x9e, 0xae, 0x77, 0xfb, 0xd0, 0xbb, 0xc3, 0x43, 0x9e, 0xf3, 0x9b, 0x61, 0x58,
-	0xe6, 0x7f, 0x01, 0xce, 0xd7, 0xd4, 0x49, 0xa7, 0xe5, 0x1e, 0xac, 0xdd, 0xe1, 0x07, 0xd3, 0xf1,
-	0x03, 0x7e, 0x5c, 0x1c, 0xdc, 0x31, 0x68, 0x66, 0x47, 0xf1, 0x89, 0x5c, 0x5b, 0xfa, 0xcd, 0x5e,
-	0x03, 0x08, 0x91, 0x66, 0x90, 0x25, 0x7c, 0xa8, 0xee, 0xc2, 0x11, 0xb2, 0x9f, 0xf0, 0xa1, 0xfb,
-	0x0e, 0x30, 0x93, 0x8f, 0x1c, 0x02, 0xda, 0x81, 0xe9, 0xc1, 0x20, 0x9b, 0x65, 0x39, 0x9f, 0xa8,
-	0x4b, 0x7e, 0x26, 0xe4, 0x5e, 0x81, 0xce, 0x9e, 0x3f, 0xf3, 0xf8, 0x47, 0xf2, 0x7a, 0x31, 0xc6,
-	0xc6, 0xfe, 0x0c, 0x45, 0x59, 0xc7, 0xc6, 0x54, 0xed, 0xfe, 0x47, 0x03, 0x16, 0x04, 0x25, 0x72,
-	0x1d, 0xf1, 0x2c, 0x0f, 0x22, 0x71, 0x68, 0x25, 0xb9, 0x1a, 0x50, 0x45, 0x36, 0x1a, 0x35, 0xb2,
-	0x21, 0xbd, 0x55, 0x75, 0xaf, 0x48, 0x0a, 0x81, 0x85, 0xa1, 0x5b, 0x53, 0x5c, 0x06, 0x10, 0xc1,
-	0x59, 0x01, 0x94, 0x92, 0x25, 0x85, 0xb5, 0x11, 0xfd, 0x53, 0x42, 0x2b, 0xc5, 0xc1, 0x84, 0x6a,
-	0x6d, 0xda, 0xa2, 0x90, 0x9a, 0x8a, 0x4d, 0xab, 0xd8, 0xae, 0xa5, 0x57, 0xb0, 0x5d, 0xc2, 0x85,
-	0x7d, 0x91, 0xed, 0x82, 0x57, 0xb0, 0x5d, 0x2e, 0x83, 0xd5, 0x7b, 0x9c, 0x7b, 0x1c, 0x77, 0x45,
-	0x25, 0x4e, 0xdf, 0x75, 0x60, 0x55, 0x6e, 0xe8, 0xba, 0x8e, 0xbd, 0x61, 0xed, 0xfe, 0x4e, 0xdd,
-	0x79, 0xc4, 0x9b, 0xb0, 0x4c, 0x7b, 0xb2, 0xce, 0x0a, 0xc9, 0x14, 0x96, 0x05, 0xe2, 0x38, 0x54,
-	0x86, 0x7d, 0x12, 0x84, 0x72, 0x51, 0x4c, 0x48, 0x25, 0x96, 0x30, 0x3e, 0xa6, 0x25, 0x71, 0x3c,
-	0x5d, 0x76, 0xff, 0xca, 0x81, 0x35, 0xa3, 0xc3, 0x52, 0x0a, 0x6f, 0x80, 0xba, 0x2c, 0x20, 0x92,
-	0x47, 0x42, 0x99, 0xce, 0xd9, 0xce, 0x49, 0xf1, 0x99, 0x45, 0x4c, 0x8b, 0xe9, 0xcf, 0xa8, 0x83,
-	0xd9, 0x74, 0x22, 0x3d, 0x10, 0x13, 0x42, 0x41, 0x3a, 0xe1, 0xfc, 0xa9, 0x26, 0x99, 0x23, 0x12,
-	0x0b, 0xa3, 0xb3, 0x60, 0xf4, 0x25, 0x34, 0x91, 0xb8, 0xfe, 0x64, 0x83, 0xee, 0x3f, 0x38, 0xb0,
-	0x2e, 0x9c, 0x42, 0xe9, 0x72, 0xeb, 0xab, 0x99, 0x0b, 0xc2, 0x0b, 0x16, 0x1a, 0xb9, 0x7b, 0xc6,
-	0x93, 0x65, 0xf6, 0x85, 0x57, 0x74, 0x64, 0xf5, 0x1d, 0x80, 0x53, 0xd6, 0x62, 0xae, 0x6e, 0x2d,
-	0x5e, 0x30, 0xd3, 0x75, 0xc9, 0x92, 0xf9, 0xda, 0x64, 0xc9, 0xad, 0x45, 0x98, 0xcf, 0x86, 0x71,
-	0xc2, 0xdd, 0x4d, 0xd8, 0xb0, 0x07, 0x27, 0x4d, 0xd0, 0xf7, 0x1c, 0xe8, 0xdd, 0x13, 0xa9, 0xc3,
-	0x20, 0x1a, 0xef, 0x06, 0x59, 0x1e, 0xa7, 0xfa, 0x2e, 0xfa, 0x25, 0x80, 0x2c, 0xf7, 0xd3, 0x5c,
-	0xdc, 0xd1, 0x92, 0x69, 0x8e, 0x02, 0xc1, 0x3e, 0xf2, 0x68, 0x24, 0x6a, 0xc5, 0xda, 0xe8, 0x32,
-	0x2e, 0x0c, 0xdd, 0x4f, 0x18, 0xc4, 0x87, 0x87, 0x19, 0xd7, 0x6e, 0xab, 0x89, 0x61, 0xe4, 0x8b,
-	0x1a, 0x8f, 0xb1, 0x1e, 0x3f, 0x26, 0x53, 0x2b, 0xfc, 0xc1, 0x12, 0xea, 0xfe, 0xa5, 0x03, 0x2b,
-	0x45, 0x27, 0xef, 0x22, 0x68, 0x5b, 0x07, 0xd1, 0x35, 0xc3, 0x3a, 0xa8, 0x04, 0x4c, 0x30, 0x1a,
-	0x04, 0x91, 0xec, 0x9b, 0x81, 0x90, 0xc6, 0xca, 0x52, 0x3c, 0x55, 0xf7, 0xe1, 0x4c, 0x48, 0x1c,
-	0x76, 0xe7, 0xf8, 0xb5, 0xb8, 0x0c, 0x27, 0x4b, 0x74, 0xc5, 0x6e, 0x92, 0xd3, 0x57, 0x0b, 0xc2,
-	0x21, 0x96, 0x45, 0xb5, 0x3f, 0x2d, 0x12, 0x8a, 0x3f, 0xdd, 0x3f, 0x70, 0xe0, 0x7c, 0xcd, 0xe4,
-	0x4a, 0xcd, 0xb8, 0x03, 0x6b, 0x87, 0xba, 0x52, 0x4d, 0x80, 0x50, 0x8f, 0x4d, 0x29, 0x45, 0xa5,
-	0x41, 0x7b, 0xd5, 0x0f, 0xd0, 0x3d, 0xa6, 0xbc, 0x91, 0x98, 0x52, 0xeb, 0x9e, 0x48, 0xb5, 0x62,
-	0xe7, 0xfb, 0x0d, 0xe8, 0x8a, 0xa3, 0x0a, 0xf1, 0x1a, 0x89, 0xa7, 0xec, 0x03, 0x58, 0x94, 0xaf,
-	0xc9, 0xd8, 0x59, 0xd9, 0xac, 0xfd, 0x7e, 0xad, 0xbf, 0x59, 0x86, 0xa5, 0xec, 0xac, 0xff, 0xf6,
-	0xa7, 0xff, 0xfc, 0x87, 0x8d, 0x65, 0xd6, 0xde, 0x3e, 0x7e, 0x7b, 0x7b, 0xcc, 0xa3, 0x0c, 0x79,
-	0xfc, 0x2a, 0x40, 0xf1, 0xce, 0x8a, 0xf5, 0xb4, 0x93, 0x51, 0x7a, 0x40, 0xd6, 0x3f, 0x5f, 0x53,
-	0x23, 0xf9, 0x9e, 0x27, 0xbe, 0xeb, 0x6e, 0x17, 0xf9, 0x06, 0x51, 0x90, 0x8b, 0x47, 0x57, 0xef,
-	0x39, 0x5b, 0x6c, 0x04, 0x1d, 0xf3, 0x19, 0x15, 0x53, 0x21, 0x73, 0xcd, 0x23, 0xae, 0xfe, 0x85,
-	0xda, 0x3a, 0x95, 0x2f, 0xa0, 0x36, 0xce, 0xba, 0xab, 0xd8, 0xc6, 0x94, 0x28, 0x74, 0x2b, 0x3b,
-	0xff, 0x78, 0x01, 0x5a, 0x3a, 0xed, 0xc4, 0x3e, 0x84, 0x65, 0xeb, 0x74, 0x87, 0x29, 0xc6, 0x75,
-	0x87, 0x41, 0xfd, 0x8b, 0xf5, 0x95, 0xb2, 0xd9, 0x4b, 0xd4, 0x6c, 0x8f, 0x6d, 0x62, 0xb3, 0xf2,
-	0x78, 0x64, 0x9b, 0xce, 0xb4, 0xc4, 0x95, 0xba, 0xa7, 0xd0, 0xb5, 0x4f, 0x64, 0xd8, 0x45, 0xdb,
-	0xa0, 0x94, 0x5a, 0x7b, 0xed, 0x94, 0x5a, 0xd9, 0xdc, 0x45, 0x6a, 0x6e, 0x93, 0x6d, 0x98, 0xcd,
-	0xe9, 0x74, 0x10, 0xa7, 0x4b, 0x90, 0xe6, 0xfb, 0x2a, 0xf6, 0x9a, 0x5e, 0xea, 0xba, 0x77, 0x57,
-	0x7a, 0xd1, 0xaa, 0x8f, 0xaf, 0xdc, 0x1e, 0x35, 0xc5, 0x18, 0x4d, 0xa8, 0xf9, 0xbc, 0x8a, 0x7d,
-	0x0b, 0x5a, 0xfa, 0x4d, 0x05, 0x3b, 0x67, 0x3c, 0x64, 0x31, 0x1f, 0x7a, 0xf4, 0x7b, 0xd5, 0x8a,
-	0xba, 0xa5, 0x32, 0x39, 0xa3, 0x40, 0x3c, 0x80, 0xb3, 0xd2, 0x49, 0x3d, 0xe0, 0x3f, 0xce, 0x48,
-	0x6a, 0x5e, 0x85, 0x5d, 0x77, 0xd8, 0x0d, 0x58, 0x52, 0x4f, 0x55, 0xd8, 0x66, 0xfd, 0x93, 0x9b,
-	0xfe, 0xb9, 0x0a, 0x2e, 0xf5, 0xf9, 0x26, 0x40, 0xf1, 0xcc, 0x42, 0x4b, 0x7e, 0xe5, 0xf1, 0x87,
-	0x9e, 0xc4, 0x9a, 0x37, 0x19, 0x63, 0x7a, 0x54, 0x62, 0xbf, 0xe2, 0x60, 0xaf, 0x17, 0xf4, 0xb5,
-	0xef, 0x3b, 0x5e, 0xc0, 0xd0, 0xdd, 0xa4, 0xb9, 0x5b, 0x65, 0xa4, 0x4a, 0x11, 0x3f, 0x51, 0xd7,
-	0x81, 0xef, 0x40, 0xdb, 0x78, 0xba, 0xc1, 0x14, 0x87, 0xea, 0xb3, 0x8f, 0x7e, 0xbf, 0xae, 0x4a,
-	0x76, 0xf7, 0x2b, 0xb0, 0x6c, 0xbd, 0xc1, 0xd0, 0x9a, 0x51, 0xf7, 0xc2, 0x43, 0x6b, 0x46, 0xfd,
-	0xb3, 0x8d, 0x6f, 0x42, 0xdb, 0x78, 0x31, 0xc1, 0x8c, 0x6b, 0x50, 0xa5, 0xb7, 0x12, 0xba, 0x47,
-	0x75, 0x0f, 0x2c, 0x36, 0x68, 0xbc, 0x5d, 0xb7, 0x85, 0xe3, 0xa5, 0x3b, 0xb1, 0x28, 0x24, 0x1f,
-	0x42, 0xd7, 0x7e, 0x43, 0xa1, 0xb5, 0xaa, 0xf6, 0x35, 0x86, 0xd6, 0xaa, 0x53, 0x1e, 0x5e, 0x48,
-	0x81, 0xdc, 0x5a, 0xd7, 0x8d, 0x6c, 0x7f, 0x22, 0x0f, 0x5d, 0x9e, 0xb3, 0xaf, 0xa3, 0xe9, 0x90,
-	0x97, 0x94, 0x59, 0xf1, 0x72, 0xc4, 0xbe, 0xca, 0xac, 0xa5, 0xbd, 0x72, 0x9f, 0xd9, 0x5d, 0x23,
-	0xe6, 0x6d, 0x56, 0x8c, 0x40, 0x58, 0x68, 0xba, 0xac, 0x6c, 0x58, 0x68, 0xf3, 0x3e, 0xb3, 0x61,
-	0xa1, 0xad, 0x3b, 0xcd, 0x65, 0x0b, 0x9d, 0x07, 0xc8, 0x23, 0x82, 0x95, 0xd2, 0x3d, 0x00, 0xad,
-	0x2c, 0xf5, 0x17, 0xa7, 0xfa, 0x97, 0x5e, 0x7c, 0x7d, 0xc0, 0x36, 0x33, 0xca, 0xbc, 0x6c, 0xab,
-	0x7b, 0x6e, 0xbf, 0x06, 0x1d, 0xf3, 0xee, 0xbb, 0xb6, 0xd9, 0x35, 0x37, 0xf6, 0xb5, 0xcd, 0xae,
-	0xbb, 0x2c, 0xaf, 0x16, 0x97, 0x75, 0xcc, 0x66, 0xd8, 0x37, 0x61, 0xc5, 0xb8, 0xf8, 0xb2, 0x3f,
-	0x8b, 0x86, 0x5a, 0x78, 0xaa, 0xd7, 0x22, 0xfb, 0x75, 0xfe, 0x99, 0x7b, 0x8e, 0x18, 0xaf, 0xb9,
-	0x16, 0x63, 0x14, 0x9c, 0xdb, 0xd0, 0x36, 0x2f, 0xd5, 0xbc, 0x80, 0xef, 0x39, 0xa3, 0xca, 0xbc,
-	0x21, 0x78, 0xdd, 0x61, 0x7f, 0xec, 0x40, 0xc7, 0xba, 0xa2, 0x62, 0xe5, 0x79, 0x4b, 0x7c, 0x7a,
-	0x66, 0x9d, 0xc9, 0xc8, 0xf5, 0xa8, 0x93, 0x0f, 0xb6, 0xbe, 0x62, 0x4d, 0xf2, 0x27, 0x96, 0x9f,
-	0x7f, 0xad, 0xfc, 0xac, 0xf1, 0x79, 0x99, 0xc0, 0xbc, 0x3a, 0xfa, 0xfc, 0xba, 0xc3, 0xde, 0x13,
-	0x4f, 0x5f, 0x55, 0x5c, 0xcf, 0x0c, 0xe3, 0x56, 0x9e, 0x32, 0xf3, 0x95, 0xe8, 0x55, 0xe7, 0xba,
-	0xc3, 0xbe, 0x2d, 0x5e, 0x2f, 0xca, 0x6f, 0x69, 0xe6, 0x5f, 0xf5, 0x7b, 0xf7, 0x4d, 0x1a, 0xcd,
-	0x25, 0xf7, 0xbc, 0x35, 0x9a, 0xb2, 0x75, 0xdf, 0x03, 0x28, 0x92, 0x34, 0xac, 0x94, 0xb1, 0xd0,
-	0x76, 0xaf, 0x9a, 0xc7, 0xb1, 0x57, 0x54, 0x25, 0x36, 0x90, 0xe3, 0xb7, 0x84, 0x30, 0x4a, 0xfa,
-	0x4c, 0x2f, 0x69, 0x35, 0xd9, 0xd2, 0xef, 0xd7, 0x55, 0xd5, 0x89, 0xa2, 0xe2, 0xcf, 0x1e, 0xc3,
-	0xf2, 0x83, 0x38, 0x7e, 0x3a, 0x4d, 0x74, 0x1a, 0xd1, 0xce, 0x19, 0xec, 0xfa, 0xd9, 0x51, 0xbf,
-	0x34, 0x0a, 0xf7, 0x32, 0xb1, 0xea, 0xb3, 0x9e, 0xc1, 0x6a, 0xfb, 0x93, 0x22, 0x45, 0xf4, 0x9c,
-	0xf9, 0xb0, 0xa6, 0xf7, 0x38, 0xdd, 0xf1, 0xbe, 0xcd, 0xc6, 0xcc, 0xd4, 0x54, 0x9a, 0xb0, 0xbc,
-	0x0e, 0xd5, 0xdb, 0xed, 0x4c, 0xf1, 0xbc, 0xee, 0xb0, 0x3d, 0xe8, 0xdc, 0xe1, 0xc3, 0x78, 0xc4,
-	0x65, 0x94, 0xbf, 0x5e, 0x74, 0x5c, 0xa7, 0x07, 0xfa, 0xcb, 0x16, 0x68, 0x6b, 0x7d, 0xe2, 0xcf,
-	0x52, 0xfe, 0xd1, 0xf6, 0x27, 0x32, 0x7f, 0xf0, 0x5c, 0x69, 0xbd, 0xca, 0x79, 0x58, 0x5a, 0x5f,
-	0x4a, 0x92, 0x58, 0x5a, 0x5f, 0x49, 0x92, 0x58, 0x53, 0xad, 0x72, 0x2e, 0x2c, 0x84, 0xb5, 0x4a,
-	0x5e, 0x45, 0xef, 0x94, 0xa7, 0x65, 0x63, 0xfa, 0x97, 0x4f, 0x27, 0xb0, 0x5b, 0xdb, 0xb2, 0x5b,
-	0xdb, 0x87, 0xe5, 0x3b, 0x5c, 0x4c, 0x96, 0x38, 0xab, 0xec, 0xdb, 0x66, 0xc4, 0x3c, 0xd7, 0x2c,
-	0x9b, 0x18, 0xaa, 0xb3, 0xcd, 0x3a, 0x1d, 0x14, 0xb2, 0x6f, 0x41, 0xfb, 0x3e, 0xcf, 0xd5, 0xe1,
-	0xa4, 0xf6, 0x37, 0x4a, 0xa7, 0x95, 0xfd, 0x9a, 0xb3, 0x4d, 0x5b, 0x66, 0x88, 0xdb, 0x36, 0x1f,
-	0x8d, 0xb9, 0x50, 0xf6, 0x41, 0x30, 0x7a, 0xce, 0x7e, 0x99, 0x98, 0xeb, 0xfb, 0x0c, 0x9b, 0xc6,
-	0x99, 0x96, 0xc9, 0x7c, 0xa5, 0x84, 0xd7, 0x71, 0x8e, 0xe2, 0x11, 0x37, 0x36, 0xb8, 0x08, 0xda,
-	0xc6, 0x65, 0x1b, 0xad, 0x40, 0xd5, 0x0b, 0x3e, 0x5a, 0x81, 0x6a, 0xee, 0xe6, 0xb8, 0x57, 0xa9,
-	0x1d, 0x97, 0x5d, 0x2e, 0xda, 0x11, 0xf7, 0x71, 0x8a, 0x96, 0xb6, 0x3f, 0xf1, 0x27, 0xf9, 0x73,
-	0xf6, 0x84, 0x5e, 0xef, 0x98, 0x07, 0xb0, 0x85, 0xbf, 0x53, 0x3e, 0xab, 0xd5, 0x93, 0x65, 0x54,
-	0xd9, 0x3e, 0x90, 0x68, 0x8a, 0xf6, 0xc1, 0x2f, 0x00, 0xec, 0xe7, 0x71, 0x72, 0xc7, 0xe7, 0x93,
-	0x38, 0x2a, 0x2c, 0x57, 0x71, 0xc8, 0x58, 0x58, 0x2e, 0xe3, 0xa4, 0x91, 0x3d, 0x31, 0x3c, 0x4e,
-	0xeb, 0xfc, 0x5a, 0x09, 0xd7, 0xa9, 0xe7, 0x90, 0x7a, 0x42, 0x6a, 0xce, 0x22, 0xaf, 0x3b, 0xe8,
-	0x3f, 0x16, 0x59, 0x3c, 0xed, 0x3f, 0x56, 0x12, 0x84, 0xda, 0xec, 0xd5, 0xa4, 0xfc, 0xf6, 0xa0,
-	0x55, 0xa4, 0x85, 0xd4, 0x96, 0x54, 0x4e, 0x22, 0xe9, 0x3d, 0xa6, 0x92, 0xac, 0x71, 0x57, 0x69,
-	0xaa, 0x80, 0x2d, 0xe1, 0x54, 0x51, 0x06, 0x26, 0x80, 0x75, 0xd1, 0x41, 0xbd, 0x61, 0xd2, 0xb1,
-	0x99, 0x1a, 0x49, 0x4d, 0xc2, 0x44, 0x6b, 0x73, 0x6d, 0xbe, 0xc1, 0x8a, 0xed, 0x50, 0x5a, 0xc5,
-	0x91, 0x1d, 0x9a, 0xe6, 0x09, 0xac, 0x55, 0x82, 0x65, 0xad, 0xd2, 0xa7, 0xe5, 0x28, 0xb4, 0x4a,
-	0x9f, 0x1a, 0x67, 0xbb, 0x67, 0xa9, 0xc9, 0x15, 0x17, 0xb0, 0xc9, 0xec, 0x24, 0xc8, 0x87, 0x47,
-	0xef, 0x39, 0x5b, 0x07, 0x0b, 0xf4, 0x77, 0x2d, 0x9f, 0xfb, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x5c, 0x78, 0xf8, 0x82, 0xe0, 0x45, 0x00, 0x00,
+	// 7323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x7c, 0xc9, 0x6f, 0x24, 0xc9,
+	0x75, 0x77, 0x67, 0x55, 0x71, 0xa9, 0x57, 0x45, 0x56, 0x31, 0xc8, 0x26, 0xab, 0xb3, 0xd7, 0xc9,
+	0x19, 0x4d, 0xf7, 0xd7, 0xdf, 0xa8, 0xd9, 0xc3, 0x91, 0x46, 0xa3, 0x19, 0x6d, 0xdc, 0xba, 0xd9,
+	0x12, 0xbb, 0x49, 0x25, 0xd9, 0x9a, 0xef, 0xd3, 0x82, 0x54, 0xb2, 0x2a, 0x48, 0xe6, 0x74, 0x55,
+	0x66, 0x4d, 0x66, 0x16, 0xd9, 0xd4, 0xa8, 0x81, 0x6f, 0x31, 0xe4, 0x83, 0x2d, 0x18, 0x86, 0x0d,
+	0x18, 0x32, 0x60, 0x18, 0x90, 0x2e, 0xf6, 0x1f, 0xe0, 0x93, 0x6c, 0xc0, 0x07, 0x9f, 0x0c, 0xd8,
+	0x3e, 0xe8, 0x64, 0x08, 0xb0, 0x61, 0xd8, 0x17, 0x5b, 0x07, 0x1b, 0x06, 0x74, 0xb4, 0x61, 0xbc,
+	0xd8, 0x32, 0x22, 0x33, 0xab, 0x49, 0x2d, 0xb6, 0x6f, 0x19, 0xbf, 0xf7, 0x32, 0xd6, 0x17, 0x2f,
+	0x5e, 0xbc, 0x78, 0x11, 0x50, 0x8f, 0x87, 0xdd, 0x7b, 0xc3, 0x38, 0x4a, 0x23, 0x32, 0xd1, 0x0f,
+	0xe3, 0x61, 0xd7, 0xbe, 0x76, 0x14, 0x45, 0x47, 0x7d, 0xba, 0xec, 0x0f, 0x83, 0x65, 0x3f, 0x0c,
+	0xa3, 0xd4, 0x4f, 0x83, 0x28, 0x4c, 0x38, 0x93, 0xf3, 0x4d, 0x98, 0x7d, 0x48, 0xc3, 0x3d, 0x4a,
+	0x7b, 0x2e, 0xfd, 0x70, 0x44, 0x93, 0x94, 0xfc, 0x4f, 0x98, 0xf3, 0xe9, 0xb7, 0x28, 0xed, 0x79,
+	0x43, 0x3f, 0x49, 0x86, 0xc7, 0xb1, 0x9f, 0xd0, 0x8e, 0x75, 0xcb, 0xba, 0xd3, 0x74, 0xdb, 0x9c,
+	0xb0, 0xab, 0x70, 0xf2, 0x0a, 0x34, 0x13, 0x64, 0xa5, 0x61, 0x1a, 0x47, 0xc3, 0xb3, 0x4e, 0x85,
+	0xf1, 0x35, 0x10, 0xdb, 0xe4, 0x90, 0xd3, 0x87, 0x96, 0x2a, 0x21, 0x19, 0x46, 0x61, 0x42, 0xc9,
+	0x7d, 0x58, 0xe8, 0x06, 0xc3, 0x63, 0x1a, 0x7b, 0xec, 0xe7, 0x41, 0x48, 0x07, 0x51, 0x18, 0x74,
+	0x3b, 0xd6, 0xad, 0xea, 0x9d, 0xba, 0x4b, 0x38, 0x0d, 0xff, 0x78, 0x2c, 0x28, 0xe4, 0x36, 0xb4,
+	0x68, 0xc8, 0x71, 0xda, 0x63, 0x7f, 0x89, 0xa2, 0x66, 0x33, 0x18, 0x7f, 0x70, 0xfe, 0xcc, 0x82,
+	0xb9, 0x47, 0x61, 0x90, 0xbe, 0xef, 0xf7, 0xfb, 0x34, 0x95, 0x6d, 0xba, 0x0d, 0xad, 0x53, 0x06,
+	0xb0, 0x36, 0x9d, 0x46, 0x71, 0x4f, 0xb4, 0x68, 0x96, 0xc3, 0xbb, 0x02, 0x1d, 0x5b, 0xb3, 0xca,
+	0xd8, 0x9a, 0x95, 0x76, 0x57, 0x75, 0x4c, 0x77, 0xdd, 0x86, 0x56, 0x4c, 0xbb, 0xd1, 0x09, 0x8d,
+	0xcf, 0xbc, 0xd3, 0x20, 0xec, 0x45, 0xa7, 0x9d, 0xda, 0x2d, 0xeb, 0xce, 0x84, 0x3b, 0x2b, 0xe1,
+	0xf7, 0x19, 0xea, 0x2c, 0x00, 0xd1, 0x5b, 0xc1, 0xfb, 0xcd, 0x39, 0x82, 0xf9, 0xa7, 0x61, 0x3f,
+	0xea, 0x3e, 0xfb, 0x39, 0x5b, 0x57, 0x52, 0x7c, 0xa5, 0xb4, 0xf8, 0x45, 0x58, 0x30, 0x0b, 0x12,
+	0x15, 0xf8, 0x5e, 0x05, 0x1a, 0xfb, 0xb1, 0x1f, 0x26, 0x7e, 0x17, 0x85, 0x88, 0x74, 0x60, 0x2a,
+	0x7d, 0xee, 0x1d, 0xfb, 0xc9, 0x31, 0x2b, 0xb1, 0xee, 0xca, 0x24, 0x59, 0x84, 0x49, 0x7f, 0x10,
+	0x8d, 0xc2, 0x94, 0x95, 0x50, 0x75, 0x45, 0x8a, 0xbc, 0x01, 0x73, 0xe1, 0x68, 0xe0, 0x75, 0xa3,
+	0xf0, 0x30, 0x88, 0x07, 0x5c, 0x14, 0x59, 0x77, 0x4d, 0xb8, 0x45, 0x02, 0xb9, 0x01, 0x70, 0x80,
+	0xd5, 0xe0, 0x45, 0xd4, 0x58, 0x11, 0x1a, 0x42, 0x1c, 0x68, 0x8a, 0x14, 0x0d, 0x8e, 0x8e, 0xd3,
+	0xce, 0x04, 0xcb, 0xc8, 0xc0, 0x30, 0x8f, 0x34, 0x18, 0x50, 0x2f, 0x49, 0xfd, 0xc1, 0xb0, 0x33,
+	0xc9, 0x6a, 0xa3, 0x21, 0x8c, 0x1e, 0xa5, 0x7e, 0xdf, 0x3b, 0xa4, 0x34, 0xe9, 0x4c, 0x09, 0xba,
+	0x42, 0xc8, 0xeb, 0x30, 0xdb, 0xa3, 0x49, 0xea, 0xf9, 0xbd, 0x5e, 0x4c, 0x93, 0x84, 0x26, 0x9d,
+	0x69, 0x26, 0x0c, 0x39, 0xd4, 0xe9, 0xc0, 0xe2, 0x43, 0x9a, 0x6a, 0xbd, 0x93, 0x88, 0xf1, 0x71,
+	0xb6, 0x81, 0x68, 0xf0, 0x06, 0x4d, 0xfd, 0xa0, 0x9f, 0x90, 0xb7, 0xa1, 0x99, 0x6a, 0xcc, 0x4c,
+	0xf8, 0x1b, 0x2b, 0xe4, 0x1e, 0x9b, 0xb5, 0xf7, 0xb4, 0x1f, 0x5c, 0x83, 0xcf, 0xf9, 0x9d, 0x0a,
+	0x34, 0xf6, 0x68, 0xa8, 0xe6, 0x2b, 0x81, 0x1a, 0xd6, 0x44, 0x0c, 0x39, 0xfb, 0x26, 0x37, 0xa1,
+	0xc1, 0x6a, 0x97, 0xa4, 0x71, 0x10, 0x1e, 0xb1, 0x21, 0xa8, 0xbb, 0x80, 0xd0, 0x1e, 0x43, 0x48,
+	0x1b, 0xaa, 0xfe, 0x20, 0x65, 0x1d, 0x5f, 0x75, 0xf1, 0x13, 0x67, 0xf2, 0xd0, 0x3f, 0x1b, 0xd0,
+	0x30, 0xcd, 0x3a, 0xbb, 0xe9, 0x36, 0x04, 0xb6, 0x85, 0xbd, 0x7d, 0x0f, 0xe6, 0x75, 0x16, 0x99,
+	0xfb, 0x04, 0xcb, 0x7d, 0x4e, 0xe3, 0x14, 0x85, 0xdc, 0x86, 0x96, 0xe4, 0x8f, 0x79, 0x65, 0x59,
+	0xf7, 0xd7, 0xdd, 0x59, 0x01, 0xcb, 0x26, 0xdc, 0x81, 0xf6, 0x61, 0x10, 0xfa, 0x7d, 0xaf, 0xdb,
+	0x4f, 0x4f, 0xbc, 0x1e, 0xed, 0xa7, 0x3e, 0x1b, 0x88, 0x09, 0x77, 0x96, 0xe1, 0xeb, 0xfd, 0xf4,
+	0x64, 0x03, 0x51, 0x72, 0x15, 0xea, 0x87, 0x94, 0x7a, 0xfd, 0x60, 0x10, 0xa4, 0x9d, 0x69, 0x56,
+	0xfb, 0xe9, 0x43, 0x4a, 0xb7, 0x31, 0xed, 0xfc, 0xb6, 0x05, 0x4d, 0xde, 0x33, 0x42, 0xcf, 0xbc,
+	0x06, 0x33, 0xb2, 0x02, 0x34, 0x8e, 0xa3, 0x58, 0x08, 0xa9, 0x09, 0x92, 0xbb, 0xd0, 0x96, 0xc0,
+	0x30, 0xa6, 0xc1, 0xc0, 0x3f, 0xa2, 0x42, 0xb9, 0x14, 0x70, 0xb2, 0x92, 0xe5, 0x18, 0x47, 0xa3,
+	0x94, 0xcf, 0xf4, 0xc6, 0x4a, 0x53, 0x8c, 0x9a, 0x8b, 0x98, 0x6b, 0xb2, 0x38, 0xdf, 0xb5, 0x80,
+	0x60, 0xb5, 0xf6, 0x23, 0x4e, 0x16, 0x8d, 0xce, 0x77, 0xb8, 0x75, 0xe1, 0x0e, 0xaf, 0x8c, 0xeb,
+	0xf0, 0xd7, 0x60, 0x92, 0x15, 0x89, 0x33, 0xaa, 0x5a, 0xa8, 0x96, 0xa0, 0x39, 0x14, 0xe6, 0xf7,
+	0x63, 0xbf, 0xfb, 0x6c, 0xd7, 0x1c, 0x04, 0xad, 0x1b, 0x64, 0x61, 0xa2, 0xbf, 0x0a, 0x38, 0xce,
+	0x3b, 0xa3, 0xee, 0xbc, 0xbb, 0x0c, 0x0c, 0x75, 0x88, 0x5e, 0x8c, 0x9a, 0x0d, 0x7f, 0x59, 0x81,
+	0x19, 0x81, 0x3d, 0x1d, 0xf6, 0xfc, 0x94, 0x16, 0x72, 0xb3, 0x8a, 0xb9, 0x91, 0x4f, 0xc1, 0x44,
+	0x92, 0xfa, 0x29, 0x1f, 0x99, 0xd9, 0x95, 0x57, 0x44, 0xcb, 0x8c, 0x8c, 0x64, 0x6a, 0x0f, 0x19,
+	0x5d, 0xce, 0x4f, 0x1c, 0x98, 0x18, 0x3f, 0x52, 0x9c, 0x54, 0x2a, 0x01, 0xb5, 0x31, 0x12, 0x60,
+	0x03, 0x0a, 0x9c, 0x37, 0x48, 0x7c, 0xae, 0x6e, 0xaa, 0xae, 0x4a, 0xa3, 0xaa, 0x38, 0xf4, 0x83,
+	0xfe, 0x28, 0xa6, 0x5e, 0x4c, 0xfd, 0x24, 0x0a, 0xa5, 0xbc, 0x9b, 0xa8, 0xb3, 0x0d, 0x4d, 0xbd,
+	0xaa, 0x64, 0x06, 0xea, 0x8f, 0x9e, 0x78, 0x0f, 0xb6, 0x1f, 0x3d, 0xdc, 0xda, 0x6f, 0x5f, 0x22,
+	0x04, 0x66, 0x57, 0xf7, 0xf7, 0x37, 0x1f, 0xef, 0xee, 0x7b, 0x0f, 0x56, 0x1f, 0x6d, 0x6f, 0x6e,
+	0xb4, 0x2d, 0x64, 0xd9, 0x7b, 0xba, 0xbe, 0xbe, 0xb9, 0xb9, 0xb1, 0xb9, 0xd1, 0xae, 0x10, 0x80,
+	0x49, 0x41, 0xaa, 0x3a, 0xdf, 0xb7, 0xa0, 0xb9, 0x7e, 0xec, 0x87, 0x21, 0xed, 0xef, 0x46, 0x41,
+	0x98, 0x92, 0xfb, 0x40, 0x0e, 0x47, 0x61, 0x2f, 0x08, 0x8f, 0xbc, 0xf4, 0x79, 0xd0, 0xf3, 0x0e,
+	0xce, 0x50, 0x24, 0x58, 0xaf, 0x6e, 0x5d, 0x72, 0x4b, 0x68, 0xe4, 0x0d, 0x68, 0x1b, 0x28, 0x8e,
+	0x3d, 0x93, 0xb2, 0xad, 0x4b, 0x6e, 0x81, 0x82, 0xe3, 0x15, 0x8d, 0xd2, 0xe1, 0x28, 0xf5, 0x82,
+	0xb0, 0x47, 0x9f, 0xb3, 0x9e, 0x9d, 0x71, 0x0d, 0x6c, 0x6d, 0x16, 0x9a, 0xfa, 0x7f, 0xce, 0xe7,
+	0xa0, 0xbd, 0x8d, 0xea, 0x38, 0x0c, 0xc2, 0xa3, 0x55, 0xae, 0x33, 0x71, 0x8d, 0x18, 0x8e, 0x0e,
+	0x9e, 0xd1, 0x33, 0x21, 0x67, 0x22, 0x85, 0x1a, 0xed, 0x38, 0x4a, 0x52, 0x21, 0xe7, 0xec, 0xdb,
+	0xf9, 0x7b, 0x0b, 0x5a, 0x38, 0x89, 0x1e, 0xfb, 0xe1, 0x99, 0x94, 0xd8, 0x6d, 0x68, 0x62, 0x56,
+	0xfb, 0xd1, 0x2a, 0x5f, 0x69, 0xb8, 0x06, 0xbd, 0x23, 0x46, 0x38, 0xc7, 0x7d, 0x4f, 0x67, 0x45,
+	0xdb, 0xe4, 0xcc, 0x35, 0xfe, 0x46, 0x9d, 0x99, 0xfa, 0xf1, 0x11, 0x4d, 0xd9, 0x1a, 0x24, 0xd6,
+	0x24, 0xe0, 0xd0, 0x7a, 0x14, 0x1e, 0x92, 0x5b, 0xd0, 0x4c, 0xfc, 0xd4, 0x1b, 0xd2, 0x98, 0xf5,
+	0x9a, 0x18, 0x7d, 0x48, 0xfc, 0x74, 0x97, 0xc6, 0x6b, 0x67, 0x29, 0xb5, 0x3f, 0x0f, 0x73, 0x85,
+	0x52, 0x50, 0xd5, 0x66, 0x4d, 0xc4, 0x4f, 0xb2, 0x00, 0x13, 0x27, 0x7e, 0x7f, 0x44, 0xc5, 0xd2,
+	0xc8, 0x13, 0xef, 0x56, 0xde, 0xb1, 0x9c, 0xd7, 0xa1, 0x9d, 0x55, 0x5b, 0x28, 0x31, 0x02, 0x35,
+	0xec, 0x41, 0x91, 0x01, 0xfb, 0x76, 0xfe, 0xaf, 0xc5, 0x19, 0xd7, 0xa3, 0x40, 0x2d, 0x33, 0xc8,
+	0x88, 0xab, 0x91, 0x64, 0xc4, 0xef, 0xb1, 0xcb, 0xf0, 0x2f, 0xde, 0x58, 0xe7, 0x36, 0xcc, 0x69,
+	0x55, 0x78, 0x49, 0x65, 0x3f, 0x80, 0xe9, 0x9d, 0x51, 0xca, 0x45, 0x13, 0x17, 0xdb, 0x9c, 0x48,
+	0xba, 0x1a, 0x82, 0xb3, 0xcb, 0x14, 0x40, 0x77, 0xfa, 0x67, 0x11, 0x3b, 0xe7, 0xff, 0x58, 0x30,
+	0xbb, 0x36, 0x1a, 0x0c, 0x1f, 0x50, 0x9a, 0xd9, 0xb3, 0xd3, 0xc8, 0x82, 0xc5, 0xb3, 0x02, 0x1b,
+	0x2b, 0x2d, 0x21, 0x21, 0xb2, 0x56, 0xae, 0x62, 0xc8, 0xf7, 0x4b, 0xe5, 0xdc, 0x7e, 0xa9, 0x16,
+	0xfa, 0xe5, 0xf3, 0xd0, 0x52, 0x35, 0x18, 0xdf, 0x2b, 0x68, 0x3a, 0xa1, 0xde, 0x40, 0x35, 0xc2,
+	0x87, 0x46, 0x26, 0x71, 0xbd, 0x98, 0x7b, 0x42, 0x4f, 0xc5, 0x2c, 0x91, 0xcd, 0x78, 0x07, 0x6a,
+	0xe9, 0xd9, 0x90, 0x5b, 0xe2, 0xb3, 0x2b, 0xaf, 0x89, 0x26, 0x14, 0xf8, 0xee, 0x89, 0xe4, 0xfe,
+	0xd9, 0x90, 0xba, 0xec, 0x0f, 0xe7, 0x73, 0xd0, 0xd0, 0x40, 0xb2, 0x04, 0xf3, 0xef, 0x3f, 0xda,
+	0x7f, 0xb2, 0xb9, 0xb7, 0xe7, 0xed, 0x3e, 0x5d, 0xfb, 0xd2, 0xe6, 0xff, 0xf6, 0xb6, 0x56, 0xf7,
+	0xb6, 0xda, 0x97, 0xc8, 0x22, 0x90, 0x27, 0x9b, 0x7b, 0xfb, 0x9b, 0x1b, 0x06, 0x6e, 0x39, 0x36,
+	0x74, 0x9e, 0xd0, 0xd3, 0xf7, 0x83, 0x34, 0xa4, 0x49, 0x62, 0x96, 0xe6, 0xdc, 0x03, 0xa2, 0x57,
+	0x41, 0xb4, 0xb7, 0x03, 0x53, 0xc2, 0x2e, 0x92, 0x66, 0xa1, 0x48, 0x3a, 0xaf, 0x03, 0xd9, 0x0b,
+	0x8e, 0xc2, 0xc7, 0x34, 0x49, 0xfc, 0x23, 0x35, 0x44, 0x6d, 0xa8, 0x0e, 0x92, 0x23, 0x21, 0x0e,
+	0xf8, 0xe9, 0xbc, 0x05, 0xf3, 0x06, 0x9f, 0xc8, 0xf8, 0x1a, 0xd4, 0x93, 0xe0, 0x28, 0xf4, 0xd3,
+	0x51, 0x4c, 0x45, 0xd6, 0x19, 0xe0, 0x3c, 0x80, 0x85, 0xaf, 0xd0, 0x38, 0x38, 0x3c, 0x3b, 0x2f,
+	0x7b, 0x33, 0x9f, 0x4a, 0x3e, 0x9f, 0x4d, 0xb8, 0x9c, 0xcb, 0x47, 0x14, 0xcf, 0x27, 0xae, 0x18,
+	0xc8, 0x69, 0x97, 0x27, 0x34, 0x35, 0x56, 0xd1, 0xd5, 0x98, 0xf3, 0x14, 0xc8, 0x7a, 0x14, 0x86,
+	0xb4, 0x9b, 0xee, 0x52, 0x1a, 0x67, 0xe2, 0x98, 0xcd, 0xd2, 0xc6, 0xca, 0x92, 0x18, 0xc7, 0xbc,
+	0x6e, 0x14, 0xd3, 0x97, 0x40, 0x6d, 0x48, 0xe3, 0x01, 0xcb, 0x78, 0xda, 0x65, 0xdf, 0xce, 0x65,
+	0x98, 0x37, 0xb2, 0x15, 0xa6, 0xf9, 0x9b, 0x70, 0x79, 0x23, 0x48, 0xba, 0xc5, 0x02, 0x3b, 0x30,
+	0x35, 0x1c, 0x1d, 0x78, 0x99, 0x0e, 0x92, 0x49, 0xb4, 0x58, 0xf3, 0xbf, 0x88, 0xcc, 0xbe, 0x63,
+	0x41, 0x6d, 0x6b, 0x7f, 0x7b, 0x1d, 0xe7, 0x63, 0x10, 0x76, 0xa3, 0x01, 0x9a, 0x1d, 0xbc, 0xd1,
+	0x2a, 0x3d, 0x56, 0xb7, 0x5c, 0x83, 0x3a, 0x33, 0x14, 0xd0, 0x08, 0x17, 0x3b, 0xa1, 0x0c, 0xc0,
+	0x0d, 0x00, 0x7d, 0x3e, 0x0c, 0x62, 0x66, 0xe1, 0x4b, 0xbb, 0xbd, 0xc6, 0xa6, 0x72, 0x91, 0xe0,
+	0xfc, 0x7b, 0x0d, 0xa6, 0xc4, 0xda, 0xc6, 0xca, 0xeb, 0xa6, 0xc1, 0x09, 0x15, 0x35, 0x11, 0x29,
+	0xb4, 0xf2, 0x62, 0x3a, 0x88, 0x52, 0xea, 0x19, 0xc3, 0x60, 0x82, 0xc8, 0xd5, 0xe5, 0x19, 0x79,
+	0x5c, 0x17, 0x54, 0x39, 0x97, 0x01, 0x62, 0x67, 0x21, 0xe0, 0x05, 0x3d, 0x56, 0xa7, 0x9a, 0x2b,
+	0x93, 0xd8, 0x13, 0x5d, 0x7f, 0xe8, 0x77, 0x83, 0xf4, 0x4c, 0xae, 0xfb, 0x32, 0x8d, 0x79, 0xf7,
+	0xa3, 0xae, 0xdf, 0xf7, 0x0e, 0xfc, 0xbe, 0x1f, 0x76, 0xa9, 0xd8, 0x65, 0x98, 0x20, 0x5a, 0x07,
+	0xa2, 0x4a, 0x92, 0x8d, 0x6f, 0x36, 0x72, 0x28, 0xea, 0xc8, 0x6e, 0x34, 0x18, 0x04, 0x29, 0xee,
+	0x3f, 0x84, 0x91, 0xab, 0x21, 0xac, 0x25, 0x3c, 0x75, 0xca, 0x7b, 0xaf, 0xce, 0x4b, 0x33, 0x40,
+	0xcc, 0x05, 0x15, 0x0a, 0x2a, 0xaa, 0x67, 0xa7, 0x1d, 0xe0, 0xb9, 0x64, 0x08, 0x8e, 0xc3, 0x28,
+	0x4c, 0x68, 0x9a, 0xf6, 0x69, 0x4f, 0x55, 0xa8, 0xc1, 0xd8, 0x8a, 0x04, 0x72, 0x1f, 0xe6, 0xf9,
+	0x96, 0x28, 0xf1, 0xd3, 0x28, 0x39, 0x0e, 0x12, 0x2f, 0xa1, 0x61, 0xda, 0x69, 0x32, 0xfe, 0x32,
+	0x12, 0x79, 0x07, 0x96, 0x72, 0x70, 0x4c, 0xbb, 0x34, 0x38, 0xa1, 0xbd, 0xce, 0x0c, 0xfb, 0x6b,
+	0x1c, 0x99, 0xdc, 0x82, 0x06, 0xee, 0x04, 0x47, 0xcc, 0xa6, 0x4b, 0x3a, 0xb3, 0x6c, 0x1c, 0x74,
+	0x88, 0xbc, 0x09, 0x33, 0x43, 0xca, 0x8d, 0x8b, 0xe3, 0xb4, 0xdf, 0x4d, 0x3a, 0x2d, 0xb6, 0xf2,
+	0x37, 0xc4, 0x64, 0x42, 0xc9, 0x75, 0x4d, 0x0e, 0x14, 0xca, 0x6e, 0xc2, 0xf6, 0x16, 0xfe, 0x59,
+	0xa7, 0xcd, 0xc4, 0x2d, 0x03, 0xd8, 0x1c, 0x89, 0x83, 0x13, 0xb4, 0x2f, 0xe7, 0x98, 0x6c, 0xc9,
+	0xa4, 0xf3, 0xfb, 0x16, 0xcc, 0x6f, 0x07, 0x49, 0x2a, 0x84, 0x50, 0xa9, 0xe3, 0x9b, 0xd0, 0xe0,
+	0xe2, 0xe7, 0x45, 0x61, 0xff, 0x4c, 0x48, 0x24, 0x70, 0x68, 0x27, 0xec, 0x9f, 0x91, 0x57, 0x61,
+	0x26, 0x08, 0x75, 0x16, 0x3e, 0x87, 0x9b, 0x41, 0xa8, 0x31, 0xdd, 0x84, 0xc6, 0x70, 0x74, 0xd0,
+	0x0f, 0xba, 0x9c, 0xa5, 0xca, 0x73, 0xe1, 0x10, 0x63, 0xc0, 0x4d, 0x02, 0xaf, 0x09, 0xe7, 0xa8,
+	0x31, 0x8e, 0x86, 0xc0, 0x90, 0xc5, 0x59, 0x83, 0x05, 0xb3, 0x82, 0x42, 0x59, 0xdd, 0x85, 0x69,
+	0x21, 0xdb, 0x49, 0xa7, 0xc1, 0xfa, 0x67, 0x56, 0xf4, 0x8f, 0x60, 0x75, 0x15, 0xdd, 0xf9, 0x69,
+	0x05, 0xc0, 0xa5, 0x49, 0xd4, 0x1f, 0xb1, 0x6d, 0xfd, 0x17, 0xd1, 0x4f, 0x20, 0x53, 0x9e, 0xb6,
+	0xec, 0xdc, 0x12, 0x39, 0x64, 0xbc, 0xda, 0x27, 0x5b, 0x72, 0xf2, 0x3f, 0x92, 0xcf, 0xc2, 0x54,
+	0x34, 0x4a, 0xbb, 0xd1, 0x40, 0x9a, 0xee, 0xaf, 0xbe, 0x2c, 0x8f, 0x1d, 0xce, 0xea, 0xca, 0x7f,
+	0x70, 0xda, 0xa9, 0xd5, 0x9b, 0xcf, 0x58, 0x95, 0x46, 0x11, 0xe7, 0x2a, 0x87, 0xad, 0xa2, 0x35,
+	0x2e, 0xe2, 0x19, 0x82, 0xf4, 0xe4, 0x94, 0xd2, 0x21, 0xb3, 0x40, 0xc5, 0x36, 0x55, 0x43, 0x9c,
+	0x35, 0x98, 0x35, 0x6b, 0x8f, 0x66, 0xf5, 0xfa, 0xce, 0xe3, 0xc7, 0x8f, 0xd0, 0x0a, 0x9f, 0x83,
+	0x99, 0x47, 0x4f, 0xd6, 0x77, 0x1e, 0x3f, 0x7a, 0xf2, 0xd0, 0x43, 0x89, 0x6a, 0x5b, 0x08, 0xed,
+	0x3c, 0xdd, 0x7f, 0xb8, 0xa3, 0xa0, 0x8a, 0xf3, 0x19, 0x98, 0x2b, 0xd4, 0x9e, 0x34, 0x60, 0x6a,
+	0x7d, 0x7b, 0xf5, 0xd1, 0xe3, 0xcd, 0x8d, 0xf6, 0x25, 0x4c, 0xec, 0x3f, 0x7a, 0xbc, 0xb9, 0xf3,
+	0x74, 0x9f, 0x9b, 0xf1, 0xab, 0x6b, 0xab, 0x4f, 0x36, 0x76, 0x9e, 0xa0, 0x19, 0xef, 0xfc, 0xb8,
+	0x06, 0xf3, 0x62, 0x34, 0xd6, 0xfb, 0x51, 0x42, 0xf7, 0x46, 0x83, 0x81, 0x1f, 0x97, 0x28, 0x2b,
+	0xeb, 0x1c, 0x65, 0x55, 0x31, 0x95, 0x15, 0xaa, 0x90, 0x63, 0x3f, 0x08, 0xf9, 0x7e, 0x8a, 0xf7,
+	0x9b, 0x86, 0x90, 0x3b, 0xd0, 0xea, 0xf6, 0xa3, 0x84, 0x5b, 0xe7, 0xba, 0x73, 0x25, 0x0f, 0x17,
+	0x95, 0xeb, 0x44, 0x99, 0x72, 0xd5, 0x95, 0xe3, 0x64, 0x4e, 0x39, 0x3a, 0xd0, 0xc4, 0x4c, 0xa9,
+	0xd4, 0xf5, 0x53, 0xdc, 0x6c, 0xd3, 0x31, 0xac, 0x4f, 0x5e, 0x15, 0x71, 0xbd, 0xd7, 0x2a, 0x53,
+	0x44, 0xe8, 0xbb, 0xc1, 0xb5, 0x44, 0xe3, 0xae, 0x0b, 0x45, 0x54, 0x24, 0x91, 0x07, 0x00, 0xbc,
+	0x2c, 0x26, 0xc7, 0xc0, 0x64, 0xf0, 0x75, 0x73, 0x26, 0xe8, 0x7d, 0x7f, 0x0f, 0x13, 0xa3, 0x98,
+	0x32, 0x69, 0xd6, 0xfe, 0x24, 0x6f, 0x41, 0x23, 0x93, 0x6d, 0x39, 0xa5, 0xe6, 0x0a, 0xc2, 0xec,
+	0xea, 0x5c, 0xce, 0x47, 0xd0, 0xd0, 0xf2, 0x23, 0x97, 0x61, 0x6e, 0x7d, 0x67, 0x67, 0x77, 0xd3,
+	0x5d, 0xdd, 0x7f, 0xf4, 0x95, 0x4d, 0x6f, 0x7d, 0x7b, 0x67, 0x6f, 0xb3, 0x7d, 0x09, 0xe1, 0xed,
+	0x9d, 0xf5, 0xd5, 0x6d, 0xef, 0xc1, 0x8e, 0xbb, 0x2e, 0x61, 0x0b, 0x0d, 0x32, 0x77, 0xf3, 0xf1,
+	0xce, 0xfe, 0xa6, 0x81, 0x57, 0x48, 0x1b, 0x9a, 0x6b, 0xee, 0xe6, 0xea, 0xfa, 0x96, 0x40, 0xaa,
+	0x64, 0x01, 0xda, 0x0f, 0x9e, 0x3e, 0xd9, 0x40, 0xb9, 0x5c, 0x5f, 0x7d, 0xb2, 0xbe, 0x89, 0x1b,
+	0xc3, 0x9a, 0xf3, 0xa7, 0x16, 0x5c, 0x66, 0x4d, 0xeb, 0xe5, 0xb5, 0xd7, 0x2d, 0x68, 0x74, 0xa3,
+	0x68, 0x48, 0x63, 0x5f, 0x5b, 0x4f, 0x75, 0x08, 0x35, 0x13, 0x5f, 0xbd, 0x0e, 0xa3, 0xb8, 0x4b,
+	0x85, 0xf2, 0x02, 0x06, 0x3d, 0x40, 0x04, 0x35, 0x93, 0x90, 0x01, 0xce, 0xc1, 0x75, 0x57, 0x83,
+	0x63, 0x9c, 0x65, 0x11, 0x26, 0x0f, 0x62, 0xea, 0x77, 0x8f, 0x85, 0xda, 0x12, 0x29, 0xf2, 0x3f,
+	0xb2, 0xdd, 0x66, 0x17, 0x87, 0xa8, 0x4f, 0xf9, 0xec, 0x9c, 0x76, 0x5b, 0x02, 0x5f, 0x17, 0xb0,
+	0xb3, 0x0b, 0x8b, 0xf9, 0x16, 0x08, 0xf5, 0xf6, 0xb6, 0xa6, 0xde, 0xf8, 0xc6, 0xcf, 0x1e, 0x3f,
+	0xa8, 0x9a, 0xaa, 0xfb, 0x27, 0x0b, 0x6a, 0x68, 0xeb, 0x8c, 0xb7, 0x8b, 0x74, 0xf3, 0xb5, 0x6a,
+	0x98, 0xaf, 0xcc, 0x1f, 0x89, 0xfb, 0x14, 0xbe, 0xfa, 0x71, 0x0b, 0x41, 0x43, 0x32, 0x7a, 0x4c,
+	0xbb, 0x27, 0x9d, 0x09, 0x9d, 0x8e, 0x08, 0xce, 0x13, 0xdc, 0x3d, 0xb0, 0xbf, 0xc5, 0x3c, 0x91,
+	0x69, 0x49, 0x63, 0x7f, 0x4e, 0x65, 0x34, 0xf6, 0x5f, 0x07, 0xa6, 0x82, 0xf0, 0x20, 0x1a, 0x85,
+	0x3d, 0x36, 0x2f, 0xa6, 0x5d, 0x99, 0xc4, 0x75, 0x6d, 0xc8, 0xe6, 0x6b, 0x30, 0x90, 0xb3, 0x20,
+	0x03, 0x1c, 0x82, 0xbb, 0xee, 0x84, 0xd9, 0x76, 0xca, 0x64, 0x7f, 0x1b, 0xe6, 0x34, 0x4c, 0xf4,
+	0xe6, 0x2b, 0x30, 0x31, 0x44, 0xa0, 0x63, 0x19, 0x2b, 0x29, 0x32, 0xb9, 0x9c, 0xe2, 0x1c, 0x00,
+	0xac, 0x61, 0x1f, 0xf6, 0xce, 0xe9, 0x3d, 0xf4, 0xc9, 0x32, 0x3e, 0x6f, 0x14, 0xa6, 0x41, 0x5f,
+	0x18, 0x87, 0x06, 0x86, 0x92, 0x21, 0x1c, 0x24, 0xbc, 0x83, 0x45, 0x0a, 0x2d, 0x52, 0xac, 0x5b,
+	0x56, 0x8e, 0xaa, 0xf5, 0x1a, 0x2c, 0x15, 0x28, 0xa2, 0xee, 0xb7, 0xcd, 0xba, 0xcb, 0x29, 0x99,
+	0xb1, 0xca, 0x16, 0xbc, 0x01, 0xed, 0xa7, 0xe1, 0x81, 0x1f, 0x5e, 0xcc, 0x3a, 0x9e, 0x87, 0x39,
+	0x8d, 0x5b, 0x18, 0xc6, 0x6d, 0x3c, 0x2e, 0x49, 0x1f, 0x85, 0x87, 0x91, 0xac, 0xd8, 0x0f, 0x6a,
+	0xd0, 0x52, 0x90, 0xa8, 0xd1, 0x1d, 0x68, 0x05, 0x3d, 0x1a, 0xa6, 0x41, 0x7a, 0xe6, 0x19, 0x1e,
+	0x8e, 0x3c, 0x8c, 0x3b, 0x0a, 0xbf, 0x1f, 0xf8, 0x89, 0xb0, 0x59, 0x79, 0x82, 0xac, 0xc0, 0x02,
+	0x9a, 0x3b, 0xd2, 0x82, 0x51, 0x72, 0xce, 0x77, 0xbc, 0xa5, 0x34, 0x54, 0x8c, 0x88, 0x0b, 0x8b,
+	0x43, 0xfd, 0xc2, 0x2d, 0xeb, 0x32, 0x12, 0x8a, 0x0e, 0xcf, 0x09, 0xfb, 0x6e, 0x82, 0x9b, 0x44,
+	0x0a, 0x28, 0xb8, 0xd6, 0x27, 0xb9, 0xda, 0xce, 0xbb, 0xd6, 0x35, 0xf7, 0xfc, 0x74, 0xc1, 0x3d,
+	0x8f, 0x6a, 0xfd, 0x2c, 0xec, 0xd2, 0x9e, 0x97, 0x46, 0x1e, 0x5b, 0x7e, 0x98, 0x88, 0x4e, 0xbb,
+	0x79, 0x18, 0x87, 0x21, 0xa5, 0x49, 0x1a, 0xd2, 0x94, 0x69, 0xe8, 0x69, 0x57, 0x26, 0x51, 0x54,
+	0x18, 0x0b, 0xd7, 0xb8, 0x75, 0x57, 0xa4, 0x70, 0x6b, 0x34, 0x8a, 0x83, 0xa4, 0xd3, 0x64, 0x28,
+	0xfb, 0x26, 0x9f, 0x80, 0xcb, 0x07, 0x34, 0x49, 0xbd, 0x63, 0xea, 0xf7, 0x68, 0xcc, 0xa6, 0x00,
+	0xf7, 0xfa, 0x73, 0x8b, 0xb3, 0x9c, 0x88, 0x65, 0x9f, 0xd0, 0x38, 0x09, 0xa2, 0x90, 0xd9, 0x9a,
+	0x75, 0x57, 0x26, 0xf9, 0x32, 0x39, 0x4a, 0x52, 0x1a, 0x7b, 0x34, 0xf4, 0x0f, 0x50, 0x4f, 0xb5,
+	0x78, 0xfd, 0x73, 0x30, 0x5b, 0x70, 0x05, 0x14, 0xf4, 0x3a, 0x6d, 0xb1, 0xe0, 0x2a, 0xc4, 0xf9,
+	0x3b, 0x0b, 0xae, 0x73, 0x27, 0xe5, 0x93, 0xa8, 0x47, 0x57, 0xc3, 0x30, 0x1a, 0x85, 0x5d, 0xaa,
+	0xbb, 0x5f, 0x95, 0x24, 0x58, 0xba, 0x24, 0x2c, 0xc0, 0x44, 0x37, 0xea, 0x47, 0xd2, 0x19, 0xc2,
+	0x13, 0x38, 0x72, 0xd9, 0x69, 0x44, 0x95, 0x75, 0x40, 0x06, 0xa0, 0x37, 0x93, 0x1b, 0xca, 0xda,
+	0x91, 0x05, 0x57, 0xc0, 0x05, 0x1c, 0x47, 0x39, 0xa1, 0xb8, 0xad, 0x60, 0x3b, 0x5f, 0x14, 0x83,
+	0x2a, 0x8e, 0xb2, 0x8e, 0xe1, 0xbe, 0x65, 0x14, 0xea, 0x48, 0x67, 0x92, 0x71, 0xe5, 0x50, 0xe7,
+	0x16, 0xdc, 0x18, 0xd7, 0x44, 0x31, 0x7b, 0xbe, 0xc5, 0x76, 0xc4, 0xea, 0x7c, 0x87, 0x73, 0xa3,
+	0x4f, 0x9f, 0xcb, 0x4c, 0x72, 0xec, 0x8b, 0x4d, 0xfa, 0x34, 0x03, 0xf6, 0x8e, 0x7d, 0x5c, 0x66,
+	0x0c, 0x31, 0xe4, 0x1e, 0x99, 0x06, 0xc3, 0xb6, 0x18, 0x44, 0x5e, 0x83, 0x59, 0x79, 0x72, 0x94,
+	0x78, 0x7d, 0x7a, 0x98, 0x4a, 0xcf, 0x50, 0x38, 0x1a, 0x60, 0x71, 0xc9, 0x36, 0x3d, 0x4c, 0x9d,
+	0x27, 0x30, 0x27, 0x16, 0x86, 0x9d, 0x21, 0x95, 0x45, 0x7f, 0xba, 0xcc, 0xce, 0x6a, 0xac, 0xcc,
+	0x9b, 0x2b, 0x09, 0x77, 0x12, 0x99, 0x9c, 0x8e, 0x0b, 0x44, 0x5f, 0x68, 0x32, 0x57, 0x76, 0x66,
+	0x41, 0x05, 0xf2, 0x1c, 0xce, 0xc0, 0x50, 0xde, 0x92, 0x51, 0xb7, 0x8b, 0xcb, 0x0b, 0x5f, 0x56,
+	0x65, 0xd2, 0xf9, 0x03, 0x0b, 0xe6, 0x59, 0x6e, 0x22, 0xe7, 0xcc, 0xf7, 0x73, 0xf1, 0x6a, 0x36,
+	0xbb, 0x5a, 0x0a, 0xe5, 0x47, 0x5f, 0xc0, 0x79, 0xe2, 0x67, 0xf7, 0xfe, 0xd5, 0x0a, 0x5e, 0xae,
+	0xbf, 0xb6, 0x60, 0x8e, 0xaf, 0xb0, 0xa9, 0x9f, 0x8e, 0x12, 0xd1, 0xfc, 0xcf, 0xc0, 0x0c, 0xb7,
+	0x98, 0x84, 0x7a, 0x12, 0x15, 0x5d, 0x50, 0xcb, 0x09, 0x43, 0x39, 0xf3, 0xd6, 0x25, 0xd7, 0x64,
+	0x26, 0x9f, 0x87, 0xa6, 0x7e, 0xfc, 0xc7, 0xea, 0xdc, 0x58, 0xb9, 0x22, 0x5b, 0x59, 0x90, 0x9c,
+	0xad, 0x4b, 0xae, 0xf1, 0x03, 0x79, 0x8f, 0x99, 0xbd, 0xa1, 0xc7, 0xb2, 0xed, 0x54, 0xcd, 0xdf,
+	0x0b, 0x83, 0xb5, 0x75, 0xc9, 0xd5, 0xd8, 0xd7, 0xa6, 0x61, 0x92, 0x4f, 0x0f, 0xe7, 0x21, 0xcc,
+	0x18, 0x35, 0x35, 0xfc, 0x77, 0x4d, 0xe1, 0xbf, 0xcb, 0x7b, 0x23, 0x2b, 0x25, 0xde, 0xc8, 0x5f,
+	0xab, 0x02, 0x41, 0x69, 0xcb, 0x0d, 0x27, 0x6e, 0x70, 0xa3, 0x9e, 0xe1, 0xae, 0x68, 0xba, 0x3a,
+	0x44, 0xee, 0x01, 0xd1, 0x92, 0xf2, 0xdc, 0x87, 0xaf, 0x95, 0x25, 0x14, 0x5c, 0x30, 0x84, 0xb5,
+	0x26, 0xec, 0x2a, 0xe1, 0x98, 0xe1, 0xe3, 0x56, 0x4a, 0x43, 0x7b, 0x63, 0x38, 0xc2, 0xf3, 0x9c,
+	0xec, 0x20, 0x43, 0xa6, 0xf3, 0x02, 0x32, 0x79, 0xae, 0x80, 0x4c, 0xe5, 0x05, 0x44, 0xdf, 0x52,
+	0x4f, 0x1b, 0x5b, 0x6a, 0xdc, 0x52, 0x0c, 0x70, 0x23, 0x92, 0xf6, 0xbb, 0xfc, 0x18, 0x45, 0xf8,
+	0x2f, 0x0c, 0x10, 0xb5, 0x98, 0xb0, 0x2f, 0xb3, 0x7d, 0x3b, 0xb0, 0x3e, 0x2e, 0xe0, 0x98, 0x23,
+	0x97, 0x24, 0x69, 0xb6, 0x35, 0xc4, 0x76, 0x49, 0x07, 0x9d, 0x7f, 0xb1, 0xa0, 0xbd, 0xe6, 0xa7,
+	0xdd, 0x63, 0x6d, 0x48, 0xf2, 0x63, 0x61, 0x15, 0xc7, 0x62, 0x5c, 0xdf, 0x56, 0x2e, 0xd8, 0xb7,
+	0xd5, 0x5c, 0xdf, 0x6a, 0x1d, 0x53, 0x3b, 0xa7, 0x63, 0x26, 0x2e, 0xda, 0x31, 0x93, 0xe5, 0x1d,
+	0xe3, 0xfc, 0xa6, 0x05, 0x4b, 0xf9, 0x26, 0x4b, 0x29, 0x7c, 0xab, 0x60, 0x40, 0x2f, 0x29, 0xcb,
+	0x29, 0xf7, 0x87, 0x62, 0xfc, 0x65, 0xf8, 0xc7, 0xbf, 0x0e, 0x9d, 0x62, 0x95, 0x84, 0xe1, 0xf4,
+	0x05, 0x68, 0x17, 0x8c, 0x1e, 0x5e, 0xb7, 0x52, 0x15, 0xe2, 0x16, 0xb8, 0x9d, 0x1f, 0x59, 0xd0,
+	0xc6, 0x9c, 0x0d, 0xb5, 0xf4, 0x2e, 0x30, 0xad, 0x78, 0x41, 0xad, 0x64, 0xf0, 0xfe, 0xe2, 0x4a,
+	0xe9, 0x1d, 0xa8, 0xb3, 0x0c, 0xa3, 0x21, 0x0d, 0x85, 0x4e, 0xea, 0x98, 0x3a, 0x29, 0x5b, 0x90,
+	0xb6, 0x2e, 0xb9, 0x19, 0xb3, 0xa6, 0x91, 0xfe, 0xca, 0x82, 0x86, 0xa8, 0xe6, 0xcf, 0xed, 0x96,
+	0x7d, 0x99, 0x27, 0xe5, 0x0e, 0xb4, 0x06, 0xb8, 0x8a, 0xa3, 0x65, 0x6a, 0xb8, 0x64, 0xf3, 0x30,
+	0x9a, 0x99, 0x6c, 0xed, 0x4d, 0xbc, 0x34, 0xe8, 0x7b, 0x92, 0x2a, 0x02, 0x2f, 0xca, 0x48, 0xb8,
+	0x04, 0x25, 0x29, 0x9e, 0xa8, 0x72, 0x21, 0xe5, 0x09, 0xb4, 0xf4, 0x45, 0x83, 0x72, 0x7b, 0x53,
+	0xe7, 0x4f, 0x9a, 0xb0, 0x54, 0x20, 0xa9, 0xc0, 0x21, 0xe1, 0x6b, 0xec, 0x07, 0x83, 0x83, 0x48,
+	0xed, 0xfe, 0x2d, 0xdd, 0x0d, 0x69, 0x90, 0xc8, 0x11, 0x5c, 0x96, 0x32, 0x82, 0x7d, 0x9a, 0x89,
+	0x55, 0x85, 0x89, 0xd5, 0x9b, 0xa6, 0x0c, 0xe4, 0x0b, 0x94, 0xb8, 0x2e, 0xab, 0xe5, 0xf9, 0x91,
+	0x63, 0xe8, 0x48, 0x82, 0x5c, 0xed, 0x35, 0xbb, 0x1d, 0xcb, 0x7a, 0xe3, 0x9c, 0xb2, 0x8c, 0x8d,
+	0xae, 0x3b, 0x36, 0x37, 0x72, 0x06, 0x37, 0x24, 0x8d, 0x2d, 0xe7, 0xc5, 0xf2, 0x6a, 0x17, 0x6a,
+	0x1b, 0xdb, 0xa4, 0x9b, 0x85, 0x9e, 0x93, 0x31, 0xf9, 0x00, 0x16, 0x4f, 0xfd, 0x20, 0x95, 0xd5,
+	0xd2, 0xf6, 0x19, 0x13, 0xac, 0xc8, 0x95, 0x73, 0x8a, 0x7c, 0x9f, 0xff, 0x6c, 0xd8, 0x38, 0x63,
+	0x72, 0xb4, 0xff, 0xdc, 0x82, 0x59, 0x33, 0x1f, 0x14, 0x53, 0xa1, 0xe2, 0xa4, 0x82, 0x96, 0xfb,
+	0xaa, 0x1c, 0x5c, 0x74, 0xa0, 0x55, 0xca, 0x1c, 0x68, 0xba, 0xdb, 0xaa, 0x7a, 0x9e, 0x4f, 0xbf,
+	0x76, 0x31, 0x9f, 0xfe, 0x44, 0x99, 0x4f, 0xdf, 0xfe, 0xa9, 0x05, 0xa4, 0x28, 0x4b, 0xe4, 0x21,
+	0xf7, 0xe0, 0x85, 0xb4, 0x2f, 0x74, 0xd2, 0xc7, 0x2f, 0x26, 0x8f, 0xb2, 0xef, 0xe4, 0xdf, 0x38,
+	0x31, 0x74, 0xa5, 0xa3, 0x5b, 0xcb, 0x33, 0x6e, 0x19, 0x29, 0x77, 0xca, 0x50, 0x3b, 0xff, 0x94,
+	0x61, 0xe2, 0xfc, 0x53, 0x86, 0xc9, 0xfc, 0x29, 0x83, 0xfd, 0x2b, 0x16, 0xcc, 0x97, 0x0c, 0xfa,
+	0x2f, 0xaf, 0xe1, 0x38, 0x4c, 0x86, 0x2e, 0xa8, 0x88, 0x61, 0xd2, 0x41, 0xfb, 0xdb, 0x30, 0x63,
+	0x08, 0xfa, 0x2f, 0xaf, 0xfc, 0xbc, 0xc1, 0xcf, 0xe5, 0xcc, 0xc0, 0xec, 0x9f, 0x54, 0x80, 0x14,
+	0x27, 0xdb, 0x7f, 0x69, 0x1d, 0x8a, 0xfd, 0x54, 0x2d, 0xe9, 0xa7, 0xff, 0xd4, 0x75, 0xe0, 0x0d,
+	0x98, 0x13, 0x51, 0x86, 0x9a, 0xdf, 0x96, 0x4b, 0x4c, 0x91, 0x80, 0x5b, 0x1e, 0xf3, 0x88, 0x67,
+	0xda, 0x08, 0x8f, 0xd3, 0x16, 0xc3, 0xdc, 0x49, 0x0f, 0xc6, 0x1d, 0xf1, 0xa8, 0xc5, 0x35, 0x9e,
+	0x95, 0x5c, 0x57, 0x7e, 0xcf, 0x82, 0xcb, 0x39, 0x42, 0x16, 0x26, 0xc6, 0x97, 0x0e, 0x73, 0x3d,
+	0x31, 0x41, 0xac, 0xbf, 0x98, 0x47, 0x5a, 0xfd, 0xb9, 0xb4, 0x15, 0x09, 0xd8, 0x3f, 0xa3, 0xb0,
+	0xc8, 0xcf, 0x7b, 0xbd, 0x8c, 0xe4, 0x2c, 0xc1, 0x65, 0x31, 0xb2, 0xb9, 0x8a, 0x1f, 0xc2, 0x62,
+	0x9e, 0x90, 0x9d, 0xb3, 0x9b, 0x55, 0x96, 0x49, 0x34, 0x5a, 0x8d, 0x65, 0xca, 0xac, 0x6f, 0x29,
+	0xcd, 0x79, 0x00, 0xcd, 0x8d, 0x20, 0xa6, 0xdd, 0x94, 0xf6, 0x36, 0x7b, 0x47, 0x54, 0x3f, 0x5e,
+	0xb0, 0xcc, 0xe3, 0x85, 0x6b, 0x50, 0x3f, 0x8c, 0xa3, 0x01, 0x53, 0xb7, 0xf2, 0xf8, 0x5c, 0x01,
+	0xce, 0xdf, 0x56, 0x80, 0x7c, 0x79, 0x44, 0xe3, 0x33, 0x16, 0x63, 0xa5, 0x7c, 0xce, 0x4b, 0x79,
+	0x4f, 0x1b, 0x9e, 0x93, 0x7f, 0x89, 0x9e, 0xc9, 0x58, 0xc4, 0x4a, 0x16, 0x8b, 0x78, 0x1d, 0x00,
+	0x77, 0xf4, 0x2a, 0x96, 0x0d, 0x65, 0x0a, 0x5d, 0x53, 0x3c, 0xc3, 0xd2, 0x70, 0xc1, 0x5a, 0x69,
+	0xb8, 0x20, 0x1e, 0xc2, 0x1d, 0x85, 0x11, 0x0a, 0x16, 0x56, 0x8d, 0x2f, 0x53, 0x75, 0xb7, 0x29,
+	0x40, 0x74, 0x54, 0x24, 0x28, 0x6a, 0x92, 0x89, 0xf6, 0x8e, 0x84, 0x7b, 0x23, 0xdb, 0x5d, 0xeb,
+	0x7d, 0xa2, 0xfe, 0xc4, 0x44, 0x82, 0x9e, 0x87, 0x24, 0x1a, 0xe1, 0x4a, 0x29, 0x5b, 0x36, 0xc5,
+	0xa7, 0x1e, 0x47, 0x77, 0x79, 0xfb, 0xae, 0xa3, 0x6f, 0x28, 0x3d, 0xd1, 0x82, 0x16, 0xf1, 0xec,
+	0xb1, 0x9f, 0x9e, 0xb0, 0xa8, 0x45, 0xf2, 0x26, 0x34, 0x58, 0x43, 0xbd, 0xe3, 0x20, 0x4c, 0x93,
+	0x4e, 0x9d, 0x15, 0xde, 0xd6, 0xc3, 0xd4, 0xb6, 0x70, 0x5f, 0x0f, 0xb1, 0xfc, 0x4c, 0x9c, 0xf7,
+	0x60, 0xde, 0xe8, 0x60, 0x25, 0xc7, 0x32, 0xfc, 0xcf, 0x7a, 0x49, 0xf8, 0xdf, 0xaf, 0x56, 0xa0,
+	0xba, 0x15, 0x0d, 0x5f, 0x32, 0xbc, 0x62, 0xf1, 0xf4, 0xd4, 0xda, 0x28, 0x74, 0xaa, 0x01, 0x92,
+	0xbb, 0x30, 0xeb, 0x0f, 0x52, 0x74, 0xe1, 0x1d, 0x46, 0xf1, 0xa9, 0x1f, 0xf7, 0xb8, 0x70, 0xaf,
+	0x55, 0x3a, 0x96, 0x9b, 0xa3, 0x90, 0x05, 0xa8, 0xaa, 0x55, 0x86, 0x31, 0x60, 0x12, 0x2d, 0x55,
+	0x76, 0xe2, 0x7f, 0x26, 0xbc, 0x8f, 0x22, 0x85, 0x73, 0xc7, 0xfc, 0x9f, 0xef, 0x86, 0xb8, 0xae,
+	0x28, 0x23, 0x19, 0x41, 0x79, 0x53, 0xb9, 0xa0, 0x3c, 0xcd, 0xc3, 0x3b, 0x6d, 0x7a, 0x78, 0xff,
+	0xd1, 0x82, 0x09, 0xd6, 0x37, 0xa8, 0xf7, 0xf8, 0x64, 0x57, 0x07, 0x48, 0xac, 0x4f, 0x66, 0xdc,
+	0x3c, 0x4c, 0x1c, 0x23, 0x5a, 0xb8, 0xa2, 0x1a, 0xa4, 0xa1, 0xe4, 0x16, 0xd4, 0x79, 0x4a, 0x85,
+	0xd8, 0x32, 0x96, 0x0c, 0x24, 0x37, 0x30, 0xc2, 0x6d, 0x28, 0x0d, 0x35, 0x90, 0xe7, 0xd6, 0xd1,
+	0xd0, 0x65, 0x78, 0x56, 0x1f, 0xcc, 0x4f, 0xdf, 0x0b, 0xe6, 0x61, 0x34, 0x40, 0x54, 0xb6, 0x7a,
+	0x37, 0xe5, 0x50, 0xe7, 0x7b, 0x16, 0xcc, 0xad, 0x8d, 0x82, 0x7e, 0xcf, 0x88, 0x41, 0xb5, 0x61,
+	0x5a, 0xfd, 0xc7, 0x35, 0x88, 0x4a, 0xe3, 0x3e, 0xb3, 0x30, 0xcb, 0xf8, 0x7e, 0xaf, 0x80, 0xe3,
+	0x2e, 0xfa, 0x38, 0x1a, 0x8a, 0x1d, 0xb3, 0x74, 0x49, 0xea, 0x10, 0x96, 0x24, 0xc4, 0x8b, 0xb7,
+	0xba, 0xe6, 0xaa, 0xb4, 0xf3, 0x0e, 0x10, 0xbd, 0x6a, 0x42, 0x9a, 0x55, 0xe0, 0xa6, 0x35, 0x36,
+	0x70, 0x13, 0x43, 0xa5, 0x96, 0x36, 0x93, 0x34, 0x18, 0xf8, 0x29, 0x65, 0x04, 0x2d, 0xee, 0xeb,
+	0xbf, 0x41, 0xdf, 0x38, 0xcf, 0xa1, 0x53, 0xac, 0xce, 0xc5, 0xdb, 0x63, 0xc8, 0x71, 0x25, 0x27,
+	0xc7, 0xd7, 0xa0, 0x9e, 0x49, 0x27, 0xf7, 0x70, 0x66, 0x80, 0x73, 0x17, 0x5a, 0xa8, 0xcd, 0xb4,
+	0x93, 0x89, 0xb1, 0x1d, 0x80, 0x41, 0x72, 0xd3, 0x92, 0x99, 0xdc, 0x81, 0x1a, 0x53, 0xe3, 0xe6,
+	0x9e, 0x58, 0xc5, 0x23, 0x21, 0x9f, 0xcb, 0x38, 0xd0, 0xcc, 0x60, 0x7e, 0xd6, 0x6c, 0x07, 0x25,
+	0xbd, 0xac, 0x0a, 0xcb, 0xc4, 0x31, 0x67, 0x57, 0xe7, 0x50, 0xe7, 0x0f, 0x2d, 0x98, 0x31, 0xca,
+	0x40, 0x11, 0xea, 0xfb, 0x49, 0x2a, 0x62, 0x3c, 0xc4, 0xf4, 0xd3, 0x21, 0x7d, 0x22, 0x57, 0xcc,
+	0x23, 0x27, 0xe5, 0x3b, 0xaf, 0xea, 0xbe, 0xf3, 0xfb, 0xba, 0x97, 0xbc, 0x66, 0x98, 0x0f, 0xcc,
+	0x43, 0xcd, 0x69, 0xba, 0xe7, 0x5c, 0x79, 0xdb, 0x27, 0x34, 0x6f, 0xbb, 0xf3, 0x1e, 0x34, 0x34,
+	0x7e, 0xac, 0x46, 0x48, 0xd3, 0xd3, 0x28, 0x7e, 0x26, 0x4f, 0x8c, 0x44, 0x52, 0x05, 0x60, 0x56,
+	0xb2, 0x00, 0x4c, 0xe7, 0x9f, 0x2d, 0x98, 0xc1, 0x21, 0x0e, 0xc2, 0xa3, 0xdd, 0xa8, 0x1f, 0x74,
+	0xcf, 0xd8, 0xdc, 0x96, 0xc3, 0x26, 0x84, 0x49, 0xea, 0x1a, 0x13, 0x46, 0x69, 0x90, 0xae, 0x1f,
+	0x29, 0x0d, 0x32, 0x8d, 0x3a, 0x1a, 0x25, 0xe3, 0xc0, 0x4f, 0x84, 0xb8, 0x08, 0x7b, 0xce, 0x00,
+	0x51, 0x93, 0x22, 0x10, 0xfb, 0x29, 0xf5, 0x06, 0x41, 0xbf, 0x1f, 0x70, 0x5e, 0x6e, 0xed, 0x97,
+	0x91, 0xb0, 0xcc, 0x5e, 0x90, 0xf0, 0xb3, 0x0e, 0x7e, 0x26, 0xab, 0xd2, 0x58, 0xe6, 0xc0, 0x7f,
+	0xae, 0xf9, 0xa7, 0x26, 0xd9, 0xba, 0x61, 0x82, 0xce, 0x0f, 0x2b, 0xd0, 0x10, 0xf6, 0x0a, 0x33,
+	0x23, 0x78, 0x2c, 0x02, 0x26, 0xb3, 0xa5, 0x46, 0x43, 0x24, 0xdd, 0xd8, 0xa7, 0x69, 0x48, 0x5e,
+	0x30, 0xaa, 0x45, 0xc1, 0xc0, 0x83, 0xac, 0xa8, 0x47, 0xdf, 0x64, 0x1b, 0x42, 0x1e, 0xc7, 0x90,
+	0x01, 0x92, 0xba, 0xc2, 0xa8, 0x13, 0x19, 0x95, 0x01, 0x2f, 0x8d, 0x5c, 0x78, 0x07, 0x9a, 0x22,
+	0x1b, 0x36, 0x72, 0x9d, 0x29, 0x63, 0x8a, 0x18, 0xa3, 0xea, 0x1a, 0x9c, 0xf2, 0xcf, 0x15, 0xf9,
+	0xe7, 0xf4, 0x79, 0x7f, 0x4a, 0x4e, 0x16, 0xdd, 0xc7, 0xfb, 0xe6, 0x61, 0xec, 0x0f, 0x8f, 0xa5,
+	0x0d, 0xf8, 0x37, 0x15, 0x20, 0x9b, 0xcf, 0x87, 0x51, 0x9c, 0xea, 0x30, 0xae, 0xa0, 0x87, 0x11,
+	0x6e, 0xec, 0xe4, 0x0c, 0xe7, 0x29, 0x14, 0x64, 0x6e, 0xef, 0xf0, 0x7b, 0x4b, 0x3c, 0xc1, 0xe3,
+	0xaa, 0x87, 0xf2, 0x18, 0x91, 0x7d, 0xe3, 0xa4, 0x46, 0x99, 0x52, 0x7d, 0xc0, 0x45, 0xc3, 0xc0,
+	0x50, 0xe2, 0x31, 0x8d, 0x3e, 0x1c, 0xbe, 0x50, 0xcb, 0x24, 0xa3, 0xf8, 0xcf, 0xbd, 0xcc, 0xbb,
+	0x23, 0x93, 0x68, 0x2d, 0xe3, 0x27, 0x13, 0xc5, 0xdc, 0xd2, 0x5c, 0x24, 0xb0, 0x5a, 0xf8, 0xcf,
+	0x3d, 0x29, 0x90, 0x22, 0xf8, 0xc3, 0xc0, 0x50, 0x96, 0x31, 0x9d, 0x9f, 0x3b, 0x75, 0xbe, 0xc5,
+	0x2d, 0x21, 0xe1, 0x0a, 0x46, 0x9f, 0x77, 0xfb, 0xa3, 0x1e, 0xf5, 0x94, 0x4c, 0xf3, 0xd3, 0xc5,
+	0x02, 0x8e, 0x91, 0xd7, 0x5a, 0xff, 0xae, 0x1f, 0x8f, 0x42, 0x36, 0x9f, 0x7b, 0x7e, 0xea, 0xab,
+	0x9b, 0x35, 0x7e, 0xea, 0x3b, 0x3d, 0x15, 0x6b, 0xcf, 0x18, 0xc9, 0x5d, 0xd9, 0xd3, 0xa6, 0x9b,
+	0xd2, 0xd4, 0x9f, 0xa2, 0xff, 0xef, 0xc0, 0x04, 0x37, 0x30, 0x2b, 0x86, 0x32, 0xd2, 0x26, 0x8b,
+	0xcb, 0x19, 0x50, 0x9b, 0x23, 0x9a, 0xd3, 0xe6, 0xa6, 0xb9, 0x86, 0x07, 0xa1, 0xe1, 0xa3, 0x1e,
+	0x5e, 0x15, 0x7b, 0xc2, 0x15, 0x90, 0xc6, 0xee, 0xfc, 0xff, 0x2a, 0x34, 0x34, 0x18, 0x15, 0xf3,
+	0x11, 0x56, 0xd8, 0xeb, 0x05, 0xfe, 0x80, 0xa6, 0x34, 0x16, 0x4a, 0x27, 0x87, 0x22, 0x9f, 0x7f,
+	0x72, 0xe4, 0x45, 0xa3, 0xd4, 0xeb, 0xd1, 0xa3, 0x98, 0x72, 0xfb, 0xde, 0x72, 0x73, 0x28, 0xf2,
+	0x61, 0x97, 0x6b, 0x7c, 0x5c, 0xaa, 0x72, 0xa8, 0x3c, 0x64, 0xe6, 0x7d, 0x54, 0xcb, 0x0e, 0x99,
+	0x79, 0x8f, 0xe4, 0x97, 0x94, 0x89, 0x92, 0x25, 0xe5, 0x6d, 0x58, 0xe4, 0x8b, 0x87, 0x50, 0xb3,
+	0x5e, 0x6e, 0xbe, 0x8e, 0xa1, 0xe2, 0xe8, 0x63, 0x9d, 0xa5, 0xa6, 0x49, 0x82, 0x6f, 0xf1, 0x63,
+	0x0a, 0xcb, 0x2d, 0xe0, 0xc8, 0xcb, 0x24, 0x5e, 0xe7, 0xe5, 0x32, 0x58, 0xc0, 0x19, 0xaf, 0xff,
+	0xdc, 0xe4, 0xad, 0x0b, 0xde, 0x1c, 0xee, 0xcc, 0x40, 0x63, 0x2f, 0x8d, 0x86, 0x72, 0x50, 0x66,
+	0xa1, 0xc9, 0x93, 0xe2, 0x3c, 0xf4, 0x2a, 0x5c, 0x61, 0x52, 0xb4, 0x1f, 0x0d, 0xa3, 0x7e, 0x74,
+	0x74, 0xb6, 0x37, 0x3a, 0x48, 0xba, 0x71, 0x30, 0x4c, 0x83, 0x28, 0x74, 0xfe, 0xc2, 0x82, 0x79,
+	0x83, 0x2a, 0x9c, 0xd9, 0x9f, 0xe0, 0xba, 0x45, 0xc5, 0x47, 0x9a, 0x51, 0x0f, 0x28, 0x6f, 0x9c,
+	0x91, 0x9f, 0x62, 0xf0, 0xef, 0x84, 0xac, 0x42, 0x4b, 0xd6, 0x4c, 0xfe, 0xc8, 0xa5, 0xb0, 0x53,
+	0x94, 0x42, 0xf1, 0xff, 0xac, 0xf8, 0x41, 0x66, 0xf1, 0x59, 0x11, 0xc8, 0xd5, 0x63, 0x6d, 0x94,
+	0x5e, 0x4d, 0x15, 0x75, 0xa3, 0xfb, 0x36, 0x64, 0x0d, 0xba, 0x0a, 0x4c, 0x9c, 0x5f, 0xb7, 0x00,
+	0xb2, 0xda, 0x99, 0x67, 0xd8, 0x56, 0xfe, 0x0c, 0xfb, 0x15, 0x68, 0xaa, 0x50, 0x89, 0x6c, 0xc1,
+	0x6f, 0x48, 0x0c, 0xcd, 0xb8, 0xdb, 0xd0, 0x3a, 0xea, 0x47, 0x07, 0xcc, 0x1a, 0x16, 0xe7, 0xd2,
+	0x3c, 0xd8, 0x78, 0x96, 0xc3, 0x0f, 0x04, 0x9a, 0x59, 0x07, 0x35, 0xcd, 0x3a, 0x70, 0xbe, 0x5b,
+	0x81, 0xb9, 0x42, 0x9b, 0xc7, 0xce, 0x32, 0xb2, 0x52, 0x58, 0xa5, 0xc6, 0x9c, 0xbf, 0x32, 0xff,
+	0xfd, 0xee, 0xb9, 0xee, 0xc5, 0xf7, 0x60, 0x36, 0xe6, 0xcb, 0x80, 0x5c, 0x23, 0x6a, 0x2f, 0x59,
+	0x23, 0x66, 0x62, 0x3d, 0x89, 0x01, 0x54, 0x7e, 0xef, 0x84, 0xc6, 0x69, 0xc0, 0x1c, 0x3c, 0xcc,
+	0x7e, 0xe3, 0x2b, 0x5b, 0x4b, 0xc3, 0x99, 0x59, 0x75, 0x1b, 0x5a, 0x22, 0xc0, 0x5b, 0x71, 0x8a,
+	0x3b, 0x49, 0x19, 0x8c, 0x8c, 0xce, 0x0f, 0xe4, 0xd9, 0xb3, 0x39, 0x86, 0xe3, 0x7b, 0x44, 0x6f,
+	0x5d, 0x25, 0xd7, 0xba, 0x57, 0xc5, 0x81, 0x5c, 0x4f, 0x7a, 0x91, 0xaa, 0x5a, 0xd0, 0x5f, 0x4f,
+	0x9c, 0xdb, 0x9b, 0x5d, 0x5a, 0xbb, 0x48, 0x97, 0xe2, 0xf1, 0xce, 0xd4, 0x56, 0x34, 0xdc, 0x12,
+	0xe1, 0x8f, 0x6c, 0x22, 0xa8, 0x8b, 0x15, 0x32, 0xf9, 0x92, 0xc0, 0xc8, 0x52, 0xb3, 0x69, 0x26,
+	0x6f, 0x36, 0x7d, 0x01, 0xae, 0x22, 0x30, 0x8c, 0x23, 0x5c, 0x11, 0x82, 0x08, 0x6d, 0x7f, 0x66,
+	0x23, 0x45, 0x61, 0x7a, 0x2c, 0xd5, 0xd8, 0xcb, 0x58, 0x98, 0xb3, 0x08, 0x37, 0x0b, 0x7c, 0x47,
+	0x2b, 0x96, 0x2a, 0xae, 0xdd, 0x8a, 0x04, 0xe7, 0xd3, 0x50, 0x57, 0x1b, 0x7d, 0xf2, 0x06, 0xd4,
+	0x71, 0xe3, 0xc4, 0xbd, 0x01, 0x96, 0x11, 0xb8, 0x2b, 0x5a, 0xee, 0x66, 0x0c, 0xce, 0x4f, 0xaa,
+	0x30, 0xf5, 0x28, 0x3c, 0x89, 0x82, 0x2e, 0x3b, 0xa6, 0x1e, 0xd0, 0x41, 0x24, 0xaf, 0x99, 0xe0,
+	0x37, 0x76, 0x05, 0x0b, 0xac, 0x1e, 0xa6, 0xe2, 0x9c, 0x59, 0x26, 0xd1, 0xee, 0x8a, 0xb3, 0xeb,
+	0x6e, 0x7c, 0xea, 0x68, 0x08, 0x8b, 0xd1, 0xd2, 0xaf, 0x82, 0x8a, 0x54, 0x76, 0x7b, 0x69, 0x42,
+	0xbb, 0xbd, 0x84, 0xe5, 0x88, 0x50, 0xcd, 0xce, 0xa4, 0x08, 0x6a, 0xe0, 0x49, 0xe6, 0x4d, 0x88,
+	0x29, 0xf7, 0x3d, 0x33, 0x0b, 0x6e, 0x4a, 0x78, 0x13, 0x74, 0x10, 0xad, 0x3c, 0xfe, 0x03, 0xe7,
+	0xe1, 0xca, 0x57, 0x87, 0xd0, 0x6e, 0xce, 0xdf, 0x26, 0xad, 0x73, 0x99, 0xcf, 0xc1, 0xa8, 0xa1,
+	0x7b, 0x54, 0x29, 0x52, 0xde, 0x06, 0xe0, 0xd7, 0xf9, 0xf2, 0xb8, 0xe6, 0x83, 0xe0, 0xb1, 0xef,
+	0x22, 0xc5, 0x04, 0xc5, 0xef, 0xf7, 0x0f, 0xfc, 0xee, 0x33, 0x76, 0x80, 0xcc, 0x42, 0xdd, 0xeb,
+	0xae, 0x09, 0x62, 0xad, 0xb5, 0xd1, 0x64, 0x61, 0x46, 0x35, 0x57, 0x87, 0xc8, 0x8a, 0xe9, 0xdd,
+	0x99, 0x1d, 0xe3, 0xdd, 0xd1, 0x99, 0xf4, 0x13, 0xe2, 0x96, 0x19, 0x8d, 0xfe, 0x15, 0x20, 0xab,
+	0xbd, 0x9e, 0x18, 0x6f, 0xb5, 0xb3, 0xcc, 0x46, 0xca, 0x32, 0x46, 0xaa, 0xa4, 0xc7, 0x2a, 0xa5,
+	0x3d, 0xe6, 0x6c, 0x42, 0x63, 0x57, 0xbb, 0x77, 0xca, 0x44, 0x23, 0x77, 0x09, 0x54, 0x43, 0xb4,
+	0x02, 0x2b, 0x7a, 0x81, 0xce, 0xa7, 0x80, 0x60, 0x90, 0x9e, 0xaa, 0x5f, 0x76, 0xd1, 0x55, 0xfa,
+	0x1b, 0xb3, 0x58, 0xf9, 0x86, 0xc0, 0x58, 0x0c, 0xfb, 0x2a, 0xcc, 0x1b, 0x3f, 0x66, 0x21, 0xec,
+	0x01, 0x87, 0xf2, 0x33, 0x41, 0x72, 0x2a, 0x3a, 0x1a, 0xce, 0x02, 0x34, 0x56, 0xd1, 0x1f, 0x5a,
+	0x30, 0x25, 0x9a, 0x56, 0x7a, 0xcf, 0xb4, 0x9e, 0xbb, 0x67, 0x5a, 0x7a, 0x37, 0xaf, 0x28, 0xc3,
+	0xd5, 0x32, 0x19, 0xc6, 0xdb, 0x3a, 0x7e, 0x7a, 0xcc, 0xf6, 0x9a, 0x75, 0x97, 0x7d, 0x93, 0x36,
+	0xf7, 0x7c, 0xf1, 0xb9, 0x82, 0x9f, 0xa5, 0x97, 0x4d, 0x27, 0xcd, 0x7b, 0xb6, 0x12, 0x77, 0x2e,
+	0xf3, 0x7e, 0xc9, 0x5f, 0xa1, 0x15, 0x21, 0xff, 0x19, 0x9c, 0xf5, 0x97, 0xc8, 0x22, 0xdf, 0x5f,
+	0x82, 0xd5, 0x55, 0x74, 0xbc, 0xd5, 0xb5, 0x41, 0xfb, 0x34, 0xa5, 0xab, 0xfd, 0x7e, 0x3e, 0xff,
+	0xab, 0x70, 0xa5, 0x84, 0x26, 0x8c, 0x96, 0x07, 0x30, 0xb7, 0x41, 0x0f, 0x46, 0x47, 0xdb, 0xf4,
+	0x24, 0x0b, 0x26, 0x20, 0x50, 0x4b, 0x8e, 0xa3, 0x53, 0x31, 0xb6, 0xec, 0x1b, 0xbd, 0x2a, 0x7d,
+	0xe4, 0xf1, 0x92, 0x21, 0xed, 0x4a, 0x37, 0x31, 0x43, 0xf6, 0x86, 0xb4, 0xeb, 0xbc, 0x0d, 0x44,
+	0xcf, 0x47, 0x34, 0x01, 0xf5, 0xc0, 0xe8, 0xc0, 0x4b, 0xce, 0x92, 0x94, 0x0e, 0x64, 0x30, 0x9c,
+	0x0e, 0x39, 0xb7, 0xd9, 0xe5, 0x59, 0x97, 0x7e, 0x28, 0x2e, 0x3d, 0xa3, 0x9b, 0xc3, 0x3f, 0x43,
+	0x51, 0x56, 0x6e, 0x0e, 0x46, 0x76, 0xfe, 0xb5, 0x02, 0x93, 0x9c, 0x13, 0x73, 0xed, 0xd1, 0x24,
+	0x0d, 0x42, 0x7e, 0x86, 0x2f, 0x72, 0xd5, 0xa0, 0xd2, 0x1b, 0xcd, 0x79, 0xd9, 0x10, 0xd6, 0xaa,
+	0xbc, 0xb1, 0x22, 0x84, 0xc0, 0xc0, 0xa4, 0x97, 0x86, 0x87, 0x1d, 0xf2, 0xcd, 0x54, 0x06, 0xe4,
+	0x3c, 0x9e, 0x99, 0xb6, 0xe1, 0xf5, 0x93, 0x42, 0x2b, 0xc4, 0x41, 0x87, 0x4a, 0x75, 0x1a, 0x77,
+	0x36, 0x17, 0xf0, 0xa2, 0xee, 0x9a, 0xbe, 0x80, 0xee, 0xe2, 0x26, 0xec, 0xcb, 0x74, 0x17, 0x5c,
+	0x40, 0x77, 0x61, 0xc4, 0x31, 0x73, 0x7a, 0xe1, 0xaa, 0x28, 0xc5, 0xe9, 0x7b, 0x16, 0xb4, 0xc5,
+	0x82, 0xae, 0x68, 0xe4, 0x15, 0x63, 0xf5, 0x2f, 0xbd, 0xdf, 0xf0, 0x1a, 0xcc, 0x98, 0xfb, 0x47,
+	0xe1, 0x87, 0x36, 0x40, 0x6c, 0x87, 0x3c, 0x70, 0x1c, 0x04, 0x7d, 0x31, 0x28, 0x3a, 0x24, 0xbd,
+	0x6a, 0xb1, 0x0c, 0xb9, 0xb1, 0x5c, 0x95, 0x76, 0xfe, 0xd8, 0x82, 0x39, 0xad, 0xc2, 0x42, 0x0a,
+	0xdf, 0x03, 0x19, 0x46, 0xc7, 0xfd, 0xbc, 0x66, 0x7c, 0x4c, 0xbe, 0x2d, 0xae, 0xc1, 0xcc, 0x06,
+	0xd3, 0x3f, 0x63, 0x15, 0x4c, 0x46, 0x03, 0x61, 0x81, 0xe8, 0x10, 0x0a, 0xd2, 0x29, 0xa5, 0xcf,
+	0x14, 0x4b, 0x95, 0xb1, 0x18, 0x18, 0x73, 0xb6, 0xa0, 0x2d, 0xa1, 0x98, 0x6a, 0xc2, 0xd9, 0xa2,
+	0x83, 0xce, 0x8f, 0x2d, 0x98, 0xe7, 0x46, 0xa1, 0x30, 0xb9, 0x55, 0x58, 0xf3, 0x24, 0xb7, 0x82,
+	0xf9, 0x8c, 0xdc, 0xba, 0xe4, 0x8a, 0x34, 0xf9, 0xe4, 0x05, 0x0d, 0x59, 0x15, 0x1d, 0x37, 0x66,
+	0x2c, 0xaa, 0x65, 0x63, 0xf1, 0x92, 0x9e, 0x2e, 0xf3, 0x7b, 0x4d, 0x94, 0xfa, 0xbd, 0xd6, 0xa6,
+	0x60, 0x22, 0xe9, 0x46, 0x43, 0x8a, 0x47, 0x79, 0x66, 0xe3, 0x84, 0x0a, 0xfa, 0xbe, 0x05, 0x9d,
+	0x07, 0xdc, 0xff, 0x8f, 0x87, 0x80, 0x41, 0x92, 0x46, 0xb1, 0xba, 0x15, 0x8e, 0xb7, 0x7e, 0x52,
+	0x3f, 0x4e, 0x79, 0x48, 0xbc, 0xf0, 0x37, 0x65, 0x08, 0xd6, 0x91, 0x86, 0x3d, 0x4e, 0xe5, 0x63,
+	0xa3, 0xd2, 0x38, 0x30, 0x2c, 0x72, 0xcf, 0x8b, 0x0e, 0x0f, 0x13, 0xaa, 0xcc, 0x56, 0x1d, 0xc3,
+	0x9d, 0x2f, 0xce, 0x78, 0xdc, 0xeb, 0xd1, 0x13, 0xa6, 0x6a, 0xb9, 0x3d, 0x98, 0x43, 0x9d, 0x3f,
+	0xb2, 0xa0, 0x95, 0x55, 0x72, 0x13, 0x41, 0x53, 0x3b, 0xf0, 0xaa, 0x65, 0x80, 0xf2, 0x84, 0x05,
+	0x3d, 0x2f, 0x08, 0x45, 0xdd, 0x34, 0x84, 0xcd, 0x58, 0x91, 0x8a, 0x46, 0xf2, 0xfa, 0x81, 0x0e,
+	0xf1, 0xd8, 0x9f, 0x14, 0xff, 0xe6, 0x77, 0x0f, 0x44, 0x8a, 0xdd, 0x68, 0x18, 0xa4, 0xec, 0x2f,
+	0xee, 0xb3, 0x93, 0x49, 0xb9, 0x3e, 0x4d, 0x31, 0x14, 0x3f, 0x9d, 0xdf, 0xb0, 0xe0, 0x4a, 0x49,
+	0xe7, 0x8a, 0x99, 0xb1, 0x01, 0x73, 0x87, 0x8a, 0x28, 0x3b, 0x80, 0x4f, 0x8f, 0x45, 0x21, 0x45,
+	0xb9, 0x46, 0xbb, 0xc5, 0x1f, 0xd0, 0x3c, 0x66, 0x0e, 0x3c, 0xde, 0xa5, 0x46, 0x04, 0x65, 0x91,
+	0xb0, 0xf2, 0x83, 0x0a, 0xcc, 0xf2, 0x93, 0x5b, 0xfe, 0x28, 0x0d, 0x8d, 0xc9, 0x63, 0x98, 0x12,
+	0x8f, 0x0a, 0x91, 0xcb, 0xa2, 0x58, 0xf3, 0x19, 0x23, 0x7b, 0x31, 0x0f, 0x0b, 0xd9, 0x99, 0xff,
+	0x7f, 0x3f, 0xfa, 0x87, 0xdf, 0xaa, 0xcc, 0x90, 0xc6, 0xf2, 0xc9, 0x9b, 0xcb, 0x47, 0x34, 0x4c,
+	0x30, 0x8f, 0xaf, 0x03, 0x64, 0xcf, 0xed, 0x90, 0x8e, 0x32, 0x32, 0x72, 0xef, 0x08, 0xd9, 0x57,
+	0x4a, 0x28, 0x22, 0xdf, 0x2b, 0x2c, 0xdf, 0x79, 0x67, 0x16, 0xf3, 0x0d, 0xc2, 0x20, 0xe5, 0x6f,
+	0xef, 0xbc, 0x6b, 0xdd, 0x25, 0x3d, 0x68, 0xea, 0xaf, 0xe9, 0x10, 0xb9, 0x65, 0x2e, 0x79, 0xcb,
+	0xc7, 0xbe, 0x5a, 0x4a, 0x93, 0xfe, 0x02, 0x56, 0xc6, 0x65, 0xa7, 0x8d, 0x65, 0x8c, 0x18, 0x87,
+	0x2a, 0x65, 0xe5, 0xdf, 0x3e, 0x06, 0x75, 0xe5, 0x76, 0x22, 0x1f, 0xc0, 0x8c, 0x71, 0xd8, 0x4d,
+	0x64, 0xc6, 0x65, 0x67, 0xe3, 0xf6, 0xb5, 0x72, 0xa2, 0x28, 0xf6, 0x06, 0x2b, 0xb6, 0x43, 0x16,
+	0xb1, 0x58, 0x71, 0x5a, 0xbc, 0xcc, 0x8e, 0xf8, 0x79, 0xf0, 0xfe, 0x33, 0x98, 0x35, 0x0f, 0xa8,
+	0xc9, 0x35, 0x53, 0xa1, 0xe4, 0x4a, 0xbb, 0x3e, 0x86, 0x2a, 0x8a, 0xbb, 0xc6, 0x8a, 0x5b, 0x24,
+	0x0b, 0x7a, 0x71, 0xca, 0x1d, 0x44, 0xd9, 0x75, 0x0b, 0xfd, 0x99, 0x1d, 0x72, 0x5d, 0x0d, 0x75,
+	0xd9, 0xf3, 0x3b, 0x6a, 0xd0, 0x8a, 0x6f, 0xf0, 0x38, 0x1d, 0x56, 0x14, 0x21, 0xac, 0x43, 0xf5,
+	0x57, 0x76, 0xc8, 0xd7, 0xa0, 0xae, 0x5e, 0x37, 0x20, 0x4b, 0xda, 0x93, 0x12, 0xfa, 0x93, 0x0b,
+	0x76, 0xa7, 0x48, 0x28, 0x1b, 0x2a, 0x3d, 0x67, 0x14, 0x88, 0x6d, 0xb8, 0x2c, 0x8c, 0xd4, 0x03,
+	0xfa, 0xb3, 0xb4, 0xa4, 0xe4, 0x71, 0xa0, 0xfb, 0x16, 0x79, 0x0f, 0xa6, 0xe5, 0xa3, 0x11, 0x64,
+	0xb1, 0xfc, 0xf1, 0x0b, 0x7b, 0xa9, 0x80, 0x8b, 0xf9, 0xfc, 0x0d, 0x98, 0x12, 0xaf, 0x15, 0xa8,
+	0x89, 0x64, 0xbe, 0x9f, 0x60, 0x2f, 0xe6, 0x61, 0xd1, 0xc2, 0x57, 0x59, 0x0b, 0xaf, 0x3b, 0x9d,
+	0x7c, 0x0b, 0x97, 0x0f, 0x46, 0x83, 0xe1, 0x21, 0xa5, 0xd8, 0xd2, 0x55, 0x80, 0xec, 0x7d, 0x00,
+	0x35, 0xb1, 0x0a, 0xaf, 0x16, 0xd8, 0x57, 0x4a, 0x28, 0xa2, 0x86, 0x47, 0x30, 0x57, 0x78, 0x7e,
+	0x80, 0xdc, 0xcc, 0xf8, 0x4b, 0x1f, 0x26, 0x78, 0x49, 0x86, 0xce, 0x22, 0xab, 0x78, 0x9b, 0xb0,
+	0x99, 0x1a, 0xd2, 0x53, 0x79, 0xb9, 0x6b, 0x03, 0x1a, 0xda, 0x9b, 0x03, 0x44, 0xe6, 0x50, 0x7c,
+	0xaf, 0xc0, 0xb6, 0xcb, 0x48, 0xa2, 0xba, 0x5f, 0x84, 0x19, 0xe3, 0xf1, 0x00, 0x35, 0xf1, 0xca,
+	0x9e, 0x26, 0xb0, 0xaf, 0x95, 0x13, 0x45, 0x5e, 0x5f, 0x85, 0x86, 0x76, 0xd5, 0x9f, 0x68, 0x41,
+	0xa7, 0xb9, 0x4b, 0xfe, 0xb6, 0x5d, 0x46, 0x12, 0xed, 0x5d, 0x60, 0xed, 0x9d, 0x75, 0xea, 0xd8,
+	0x5e, 0x76, 0xb9, 0x07, 0x47, 0xe6, 0x03, 0x98, 0x35, 0x2f, 0xff, 0xab, 0x49, 0x5b, 0xfa, 0x8c,
+	0x80, 0x7d, 0x7d, 0x0c, 0xd5, 0x94, 0xf7, 0xbb, 0xf3, 0xaa, 0x90, 0xe5, 0x8f, 0xc4, 0xf1, 0xdc,
+	0x0b, 0xf2, 0x65, 0xa8, 0xab, 0x2b, 0x67, 0x24, 0x7b, 0xf2, 0xc0, 0xbc, 0x98, 0x66, 0x77, 0x8a,
+	0x04, 0x91, 0xf9, 0x1c, 0xcb, 0xbc, 0x41, 0xb2, 0x16, 0x90, 0x0f, 0xa0, 0x95, 0xbb, 0x0f, 0xa6,
+	0x26, 0x4f, 0xf9, 0x0d, 0x32, 0xfb, 0xc6, 0x38, 0xb2, 0x28, 0xc4, 0xd0, 0x05, 0xbc, 0x05, 0xfc,
+	0xce, 0x1a, 0x39, 0x80, 0xba, 0xba, 0x09, 0xa6, 0xaa, 0x9f, 0xbf, 0x49, 0x66, 0x77, 0x8a, 0x04,
+	0x91, 0xb3, 0xc3, 0x72, 0xbe, 0x76, 0xd7, 0xce, 0xe7, 0xac, 0x75, 0x11, 0x5b, 0xd0, 0xd8, 0x2d,
+	0x32, 0x6d, 0x41, 0xd3, 0x2f, 0x9a, 0xd9, 0x8b, 0x79, 0xb8, 0x7c, 0x41, 0x4b, 0x03, 0xcc, 0xe3,
+	0x3b, 0x16, 0x2c, 0x96, 0x5f, 0xc6, 0x21, 0xf2, 0xe9, 0x90, 0x97, 0x5e, 0x47, 0xb2, 0x3f, 0x76,
+	0x0e, 0x97, 0x28, 0xfc, 0x26, 0x2b, 0xfc, 0x8a, 0xc3, 0x74, 0x75, 0x18, 0xf5, 0xa8, 0xaf, 0x71,
+	0xa1, 0x98, 0x85, 0xd0, 0xca, 0x85, 0xb3, 0xa9, 0x71, 0x2a, 0x8f, 0xff, 0xb5, 0x6f, 0x8c, 0x23,
+	0x97, 0x2d, 0x0f, 0x72, 0x59, 0x58, 0x96, 0xe1, 0xda, 0xdf, 0x80, 0xa6, 0x7e, 0x1b, 0x5e, 0xad,
+	0xb5, 0x25, 0x77, 0xf8, 0xed, 0xab, 0xa5, 0x34, 0x73, 0xd6, 0x90, 0xa6, 0x5e, 0x0c, 0xce, 0x1a,
+	0xf3, 0x3e, 0x6a, 0xb6, 0xd4, 0x95, 0x5d, 0xb4, 0xb5, 0xaf, 0x8f, 0xa1, 0x9a, 0xb3, 0x86, 0xcc,
+	0x1b, 0x6d, 0xe1, 0x5e, 0x52, 0xf2, 0x55, 0x68, 0x69, 0xb1, 0xa2, 0x7b, 0x67, 0x61, 0x57, 0x69,
+	0x80, 0x62, 0x38, 0xbf, 0x5d, 0x66, 0xc3, 0x3b, 0x4b, 0x2c, 0xff, 0x39, 0xc7, 0x68, 0x04, 0x0e,
+	0xcb, 0x3a, 0x34, 0xb4, 0x3c, 0x5e, 0x96, 0xef, 0x92, 0x46, 0xd2, 0x83, 0xea, 0xef, 0x5b, 0x24,
+	0x2e, 0xb9, 0x4f, 0x71, 0x63, 0xdc, 0x1d, 0x02, 0x91, 0xdd, 0xcd, 0xb1, 0x74, 0xd1, 0x25, 0xd7,
+	0x59, 0x95, 0x97, 0x1c, 0x62, 0x74, 0xc9, 0x01, 0xb2, 0x63, 0xc5, 0x7f, 0x17, 0x1f, 0xbb, 0xd2,
+	0x23, 0x49, 0x8d, 0xf3, 0x87, 0x5c, 0x61, 0x1d, 0x9d, 0xa6, 0x57, 0xde, 0x71, 0x59, 0x29, 0xdb,
+	0x77, 0xbf, 0x68, 0x94, 0xf2, 0x91, 0xb1, 0xff, 0xbc, 0x97, 0x7f, 0xf8, 0xea, 0x45, 0x9e, 0x41,
+	0xbf, 0xec, 0xf3, 0xe2, 0xbe, 0x45, 0xde, 0xe5, 0x2f, 0xf3, 0x49, 0x7f, 0x13, 0xd1, 0x16, 0xdd,
+	0xfc, 0x30, 0xe9, 0xef, 0xd4, 0xdd, 0xb1, 0xee, 0x5b, 0xe4, 0x9b, 0xd0, 0xd2, 0xfe, 0x65, 0xa3,
+	0x7d, 0xd1, 0xff, 0x9d, 0xd7, 0x58, 0x6b, 0x6e, 0x38, 0x57, 0x8c, 0xd6, 0xe4, 0xad, 0x8e, 0x00,
+	0x1a, 0xda, 0x33, 0x74, 0xd9, 0xfa, 0x56, 0x78, 0x9a, 0xae, 0xbc, 0x90, 0xbb, 0xac, 0x90, 0xd7,
+	0x9c, 0x9b, 0x63, 0x0b, 0x59, 0x66, 0x8e, 0x00, 0x2c, 0xea, 0x43, 0x68, 0xea, 0x6f, 0xbf, 0xa9,
+	0x41, 0x2a, 0x79, 0x77, 0xce, 0x5e, 0x28, 0x7b, 0xca, 0xcd, 0xf9, 0x38, 0x2b, 0xed, 0x36, 0xf9,
+	0x18, 0xd3, 0x99, 0x9c, 0xc4, 0x4a, 0xeb, 0x3e, 0x5b, 0xfe, 0x28, 0xff, 0x1e, 0xdd, 0x0b, 0xd6,
+	0x7f, 0x33, 0x7a, 0xee, 0x89, 0x5a, 0x77, 0xcb, 0x1e, 0xa1, 0x1b, 0x53, 0xa8, 0xcd, 0x0a, 0x5d,
+	0x20, 0xa4, 0x58, 0xe8, 0x7d, 0x8b, 0xec, 0x02, 0x64, 0xce, 0x57, 0x92, 0xf3, 0x44, 0x2a, 0x83,
+	0xa3, 0xe8, 0x9f, 0x35, 0x67, 0xa1, 0x74, 0x58, 0x62, 0x37, 0x7d, 0x8d, 0x2b, 0x2b, 0xc1, 0x9f,
+	0xa8, 0x21, 0x29, 0x3a, 0x51, 0x6d, 0xbb, 0x8c, 0x54, 0xa6, 0xaa, 0x64, 0xfe, 0xe4, 0x29, 0xcc,
+	0x6c, 0x47, 0xd1, 0xb3, 0xd1, 0x50, 0xd6, 0x98, 0x98, 0x6d, 0x46, 0x4f, 0xaf, 0x9d, 0x6b, 0x85,
+	0x73, 0x8b, 0x65, 0x65, 0x93, 0x8e, 0x96, 0xd5, 0xf2, 0x47, 0x99, 0xeb, 0xf7, 0x05, 0xf1, 0x61,
+	0x4e, 0xd9, 0xae, 0xaa, 0xe2, 0xb6, 0x99, 0x8d, 0xee, 0x81, 0x2d, 0x14, 0x61, 0xec, 0x26, 0x64,
+	0x6d, 0x97, 0x13, 0x99, 0x27, 0xeb, 0xe8, 0xe6, 0x06, 0xed, 0x46, 0x3d, 0x2a, 0xbc, 0x77, 0xf3,
+	0x59, 0xc5, 0x95, 0xdb, 0xcf, 0x9e, 0x31, 0x40, 0x73, 0x55, 0x18, 0xfa, 0x67, 0x31, 0xfd, 0x70,
+	0xf9, 0x23, 0xe1, 0x17, 0x7c, 0x21, 0x57, 0x05, 0x25, 0x1b, 0x7a, 0x6f, 0xe6, 0x45, 0xe3, 0x6a,
+	0x29, 0xad, 0xac, 0xab, 0xa5, 0x84, 0x90, 0x3e, 0xcc, 0x15, 0xfc, 0xa5, 0xca, 0x44, 0x1d, 0xe7,
+	0x65, 0xb5, 0x6f, 0x8d, 0x67, 0x30, 0x4b, 0xbb, 0x6b, 0x96, 0xb6, 0x07, 0x33, 0x1b, 0x94, 0x77,
+	0x16, 0x8f, 0x41, 0xc8, 0x3d, 0x7c, 0xa0, 0x47, 0x88, 0xd8, 0xf3, 0x25, 0x34, 0xd3, 0x9e, 0x62,
+	0x01, 0x00, 0xe4, 0x1b, 0xd0, 0xd0, 0xe2, 0x1f, 0x94, 0x24, 0x16, 0x63, 0x4e, 0xec, 0xa5, 0x22,
+	0x89, 0x85, 0x4b, 0x98, 0x06, 0x14, 0xcb, 0x75, 0x99, 0x32, 0x9e, 0xfb, 0x16, 0xf9, 0x1a, 0x34,
+	0x1e, 0xd2, 0x54, 0xc6, 0x34, 0xa8, 0x6d, 0x4a, 0x2e, 0xc8, 0xc1, 0x2e, 0x09, 0x89, 0x30, 0x45,
+	0x52, 0x64, 0xdb, 0x3b, 0xa2, 0x5c, 0x17, 0x7b, 0x41, 0xef, 0x05, 0xf9, 0x5f, 0x2c, 0x73, 0x15,
+	0xd1, 0xb6, 0xa8, 0x1d, 0x85, 0xeb, 0x99, 0xb7, 0x72, 0x78, 0x59, 0xce, 0x68, 0xc2, 0x68, 0x56,
+	0x59, 0x08, 0x0d, 0x2d, 0xd0, 0x56, 0xf5, 0x4a, 0x31, 0xba, 0xd9, 0xb6, 0xcb, 0x48, 0x62, 0x18,
+	0xef, 0xb0, 0x72, 0x1c, 0x72, 0x2b, 0x2b, 0x87, 0x87, 0x1a, 0x66, 0x25, 0x2d, 0x7f, 0xe4, 0x0f,
+	0xd2, 0x17, 0xa4, 0x07, 0x90, 0x45, 0x42, 0xaa, 0xed, 0x52, 0x21, 0x6e, 0xd3, 0xbe, 0x52, 0x42,
+	0x11, 0x85, 0xbd, 0xc2, 0x0a, 0xbb, 0xea, 0x2c, 0x16, 0x0a, 0x3b, 0x40, 0x66, 0x54, 0x3b, 0xdf,
+	0x86, 0x76, 0x3e, 0x4a, 0x51, 0xad, 0xdb, 0x63, 0xa2, 0x29, 0xed, 0x9b, 0x63, 0xe9, 0xa2, 0xdc,
+	0xdb, 0xac, 0xdc, 0x57, 0x9c, 0x6b, 0x85, 0x72, 0xa9, 0xf8, 0x45, 0x6c, 0x09, 0xdf, 0x67, 0x4f,
+	0x28, 0xe8, 0xb1, 0x29, 0xd9, 0x5e, 0x2d, 0x1f, 0xc6, 0x62, 0x93, 0x22, 0xc9, 0xdc, 0xbf, 0xf1,
+	0x92, 0x98, 0xcd, 0xfb, 0x49, 0x00, 0x8c, 0xae, 0xd8, 0xf0, 0xe9, 0x20, 0x0a, 0xb3, 0xc5, 0x33,
+	0x8b, 0xbf, 0xb0, 0xe7, 0x0d, 0x4c, 0x6c, 0xb2, 0xde, 0xd7, 0x36, 0xe3, 0x46, 0x68, 0x8f, 0x9c,
+	0x9f, 0x63, 0x43, 0x34, 0x6c, 0xbb, 0x8c, 0x43, 0x99, 0x47, 0xab, 0x00, 0xd9, 0x01, 0x87, 0x1a,
+	0xcc, 0xc2, 0xd9, 0x89, 0x7d, 0xa5, 0x84, 0x22, 0xea, 0xb6, 0x0b, 0xf5, 0xcc, 0x63, 0x2e, 0x27,
+	0x5e, 0xde, 0xbf, 0x6e, 0x77, 0x8a, 0x04, 0xf9, 0x5c, 0x05, 0xeb, 0x2a, 0x20, 0xd3, 0xd8, 0x55,
+	0xcc, 0x39, 0x1d, 0xc0, 0x3c, 0xaf, 0xa0, 0xb2, 0x13, 0x59, 0x44, 0x81, 0x6c, 0x49, 0x89, 0x2f,
+	0xd9, 0xbe, 0x5a, 0x4a, 0x2b, 0x73, 0x7b, 0xe1, 0x8c, 0xe4, 0xd1, 0x0c, 0x38, 0xd0, 0x03, 0x98,
+	0x2b, 0xf8, 0x11, 0x95, 0x56, 0x1c, 0xe7, 0xbe, 0xb5, 0x6f, 0x8d, 0x67, 0x10, 0x45, 0x5e, 0x66,
+	0x45, 0xb6, 0x1c, 0xc0, 0x22, 0x93, 0xd3, 0x80, 0x5b, 0x86, 0x07, 0x93, 0xec, 0x41, 0xf3, 0xb7,
+	0xfe, 0x63, 0x00, 0xaf, 0x64, 0x92, 0x90, 0x02, 0x5d, 0x00, 0x00,
 }
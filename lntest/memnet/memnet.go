@@ -0,0 +1,236 @@
+// Package memnet provides an in-memory network that allows several nodes to
+// connect to each other within a single process, without opening any sockets.
+package memnet
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+var (
+	// ErrListenerClosed is returned when accepting connections on a
+	// listener that has been closed.
+	ErrListenerClosed = errors.New("listener closed")
+
+	// ErrConnRefused is returned when dialing an address on which no
+	// listener is active.
+	ErrConnRefused = errors.New("connection refused")
+)
+
+// firstEphemeralPort is the first port handed out to the dialing side of a
+// connection.
+const firstEphemeralPort = 49152
+
+// Network is an in-memory network which connects dialers to listeners using
+// synchronous net.Pipe connections rather than sockets. Addresses are plain
+// "host:port" strings, and are reported as TCP addresses, so components that
+// expect to run on top of TCP are unable to tell the difference. A Network
+// implements the torsvc.Net interface, allowing it to be used as the network
+// of an lnd node.
+type Network struct {
+	mu        sync.Mutex
+	listeners map[string]*Listener
+	nextPort  int
+}
+
+// NewNetwork creates a new in-memory network without any active listeners.
+func NewNetwork() *Network {
+	return &Network{
+		listeners: make(map[string]*Listener),
+		nextPort:  firstEphemeralPort,
+	}
+}
+
+// Listen creates a new listener which accepts connections dialed to the passed
+// address.
+func (n *Network) Listen(address string) (*Listener, error) {
+	addr, err := n.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.listeners[addr.String()]; ok {
+		return nil, fmt.Errorf("address %v already in use", addr)
+	}
+
+	l := &Listener{
+		net:   n,
+		addr:  addr,
+		conns: make(chan net.Conn),
+		quit:  make(chan struct{}),
+	}
+	n.listeners[addr.String()] = l
+
+	return l, nil
+}
+
+// Dial connects to the listener active on the passed address. The returned
+// connection reports an ephemeral port on the IP of the target address as its
+// local address.
+func (n *Network) Dial(network, address string) (net.Conn, error) {
+	addr, err := n.ResolveTCPAddr(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	l, ok := n.listeners[addr.String()]
+	localAddr := &net.TCPAddr{IP: addr.IP, Port: n.nextPort}
+	n.nextPort++
+	n.mu.Unlock()
+
+	if !ok {
+		return nil, &net.OpError{
+			Op:   "dial",
+			Net:  network,
+			Addr: addr,
+			Err:  ErrConnRefused,
+		}
+	}
+
+	local, remote := net.Pipe()
+	select {
+	case l.conns <- &conn{Conn: remote, local: addr, remote: localAddr}:
+	case <-l.quit:
+		local.Close()
+		remote.Close()
+		return nil, &net.OpError{
+			Op:   "dial",
+			Net:  network,
+			Addr: addr,
+			Err:  ErrConnRefused,
+		}
+	}
+
+	return &conn{Conn: local, local: localAddr, remote: addr}, nil
+}
+
+// LookupHost resolves the passed host, which must be an IP address as the
+// in-memory network doesn't support DNS.
+func (n *Network) LookupHost(host string) ([]string, error) {
+	if net.ParseIP(host) == nil {
+		return nil, fmt.Errorf("unable to resolve host %v", host)
+	}
+
+	return []string{host}, nil
+}
+
+// LookupSRV always fails, as the in-memory network doesn't support DNS.
+func (n *Network) LookupSRV(service, proto, name string) (string, []*net.SRV,
+	error) {
+
+	return "", nil, fmt.Errorf("unable to lookup SRV record of %v", name)
+}
+
+// ResolveTCPAddr parses the passed "host:port" address, whose host must be an
+// IP address.
+func (n *Network) ResolveTCPAddr(network, address string) (*net.TCPAddr,
+	error) {
+
+	if network != "tcp" {
+		return nil, fmt.Errorf("unsupported network: %v", network)
+	}
+
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("unable to resolve host %v", host)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %v: %v", portStr, err)
+	}
+
+	return &net.TCPAddr{IP: ip, Port: port}, nil
+}
+
+// removeListener removes the passed listener from the set of active
+// listeners.
+func (n *Network) removeListener(l *Listener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.listeners[l.addr.String()] == l {
+		delete(n.listeners, l.addr.String())
+	}
+}
+
+// Listener is a net.Listener which accepts connections dialed over an
+// in-memory Network.
+type Listener struct {
+	net  *Network
+	addr *net.TCPAddr
+
+	conns chan net.Conn
+
+	closeOnce sync.Once
+	quit      chan struct{}
+}
+
+// A compile-time assertion to ensure that Listener meets the net.Listener
+// interface.
+var _ net.Listener = (*Listener)(nil)
+
+// Accept waits for and returns the next connection to the listener.
+//
+// Part of the net.Listener interface.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.quit:
+		return nil, ErrListenerClosed
+	}
+}
+
+// Close stops the listener from accepting any further connections, and
+// unblocks any pending calls to Accept.
+//
+// Part of the net.Listener interface.
+func (l *Listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.quit)
+		l.net.removeListener(l)
+	})
+
+	return nil
+}
+
+// Addr returns the address the listener accepts connections on.
+//
+// Part of the net.Listener interface.
+func (l *Listener) Addr() net.Addr {
+	return l.addr
+}
+
+// conn is one end of an in-memory connection, which reports TCP addresses
+// rather than the addresses of the underlying pipe.
+type conn struct {
+	net.Conn
+
+	local  net.Addr
+	remote net.Addr
+}
+
+// LocalAddr returns the local network address.
+//
+// Part of the net.Conn interface.
+func (c *conn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// RemoteAddr returns the remote network address.
+//
+// Part of the net.Conn interface.
+func (c *conn) RemoteAddr() net.Addr {
+	return c.remote
+}
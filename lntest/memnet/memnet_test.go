@@ -0,0 +1,104 @@
+package memnet
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+)
+
+// TestDialListen asserts that data written on either end of a connection
+// dialed over the network arrives at the other end, and that both ends report
+// the expected addresses.
+func TestDialListen(t *testing.T) {
+	t.Parallel()
+
+	n := NewNetwork()
+
+	l, err := n.Listen("127.0.0.1:9735")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer l.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			t.Errorf("unable to accept: %v", err)
+			close(accepted)
+			return
+		}
+		accepted <- c
+	}()
+
+	dialed, err := n.Dial("tcp", "127.0.0.1:9735")
+	if err != nil {
+		t.Fatalf("unable to dial: %v", err)
+	}
+	defer dialed.Close()
+
+	remote, ok := <-accepted
+	if !ok {
+		t.Fatalf("no connection accepted")
+	}
+	defer remote.Close()
+
+	if dialed.RemoteAddr().String() != l.Addr().String() {
+		t.Fatalf("expected remote address %v, got %v", l.Addr(),
+			dialed.RemoteAddr())
+	}
+	if remote.RemoteAddr().String() != dialed.LocalAddr().String() {
+		t.Fatalf("expected remote address %v, got %v",
+			dialed.LocalAddr(), remote.RemoteAddr())
+	}
+	if _, ok := dialed.LocalAddr().(*net.TCPAddr); !ok {
+		t.Fatalf("expected TCP address, got %T", dialed.LocalAddr())
+	}
+
+	msg := []byte("hello")
+	go dialed.Write(msg)
+
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(remote, buf); err != nil {
+		t.Fatalf("unable to read: %v", err)
+	}
+	if !bytes.Equal(buf, msg) {
+		t.Fatalf("expected %x, got %x", msg, buf)
+	}
+}
+
+// TestDialRefused asserts that dialing an address without an active listener
+// fails, including after the listener has been closed.
+func TestDialRefused(t *testing.T) {
+	t.Parallel()
+
+	n := NewNetwork()
+
+	if _, err := n.Dial("tcp", "127.0.0.1:9735"); err == nil {
+		t.Fatalf("expected dial to fail")
+	}
+
+	l, err := n.Listen("127.0.0.1:9735")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	if _, err := n.Listen("127.0.0.1:9735"); err == nil {
+		t.Fatalf("expected duplicate listen to fail")
+	}
+	l.Close()
+
+	if _, err := n.Dial("tcp", "127.0.0.1:9735"); err == nil {
+		t.Fatalf("expected dial to fail after close")
+	}
+	if _, err := l.Accept(); err != ErrListenerClosed {
+		t.Fatalf("expected ErrListenerClosed, got %v", err)
+	}
+
+	// The address can be reused once the listener has been closed.
+	l, err = n.Listen("127.0.0.1:9735")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	l.Close()
+}
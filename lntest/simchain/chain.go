@@ -0,0 +1,1065 @@
+// Package simchain provides a simulated blockchain along with implementations
+// of the chain backend interfaces of lnd driven by it, allowing several nodes
+// to share a chain within a single process without any external daemons.
+package simchain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// BlockInterval is the amount of time the clock of the chain is
+	// advanced by for every block that is mined.
+	BlockInterval = 10 * time.Minute
+
+	// mempoolHeight is the height reported for outputs and spends which
+	// haven't been included in a block yet.
+	mempoolHeight = -1
+)
+
+// UpdateType describes the kind of change to the chain an Update carries.
+type UpdateType uint8
+
+const (
+	// BlockConnected indicates that a block was connected to the tip of
+	// the main chain.
+	BlockConnected UpdateType = iota
+
+	// BlockDisconnected indicates that the tip of the main chain was
+	// disconnected during a reorganization.
+	BlockDisconnected
+
+	// TxAccepted indicates that a transaction was accepted into the
+	// mempool.
+	TxAccepted
+)
+
+// Update is delivered to subscribers of the chain for every block that is
+// connected or disconnected, and every transaction that is accepted into the
+// mempool.
+type Update struct {
+	// Type is the kind of change this update represents.
+	Type UpdateType
+
+	// Block is the connected or disconnected block. This is nil for
+	// TxAccepted updates.
+	Block *wire.MsgBlock
+
+	// Height is the height of the connected or disconnected block.
+	Height int32
+
+	// Tx is the accepted transaction. This is only set for TxAccepted
+	// updates.
+	Tx *wire.MsgTx
+}
+
+// utxoEntry is an unspent output within the main chain.
+type utxoEntry struct {
+	txOut    *wire.TxOut
+	height   int32
+	coinbase bool
+}
+
+// spentOutput records an output spent by a block, so that it can be
+// restored if the block is disconnected.
+type spentOutput struct {
+	op    wire.OutPoint
+	entry *utxoEntry
+}
+
+// txLocation is the position of a transaction within the main chain.
+type txLocation struct {
+	blockHash chainhash.Hash
+	height    int32
+	index     uint32
+}
+
+// spendLocation describes the transaction spending an output, along with the
+// height it was included at, or mempoolHeight if it's unconfirmed.
+type spendLocation struct {
+	tx         *wire.MsgTx
+	inputIndex uint32
+	height     int32
+}
+
+// Chain is a simulated blockchain which lives entirely in memory. Blocks are
+// only ever produced by explicitly mining them, which makes tests built on top
+// of it fully deterministic. Transactions are validated against the main chain
+// and mempool, including their scripts and lock times, before they're
+// accepted, so that broken transactions are caught as they would be by a real
+// node. Proof of work is not simulated.
+type Chain struct {
+	params *chaincfg.Params
+	clock  *clock.TestClock
+
+	mu sync.RWMutex
+
+	// blocks holds the blocks of the main chain, indexed by height.
+	blocks []*wire.MsgBlock
+
+	// blockIndex holds every block ever mined, including those that
+	// have been reorganized out of the main chain.
+	blockIndex map[chainhash.Hash]*wire.MsgBlock
+
+	// blockHeights holds the height of every block ever mined.
+	blockHeights map[chainhash.Hash]int32
+
+	// undo holds the outputs spent by each block within the main chain.
+	undo map[chainhash.Hash][]spentOutput
+
+	utxos   map[wire.OutPoint]*utxoEntry
+	txIndex map[chainhash.Hash]txLocation
+	spends  map[wire.OutPoint]spendLocation
+
+	// mempool holds the accepted but unconfirmed transactions, with
+	// mempoolOrder preserving the order they were accepted in so that
+	// parents are always mined before their children.
+	mempool       map[chainhash.Hash]*wire.MsgTx
+	mempoolOrder  []chainhash.Hash
+	mempoolSpends map[wire.OutPoint]spendLocation
+
+	// faucetNonce is used to create the made up outpoints spent by the
+	// transactions created by the faucet.
+	faucetNonce uint64
+
+	// nonce is incremented for every mined block, ensuring that blocks
+	// replacing those reorganized out of the chain have distinct hashes.
+	nonce uint32
+
+	subscribers map[uint64]*Subscription
+	nextSubID   uint64
+}
+
+// NewChain creates a new simulated chain for the passed network, starting at
+// its genesis block. The timestamps of mined blocks are taken from the passed
+// clock, which is advanced by BlockInterval for every block.
+func NewChain(params *chaincfg.Params, clock *clock.TestClock) *Chain {
+	genesis := params.GenesisBlock
+	genesisHash := genesis.BlockHash()
+
+	return &Chain{
+		params:        params,
+		clock:         clock,
+		blocks:        []*wire.MsgBlock{genesis},
+		blockIndex:    map[chainhash.Hash]*wire.MsgBlock{genesisHash: genesis},
+		blockHeights:  map[chainhash.Hash]int32{genesisHash: 0},
+		undo:          make(map[chainhash.Hash][]spentOutput),
+		utxos:         make(map[wire.OutPoint]*utxoEntry),
+		txIndex:       make(map[chainhash.Hash]txLocation),
+		spends:        make(map[wire.OutPoint]spendLocation),
+		mempool:       make(map[chainhash.Hash]*wire.MsgTx),
+		mempoolSpends: make(map[wire.OutPoint]spendLocation),
+		subscribers:   make(map[uint64]*Subscription),
+	}
+}
+
+// Params returns the parameters of the network the chain simulates.
+func (c *Chain) Params() *chaincfg.Params {
+	return c.params
+}
+
+// Clock returns the clock that drives the timestamps of the chain.
+func (c *Chain) Clock() *clock.TestClock {
+	return c.clock
+}
+
+// GetBestBlock returns the hash and height of the tip of the main chain.
+//
+// This is part of the lnwallet.BlockChainIO interface.
+func (c *Chain) GetBestBlock() (*chainhash.Hash, int32, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	hash, height := c.tip()
+	return &hash, height, nil
+}
+
+// GetUtxo returns the output at the passed outpoint if it's unspent within
+// the main chain.
+//
+// This is part of the lnwallet.BlockChainIO interface.
+func (c *Chain) GetUtxo(op *wire.OutPoint, _ uint32) (*wire.TxOut, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.utxos[*op]
+	if !ok {
+		return nil, fmt.Errorf("output %v not found", op)
+	}
+
+	return entry.txOut, nil
+}
+
+// GetBlockHash returns the hash of the block at the passed height within the
+// main chain.
+//
+// This is part of the lnwallet.BlockChainIO interface.
+func (c *Chain) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if blockHeight < 0 || blockHeight >= int64(len(c.blocks)) {
+		return nil, fmt.Errorf("no block at height %v", blockHeight)
+	}
+
+	hash := c.blocks[blockHeight].BlockHash()
+	return &hash, nil
+}
+
+// GetBlock returns the block with the passed hash. Blocks that have been
+// reorganized out of the main chain are still returned.
+//
+// This is part of the lnwallet.BlockChainIO interface.
+func (c *Chain) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	block, ok := c.blockIndex[*blockHash]
+	if !ok {
+		return nil, fmt.Errorf("block %v not found", blockHash)
+	}
+
+	return block, nil
+}
+
+// BlockHeight returns the height of the block with the passed hash. Blocks
+// that have been reorganized out of the main chain are still found.
+func (c *Chain) BlockHeight(blockHash *chainhash.Hash) (int32, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	height, ok := c.blockHeights[*blockHash]
+	if !ok {
+		return 0, fmt.Errorf("block %v not found", blockHash)
+	}
+
+	return height, nil
+}
+
+// A compile time check to ensure Chain implements the BlockChainIO interface.
+var _ lnwallet.BlockChainIO = (*Chain)(nil)
+
+// tip returns the hash and height of the tip of the main chain.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) tip() (chainhash.Hash, int32) {
+	height := int32(len(c.blocks) - 1)
+	return c.blocks[height].BlockHash(), height
+}
+
+// PublishTransaction validates the passed transaction, and adds it to the
+// mempool so it's included in the next mined block. Publishing a transaction
+// that is already known is not an error. If any of the outputs spent by the
+// transaction don't exist, or have already been spent, then
+// lnwallet.ErrDoubleSpend is returned.
+func (c *Chain) PublishTransaction(tx *wire.MsgTx) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	txid := tx.TxHash()
+	if _, ok := c.mempool[txid]; ok {
+		return nil
+	}
+	if _, ok := c.txIndex[txid]; ok {
+		return nil
+	}
+
+	if err := c.validateTx(tx); err != nil {
+		return err
+	}
+
+	c.addToMempool(tx)
+
+	return nil
+}
+
+// validateTx checks that the passed transaction can be included in the next
+// block.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) validateTx(tx *wire.MsgTx) error {
+	if len(tx.TxIn) == 0 || len(tx.TxOut) == 0 {
+		return fmt.Errorf("transaction %v has no inputs or outputs",
+			tx.TxHash())
+	}
+
+	_, bestHeight := c.tip()
+	nextHeight := bestHeight + 1
+
+	// First, we'll look up each output spent by the transaction, which
+	// must either be unspent within the main chain, or created by a
+	// transaction within the mempool.
+	var (
+		totalIn     int64
+		prevOuts    = make([]*wire.TxOut, len(tx.TxIn))
+		prevHeights = make([]int32, len(tx.TxIn))
+	)
+	for i, txIn := range tx.TxIn {
+		op := txIn.PreviousOutPoint
+		if _, ok := c.mempoolSpends[op]; ok {
+			return lnwallet.ErrDoubleSpend
+		}
+
+		txOut, height, ok := c.fetchOutput(op)
+		if !ok {
+			return lnwallet.ErrDoubleSpend
+		}
+
+		entry, ok := c.utxos[op]
+		maturity := int32(c.params.CoinbaseMaturity)
+		if ok && entry.coinbase && nextHeight-height < maturity {
+			return fmt.Errorf("transaction %v spends immature "+
+				"coinbase output %v", tx.TxHash(), op)
+		}
+
+		// Unconfirmed outputs are treated as if they'll be included
+		// in the next block along with the transaction.
+		if height == mempoolHeight {
+			height = nextHeight
+		}
+
+		totalIn += txOut.Value
+		prevOuts[i] = txOut
+		prevHeights[i] = height
+	}
+
+	var totalOut int64
+	for _, txOut := range tx.TxOut {
+		if txOut.Value < 0 {
+			return fmt.Errorf("transaction %v has negative output",
+				tx.TxHash())
+		}
+		totalOut += txOut.Value
+	}
+	if totalOut > totalIn {
+		return fmt.Errorf("transaction %v spends %v, but only has "+
+			"inputs worth %v", tx.TxHash(),
+			btcutil.Amount(totalOut), btcutil.Amount(totalIn))
+	}
+
+	if err := c.checkLockTimes(tx, prevHeights, nextHeight); err != nil {
+		return err
+	}
+
+	// Finally, we'll execute the script of every input to ensure the
+	// transaction is properly signed.
+	hashCache := txscript.NewTxSigHashes(tx)
+	for i, prevOut := range prevOuts {
+		vm, err := txscript.NewEngine(
+			prevOut.PkScript, tx, i, txscript.StandardVerifyFlags,
+			nil, hashCache, prevOut.Value,
+		)
+		if err != nil {
+			return err
+		}
+		if err := vm.Execute(); err != nil {
+			return fmt.Errorf("input %v of transaction %v is "+
+				"invalid: %v", i, tx.TxHash(), err)
+		}
+	}
+
+	return nil
+}
+
+// checkLockTimes ensures that both the absolute and relative lock times of the
+// passed transaction allow it to be included in a block at nextHeight. Time
+// based relative lock times are not supported.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) checkLockTimes(tx *wire.MsgTx, prevHeights []int32,
+	nextHeight int32) error {
+
+	if tx.LockTime != 0 {
+		final := true
+		for _, txIn := range tx.TxIn {
+			if txIn.Sequence != wire.MaxTxInSequenceNum {
+				final = false
+				break
+			}
+		}
+
+		var locked bool
+		if tx.LockTime < txscript.LockTimeThreshold {
+			locked = int64(tx.LockTime) >= int64(nextHeight)
+		} else {
+			locked = int64(tx.LockTime) >= c.clock.Now().Unix()
+		}
+
+		if !final && locked {
+			return fmt.Errorf("transaction %v is locked until %v",
+				tx.TxHash(), tx.LockTime)
+		}
+	}
+
+	if tx.Version < 2 {
+		return nil
+	}
+
+	for i, txIn := range tx.TxIn {
+		if txIn.Sequence&wire.SequenceLockTimeDisabled != 0 {
+			continue
+		}
+		if txIn.Sequence&wire.SequenceLockTimeIsSeconds != 0 {
+			return fmt.Errorf("time based relative lock times " +
+				"are not supported")
+		}
+
+		relativeLock := int32(txIn.Sequence & wire.SequenceLockTimeMask)
+		if prevHeights[i]+relativeLock > nextHeight {
+			return fmt.Errorf("input %v of transaction %v is "+
+				"locked until height %v", i, tx.TxHash(),
+				prevHeights[i]+relativeLock)
+		}
+	}
+
+	return nil
+}
+
+// fetchOutput looks up the output at the passed outpoint, which must either be
+// unspent within the main chain or be created by a transaction within the
+// mempool. The height of the block that created the output is returned along
+// with it, or mempoolHeight if the output is unconfirmed.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) fetchOutput(op wire.OutPoint) (*wire.TxOut, int32, bool) {
+	if entry, ok := c.utxos[op]; ok {
+		return entry.txOut, entry.height, true
+	}
+
+	tx, ok := c.mempool[op.Hash]
+	if !ok || op.Index >= uint32(len(tx.TxOut)) {
+		return nil, 0, false
+	}
+
+	return tx.TxOut[op.Index], mempoolHeight, true
+}
+
+// addToMempool adds the passed transaction to the mempool and notifies all
+// subscribers. The transaction must already have been validated.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) addToMempool(tx *wire.MsgTx) {
+	txid := tx.TxHash()
+	c.mempool[txid] = tx
+	c.mempoolOrder = append(c.mempoolOrder, txid)
+
+	for i, txIn := range tx.TxIn {
+		c.mempoolSpends[txIn.PreviousOutPoint] = spendLocation{
+			tx:         tx,
+			inputIndex: uint32(i),
+			height:     mempoolHeight,
+		}
+	}
+
+	c.notify(&Update{
+		Type: TxAccepted,
+		Tx:   tx,
+	})
+}
+
+// SendTo creates a transaction paying amt to the passed script, and adds it to
+// the mempool. The inputs of faucet transactions are made up, and aren't
+// validated, allowing tests to fund wallets without having to mine and mature
+// coinbase outputs first.
+func (c *Chain) SendTo(pkScript []byte, amt btcutil.Amount) *wire.MsgTx {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.faucetNonce++
+
+	var faucetHash chainhash.Hash
+	binary.BigEndian.PutUint64(faucetHash[:], c.faucetNonce)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: faucetHash},
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	tx.AddTxOut(&wire.TxOut{
+		Value:    int64(amt),
+		PkScript: pkScript,
+	})
+
+	c.addToMempool(tx)
+
+	return tx
+}
+
+// Mine mines n blocks on top of the main chain. The first block includes all
+// transactions within the mempool.
+func (c *Chain) Mine(n int) []*wire.MsgBlock {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.mine(n)
+}
+
+// mine mines n blocks on top of the main chain.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) mine(n int) []*wire.MsgBlock {
+	blocks := make([]*wire.MsgBlock, 0, n)
+	for i := 0; i < n; i++ {
+		prevHash, bestHeight := c.tip()
+		height := bestHeight + 1
+
+		c.nonce++
+		c.clock.Advance(BlockInterval)
+
+		// Each coinbase commits to the height of its block, along with
+		// the nonce of the block to ensure blocks replacing those
+		// reorganized out of the chain have distinct transactions.
+		coinbaseScript, err := txscript.NewScriptBuilder().
+			AddInt64(int64(height)).
+			AddInt64(int64(c.nonce)).
+			Script()
+		if err != nil {
+			panic(err)
+		}
+		coinbase := wire.NewMsgTx(1)
+		coinbase.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{
+				Index: wire.MaxPrevOutIndex,
+			},
+			SignatureScript: coinbaseScript,
+			Sequence:        wire.MaxTxInSequenceNum,
+		})
+		coinbase.AddTxOut(&wire.TxOut{
+			Value:    blockchain.CalcBlockSubsidy(height, c.params),
+			PkScript: []byte{txscript.OP_TRUE},
+		})
+
+		txns := []*wire.MsgTx{coinbase}
+		for _, txid := range c.mempoolOrder {
+			txns = append(txns, c.mempool[txid])
+		}
+		c.mempool = make(map[chainhash.Hash]*wire.MsgTx)
+		c.mempoolOrder = nil
+		c.mempoolSpends = make(map[wire.OutPoint]spendLocation)
+
+		block := &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Version:    4,
+				PrevBlock:  prevHash,
+				MerkleRoot: merkleRoot(txns),
+				Timestamp:  c.clock.Now(),
+				Bits:       c.params.PowLimitBits,
+				Nonce:      c.nonce,
+			},
+			Transactions: txns,
+		}
+
+		c.connectBlock(block)
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// merkleRoot computes the merkle root of the passed transactions.
+func merkleRoot(txns []*wire.MsgTx) chainhash.Hash {
+	utilTxns := make([]*btcutil.Tx, 0, len(txns))
+	for _, tx := range txns {
+		utilTxns = append(utilTxns, btcutil.NewTx(tx))
+	}
+
+	merkles := blockchain.BuildMerkleTreeStore(utilTxns, false)
+	return *merkles[len(merkles)-1]
+}
+
+// connectBlock connects the passed block to the tip of the main chain,
+// updating the utxo set and indexes, and notifies all subscribers.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) connectBlock(block *wire.MsgBlock) {
+	blockHash := block.BlockHash()
+	height := int32(len(c.blocks))
+
+	var undo []spentOutput
+	for i, tx := range block.Transactions {
+		txid := tx.TxHash()
+		coinbase := i == 0
+
+		if !coinbase {
+			for j, txIn := range tx.TxIn {
+				op := txIn.PreviousOutPoint
+				c.spends[op] = spendLocation{
+					tx:         tx,
+					inputIndex: uint32(j),
+					height:     height,
+				}
+
+				// The inputs of faucet transactions don't
+				// exist within the utxo set.
+				entry, ok := c.utxos[op]
+				if !ok {
+					continue
+				}
+				undo = append(undo, spentOutput{
+					op:    op,
+					entry: entry,
+				})
+				delete(c.utxos, op)
+			}
+		}
+
+		for j, txOut := range tx.TxOut {
+			op := wire.OutPoint{Hash: txid, Index: uint32(j)}
+			c.utxos[op] = &utxoEntry{
+				txOut:    txOut,
+				height:   height,
+				coinbase: coinbase,
+			}
+		}
+
+		c.txIndex[txid] = txLocation{
+			blockHash: blockHash,
+			height:    height,
+			index:     uint32(i),
+		}
+	}
+
+	c.blocks = append(c.blocks, block)
+	c.blockIndex[blockHash] = block
+	c.blockHeights[blockHash] = height
+	c.undo[blockHash] = undo
+
+	c.notify(&Update{
+		Type:   BlockConnected,
+		Block:  block,
+		Height: height,
+	})
+}
+
+// disconnectTip disconnects the tip of the main chain, restoring the outputs
+// it spent, and notifies all subscribers. The transactions of the block are
+// returned, excluding its coinbase.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) disconnectTip() []*wire.MsgTx {
+	blockHash, height := c.tip()
+	block := c.blocks[height]
+
+	for i := len(block.Transactions) - 1; i >= 0; i-- {
+		tx := block.Transactions[i]
+		txid := tx.TxHash()
+
+		for j := range tx.TxOut {
+			delete(c.utxos, wire.OutPoint{Hash: txid, Index: uint32(j)})
+		}
+		if i != 0 {
+			for _, txIn := range tx.TxIn {
+				delete(c.spends, txIn.PreviousOutPoint)
+			}
+		}
+		delete(c.txIndex, txid)
+	}
+	for _, spent := range c.undo[blockHash] {
+		c.utxos[spent.op] = spent.entry
+	}
+
+	c.blocks = c.blocks[:height]
+	delete(c.undo, blockHash)
+
+	c.notify(&Update{
+		Type:   BlockDisconnected,
+		Block:  block,
+		Height: height,
+	})
+
+	return block.Transactions[1:]
+}
+
+// Reorg disconnects the top depth blocks of the main chain, then mines n new
+// blocks on top of the fork point, so n must be greater than depth for the new
+// chain to be longer. The transactions of the disconnected blocks are returned
+// to the mempool, and included in the first new block, except for the passed
+// transactions to drop, along with any transactions spending their outputs.
+// These are left out of the new chain entirely.
+func (c *Chain) Reorg(depth, n int,
+	drop ...chainhash.Hash) ([]*wire.MsgBlock, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if depth >= len(c.blocks) {
+		return nil, fmt.Errorf("unable to disconnect genesis block")
+	}
+	if n <= depth {
+		return nil, fmt.Errorf("reorg of depth %v must mine more "+
+			"than %v blocks, got %v", depth, depth, n)
+	}
+
+	// The transactions of the disconnected blocks are placed in front of
+	// those already within the mempool, as the latter may spend the
+	// former.
+	var (
+		reorged []*wire.MsgTx
+		mempool []*wire.MsgTx
+	)
+	for _, txid := range c.mempoolOrder {
+		mempool = append(mempool, c.mempool[txid])
+	}
+	for i := 0; i < depth; i++ {
+		reorged = append(c.disconnectTip(), reorged...)
+	}
+
+	c.mempool = make(map[chainhash.Hash]*wire.MsgTx)
+	c.mempoolOrder = nil
+	c.mempoolSpends = make(map[wire.OutPoint]spendLocation)
+	for _, tx := range append(reorged, mempool...) {
+		c.addToMempool(tx)
+	}
+	for _, txid := range drop {
+		if err := c.dropTransaction(txid); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.mine(n), nil
+}
+
+// DropTransaction removes the passed transaction, along with any transactions
+// spending its outputs, from the mempool. This can be used to simulate
+// transactions that are never confirmed.
+func (c *Chain) DropTransaction(txid chainhash.Hash) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.dropTransaction(txid)
+}
+
+// dropTransaction removes the passed transaction, along with any transactions
+// spending its outputs, from the mempool.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) dropTransaction(txid chainhash.Hash) error {
+	if _, ok := c.mempool[txid]; !ok {
+		return fmt.Errorf("transaction %v not found in mempool", txid)
+	}
+
+	dropped := map[chainhash.Hash]struct{}{txid: {}}
+	order := c.mempoolOrder[:0]
+	for _, hash := range c.mempoolOrder {
+		tx := c.mempool[hash]
+
+		drop := false
+		if _, ok := dropped[hash]; ok {
+			drop = true
+		}
+		for _, txIn := range tx.TxIn {
+			if _, ok := dropped[txIn.PreviousOutPoint.Hash]; ok {
+				drop = true
+			}
+		}
+
+		if !drop {
+			order = append(order, hash)
+			continue
+		}
+
+		dropped[hash] = struct{}{}
+		delete(c.mempool, hash)
+		for _, txIn := range tx.TxIn {
+			delete(c.mempoolSpends, txIn.PreviousOutPoint)
+		}
+	}
+	c.mempoolOrder = order
+
+	return nil
+}
+
+// TxConfirmation returns the block hash, height and index of the passed
+// transaction within the main chain. False is returned if the transaction
+// hasn't been confirmed.
+func (c *Chain) TxConfirmation(txid *chainhash.Hash) (*chainhash.Hash, int32,
+	uint32, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	loc, ok := c.txIndex[*txid]
+	if !ok {
+		return nil, 0, 0, false
+	}
+
+	blockHash := loc.blockHash
+	return &blockHash, loc.height, loc.index, true
+}
+
+// SpendDetails returns the transaction that spends the passed outpoint within
+// the main chain, or the mempool if includeMempool is true, along with the
+// index of the spending input and the height the spend was confirmed at. The
+// height is -1 for spends within the mempool. False is returned if the output
+// hasn't been spent.
+func (c *Chain) SpendDetails(op *wire.OutPoint,
+	includeMempool bool) (*wire.MsgTx, uint32, int32, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if spend, ok := c.spends[*op]; ok {
+		return spend.tx, spend.inputIndex, spend.height, true
+	}
+
+	if !includeMempool {
+		return nil, 0, 0, false
+	}
+	if spend, ok := c.mempoolSpends[*op]; ok {
+		return spend.tx, spend.inputIndex, spend.height, true
+	}
+
+	return nil, 0, 0, false
+}
+
+// UnspentOutput is an output that hasn't been spent within either the main
+// chain or the mempool.
+type UnspentOutput struct {
+	wire.OutPoint
+	*wire.TxOut
+
+	// Height is the height of the block the output was created in, or -1
+	// if the output is unconfirmed.
+	Height int32
+}
+
+// UnspentOutputs returns all outputs whose script matches the passed
+// predicate, and which are unspent within both the main chain and the mempool.
+// Coinbase outputs are never returned.
+func (c *Chain) UnspentOutputs(match func(pkScript []byte) bool) []*UnspentOutput {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var unspent []*UnspentOutput
+	for op, entry := range c.utxos {
+		if entry.coinbase || !match(entry.txOut.PkScript) {
+			continue
+		}
+		if _, ok := c.mempoolSpends[op]; ok {
+			continue
+		}
+
+		unspent = append(unspent, &UnspentOutput{
+			OutPoint: op,
+			TxOut:    entry.txOut,
+			Height:   entry.height,
+		})
+	}
+
+	for _, txid := range c.mempoolOrder {
+		tx := c.mempool[txid]
+		for i, txOut := range tx.TxOut {
+			op := wire.OutPoint{Hash: txid, Index: uint32(i)}
+			if !match(txOut.PkScript) {
+				continue
+			}
+			if _, ok := c.mempoolSpends[op]; ok {
+				continue
+			}
+
+			unspent = append(unspent, &UnspentOutput{
+				OutPoint: op,
+				TxOut:    txOut,
+				Height:   mempoolHeight,
+			})
+		}
+	}
+
+	return unspent
+}
+
+// FetchOutput returns the output at the passed outpoint if it's been created
+// by a transaction within either the main chain or the mempool, regardless of
+// whether it has been spent since.
+func (c *Chain) FetchOutput(op *wire.OutPoint) (*wire.TxOut, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var tx *wire.MsgTx
+	if loc, ok := c.txIndex[op.Hash]; ok {
+		tx = c.blocks[loc.height].Transactions[loc.index]
+	} else if mempoolTx, ok := c.mempool[op.Hash]; ok {
+		tx = mempoolTx
+	}
+
+	if tx == nil || op.Index >= uint32(len(tx.TxOut)) {
+		return nil, false
+	}
+
+	return tx.TxOut[op.Index], true
+}
+
+// ChainTx is a transaction within either the main chain or the mempool.
+type ChainTx struct {
+	Tx *wire.MsgTx
+
+	// BlockHash is the hash of the block including the transaction, or
+	// nil if the transaction is unconfirmed.
+	BlockHash *chainhash.Hash
+
+	// Height is the height of the block including the transaction, or -1
+	// if the transaction is unconfirmed.
+	Height int32
+
+	// Timestamp is the timestamp of the block including the transaction,
+	// or the current time of the chain's clock if it's unconfirmed.
+	Timestamp time.Time
+}
+
+// Transactions returns every transaction within the main chain followed by
+// those within the mempool, excluding coinbase transactions. The height of the
+// tip of the main chain is returned along with them.
+func (c *Chain) Transactions() ([]*ChainTx, int32) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var txns []*ChainTx
+	for height, block := range c.blocks {
+		blockHash := block.BlockHash()
+		for _, tx := range block.Transactions[1:] {
+			txns = append(txns, &ChainTx{
+				Tx:        tx,
+				BlockHash: &blockHash,
+				Height:    int32(height),
+				Timestamp: block.Header.Timestamp,
+			})
+		}
+	}
+
+	now := c.clock.Now()
+	for _, txid := range c.mempoolOrder {
+		txns = append(txns, &ChainTx{
+			Tx:        c.mempool[txid],
+			Height:    mempoolHeight,
+			Timestamp: now,
+		})
+	}
+
+	_, bestHeight := c.tip()
+	return txns, bestHeight
+}
+
+// MempoolTransactions returns the transactions within the mempool, in the
+// order they were accepted.
+func (c *Chain) MempoolTransactions() []*wire.MsgTx {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	txns := make([]*wire.MsgTx, 0, len(c.mempoolOrder))
+	for _, txid := range c.mempoolOrder {
+		txns = append(txns, c.mempool[txid])
+	}
+
+	return txns
+}
+
+// Subscribe registers a new subscription which receives an Update for every
+// subsequent change to the chain. The current tip of the main chain is
+// returned along with the subscription, so that no updates are missed in
+// between.
+func (c *Chain) Subscribe() (*Subscription, *chainhash.Hash, int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub := &Subscription{
+		chain:   c,
+		id:      c.nextSubID,
+		in:      make(chan *Update),
+		updates: make(chan *Update),
+		quit:    make(chan struct{}),
+	}
+	c.nextSubID++
+	c.subscribers[sub.id] = sub
+
+	sub.wg.Add(1)
+	go sub.dispatch()
+
+	hash, height := c.tip()
+	return sub, &hash, height
+}
+
+// notify delivers the passed update to all subscribers.
+//
+// NOTE: The chain's mutex MUST be held when calling this method.
+func (c *Chain) notify(update *Update) {
+	for _, sub := range c.subscribers {
+		sub.in <- update
+	}
+}
+
+// Subscription delivers the updates of the chain it was registered with, in
+// the order they occurred. Updates are queued without bound, so a slow
+// subscriber never blocks the chain.
+type Subscription struct {
+	chain *Chain
+	id    uint64
+
+	in      chan *Update
+	updates chan *Update
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Updates returns the channel over which updates are delivered.
+func (s *Subscription) Updates() <-chan *Update {
+	return s.updates
+}
+
+// Cancel stops the delivery of any further updates.
+func (s *Subscription) Cancel() {
+	s.chain.mu.Lock()
+	if _, ok := s.chain.subscribers[s.id]; !ok {
+		s.chain.mu.Unlock()
+		return
+	}
+	delete(s.chain.subscribers, s.id)
+	s.chain.mu.Unlock()
+
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// dispatch queues the updates sent by the chain, and delivers them to the
+// subscriber.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *Subscription) dispatch() {
+	defer s.wg.Done()
+
+	var pending []*Update
+	for {
+		var (
+			out  chan *Update
+			next *Update
+		)
+		if len(pending) > 0 {
+			out = s.updates
+			next = pending[0]
+		}
+
+		select {
+		case update := <-s.in:
+			pending = append(pending, update)
+
+		case out <- next:
+			pending[0] = nil
+			pending = pending[1:]
+
+		case <-s.quit:
+			return
+		}
+	}
+}
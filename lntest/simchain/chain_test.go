@@ -0,0 +1,323 @@
+package simchain
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var testSeed = []byte("simchain test wallet seed 000000")
+
+// newTestWallet creates a chain along with a wallet on top of it, funded with
+// a single confirmed output of the passed amount.
+func newTestWallet(t *testing.T, amt btcutil.Amount) (*Chain, *Wallet) {
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := NewChain(&chaincfg.RegressionNetParams, testClock)
+
+	wallet, err := NewWallet(chain, testSeed)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+
+	addr, err := wallet.NewAddress(lnwallet.WitnessPubKey, false)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	chain.SendTo(pkScript, amt)
+	chain.Mine(1)
+
+	return chain, wallet
+}
+
+// TestMineAndSpend asserts that transactions are only confirmed once mined,
+// that the wallet tracks its outputs as they're spent and created, and that
+// double spends are rejected.
+func TestMineAndSpend(t *testing.T) {
+	t.Parallel()
+
+	chain, wallet := newTestWallet(t, btcutil.SatoshiPerBitcoin)
+
+	balance, err := wallet.ConfirmedBalance(1)
+	if err != nil {
+		t.Fatalf("unable to fetch balance: %v", err)
+	}
+	if balance != btcutil.SatoshiPerBitcoin {
+		t.Fatalf("expected balance %v, got %v",
+			btcutil.Amount(btcutil.SatoshiPerBitcoin), balance)
+	}
+
+	// We'll send half of our funds to an output the wallet doesn't
+	// control.
+	output := &wire.TxOut{
+		Value:    btcutil.SatoshiPerBitcoin / 2,
+		PkScript: []byte{txscript.OP_TRUE},
+	}
+	txid, err := wallet.SendOutputs([]*wire.TxOut{output}, 10)
+	if err != nil {
+		t.Fatalf("unable to send outputs: %v", err)
+	}
+
+	if _, _, _, ok := chain.TxConfirmation(txid); ok {
+		t.Fatalf("transaction confirmed before being mined")
+	}
+	if len(chain.MempoolTransactions()) != 1 {
+		t.Fatalf("expected transaction in mempool")
+	}
+
+	// The change of the transaction is unconfirmed, so our confirmed
+	// balance should now be zero.
+	balance, err = wallet.ConfirmedBalance(1)
+	if err != nil {
+		t.Fatalf("unable to fetch balance: %v", err)
+	}
+	if balance != 0 {
+		t.Fatalf("expected zero confirmed balance, got %v", balance)
+	}
+
+	blocks := chain.Mine(1)
+	if len(blocks[0].Transactions) != 2 {
+		t.Fatalf("expected block with 2 transactions, got %v",
+			len(blocks[0].Transactions))
+	}
+
+	blockHash, height, index, ok := chain.TxConfirmation(txid)
+	if !ok {
+		t.Fatalf("transaction not confirmed")
+	}
+	if *blockHash != blocks[0].BlockHash() || height != 2 || index != 1 {
+		t.Fatalf("unexpected confirmation: hash=%v, height=%v, "+
+			"index=%v", blockHash, height, index)
+	}
+
+	balance, err = wallet.ConfirmedBalance(1)
+	if err != nil {
+		t.Fatalf("unable to fetch balance: %v", err)
+	}
+	if balance == 0 || balance >= btcutil.SatoshiPerBitcoin/2 {
+		t.Fatalf("unexpected balance after spend: %v", balance)
+	}
+
+	// Re-publishing the mined transaction isn't an error, but spending
+	// its inputs again is a double spend.
+	block, err := chain.GetBlock(blockHash)
+	if err != nil {
+		t.Fatalf("unable to fetch block: %v", err)
+	}
+	spendTx := block.Transactions[1]
+	if err := chain.PublishTransaction(spendTx); err != nil {
+		t.Fatalf("unable to republish transaction: %v", err)
+	}
+
+	doubleSpend := spendTx.Copy()
+	doubleSpend.TxOut[0].Value--
+	err = chain.PublishTransaction(doubleSpend)
+	if err != lnwallet.ErrDoubleSpend {
+		t.Fatalf("expected ErrDoubleSpend, got %v", err)
+	}
+}
+
+// TestInvalidScript asserts that transactions whose inputs aren't properly
+// signed are rejected.
+func TestInvalidScript(t *testing.T) {
+	t.Parallel()
+
+	chain, wallet := newTestWallet(t, btcutil.SatoshiPerBitcoin)
+
+	utxos, err := wallet.ListUnspentWitness(1)
+	if err != nil {
+		t.Fatalf("unable to list utxos: %v", err)
+	}
+	if len(utxos) != 1 {
+		t.Fatalf("expected 1 utxo, got %v", len(utxos))
+	}
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: utxos[0].OutPoint})
+	tx.AddTxOut(&wire.TxOut{
+		Value:    int64(utxos[0].Value) / 2,
+		PkScript: []byte{txscript.OP_TRUE},
+	})
+
+	if err := chain.PublishTransaction(tx); err == nil {
+		t.Fatalf("expected unsigned transaction to be rejected")
+	}
+}
+
+// TestRelativeLockTime asserts that transactions spending outputs with a
+// relative lock time are only accepted once the lock time has expired.
+func TestRelativeLockTime(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := NewChain(&chaincfg.RegressionNetParams, testClock)
+
+	// We'll create an output which can be spent by anyone with a relative
+	// lock time of 5 blocks.
+	const csvDelay = 5
+	witnessScript, err := txscript.NewScriptBuilder().
+		AddInt64(csvDelay).
+		AddOp(txscript.OP_CHECKSEQUENCEVERIFY).
+		Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+	scriptHash := sha256.Sum256(witnessScript)
+	pkScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(scriptHash[:]).
+		Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	fundingTx := chain.SendTo(pkScript, btcutil.SatoshiPerBitcoin)
+	chain.Mine(1)
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: fundingTx.TxHash()},
+		Sequence:         csvDelay,
+		Witness:          wire.TxWitness{witnessScript},
+	})
+	spendTx.AddTxOut(&wire.TxOut{
+		Value:    btcutil.SatoshiPerBitcoin / 2,
+		PkScript: []byte{txscript.OP_TRUE},
+	})
+
+	// The output was confirmed in the last block, so it can only be spent
+	// within the fifth block after it.
+	for i := 0; i < csvDelay-1; i++ {
+		if err := chain.PublishTransaction(spendTx); err == nil {
+			t.Fatalf("transaction accepted before lock time "+
+				"expired at height %v", i)
+		}
+		chain.Mine(1)
+	}
+
+	if err := chain.PublishTransaction(spendTx); err != nil {
+		t.Fatalf("unable to publish transaction: %v", err)
+	}
+}
+
+// TestReorg asserts that the transactions of blocks disconnected during a
+// reorg are returned to the mempool and included in the new chain, and that
+// subscribers are notified of every disconnected and connected block in
+// order.
+func TestReorg(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := NewChain(&chaincfg.RegressionNetParams, testClock)
+
+	sub, _, startHeight := chain.Subscribe()
+	defer sub.Cancel()
+
+	tx := chain.SendTo([]byte{txscript.OP_TRUE}, btcutil.SatoshiPerBitcoin)
+	txid := tx.TxHash()
+	oldBlocks := chain.Mine(2)
+
+	newBlocks, err := chain.Reorg(2, 3)
+	if err != nil {
+		t.Fatalf("unable to reorg: %v", err)
+	}
+
+	_, height, _, ok := chain.TxConfirmation(&txid)
+	if !ok {
+		t.Fatalf("transaction not confirmed after reorg")
+	}
+	if height != startHeight+1 {
+		t.Fatalf("expected transaction at height %v, got %v",
+			startHeight+1, height)
+	}
+
+	bestHash, bestHeight, err := chain.GetBestBlock()
+	if err != nil {
+		t.Fatalf("unable to fetch best block: %v", err)
+	}
+	if bestHeight != startHeight+3 || *bestHash != newBlocks[2].BlockHash() {
+		t.Fatalf("unexpected tip after reorg: %v at height %v",
+			bestHash, bestHeight)
+	}
+
+	// The stale blocks can still be fetched.
+	staleHash := oldBlocks[0].BlockHash()
+	if _, err := chain.GetBlock(&staleHash); err != nil {
+		t.Fatalf("unable to fetch stale block: %v", err)
+	}
+
+	expected := []struct {
+		updateType UpdateType
+		height     int32
+	}{
+		{TxAccepted, 0},
+		{BlockConnected, startHeight + 1},
+		{BlockConnected, startHeight + 2},
+		{BlockDisconnected, startHeight + 2},
+		{BlockDisconnected, startHeight + 1},
+		{TxAccepted, 0},
+		{BlockConnected, startHeight + 1},
+		{BlockConnected, startHeight + 2},
+		{BlockConnected, startHeight + 3},
+	}
+	for i, exp := range expected {
+		select {
+		case update := <-sub.Updates():
+			if update.Type != exp.updateType {
+				t.Fatalf("update %v: expected type %v, got %v",
+					i, exp.updateType, update.Type)
+			}
+			if update.Type != TxAccepted &&
+				update.Height != exp.height {
+
+				t.Fatalf("update %v: expected height %v, "+
+					"got %v", i, exp.height, update.Height)
+			}
+
+		case <-time.After(time.Second * 5):
+			t.Fatalf("update %v not received", i)
+		}
+	}
+}
+
+// TestReorgDropTransaction asserts that transactions dropped during a reorg,
+// along with their descendants, are left out of the new chain.
+func TestReorgDropTransaction(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := NewChain(&chaincfg.RegressionNetParams, testClock)
+
+	pkScript := []byte{txscript.OP_TRUE}
+	dropped := chain.SendTo(pkScript, btcutil.SatoshiPerBitcoin)
+	kept := chain.SendTo(pkScript, btcutil.SatoshiPerBitcoin)
+	chain.Mine(1)
+
+	droppedTxid := dropped.TxHash()
+	if _, err := chain.Reorg(1, 2, droppedTxid); err != nil {
+		t.Fatalf("unable to reorg: %v", err)
+	}
+
+	if _, _, _, ok := chain.TxConfirmation(&droppedTxid); ok {
+		t.Fatalf("dropped transaction confirmed after reorg")
+	}
+	if len(chain.MempoolTransactions()) != 0 {
+		t.Fatalf("dropped transaction left in mempool")
+	}
+
+	keptTxid := kept.TxHash()
+	if _, _, _, ok := chain.TxConfirmation(&keptTxid); !ok {
+		t.Fatalf("transaction not confirmed after reorg")
+	}
+}
@@ -0,0 +1,297 @@
+package simchain
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/routing/chainview"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// blockEvent is a connected or disconnected block, queued for delivery to the
+// client of a ChainView.
+type blockEvent struct {
+	connected bool
+	block     *chainview.FilteredBlock
+}
+
+// ChainView is an implementation of the FilteredChainView interface that is
+// driven by a simulated chain.
+type ChainView struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	chain *Chain
+	sub   *Subscription
+
+	// filterMtx guards the fields below.
+	filterMtx   sync.Mutex
+	chainFilter map[wire.OutPoint]struct{}
+	bestHeight  uint32
+
+	events      chan *blockEvent
+	newBlocks   chan *chainview.FilteredBlock
+	staleBlocks chan *chainview.FilteredBlock
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile time check to ensure ChainView implements the FilteredChainView
+// interface.
+var _ chainview.FilteredChainView = (*ChainView)(nil)
+
+// NewChainView creates a new chain view driven by the passed chain.
+func NewChainView(chain *Chain) *ChainView {
+	return &ChainView{
+		chain:       chain,
+		chainFilter: make(map[wire.OutPoint]struct{}),
+		events:      make(chan *blockEvent),
+		newBlocks:   make(chan *chainview.FilteredBlock),
+		staleBlocks: make(chan *chainview.FilteredBlock),
+		quit:        make(chan struct{}),
+	}
+}
+
+// Start subscribes the chain view to the chain, and begins dispatching
+// filtered blocks.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (c *ChainView) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	sub, _, bestHeight := c.chain.Subscribe()
+	c.sub = sub
+
+	c.filterMtx.Lock()
+	c.bestHeight = uint32(bestHeight)
+	c.filterMtx.Unlock()
+
+	c.wg.Add(2)
+	go c.chainFilterer()
+	go c.blockDispatcher()
+
+	return nil
+}
+
+// Stop stops the chain view.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (c *ChainView) Stop() error {
+	if atomic.LoadInt32(&c.started) == 0 {
+		return nil
+	}
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	c.sub.Cancel()
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// filterBlock returns the transactions of the passed block which spend any
+// of the watched outputs, removing those outputs from the filter.
+func (c *ChainView) filterBlock(block *wire.MsgBlock) []*wire.MsgTx {
+	c.filterMtx.Lock()
+	defer c.filterMtx.Unlock()
+
+	var filteredTxns []*wire.MsgTx
+	for _, tx := range block.Transactions {
+		for _, txIn := range tx.TxIn {
+			prevOp := txIn.PreviousOutPoint
+			if _, ok := c.chainFilter[prevOp]; ok {
+				filteredTxns = append(filteredTxns, tx)
+				delete(c.chainFilter, prevOp)
+				break
+			}
+		}
+	}
+
+	return filteredTxns
+}
+
+// queueEvent hands the passed event to the block dispatcher.
+func (c *ChainView) queueEvent(event *blockEvent) {
+	select {
+	case c.events <- event:
+	case <-c.quit:
+	}
+}
+
+// chainFilterer filters the blocks connected to the chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChainView) chainFilterer() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case update := <-c.sub.Updates():
+			switch update.Type {
+			case BlockConnected:
+				filtered := &chainview.FilteredBlock{
+					Hash:         update.Block.BlockHash(),
+					Height:       uint32(update.Height),
+					Transactions: c.filterBlock(update.Block),
+				}
+
+				c.filterMtx.Lock()
+				c.bestHeight = uint32(update.Height)
+				c.filterMtx.Unlock()
+
+				c.queueEvent(&blockEvent{
+					connected: true,
+					block:     filtered,
+				})
+
+			case BlockDisconnected:
+				c.filterMtx.Lock()
+				c.bestHeight = uint32(update.Height - 1)
+				c.filterMtx.Unlock()
+
+				c.queueEvent(&blockEvent{
+					block: &chainview.FilteredBlock{
+						Hash:   update.Block.BlockHash(),
+						Height: uint32(update.Height),
+					},
+				})
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// blockDispatcher delivers the queued block events to the client, in the
+// order they occurred.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChainView) blockDispatcher() {
+	defer c.wg.Done()
+
+	var pending []*blockEvent
+	for {
+		var (
+			newBlocks   chan *chainview.FilteredBlock
+			staleBlocks chan *chainview.FilteredBlock
+			next        *chainview.FilteredBlock
+		)
+		if len(pending) > 0 {
+			next = pending[0].block
+			if pending[0].connected {
+				newBlocks = c.newBlocks
+			} else {
+				staleBlocks = c.staleBlocks
+			}
+		}
+
+		select {
+		case event := <-c.events:
+			pending = append(pending, event)
+
+		case newBlocks <- next:
+			pending = pending[1:]
+
+		case staleBlocks <- next:
+			pending = pending[1:]
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// UpdateFilter adds the passed outpoints to the set of watched outputs. If
+// updateHeight is below the height of the last block processed, the blocks in
+// between are rescanned so no spends of the new outputs are missed.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (c *ChainView) UpdateFilter(ops []wire.OutPoint, updateHeight uint32) error {
+	select {
+	case <-c.quit:
+		return fmt.Errorf("chain view shutting down")
+	default:
+	}
+
+	c.filterMtx.Lock()
+	for _, op := range ops {
+		c.chainFilter[op] = struct{}{}
+	}
+	bestHeight := c.bestHeight
+	c.filterMtx.Unlock()
+
+	for height := updateHeight + 1; height <= bestHeight; height++ {
+		blockHash, err := c.chain.GetBlockHash(int64(height))
+		if err != nil {
+			return err
+		}
+		block, err := c.chain.GetBlock(blockHash)
+		if err != nil {
+			return err
+		}
+
+		filtered := c.filterBlock(block)
+		if len(filtered) == 0 {
+			continue
+		}
+
+		c.queueEvent(&blockEvent{
+			connected: true,
+			block: &chainview.FilteredBlock{
+				Hash:         *blockHash,
+				Height:       height,
+				Transactions: filtered,
+			},
+		})
+	}
+
+	return nil
+}
+
+// FilterBlock returns the transactions of the block with the passed hash that
+// spend any of the watched outputs.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (c *ChainView) FilterBlock(blockHash *chainhash.Hash) (*chainview.FilteredBlock,
+	error) {
+
+	block, err := c.chain.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := c.chain.BlockHeight(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainview.FilteredBlock{
+		Hash:         *blockHash,
+		Height:       uint32(height),
+		Transactions: c.filterBlock(block),
+	}, nil
+}
+
+// FilteredBlocks returns the channel over which the filtered version of every
+// block connected to the main chain is sent.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (c *ChainView) FilteredBlocks() <-chan *chainview.FilteredBlock {
+	return c.newBlocks
+}
+
+// DisconnectedBlocks returns the channel over which every block disconnected
+// from the main chain is sent.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (c *ChainView) DisconnectedBlocks() <-chan *chainview.FilteredBlock {
+	return c.staleBlocks
+}
@@ -0,0 +1,502 @@
+package simchain
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// reorgSafetyLimit is the assumed maximum depth of a reorganization of the
+// simulated chain.
+const reorgSafetyLimit = 100
+
+// ErrNotifierShuttingDown is returned when registering for notifications with
+// a notifier that's being stopped.
+var ErrNotifierShuttingDown = errors.New("notifier shutting down")
+
+// spendNotification is a client's intent to be notified once an outpoint is
+// spent.
+type spendNotification struct {
+	spendID   uint64
+	mempool   bool
+	spendChan chan *chainntnfs.SpendDetail
+}
+
+// epochRegistration is a client's intent to be notified of every block
+// connected to the main chain.
+type epochRegistration struct {
+	epochQueue *chainntnfs.ConcurrentQueue
+	epochChan  chan *chainntnfs.BlockEpoch
+	cancelChan chan struct{}
+	wg         sync.WaitGroup
+}
+
+// Notifier is an implementation of the ChainNotifier interface that is driven
+// by a simulated chain.
+type Notifier struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	chain *Chain
+	sub   *Subscription
+
+	// mu guards all fields below.
+	mu sync.Mutex
+
+	// bestHeight and blockHashes describe the view of the main chain
+	// the notifier has processed so far, which may lag behind that of the
+	// chain itself.
+	bestHeight  int32
+	blockHashes map[int32]chainhash.Hash
+
+	txConfNotifier *chainntnfs.TxConfNotifier
+
+	spendClientCounter uint64
+	spendNotifications map[wire.OutPoint]map[uint64]*spendNotification
+
+	epochClientCounter uint64
+	epochClients       map[uint64]*epochRegistration
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile time check to ensure Notifier implements the ChainNotifier
+// interface.
+var _ chainntnfs.ChainNotifier = (*Notifier)(nil)
+
+// NewNotifier creates a new notifier driven by the passed chain.
+func NewNotifier(chain *Chain) *Notifier {
+	return &Notifier{
+		chain:              chain,
+		blockHashes:        make(map[int32]chainhash.Hash),
+		spendNotifications: make(map[wire.OutPoint]map[uint64]*spendNotification),
+		epochClients:       make(map[uint64]*epochRegistration),
+		quit:               make(chan struct{}),
+	}
+}
+
+// Start subscribes the notifier to the chain, and begins dispatching
+// notifications.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *Notifier) Start() error {
+	if !atomic.CompareAndSwapInt32(&n.started, 0, 1) {
+		return nil
+	}
+
+	sub, bestHash, bestHeight := n.chain.Subscribe()
+
+	n.mu.Lock()
+	n.sub = sub
+	n.bestHeight = bestHeight
+	n.blockHashes[bestHeight] = *bestHash
+	n.txConfNotifier = chainntnfs.NewTxConfNotifier(
+		uint32(bestHeight), reorgSafetyLimit,
+	)
+	n.mu.Unlock()
+
+	n.wg.Add(1)
+	go n.notificationDispatcher()
+
+	return nil
+}
+
+// Stop cancels all outstanding notifications, and stops the notifier.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *Notifier) Stop() error {
+	if atomic.LoadInt32(&n.started) == 0 {
+		return nil
+	}
+	if !atomic.CompareAndSwapInt32(&n.stopped, 0, 1) {
+		return nil
+	}
+
+	n.sub.Cancel()
+	close(n.quit)
+	n.wg.Wait()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, clients := range n.spendNotifications {
+		for _, client := range clients {
+			close(client.spendChan)
+		}
+	}
+	for _, epochClient := range n.epochClients {
+		close(epochClient.cancelChan)
+		epochClient.wg.Wait()
+		epochClient.epochQueue.Stop()
+		close(epochClient.epochChan)
+	}
+	n.txConfNotifier.TearDown()
+
+	return nil
+}
+
+// notificationDispatcher processes the updates of the chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *Notifier) notificationDispatcher() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case update := <-n.sub.Updates():
+			n.mu.Lock()
+			switch update.Type {
+			case BlockConnected:
+				n.handleBlockConnected(update.Block, update.Height)
+
+			case BlockDisconnected:
+				n.handleBlockDisconnected(update.Height)
+
+			case TxAccepted:
+				n.handleTxAccepted(update.Tx)
+			}
+			n.mu.Unlock()
+
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// handleBlockConnected dispatches the notifications triggered by the passed
+// block being connected to the main chain.
+//
+// NOTE: The notifier's mutex MUST be held when calling this method.
+func (n *Notifier) handleBlockConnected(block *wire.MsgBlock, height int32) {
+	blockHash := block.BlockHash()
+	n.bestHeight = height
+	n.blockHashes[height] = blockHash
+
+	n.notifyBlockEpochs(height, &blockHash)
+
+	txns := make([]*btcutil.Tx, 0, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		n.notifySpends(tx, height)
+
+		utilTx := btcutil.NewTx(tx)
+		utilTx.SetIndex(i)
+		txns = append(txns, utilTx)
+	}
+
+	err := n.txConfNotifier.ConnectTip(&blockHash, uint32(height), txns)
+	if err != nil {
+		chainntnfs.Log.Errorf("Unable to connect block %v: %v",
+			blockHash, err)
+	}
+}
+
+// handleBlockDisconnected updates the confirmation notifications of any
+// transactions included in the disconnected tip of the main chain.
+//
+// NOTE: The notifier's mutex MUST be held when calling this method.
+func (n *Notifier) handleBlockDisconnected(height int32) {
+	delete(n.blockHashes, height)
+	n.bestHeight = height - 1
+
+	if err := n.txConfNotifier.DisconnectTip(uint32(height)); err != nil {
+		chainntnfs.Log.Errorf("Unable to disconnect block at "+
+			"height %v: %v", height, err)
+	}
+}
+
+// handleTxAccepted dispatches the spend notifications of clients which
+// requested to be notified of spends within the mempool.
+//
+// NOTE: The notifier's mutex MUST be held when calling this method.
+func (n *Notifier) handleTxAccepted(tx *wire.MsgTx) {
+	txHash := tx.TxHash()
+	for i, txIn := range tx.TxIn {
+		prevOut := txIn.PreviousOutPoint
+		for spendID, client := range n.spendNotifications[prevOut] {
+			if !client.mempool {
+				continue
+			}
+
+			client.spendChan <- &chainntnfs.SpendDetail{
+				SpentOutPoint:     &prevOut,
+				SpenderTxHash:     &txHash,
+				SpendingTx:        tx,
+				SpenderInputIndex: uint32(i),
+				SpendingHeight:    n.bestHeight + 1,
+			}
+			close(client.spendChan)
+
+			delete(n.spendNotifications[prevOut], spendID)
+		}
+
+		if len(n.spendNotifications[prevOut]) == 0 {
+			delete(n.spendNotifications, prevOut)
+		}
+	}
+}
+
+// notifySpends dispatches the spend notifications of all outpoints spent by
+// the passed transaction, which was confirmed at the passed height.
+//
+// NOTE: The notifier's mutex MUST be held when calling this method.
+func (n *Notifier) notifySpends(tx *wire.MsgTx, height int32) {
+	txHash := tx.TxHash()
+	for i, txIn := range tx.TxIn {
+		prevOut := txIn.PreviousOutPoint
+		clients, ok := n.spendNotifications[prevOut]
+		if !ok {
+			continue
+		}
+
+		for _, client := range clients {
+			chainntnfs.Log.Infof("Dispatching spend notification "+
+				"for outpoint=%v", prevOut)
+
+			client.spendChan <- &chainntnfs.SpendDetail{
+				SpentOutPoint:     &prevOut,
+				SpenderTxHash:     &txHash,
+				SpendingTx:        tx,
+				SpenderInputIndex: uint32(i),
+				SpendingHeight:    height,
+			}
+			close(client.spendChan)
+		}
+
+		delete(n.spendNotifications, prevOut)
+	}
+}
+
+// notifyBlockEpochs notifies all registered block epoch clients of the newly
+// connected block to the main chain.
+//
+// NOTE: The notifier's mutex MUST be held when calling this method.
+func (n *Notifier) notifyBlockEpochs(height int32, hash *chainhash.Hash) {
+	epoch := &chainntnfs.BlockEpoch{
+		Height: height,
+		Hash:   hash,
+	}
+
+	for _, epochClient := range n.epochClients {
+		select {
+		case epochClient.epochQueue.ChanIn() <- epoch:
+		case <-epochClient.cancelChan:
+		case <-n.quit:
+		}
+	}
+}
+
+// processed returns true if the block with the passed hash and height is part
+// of the view of the main chain the notifier has processed so far.
+//
+// NOTE: The notifier's mutex MUST be held when calling this method.
+func (n *Notifier) processed(hash *chainhash.Hash, height int32) bool {
+	if height > n.bestHeight {
+		return false
+	}
+
+	processedHash, ok := n.blockHashes[height]
+	return ok && processedHash == *hash
+}
+
+// RegisterConfirmationsNtfn registers a notification which is triggered once
+// the passed transaction reaches numConfs confirmations.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *Notifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	numConfs, _ uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	select {
+	case <-n.quit:
+		return nil, ErrNotifierShuttingDown
+	default:
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ntfn := &chainntnfs.ConfNtfn{
+		TxID:             txid,
+		NumConfirmations: numConfs,
+		Event:            chainntnfs.NewConfirmationEvent(numConfs),
+	}
+
+	// If the transaction has already been confirmed within a block we've
+	// processed, the notification may be dispatched right away.
+	// Otherwise, it'll be dispatched once we process the block including
+	// the transaction.
+	var txConf *chainntnfs.TxConfirmation
+	blockHash, height, index, ok := n.chain.TxConfirmation(txid)
+	if ok && n.processed(blockHash, height) {
+		txConf = &chainntnfs.TxConfirmation{
+			BlockHash:   blockHash,
+			BlockHeight: uint32(height),
+			TxIndex:     index,
+		}
+	}
+
+	if err := n.txConfNotifier.Register(ntfn, txConf); err != nil {
+		return nil, err
+	}
+
+	return ntfn.Event, nil
+}
+
+// RegisterSpendNtfn registers a notification which is triggered once the
+// passed outpoint is spent within the main chain, or within the mempool if
+// mempool is true.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *Notifier) RegisterSpendNtfn(outpoint *wire.OutPoint, _ uint32,
+	mempool bool) (*chainntnfs.SpendEvent, error) {
+
+	select {
+	case <-n.quit:
+		return nil, ErrNotifierShuttingDown
+	default:
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ntfn := &spendNotification{
+		spendID:   atomic.AddUint64(&n.spendClientCounter, 1),
+		mempool:   mempool,
+		spendChan: make(chan *chainntnfs.SpendDetail, 1),
+	}
+
+	// If the outpoint has already been spent within a block we've
+	// processed, or within the mempool if requested, then we'll dispatch
+	// the notification right away.
+	tx, inputIndex, height, ok := n.chain.SpendDetails(outpoint, mempool)
+	if ok {
+		var alreadySpent bool
+		switch {
+		case height == mempoolHeight:
+			alreadySpent = true
+			height = n.bestHeight + 1
+
+		default:
+			blockHash, err := n.chain.GetBlockHash(int64(height))
+			alreadySpent = err == nil && n.processed(blockHash, height)
+		}
+
+		if alreadySpent {
+			txHash := tx.TxHash()
+			ntfn.spendChan <- &chainntnfs.SpendDetail{
+				SpentOutPoint:     outpoint,
+				SpenderTxHash:     &txHash,
+				SpendingTx:        tx,
+				SpenderInputIndex: inputIndex,
+				SpendingHeight:    height,
+			}
+			close(ntfn.spendChan)
+
+			return &chainntnfs.SpendEvent{
+				Spend:  ntfn.spendChan,
+				Cancel: func() {},
+			}, nil
+		}
+	}
+
+	if _, ok := n.spendNotifications[*outpoint]; !ok {
+		n.spendNotifications[*outpoint] = make(map[uint64]*spendNotification)
+	}
+	n.spendNotifications[*outpoint][ntfn.spendID] = ntfn
+
+	return &chainntnfs.SpendEvent{
+		Spend: ntfn.spendChan,
+		Cancel: func() {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+
+			clients, ok := n.spendNotifications[*outpoint]
+			if !ok {
+				return
+			}
+			if _, ok := clients[ntfn.spendID]; !ok {
+				return
+			}
+
+			close(ntfn.spendChan)
+			delete(clients, ntfn.spendID)
+			if len(clients) == 0 {
+				delete(n.spendNotifications, *outpoint)
+			}
+		},
+	}, nil
+}
+
+// RegisterBlockEpochNtfn returns a BlockEpochEvent which subscribes the caller
+// to receive a notification for each new block connected to the main chain.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *Notifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, error) {
+	select {
+	case <-n.quit:
+		return nil, ErrNotifierShuttingDown
+	default:
+	}
+
+	reg := &epochRegistration{
+		epochQueue: chainntnfs.NewConcurrentQueue(20),
+		epochChan:  make(chan *chainntnfs.BlockEpoch, 20),
+		cancelChan: make(chan struct{}),
+	}
+	reg.epochQueue.Start()
+
+	// We'll proxy the items added to the queue to the client, ensuring all
+	// notifications are received in order without blocking the notifier.
+	reg.wg.Add(1)
+	go func() {
+		defer reg.wg.Done()
+
+		for {
+			select {
+			case ntfn := <-reg.epochQueue.ChanOut():
+				blockNtfn := ntfn.(*chainntnfs.BlockEpoch)
+				select {
+				case reg.epochChan <- blockNtfn:
+				case <-reg.cancelChan:
+					return
+				case <-n.quit:
+					return
+				}
+
+			case <-reg.cancelChan:
+				return
+
+			case <-n.quit:
+				return
+			}
+		}
+	}()
+
+	n.mu.Lock()
+	epochID := atomic.AddUint64(&n.epochClientCounter, 1)
+	n.epochClients[epochID] = reg
+	n.mu.Unlock()
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: reg.epochChan,
+		Cancel: func() {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+
+			if _, ok := n.epochClients[epochID]; !ok {
+				return
+			}
+			delete(n.epochClients, epochID)
+
+			close(reg.cancelChan)
+			reg.wg.Wait()
+			reg.epochQueue.Stop()
+			close(reg.epochChan)
+		},
+	}, nil
+}
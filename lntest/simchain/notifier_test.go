@@ -0,0 +1,206 @@
+package simchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestNotifierConfirmations asserts that confirmation notifications are
+// dispatched once the target number of confirmations is reached, both for
+// transactions confirmed before and after registration, and that a reorg of
+// a confirmed transaction is reported.
+func TestNotifierConfirmations(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := NewChain(&chaincfg.RegressionNetParams, testClock)
+
+	notifier := NewNotifier(chain)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	epochs, err := notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		t.Fatalf("unable to register for epochs: %v", err)
+	}
+	defer epochs.Cancel()
+
+	tx := chain.SendTo([]byte{txscript.OP_TRUE}, btcutil.SatoshiPerBitcoin)
+	txid := tx.TxHash()
+
+	confEvent, err := notifier.RegisterConfirmationsNtfn(&txid, 2, 0)
+	if err != nil {
+		t.Fatalf("unable to register for confirmation: %v", err)
+	}
+
+	blocks := chain.Mine(1)
+	select {
+	case <-confEvent.Confirmed:
+		t.Fatalf("confirmation dispatched after a single block")
+	case epoch := <-epochs.Epochs:
+		if *epoch.Hash != blocks[0].BlockHash() {
+			t.Fatalf("unexpected epoch: %v", epoch.Hash)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("epoch not received")
+	}
+
+	chain.Mine(1)
+	select {
+	case conf := <-confEvent.Confirmed:
+		if *conf.BlockHash != blocks[0].BlockHash() {
+			t.Fatalf("expected confirmation in block %v, got %v",
+				blocks[0].BlockHash(), conf.BlockHash)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("confirmation not received")
+	}
+
+	// Wait for the notifier to process the second block, so registering
+	// for the already confirmed transaction dispatches right away.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-epochs.Epochs:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("epoch not received")
+		}
+	}
+
+	histEvent, err := notifier.RegisterConfirmationsNtfn(&txid, 1, 0)
+	if err != nil {
+		t.Fatalf("unable to register for confirmation: %v", err)
+	}
+	select {
+	case <-histEvent.Confirmed:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("historical confirmation not received")
+	}
+
+	// Finally, reorging out the transaction's block should be reported
+	// to the clients awaiting its confirmation.
+	reorgEvent, err := notifier.RegisterConfirmationsNtfn(&txid, 6, 0)
+	if err != nil {
+		t.Fatalf("unable to register for confirmation: %v", err)
+	}
+	if err := chain.DropTransaction(txid); err == nil {
+		t.Fatalf("expected confirmed transaction not to be dropped")
+	}
+	if _, err := chain.Reorg(2, 3); err != nil {
+		t.Fatalf("unable to reorg: %v", err)
+	}
+
+	select {
+	case depth := <-reorgEvent.NegativeConf:
+		if depth != 2 {
+			t.Fatalf("expected reorg depth 2, got %v", depth)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("negative confirmation not received")
+	}
+}
+
+// TestNotifierSpends asserts that spend notifications are dispatched for
+// spends within the mempool if requested, for spends within blocks, and for
+// outputs already spent at the time of registration.
+func TestNotifierSpends(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := NewChain(&chaincfg.RegressionNetParams, testClock)
+
+	notifier := NewNotifier(chain)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	fundingTx := chain.SendTo(
+		[]byte{txscript.OP_TRUE}, btcutil.SatoshiPerBitcoin,
+	)
+	chain.Mine(1)
+
+	outpoint := &wire.OutPoint{Hash: fundingTx.TxHash()}
+	mempoolSpend, err := notifier.RegisterSpendNtfn(outpoint, 0, true)
+	if err != nil {
+		t.Fatalf("unable to register for spend: %v", err)
+	}
+	blockSpend, err := notifier.RegisterSpendNtfn(outpoint, 0, false)
+	if err != nil {
+		t.Fatalf("unable to register for spend: %v", err)
+	}
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{PreviousOutPoint: *outpoint})
+	spendTx.AddTxOut(&wire.TxOut{
+		Value:    btcutil.SatoshiPerBitcoin / 2,
+		PkScript: []byte{txscript.OP_TRUE},
+	})
+	if err := chain.PublishTransaction(spendTx); err != nil {
+		t.Fatalf("unable to publish spend: %v", err)
+	}
+
+	select {
+	case spend := <-mempoolSpend.Spend:
+		if *spend.SpenderTxHash != spendTx.TxHash() {
+			t.Fatalf("unexpected spender: %v", spend.SpenderTxHash)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("mempool spend not received")
+	}
+	select {
+	case <-blockSpend.Spend:
+		t.Fatalf("spend dispatched before being mined")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	blocks := chain.Mine(1)
+	select {
+	case spend := <-blockSpend.Spend:
+		if spend.SpendingHeight != 2 {
+			t.Fatalf("expected spend at height 2, got %v",
+				spend.SpendingHeight)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("spend not received")
+	}
+
+	// Registering for the spend once it's been confirmed should dispatch
+	// the notification right away. We'll wait for the notifier to process
+	// the block first.
+	epochs, err := notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		t.Fatalf("unable to register for epochs: %v", err)
+	}
+	defer epochs.Cancel()
+	chain.Mine(1)
+	select {
+	case <-epochs.Epochs:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("epoch not received")
+	}
+
+	histSpend, err := notifier.RegisterSpendNtfn(outpoint, 0, false)
+	if err != nil {
+		t.Fatalf("unable to register for spend: %v", err)
+	}
+	select {
+	case spend := <-histSpend.Spend:
+		if spend.SpendingHeight != 2 {
+			t.Fatalf("expected spend at height 2, got %v",
+				spend.SpendingHeight)
+		}
+		if *spend.SpenderTxHash != blocks[0].Transactions[1].TxHash() {
+			t.Fatalf("unexpected spender: %v", spend.SpenderTxHash)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("historical spend not received")
+	}
+}
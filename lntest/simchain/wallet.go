@@ -0,0 +1,881 @@
+package simchain
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+// addressPurpose is the BIP0043 purpose of the keys backing the addresses of
+// the wallet.
+const addressPurpose = 84
+
+// walletScript is a script controlled by the wallet.
+type walletScript struct {
+	privKey  *btcec.PrivateKey
+	addrType lnwallet.AddressType
+}
+
+// Wallet is a deterministic wallet which tracks its outputs on a simulated
+// chain. It implements the WalletController, Signer, MessageSigner and
+// SecretKeyRing interfaces, providing everything needed to back an
+// lnwallet.LightningWallet. As the wallet derives its state from the chain on
+// demand, it doesn't need to be synced.
+type Wallet struct {
+	chain   *Chain
+	rootKey *hdkeychain.ExtendedKey
+
+	// mu guards all fields below.
+	mu sync.Mutex
+
+	// nextIndex is the index of the next key to derive within each key
+	// family.
+	nextIndex map[keychain.KeyFamily]uint32
+
+	// privKeys holds every private key derived so far, keyed by the
+	// serialized public key.
+	privKeys map[string]*btcec.PrivateKey
+
+	// scripts holds the output scripts of every address created so far.
+	scripts       map[string]*walletScript
+	nextAddrIndex uint32
+
+	lockedOutpoints map[wire.OutPoint]struct{}
+}
+
+// A compile time check to ensure Wallet implements the interfaces needed to
+// back a LightningWallet.
+var (
+	_ lnwallet.WalletController = (*Wallet)(nil)
+	_ lnwallet.Signer           = (*Wallet)(nil)
+	_ lnwallet.MessageSigner    = (*Wallet)(nil)
+	_ keychain.SecretKeyRing    = (*Wallet)(nil)
+)
+
+// NewWallet creates a new wallet on the passed chain, deriving all of its keys
+// from the passed seed.
+func NewWallet(chain *Chain, seed []byte) (*Wallet, error) {
+	rootKey, err := hdkeychain.NewMaster(seed, chain.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Wallet{
+		chain:           chain,
+		rootKey:         rootKey,
+		nextIndex:       make(map[keychain.KeyFamily]uint32),
+		privKeys:        make(map[string]*btcec.PrivateKey),
+		scripts:         make(map[string]*walletScript),
+		lockedOutpoints: make(map[wire.OutPoint]struct{}),
+	}, nil
+}
+
+// derivePrivKey derives the private key at the passed path from the root key
+// of the wallet, and records it so it can later be looked up by its public
+// key.
+//
+// NOTE: The wallet's mutex MUST be held when calling this method.
+func (w *Wallet) derivePrivKey(path ...uint32) (*btcec.PrivateKey, error) {
+	key := w.rootKey
+	for _, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	w.privKeys[string(privKey.PubKey().SerializeCompressed())] = privKey
+
+	return privKey, nil
+}
+
+// deriveKeyFromLocator derives the private key at the passed location, using
+// the same path layout as the keychain of the btcwallet backend.
+//
+// NOTE: The wallet's mutex MUST be held when calling this method.
+func (w *Wallet) deriveKeyFromLocator(
+	keyLoc keychain.KeyLocator) (*btcec.PrivateKey, error) {
+
+	return w.derivePrivKey(
+		hdkeychain.HardenedKeyStart+keychain.BIP0043Purpose,
+		hdkeychain.HardenedKeyStart+uint32(keyLoc.Family),
+		0, keyLoc.Index,
+	)
+}
+
+// fetchPrivKey returns the private key described by the passed key
+// descriptor, either by deriving it from its locator if set, or by looking it
+// up by its public key otherwise.
+func (w *Wallet) fetchPrivKey(
+	keyDesc *keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !keyDesc.KeyLocator.IsEmpty() {
+		return w.deriveKeyFromLocator(keyDesc.KeyLocator)
+	}
+
+	if keyDesc.PubKey == nil {
+		return nil, fmt.Errorf("key descriptor has neither locator " +
+			"nor public key")
+	}
+
+	privKey, ok := w.privKeys[string(keyDesc.PubKey.SerializeCompressed())]
+	if !ok {
+		return nil, fmt.Errorf("private key for %x not found",
+			keyDesc.PubKey.SerializeCompressed())
+	}
+
+	return privKey, nil
+}
+
+// fetchScript returns the wallet script matching the passed output script, if
+// it's controlled by the wallet.
+func (w *Wallet) fetchScript(pkScript []byte) (*walletScript, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	script, ok := w.scripts[string(pkScript)]
+	return script, ok
+}
+
+// isOurs returns true if the passed output script is controlled by the wallet.
+func (w *Wallet) isOurs(pkScript []byte) bool {
+	_, ok := w.fetchScript(pkScript)
+	return ok
+}
+
+// DeriveNextKey derives the next key within the passed key family.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (w *Wallet) DeriveNextKey(
+	keyFam keychain.KeyFamily) (keychain.KeyDescriptor, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	keyLoc := keychain.KeyLocator{
+		Family: keyFam,
+		Index:  w.nextIndex[keyFam],
+	}
+	privKey, err := w.deriveKeyFromLocator(keyLoc)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+	w.nextIndex[keyFam]++
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     privKey.PubKey(),
+	}, nil
+}
+
+// DeriveKey derives the key at the passed location.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (w *Wallet) DeriveKey(
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	privKey, err := w.deriveKeyFromLocator(keyLoc)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     privKey.PubKey(),
+	}, nil
+}
+
+// DerivePrivKey returns the private key described by the passed key
+// descriptor.
+//
+// NOTE: This is part of the keychain.SecretKeyRing interface.
+func (w *Wallet) DerivePrivKey(
+	keyDesc keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+
+	return w.fetchPrivKey(&keyDesc)
+}
+
+// ScalarMult performs a scalar multiplication (ECDH-like operation) between
+// the private key described by the passed key descriptor and the passed public
+// key, returning the SHA-256 of the resulting shared point.
+//
+// NOTE: This is part of the keychain.SecretKeyRing interface.
+func (w *Wallet) ScalarMult(keyDesc keychain.KeyDescriptor,
+	pub *btcec.PublicKey) ([]byte, error) {
+
+	privKey, err := w.fetchPrivKey(&keyDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &btcec.PublicKey{}
+	s.X, s.Y = btcec.S256().ScalarMult(pub.X, pub.Y, privKey.D.Bytes())
+
+	h := sha256.Sum256(s.SerializeCompressed())
+	return h[:], nil
+}
+
+// maybeTweakPrivKey applies the single or double tweak of the passed sign
+// descriptor to the passed private key, if set.
+func maybeTweakPrivKey(signDesc *lnwallet.SignDescriptor,
+	privKey *btcec.PrivateKey) *btcec.PrivateKey {
+
+	switch {
+	case signDesc.SingleTweak != nil:
+		return lnwallet.TweakPrivKey(privKey, signDesc.SingleTweak)
+
+	case signDesc.DoubleTweak != nil:
+		return lnwallet.DeriveRevocationPrivKey(
+			privKey, signDesc.DoubleTweak,
+		)
+
+	default:
+		return privKey
+	}
+}
+
+// SignOutputRaw generates a signature for the passed transaction according to
+// the data within the passed SignDescriptor.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (w *Wallet) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	privKey, err := w.fetchPrivKey(&signDesc.KeyDesc)
+	if err != nil {
+		return nil, err
+	}
+	privKey = maybeTweakPrivKey(signDesc, privKey)
+
+	sig, err := txscript.RawTxInWitnessSignature(
+		tx, signDesc.SigHashes, signDesc.InputIndex,
+		signDesc.Output.Value, signDesc.WitnessScript,
+		signDesc.HashType, privKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chop off the sighash flag at the end of the signature.
+	return sig[:len(sig)-1], nil
+}
+
+// ComputeInputScript generates a complete InputScript for the passed
+// transaction, spending an output controlled by the wallet.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (w *Wallet) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	outputScript := signDesc.Output.PkScript
+	script, ok := w.fetchScript(outputScript)
+	if !ok {
+		return nil, lnwallet.ErrNotMine
+	}
+
+	pubKeyHash := btcutil.Hash160(script.privKey.PubKey().SerializeCompressed())
+	witnessProgram, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(pubKeyHash).
+		Script()
+	if err != nil {
+		return nil, err
+	}
+
+	inputScript := &lnwallet.InputScript{}
+
+	// Nested outputs must additionally push the witness program within
+	// the sigScript of the input.
+	if script.addrType == lnwallet.NestedWitnessPubKey {
+		sigScript, err := txscript.NewScriptBuilder().
+			AddData(witnessProgram).
+			Script()
+		if err != nil {
+			return nil, err
+		}
+		inputScript.ScriptSig = sigScript
+	}
+
+	privKey := maybeTweakPrivKey(signDesc, script.privKey)
+	witness, err := txscript.WitnessSignature(
+		tx, signDesc.SigHashes, signDesc.InputIndex,
+		signDesc.Output.Value, witnessProgram, signDesc.HashType,
+		privKey, true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	inputScript.Witness = witness
+
+	return inputScript, nil
+}
+
+// SignMessage signs the double SHA-256 of the passed message with the private
+// key corresponding to the passed public key.
+//
+// NOTE: This is part of the lnwallet.MessageSigner interface.
+func (w *Wallet) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	privKey, err := w.fetchPrivKey(&keychain.KeyDescriptor{
+		PubKey: pubKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return privKey.Sign(chainhash.DoubleHashB(msg))
+}
+
+// BackEnd returns the name of the wallet's backend.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) BackEnd() string {
+	return "simchain"
+}
+
+// FetchInputInfo returns the output at the passed outpoint if it's controlled
+// by the wallet, and lnwallet.ErrNotMine otherwise.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) FetchInputInfo(prevOut *wire.OutPoint) (*wire.TxOut, error) {
+	txOut, ok := w.chain.FetchOutput(prevOut)
+	if !ok || !w.isOurs(txOut.PkScript) {
+		return nil, lnwallet.ErrNotMine
+	}
+
+	return txOut, nil
+}
+
+// ConfirmedBalance returns the sum of all outputs of the wallet with at least
+// confs confirmations.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) ConfirmedBalance(confs int32) (btcutil.Amount, error) {
+	utxos, err := w.listUnspent(confs, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance btcutil.Amount
+	for _, utxo := range utxos {
+		balance += utxo.Value
+	}
+
+	return balance, nil
+}
+
+// NewAddress returns a new address of the passed type controlled by the
+// wallet.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) NewAddress(addrType lnwallet.AddressType,
+	change bool) (btcutil.Address, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var branch uint32
+	if change {
+		branch = 1
+	}
+
+	privKey, err := w.derivePrivKey(
+		hdkeychain.HardenedKeyStart+addressPurpose, branch,
+		w.nextAddrIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	w.nextAddrIndex++
+
+	params := w.chain.Params()
+	pubKeyHash := btcutil.Hash160(privKey.PubKey().SerializeCompressed())
+
+	var addr btcutil.Address
+	switch addrType {
+	case lnwallet.WitnessPubKey:
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(
+			pubKeyHash, params,
+		)
+
+	case lnwallet.NestedWitnessPubKey:
+		var witnessAddr btcutil.Address
+		witnessAddr, err = btcutil.NewAddressWitnessPubKeyHash(
+			pubKeyHash, params,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var witnessProgram []byte
+		witnessProgram, err = txscript.PayToAddrScript(witnessAddr)
+		if err != nil {
+			return nil, err
+		}
+		addr, err = btcutil.NewAddressScriptHash(witnessProgram, params)
+
+	default:
+		return nil, fmt.Errorf("unsupported address type: %v", addrType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	w.scripts[string(pkScript)] = &walletScript{
+		privKey:  privKey,
+		addrType: addrType,
+	}
+
+	return addr, nil
+}
+
+// GetPrivKey returns the private key backing the passed address.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) GetPrivKey(a btcutil.Address) (*btcec.PrivateKey, error) {
+	pkScript, err := txscript.PayToAddrScript(a)
+	if err != nil {
+		return nil, err
+	}
+
+	script, ok := w.fetchScript(pkScript)
+	if !ok {
+		return nil, fmt.Errorf("address %v not found", a)
+	}
+
+	return script.privKey, nil
+}
+
+// SendOutputs funds, signs and publishes a transaction creating the passed
+// outputs, paying the passed fee rate.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) SendOutputs(outputs []*wire.TxOut,
+	feeRate lnwallet.SatPerVByte) (*chainhash.Hash, error) {
+
+	utxos, err := w.listUnspent(1, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll spend the largest outputs first, to keep the transaction
+	// small.
+	sort.Slice(utxos, func(i, j int) bool {
+		return utxos[i].Value > utxos[j].Value
+	})
+
+	// The size of the outputs is estimated conservatively, as they may
+	// pay to arbitrary scripts.
+	var (
+		weightEstimate lnwallet.TxWeightEstimator
+		totalOut       btcutil.Amount
+	)
+	tx := wire.NewMsgTx(2)
+	for _, txOut := range outputs {
+		weightEstimate.AddP2WSHOutput()
+		totalOut += btcutil.Amount(txOut.Value)
+		tx.AddTxOut(txOut)
+	}
+	weightEstimate.AddP2WKHOutput()
+
+	var (
+		totalIn btcutil.Amount
+		fee     btcutil.Amount
+		inputs  []*lnwallet.Utxo
+	)
+	for _, utxo := range utxos {
+		switch utxo.AddressType {
+		case lnwallet.WitnessPubKey:
+			weightEstimate.AddP2WKHInput()
+		case lnwallet.NestedWitnessPubKey:
+			weightEstimate.AddNestedP2WKHInput()
+		}
+
+		totalIn += utxo.Value
+		inputs = append(inputs, utxo)
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: utxo.OutPoint,
+		})
+
+		fee = feeRate.FeeForVSize(int64(weightEstimate.VSize()))
+		if totalIn >= totalOut+fee {
+			break
+		}
+	}
+	if totalIn < totalOut+fee {
+		return nil, fmt.Errorf("insufficient funds: have %v, need %v",
+			totalIn, totalOut+fee)
+	}
+
+	if change := totalIn - totalOut - fee; change > lnwallet.DefaultDustLimit() {
+		changeAddr, err := w.NewAddress(lnwallet.WitnessPubKey, true)
+		if err != nil {
+			return nil, err
+		}
+		changeScript, err := txscript.PayToAddrScript(changeAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.AddTxOut(&wire.TxOut{
+			Value:    int64(change),
+			PkScript: changeScript,
+		})
+	}
+
+	signDesc := lnwallet.SignDescriptor{
+		HashType:  txscript.SigHashAll,
+		SigHashes: txscript.NewTxSigHashes(tx),
+	}
+	for i, utxo := range inputs {
+		signDesc.Output = &wire.TxOut{
+			Value:    int64(utxo.Value),
+			PkScript: utxo.PkScript,
+		}
+		signDesc.InputIndex = i
+
+		inputScript, err := w.ComputeInputScript(tx, &signDesc)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.TxIn[i].SignatureScript = inputScript.ScriptSig
+		tx.TxIn[i].Witness = inputScript.Witness
+	}
+
+	if err := w.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+// listUnspent returns the unspent outputs of the wallet with at least
+// minConfs confirmations, optionally excluding locked outputs.
+func (w *Wallet) listUnspent(minConfs int32,
+	skipLocked bool) ([]*lnwallet.Utxo, error) {
+
+	_, bestHeight, err := w.chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var utxos []*lnwallet.Utxo
+	for _, output := range w.chain.UnspentOutputs(w.isOurs) {
+		var confs int32
+		if output.Height != mempoolHeight {
+			confs = bestHeight - output.Height + 1
+		}
+		if confs < minConfs {
+			continue
+		}
+
+		script, _ := w.fetchScript(output.PkScript)
+
+		w.mu.Lock()
+		_, locked := w.lockedOutpoints[output.OutPoint]
+		w.mu.Unlock()
+		if skipLocked && locked {
+			continue
+		}
+
+		utxos = append(utxos, &lnwallet.Utxo{
+			AddressType: script.addrType,
+			Value:       btcutil.Amount(output.Value),
+			PkScript:    output.PkScript,
+			OutPoint:    output.OutPoint,
+		})
+	}
+
+	return utxos, nil
+}
+
+// ListUnspentWitness returns the unlocked witness outputs of the wallet with
+// at least minConfs confirmations.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error) {
+	return w.listUnspent(minConfs, true)
+}
+
+// txDetail returns the details of the passed transaction from the point of
+// view of the wallet, or nil if the transaction isn't relevant to it.
+func (w *Wallet) txDetail(tx *ChainTx, bestHeight int32) *lnwallet.TransactionDetail {
+	var (
+		relevant      bool
+		allInputs     = true
+		totalIn       btcutil.Amount
+		totalOut      btcutil.Amount
+		balanceDelta  btcutil.Amount
+		destAddresses []btcutil.Address
+	)
+	for _, txIn := range tx.Tx.TxIn {
+		prevOut, ok := w.chain.FetchOutput(&txIn.PreviousOutPoint)
+		if !ok {
+			allInputs = false
+			continue
+		}
+
+		totalIn += btcutil.Amount(prevOut.Value)
+		if w.isOurs(prevOut.PkScript) {
+			relevant = true
+			balanceDelta -= btcutil.Amount(prevOut.Value)
+		}
+	}
+	for _, txOut := range tx.Tx.TxOut {
+		totalOut += btcutil.Amount(txOut.Value)
+		if w.isOurs(txOut.PkScript) {
+			relevant = true
+			balanceDelta += btcutil.Amount(txOut.Value)
+		}
+
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			txOut.PkScript, w.chain.Params(),
+		)
+		if err == nil {
+			destAddresses = append(destAddresses, addrs...)
+		}
+	}
+	if !relevant {
+		return nil
+	}
+
+	detail := &lnwallet.TransactionDetail{
+		Hash:          tx.Tx.TxHash(),
+		Value:         balanceDelta,
+		BlockHash:     tx.BlockHash,
+		BlockHeight:   tx.Height,
+		Timestamp:     tx.Timestamp.Unix(),
+		DestAddresses: destAddresses,
+		RawTx:         tx.Tx,
+	}
+	if tx.Height != mempoolHeight {
+		detail.NumConfirmations = bestHeight - tx.Height + 1
+	} else {
+		detail.BlockHeight = 0
+	}
+	if allInputs {
+		detail.TotalFees = int64(totalIn - totalOut)
+	}
+
+	return detail
+}
+
+// ListTransactionDetails returns the details of every transaction within the
+// main chain or the mempool relevant to the wallet.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) ListTransactionDetails() ([]*lnwallet.TransactionDetail, error) {
+	txns, bestHeight := w.chain.Transactions()
+
+	var details []*lnwallet.TransactionDetail
+	for _, tx := range txns {
+		if detail := w.txDetail(tx, bestHeight); detail != nil {
+			details = append(details, detail)
+		}
+	}
+
+	return details, nil
+}
+
+// LockOutpoint marks the passed outpoint as locked, excluding it from coin
+// selection.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) LockOutpoint(o wire.OutPoint) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lockedOutpoints[o] = struct{}{}
+}
+
+// UnlockOutpoint unlocks an outpoint previously locked with LockOutpoint.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) UnlockOutpoint(o wire.OutPoint) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.lockedOutpoints, o)
+}
+
+// PublishTransaction publishes the passed transaction to the chain.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) PublishTransaction(tx *wire.MsgTx) error {
+	return w.chain.PublishTransaction(tx)
+}
+
+// IsSynced always returns true, as the wallet reads its state directly from
+// the chain. The timestamp of the tip of the chain is returned along with it.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) IsSynced() (bool, int64, error) {
+	bestHash, _, err := w.chain.GetBestBlock()
+	if err != nil {
+		return false, 0, err
+	}
+	block, err := w.chain.GetBlock(bestHash)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return true, block.Header.Timestamp.Unix(), nil
+}
+
+// Start is a no-op, as the wallet reads its state directly from the chain.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) Start() error {
+	return nil
+}
+
+// Stop is a no-op, as the wallet reads its state directly from the chain.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) Stop() error {
+	return nil
+}
+
+// txSubscription delivers the transactions relevant to a wallet as they're
+// accepted into the mempool and confirmed.
+type txSubscription struct {
+	wallet *Wallet
+	sub    *Subscription
+
+	confirmed   chan *lnwallet.TransactionDetail
+	unconfirmed chan *lnwallet.TransactionDetail
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// SubscribeTransactions returns a subscription which delivers the details of
+// every transaction relevant to the wallet once it's accepted into the
+// mempool, and again once it's confirmed.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *Wallet) SubscribeTransactions() (lnwallet.TransactionSubscription,
+	error) {
+
+	sub, _, _ := w.chain.Subscribe()
+	txSub := &txSubscription{
+		wallet:      w,
+		sub:         sub,
+		confirmed:   make(chan *lnwallet.TransactionDetail),
+		unconfirmed: make(chan *lnwallet.TransactionDetail),
+		quit:        make(chan struct{}),
+	}
+
+	txSub.wg.Add(1)
+	go txSub.notificationDispatcher()
+
+	return txSub, nil
+}
+
+// notificationDispatcher processes the updates of the chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (t *txSubscription) notificationDispatcher() {
+	defer t.wg.Done()
+
+	for {
+		var update *Update
+		select {
+		case update = <-t.sub.Updates():
+		case <-t.quit:
+			return
+		}
+
+		switch update.Type {
+		case TxAccepted:
+			detail := t.wallet.txDetail(&ChainTx{
+				Tx:        update.Tx,
+				Height:    mempoolHeight,
+				Timestamp: t.wallet.chain.Clock().Now(),
+			}, 0)
+			if detail == nil {
+				continue
+			}
+
+			select {
+			case t.unconfirmed <- detail:
+			case <-t.quit:
+				return
+			}
+
+		case BlockConnected:
+			blockHash := update.Block.BlockHash()
+			for _, tx := range update.Block.Transactions[1:] {
+				detail := t.wallet.txDetail(&ChainTx{
+					Tx:        tx,
+					BlockHash: &blockHash,
+					Height:    update.Height,
+					Timestamp: update.Block.Header.Timestamp,
+				}, update.Height)
+				if detail == nil {
+					continue
+				}
+
+				select {
+				case t.confirmed <- detail:
+				case <-t.quit:
+					return
+				}
+			}
+		}
+	}
+}
+
+// ConfirmedTransactions returns the channel over which confirmed transactions
+// are delivered.
+//
+// NOTE: This is part of the lnwallet.TransactionSubscription interface.
+func (t *txSubscription) ConfirmedTransactions() chan *lnwallet.TransactionDetail {
+	return t.confirmed
+}
+
+// UnconfirmedTransactions returns the channel over which transactions accepted
+// into the mempool are delivered.
+//
+// NOTE: This is part of the lnwallet.TransactionSubscription interface.
+func (t *txSubscription) UnconfirmedTransactions() chan *lnwallet.TransactionDetail {
+	return t.unconfirmed
+}
+
+// Cancel stops the delivery of any further transactions.
+//
+// NOTE: This is part of the lnwallet.TransactionSubscription interface.
+func (t *txSubscription) Cancel() {
+	t.sub.Cancel()
+	close(t.quit)
+	t.wg.Wait()
+}
@@ -0,0 +1,388 @@
+package lnwallet
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+// addBatchContributionMsg carries the remote party's contribution for each
+// reservation within a batch of single funder channels we initiated. Once
+// processed, a single funding transaction creating the funding output of
+// every channel in the batch will have been constructed.
+type addBatchContributionMsg struct {
+	reservations  []*ChannelReservation
+	contributions []*ChannelContribution
+
+	// feeRate is the fee rate the batch funding transaction should pay.
+	feeRate SatPerVByte
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	err chan error
+}
+
+// addBatchSigsMsg carries the remote party's signature for our version of the
+// commitment transaction of each reservation within a batch. Once all the
+// signatures have been verified, the batch funding transaction is broadcast.
+type addBatchSigsMsg struct {
+	reservations []*ChannelReservation
+	commitSigs   [][]byte
+
+	// This channel is used to return the completed channels after the
+	// funding transaction has been broadcast.
+	completeChan chan []*channeldb.OpenChannel
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	err chan error
+}
+
+// ProcessBatchContributions is the batch equivalent of .ProcessContribution()
+// for a set of single funder reservations we initiated. Rather than funding
+// each channel within its own transaction, a single funding transaction is
+// constructed which spends the inputs selected for every reservation, creates
+// the funding output of each channel, and pays any remaining funds back to the
+// wallet within a single change output. Once this method returns, our
+// signature for each remote party's version of the commitment transaction is
+// available via .OurSignatures() of the respective reservation.
+//
+// NOTE: Reservations that are funded as part of a batch MUST be completed
+// using .CompleteBatch() rather than .CompleteReservation().
+func (l *LightningWallet) ProcessBatchContributions(
+	reservations []*ChannelReservation,
+	contributions []*ChannelContribution, feeRate SatPerVByte) error {
+
+	errChan := make(chan error, 1)
+
+	l.msgChan <- &addBatchContributionMsg{
+		reservations:  reservations,
+		contributions: contributions,
+		feeRate:       feeRate,
+		err:           errChan,
+	}
+
+	return <-errChan
+}
+
+// CompleteBatch finalizes a batch of reservations previously processed using
+// .ProcessBatchContributions(). The remote party's signature for our version
+// of the commitment transaction of every channel is verified before any of the
+// channels are written to disk, and the shared funding transaction is only
+// broadcast once all of them have been, within a single database transaction.
+// If an error is returned, no channel is persisted and the caller should
+// cancel all reservations within the batch. A failure to broadcast the funding
+// transaction isn't returned, as it's rebroadcast upon restart.
+func (l *LightningWallet) CompleteBatch(reservations []*ChannelReservation,
+	commitSigs [][]byte) ([]*channeldb.OpenChannel, error) {
+
+	errChan := make(chan error, 1)
+	completeChan := make(chan []*channeldb.OpenChannel, 1)
+
+	l.msgChan <- &addBatchSigsMsg{
+		reservations: reservations,
+		commitSigs:   commitSigs,
+		completeChan: completeChan,
+		err:          errChan,
+	}
+
+	return <-completeChan, <-errChan
+}
+
+// batchChange returns the value of the change output of a batch funding
+// transaction spending totalIn and creating funding outputs worth totalOut.
+// The passed weight estimate should account for all inputs and funding
+// outputs, the change output is accounted for within this function. A zero
+// value is returned if the change would be dust, leaving the remainder to the
+// miners.
+func batchChange(feeRate SatPerVByte, weightEstimate TxWeightEstimator,
+	totalIn, totalOut btcutil.Amount) (btcutil.Amount, error) {
+
+	// Assume that change output is a P2WKH output.
+	weightEstimate.AddP2WKHOutput()
+
+	fee := feeRate.FeeForVSize(int64(weightEstimate.VSize()))
+	if totalIn < totalOut+fee {
+		return 0, fmt.Errorf("batch inputs of %v can't pay for "+
+			"funding outputs of %v with fee of %v", totalIn,
+			totalOut, fee)
+	}
+
+	changeAmt := totalIn - totalOut - fee
+	if changeAmt <= DefaultDustLimit() {
+		return 0, nil
+	}
+
+	return changeAmt, nil
+}
+
+// handleBatchContribution constructs the shared funding transaction of a
+// batch of reservations, then creates and signs the commitment transactions of
+// each channel within the batch.
+func (l *LightningWallet) handleBatchContribution(req *addBatchContributionMsg) {
+	if len(req.reservations) == 0 {
+		req.err <- fmt.Errorf("batch contains no reservations")
+		return
+	}
+	if len(req.reservations) != len(req.contributions) {
+		req.err <- fmt.Errorf("got %v contributions for batch of %v "+
+			"reservations", len(req.contributions),
+			len(req.reservations))
+		return
+	}
+
+	l.limboMtx.RLock()
+	for _, res := range req.reservations {
+		if _, ok := l.fundingLimbo[res.reservationID]; !ok {
+			l.limboMtx.RUnlock()
+			req.err <- fmt.Errorf("attempted to update " +
+				"non-existent funding state")
+			return
+		}
+	}
+	l.limboMtx.RUnlock()
+
+	// Grab the mutex on all ChannelReservations to ensure thread-safety.
+	for _, res := range req.reservations {
+		res.Lock()
+		defer res.Unlock()
+	}
+
+	var (
+		fundingTx      = wire.NewMsgTx(1)
+		weightEstimate TxWeightEstimator
+		totalIn        btcutil.Amount
+		totalOut       btcutil.Amount
+		multiSigOuts   = make([]*wire.TxOut, len(req.reservations))
+		witnessScripts = make([][]byte, len(req.reservations))
+	)
+
+	// Add the inputs selected for each reservation along with its 2-of-2
+	// multi-sig output to the transaction. The change outputs generated
+	// during coin selection of each reservation are replaced by a single
+	// change output below.
+	for i, res := range req.reservations {
+		res.theirContribution = req.contributions[i]
+
+		for _, txIn := range res.ourContribution.Inputs {
+			info, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+			if err != nil {
+				req.err <- err
+				return
+			}
+
+			switch {
+			case txscript.IsPayToWitnessPubKeyHash(info.PkScript):
+				weightEstimate.AddP2WKHInput()
+			case txscript.IsPayToScriptHash(info.PkScript):
+				weightEstimate.AddNestedP2WKHInput()
+			default:
+				req.err <- fmt.Errorf("unsupported input "+
+					"script: %x", info.PkScript)
+				return
+			}
+
+			totalIn += btcutil.Amount(info.Value)
+			fundingTx.AddTxIn(txIn)
+		}
+
+		ourKey := res.ourContribution.MultiSigKey
+		theirKey := res.theirContribution.MultiSigKey
+		witnessScript, multiSigOut, err := GenFundingPkScript(
+			ourKey.PubKey.SerializeCompressed(),
+			theirKey.PubKey.SerializeCompressed(),
+			int64(res.partialState.Capacity),
+		)
+		if err != nil {
+			req.err <- err
+			return
+		}
+
+		weightEstimate.AddP2WSHOutput()
+		totalOut += btcutil.Amount(multiSigOut.Value)
+		fundingTx.AddTxOut(multiSigOut)
+
+		multiSigOuts[i] = multiSigOut
+		witnessScripts[i] = witnessScript
+	}
+
+	changeAmt, err := batchChange(
+		req.feeRate, weightEstimate, totalIn, totalOut,
+	)
+	if err != nil {
+		req.err <- err
+		return
+	}
+	if changeAmt != 0 {
+		changeAddr, err := l.NewAddress(WitnessPubKey, true)
+		if err != nil {
+			req.err <- err
+			return
+		}
+		changeScript, err := txscript.PayToAddrScript(changeAddr)
+		if err != nil {
+			req.err <- err
+			return
+		}
+
+		fundingTx.AddTxOut(&wire.TxOut{
+			Value:    int64(changeAmt),
+			PkScript: changeScript,
+		})
+	}
+
+	txsort.InPlaceSort(fundingTx)
+
+	// As every input of the funding transaction belongs to us, we can now
+	// sign all of them.
+	signDesc := SignDescriptor{
+		HashType:  txscript.SigHashAll,
+		SigHashes: txscript.NewTxSigHashes(fundingTx),
+	}
+	for i, txIn := range fundingTx.TxIn {
+		info, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			req.err <- err
+			return
+		}
+
+		signDesc.Output = info
+		signDesc.InputIndex = i
+
+		inputScript, err := l.Cfg.Signer.ComputeInputScript(
+			fundingTx, &signDesc,
+		)
+		if err != nil {
+			req.err <- err
+			return
+		}
+
+		txIn.SignatureScript = inputScript.ScriptSig
+		txIn.Witness = inputScript.Witness
+	}
+
+	walletLog.Debugf("Batch funding tx for %v channels generated: %v",
+		len(req.reservations), newLogClosure(func() string {
+			return spew.Sdump(fundingTx)
+		}),
+	)
+
+	// With the funding transaction complete, we can create the commitment
+	// transactions of each channel.
+	for i, res := range req.reservations {
+		res.fundingTx = fundingTx
+
+		err := l.initCommitments(
+			res, fundingTx, multiSigOuts[i], witnessScripts[i],
+		)
+		if err != nil {
+			req.err <- err
+			return
+		}
+	}
+
+	req.err <- nil
+}
+
+// handleBatchSigs verifies the remote party's commitment signature of every
+// channel within a batch, atomically writes all channels to disk, then
+// broadcasts the shared funding transaction.
+func (l *LightningWallet) handleBatchSigs(req *addBatchSigsMsg) {
+	if len(req.reservations) == 0 {
+		req.err <- fmt.Errorf("batch contains no reservations")
+		req.completeChan <- nil
+		return
+	}
+	if len(req.reservations) != len(req.commitSigs) {
+		req.err <- fmt.Errorf("got %v commitment signatures for "+
+			"batch of %v reservations", len(req.commitSigs),
+			len(req.reservations))
+		req.completeChan <- nil
+		return
+	}
+
+	// Grab the mutex on all ChannelReservations to ensure thread-safety.
+	for _, res := range req.reservations {
+		res.Lock()
+		defer res.Unlock()
+	}
+
+	// Before we write any of the channels to disk, we'll ensure we hold a
+	// valid commitment transaction for each of them.
+	for i, res := range req.reservations {
+		if err := res.verifyCommitSig(req.commitSigs[i]); err != nil {
+			req.err <- err
+			req.completeChan <- nil
+			return
+		}
+
+		res.theirCommitmentSig = req.commitSigs[i]
+		res.partialState.LocalCommitment.CommitSig = req.commitSigs[i]
+	}
+
+	_, bestHeight, err := l.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		req.err <- err
+		req.completeChan <- nil
+		return
+	}
+
+	// All channels are written to disk within a single transaction, as
+	// the batch can only be broadcast if every channel within it was
+	// persisted.
+	var (
+		channels = make([]*channeldb.OpenChannel, 0, len(req.reservations))
+		addrs    = make([]net.Addr, 0, len(req.reservations))
+	)
+	for _, res := range req.reservations {
+		res.partialState.LocalChanCfg = res.ourContribution.toChanConfig()
+		res.partialState.RemoteChanCfg = res.theirContribution.toChanConfig()
+		res.partialState.FundingTxn = res.fundingTx
+
+		channels = append(channels, res.partialState)
+		addrs = append(addrs, res.nodeAddr)
+	}
+
+	err = l.Cfg.Database.SyncPendingChannels(
+		channels, addrs, uint32(bestHeight),
+	)
+	if err != nil {
+		req.err <- err
+		req.completeChan <- nil
+		return
+	}
+
+	// Funding of the batch is complete, so these entries can be removed
+	// from limbo.
+	l.limboMtx.Lock()
+	for _, res := range req.reservations {
+		delete(l.fundingLimbo, res.reservationID)
+	}
+	l.limboMtx.Unlock()
+
+	fundingTx := req.reservations[0].fundingTx
+
+	walletLog.Infof("Broadcasting batch funding tx %v for %v channels",
+		fundingTx.TxHash(), len(channels))
+	walletLog.Debugf("Batch funding tx %v: %v", fundingTx.TxHash(),
+		newLogClosure(func() string {
+			return spew.Sdump(fundingTx)
+		}),
+	)
+
+	// As every channel has already been written to disk, a failure to
+	// broadcast doesn't fail the batch. The funding transaction is stored
+	// along with each channel, so it'll be rebroadcast upon restart.
+	err = l.PublishTransaction(fundingTx)
+	if err != nil && err != ErrDoubleSpend {
+		walletLog.Errorf("Unable to broadcast batch funding tx %v, "+
+			"will retry on restart: %v", fundingTx.TxHash(), err)
+	}
+
+	req.completeChan <- channels
+	req.err <- nil
+}
@@ -0,0 +1,77 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcutil"
+)
+
+// TestBatchChange asserts that the change output of a batch funding
+// transaction pays for the fee of the complete transaction, is dropped if it
+// would be dust, and that batches that can't pay for their outputs are
+// rejected.
+func TestBatchChange(t *testing.T) {
+	t.Parallel()
+
+	const feeRate = SatPerVByte(10)
+
+	// The batch spends two inputs and creates two funding outputs.
+	var weightEstimate TxWeightEstimator
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WSHOutput()
+	weightEstimate.AddP2WSHOutput()
+
+	// The fee is computed over the transaction including its change
+	// output.
+	withChange := weightEstimate
+	withChange.AddP2WKHOutput()
+	fee := feeRate.FeeForVSize(int64(withChange.VSize()))
+
+	const totalOut = btcutil.Amount(2000000)
+
+	tests := []struct {
+		name           string
+		totalIn        btcutil.Amount
+		expectedChange btcutil.Amount
+		expectErr      bool
+	}{
+		{
+			name:           "change above dust",
+			totalIn:        totalOut + fee + 100000,
+			expectedChange: 100000,
+		},
+		{
+			name:           "dust change dropped",
+			totalIn:        totalOut + fee + DefaultDustLimit(),
+			expectedChange: 0,
+		},
+		{
+			name:      "insufficient inputs",
+			totalIn:   totalOut + fee - 1,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		change, err := batchChange(
+			feeRate, weightEstimate, test.totalIn, totalOut,
+		)
+		switch {
+		case test.expectErr && err == nil:
+			t.Fatalf("%v: expected error", test.name)
+		case !test.expectErr && err != nil:
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		}
+
+		if change != test.expectedChange {
+			t.Fatalf("%v: expected change %v, got %v", test.name,
+				test.expectedChange, change)
+		}
+	}
+
+	// The estimate passed in must not be modified by the computation.
+	if weightEstimate.Weight() == withChange.Weight() {
+		t.Fatalf("weight estimate was modified")
+	}
+}
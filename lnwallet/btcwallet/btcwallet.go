@@ -500,6 +500,7 @@ func minedTransactionsToDetails(
 			Timestamp:        block.Timestamp,
 			TotalFees:        int64(tx.Fee),
 			DestAddresses:    destAddresses,
+			RawTx:            wireTx,
 		}
 
 		balanceDelta, err := extractBalanceDelta(tx, wireTx)
@@ -530,6 +531,7 @@ func unminedTransactionsToDetail(
 		Hash:      *summary.Hash,
 		TotalFees: int64(summary.Fee),
 		Timestamp: summary.Timestamp,
+		RawTx:     wireTx,
 	}
 
 	balanceDelta, err := extractBalanceDelta(summary, wireTx)
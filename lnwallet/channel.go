@@ -4169,9 +4169,10 @@ func (lc *LightningChannel) SetFwdFilter(height uint64,
 	return lc.channelState.SetFwdFilter(height, fwdFilter)
 }
 
-// RemoveFwdPkg permanently deletes the forwarding package at the given height.
-func (lc *LightningChannel) RemoveFwdPkg(height uint64) error {
-	return lc.channelState.RemoveFwdPkg(height)
+// RemoveFwdPkgs permanently deletes the forwarding packages at the given
+// heights.
+func (lc *LightningChannel) RemoveFwdPkgs(heights ...uint64) error {
+	return lc.channelState.RemoveFwdPkgs(heights...)
 }
 
 // NextRevocationKey returns the commitment point for the _next_ commitment
@@ -5370,6 +5371,117 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 	}, nil
 }
 
+// NewCommitOutputResolution returns the resolution of our commitment output
+// on the passed commitment transaction of the channel, which must be either
+// our own or the remote party's latest commitment transaction. Unlike the
+// force close summaries, no HTLCs are resolved, so this can be used to spend
+// our output before the commitment transaction confirms, e.g. in order to
+// bump its fee. If we have no output above dust on the commitment
+// transaction, nil is returned.
+func NewCommitOutputResolution(chanState *channeldb.OpenChannel,
+	commitTx *wire.MsgTx) (*CommitOutputResolution, error) {
+
+	var (
+		localCommit = chanState.LocalCommitment
+		isOurCommit bool
+		commitPoint *btcec.PublicKey
+		balance     btcutil.Amount
+	)
+	commitHash := commitTx.TxHash()
+	switch {
+	case localCommit.CommitTx != nil &&
+		localCommit.CommitTx.TxHash() == commitHash:
+
+		revocation, err := chanState.RevocationProducer.AtIndex(
+			localCommit.CommitHeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		isOurCommit = true
+		commitPoint = ComputeCommitmentPoint(revocation[:])
+		balance = localCommit.LocalBalance.ToSatoshis()
+
+	case chanState.RemoteCommitment.CommitTx != nil &&
+		chanState.RemoteCommitment.CommitTx.TxHash() == commitHash:
+
+		commitPoint = chanState.RemoteCurrentRevocation
+		balance = chanState.RemoteCommitment.LocalBalance.ToSatoshis()
+
+	default:
+		return nil, fmt.Errorf("transaction %v isn't the latest "+
+			"commitment of ChannelPoint(%v)", commitHash,
+			chanState.FundingOutpoint)
+	}
+
+	keyRing := deriveCommitmentKeys(
+		commitPoint, isOurCommit, &chanState.LocalChanCfg,
+		&chanState.RemoteChanCfg,
+	)
+
+	// On our own commitment transaction, our output is delayed, while on
+	// the remote party's it can be spent immediately.
+	var (
+		keyDesc       = chanState.LocalChanCfg.PaymentBasePoint
+		witnessScript []byte
+		pkScript      []byte
+		maturityDelay uint32
+		err           error
+	)
+	if isOurCommit {
+		keyDesc = chanState.LocalChanCfg.DelayBasePoint
+		maturityDelay = uint32(chanState.LocalChanCfg.CsvDelay)
+
+		witnessScript, err = commitScriptToSelf(
+			maturityDelay, keyRing.DelayKey, keyRing.RevocationKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err = witnessScriptHash(witnessScript)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		witnessScript, err = commitScriptUnencumbered(
+			keyRing.NoDelayKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		pkScript = witnessScript
+	}
+
+	for i, txOut := range commitTx.TxOut {
+		if !bytes.Equal(txOut.PkScript, pkScript) {
+			continue
+		}
+
+		return &CommitOutputResolution{
+			SelfOutPoint: wire.OutPoint{
+				Hash:  commitHash,
+				Index: uint32(i),
+			},
+			SelfOutputSignDesc: SignDescriptor{
+				KeyDesc: keyDesc,
+				SingleTweak: SingleTweakBytes(
+					commitPoint, keyDesc.PubKey,
+				),
+				WitnessScript: witnessScript,
+				Output: &wire.TxOut{
+					PkScript: pkScript,
+					Value:    int64(balance),
+				},
+				HashType: txscript.SigHashAll,
+			},
+			MaturityDelay: maturityDelay,
+		}, nil
+	}
+
+	return nil, nil
+}
+
 // CreateCloseProposal is used by both parties in a cooperative channel close
 // workflow to generate proposed close transactions and signatures. This method
 // should only be executed once all pending HTLCs (if any) on the channel have
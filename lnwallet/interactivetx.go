@@ -0,0 +1,597 @@
+package lnwallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// MaxInteractiveTxInputs is the maximum number of inputs that may be
+	// added to a funding transaction by either party during the
+	// interactive transaction construction.
+	MaxInteractiveTxInputs = 252
+
+	// MaxInteractiveTxOutputs is the maximum number of outputs that may
+	// be added to a funding transaction by either party during the
+	// interactive transaction construction.
+	MaxInteractiveTxOutputs = 252
+)
+
+var (
+	// ErrNotOurTurn is returned when we attempt to send an interactive tx
+	// message while we're waiting for the remote party.
+	ErrNotOurTurn = errors.New("not our turn to send a message")
+
+	// ErrNotTheirTurn is returned when the remote party sends an
+	// interactive tx message while we're expected to send one ourselves.
+	ErrNotTheirTurn = errors.New("remote party sent message out of turn")
+
+	// ErrInteractiveTxComplete is returned when a message is sent or
+	// received after the construction of the transaction has completed.
+	ErrInteractiveTxComplete = errors.New("interactive tx construction " +
+		"already complete")
+)
+
+// InteractiveTxInput is an input that has been added to a transaction under
+// construction, along with the previous output it spends.
+type InteractiveTxInput struct {
+	// SerialID is the serial ID the input was added under.
+	SerialID uint64
+
+	// TxIn is the input itself.
+	TxIn *wire.TxIn
+
+	// PrevOut is the output spent by the input.
+	PrevOut *wire.TxOut
+
+	// Local is true if the input was added by us.
+	Local bool
+}
+
+// InteractiveTxOutput is an output that has been added to a transaction under
+// construction.
+type InteractiveTxOutput struct {
+	// SerialID is the serial ID the output was added under.
+	SerialID uint64
+
+	// TxOut is the output itself.
+	TxOut *wire.TxOut
+
+	// Local is true if the output was added by us.
+	Local bool
+}
+
+// InteractiveTxConfig houses the parameters of a single interactive
+// transaction construction.
+type InteractiveTxConfig struct {
+	// ChanID is the pending channel ID that all messages exchanged during
+	// the construction are bound to.
+	ChanID lnwire.ChannelID
+
+	// Initiator is true if we're the party that opened the channel. The
+	// initiator sends the first message, uses even serial IDs, and is the
+	// only party allowed to add the funding output.
+	Initiator bool
+
+	// FundingPkScript is the script of the channel's funding output.
+	FundingPkScript []byte
+
+	// FetchInput looks up the output spent by an input added by the
+	// remote party. An error should be returned if the output doesn't
+	// exist or has already been spent.
+	FetchInput func(*wire.OutPoint) (*wire.TxOut, error)
+}
+
+// InteractiveTxBuilder drives our side of the interactive construction of a
+// dual-funded channel's funding transaction. Both parties take turns sending
+// a single message each, either adding an input or output of their own, or
+// signalling that they have nothing further to add with a TxComplete. The
+// construction is finished once both parties have sent a TxComplete in
+// succession.
+//
+// NOTE: The builder isn't safe for concurrent use.
+type InteractiveTxBuilder struct {
+	cfg InteractiveTxConfig
+
+	// nextSerialID is the serial ID that will be assigned to the next
+	// input or output that we add.
+	nextSerialID uint64
+
+	// pending is the queue of messages we've yet to send.
+	pending []lnwire.Message
+
+	inputs   map[uint64]*InteractiveTxInput
+	outputs  map[uint64]*InteractiveTxOutput
+	spending map[wire.OutPoint]struct{}
+
+	numLocalInputs   int
+	numRemoteInputs  int
+	numLocalOutputs  int
+	numRemoteOutputs int
+
+	// ourTurn is true if the next message must be sent by us.
+	ourTurn bool
+
+	// localComplete and remoteComplete track whether the last message
+	// sent by each party was a TxComplete.
+	localComplete  bool
+	remoteComplete bool
+}
+
+// NewInteractiveTxBuilder creates a new builder for a single interactive
+// transaction construction.
+func NewInteractiveTxBuilder(cfg InteractiveTxConfig) *InteractiveTxBuilder {
+	nextSerialID := uint64(1)
+	if cfg.Initiator {
+		nextSerialID = 0
+	}
+
+	return &InteractiveTxBuilder{
+		cfg:          cfg,
+		nextSerialID: nextSerialID,
+		inputs:       make(map[uint64]*InteractiveTxInput),
+		outputs:      make(map[uint64]*InteractiveTxOutput),
+		spending:     make(map[wire.OutPoint]struct{}),
+		ourTurn:      cfg.Initiator,
+	}
+}
+
+// isRemoteSerialID returns true if the passed serial ID has the parity
+// assigned to the remote party.
+func (b *InteractiveTxBuilder) isRemoteSerialID(serialID uint64) bool {
+	isEven := serialID%2 == 0
+	return isEven != b.cfg.Initiator
+}
+
+// QueueInput queues one of our inputs, spending the passed output, to be
+// added to the transaction.
+func (b *InteractiveTxBuilder) QueueInput(txIn *wire.TxIn,
+	prevOut *wire.TxOut) {
+
+	b.pending = append(b.pending, &lnwire.TxAddInput{
+		ChanID:   b.cfg.ChanID,
+		SerialID: b.nextSerialID,
+		PrevOut:  txIn.PreviousOutPoint,
+		Sequence: txIn.Sequence,
+	})
+	b.inputs[b.nextSerialID] = &InteractiveTxInput{
+		SerialID: b.nextSerialID,
+		TxIn:     txIn,
+		PrevOut:  prevOut,
+		Local:    true,
+	}
+	b.nextSerialID += 2
+}
+
+// QueueOutput queues one of our outputs to be added to the transaction.
+func (b *InteractiveTxBuilder) QueueOutput(txOut *wire.TxOut) {
+	b.pending = append(b.pending, &lnwire.TxAddOutput{
+		ChanID:   b.cfg.ChanID,
+		SerialID: b.nextSerialID,
+		Amount:   btcutil.Amount(txOut.Value),
+		PkScript: txOut.PkScript,
+	})
+	b.outputs[b.nextSerialID] = &InteractiveTxOutput{
+		SerialID: b.nextSerialID,
+		TxOut:    txOut,
+		Local:    true,
+	}
+	b.nextSerialID += 2
+}
+
+// NextMessage returns the next message we should send to the remote party.
+// This is either the next queued addition, or a TxComplete if we have
+// nothing left to add.
+func (b *InteractiveTxBuilder) NextMessage() (lnwire.Message, error) {
+	switch {
+	case b.Complete():
+		return nil, ErrInteractiveTxComplete
+	case !b.ourTurn:
+		return nil, ErrNotOurTurn
+	}
+
+	b.ourTurn = false
+
+	if len(b.pending) == 0 {
+		b.localComplete = true
+		return &lnwire.TxComplete{ChanID: b.cfg.ChanID}, nil
+	}
+
+	msg := b.pending[0]
+	b.pending = b.pending[1:]
+
+	switch m := msg.(type) {
+	case *lnwire.TxAddInput:
+		if b.numLocalInputs >= MaxInteractiveTxInputs {
+			return nil, fmt.Errorf("too many local inputs")
+		}
+		b.spending[m.PrevOut] = struct{}{}
+		b.numLocalInputs++
+
+	case *lnwire.TxAddOutput:
+		if b.numLocalOutputs >= MaxInteractiveTxOutputs {
+			return nil, fmt.Errorf("too many local outputs")
+		}
+		b.numLocalOutputs++
+	}
+
+	// Adding anything to the transaction restarts the search for two
+	// consecutive TxComplete messages.
+	b.localComplete = false
+	b.remoteComplete = false
+
+	return msg, nil
+}
+
+// ReceiveMessage processes a message sent by the remote party. An error is
+// returned if the message violates the protocol, in which case the
+// construction should be aborted.
+func (b *InteractiveTxBuilder) ReceiveMessage(msg lnwire.Message) error {
+	switch {
+	case b.Complete():
+		return ErrInteractiveTxComplete
+	case b.ourTurn:
+		return ErrNotTheirTurn
+	}
+
+	switch m := msg.(type) {
+	case *lnwire.TxAddInput:
+		if err := b.receiveInput(m); err != nil {
+			return err
+		}
+
+	case *lnwire.TxAddOutput:
+		if err := b.receiveOutput(m); err != nil {
+			return err
+		}
+
+	case *lnwire.TxComplete:
+		b.remoteComplete = true
+		b.ourTurn = true
+		return nil
+
+	default:
+		return fmt.Errorf("unexpected interactive tx message: %T", msg)
+	}
+
+	b.localComplete = false
+	b.remoteComplete = false
+	b.ourTurn = true
+
+	return nil
+}
+
+// receiveInput validates and records an input added by the remote party.
+func (b *InteractiveTxBuilder) receiveInput(msg *lnwire.TxAddInput) error {
+	if !b.isRemoteSerialID(msg.SerialID) {
+		return fmt.Errorf("input serial_id=%v has wrong parity",
+			msg.SerialID)
+	}
+	if _, ok := b.inputs[msg.SerialID]; ok {
+		return fmt.Errorf("duplicate input serial_id=%v", msg.SerialID)
+	}
+	if _, ok := b.spending[msg.PrevOut]; ok {
+		return fmt.Errorf("outpoint %v already spent by funding "+
+			"transaction", msg.PrevOut)
+	}
+	if b.numRemoteInputs >= MaxInteractiveTxInputs {
+		return fmt.Errorf("too many remote inputs")
+	}
+
+	prevOut, err := b.cfg.FetchInput(&msg.PrevOut)
+	if err != nil {
+		return fmt.Errorf("unable to fetch input %v: %v", msg.PrevOut,
+			err)
+	}
+	if prevOut == nil {
+		return fmt.Errorf("input %v does not exist", msg.PrevOut)
+	}
+
+	// We only accept inputs that spend native P2WKH outputs, as we need to
+	// be able to estimate the weight of their witnesses. The redeem script
+	// of a P2SH output is only revealed once it's signed for, well after
+	// we've released our own signatures, so we're unable to verify that
+	// it actually is a nested P2WKH output.
+	if !txscript.IsPayToWitnessPubKeyHash(prevOut.PkScript) {
+		return fmt.Errorf("input %v spends unsupported script type",
+			msg.PrevOut)
+	}
+
+	b.inputs[msg.SerialID] = &InteractiveTxInput{
+		SerialID: msg.SerialID,
+		TxIn: &wire.TxIn{
+			PreviousOutPoint: msg.PrevOut,
+			Sequence:         msg.Sequence,
+		},
+		PrevOut: prevOut,
+	}
+	b.spending[msg.PrevOut] = struct{}{}
+	b.numRemoteInputs++
+
+	return nil
+}
+
+// receiveOutput validates and records an output added by the remote party.
+func (b *InteractiveTxBuilder) receiveOutput(msg *lnwire.TxAddOutput) error {
+	if !b.isRemoteSerialID(msg.SerialID) {
+		return fmt.Errorf("output serial_id=%v has wrong parity",
+			msg.SerialID)
+	}
+	if _, ok := b.outputs[msg.SerialID]; ok {
+		return fmt.Errorf("duplicate output serial_id=%v", msg.SerialID)
+	}
+	if b.numRemoteOutputs >= MaxInteractiveTxOutputs {
+		return fmt.Errorf("too many remote outputs")
+	}
+	if msg.Amount <= DefaultDustLimit() {
+		return fmt.Errorf("output serial_id=%v is dust", msg.SerialID)
+	}
+
+	switch txscript.GetScriptClass(msg.PkScript) {
+	case txscript.WitnessV0PubKeyHashTy, txscript.WitnessV0ScriptHashTy,
+		txscript.ScriptHashTy, txscript.PubKeyHashTy:
+	default:
+		return fmt.Errorf("output serial_id=%v has non-standard "+
+			"script", msg.SerialID)
+	}
+
+	// Only the initiator may add the funding output, as they're the one
+	// paying the fees of the transaction.
+	isFunding := bytes.Equal(msg.PkScript, b.cfg.FundingPkScript)
+	if isFunding && b.cfg.Initiator {
+		return fmt.Errorf("responder attempted to add funding output")
+	}
+
+	b.outputs[msg.SerialID] = &InteractiveTxOutput{
+		SerialID: msg.SerialID,
+		TxOut:    wire.NewTxOut(int64(msg.Amount), msg.PkScript),
+	}
+	b.numRemoteOutputs++
+
+	return nil
+}
+
+// Initiator returns true if we're the initiator of the construction.
+func (b *InteractiveTxBuilder) Initiator() bool {
+	return b.cfg.Initiator
+}
+
+// FundingPkScript returns the script of the channel's funding output.
+func (b *InteractiveTxBuilder) FundingPkScript() []byte {
+	return b.cfg.FundingPkScript
+}
+
+// Complete returns true once both parties have sent a TxComplete in
+// succession, finalizing the set of inputs and outputs.
+func (b *InteractiveTxBuilder) Complete() bool {
+	return b.localComplete && b.remoteComplete
+}
+
+// HasFundingOutput returns true if the funding output has been added to the
+// transaction.
+func (b *InteractiveTxBuilder) HasFundingOutput() bool {
+	for _, output := range b.outputs {
+		if bytes.Equal(output.TxOut.PkScript, b.cfg.FundingPkScript) {
+			return true
+		}
+	}
+
+	// The funding output may also still be sitting within our queue.
+	for _, msg := range b.pending {
+		m, ok := msg.(*lnwire.TxAddOutput)
+		if ok && bytes.Equal(m.PkScript, b.cfg.FundingPkScript) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoteAmount returns the total value of the inputs added by the remote
+// party so far, minus the total value of its outputs. An error is returned
+// if the remote party's outputs exceed its inputs.
+func (b *InteractiveTxBuilder) RemoteAmount() (btcutil.Amount, error) {
+	var remoteIn, remoteOut btcutil.Amount
+	for _, input := range b.inputs {
+		if !input.Local {
+			remoteIn += btcutil.Amount(input.PrevOut.Value)
+		}
+	}
+	for _, output := range b.outputs {
+		if !output.Local {
+			remoteOut += btcutil.Amount(output.TxOut.Value)
+		}
+	}
+
+	if remoteIn < remoteOut {
+		return 0, fmt.Errorf("remote outputs (%v) exceed remote "+
+			"inputs (%v)", remoteOut, remoteIn)
+	}
+
+	return remoteIn - remoteOut, nil
+}
+
+// RemoteWeight estimates the weight added to the transaction by the inputs
+// and outputs of the remote party. As the initiator pays the entire fee of
+// the funding transaction, it'll use this to increase its fee accordingly.
+// The estimate includes the base size of a transaction, so it errs on the
+// side of overpaying.
+func (b *InteractiveTxBuilder) RemoteWeight() int {
+	var weightEstimate TxWeightEstimator
+	for _, input := range b.inputs {
+		if input.Local {
+			continue
+		}
+
+		weightEstimate.AddP2WKHInput()
+	}
+	for _, output := range b.outputs {
+		if output.Local {
+			continue
+		}
+
+		switch txscript.GetScriptClass(output.TxOut.PkScript) {
+		case txscript.WitnessV0PubKeyHashTy:
+			weightEstimate.AddP2WKHOutput()
+		case txscript.WitnessV0ScriptHashTy:
+			weightEstimate.AddP2WSHOutput()
+		case txscript.ScriptHashTy:
+			weightEstimate.AddP2SHOutput()
+		default:
+			weightEstimate.AddP2PKHOutput()
+		}
+	}
+
+	return weightEstimate.Weight()
+}
+
+// InteractiveTxResult is the outcome of a completed interactive transaction
+// construction.
+type InteractiveTxResult struct {
+	// Capacity is the value of the funding output.
+	Capacity btcutil.Amount
+
+	// LocalInputs and LocalChange are the inputs and non-funding outputs
+	// added by us.
+	LocalInputs []*wire.TxIn
+	LocalChange []*wire.TxOut
+
+	// RemoteInputs and RemoteChange are the inputs and non-funding
+	// outputs added by the remote party.
+	RemoteInputs []*wire.TxIn
+	RemoteChange []*wire.TxOut
+
+	// LocalAmount and RemoteAmount are the amounts each party contributed
+	// to the funding output. As the initiator pays the entire fee of the
+	// funding transaction, the responder's amount is the total value of
+	// its inputs minus the total value of its change, while the
+	// initiator's amount is the remainder of the funding output.
+	LocalAmount  btcutil.Amount
+	RemoteAmount btcutil.Amount
+
+	// remoteInputs indexes the remote inputs by outpoint, so their input
+	// scripts can be reconstructed from their witnesses.
+	remoteInputs map[wire.OutPoint]*InteractiveTxInput
+}
+
+// Result returns the final inputs and outputs of the transaction split by
+// the party that added them, along with the amount each party contributed to
+// the funding output. An error is returned if the construction hasn't
+// completed yet or the resulting transaction is invalid.
+func (b *InteractiveTxBuilder) Result() (*InteractiveTxResult, error) {
+	if !b.Complete() {
+		return nil, fmt.Errorf("interactive tx construction not " +
+			"complete")
+	}
+
+	res := &InteractiveTxResult{
+		remoteInputs: make(map[wire.OutPoint]*InteractiveTxInput),
+	}
+
+	var (
+		numFundingOutputs int
+		totalIn           btcutil.Amount
+		totalOut          btcutil.Amount
+		responderIn       btcutil.Amount
+		responderOut      btcutil.Amount
+	)
+	for _, input := range b.inputs {
+		value := btcutil.Amount(input.PrevOut.Value)
+		totalIn += value
+
+		if input.Local {
+			res.LocalInputs = append(res.LocalInputs, input.TxIn)
+		} else {
+			res.RemoteInputs = append(res.RemoteInputs, input.TxIn)
+			res.remoteInputs[input.TxIn.PreviousOutPoint] = input
+		}
+
+		if input.Local != b.cfg.Initiator {
+			responderIn += value
+		}
+	}
+	for _, output := range b.outputs {
+		value := btcutil.Amount(output.TxOut.Value)
+		totalOut += value
+
+		if bytes.Equal(output.TxOut.PkScript, b.cfg.FundingPkScript) {
+			res.Capacity = value
+			numFundingOutputs++
+			continue
+		}
+
+		if output.Local {
+			res.LocalChange = append(res.LocalChange, output.TxOut)
+		} else {
+			res.RemoteChange = append(res.RemoteChange, output.TxOut)
+		}
+
+		if output.Local != b.cfg.Initiator {
+			responderOut += value
+		}
+	}
+
+	switch {
+	case numFundingOutputs != 1:
+		return nil, fmt.Errorf("transaction has %v funding outputs",
+			numFundingOutputs)
+
+	case totalIn < totalOut:
+		return nil, fmt.Errorf("transaction outputs (%v) exceed "+
+			"inputs (%v)", totalOut, totalIn)
+
+	case responderIn < responderOut:
+		return nil, fmt.Errorf("responder change (%v) exceeds its "+
+			"inputs (%v)", responderOut, responderIn)
+	}
+
+	responderAmt := responderIn - responderOut
+	initiatorAmt := res.Capacity - responderAmt
+	if initiatorAmt <= 0 {
+		return nil, fmt.Errorf("initiator contributes nothing to the " +
+			"funding output")
+	}
+
+	if b.cfg.Initiator {
+		res.LocalAmount = initiatorAmt
+		res.RemoteAmount = responderAmt
+	} else {
+		res.LocalAmount = responderAmt
+		res.RemoteAmount = initiatorAmt
+	}
+
+	return res, nil
+}
+
+// RemoteInputScripts converts the witnesses sent by the remote party within
+// their TxSignatures message into the input scripts expected by
+// ChannelReservation.CompleteReservation. The witnesses must be ordered by
+// the position of the remote inputs within the final funding transaction.
+func (r *InteractiveTxResult) RemoteInputScripts(fundingTx *wire.MsgTx,
+	witnesses []wire.TxWitness) ([]*InputScript, error) {
+
+	if len(witnesses) != len(r.RemoteInputs) {
+		return nil, fmt.Errorf("expected %v witnesses, got %v",
+			len(r.RemoteInputs), len(witnesses))
+	}
+
+	inputScripts := make([]*InputScript, 0, len(witnesses))
+	for _, txIn := range fundingTx.TxIn {
+		if _, ok := r.remoteInputs[txIn.PreviousOutPoint]; !ok {
+			continue
+		}
+
+		inputScript := &InputScript{
+			Witness: witnesses[len(inputScripts)],
+		}
+		inputScripts = append(inputScripts, inputScript)
+	}
+
+	return inputScripts, nil
+}
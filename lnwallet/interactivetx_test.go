@@ -0,0 +1,228 @@
+package lnwallet
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	testP2WKHScript = append([]byte{0x00, 0x14}, bytes.Repeat(
+		[]byte{0x01}, 20)...)
+	testFundingScript = append([]byte{0x00, 0x20}, bytes.Repeat(
+		[]byte{0x02}, 32)...)
+)
+
+// testUtxoSet is a set of outputs the builders may spend from.
+type testUtxoSet map[wire.OutPoint]*wire.TxOut
+
+func (u testUtxoSet) add(index uint32, value int64) (*wire.TxIn, *wire.TxOut) {
+	op := wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: index}
+	txOut := wire.NewTxOut(value, testP2WKHScript)
+	u[op] = txOut
+
+	return wire.NewTxIn(&op, nil, nil), txOut
+}
+
+func (u testUtxoSet) fetch(op *wire.OutPoint) (*wire.TxOut, error) {
+	txOut, ok := u[*op]
+	if !ok {
+		return nil, fmt.Errorf("utxo %v not found", op)
+	}
+
+	return txOut, nil
+}
+
+// newTestBuilders creates an initiator and responder builder sharing the
+// passed utxo set.
+func newTestBuilders(utxos testUtxoSet) (*InteractiveTxBuilder,
+	*InteractiveTxBuilder) {
+
+	cfg := InteractiveTxConfig{
+		ChanID:          lnwire.ChannelID{0x03},
+		FundingPkScript: testFundingScript,
+		FetchInput:      utxos.fetch,
+	}
+
+	initiatorCfg := cfg
+	initiatorCfg.Initiator = true
+
+	return NewInteractiveTxBuilder(initiatorCfg),
+		NewInteractiveTxBuilder(cfg)
+}
+
+// exchangeMessages passes messages back and forth between the two builders,
+// starting with the sender, until the construction completes.
+func exchangeMessages(t *testing.T, sender, receiver *InteractiveTxBuilder) {
+	for i := 0; !sender.Complete() || !receiver.Complete(); i++ {
+		if i > 100 {
+			t.Fatalf("construction did not complete")
+		}
+
+		msg, err := sender.NextMessage()
+		if err != nil {
+			t.Fatalf("unable to get next message: %v", err)
+		}
+		if err := receiver.ReceiveMessage(msg); err != nil {
+			t.Fatalf("unable to receive %T: %v", msg, err)
+		}
+
+		sender, receiver = receiver, sender
+	}
+}
+
+// TestInteractiveTxConstruction tests that two builders arrive at the same
+// funding transaction, attributing each party's contribution correctly.
+func TestInteractiveTxConstruction(t *testing.T) {
+	t.Parallel()
+
+	utxos := make(testUtxoSet)
+	initiator, responder := newTestBuilders(utxos)
+
+	initiator.QueueInput(utxos.add(0, 5e6))
+	responder.QueueInput(utxos.add(1, 3e6))
+	responder.QueueOutput(wire.NewTxOut(1e6, testP2WKHScript))
+	initiator.QueueOutput(wire.NewTxOut(6e6, testFundingScript))
+
+	exchangeMessages(t, initiator, responder)
+
+	initiatorRes, err := initiator.Result()
+	if err != nil {
+		t.Fatalf("unable to get initiator result: %v", err)
+	}
+	responderRes, err := responder.Result()
+	if err != nil {
+		t.Fatalf("unable to get responder result: %v", err)
+	}
+
+	if initiatorRes.Capacity != 6e6 || responderRes.Capacity != 6e6 {
+		t.Fatalf("unexpected capacity: %v vs %v",
+			initiatorRes.Capacity, responderRes.Capacity)
+	}
+	if initiatorRes.LocalAmount != 4e6 ||
+		initiatorRes.RemoteAmount != 2e6 {
+
+		t.Fatalf("unexpected initiator amounts: local=%v, remote=%v",
+			initiatorRes.LocalAmount, initiatorRes.RemoteAmount)
+	}
+	if responderRes.LocalAmount != initiatorRes.RemoteAmount ||
+		responderRes.RemoteAmount != initiatorRes.LocalAmount {
+
+		t.Fatalf("parties disagree on amounts")
+	}
+	if len(initiatorRes.RemoteInputs) != 1 ||
+		len(initiatorRes.RemoteChange) != 1 ||
+		len(responderRes.RemoteInputs) != 1 ||
+		len(responderRes.RemoteChange) != 0 {
+
+		t.Fatalf("inputs and outputs attributed to wrong party")
+	}
+
+	amt, err := initiator.RemoteAmount()
+	if err != nil {
+		t.Fatalf("unable to get remote amount: %v", err)
+	}
+	if amt != 2e6 {
+		t.Fatalf("expected remote amount of %v, got %v",
+			btcutil.Amount(2e6), amt)
+	}
+}
+
+// TestInteractiveTxProtocolViolations tests that the builder rejects messages
+// violating the construction protocol.
+func TestInteractiveTxProtocolViolations(t *testing.T) {
+	t.Parallel()
+
+	utxos := make(testUtxoSet)
+	initiator, responder := newTestBuilders(utxos)
+	txIn, _ := utxos.add(0, 1e6)
+
+	// The responder must wait for the initiator to send the first message.
+	if _, err := responder.NextMessage(); err != ErrNotOurTurn {
+		t.Fatalf("expected ErrNotOurTurn, got %v", err)
+	}
+	complete := &lnwire.TxComplete{ChanID: lnwire.ChannelID{0x03}}
+	if err := initiator.ReceiveMessage(complete); err != ErrNotTheirTurn {
+		t.Fatalf("expected ErrNotTheirTurn, got %v", err)
+	}
+
+	// Inputs added by the responder must use odd serial IDs.
+	addInput := &lnwire.TxAddInput{
+		SerialID: 2,
+		PrevOut:  txIn.PreviousOutPoint,
+	}
+	msg, err := initiator.NextMessage()
+	if err != nil {
+		t.Fatalf("unable to get next message: %v", err)
+	}
+	if err := responder.ReceiveMessage(msg); err != nil {
+		t.Fatalf("unable to receive message: %v", err)
+	}
+	if err := initiator.ReceiveMessage(addInput); err == nil {
+		t.Fatalf("expected input with wrong parity to be rejected")
+	}
+
+	// The responder may not add the funding output.
+	_, responder = newTestBuilders(utxos)
+	addFunding := &lnwire.TxAddOutput{
+		SerialID: 0,
+		Amount:   1e6,
+		PkScript: testFundingScript,
+	}
+	if err := responder.ReceiveMessage(addFunding); err != nil {
+		t.Fatalf("initiator should be able to add funding output: %v",
+			err)
+	}
+	responder.QueueOutput(wire.NewTxOut(1e6, testFundingScript))
+	msg, err = responder.NextMessage()
+	if err != nil {
+		t.Fatalf("unable to get next message: %v", err)
+	}
+	initiator, _ = newTestBuilders(utxos)
+	initiator.NextMessage()
+	if err := initiator.ReceiveMessage(msg); err == nil {
+		t.Fatalf("expected funding output from responder to be " +
+			"rejected")
+	}
+
+	// Spending the same outpoint twice must be rejected.
+	initiator, responder = newTestBuilders(utxos)
+	initiator.QueueInput(txIn, utxos[txIn.PreviousOutPoint])
+	msg, _ = initiator.NextMessage()
+	if err := responder.ReceiveMessage(msg); err != nil {
+		t.Fatalf("unable to receive input: %v", err)
+	}
+	addInput.SerialID = 1
+	if err := initiator.ReceiveMessage(addInput); err == nil {
+		t.Fatalf("expected double spend to be rejected")
+	}
+
+	// Remote inputs spending P2SH outputs must be rejected, as we're
+	// unable to verify their redeem script in time.
+	p2shOp := wire.OutPoint{Hash: chainhash.Hash{0x02}}
+	utxos[p2shOp] = wire.NewTxOut(1e6, append(append([]byte{0xa9, 0x14},
+		bytes.Repeat([]byte{0x03}, 20)...), 0x87))
+	initiator, responder = newTestBuilders(utxos)
+	msg, _ = initiator.NextMessage()
+	if err := responder.ReceiveMessage(msg); err != nil {
+		t.Fatalf("unable to receive message: %v", err)
+	}
+	addP2SHInput := &lnwire.TxAddInput{
+		SerialID: 1,
+		PrevOut:  p2shOp,
+	}
+	if err := initiator.ReceiveMessage(addP2SHInput); err == nil {
+		t.Fatalf("expected P2SH input to be rejected")
+	}
+
+	// Finally, the result is only available once both parties have sent
+	// a TxComplete in succession.
+	if _, err := responder.Result(); err == nil {
+		t.Fatalf("expected result of incomplete construction to fail")
+	}
+}
@@ -84,6 +84,9 @@ type TransactionDetail struct {
 
 	// DestAddresses are the destinations for a transaction
 	DestAddresses []btcutil.Address
+
+	// RawTx is the fully signed transaction itself.
+	RawTx *wire.MsgTx
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -1,6 +1,7 @@
 package lnwallet
 
 import (
+	"fmt"
 	"net"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -36,6 +38,11 @@ type ChannelContribution struct {
 	// send to the remote party.
 	FirstCommitmentPoint *btcec.PublicKey
 
+	// UpfrontShutdown is the script that this node commits to paying its
+	// funds out to upon a cooperative close of the channel. If empty, the
+	// node doesn't commit to a script.
+	UpfrontShutdown lnwire.DeliveryAddress
+
 	// ChannelConfig is the concrete contribution that this node is
 	// offering to the channel. This includes all the various constraints
 	// such as the min HTLC, and also all the keys which will be used for
@@ -276,6 +283,78 @@ func (r *ChannelReservation) SetNumConfsRequired(numConfs uint16) {
 	r.partialState.NumConfsRequired = numConfs
 }
 
+// SetOurUpfrontShutdown commits us to paying our funds out to the passed
+// script upon a cooperative close of the channel. The script is included
+// within our contribution to the channel.
+func (r *ChannelReservation) SetOurUpfrontShutdown(script lnwire.DeliveryAddress) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.ourContribution.UpfrontShutdown = script
+	r.partialState.LocalShutdownScript = script
+}
+
+// ApplyInteractiveTx updates the reservation to reflect a funding transaction
+// that was negotiated using the interactive transaction construction
+// protocol. The reservation is converted into a dual funder reservation,
+// with the capacity and initial balances derived from the amount each party
+// contributed. The party that opened the channel pays the commitment fee and
+// any amount pushed to the other party.
+//
+// NOTE: This MUST be called before .ProcessContribution(), and the inputs
+// and change outputs passed to it should be those of the remote party's
+// final contribution.
+func (r *ChannelReservation) ApplyInteractiveTx(res *InteractiveTxResult,
+	opener bool) error {
+
+	r.Lock()
+	defer r.Unlock()
+
+	commitFee := r.partialState.LocalCommitment.CommitFee
+	feeMSat := lnwire.NewMSatFromSatoshis(commitFee)
+	localMSat := lnwire.NewMSatFromSatoshis(res.LocalAmount)
+	remoteMSat := lnwire.NewMSatFromSatoshis(res.RemoteAmount)
+
+	var ourBalance, theirBalance lnwire.MilliSatoshi
+	if opener {
+		ourBalance = localMSat - feeMSat - r.pushMSat
+		theirBalance = remoteMSat + r.pushMSat
+	} else {
+		ourBalance = localMSat + r.pushMSat
+		theirBalance = remoteMSat - feeMSat - r.pushMSat
+	}
+
+	// The opener must still be left with a balance above the dust limit
+	// after paying for the commitment fee.
+	openerBalance := ourBalance
+	if !opener {
+		openerBalance = theirBalance
+	}
+	if int64(openerBalance) < 0 ||
+		openerBalance.ToSatoshis() <= 2*DefaultDustLimit() {
+
+		return ErrFunderBalanceDust(
+			int64(commitFee), int64(openerBalance.ToSatoshis()),
+			int64(2*DefaultDustLimit()),
+		)
+	}
+
+	r.ourContribution.Inputs = res.LocalInputs
+	r.ourContribution.ChangeOutputs = res.LocalChange
+	r.ourContribution.FundingAmount = ourBalance.ToSatoshis()
+	r.theirContribution.FundingAmount = theirBalance.ToSatoshis()
+
+	r.partialState.ChanType = channeldb.DualFunder
+	r.partialState.IsInitiator = opener
+	r.partialState.Capacity = res.Capacity
+	r.partialState.LocalCommitment.LocalBalance = ourBalance
+	r.partialState.LocalCommitment.RemoteBalance = theirBalance
+	r.partialState.RemoteCommitment.LocalBalance = ourBalance
+	r.partialState.RemoteCommitment.RemoteBalance = theirBalance
+
+	return nil
+}
+
 // CommitConstraints takes the constraints that the remote party specifies for
 // the type of commitments that we can generate for them. These constraints
 // include several parameters that serve as flow control restricting the amount
@@ -471,6 +550,104 @@ func (r *ChannelReservation) CompleteReservationSingle(fundingPoint *wire.OutPoi
 	return <-completeChan, <-errChan
 }
 
+// VerifyCommitSig verifies that the passed signature of the counterparty is
+// valid for our version of the commitment transaction. This allows a party to
+// a dual funder channel to ensure it holds a valid commitment transaction
+// before releasing its signatures for the funding transaction.
+//
+// NOTE: This can only be called after .ProcessContribution().
+func (r *ChannelReservation) VerifyCommitSig(commitSig []byte) error {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.verifyCommitSig(commitSig)
+}
+
+// SyncPending verifies the counterparty's signature for our version of the
+// commitment transaction of a dual funder channel, then persists the channel
+// as pending. Either party is able to broadcast the funding transaction once
+// it holds the other's signatures for it, so this MUST be called before
+// releasing our own signatures, ensuring we never have a channel confirm that
+// we hold no record of. The funding transaction is persisted again, fully
+// signed, once the reservation completes.
+//
+// NOTE: This can only be called after .ProcessContribution().
+func (r *ChannelReservation) SyncPending(
+	commitSig []byte) (*channeldb.OpenChannel, error) {
+
+	r.Lock()
+	defer r.Unlock()
+
+	if err := r.verifyCommitSig(commitSig); err != nil {
+		return nil, err
+	}
+
+	_, bestHeight, err := r.wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	r.theirCommitmentSig = commitSig
+	r.partialState.LocalCommitment.CommitSig = commitSig
+	r.partialState.LocalChanCfg = r.ourContribution.toChanConfig()
+	r.partialState.RemoteChanCfg = r.theirContribution.toChanConfig()
+	r.partialState.FundingTxn = r.fundingTx
+
+	err = r.partialState.SyncPending(r.nodeAddr, uint32(bestHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.partialState, nil
+}
+
+// verifyCommitSig verifies the counterparty's signature for our version of
+// the commitment transaction.
+//
+// NOTE: The reservation's mutex MUST be held when calling this method.
+func (r *ChannelReservation) verifyCommitSig(commitSig []byte) error {
+	commitTx := r.partialState.LocalCommitment.CommitTx
+	if commitTx == nil {
+		return fmt.Errorf("commitment transaction not yet created")
+	}
+
+	ourKey := r.ourContribution.MultiSigKey
+	theirKey := r.theirContribution.MultiSigKey
+
+	// Re-generate both the witnessScript and p2sh output. We sign the
+	// witnessScript script, but include the p2sh output as the subscript
+	// for verification.
+	witnessScript, _, err := GenFundingPkScript(
+		ourKey.PubKey.SerializeCompressed(),
+		theirKey.PubKey.SerializeCompressed(),
+		int64(r.partialState.Capacity),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Next, create the spending scriptSig, and then verify that the script
+	// is complete, allowing us to spend from the funding transaction.
+	channelValue := int64(r.partialState.Capacity)
+	hashCache := txscript.NewTxSigHashes(commitTx)
+	sigHash, err := txscript.CalcWitnessSigHash(witnessScript, hashCache,
+		txscript.SigHashAll, commitTx, 0, channelValue)
+	if err != nil {
+		return err
+	}
+
+	// Verify that we've received a valid signature from the remote party
+	// for our version of the commitment transaction.
+	sig, err := btcec.ParseSignature(commitSig, btcec.S256())
+	if err != nil {
+		return err
+	} else if !sig.Verify(sigHash, theirKey.PubKey) {
+		return fmt.Errorf("counterparty's commitment signature is invalid")
+	}
+
+	return nil
+}
+
 // TheirSignatures returns the counterparty's signatures to all inputs to the
 // funding transaction belonging to them, as well as their signature for the
 // wallet's version of the commitment transaction. This methods is provided for
@@ -382,6 +382,10 @@ out:
 				l.handleSingleFunderSigs(msg)
 			case *addCounterPartySigsMsg:
 				l.handleFundingCounterPartySigs(msg)
+			case *addBatchContributionMsg:
+				l.handleBatchContribution(msg)
+			case *addBatchSigsMsg:
+				l.handleBatchSigs(msg)
 			}
 		case <-l.quit:
 			// TODO: do some clean up
@@ -769,13 +773,36 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		)
 	}
 
+	err = l.initCommitments(
+		pendingReservation, fundingTx, multiSigOut, witnessScript,
+	)
+	if err != nil {
+		req.err <- err
+		return
+	}
+
+	req.err <- nil
+}
+
+// initCommitments records the location of the funding output within the
+// finalized funding transaction, then creates both versions of the initial
+// commitment transaction along with our signature for the remote party's
+// version. The reservation's mutex MUST be held by the caller.
+func (l *LightningWallet) initCommitments(res *ChannelReservation,
+	fundingTx *wire.MsgTx, multiSigOut *wire.TxOut,
+	witnessScript []byte) error {
+
+	ourContribution := res.ourContribution
+	theirContribution := res.theirContribution
+	ourKey := ourContribution.MultiSigKey
+
 	// Locate the index of the multi-sig outpoint in order to record it
 	// since the outputs are canonically sorted. If this is a single funder
 	// workflow, then we'll also need to send this to the remote node.
 	fundingTxID := fundingTx.TxHash()
 	_, multiSigIndex := FindScriptOutputIndex(fundingTx, multiSigOut.PkScript)
 	fundingOutpoint := wire.NewOutPoint(&fundingTxID, multiSigIndex)
-	pendingReservation.partialState.FundingOutpoint = *fundingOutpoint
+	res.partialState.FundingOutpoint = *fundingOutpoint
 
 	walletLog.Debugf("Funding tx for ChannelPoint(%v) generated: %v",
 		fundingOutpoint, spew.Sdump(fundingTx))
@@ -784,13 +811,14 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	// revocation hash (we don't yet know the preimage so we can't add it
 	// to the chain).
 	s := shachain.NewRevocationStore()
-	pendingReservation.partialState.RevocationStore = s
+	res.partialState.RevocationStore = s
 
 	// Store their current commitment point. We'll need this after the
 	// first state transition in order to verify the authenticity of the
 	// revocation.
-	chanState := pendingReservation.partialState
+	chanState := res.partialState
 	chanState.RemoteCurrentRevocation = theirContribution.FirstCommitmentPoint
+	chanState.RemoteShutdownScript = theirContribution.UpfrontShutdown
 
 	// Create the txin to our commitment transaction; required to construct
 	// the commitment transactions.
@@ -802,8 +830,8 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 
 	// With the funding tx complete, create both commitment transactions.
-	localBalance := pendingReservation.partialState.LocalCommitment.LocalBalance.ToSatoshis()
-	remoteBalance := pendingReservation.partialState.LocalCommitment.RemoteBalance.ToSatoshis()
+	localBalance := res.partialState.LocalCommitment.LocalBalance.ToSatoshis()
+	remoteBalance := res.partialState.LocalCommitment.RemoteBalance.ToSatoshis()
 	ourCommitTx, theirCommitTx, err := CreateCommitmentTxns(
 		localBalance, remoteBalance, ourContribution.ChannelConfig,
 		theirContribution.ChannelConfig,
@@ -811,8 +839,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		theirContribution.FirstCommitmentPoint, fundingTxIn,
 	)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 
 	// With both commitment transactions constructed, generate the state
@@ -842,8 +869,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	err = initStateHints(ourCommitTx, theirCommitTx, stateObfuscator)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 
 	// Sort both transactions according to the agreed upon canonical
@@ -864,7 +890,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 
 	// Generate a signature for their version of the initial commitment
 	// transaction.
-	signDesc = SignDescriptor{
+	signDesc := SignDescriptor{
 		WitnessScript: witnessScript,
 		KeyDesc:       ourKey,
 		Output:        multiSigOut,
@@ -874,12 +900,11 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	sigTheirCommit, err := l.Cfg.Signer.SignOutputRaw(theirCommitTx, &signDesc)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
-	pendingReservation.ourCommitmentSig = sigTheirCommit
+	res.ourCommitmentSig = sigTheirCommit
 
-	req.err <- nil
+	return nil
 }
 
 // handleSingleContribution is called as the second step to a single funder
@@ -918,6 +943,7 @@ func (l *LightningWallet) handleSingleContribution(req *addSingleContributionMsg
 	// within the channel state so we can sync it to disk once the funding
 	// process is complete.
 	chanState.RemoteCurrentRevocation = theirContribution.FirstCommitmentPoint
+	chanState.RemoteShutdownScript = theirContribution.UpfrontShutdown
 
 	req.err <- nil
 	return
@@ -1002,48 +1028,11 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 	// At this point, we can also record and verify their signature for our
 	// commitment transaction.
 	res.theirCommitmentSig = msg.theirCommitmentSig
-	commitTx := res.partialState.LocalCommitment.CommitTx
-	ourKey := res.ourContribution.MultiSigKey
-	theirKey := res.theirContribution.MultiSigKey
-
-	// Re-generate both the witnessScript and p2sh output. We sign the
-	// witnessScript script, but include the p2sh output as the subscript
-	// for verification.
-	witnessScript, _, err := GenFundingPkScript(
-		ourKey.PubKey.SerializeCompressed(),
-		theirKey.PubKey.SerializeCompressed(),
-		int64(res.partialState.Capacity),
-	)
-	if err != nil {
-		msg.err <- err
-		msg.completeChan <- nil
-		return
-	}
-
-	// Next, create the spending scriptSig, and then verify that the script
-	// is complete, allowing us to spend from the funding transaction.
-	channelValue := int64(res.partialState.Capacity)
-	hashCache := txscript.NewTxSigHashes(commitTx)
-	sigHash, err := txscript.CalcWitnessSigHash(witnessScript, hashCache,
-		txscript.SigHashAll, commitTx, 0, channelValue)
-	if err != nil {
-		msg.err <- err
-		msg.completeChan <- nil
-		return
-	}
-
-	// Verify that we've received a valid signature from the remote party
-	// for our version of the commitment transaction.
 	theirCommitSig := msg.theirCommitmentSig
-	sig, err := btcec.ParseSignature(theirCommitSig, btcec.S256())
-	if err != nil {
+	if err := res.verifyCommitSig(theirCommitSig); err != nil {
 		msg.err <- err
 		msg.completeChan <- nil
 		return
-	} else if !sig.Verify(sigHash, theirKey.PubKey) {
-		msg.err <- fmt.Errorf("counterparty's commitment signature is invalid")
-		msg.completeChan <- nil
-		return
 	}
 	res.partialState.LocalCommitment.CommitSig = theirCommitSig
 
@@ -7,16 +7,32 @@ import (
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 )
 
-// ChanUpdateFlag is a bitfield that signals various options concerning a
+// ChanUpdateMsgFlags is a bitfield that signals whether optional fields are
+// present in the ChannelUpdate.
+type ChanUpdateMsgFlags uint8
+
+const (
+	// ChanUpdateOptionMaxHtlc is a bit that indicates whether the
+	// optional htlc_maximum_msat field is present in this ChannelUpdate.
+	ChanUpdateOptionMaxHtlc ChanUpdateMsgFlags = 1 << iota
+)
+
+// HasMaxHtlc returns true if the htlc_maximum_msat option bit is set in the
+// message flags.
+func (c ChanUpdateMsgFlags) HasMaxHtlc() bool {
+	return c&ChanUpdateOptionMaxHtlc != 0
+}
+
+// ChanUpdateChanFlags is a bitfield that signals various options concerning a
 // particular channel edge. Each bit is to be examined in order to determine
 // how the ChannelUpdate message is to be interpreted.
-type ChanUpdateFlag uint16
+type ChanUpdateChanFlags uint8
 
 const (
 	// ChanUpdateDirection indicates the direction of a channel update. If
 	// this bit is set to 0 if Node1 (the node with the "smaller" Node ID)
 	// is updating the channel, and to 1 otherwise.
-	ChanUpdateDirection ChanUpdateFlag = 1 << iota
+	ChanUpdateDirection ChanUpdateChanFlags = 1 << iota
 
 	// ChanUpdateDisabled is a bit that indicates if the channel edge
 	// selected by the ChanUpdateDirection bit is to be treated as being
@@ -47,13 +63,18 @@ type ChannelUpdate struct {
 	// the last-received.
 	Timestamp uint32
 
-	// Flags is a bitfield that describes additional meta-data concerning
-	// how the update is to be interpreted. Currently, the
+	// MessageFlags is a bitfield that describes whether optional fields
+	// are present in this update. Currently, the least-significant bit
+	// must be set to 1 if the optional field HtlcMaximumMsat is present.
+	MessageFlags ChanUpdateMsgFlags
+
+	// ChannelFlags is a bitfield that describes additional meta-data
+	// concerning how the update is to be interpreted. Currently, the
 	// least-significant bit must be set to 0 if the creating node
 	// corresponds to the first node in the previously sent channel
 	// announcement and 1 otherwise. If the second bit is set, then the
 	// channel is set to be disabled.
-	Flags ChanUpdateFlag
+	ChannelFlags ChanUpdateChanFlags
 
 	// TimeLockDelta is the minimum number of blocks this node requires to
 	// be added to the expiry of HTLCs. This is a security parameter
@@ -73,6 +94,11 @@ type ChannelUpdate struct {
 	// FeeRate is the fee rate that will be charged per millionth of a
 	// satoshi.
 	FeeRate uint32
+
+	// HtlcMaximumMsat is the maximum HTLC value which will be accepted.
+	// This field is only present on the wire if the
+	// ChanUpdateOptionMaxHtlc bit is set within MessageFlags.
+	HtlcMaximumMsat MilliSatoshi
 }
 
 // A compile time check to ensure ChannelUpdate implements the lnwire.Message
@@ -84,17 +110,30 @@ var _ Message = (*ChannelUpdate)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		&a.Signature,
 		a.ChainHash[:],
 		&a.ShortChannelID,
 		&a.Timestamp,
-		&a.Flags,
+		&a.MessageFlags,
+		&a.ChannelFlags,
 		&a.TimeLockDelta,
 		&a.HtlcMinimumMsat,
 		&a.BaseFee,
 		&a.FeeRate,
 	)
+	if err != nil {
+		return err
+	}
+
+	// Now check whether the max HTLC field is present and read it if so.
+	if a.MessageFlags.HasMaxHtlc() {
+		if err := readElements(r, &a.HtlcMaximumMsat); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Encode serializes the target ChannelUpdate into the passed io.Writer
@@ -102,17 +141,28 @@ func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (a *ChannelUpdate) Encode(w io.Writer, pver uint32) error {
-	return writeElements(w,
+	err := writeElements(w,
 		a.Signature,
 		a.ChainHash[:],
 		a.ShortChannelID,
 		a.Timestamp,
-		a.Flags,
+		a.MessageFlags,
+		a.ChannelFlags,
 		a.TimeLockDelta,
 		a.HtlcMinimumMsat,
 		a.BaseFee,
 		a.FeeRate,
 	)
+	if err != nil {
+		return err
+	}
+
+	// Now append the optional max HTLC field if its presence is signalled.
+	if a.MessageFlags.HasMaxHtlc() {
+		return writeElements(w, a.HtlcMaximumMsat)
+	}
+
+	return nil
 }
 
 // MsgType returns the integer uniquely identifying this message type on the
@@ -142,8 +192,11 @@ func (a *ChannelUpdate) MaxPayloadLength(pver uint32) uint32 {
 	// Timestamp - 4 bytes
 	length += 4
 
-	// Flags - 2 bytes
-	length += 2
+	// MessageFlags - 1 byte
+	length += 1
+
+	// ChannelFlags - 1 byte
+	length += 1
 
 	// Expiry - 2 bytes
 	length += 2
@@ -157,6 +210,9 @@ func (a *ChannelUpdate) MaxPayloadLength(pver uint32) uint32 {
 	// FeeProportionalMillionths - 4 bytes
 	length += 4
 
+	// HtlcMaximumMsat - 8 bytes
+	length += 8
+
 	return length
 }
 
@@ -170,7 +226,8 @@ func (a *ChannelUpdate) DataToSign() ([]byte, error) {
 		a.ChainHash[:],
 		a.ShortChannelID,
 		a.Timestamp,
-		a.Flags,
+		a.MessageFlags,
+		a.ChannelFlags,
 		a.TimeLockDelta,
 		a.HtlcMinimumMsat,
 		a.BaseFee,
@@ -180,5 +237,12 @@ func (a *ChannelUpdate) DataToSign() ([]byte, error) {
 		return nil, err
 	}
 
+	// Now append the optional max HTLC field if its presence is signalled.
+	if a.MessageFlags.HasMaxHtlc() {
+		if err := writeElements(&w, a.HtlcMaximumMsat); err != nil {
+			return nil, err
+		}
+	}
+
 	return w.Bytes(), nil
 }
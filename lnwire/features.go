@@ -21,6 +21,22 @@ const (
 	// connection is established.
 	InitialRoutingSync FeatureBit = 3
 
+	// UpfrontShutdownScriptOptional is a local feature bit signalling that
+	// the sending node supports committing to the script its funds are
+	// paid out to upon a cooperative close when opening a channel.
+	UpfrontShutdownScriptOptional FeatureBit = 5
+
+	// LargeChannelsOptional is a local feature bit signalling that the
+	// sending node is willing to open and accept channels above the
+	// 2^24 satoshi limit defined in BOLT-0002.
+	LargeChannelsOptional FeatureBit = 19
+
+	// DualFundingOptional is a local feature bit signalling that the
+	// sending node supports opening dual-funded channels, wherein both
+	// parties contribute inputs to the funding transaction through the
+	// interactive transaction construction protocol.
+	DualFundingOptional FeatureBit = 29
+
 	// maxAllowedSize is a maximum allowed size of feature vector.
 	//
 	// NOTE: Within the protocol, the maximum allowed message size is 65535
@@ -42,7 +58,10 @@ const (
 // not advertised to the entire network. A full description of these feature
 // bits is provided in the BOLT-09 specification.
 var LocalFeatures = map[FeatureBit]string{
-	InitialRoutingSync: "initial-routing-sync",
+	InitialRoutingSync:            "initial-routing-sync",
+	UpfrontShutdownScriptOptional: "upfront-shutdown-script",
+	LargeChannelsOptional:         "large-channels",
+	DualFundingOptional:           "dual-funding",
 }
 
 // GlobalFeatures is a mapping of known global feature bits to a descriptive
@@ -85,6 +104,15 @@ func (fv *RawFeatureVector) Unset(feature FeatureBit) {
 	delete(fv.features, feature)
 }
 
+// Clone makes a copy of the feature vector.
+func (fv *RawFeatureVector) Clone() *RawFeatureVector {
+	newFeatures := NewRawFeatureVector()
+	for bit := range fv.features {
+		newFeatures.Set(bit)
+	}
+	return newFeatures
+}
+
 // SerializeSize returns the number of bytes needed to represent feature vector
 // in byte format.
 func (fv *RawFeatureVector) SerializeSize() int {
@@ -99,9 +99,15 @@ func writeElement(w io.Writer, element interface{}) error {
 		if _, err := w.Write(b[:]); err != nil {
 			return err
 		}
-	case ChanUpdateFlag:
-		var b [2]byte
-		binary.BigEndian.PutUint16(b[:], uint16(e))
+	case ChanUpdateMsgFlags:
+		var b [1]byte
+		b[0] = uint8(e)
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+	case ChanUpdateChanFlags:
+		var b [1]byte
+		b[0] = uint8(e)
 		if _, err := w.Write(b[:]); err != nil {
 			return err
 		}
@@ -255,6 +261,42 @@ func writeElement(w io.Writer, element interface{}) error {
 		if _, err := w.Write(e[:]); err != nil {
 			return err
 		}
+	case chainhash.Hash:
+		if _, err := w.Write(e[:]); err != nil {
+			return err
+		}
+	case []wire.TxWitness:
+		if len(e) > math.MaxUint16 {
+			return fmt.Errorf("too many witnesses: %d", len(e))
+		}
+		if err := writeElement(w, uint16(len(e))); err != nil {
+			return err
+		}
+
+		for _, witness := range e {
+			if len(witness) > math.MaxUint16 {
+				return fmt.Errorf("too many witness elements: "+
+					"%d", len(witness))
+			}
+			err := writeElement(w, uint16(len(witness)))
+			if err != nil {
+				return err
+			}
+
+			for _, item := range witness {
+				if len(item) > math.MaxUint16 {
+					return fmt.Errorf("witness element "+
+						"too large: %d bytes", len(item))
+				}
+				err := writeElement(w, uint16(len(item)))
+				if err != nil {
+					return err
+				}
+				if _, err := w.Write(item); err != nil {
+					return err
+				}
+			}
+		}
 	case FailCode:
 		if err := writeElement(w, uint16(e)); err != nil {
 			return err
@@ -408,12 +450,18 @@ func readElement(r io.Reader, element interface{}) error {
 			return err
 		}
 		*e = binary.BigEndian.Uint16(b[:])
-	case *ChanUpdateFlag:
-		var b [2]byte
+	case *ChanUpdateMsgFlags:
+		var b [1]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return err
+		}
+		*e = ChanUpdateMsgFlags(b[0])
+	case *ChanUpdateChanFlags:
+		var b [1]byte
 		if _, err := io.ReadFull(r, b[:]); err != nil {
 			return err
 		}
-		*e = ChanUpdateFlag(binary.BigEndian.Uint16(b[:]))
+		*e = ChanUpdateChanFlags(b[0])
 	case *ErrorCode:
 		var b [2]byte
 		if _, err := io.ReadFull(r, b[:]); err != nil {
@@ -574,6 +622,39 @@ func readElement(r io.Reader, element interface{}) error {
 		if _, err := io.ReadFull(r, e[:]); err != nil {
 			return err
 		}
+	case *chainhash.Hash:
+		if _, err := io.ReadFull(r, e[:]); err != nil {
+			return err
+		}
+	case *[]wire.TxWitness:
+		var numWitnesses uint16
+		if err := readElement(r, &numWitnesses); err != nil {
+			return err
+		}
+
+		witnesses := make([]wire.TxWitness, numWitnesses)
+		for i := range witnesses {
+			var numItems uint16
+			if err := readElement(r, &numItems); err != nil {
+				return err
+			}
+
+			witness := make(wire.TxWitness, numItems)
+			for j := range witness {
+				var itemLen uint16
+				if err := readElement(r, &itemLen); err != nil {
+					return err
+				}
+
+				witness[j] = make([]byte, itemLen)
+				_, err := io.ReadFull(r, witness[j])
+				if err != nil {
+					return err
+				}
+			}
+			witnesses[i] = witness
+		}
+		*e = witnesses
 
 	case *ShortChannelID:
 		var blockHeight [4]byte
@@ -65,6 +65,17 @@ func randRawKey() ([33]byte, error) {
 	return n, nil
 }
 
+// randWitness generates a random, non-empty witness stack.
+func randWitness(r *rand.Rand) wire.TxWitness {
+	witness := make(wire.TxWitness, 1+r.Intn(3))
+	for i := range witness {
+		witness[i] = make([]byte, 1+r.Intn(73))
+		r.Read(witness[i])
+	}
+
+	return witness
+}
+
 func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	featureVec := NewRawFeatureVector()
 	for i := 0; i < 10000; i++ {
@@ -213,6 +224,12 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			req.UpfrontShutdownScript = make([]byte, r.Intn(35))
+			if _, err := r.Read(req.UpfrontShutdownScript); err != nil {
+				t.Fatalf("unable to generate script: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgAcceptChannel: func(v []reflect.Value, r *rand.Rand) {
@@ -263,6 +280,12 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			req.UpfrontShutdownScript = make([]byte, r.Intn(35))
+			if _, err := r.Read(req.UpfrontShutdownScript); err != nil {
+				t.Fatalf("unable to generate script: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgFundingCreated: func(v []reflect.Value, r *rand.Rand) {
@@ -483,14 +506,24 @@ func TestLightningWireProtocol(t *testing.T) {
 		},
 		MsgChannelUpdate: func(v []reflect.Value, r *rand.Rand) {
 			var err error
+			// We'll only set the max HTLC field, and the
+			// corresponding message flag, some of the time.
+			msgFlags := ChanUpdateMsgFlags(r.Int31())
+			maxHtlc := MilliSatoshi(r.Int63())
+			if !msgFlags.HasMaxHtlc() {
+				maxHtlc = 0
+			}
+
 			req := ChannelUpdate{
 				ShortChannelID:  NewShortChanIDFromInt(uint64(r.Int63())),
 				Timestamp:       uint32(r.Int31()),
-				Flags:           ChanUpdateFlag(r.Int31()),
+				MessageFlags:    msgFlags,
+				ChannelFlags:    ChanUpdateChanFlags(r.Int31()),
 				TimeLockDelta:   uint16(r.Int31()),
 				HtlcMinimumMsat: MilliSatoshi(r.Int63()),
 				BaseFee:         uint32(r.Int31()),
 				FeeRate:         uint32(r.Int31()),
+				HtlcMaximumMsat: maxHtlc,
 			}
 			req.Signature, err = NewSigFromSignature(testSig)
 			if err != nil {
@@ -553,6 +586,58 @@ func TestLightningWireProtocol(t *testing.T) {
 				}
 			}
 
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgTxAddInput: func(v []reflect.Value, r *rand.Rand) {
+			req := TxAddInput{
+				SerialID: uint64(r.Int63()),
+				Sequence: uint32(r.Int31()),
+			}
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+			if _, err := r.Read(req.PrevOut.Hash[:]); err != nil {
+				t.Fatalf("unable to generate prev hash: %v", err)
+				return
+			}
+			req.PrevOut.Index = uint32(r.Int31())
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgTxAddOutput: func(v []reflect.Value, r *rand.Rand) {
+			req := TxAddOutput{
+				SerialID: uint64(r.Int63()),
+				Amount:   btcutil.Amount(r.Int63()),
+				PkScript: make([]byte, 1+r.Intn(34)),
+			}
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+			if _, err := r.Read(req.PkScript); err != nil {
+				t.Fatalf("unable to generate pkscript: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgTxSignatures: func(v []reflect.Value, r *rand.Rand) {
+			req := TxSignatures{
+				Witnesses: make([]wire.TxWitness, r.Intn(5)),
+			}
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+			if _, err := r.Read(req.TxHash[:]); err != nil {
+				t.Fatalf("unable to generate tx hash: %v", err)
+				return
+			}
+			for i := range req.Witnesses {
+				req.Witnesses[i] = randWitness(r)
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 	}
@@ -705,6 +790,30 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgTxAddInput,
+			scenario: func(m TxAddInput) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgTxAddOutput,
+			scenario: func(m TxAddOutput) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgTxComplete,
+			scenario: func(m TxComplete) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgTxSignatures,
+			scenario: func(m TxSignatures) bool {
+				return mainScenario(&m)
+			},
+		},
 	}
 	for _, test := range tests {
 		var config *quick.Config
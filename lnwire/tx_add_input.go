@@ -0,0 +1,79 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TxAddInput is sent by either party during the interactive construction of
+// a dual-funded channel's funding transaction in order to add one of its
+// inputs to the transaction. Only the outpoint being spent is sent, the
+// receiver is expected to look up the value and script of the output within
+// the UTXO set itself.
+type TxAddInput struct {
+	// ChanID is the pending channel ID of the channel whose funding
+	// transaction is being constructed.
+	ChanID ChannelID
+
+	// SerialID uniquely identifies this input within the transaction
+	// being constructed. The initiator of the negotiation MUST use even
+	// serial IDs, while the responder MUST use odd serial IDs.
+	SerialID uint64
+
+	// PrevOut is the outpoint of the output being spent.
+	PrevOut wire.OutPoint
+
+	// Sequence is the sequence number that should be set on the input.
+	Sequence uint32
+}
+
+// A compile time check to ensure TxAddInput implements the lnwire.Message
+// interface.
+var _ Message = (*TxAddInput)(nil)
+
+// Encode serializes the target TxAddInput into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddInput) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		t.ChanID,
+		t.SerialID,
+		t.PrevOut.Hash,
+		t.PrevOut.Index,
+		t.Sequence,
+	)
+}
+
+// Decode deserializes the serialized TxAddInput stored in the passed
+// io.Reader into the target TxAddInput using the deserialization rules
+// defined by the passed protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddInput) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&t.ChanID,
+		&t.SerialID,
+		&t.PrevOut.Hash,
+		&t.PrevOut.Index,
+		&t.Sequence,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddInput) MsgType() MessageType {
+	return MsgTxAddInput
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// TxAddInput complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddInput) MaxPayloadLength(uint32) uint32 {
+	// 32 + 8 + 32 + 4 + 4
+	return 80
+}
@@ -0,0 +1,75 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/roasbeef/btcutil"
+)
+
+// TxAddOutput is sent by either party during the interactive construction of
+// a dual-funded channel's funding transaction in order to add an output to
+// the transaction, such as the funding output itself or a change output.
+type TxAddOutput struct {
+	// ChanID is the pending channel ID of the channel whose funding
+	// transaction is being constructed.
+	ChanID ChannelID
+
+	// SerialID uniquely identifies this output within the transaction
+	// being constructed. The initiator of the negotiation MUST use even
+	// serial IDs, while the responder MUST use odd serial IDs.
+	SerialID uint64
+
+	// Amount is the value of the output.
+	Amount btcutil.Amount
+
+	// PkScript is the script the output pays to.
+	PkScript PkScript
+}
+
+// A compile time check to ensure TxAddOutput implements the lnwire.Message
+// interface.
+var _ Message = (*TxAddOutput)(nil)
+
+// Encode serializes the target TxAddOutput into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddOutput) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		t.ChanID,
+		t.SerialID,
+		t.Amount,
+		t.PkScript,
+	)
+}
+
+// Decode deserializes the serialized TxAddOutput stored in the passed
+// io.Reader into the target TxAddOutput using the deserialization rules
+// defined by the passed protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddOutput) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&t.ChanID,
+		&t.SerialID,
+		&t.Amount,
+		&t.PkScript,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddOutput) MsgType() MessageType {
+	return MsgTxAddOutput
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// TxAddOutput complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxAddOutput) MaxPayloadLength(uint32) uint32 {
+	// 32 + 8 + 8 + 1 + 34
+	return 83
+}
@@ -0,0 +1,51 @@
+package lnwire
+
+import "io"
+
+// TxComplete is sent by either party during the interactive construction of
+// a dual-funded channel's funding transaction to signal that it has nothing
+// further to add. Once both parties have sent a TxComplete in succession,
+// the transaction is considered final.
+type TxComplete struct {
+	// ChanID is the pending channel ID of the channel whose funding
+	// transaction is being constructed.
+	ChanID ChannelID
+}
+
+// A compile time check to ensure TxComplete implements the lnwire.Message
+// interface.
+var _ Message = (*TxComplete)(nil)
+
+// Encode serializes the target TxComplete into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxComplete) Encode(w io.Writer, pver uint32) error {
+	return writeElement(w, t.ChanID)
+}
+
+// Decode deserializes the serialized TxComplete stored in the passed
+// io.Reader into the target TxComplete using the deserialization rules
+// defined by the passed protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxComplete) Decode(r io.Reader, pver uint32) error {
+	return readElement(r, &t.ChanID)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxComplete) MsgType() MessageType {
+	return MsgTxComplete
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a TxComplete
+// complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxComplete) MaxPayloadLength(uint32) uint32 {
+	// 32
+	return 32
+}
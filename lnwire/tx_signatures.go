@@ -0,0 +1,69 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TxSignatures is sent by each party once the commitment signatures for a
+// dual-funded channel have been exchanged. It carries the witnesses for all
+// of the sender's inputs to the funding transaction, ordered by the position
+// of those inputs within the final transaction.
+type TxSignatures struct {
+	// ChanID is the pending channel ID of the channel whose funding
+	// transaction is being signed.
+	ChanID ChannelID
+
+	// TxHash is the txid of the funding transaction being signed.
+	TxHash chainhash.Hash
+
+	// Witnesses is the set of witnesses for the sender's inputs.
+	Witnesses []wire.TxWitness
+}
+
+// A compile time check to ensure TxSignatures implements the lnwire.Message
+// interface.
+var _ Message = (*TxSignatures)(nil)
+
+// Encode serializes the target TxSignatures into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxSignatures) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		t.ChanID,
+		t.TxHash,
+		t.Witnesses,
+	)
+}
+
+// Decode deserializes the serialized TxSignatures stored in the passed
+// io.Reader into the target TxSignatures using the deserialization rules
+// defined by the passed protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxSignatures) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&t.ChanID,
+		&t.TxHash,
+		&t.Witnesses,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxSignatures) MsgType() MessageType {
+	return MsgTxSignatures
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// TxSignatures complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (t *TxSignatures) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
@@ -74,6 +74,7 @@ var (
 	atplLog = backendLog.Logger("ATPL")
 	cnctLog = backendLog.Logger("CNCT")
 	sphxLog = backendLog.Logger("SPHX")
+	bumpLog = backendLog.Logger("BUMP")
 )
 
 // Initialize package-global logger variables.
@@ -111,6 +112,7 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"ATPL": atplLog,
 	"CNCT": cnctLog,
 	"SPHX": sphxLog,
+	"BUMP": bumpLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
@@ -3,6 +3,8 @@ package macaroons
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/peer"
@@ -11,6 +13,27 @@ import (
 	macaroon "gopkg.in/macaroon.v2"
 
 	"golang.org/x/net/context"
+
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// CondTimeAfter is the caveat condition that restricts a macaroon to
+	// only be valid after a certain point in time.
+	CondTimeAfter = "time-after"
+
+	// CondMethods is the caveat condition that restricts a macaroon to a
+	// whitelist of RPC methods.
+	CondMethods = "methods"
+
+	// CondMaxSpend is the caveat condition that restricts the amount a
+	// single RPC call authorized by the macaroon may spend.
+	CondMaxSpend = "maxspend"
+
+	// CondDailySpend is the caveat condition that restricts the cumulative
+	// amount spent by all calls authorized by the macaroon within a single
+	// UTC day.
+	CondDailySpend = "dailyspend"
 )
 
 // Constraint type adds a layer of indirection over macaroon caveats.
@@ -66,6 +89,95 @@ func IPLockConstraint(ipAddr string) func(*macaroon.Macaroon) error {
 	}
 }
 
+// TimeWindowConstraint restricts the macaroon to only be valid between the
+// two passed points in time. A zero notBefore or notAfter leaves the
+// respective side of the window open.
+func TimeWindowConstraint(notBefore, notAfter time.Time) func(*macaroon.Macaroon) error {
+	return func(mac *macaroon.Macaroon) error {
+		if !notBefore.IsZero() && !notAfter.IsZero() &&
+			!notBefore.Before(notAfter) {
+
+			return fmt.Errorf("invalid time window: %v is not "+
+				"before %v", notBefore, notAfter)
+		}
+
+		if !notBefore.IsZero() {
+			caveat := checkers.Condition(
+				CondTimeAfter, notBefore.UTC().Format(time.RFC3339),
+			)
+			err := mac.AddFirstPartyCaveat([]byte(caveat))
+			if err != nil {
+				return err
+			}
+		}
+
+		if !notAfter.IsZero() {
+			caveat := checkers.TimeBeforeCaveat(notAfter.UTC())
+			return mac.AddFirstPartyCaveat([]byte(caveat.Condition))
+		}
+
+		return nil
+	}
+}
+
+// MethodConstraint restricts the macaroon to the passed list of RPC methods.
+// Methods can either be given by their full gRPC name (e.g.
+// "/lnrpc.Lightning/GetInfo") or by their short name (e.g. "GetInfo"). If no
+// methods are passed, this constraint does nothing.
+func MethodConstraint(methods ...string) func(*macaroon.Macaroon) error {
+	return func(mac *macaroon.Macaroon) error {
+		if len(methods) == 0 {
+			return nil
+		}
+
+		for _, method := range methods {
+			if method == "" || strings.ContainsAny(method, " \t") {
+				return fmt.Errorf("invalid method name %q", method)
+			}
+		}
+
+		caveat := checkers.Condition(
+			CondMethods, strings.Join(methods, " "),
+		)
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
+// SpendLimitConstraint restricts the amount that a single payment, on-chain
+// send or channel open authorized by the macaroon may spend. A zero limit
+// leaves the macaroon unrestricted.
+func SpendLimitConstraint(limit btcutil.Amount) func(*macaroon.Macaroon) error {
+	return amountConstraint(CondMaxSpend, limit)
+}
+
+// DailySpendConstraint restricts the cumulative amount that all calls
+// authorized by the macaroon may spend within a single UTC day. The budget is
+// tracked within the macaroon database. A zero budget leaves the macaroon
+// unrestricted.
+func DailySpendConstraint(budget btcutil.Amount) func(*macaroon.Macaroon) error {
+	return amountConstraint(CondDailySpend, budget)
+}
+
+// amountConstraint adds a first-party caveat with the given condition and an
+// amount in satoshis as its argument.
+func amountConstraint(cond string,
+	amt btcutil.Amount) func(*macaroon.Macaroon) error {
+
+	return func(mac *macaroon.Macaroon) error {
+		switch {
+		case amt < 0:
+			return fmt.Errorf("%s amount must not be negative", cond)
+		case amt == 0:
+			return nil
+		}
+
+		caveat := checkers.Condition(
+			cond, strconv.FormatInt(int64(amt), 10),
+		)
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
 // IPLockChecker accepts client IP from the validation context and compares it
 // with IP locked in the macaroon. It is of the `Checker` type.
 func IPLockChecker() (string, checkers.Func) {
@@ -88,3 +200,110 @@ func IPLockChecker() (string, checkers.Func) {
 		return nil
 	}
 }
+
+// TimeAfterChecker ensures that the current time is past the point in time
+// encoded within a time-after caveat. It is of the `Checker` type.
+func TimeAfterChecker() (string, checkers.Func) {
+	return CondTimeAfter, func(_ context.Context, _, arg string) error {
+		notBefore, err := time.Parse(time.RFC3339, arg)
+		if err != nil {
+			return fmt.Errorf("invalid time-after caveat: %v", err)
+		}
+
+		if time.Now().Before(notBefore) {
+			return fmt.Errorf("macaroon not valid before %v",
+				notBefore)
+		}
+		return nil
+	}
+}
+
+// MethodChecker ensures that the RPC method being called is within the list
+// of methods the macaroon is restricted to. It is of the `Checker` type.
+func MethodChecker() (string, checkers.Func) {
+	return CondMethods, func(ctx context.Context, _, arg string) error {
+		info := rpcInfoFromContext(ctx)
+		if info == nil || info.method == "" {
+			return fmt.Errorf("unable to determine rpc method")
+		}
+
+		shortName := info.method
+		if idx := strings.LastIndex(shortName, "/"); idx != -1 {
+			shortName = shortName[idx+1:]
+		}
+
+		for _, method := range strings.Fields(arg) {
+			if method == info.method || method == shortName {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("macaroon not valid for method %v",
+			info.method)
+	}
+}
+
+// RequestAmountFunc returns the amount in satoshis that an RPC request will
+// spend if it's executed. Requests that don't spend any funds should return
+// a zero amount.
+type RequestAmountFunc func(method string, req interface{}) (btcutil.Amount,
+	error)
+
+// SpendLimitChecker returns a `Checker` which ensures that the amount spent
+// by the RPC request doesn't exceed the limit encoded within a maxspend
+// caveat. The passed function is used to determine the amount spent by a
+// request.
+func SpendLimitChecker(reqAmt RequestAmountFunc) Checker {
+	return func() (string, checkers.Func) {
+		return CondMaxSpend, func(ctx context.Context, _,
+			arg string) error {
+
+			limit, err := parseAmountCaveat(arg)
+			if err != nil {
+				return err
+			}
+
+			amt, err := requestAmount(ctx, reqAmt)
+			if err != nil {
+				return err
+			}
+
+			if amt > limit {
+				return fmt.Errorf("amount %v exceeds macaroon "+
+					"spend limit of %v", amt, limit)
+			}
+			return nil
+		}
+	}
+}
+
+// parseAmountCaveat parses the satoshi amount argument of a maxspend or
+// dailyspend caveat.
+func parseAmountCaveat(arg string) (btcutil.Amount, error) {
+	sats, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || sats < 0 {
+		return 0, fmt.Errorf("invalid amount caveat %q", arg)
+	}
+
+	return btcutil.Amount(sats), nil
+}
+
+// requestAmount returns the amount spent by the request attached to the
+// context. If no request is attached (e.g. when a stream is first opened),
+// zero is returned.
+func requestAmount(ctx context.Context,
+	reqAmt RequestAmountFunc) (btcutil.Amount, error) {
+
+	info := rpcInfoFromContext(ctx)
+	if info == nil || info.req == nil {
+		return 0, nil
+	}
+
+	amt, err := reqAmt(info.method, info.req)
+	if err != nil {
+		return 0, fmt.Errorf("unable to determine request amount: %v",
+			err)
+	}
+
+	return amt, nil
+}
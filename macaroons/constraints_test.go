@@ -0,0 +1,314 @@
+package macaroons_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	macaroon "gopkg.in/macaroon.v2"
+
+	"github.com/lightningnetwork/lnd/macaroons"
+
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	testOp = bakery.Op{Entity: "onchain", Action: "write"}
+
+	sendMethod = "/lnrpc.Lightning/SendCoins"
+	infoMethod = "/lnrpc.Lightning/GetInfo"
+
+	testPermissions = map[string][]bakery.Op{
+		sendMethod: {testOp},
+		infoMethod: {testOp},
+	}
+)
+
+// spendRequest is a mock RPC request that spends the given amount.
+type spendRequest struct {
+	amt btcutil.Amount
+}
+
+// mockSpendAmount is a RequestAmountFunc for the spendRequest mock.
+func mockSpendAmount(_ string, req interface{}) (btcutil.Amount, error) {
+	if r, ok := req.(*spendRequest); ok {
+		return r.amt, nil
+	}
+	return 0, nil
+}
+
+// setupService creates an unlocked macaroon service within a temporary
+// directory, along with a fresh macaroon minted from it.
+func setupService(t *testing.T) (*macaroons.Service, *macaroon.Macaroon,
+	func()) {
+
+	tempDir, err := ioutil.TempDir("", "macaroonconstraints-")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+
+	svc, err := macaroons.NewService(
+		tempDir, macaroons.TimeAfterChecker, macaroons.MethodChecker,
+		macaroons.SpendLimitChecker(mockSpendAmount),
+	)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("Error creating macaroon service: %v", err)
+	}
+	svc.RegisterCheckers(svc.DailySpendChecker(mockSpendAmount))
+
+	cleanUp := func() {
+		svc.Close()
+		os.RemoveAll(tempDir)
+	}
+
+	pw := []byte("weks")
+	if err := svc.CreateUnlock(&pw); err != nil {
+		cleanUp()
+		t.Fatalf("Error unlocking macaroon service: %v", err)
+	}
+
+	mac, err := svc.Oven.NewMacaroon(
+		context.Background(), bakery.LatestVersion, nil, testOp,
+	)
+	if err != nil {
+		cleanUp()
+		t.Fatalf("Error creating macaroon: %v", err)
+	}
+
+	return svc, mac.M(), cleanUp
+}
+
+// callWithMacaroon invokes the service's unary interceptor for the passed
+// method and request, authenticating with the passed macaroon.
+func callWithMacaroon(t *testing.T, svc *macaroons.Service,
+	mac *macaroon.Macaroon, method string, req interface{}) error {
+
+	return callWithHandlerErr(t, svc, mac, method, req, nil)
+}
+
+// callWithHandlerErr invokes the service's unary interceptor like
+// callWithMacaroon, but with a handler that fails with handlerErr.
+func callWithHandlerErr(t *testing.T, svc *macaroons.Service,
+	mac *macaroon.Macaroon, method string, req interface{},
+	handlerErr error) error {
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error serializing macaroon: %v", err)
+	}
+	md := metadata.Pairs("macaroon", hex.EncodeToString(macBytes))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	interceptor := svc.UnaryServerInterceptor(testPermissions)
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, handlerErr
+	}
+
+	_, err = interceptor(ctx, req, info, handler)
+	return err
+}
+
+// TestMethodConstraint asserts that a macaroon restricted to a set of
+// methods can only be used to call those methods.
+func TestMethodConstraint(t *testing.T) {
+	svc, mac, cleanUp := setupService(t)
+	defer cleanUp()
+
+	constrained, err := macaroons.AddConstraints(
+		mac, macaroons.MethodConstraint("GetInfo"),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+
+	if err := callWithMacaroon(t, svc, constrained, infoMethod, nil); err != nil {
+		t.Fatalf("Expected GetInfo to be allowed: %v", err)
+	}
+	if err := callWithMacaroon(t, svc, constrained, sendMethod, nil); err == nil {
+		t.Fatalf("Expected SendCoins to be rejected")
+	}
+}
+
+// TestTimeWindowConstraint asserts that a macaroon is only valid within its
+// time window.
+func TestTimeWindowConstraint(t *testing.T) {
+	svc, mac, cleanUp := setupService(t)
+	defer cleanUp()
+
+	now := time.Now()
+	future, err := macaroons.AddConstraints(
+		mac, macaroons.TimeWindowConstraint(
+			now.Add(time.Hour), now.Add(2*time.Hour),
+		),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+	if err := callWithMacaroon(t, svc, future, infoMethod, nil); err == nil {
+		t.Fatalf("Expected macaroon to not be valid yet")
+	}
+
+	current, err := macaroons.AddConstraints(
+		mac, macaroons.TimeWindowConstraint(
+			now.Add(-time.Hour), now.Add(time.Hour),
+		),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+	if err := callWithMacaroon(t, svc, current, infoMethod, nil); err != nil {
+		t.Fatalf("Expected macaroon to be valid: %v", err)
+	}
+
+	// A window that ends before it starts must be rejected.
+	_, err = macaroons.AddConstraints(
+		mac, macaroons.TimeWindowConstraint(now, now.Add(-time.Hour)),
+	)
+	if err == nil {
+		t.Fatalf("Expected invalid time window to be rejected")
+	}
+}
+
+// TestSpendLimitConstraint asserts that a single call can't spend more than
+// the macaroon's spend limit.
+func TestSpendLimitConstraint(t *testing.T) {
+	svc, mac, cleanUp := setupService(t)
+	defer cleanUp()
+
+	constrained, err := macaroons.AddConstraints(
+		mac, macaroons.SpendLimitConstraint(1000),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+
+	err = callWithMacaroon(
+		t, svc, constrained, sendMethod, &spendRequest{amt: 1000},
+	)
+	if err != nil {
+		t.Fatalf("Expected spend within limit to be allowed: %v", err)
+	}
+
+	err = callWithMacaroon(
+		t, svc, constrained, sendMethod, &spendRequest{amt: 1001},
+	)
+	if err == nil {
+		t.Fatalf("Expected spend above limit to be rejected")
+	}
+}
+
+// TestDailySpendConstraint asserts that the cumulative amount spent by a
+// macaroon within a day is bounded by its budget, and that caveats added
+// after the budget caveat don't reset the budget.
+func TestDailySpendConstraint(t *testing.T) {
+	svc, mac, cleanUp := setupService(t)
+	defer cleanUp()
+
+	constrained, err := macaroons.AddConstraints(
+		mac, macaroons.DailySpendConstraint(1000),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+
+	err = callWithMacaroon(
+		t, svc, constrained, sendMethod, &spendRequest{amt: 600},
+	)
+	if err != nil {
+		t.Fatalf("Expected first spend to be allowed: %v", err)
+	}
+
+	// Derive a macaroon with a timeout like lncli does for every call. It
+	// must share the budget of the macaroon it was derived from.
+	derived, err := macaroons.AddConstraints(
+		constrained, macaroons.TimeoutConstraint(60),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+	err = callWithMacaroon(
+		t, svc, derived, sendMethod, &spendRequest{amt: 600},
+	)
+	if err == nil {
+		t.Fatalf("Expected spend above daily budget to be rejected")
+	}
+
+	err = callWithMacaroon(
+		t, svc, derived, sendMethod, &spendRequest{amt: 400},
+	)
+	if err != nil {
+		t.Fatalf("Expected spend within budget to be allowed: %v", err)
+	}
+
+	// Calls that don't spend anything are unaffected by the budget.
+	if err := callWithMacaroon(t, svc, derived, infoMethod, nil); err != nil {
+		t.Fatalf("Expected GetInfo to be allowed: %v", err)
+	}
+}
+
+// TestDailySpendRejectedCall asserts that calls which are rejected by a caveat
+// following the budget caveat, or which fail within the RPC handler, aren't
+// charged against the daily budget.
+func TestDailySpendRejectedCall(t *testing.T) {
+	svc, mac, cleanUp := setupService(t)
+	defer cleanUp()
+
+	constrained, err := macaroons.AddConstraints(
+		mac, macaroons.DailySpendConstraint(1000),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+
+	// Restrict a derived macaroon to GetInfo after the budget caveat, so
+	// that the budget checker approves SendCoins before the method caveat
+	// rejects it.
+	infoOnly, err := macaroons.AddConstraints(
+		constrained, macaroons.MethodConstraint("GetInfo"),
+	)
+	if err != nil {
+		t.Fatalf("Error constraining macaroon: %v", err)
+	}
+	err = callWithMacaroon(
+		t, svc, infoOnly, sendMethod, &spendRequest{amt: 600},
+	)
+	if err == nil {
+		t.Fatalf("Expected SendCoins to be rejected")
+	}
+
+	// A call that fails within the handler must be refunded.
+	handlerErr := errors.New("insufficient funds")
+	err = callWithHandlerErr(
+		t, svc, constrained, sendMethod, &spendRequest{amt: 600},
+		handlerErr,
+	)
+	if err != handlerErr {
+		t.Fatalf("Expected handler error, got: %v", err)
+	}
+
+	// As neither call was charged, the whole budget must still be
+	// available.
+	err = callWithMacaroon(
+		t, svc, constrained, sendMethod, &spendRequest{amt: 1000},
+	)
+	if err != nil {
+		t.Fatalf("Expected spend of full budget to be allowed: %v", err)
+	}
+	err = callWithMacaroon(
+		t, svc, constrained, sendMethod, &spendRequest{amt: 1},
+	)
+	if err == nil {
+		t.Fatalf("Expected spend above daily budget to be rejected")
+	}
+}
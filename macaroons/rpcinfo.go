@@ -0,0 +1,68 @@
+package macaroons
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/roasbeef/btcutil"
+	macaroon "gopkg.in/macaroon.v2"
+)
+
+// rpcInfoKey is the context key under which the rpcInfo of the RPC call
+// currently being authorized is stored.
+type rpcInfoKey struct{}
+
+// rpcInfo carries information about the RPC call being authorized to the
+// caveat checkers.
+type rpcInfo struct {
+	// method is the full gRPC method name of the call, e.g.
+	// "/lnrpc.Lightning/SendCoins".
+	method string
+
+	// req is the request of the call. It is nil for streaming calls until
+	// the first request has been received.
+	req interface{}
+
+	// mac is the macaroon the call is being authorized with. It is set
+	// once the macaroon has been decoded.
+	mac *macaroon.Macaroon
+
+	// charges holds the charges against spending budgets required by the
+	// request, keyed by budget key. They're recorded by the checkers, and
+	// only committed once the macaroon has been fully validated.
+	charges map[string]*budgetCharge
+
+	// committed holds the charges that have been committed to the spend
+	// ledger, so they can be refunded should the call fail.
+	committed []*budgetCharge
+}
+
+// budgetCharge is a charge of a request against a macaroon's daily spending
+// budget.
+type budgetCharge struct {
+	key    []byte
+	amt    btcutil.Amount
+	budget btcutil.Amount
+
+	// at is the time the charge was committed.
+	at time.Time
+}
+
+// withRPCInfo returns a copy of the passed context which carries the given
+// method and request.
+func withRPCInfo(ctx context.Context, method string,
+	req interface{}) context.Context {
+
+	return context.WithValue(ctx, rpcInfoKey{}, &rpcInfo{
+		method: method,
+		req:    req,
+	})
+}
+
+// rpcInfoFromContext returns the rpcInfo attached to the passed context, or
+// nil if there is none.
+func rpcInfoFromContext(ctx context.Context) *rpcInfo {
+	info, _ := ctx.Value(rpcInfoKey{}).(*rpcInfo)
+	return info
+}
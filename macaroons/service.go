@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"path"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -15,6 +16,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 )
 
 var (
@@ -30,6 +32,8 @@ type Service struct {
 	bakery.Bakery
 
 	rks *RootKeyStorage
+
+	spends *SpendLedger
 }
 
 // NewService returns a service backed by the macaroon Bolt DB stored in the
@@ -42,7 +46,7 @@ type Service struct {
 func NewService(dir string, checks ...Checker) (*Service, error) {
 	// Open the database that we'll use to store the primary macaroon key,
 	// and all generated macaroons+caveats.
-	macaroonDB, err := bolt.Open(path.Join(dir, dbFilename), 0600,
+	macaroonDB, err := kvdb.OpenBolt(path.Join(dir, dbFilename), 0600,
 		bolt.DefaultOptions)
 	if err != nil {
 		return nil, err
@@ -53,6 +57,11 @@ func NewService(dir string, checks ...Checker) (*Service, error) {
 		return nil, err
 	}
 
+	spendLedger, err := NewSpendLedger(macaroonDB)
+	if err != nil {
+		return nil, err
+	}
+
 	macaroonParams := bakery.BakeryParams{
 		Location:     "lnd",
 		RootKeyStore: rootKeyStore,
@@ -62,10 +71,21 @@ func NewService(dir string, checks ...Checker) (*Service, error) {
 		Key:     nil,
 	}
 
-	svc := bakery.New(macaroonParams)
+	svc := &Service{
+		Bakery: *bakery.New(macaroonParams),
+		rks:    rootKeyStore,
+		spends: spendLedger,
+	}
 
 	// Register all custom caveat checkers with the bakery's checker.
-	// TODO(aakselrod): Add more checks as required.
+	svc.RegisterCheckers(checks...)
+
+	return svc, nil
+}
+
+// RegisterCheckers registers the passed custom caveat checkers with the
+// bakery's checker. Checkers that have already been registered are skipped.
+func (svc *Service) RegisterCheckers(checks ...Checker) {
 	checker := svc.Checker.FirstPartyCaveatChecker.(*checkers.Checker)
 	for _, check := range checks {
 		cond, fun := check()
@@ -73,8 +93,6 @@ func NewService(dir string, checks ...Checker) (*Service, error) {
 			checker.Register(cond, "std", fun)
 		}
 	}
-
-	return &Service{*svc, rootKeyStore}, nil
 }
 
 // isRegistered checks to see if the required checker has already been
@@ -107,12 +125,23 @@ func (svc *Service) UnaryServerInterceptor(
 				"required for method", info.FullMethod)
 		}
 
+		// Attach the method and request to the context, so that
+		// checkers for method and amount based caveats can inspect
+		// them.
+		ctx = withRPCInfo(ctx, info.FullMethod, req)
 		err := svc.ValidateMacaroon(ctx, permissionMap[info.FullMethod])
 		if err != nil {
 			return nil, err
 		}
 
-		return handler(ctx, req)
+		// If the call fails, then nothing was spent, so we'll refund
+		// any spending budgets it was charged against.
+		resp, err := handler(ctx, req)
+		if err != nil {
+			_ = svc.refundCharges(rpcInfoFromContext(ctx))
+		}
+
+		return resp, err
 	}
 }
 
@@ -129,14 +158,44 @@ func (svc *Service) StreamServerInterceptor(
 				"for method", info.FullMethod)
 		}
 
-		err := svc.ValidateMacaroon(ss.Context(),
-			permissionMap[info.FullMethod])
+		requiredPermissions := permissionMap[info.FullMethod]
+		ctx := withRPCInfo(ss.Context(), info.FullMethod, nil)
+		err := svc.ValidateMacaroon(ctx, requiredPermissions)
 		if err != nil {
 			return err
 		}
 
-		return handler(srv, ss)
+		// The requests of a stream are only known once they're
+		// received, so we'll re-validate the macaroon against each of
+		// them as they arrive.
+		return handler(srv, &validatingStream{
+			ServerStream:        ss,
+			svc:                 svc,
+			method:              info.FullMethod,
+			requiredPermissions: requiredPermissions,
+		})
+	}
+}
+
+// validatingStream wraps a grpc.ServerStream and validates the macaroon of
+// the stream against every request received over it.
+type validatingStream struct {
+	grpc.ServerStream
+
+	svc                 *Service
+	method              string
+	requiredPermissions []bakery.Op
+}
+
+// RecvMsg receives a request from the stream, and validates the stream's
+// macaroon against it before handing it to the caller.
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
 	}
+
+	ctx := withRPCInfo(s.Context(), s.method, m)
+	return s.svc.ValidateMacaroon(ctx, s.requiredPermissions)
 }
 
 // ValidateMacaroon validates the capabilities of a given request given a
@@ -169,11 +228,132 @@ func (svc *Service) ValidateMacaroon(ctx context.Context,
 		return err
 	}
 
+	// Make the macaroon itself available to checkers that need to track
+	// state on a per-macaroon basis.
+	if info := rpcInfoFromContext(ctx); info != nil {
+		info.mac = mac
+	}
+
 	// Check the method being called against the permitted operation and
-	// the expiration time and IP address and return the result.
+	// the expiration time and IP address.
 	authChecker := svc.Checker.Auth(macaroon.Slice{mac})
 	_, err = authChecker.Allow(ctx, requiredPermissions...)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Now that the macaroon has been fully validated, we can commit the
+	// charges against its spending budgets.
+	return svc.commitCharges(rpcInfoFromContext(ctx))
+}
+
+// commitCharges commits the charges against spending budgets recorded while
+// validating a macaroon to the spend ledger. If any of them would exceed its
+// budget, then the charges committed so far are refunded and
+// ErrBudgetExceeded is returned.
+func (svc *Service) commitCharges(info *rpcInfo) error {
+	if info == nil {
+		return nil
+	}
+
+	for _, charge := range info.charges {
+		charge.at = time.Now()
+		err := svc.spends.Charge(
+			charge.key, charge.at, charge.amt, charge.budget,
+		)
+		if err != nil {
+			// The error of the charge takes precedence over
+			// any failure to refund the previous ones.
+			_ = svc.refundCharges(info)
+			return err
+		}
+
+		info.committed = append(info.committed, charge)
+	}
+	info.charges = nil
+
+	return nil
+}
+
+// refundCharges refunds all charges committed for the call described by the
+// passed rpcInfo. Refunding is best effort: a charge that can't be refunded
+// merely leaves the budget more restrictive than necessary, so the first
+// error is only returned once all charges have been attempted.
+func (svc *Service) refundCharges(info *rpcInfo) error {
+	if info == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, charge := range info.committed {
+		err := svc.spends.Refund(charge.key, charge.at, charge.amt)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	info.committed = nil
+
+	return firstErr
+}
+
+// DailySpendChecker returns a `Checker` which ensures that the cumulative
+// amount spent by all requests authorized by a macaroon within the current
+// UTC day doesn't exceed the budget encoded within its dailyspend caveat.
+// The amount of a request is only charged against the budget once the
+// macaroon has been fully validated, and is refunded if the unary call fails.
+// As the outcome of requests received over a stream isn't reported as an
+// error of the call, those remain charged.
+func (svc *Service) DailySpendChecker(reqAmt RequestAmountFunc) Checker {
+	return func() (string, checkers.Func) {
+		return CondDailySpend, func(ctx context.Context, cond,
+			arg string) error {
+
+			budget, err := parseAmountCaveat(arg)
+			if err != nil {
+				return err
+			}
+
+			info := rpcInfoFromContext(ctx)
+			if info == nil || info.mac == nil {
+				return fmt.Errorf("unable to determine macaroon")
+			}
+
+			amt, err := requestAmount(ctx, reqAmt)
+			if err != nil {
+				return err
+			}
+			if amt == 0 {
+				return nil
+			}
+
+			// We'll fail early if the request exceeds the budget,
+			// but only record the charge for now, as the remaining
+			// caveats may still reject the request. The charge is
+			// committed once the macaroon has been fully validated.
+			key := budgetKey(info.mac, checkers.Condition(cond, arg))
+			spent, err := svc.spends.Spent(key, time.Now())
+			if err != nil {
+				return err
+			}
+			if spent+amt > budget {
+				return ErrBudgetExceeded
+			}
+
+			// The checker may be consulted more than once for the
+			// same request, so we make sure to only charge the
+			// budget once.
+			if info.charges == nil {
+				info.charges = make(map[string]*budgetCharge)
+			}
+			info.charges[string(key)] = &budgetCharge{
+				key:    key,
+				amt:    amt,
+				budget: budget,
+			}
+
+			return nil
+		}
+	}
 }
 
 // Close closes the database that underlies the RootKeyStore and zeroes the
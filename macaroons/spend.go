@@ -0,0 +1,165 @@
+package macaroons
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/roasbeef/btcutil"
+
+	macaroon "gopkg.in/macaroon.v2"
+)
+
+var (
+	// spendBucketName is the name of the bucket which tracks the amount
+	// spent by macaroons carrying a dailyspend caveat. Keys are the
+	// 32-byte budget key followed by the big endian UTC day number, and
+	// values are the amount spent on that day in satoshis.
+	spendBucketName = []byte("macspend")
+
+	// ErrBudgetExceeded is returned when a request would exceed the daily
+	// spending budget of a macaroon.
+	ErrBudgetExceeded = fmt.Errorf("macaroon daily spend budget exceeded")
+)
+
+// SpendLedger keeps track of the cumulative amount spent per macaroon and
+// day within the macaroon database.
+type SpendLedger struct {
+	db kvdb.Backend
+}
+
+// NewSpendLedger creates a SpendLedger backed by the passed database.
+func NewSpendLedger(db kvdb.Backend) (*SpendLedger, error) {
+	// If the ledger's bucket doesn't exist, create it.
+	err := db.Update(func(tx kvdb.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(spendBucketName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpendLedger{db: db}, nil
+}
+
+// Charge adds amt to the amount spent under the passed budget key on the UTC
+// day of now. If the new total would exceed budget, ErrBudgetExceeded is
+// returned and nothing is recorded. Entries of previous days are removed as
+// they're no longer needed.
+func (s *SpendLedger) Charge(key []byte, now time.Time, amt,
+	budget btcutil.Amount) error {
+
+	dayKey := spendDayKey(key, now)
+
+	return s.db.Update(func(tx kvdb.Tx) error {
+		bucket := tx.Bucket(spendBucketName)
+
+		// Remove all entries of earlier days for this key. As the day
+		// is encoded in big endian, they sort before today's entry.
+		var stale [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.Seek(key); k != nil && bytes.HasPrefix(k, key); k, _ = c.Next() {
+			if bytes.Compare(k, dayKey) < 0 {
+				stale = append(stale, k)
+			}
+		}
+		for _, k := range stale {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		var spent btcutil.Amount
+		if v := bucket.Get(dayKey); len(v) == 8 {
+			spent = btcutil.Amount(binary.BigEndian.Uint64(v))
+		}
+
+		if spent+amt > budget {
+			return ErrBudgetExceeded
+		}
+
+		var v [8]byte
+		binary.BigEndian.PutUint64(v[:], uint64(spent+amt))
+		return bucket.Put(dayKey, v[:])
+	})
+}
+
+// Refund subtracts amt from the amount spent under the passed budget key on
+// the UTC day of chargedAt, undoing an earlier charge. Charges of previous
+// days no longer count towards the budget, so refunding them is a no-op.
+func (s *SpendLedger) Refund(key []byte, chargedAt time.Time,
+	amt btcutil.Amount) error {
+
+	dayKey := spendDayKey(key, chargedAt)
+
+	return s.db.Update(func(tx kvdb.Tx) error {
+		bucket := tx.Bucket(spendBucketName)
+
+		v := bucket.Get(dayKey)
+		if len(v) != 8 {
+			return nil
+		}
+
+		spent := btcutil.Amount(binary.BigEndian.Uint64(v))
+		if amt >= spent {
+			return bucket.Delete(dayKey)
+		}
+
+		var newV [8]byte
+		binary.BigEndian.PutUint64(newV[:], uint64(spent-amt))
+		return bucket.Put(dayKey, newV[:])
+	})
+}
+
+// Spent returns the amount spent under the passed budget key on the UTC day
+// of now.
+func (s *SpendLedger) Spent(key []byte, now time.Time) (btcutil.Amount,
+	error) {
+
+	var spent btcutil.Amount
+	err := s.db.View(func(tx kvdb.Tx) error {
+		v := tx.Bucket(spendBucketName).Get(spendDayKey(key, now))
+		if len(v) == 8 {
+			spent = btcutil.Amount(binary.BigEndian.Uint64(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return spent, nil
+}
+
+// spendDayKey returns the ledger key for the passed budget key and the UTC
+// day of now.
+func spendDayKey(key []byte, now time.Time) []byte {
+	day := uint32(now.UTC().Unix() / int64(24*time.Hour/time.Second))
+
+	dayKey := make([]byte, len(key)+4)
+	copy(dayKey, key)
+	binary.BigEndian.PutUint32(dayKey[len(key):], day)
+	return dayKey
+}
+
+// budgetKey derives the key a macaroon's spending is tracked under. It
+// commits to the macaroon's ID and all of its caveats up to and including
+// the budget caveat itself. Caveats added afterwards, such as the short-lived
+// timeouts lncli adds to every call, therefore don't change the key, while
+// two macaroons derived from the same root with different budgets are
+// tracked separately.
+func budgetKey(mac *macaroon.Macaroon, budgetCaveat string) []byte {
+	h := sha256.New()
+	h.Write(mac.Id())
+	for _, caveat := range mac.Caveats() {
+		h.Write(caveat.Id)
+		if string(caveat.Id) == budgetCaveat {
+			break
+		}
+	}
+
+	return h.Sum(nil)
+}
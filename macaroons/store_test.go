@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 
 	"github.com/lightningnetwork/lnd/macaroons"
 
@@ -21,7 +22,7 @@ func TestStore(t *testing.T) {
 	}
 	defer os.RemoveAll(tempDir)
 
-	db, err := bolt.Open(path.Join(tempDir, "weks.db"), 0600,
+	db, err := kvdb.OpenBolt(path.Join(tempDir, "weks.db"), 0600,
 		bolt.DefaultOptions)
 	if err != nil {
 		t.Fatalf("Error opening store DB: %v", err)
@@ -75,7 +76,7 @@ func TestStore(t *testing.T) {
 	// Between here and the re-opening of the store, it's possible to get
 	// a double-close, but that's not such a big deal since the tests will
 	// fail anyway in that case.
-	db, err = bolt.Open(path.Join(tempDir, "weks.db"), 0600,
+	db, err = kvdb.OpenBolt(path.Join(tempDir, "weks.db"), 0600,
 		bolt.DefaultOptions)
 	if err != nil {
 		t.Fatalf("Error opening store DB: %v", err)
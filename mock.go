@@ -161,15 +161,19 @@ func (m *mockSpendNotifier) Spend(outpoint *wire.OutPoint, height int32,
 	}
 }
 
-type mockChainIO struct{}
+type mockChainIO struct {
+	// utxos is the set of outputs known to the chain. If nil, GetUtxo
+	// won't find any outputs.
+	utxos map[wire.OutPoint]*wire.TxOut
+}
 
 func (*mockChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
 	return activeNetParams.GenesisHash, fundingBroadcastHeight, nil
 }
 
-func (*mockChainIO) GetUtxo(op *wire.OutPoint,
+func (m *mockChainIO) GetUtxo(op *wire.OutPoint,
 	heightHint uint32) (*wire.TxOut, error) {
-	return nil, nil
+	return m.utxos[*op], nil
 }
 
 func (*mockChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
@@ -186,6 +190,15 @@ type mockWalletController struct {
 	rootKey               *btcec.PrivateKey
 	prevAddres            btcutil.Address
 	publishedTransactions chan *wire.MsgTx
+
+	// utxos are the outputs owned by the wallet. If nil, the wallet will
+	// claim ownership of a single dummy output.
+	utxos []*lnwallet.Utxo
+
+	// lockedOutpoints are the outputs within utxos that have been locked
+	// by coin selection, and are therefore no longer listed as unspent.
+	lockedOutpoints map[wire.OutPoint]struct{}
+	lockMtx         sync.Mutex
 }
 
 // BackEnd returns "mock" to signify a mock wallet controller.
@@ -195,8 +208,20 @@ func (*mockWalletController) BackEnd() string {
 
 // FetchInputInfo will be called to get info about the inputs to the funding
 // transaction.
-func (*mockWalletController) FetchInputInfo(
+func (m *mockWalletController) FetchInputInfo(
 	prevOut *wire.OutPoint) (*wire.TxOut, error) {
+	if m.utxos != nil {
+		for _, utxo := range m.utxos {
+			if utxo.OutPoint == *prevOut {
+				return wire.NewTxOut(
+					int64(utxo.Value), utxo.PkScript,
+				), nil
+			}
+		}
+
+		return nil, lnwallet.ErrNotMine
+	}
+
 	txOut := &wire.TxOut{
 		Value:    int64(10 * btcutil.SatoshiPerBitcoin),
 		PkScript: []byte("dummy"),
@@ -210,6 +235,13 @@ func (*mockWalletController) ConfirmedBalance(confs int32) (btcutil.Amount, erro
 // NewAddress is called to get new addresses for delivery, change etc.
 func (m *mockWalletController) NewAddress(addrType lnwallet.AddressType,
 	change bool) (btcutil.Address, error) {
+	if addrType == lnwallet.WitnessPubKey && m.utxos != nil {
+		return btcutil.NewAddressWitnessPubKeyHash(
+			btcutil.Hash160(m.rootKey.PubKey().SerializeCompressed()),
+			&chaincfg.MainNetParams,
+		)
+	}
+
 	addr, _ := btcutil.NewAddressPubKey(
 		m.rootKey.PubKey().SerializeCompressed(), &chaincfg.MainNetParams)
 	return addr, nil
@@ -226,7 +258,22 @@ func (*mockWalletController) SendOutputs(outputs []*wire.TxOut,
 
 // ListUnspentWitness is called by the wallet when doing coin selection. We just
 // need one unspent for the funding transaction.
-func (*mockWalletController) ListUnspentWitness(confirms int32) ([]*lnwallet.Utxo, error) {
+func (m *mockWalletController) ListUnspentWitness(confirms int32) ([]*lnwallet.Utxo, error) {
+	if m.utxos != nil {
+		m.lockMtx.Lock()
+		defer m.lockMtx.Unlock()
+
+		var unlocked []*lnwallet.Utxo
+		for _, utxo := range m.utxos {
+			if _, ok := m.lockedOutpoints[utxo.OutPoint]; ok {
+				continue
+			}
+			unlocked = append(unlocked, utxo)
+		}
+
+		return unlocked, nil
+	}
+
 	utxo := &lnwallet.Utxo{
 		AddressType: lnwallet.WitnessPubKey,
 		Value:       btcutil.Amount(10 * btcutil.SatoshiPerBitcoin),
@@ -243,8 +290,21 @@ func (*mockWalletController) ListUnspentWitness(confirms int32) ([]*lnwallet.Utx
 func (*mockWalletController) ListTransactionDetails() ([]*lnwallet.TransactionDetail, error) {
 	return nil, nil
 }
-func (*mockWalletController) LockOutpoint(o wire.OutPoint)   {}
-func (*mockWalletController) UnlockOutpoint(o wire.OutPoint) {}
+func (m *mockWalletController) LockOutpoint(o wire.OutPoint) {
+	m.lockMtx.Lock()
+	defer m.lockMtx.Unlock()
+
+	if m.lockedOutpoints == nil {
+		m.lockedOutpoints = make(map[wire.OutPoint]struct{})
+	}
+	m.lockedOutpoints[o] = struct{}{}
+}
+func (m *mockWalletController) UnlockOutpoint(o wire.OutPoint) {
+	m.lockMtx.Lock()
+	defer m.lockMtx.Unlock()
+
+	delete(m.lockedOutpoints, o)
+}
 func (m *mockWalletController) PublishTransaction(tx *wire.MsgTx) error {
 	m.publishedTransactions <- tx
 	return nil
@@ -157,6 +157,23 @@ type peer struct {
 	// peer during the connection handshake.
 	remoteLocalFeatures *lnwire.FeatureVector
 
+	// largeChannels is set to 1 if both we and the peer signalled support
+	// for channels above the regular channel size limit during the
+	// connection handshake. It's set once the handshake completes, which
+	// may be after the peer has been published to the server, so it MUST
+	// be accessed atomically.
+	largeChannels int32
+
+	// upfrontShutdown is set to 1 if the peer signalled support for
+	// upfront shutdown scripts during the connection handshake. Like
+	// largeChannels, it MUST be accessed atomically.
+	upfrontShutdown int32
+
+	// dualFunding is set to 1 if the peer signalled support for dual
+	// funded channels during the connection handshake. Like
+	// largeChannels, it MUST be accessed atomically.
+	dualFunding int32
+
 	// remoteGlobalFeatures is the global feature vector received from the
 	// peer during the connection handshake.
 	remoteGlobalFeatures *lnwire.FeatureVector
@@ -505,6 +522,18 @@ func (p *peer) readNextMessage() (lnwire.Message, error) {
 	msgReader := bytes.NewReader(rawMsg)
 	nextMsg, err := lnwire.ReadMessage(msgReader, 0)
 	if err != nil {
+		// Messages we don't yet understand are tolerated, while any
+		// other message that fails to decode is malformed, which
+		// counts towards the peer's ban score.
+		switch err.(type) {
+		case *lnwire.UnknownMessage, *lnwire.ErrUnknownAddrType:
+		default:
+			p.server.peerMisbehaved(
+				p.addr.IdentityKey, protocolViolationBanScore,
+				fmt.Sprintf("malformed message: %v", err),
+			)
+		}
+
 		return nil, err
 	}
 
@@ -796,7 +825,8 @@ out:
 			// sent the ping message to measure a rough estimate of
 			// round trip time.
 			pingSendTime := atomic.LoadInt64(&p.pingLastSend)
-			delay := (time.Now().UnixNano() - pingSendTime) / 1000
+			now := p.server.clock.Now().UnixNano()
+			delay := (now - pingSendTime) / 1000
 			atomic.StoreInt64(&p.pingTime, delay)
 
 		case *lnwire.Ping:
@@ -813,6 +843,9 @@ out:
 			p.server.fundingMgr.processFundingSigned(msg, p.addr)
 		case *lnwire.FundingLocked:
 			p.server.fundingMgr.processFundingLocked(msg, p.addr)
+		case *lnwire.TxAddInput, *lnwire.TxAddOutput,
+			*lnwire.TxComplete, *lnwire.TxSignatures:
+			p.server.fundingMgr.processInteractiveTxMsg(msg, p.addr)
 
 		case *lnwire.Shutdown:
 			select {
@@ -896,6 +929,11 @@ out:
 		default:
 			peerLog.Errorf("unknown message %v received from peer "+
 				"%v", uint16(msg.MsgType()), p)
+
+			p.server.peerMisbehaved(
+				p.addr.IdentityKey, protocolViolationBanScore,
+				fmt.Sprintf("unexpected message %v", msg.MsgType()),
+			)
 		}
 
 		if isChanUpdate {
@@ -964,6 +1002,21 @@ func messageSummary(msg lnwire.Message) string {
 		return fmt.Sprintf("chan_id=%v, next_point=%x",
 			msg.ChanID, msg.NextPerCommitmentPoint.SerializeCompressed())
 
+	case *lnwire.TxAddInput:
+		return fmt.Sprintf("temp_chan_id=%x, serial_id=%v, prev_out=%v",
+			msg.ChanID[:], msg.SerialID, msg.PrevOut)
+
+	case *lnwire.TxAddOutput:
+		return fmt.Sprintf("temp_chan_id=%x, serial_id=%v, amt=%v",
+			msg.ChanID[:], msg.SerialID, msg.Amount)
+
+	case *lnwire.TxComplete:
+		return fmt.Sprintf("temp_chan_id=%x", msg.ChanID[:])
+
+	case *lnwire.TxSignatures:
+		return fmt.Sprintf("temp_chan_id=%x, txid=%v, num_witnesses=%v",
+			msg.ChanID[:], msg.TxHash, len(msg.Witnesses))
+
 	case *lnwire.Shutdown:
 		return fmt.Sprintf("chan_id=%v, script=%x", msg.ChannelID,
 			msg.Address[:])
@@ -1009,10 +1062,10 @@ func messageSummary(msg lnwire.Message) string {
 			msg.ChainHash, msg.ShortChannelID.ToUint64())
 
 	case *lnwire.ChannelUpdate:
-		return fmt.Sprintf("chain_hash=%v, short_chan_id=%v, flag=%v, "+
-			"update_time=%v", msg.ChainHash,
-			msg.ShortChannelID.ToUint64(), msg.Flags,
-			time.Unix(int64(msg.Timestamp), 0))
+		return fmt.Sprintf("chain_hash=%v, short_chan_id=%v, "+
+			"mflags=%v, cflags=%v, update_time=%v", msg.ChainHash,
+			msg.ShortChannelID.ToUint64(), msg.MessageFlags,
+			msg.ChannelFlags, time.Unix(int64(msg.Timestamp), 0))
 
 	case *lnwire.NodeAnnouncement:
 		return fmt.Sprintf("node=%x, update_time=%v",
@@ -1149,7 +1202,7 @@ out:
 			case *lnwire.Ping:
 				// TODO(roasbeef): do this before the write?
 				// possibly account for processing within func?
-				now := time.Now().UnixNano()
+				now := p.server.clock.Now().UnixNano()
 				atomic.StoreInt64(&p.pingLastSend, now)
 			}
 
@@ -1230,8 +1283,7 @@ func (p *peer) queueHandler() {
 func (p *peer) pingHandler() {
 	defer p.wg.Done()
 
-	pingTicker := time.NewTicker(pingInterval)
-	defer pingTicker.Stop()
+	pingTicker := p.server.clock.TickAfter(pingInterval)
 
 	// TODO(roasbeef): make dynamic in order to create fake cover traffic
 	const numPingBytes = 16
@@ -1239,7 +1291,8 @@ func (p *peer) pingHandler() {
 out:
 	for {
 		select {
-		case <-pingTicker.C:
+		case <-pingTicker:
+			pingTicker = p.server.clock.TickAfter(pingInterval)
 			p.queueMsg(lnwire.NewPing(numPingBytes), nil)
 		case <-p.quit:
 			break out
@@ -1303,6 +1356,21 @@ func (p *peer) genDeliveryScript() ([]byte, error) {
 	return txscript.PayToAddrScript(deliveryAddr)
 }
 
+// chooseDeliveryScript returns the script to send our funds to in the case of
+// a cooperative close of the passed channel. If we committed to a script when
+// opening the channel, then we must use it. Otherwise, a fresh script is
+// generated.
+func (p *peer) chooseDeliveryScript(
+	channel *lnwallet.LightningChannel) ([]byte, error) {
+
+	upfrontScript := channel.State().LocalShutdownScript
+	if len(upfrontScript) != 0 {
+		return upfrontScript, nil
+	}
+
+	return p.genDeliveryScript()
+}
+
 // channelManager is goroutine dedicated to handling all requests/signals
 // pertaining to the opening, cooperative closing, and force closing of all
 // channels maintained with the remote peer.
@@ -1543,7 +1611,7 @@ func (p *peer) fetchActiveChanCloser(chanID lnwire.ChannelID) (*channelCloser, e
 
 		// We'll create a valid closing state machine in order to
 		// respond to the initiated cooperative channel closure.
-		deliveryAddr, err := p.genDeliveryScript()
+		deliveryAddr, err := p.chooseDeliveryScript(channel)
 		if err != nil {
 			peerLog.Errorf("unable to gen delivery script: %v", err)
 
@@ -1627,10 +1695,9 @@ func (p *peer) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 	// out this channel on-chain, so we execute the cooperative channel
 	// closure workflow.
 	case htlcswitch.CloseRegular:
-		// First, we'll fetch a fresh delivery address that we'll use
-		// to send the funds to in the case of a successful
-		// negotiation.
-		deliveryAddr, err := p.genDeliveryScript()
+		// First, we'll fetch the delivery address that we'll use to
+		// send the funds to in the case of a successful negotiation.
+		deliveryAddr, err := p.chooseDeliveryScript(channel)
 		if err != nil {
 			peerLog.Errorf(err.Error())
 			req.Err <- err
@@ -1868,9 +1935,45 @@ func (p *peer) handleInitMsg(msg *lnwire.Init) error {
 		return err
 	}
 
+	// Channels above the regular size limit may only be opened with the
+	// peer if we've both signalled support for them.
+	if p.localFeatures.IsSet(lnwire.LargeChannelsOptional) &&
+		p.remoteLocalFeatures.HasFeature(lnwire.LargeChannelsOptional) {
+
+		atomic.StoreInt32(&p.largeChannels, 1)
+	}
+
+	if p.remoteLocalFeatures.HasFeature(
+		lnwire.UpfrontShutdownScriptOptional) {
+
+		atomic.StoreInt32(&p.upfrontShutdown, 1)
+	}
+
+	if p.remoteLocalFeatures.HasFeature(lnwire.DualFundingOptional) {
+		atomic.StoreInt32(&p.dualFunding, 1)
+	}
+
 	return nil
 }
 
+// supportsLargeChannels returns true if both we and the peer signalled
+// support for channels above the regular channel size limit.
+func (p *peer) supportsLargeChannels() bool {
+	return atomic.LoadInt32(&p.largeChannels) == 1
+}
+
+// supportsUpfrontShutdown returns true if the peer signalled support for
+// upfront shutdown scripts.
+func (p *peer) supportsUpfrontShutdown() bool {
+	return atomic.LoadInt32(&p.upfrontShutdown) == 1
+}
+
+// supportsDualFunding returns true if the peer signalled support for dual
+// funded channels.
+func (p *peer) supportsDualFunding() bool {
+	return atomic.LoadInt32(&p.dualFunding) == 1
+}
+
 // sendInitMsg sends init message to remote peer which contains our currently
 // supported local and global features.
 func (p *peer) sendInitMsg() error {
@@ -1938,9 +2041,11 @@ func fetchLastChanUpdate(router *routing.ChannelRouter,
 			ChainHash:       info.ChainHash,
 			ShortChannelID:  lnwire.NewShortChanIDFromInt(local.ChannelID),
 			Timestamp:       uint32(local.LastUpdate.Unix()),
-			Flags:           local.Flags,
+			MessageFlags:    local.MessageFlags,
+			ChannelFlags:    local.ChannelFlags,
 			TimeLockDelta:   local.TimeLockDelta,
 			HtlcMinimumMsat: local.MinHTLC,
+			HtlcMaximumMsat: local.MaxHTLC,
 			BaseFee:         uint32(local.FeeBaseMSat),
 			FeeRate:         uint32(local.FeeProportionalMillionths),
 		}
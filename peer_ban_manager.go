@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// invalidGossipBanScore is the misbehavior score a peer accrues for
+	// each announcement it sends us that fails validation.
+	invalidGossipBanScore = 10
+
+	// protocolViolationBanScore is the misbehavior score a peer accrues
+	// for each message it sends us that violates the wire protocol.
+	protocolViolationBanScore = 50
+
+	// defaultBanScoreDecayInterval is the interval after which a single
+	// point of a peer's misbehavior score is forgiven.
+	defaultBanScoreDecayInterval = time.Minute
+)
+
+// peerBanConfig houses the set of parameters and functions the
+// peerBanManager requires to carry out its duties.
+type peerBanConfig struct {
+	// DB is the database in which the misbehavior score and ban status of
+	// each peer are persisted.
+	DB *channeldb.DB
+
+	// BanThreshold is the misbehavior score at which a peer gets banned.
+	BanThreshold uint32
+
+	// BanDuration is the duration for which a peer remains banned.
+	BanDuration time.Duration
+
+	// ScoreDecayInterval is the interval after which a single point of a
+	// peer's misbehavior score is forgiven, so only peers that misbehave
+	// repeatedly within a short period of time get banned. If zero,
+	// scores never decay.
+	ScoreDecayInterval time.Duration
+
+	// Clock is the time source used to timestamp misbehavior and bans.
+	Clock clock.Clock
+
+	// DisconnectPeer disconnects the target peer once it's banned.
+	DisconnectPeer func(*btcec.PublicKey) error
+
+	// HasActiveChannels returns true if we have any pending or open
+	// channels with the target peer. Such peers are never banned, as we
+	// need to remain connected to them in order to operate our channels.
+	HasActiveChannels func(*btcec.PublicKey) (bool, error)
+}
+
+// peerBanManager keeps track of the misbehavior of our Lightning peers, such
+// as sending invalid gossip or violating the wire protocol. Each misbehavior
+// adds to the peer's score, and once it crosses the configured threshold the
+// peer is disconnected and banned, causing us to refuse any connections with
+// it until the ban expires or is lifted.
+type peerBanManager struct {
+	cfg *peerBanConfig
+
+	// mu serializes the updates of peer scores.
+	mu sync.Mutex
+}
+
+// newPeerBanManager creates a new peerBanManager from the passed config.
+func newPeerBanManager(cfg *peerBanConfig) *peerBanManager {
+	return &peerBanManager{
+		cfg: cfg,
+	}
+}
+
+// Misbehaved adds the passed score to the misbehavior score of the target
+// peer. If the score reaches the ban threshold, the peer is banned and
+// disconnected, and its score is reset. True is returned if the peer is
+// banned. Peers we have active channels with are exempt from banning.
+func (m *peerBanManager) Misbehaved(peerKey *btcec.PublicKey, score uint32,
+	reason string) (bool, error) {
+
+	var pubKey [33]byte
+	copy(pubKey[:], peerKey.SerializeCompressed())
+
+	hasChannels, err := m.cfg.HasActiveChannels(peerKey)
+	if err != nil {
+		return false, err
+	}
+	if hasChannels {
+		srvrLog.Debugf("Not scoring misbehavior of peer %x with active "+
+			"channels: %v", pubKey[:], reason)
+		return false, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.cfg.Clock.Now()
+
+	ban, err := m.cfg.DB.FetchPeerBan(pubKey)
+	switch {
+	case err == channeldb.ErrPeerBanNotFound:
+		ban = &channeldb.PeerBan{
+			PubKey: pubKey,
+		}
+
+	case err != nil:
+		return false, err
+	}
+
+	// A peer that's already banned shouldn't be connected, so there's no
+	// need to extend its ban.
+	if ban.IsBanned(now) {
+		return true, nil
+	}
+
+	ban.Score = m.decayedScore(ban, now) + score
+	ban.LastUpdate = now
+	ban.Reason = reason
+
+	banned := ban.Score >= m.cfg.BanThreshold
+	if banned {
+		ban.Score = 0
+		ban.BannedUntil = now.Add(m.cfg.BanDuration)
+	}
+
+	if err := m.cfg.DB.PutPeerBan(ban); err != nil {
+		return false, err
+	}
+
+	if !banned {
+		srvrLog.Debugf("Misbehavior score of peer %x increased to %v: "+
+			"%v", pubKey[:], ban.Score, reason)
+		return false, nil
+	}
+
+	srvrLog.Warnf("Banning peer %x until %v: %v", pubKey[:],
+		ban.BannedUntil, reason)
+
+	// We'll disconnect the peer in a goroutine, as we may have been
+	// called from one of the peer's own goroutines.
+	go func() {
+		if err := m.cfg.DisconnectPeer(peerKey); err != nil {
+			srvrLog.Debugf("Unable to disconnect banned peer %x: %v",
+				pubKey[:], err)
+		}
+	}()
+
+	return true, nil
+}
+
+// decayedScore returns the misbehavior score of the passed ban record at the
+// given time, after forgiving a point for each decay interval that passed
+// since it was last updated.
+func (m *peerBanManager) decayedScore(ban *channeldb.PeerBan,
+	now time.Time) uint32 {
+
+	if m.cfg.ScoreDecayInterval == 0 || ban.LastUpdate.IsZero() {
+		return ban.Score
+	}
+
+	elapsed := now.Sub(ban.LastUpdate)
+	if elapsed <= 0 {
+		return ban.Score
+	}
+
+	decay := uint64(elapsed / m.cfg.ScoreDecayInterval)
+	if decay >= uint64(ban.Score) {
+		return 0
+	}
+
+	return ban.Score - uint32(decay)
+}
+
+// IsBanned returns true if the target peer is currently banned. Peers we have
+// active channels with are never considered banned.
+func (m *peerBanManager) IsBanned(peerKey *btcec.PublicKey) bool {
+	var pubKey [33]byte
+	copy(pubKey[:], peerKey.SerializeCompressed())
+
+	ban, err := m.cfg.DB.FetchPeerBan(pubKey)
+	switch {
+	case err == channeldb.ErrPeerBanNotFound:
+		return false
+
+	case err != nil:
+		srvrLog.Errorf("Unable to fetch ban of peer %x: %v", pubKey[:],
+			err)
+		return false
+	}
+
+	if !ban.IsBanned(m.cfg.Clock.Now()) {
+		return false
+	}
+
+	hasChannels, err := m.cfg.HasActiveChannels(peerKey)
+	if err != nil {
+		srvrLog.Errorf("Unable to fetch channels of peer %x: %v",
+			pubKey[:], err)
+		return true
+	}
+
+	return !hasChannels
+}
+
+// BannedPeers returns the ban records of all peers that are currently banned.
+func (m *peerBanManager) BannedPeers() ([]*channeldb.PeerBan, error) {
+	bans, err := m.cfg.DB.FetchPeerBans()
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.cfg.Clock.Now()
+
+	var banned []*channeldb.PeerBan
+	for _, ban := range bans {
+		if ban.IsBanned(now) {
+			banned = append(banned, ban)
+		}
+	}
+
+	return banned, nil
+}
+
+// Unban lifts the ban of the target peer, and clears its misbehavior score.
+func (m *peerBanManager) Unban(peerKey *btcec.PublicKey) error {
+	var pubKey [33]byte
+	copy(pubKey[:], peerKey.SerializeCompressed())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err := m.cfg.DB.DeletePeerBan(pubKey)
+	if err == channeldb.ErrPeerBanNotFound {
+		return fmt.Errorf("peer %x is not banned", pubKey[:])
+	}
+
+	return err
+}
@@ -0,0 +1,254 @@
+// +build !rpctest
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestPeerBanManager asserts that a peer is banned and disconnected once its
+// misbehavior score crosses the threshold, that the ban expires after the
+// configured duration, and that it can be lifted early.
+func TestPeerBanManager(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "peerbans")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peerKey := priv.PubKey()
+
+	const banDuration = time.Hour
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	disconnected := make(chan *btcec.PublicKey, 1)
+	m := newPeerBanManager(&peerBanConfig{
+		DB:           db,
+		BanThreshold: 100,
+		BanDuration:  banDuration,
+		Clock:        testClock,
+		DisconnectPeer: func(pub *btcec.PublicKey) error {
+			disconnected <- pub
+			return nil
+		},
+		HasActiveChannels: func(*btcec.PublicKey) (bool, error) {
+			return false, nil
+		},
+	})
+
+	// Misbehavior below the threshold shouldn't get the peer banned.
+	banned, err := m.Misbehaved(peerKey, 60, "first")
+	if err != nil {
+		t.Fatalf("unable to record misbehavior: %v", err)
+	}
+	if banned || m.IsBanned(peerKey) {
+		t.Fatal("peer banned below threshold")
+	}
+
+	// Crossing the threshold should ban and disconnect the peer.
+	banned, err = m.Misbehaved(peerKey, 40, "second")
+	if err != nil {
+		t.Fatalf("unable to record misbehavior: %v", err)
+	}
+	if !banned || !m.IsBanned(peerKey) {
+		t.Fatal("peer not banned once threshold was crossed")
+	}
+
+	select {
+	case pub := <-disconnected:
+		if !pub.IsEqual(peerKey) {
+			t.Fatalf("disconnected unexpected peer %x",
+				pub.SerializeCompressed())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("banned peer wasn't disconnected")
+	}
+
+	bans, err := m.BannedPeers()
+	if err != nil {
+		t.Fatalf("unable to fetch banned peers: %v", err)
+	}
+	if len(bans) != 1 || bans[0].Reason != "second" {
+		t.Fatalf("unexpected banned peers: %v", bans)
+	}
+
+	// Once the ban expires, the peer should be allowed back.
+	testClock.Advance(banDuration)
+	if m.IsBanned(peerKey) {
+		t.Fatal("peer still banned after ban expired")
+	}
+
+	// Its score should have been reset when it was banned, so it should
+	// take another 100 points to get banned again.
+	if banned, _ := m.Misbehaved(peerKey, 60, "third"); banned {
+		t.Fatal("peer banned below threshold")
+	}
+	if banned, _ := m.Misbehaved(peerKey, 40, "fourth"); !banned {
+		t.Fatal("peer not banned once threshold was crossed")
+	}
+	<-disconnected
+
+	// Lifting the ban should allow the peer back immediately.
+	if err := m.Unban(peerKey); err != nil {
+		t.Fatalf("unable to unban peer: %v", err)
+	}
+	if m.IsBanned(peerKey) {
+		t.Fatal("peer still banned after being unbanned")
+	}
+	if err := m.Unban(peerKey); err == nil {
+		t.Fatal("expected unbanning peer twice to fail")
+	}
+}
+
+// newTestPeerBanManager creates a peerBanManager backed by a fresh database,
+// banning peers once their score reaches 100. Peers are considered to have
+// active channels with us if hasChannels returns true.
+func newTestPeerBanManager(t *testing.T, testClock clock.Clock,
+	decayInterval time.Duration,
+	hasChannels func() bool) (*peerBanManager, func()) {
+
+	tempDir, err := ioutil.TempDir("", "peerbans")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+
+	m := newPeerBanManager(&peerBanConfig{
+		DB:                 db,
+		BanThreshold:       100,
+		BanDuration:        time.Hour,
+		ScoreDecayInterval: decayInterval,
+		Clock:              testClock,
+		DisconnectPeer: func(*btcec.PublicKey) error {
+			return nil
+		},
+		HasActiveChannels: func(*btcec.PublicKey) (bool, error) {
+			return hasChannels(), nil
+		},
+	})
+
+	cleanUp := func() {
+		db.Close()
+		os.RemoveAll(tempDir)
+	}
+
+	return m, cleanUp
+}
+
+// TestPeerBanManagerScoreDecay asserts that misbehavior scores decay over
+// time, so a peer only gets banned if it misbehaves repeatedly within a short
+// period of time.
+func TestPeerBanManagerScoreDecay(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	m, cleanUp := newTestPeerBanManager(
+		t, testClock, time.Minute, func() bool { return false },
+	)
+	defer cleanUp()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peerKey := priv.PubKey()
+
+	if banned, _ := m.Misbehaved(peerKey, 60, "first"); banned {
+		t.Fatal("peer banned below threshold")
+	}
+
+	// After 30 minutes, half of the score has been forgiven, so the next
+	// misbehavior only brings the score up to 70.
+	testClock.Advance(30 * time.Minute)
+	if banned, _ := m.Misbehaved(peerKey, 40, "second"); banned {
+		t.Fatal("peer banned although its score decayed")
+	}
+
+	// Misbehaving again right away crosses the threshold.
+	if banned, _ := m.Misbehaved(peerKey, 30, "third"); !banned {
+		t.Fatal("peer not banned once threshold was crossed")
+	}
+	if !m.IsBanned(peerKey) {
+		t.Fatal("peer not banned")
+	}
+
+	// A score never decays below zero.
+	if err := m.Unban(peerKey); err != nil {
+		t.Fatalf("unable to unban peer: %v", err)
+	}
+	if banned, _ := m.Misbehaved(peerKey, 10, "fourth"); banned {
+		t.Fatal("peer banned below threshold")
+	}
+	testClock.Advance(24 * time.Hour)
+	if banned, _ := m.Misbehaved(peerKey, 99, "fifth"); banned {
+		t.Fatal("peer banned although its score decayed")
+	}
+}
+
+// TestPeerBanManagerActiveChannels asserts that peers we have active channels
+// with are never banned, nor considered banned.
+func TestPeerBanManagerActiveChannels(t *testing.T) {
+	t.Parallel()
+
+	var hasChannels bool
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	m, cleanUp := newTestPeerBanManager(
+		t, testClock, 0, func() bool { return hasChannels },
+	)
+	defer cleanUp()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peerKey := priv.PubKey()
+
+	// Misbehavior of a peer we have channels with isn't scored at all.
+	hasChannels = true
+	banned, err := m.Misbehaved(peerKey, 100, "channel peer")
+	if err != nil {
+		t.Fatalf("unable to record misbehavior: %v", err)
+	}
+	if banned || m.IsBanned(peerKey) {
+		t.Fatal("peer with active channels banned")
+	}
+
+	// Without channels, the same misbehavior gets the peer banned.
+	hasChannels = false
+	if banned, _ := m.Misbehaved(peerKey, 100, "peer"); !banned {
+		t.Fatal("peer not banned once threshold was crossed")
+	}
+	if !m.IsBanned(peerKey) {
+		t.Fatal("peer not banned")
+	}
+
+	// Should a channel be opened with the banned peer, then it's no
+	// longer considered banned.
+	hasChannels = true
+	if m.IsBanned(peerKey) {
+		t.Fatal("peer with active channels considered banned")
+	}
+}
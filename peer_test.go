@@ -3,6 +3,7 @@
 package main
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -115,6 +116,71 @@ func TestPeerChannelClosureAcceptFeeResponder(t *testing.T) {
 	notifier.confChannel <- &chainntnfs.TxConfirmation{}
 }
 
+// TestPeerChannelClosureUpfrontShutdownScript tests that the shutdown
+// responder rejects a shutdown request to a script other than the one the
+// remote party committed to upon opening the channel, and that it uses its
+// own committed script when responding.
+func TestPeerChannelClosureUpfrontShutdownScript(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	broadcastTxChan := make(chan *wire.MsgTx)
+
+	responder, responderChan, _, cleanUp, err := createTestPeer(
+		notifier, broadcastTxChan)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	localScript := bytes.Repeat([]byte{0x01}, 22)
+	remoteScript := bytes.Repeat([]byte{0x02}, 22)
+	responderChan.State().LocalShutdownScript = localScript
+	responderChan.State().RemoteShutdownScript = remoteScript
+
+	chanID := lnwire.NewChanIDFromOutPoint(responderChan.ChannelPoint())
+
+	// We send a shutdown request to Alice to a script other than the one
+	// we committed to. She should reject it, not responding with a
+	// Shutdown message of her own.
+	responder.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, dummyDeliveryScript),
+	}
+
+	select {
+	case outMsg := <-responder.outgoingQueue:
+		t.Fatalf("expected shutdown to be rejected, got %T", outMsg.msg)
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	// If we instead use the script we committed to, Alice should respond
+	// with a Shutdown message to the script she committed to.
+	responder.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, remoteScript),
+	}
+
+	var msg lnwire.Message
+	select {
+	case outMsg := <-responder.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive shutdown message")
+	}
+
+	shutdownMsg, ok := msg.(*lnwire.Shutdown)
+	if !ok {
+		t.Fatalf("expected Shutdown message, got %T", msg)
+	}
+	if !bytes.Equal(shutdownMsg.Address, localScript) {
+		t.Fatalf("expected shutdown to script %x, got %x",
+			localScript, shutdownMsg.Address)
+	}
+}
+
 // TestPeerChannelClosureAcceptFeeInitiator tests the shutdown initiator's
 // behavior if we can agree on the fee immediately.
 func TestPeerChannelClosureAcceptFeeInitiator(t *testing.T) {
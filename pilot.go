@@ -24,6 +24,52 @@ type chanController struct {
 func (c *chanController) OpenChannel(target *btcec.PublicKey,
 	amt btcutil.Amount, addrs []net.Addr) error {
 
+	if err := c.connectToPeer(target, addrs); err != nil {
+		return err
+	}
+
+	amt = c.capChanSize(target, amt)
+
+	// With the connection established, we'll now establish our connection
+	// to the target peer, waiting for the first update before we exit.
+	feePerVSize, err := c.server.cc.feeEstimator.EstimateFeePerVSize(3)
+	if err != nil {
+		return err
+	}
+
+	// TODO(halseth): make configurable?
+	minHtlc := lnwire.NewMSatFromSatoshis(1)
+
+	updateStream, errChan := c.server.OpenChannel(target, amt, 0,
+		minHtlc, feePerVSize, false, 0, nil)
+
+	select {
+	case err := <-errChan:
+		// If we were not able to actually open a channel to the peer
+		// for whatever reason, then we'll disconnect from the peer to
+		// ensure we don't accumulate a bunch of unnecessary
+		// connections.
+		if err != nil {
+			dcErr := c.server.DisconnectPeer(target)
+			if dcErr != nil {
+				atplLog.Errorf("Unable to disconnect from peer %v",
+					target.SerializeCompressed())
+			}
+		}
+
+		return err
+	case <-updateStream:
+		return nil
+	case <-c.server.quit:
+		return nil
+	}
+}
+
+// connectToPeer ensures we're connected to the target peer, attempting to
+// connect to each of the passed addresses if we aren't yet.
+func (c *chanController) connectToPeer(target *btcec.PublicKey,
+	addrs []net.Addr) error {
+
 	// We can't establish a channel if no addresses were provided for the
 	// peer.
 	if len(addrs) == 0 {
@@ -83,35 +129,60 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 		}
 	}
 
-	// With the connection established, we'll now establish our connection
-	// to the target peer, waiting for the first update before we exit.
-	feePerVSize, err := c.server.cc.feeEstimator.EstimateFeePerVSize(3)
-	if err != nil {
-		return err
+	return nil
+}
+
+// capChanSize caps the size of a channel with the target peer at the regular
+// limit, unless the peer supports large channels.
+func (c *chanController) capChanSize(target *btcec.PublicKey,
+	amt btcutil.Amount) btcutil.Amount {
+
+	if amt > maxFundingAmount {
+		peer, err := c.server.FindPeer(target)
+		if err != nil || !peer.supportsLargeChannels() {
+			return maxFundingAmount
+		}
 	}
 
+	return amt
+}
+
+// OpenChannels opens a channel for each of the passed attachment directives
+// within a single funding transaction. This function should un-block
+// immediately after the funding transaction has been broadcast.
+func (c *chanController) OpenChannels(
+	directives []autopilot.AttachmentDirective) error {
+
 	// TODO(halseth): make configurable?
 	minHtlc := lnwire.NewMSatFromSatoshis(1)
 
-	updateStream, errChan := c.server.OpenChannel(target, amt, 0,
-		minHtlc, feePerVSize, false, 0)
-
-	select {
-	case err := <-errChan:
-		// If we were not able to actually open a channel to the peer
-		// for whatever reason, then we'll disconnect from the peer to
-		// ensure we don't accumulate a bunch of unnecessary
-		// connections.
+	reqs := make([]*openChanReq, 0, len(directives))
+	for _, directive := range directives {
+		err := c.connectToPeer(directive.PeerKey, directive.Addrs)
 		if err != nil {
-			dcErr := c.server.DisconnectPeer(target)
-			if dcErr != nil {
-				atplLog.Errorf("Unable to disconnect from peer %v",
-					target.SerializeCompressed())
-			}
+			return err
 		}
 
+		reqs = append(reqs, &openChanReq{
+			targetPubkey: directive.PeerKey,
+			localFundingAmt: c.capChanSize(
+				directive.PeerKey, directive.ChanAmt,
+			),
+			minHtlc: minHtlc,
+		})
+	}
+
+	feePerVSize, err := c.server.cc.feeEstimator.EstimateFeePerVSize(3)
+	if err != nil {
 		return err
-	case <-updateStream:
+	}
+
+	respChan, errChan := c.server.BatchOpenChannel(reqs, feePerVSize)
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-respChan:
 		return nil
 	case <-c.server.quit:
 		return nil
@@ -42,6 +42,15 @@ const (
 	// ErrPaymentAttemptTimeout is an error that indicates that a payment
 	// attempt timed out before we were able to successfully route an HTLC.
 	ErrPaymentAttemptTimeout
+
+	// ErrMaxHTLCExceeded is returned when a path is found, yet the amount
+	// to be forwarded over one of the channels in the path exceeds the
+	// max HTLC advertised by that channel's policy.
+	ErrMaxHTLCExceeded
+
+	// ErrFeeLimitExceeded is returned when the fees of the route found to
+	// the target destination exceed the fee limit of the payment.
+	ErrFeeLimitExceeded
 )
 
 // routerError is a structure that represent the error inside the routing package,
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 )
@@ -57,6 +58,10 @@ type missionControl struct {
 
 	selfNode *channeldb.LightningNode
 
+	// clock is the time source used to timestamp reported failures and
+	// determine when they've decayed.
+	clock clock.Clock
+
 	sync.Mutex
 
 	// TODO(roasbeef): further counters, if vertex continually unavailable,
@@ -69,13 +74,14 @@ type missionControl struct {
 //
 // TODO(roasbeef): persist memory
 func newMissionControl(g *channeldb.ChannelGraph,
-	selfNode *channeldb.LightningNode) *missionControl {
+	selfNode *channeldb.LightningNode, clock clock.Clock) *missionControl {
 
 	return &missionControl{
 		failedEdges:    make(map[uint64]time.Time),
 		failedVertexes: make(map[Vertex]time.Time),
 		selfNode:       selfNode,
 		graph:          g,
+		clock:          clock,
 	}
 }
 
@@ -98,7 +104,7 @@ type graphPruneView struct {
 func (m *missionControl) GraphPruneView() graphPruneView {
 	// First, we'll grab the current time, this value will be used to
 	// determine if an entry is stale or not.
-	now := time.Now()
+	now := m.clock.Now()
 
 	m.Lock()
 
@@ -169,6 +175,19 @@ func (m *missionControl) NewPaymentSession(routeHints [][]HopHint,
 
 	viewSnapshot := m.GraphPruneView()
 
+	return &paymentSession{
+		pruneViewSnapshot: viewSnapshot,
+		additionalEdges:   edgesFromRouteHints(routeHints, target),
+		mc:                m,
+	}
+}
+
+// edgesFromRouteHints converts the passed routing hints for reaching the
+// target into a set of additional edges to explore when finding a path to the
+// target, indexed by the public key of each channel's starting node.
+func edgesFromRouteHints(routeHints [][]HopHint,
+	target *btcec.PublicKey) map[Vertex][]*channeldb.ChannelEdgePolicy {
+
 	edges := make(map[Vertex][]*channeldb.ChannelEdgePolicy)
 
 	// Traverse through all of the available hop hints and include them in
@@ -210,11 +229,7 @@ func (m *missionControl) NewPaymentSession(routeHints [][]HopHint,
 		}
 	}
 
-	return &paymentSession{
-		pruneViewSnapshot: viewSnapshot,
-		additionalEdges:   edges,
-		mc:                m,
-	}
+	return edges
 }
 
 // ReportVertexFailure adds a vertex to the graph prune view after a client
@@ -232,7 +247,7 @@ func (p *paymentSession) ReportVertexFailure(v Vertex) {
 	// view, with this new piece of information so it can be utilized for
 	// new payment sessions.
 	p.mc.Lock()
-	p.mc.failedVertexes[v] = time.Now()
+	p.mc.failedVertexes[v] = p.mc.clock.Now()
 	p.mc.Unlock()
 }
 
@@ -253,7 +268,7 @@ func (p *paymentSession) ReportChannelFailure(e uint64) {
 	// with this new piece of information so it can be utilized for new
 	// payment sessions.
 	p.mc.Lock()
-	p.mc.failedEdges[e] = time.Now()
+	p.mc.failedEdges[e] = p.mc.clock.Now()
 	p.mc.Unlock()
 }
 
@@ -285,7 +300,7 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 	path, err := findPath(
 		nil, p.mc.graph, p.additionalEdges, p.mc.selfNode,
 		payment.Target, pruneView.vertexes, pruneView.edges,
-		payment.Amount,
+		payment.Amount, nil,
 	)
 	if err != nil {
 		return nil, err
@@ -302,6 +317,14 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 		return nil, err
 	}
 
+	// As path finding favours the cheapest path, we won't look any
+	// further if the fees of this route exceed the payment's fee limit.
+	if payment.FeeLimit != nil && route.TotalFees > *payment.FeeLimit {
+		return nil, newErrf(ErrFeeLimitExceeded, "route fees of %v "+
+			"exceed fee limit of %v", route.TotalFees,
+			*payment.FeeLimit)
+	}
+
 	return route, err
 }
 
@@ -0,0 +1,52 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+// TestMissionControlDecay asserts that failures reported to mission control
+// are only pruned from its view once their decay period has elapsed.
+func TestMissionControlDecay(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	mc := newMissionControl(nil, nil, testClock)
+
+	var vertex Vertex
+	vertex[0] = 0x02
+
+	const chanID = 1
+
+	session := mc.NewPaymentSession(nil, nil)
+	session.ReportVertexFailure(vertex)
+	session.ReportChannelFailure(chanID)
+
+	assertView := func(expectVertex, expectEdge bool) {
+		t.Helper()
+
+		view := mc.GraphPruneView()
+		if _, ok := view.vertexes[vertex]; ok != expectVertex {
+			t.Fatalf("expected vertex pruned=%v, got %v",
+				expectVertex, ok)
+		}
+		if _, ok := view.edges[chanID]; ok != expectEdge {
+			t.Fatalf("expected edge pruned=%v, got %v",
+				expectEdge, ok)
+		}
+	}
+
+	assertView(true, true)
+
+	// Once the edge decay has passed, only the vertex should remain
+	// pruned.
+	testClock.Advance(edgeDecay)
+	assertView(true, false)
+
+	// Finally, once the vertex decay has passed as well, the view should
+	// be empty.
+	testClock.Advance(vertexDecay - edgeDecay)
+	assertView(false, false)
+}
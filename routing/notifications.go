@@ -262,6 +262,10 @@ type ChannelEdgeUpdate struct {
 	// MinHTLC is the minimum HTLC amount that this channel will forward.
 	MinHTLC lnwire.MilliSatoshi
 
+	// MaxHTLC is the maximum HTLC amount that this channel will forward.
+	// A value of zero indicates that no maximum has been advertised.
+	MaxHTLC lnwire.MilliSatoshi
+
 	// BaseFee is the base fee that will charged for all HTLC's forwarded
 	// across the this channel direction.
 	BaseFee lnwire.MilliSatoshi
@@ -276,6 +280,10 @@ type ChannelEdgeUpdate struct {
 	// through this hop.
 	TimeLockDelta uint16
 
+	// Disabled, if true, signals that the channel direction is currently
+	// disabled and shouldn't be used for forwarding.
+	Disabled bool
+
 	// AdvertisingNode is the node that's advertising this edge.
 	AdvertisingNode *btcec.PublicKey
 
@@ -331,7 +339,7 @@ func addToTopologyChange(graph *channeldb.ChannelGraph, update *TopologyChange,
 		// the second node.
 		sourceNode := edgeInfo.NodeKey1
 		connectingNode := edgeInfo.NodeKey2
-		if m.Flags&lnwire.ChanUpdateDirection == 1 {
+		if m.ChannelFlags&lnwire.ChanUpdateDirection == 1 {
 			sourceNode = edgeInfo.NodeKey2
 			connectingNode = edgeInfo.NodeKey1
 		}
@@ -355,6 +363,11 @@ func addToTopologyChange(graph *channeldb.ChannelGraph, update *TopologyChange,
 			FeeRate:         m.FeeProportionalMillionths,
 			AdvertisingNode: aNode,
 			ConnectingNode:  cNode,
+			Disabled:        m.ChannelFlags&lnwire.ChanUpdateDisabled != 0,
+		}
+
+		if m.MessageFlags.HasMaxHtlc() {
+			edgeUpdate.MaxHTLC = m.MaxHTLC
 		}
 		edgeUpdate.AdvertisingNode.Curve = nil
 		edgeUpdate.ConnectingNode.Curve = nil
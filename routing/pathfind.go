@@ -8,9 +8,9 @@ import (
 
 	"container/heap"
 
-	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -354,6 +354,19 @@ func newRoute(amtToSend lnwire.MilliSatoshi, sourceVertex Vertex,
 			return nil, newErrf(ErrInsufficientCapacity, err)
 		}
 
+		// Similarly, if the channel advertises a max HTLC, we ensure
+		// that the amount to forward doesn't exceed it.
+		if nextHop.Channel.MessageFlags.HasMaxHtlc() &&
+			nextHop.AmtToForward > nextHop.Channel.MaxHTLC {
+
+			err := fmt.Sprintf("amount to forward exceeds max "+
+				"htlc of channel %v: need %v, max %v",
+				nextHop.Channel.ChannelID,
+				nextHop.AmtToForward, nextHop.Channel.MaxHTLC)
+
+			return nil, newErrf(ErrMaxHTLCExceeded, err)
+		}
+
 		// If this is the last hop, then for verification purposes, the
 		// value of the outgoing time-lock should be _exactly_ the
 		// absolute time out they'd expect in the HTLC.
@@ -396,6 +409,48 @@ func newRoute(amtToSend lnwire.MilliSatoshi, sourceVertex Vertex,
 	return route, nil
 }
 
+// NewRouteFromHops creates a new Route from the passed hops, which must be
+// ordered from the first hop to the final destination and have their amounts,
+// fees and time-locks already computed. Unlike newRoute, no fees or time-locks
+// are derived from the channel policies, which allows a route found outside
+// of the router to be used to dispatch a payment.
+func NewRouteFromHops(amtToSend lnwire.MilliSatoshi, timeLock uint32,
+	sourceVertex Vertex, hops []*Hop) (*Route, error) {
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("route must contain at least one hop")
+	}
+
+	route := &Route{
+		Hops:          hops,
+		TotalTimeLock: timeLock,
+		TotalAmount:   amtToSend,
+		nodeIndex:     make(map[Vertex]struct{}),
+		chanIndex:     make(map[uint64]struct{}),
+		nextHopMap:    make(map[Vertex]*ChannelHop),
+		prevHopMap:    make(map[Vertex]*ChannelHop),
+	}
+
+	// We'll populate the next hop map for the _source_ node with the
+	// information for the first hop, followed by the indexes of each of
+	// the hops themselves.
+	route.nextHopMap[sourceVertex] = hops[0].Channel
+	for i, hop := range hops {
+		v := Vertex(hop.Channel.Node.PubKeyBytes)
+		route.nodeIndex[v] = struct{}{}
+		route.chanIndex[hop.Channel.ChannelID] = struct{}{}
+		route.prevHopMap[v] = hop.Channel
+
+		if i != len(hops)-1 {
+			route.nextHopMap[v] = hops[i+1].Channel
+		}
+
+		route.TotalFees += hop.Fee
+	}
+
+	return route, nil
+}
+
 // Vertex is a simple alias for the serialization of a compressed Bitcoin
 // public key.
 type Vertex [33]byte
@@ -420,6 +475,32 @@ type edgeWithPrev struct {
 	prevNode [33]byte
 }
 
+// DirectedEdge identifies a channel along with the direction it's traversed
+// in, denoted by the node at the start of the channel.
+type DirectedEdge struct {
+	// ChannelID is the unique identifier of the channel.
+	ChannelID uint64
+
+	// From is the node the payment is forwarded from over the channel.
+	From Vertex
+}
+
+// pathRestrictions holds the restrictions placed on a path by the caller of
+// findPath, in addition to the nodes and channels ignored by our k-shortest
+// paths algorithm.
+type pathRestrictions struct {
+	// ignoredEdges is a set of directed edges that the path must not
+	// traverse.
+	ignoredEdges map[DirectedEdge]struct{}
+
+	// cltvLimit is the maximum sum of the time-lock deltas along the path,
+	// including the final CLTV delta. A value of zero denotes no limit.
+	cltvLimit uint32
+
+	// finalCLTVDelta is the CLTV delta of the final hop of the path.
+	finalCLTVDelta uint16
+}
+
 // edgeWeight computes the weight of an edge. This value is used when searching
 // for the shortest path within the channel graph between two nodes. Currently
 // a component is just 1 + the cltv delta value required at this hop, this
@@ -453,12 +534,18 @@ func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy) int64 {
 // and the destination. The distance metric used for edges is related to the
 // time-lock+fee costs along a particular edge. If a path is found, this
 // function returns a slice of ChannelHop structs which encoded the chosen path
-// from the target to the source.
-func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+// from the target to the source. The path will also satisfy the optional set
+// of restrictions passed in.
+func findPath(tx kvdb.Tx, graph *channeldb.ChannelGraph,
 	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy,
 	sourceNode *channeldb.LightningNode, target *btcec.PublicKey,
 	ignoredNodes map[Vertex]struct{}, ignoredEdges map[uint64]struct{},
-	amt lnwire.MilliSatoshi) ([]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi,
+	restrictions *pathRestrictions) ([]*ChannelHop, error) {
+
+	if restrictions == nil {
+		restrictions = &pathRestrictions{}
+	}
 
 	var err error
 	if tx == nil {
@@ -474,45 +561,29 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// traversal.
 	var nodeHeap distanceHeap
 
-	// For each node in the graph, we create an entry in the distance
-	// map for the node set with a distance of "infinity".
+	// The distance map holds the best known distance to each node we've
+	// reached so far. Rather than populating it with every node in the
+	// graph up front, nodes missing from the map are treated as being at
+	// a distance of "infinity".
 	distance := make(map[Vertex]nodeWithDist)
-	if err := graph.ForEachNode(tx, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
-		// TODO(roasbeef): with larger graph can just use disk seeks
-		// with a visited map
-		distance[Vertex(node.PubKeyBytes)] = nodeWithDist{
-			dist: infinity,
-			node: node,
-		}
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-
-	// We'll also include all the nodes found within the additional edges
-	// that are not known to us yet in the distance map.
-	for vertex := range additionalEdges {
-		node := &channeldb.LightningNode{PubKeyBytes: vertex}
-		distance[vertex] = nodeWithDist{
-			dist: infinity,
-			node: node,
+	nodeDist := func(v Vertex) int64 {
+		if n, ok := distance[v]; ok {
+			return n.dist
 		}
+		return infinity
 	}
 
-	// We can't always assume that the end destination is publicly
-	// advertised to the network and included in the graph.ForEachNode call
-	// above, so we'll manually include the target node.
 	targetVertex := NewVertex(target)
-	targetNode := &channeldb.LightningNode{PubKeyBytes: targetVertex}
-	distance[targetVertex] = nodeWithDist{
-		dist: infinity,
-		node: targetNode,
-	}
 
 	// We'll use this map as a series of "previous" hop pointers. So to get
 	// to `Vertex` we'll take the edge that it's mapped to within `prev`.
 	prev := make(map[Vertex]edgeWithPrev)
 
+	// The time lock map holds the sum of the time-lock deltas along the
+	// best known path to each node, which is needed to enforce the CLTV
+	// limit of the path.
+	timeLock := make(map[Vertex]uint32)
+
 	// processEdge is a helper closure that will be used to make sure edges
 	// satisfy our specific requirements.
 	processEdge := func(edge *channeldb.ChannelEdgePolicy,
@@ -522,8 +593,13 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 
 		// If the edge is currently disabled, then we'll stop here, as
 		// we shouldn't attempt to route through it.
-		edgeFlags := lnwire.ChanUpdateFlag(edge.Flags)
-		if edgeFlags&lnwire.ChanUpdateDisabled != 0 {
+		if edge.ChannelFlags&lnwire.ChanUpdateDisabled != 0 {
+			return
+		}
+
+		// If the edge advertises a max HTLC that is below the amount
+		// we need to send, then it's unable to carry the payment.
+		if edge.MessageFlags.HasMaxHtlc() && amt > edge.MaxHTLC {
 			return
 		}
 
@@ -535,6 +611,30 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		if _, ok := ignoredEdges[edge.ChannelID]; ok {
 			return
 		}
+		directedEdge := DirectedEdge{
+			ChannelID: edge.ChannelID,
+			From:      pivot,
+		}
+		if _, ok := restrictions.ignoredEdges[directedEdge]; ok {
+			return
+		}
+
+		// The time-lock delta of the edge only contributes to the
+		// total time lock of the path if it isn't the final edge, as
+		// the final CLTV delta is used in its place. If the path would
+		// exceed the CLTV limit, then we won't explore the edge.
+		edgeTimeLock := timeLock[pivot] + uint32(edge.TimeLockDelta)
+		if restrictions.cltvLimit != 0 {
+			totalTimeLock := timeLock[pivot] +
+				uint32(restrictions.finalCLTVDelta)
+			if v != targetVertex {
+				totalTimeLock += uint32(edge.TimeLockDelta)
+			}
+
+			if totalTimeLock > restrictions.cltvLimit {
+				return
+			}
+		}
 
 		// Compute the tentative distance to this new channel/edge which
 		// is the distance to our pivot node plus the weight of this
@@ -547,7 +647,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		// this edge. We'll also shave off irrelevant edges by adding
 		// the sufficient capacity of an edge and clearing their
 		// min-htlc amount to our relaxation condition.
-		if tempDist < distance[v].dist && capacity >= amt.ToSatoshis() &&
+		if tempDist < nodeDist(v) && capacity >= amt.ToSatoshis() &&
 			amt >= edge.MinHTLC && edge.TimeLockDelta != 0 {
 
 			distance[v] = nodeWithDist{
@@ -562,6 +662,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 				},
 				prevNode: pivot,
 			}
+			timeLock[v] = edgeTimeLock
 
 			// Add this new node to our heap as we'd like to further
 			// explore down this edge.
@@ -602,7 +703,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		// examine all the outgoing edge (channels) from this node to
 		// further our graph traversal.
 		pivot := Vertex(bestNode.PubKeyBytes)
-		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
+		err := graph.ForEachNodeChannel(tx, pivot, func(
 			edgeInfo *channeldb.ChannelEdgeInfo,
 			outEdge, _ *channeldb.ChannelEdgePolicy) error {
 
@@ -678,13 +779,24 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 // will be ignored by our modified Dijkstra's algorithm. With this approach, we
 // make our inner path finding algorithm aware of our k-shortest paths
 // algorithm, rather than attempting to use an unmodified path finding
-// algorithm in a block box manner.
-func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+// algorithm in a block box manner. All paths found will avoid the passed set
+// of ignored nodes and satisfy the optional set of restrictions.
+func findPaths(tx kvdb.Tx, graph *channeldb.ChannelGraph,
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy,
 	source *channeldb.LightningNode, target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi, numPaths uint32) ([][]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, numPaths uint32,
+	ignoredNodes map[Vertex]struct{},
+	restrictions *pathRestrictions) ([][]*ChannelHop, error) {
+
+	if restrictions == nil {
+		restrictions = &pathRestrictions{}
+	}
 
 	ignoredEdges := make(map[uint64]struct{})
 	ignoredVertexes := make(map[Vertex]struct{})
+	for v := range ignoredNodes {
+		ignoredVertexes[v] = struct{}{}
+	}
 
 	// TODO(roasbeef): modifying ordering within heap to eliminate final
 	// sorting step?
@@ -697,8 +809,8 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// selfNode) to the target destination that's capable of carrying amt
 	// satoshis along the path before fees are calculated.
 	startingPath, err := findPath(
-		tx, graph, nil, source, target, ignoredVertexes, ignoredEdges,
-		amt,
+		tx, graph, additionalEdges, source, target, ignoredVertexes,
+		ignoredEdges, amt, restrictions,
 	)
 	if err != nil {
 		log.Errorf("Unable to find path: %v", err)
@@ -733,6 +845,9 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// and loopless.
 			ignoredEdges = make(map[uint64]struct{})
 			ignoredVertexes = make(map[Vertex]struct{})
+			for v := range ignoredNodes {
+				ignoredVertexes[v] = struct{}{}
+			}
 
 			// Our spur node is the i-th node in the prior shortest
 			// path, and our root path will be all nodes in the
@@ -766,13 +881,29 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 				ignoredVertexes[Vertex(node)] = struct{}{}
 			}
 
+			// As the spur path will extend the root path, the
+			// time-lock deltas of the root path count towards the
+			// CLTV limit of the spur path.
+			spurRestrictions := *restrictions
+			if restrictions.cltvLimit != 0 {
+				var rootTimeLock uint32
+				for _, hop := range rootPath {
+					rootTimeLock += uint32(hop.TimeLockDelta)
+				}
+				if rootTimeLock >= restrictions.cltvLimit {
+					continue
+				}
+				spurRestrictions.cltvLimit -= rootTimeLock
+			}
+
 			// With the edges that are part of our root path, and
 			// the Vertexes (other than the spur path) within the
 			// root path removed, we'll attempt to find another
 			// shortest path from the spur node to the destination.
 			spurPath, err := findPath(
-				tx, graph, nil, spurNode, target,
+				tx, graph, additionalEdges, spurNode, target,
 				ignoredVertexes, ignoredEdges, amt,
+				&spurRestrictions,
 			)
 
 			// If we weren't able to find a path, we'll continue to
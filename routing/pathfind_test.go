@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net"
@@ -86,9 +87,11 @@ type testChan struct {
 	Node2        string `json:"node_2"`
 	ChannelID    uint64 `json:"channel_id"`
 	ChannelPoint string `json:"channel_point"`
-	Flags        uint16 `json:"flags"`
+	ChannelFlags uint8  `json:"flags"`
+	MessageFlags uint8  `json:"message_flags"`
 	Expiry       uint16 `json:"expiry"`
 	MinHTLC      int64  `json:"min_htlc"`
+	MaxHTLC      int64  `json:"max_htlc"`
 	FeeBaseMsat  int64  `json:"fee_base_msat"`
 	FeeRate      int64  `json:"fee_rate"`
 	Capacity     int64  `json:"capacity"`
@@ -97,7 +100,8 @@ type testChan struct {
 // makeTestGraph creates a new instance of a channeldb.ChannelGraph for testing
 // purposes. A callback which cleans up the created temporary directories is
 // also returned and intended to be executed after the test completes.
-func makeTestGraph() (*channeldb.ChannelGraph, func(), error) {
+func makeTestGraph(modifiers ...channeldb.OptionModifier) (
+	*channeldb.ChannelGraph, func(), error) {
 	// First, create a temporary directory to be used for the duration of
 	// this test.
 	tempDirName, err := ioutil.TempDir("", "channeldb")
@@ -106,7 +110,7 @@ func makeTestGraph() (*channeldb.ChannelGraph, func(), error) {
 	}
 
 	// Next, create channeldb for the first time.
-	cdb, err := channeldb.Open(tempDirName)
+	cdb, err := channeldb.Open(tempDirName, modifiers...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -266,11 +270,13 @@ func parseTestGraph(path string) (*channeldb.ChannelGraph, func(), aliasMap, err
 
 		edgePolicy := &channeldb.ChannelEdgePolicy{
 			SigBytes:                  testSig.Serialize(),
-			Flags:                     lnwire.ChanUpdateFlag(edge.Flags),
+			MessageFlags:              lnwire.ChanUpdateMsgFlags(edge.MessageFlags),
+			ChannelFlags:              lnwire.ChanUpdateChanFlags(edge.ChannelFlags),
 			ChannelID:                 edge.ChannelID,
 			LastUpdate:                time.Now(),
 			TimeLockDelta:             edge.Expiry,
 			MinHTLC:                   lnwire.MilliSatoshi(edge.MinHTLC),
+			MaxHTLC:                   lnwire.MilliSatoshi(edge.MaxHTLC),
 			FeeBaseMSat:               lnwire.MilliSatoshi(edge.FeeBaseMsat),
 			FeeProportionalMillionths: lnwire.MilliSatoshi(edge.FeeRate),
 		}
@@ -313,7 +319,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	target := aliases["sophon"]
 	path, err := findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt,
+		ignoredEdges, paymentAmt, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
@@ -455,7 +461,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	target = aliases["luoji"]
 	path, err = findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt,
+		ignoredEdges, paymentAmt, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
@@ -541,7 +547,7 @@ func TestPathFindingWithAdditionalEdges(t *testing.T) {
 	// We should now be able to find a path from roasbeef to doge.
 	path, err := findPath(
 		nil, graph, additionalEdges, sourceNode, dogePubKey, nil, nil,
-		paymentAmt,
+		paymentAmt, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find private path to doge: %v", err)
@@ -577,7 +583,7 @@ func TestKShortestPathFinding(t *testing.T) {
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["luoji"]
 	paths, err := findPaths(
-		nil, graph, sourceNode, target, paymentAmt, 100,
+		nil, graph, nil, sourceNode, target, paymentAmt, 100, nil, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find paths between roasbeef and "+
@@ -629,7 +635,7 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	target := aliases["ursula"]
 	_, err = findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt,
+		ignoredEdges, paymentAmt, nil,
 	)
 	if err != nil {
 		t.Fatalf("path should have been found")
@@ -640,7 +646,7 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	target = aliases["vincent"]
 	path, err := findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt,
+		ignoredEdges, paymentAmt, nil,
 	)
 	if err == nil {
 		t.Fatalf("should not have been able to find path, supposed to be "+
@@ -682,7 +688,7 @@ func TestPathNotAvailable(t *testing.T) {
 
 	_, err = findPath(
 		nil, graph, nil, sourceNode, unknownNode, ignoredVertexes,
-		ignoredEdges, 100,
+		ignoredEdges, 100, nil,
 	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("path shouldn't have been found: %v", err)
@@ -718,7 +724,7 @@ func TestPathInsufficientCapacity(t *testing.T) {
 	payAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
 	_, err = findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt,
+		ignoredEdges, payAmt, nil,
 	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
@@ -748,7 +754,7 @@ func TestRouteFailMinHTLC(t *testing.T) {
 	payAmt := lnwire.MilliSatoshi(10)
 	_, err = findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt,
+		ignoredEdges, payAmt, nil,
 	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
@@ -778,7 +784,7 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	payAmt := lnwire.NewMSatFromSatoshis(10000)
 	_, err = findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt,
+		ignoredEdges, payAmt, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
@@ -790,7 +796,7 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch goku's edge: %v", err)
 	}
-	gokuEdge.Flags = lnwire.ChanUpdateDisabled
+	gokuEdge.ChannelFlags = lnwire.ChanUpdateDisabled
 	if err := graph.UpdateEdgePolicy(gokuEdge); err != nil {
 		t.Fatalf("unable to update edge: %v", err)
 	}
@@ -799,7 +805,59 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	// failure as it is no longer eligible.
 	_, err = findPath(
 		nil, graph, nil, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt,
+		ignoredEdges, payAmt, nil,
+	)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("graph shouldn't be able to support payment: %v", err)
+	}
+}
+
+// TestRouteFailMaxHTLC tests that if we attempt to route through an edge with
+// a max HTLC below the payment amount, then that edge is disqualified, and the
+// routing attempt will fail.
+func TestRouteFailMaxHTLC(t *testing.T) {
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	ignoredEdges := make(map[uint64]struct{})
+	ignoredVertexes := make(map[Vertex]struct{})
+
+	// First, we'll try to route from roasbeef -> songoku. This should
+	// succeed without issue, and return a single path.
+	target := aliases["songoku"]
+	payAmt := lnwire.NewMSatFromSatoshis(10000)
+	_, err = findPath(
+		nil, graph, nil, sourceNode, target, ignoredVertexes,
+		ignoredEdges, payAmt, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+
+	// Next, we'll modify the edge from roasbeef -> songoku, to advertise
+	// a max HTLC just below the payment amount.
+	_, gokuEdge, _, err := graph.FetchChannelEdgesByID(12345)
+	if err != nil {
+		t.Fatalf("unable to fetch goku's edge: %v", err)
+	}
+	gokuEdge.MessageFlags = lnwire.ChanUpdateOptionMaxHtlc
+	gokuEdge.MaxHTLC = payAmt - 1
+	if err := graph.UpdateEdgePolicy(gokuEdge); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+
+	// Now, if we attempt to route through that edge, we should get a
+	// failure as it is no longer able to carry the payment.
+	_, err = findPath(
+		nil, graph, nil, sourceNode, target, ignoredVertexes,
+		ignoredEdges, payAmt, nil,
 	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
@@ -851,7 +909,7 @@ func TestPathFindSpecExample(t *testing.T) {
 	// Query for a route of 4,999,999 mSAT to carol.
 	carol := ctx.aliases["C"]
 	const amt lnwire.MilliSatoshi = 4999999
-	routes, err := ctx.router.FindRoutes(carol, amt, 100)
+	routes, err := ctx.router.FindRoutes(carol, amt, 100, nil)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -911,7 +969,7 @@ func TestPathFindSpecExample(t *testing.T) {
 
 	// We'll now request a route from A -> B -> C.
 	ctx.router.routeCache = make(map[routeTuple][]*Route)
-	routes, err = ctx.router.FindRoutes(carol, amt, 100)
+	routes, err = ctx.router.FindRoutes(carol, amt, 100, nil)
 	if err != nil {
 		t.Fatalf("unable to find routes: %v", err)
 	}
@@ -1049,3 +1107,153 @@ func assertExpectedPath(t *testing.T, path []*ChannelHop, nodeAliases ...string)
 		}
 	}
 }
+
+// populateBenchGraph fills the passed graph with numNodes nodes, each of which
+// opens numChans channels to randomly selected other nodes. The graph is
+// generated from a fixed seed, so it's identical across calls. The nodes are
+// returned, with the first having been set as the source node.
+func populateBenchGraph(graph *channeldb.ChannelGraph, numNodes,
+	numChans int) ([]*channeldb.LightningNode, error) {
+
+	rand := prand.New(prand.NewSource(1))
+
+	nodes := make([]*channeldb.LightningNode, numNodes)
+	for i := range nodes {
+		var privKeyBytes [32]byte
+		rand.Read(privKeyBytes[:])
+		_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), privKeyBytes[:])
+
+		node := &channeldb.LightningNode{
+			HaveNodeAnnouncement: true,
+			AuthSigBytes:         testSig.Serialize(),
+			LastUpdate:           time.Unix(int64(i), 0),
+			Alias:                fmt.Sprintf("node%d", i),
+			Features:             testFeatures,
+		}
+		copy(node.PubKeyBytes[:], pubKey.SerializeCompressed())
+
+		var err error
+		if i == 0 {
+			err = graph.SetSourceNode(node)
+		} else {
+			err = graph.AddLightningNode(node)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[i] = node
+	}
+
+	chanID := uint64(1)
+	for i, node := range nodes {
+		for j := 0; j < numChans; j++ {
+			peer := nodes[rand.Intn(numNodes)]
+			if peer == node {
+				continue
+			}
+
+			node1, node2 := node, peer
+			if bytes.Compare(node1.PubKeyBytes[:],
+				node2.PubKeyBytes[:]) > 0 {
+
+				node1, node2 = node2, node1
+			}
+
+			edgeInfo := &channeldb.ChannelEdgeInfo{
+				ChannelID:        chanID,
+				AuthProof:        &testAuthProof,
+				NodeKey1Bytes:    node1.PubKeyBytes,
+				NodeKey2Bytes:    node2.PubKeyBytes,
+				BitcoinKey1Bytes: node1.PubKeyBytes,
+				BitcoinKey2Bytes: node2.PubKeyBytes,
+				ChannelPoint: wire.OutPoint{
+					Index: uint32(chanID),
+				},
+				Capacity: btcutil.SatoshiPerBitcoin,
+			}
+			if err := graph.AddChannelEdge(edgeInfo); err != nil {
+				return nil, err
+			}
+
+			for _, direction := range []lnwire.ChanUpdateChanFlags{
+				0, lnwire.ChanUpdateDirection,
+			} {
+				policy := &channeldb.ChannelEdgePolicy{
+					SigBytes:      testSig.Serialize(),
+					ChannelID:     chanID,
+					LastUpdate:    time.Unix(int64(i), 0),
+					ChannelFlags:  direction,
+					TimeLockDelta: uint16(rand.Intn(144) + 1),
+					FeeBaseMSat: lnwire.MilliSatoshi(
+						rand.Intn(1000),
+					),
+					FeeProportionalMillionths: lnwire.MilliSatoshi(
+						rand.Intn(1000),
+					),
+				}
+				if err := graph.UpdateEdgePolicy(policy); err != nil {
+					return nil, err
+				}
+			}
+
+			chanID++
+		}
+	}
+
+	return nodes, nil
+}
+
+// benchmarkFindPath measures finding a path between the source node and
+// randomly selected targets within a graph of 500 nodes and about 2000
+// channels, created with the passed database options.
+func benchmarkFindPath(b *testing.B, modifiers ...channeldb.OptionModifier) {
+	graph, cleanUp, err := makeTestGraph(modifiers...)
+	if err != nil {
+		b.Fatalf("unable to create graph: %v", err)
+	}
+	defer cleanUp()
+
+	nodes, err := populateBenchGraph(graph, 500, 4)
+	if err != nil {
+		b.Fatalf("unable to populate graph: %v", err)
+	}
+
+	targets := make([]*btcec.PublicKey, 0, len(nodes)-1)
+	for _, node := range nodes[1:] {
+		pubKey, err := node.PubKey()
+		if err != nil {
+			b.Fatalf("unable to parse public key: %v", err)
+		}
+		targets = append(targets, pubKey)
+	}
+
+	const amt = lnwire.MilliSatoshi(100000)
+	ignoredEdges := make(map[uint64]struct{})
+	ignoredVertexes := make(map[Vertex]struct{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := findPath(
+			nil, graph, nil, nodes[0], targets[i%len(targets)],
+			ignoredVertexes, ignoredEdges, amt, nil,
+		)
+		if err != nil && !IsError(err, ErrNoPathFound) {
+			b.Fatalf("unable to find path: %v", err)
+		}
+	}
+}
+
+// BenchmarkFindPathGraphCache measures path finding over the in-memory graph
+// cache.
+func BenchmarkFindPathGraphCache(b *testing.B) {
+	benchmarkFindPath(b)
+}
+
+// BenchmarkFindPathNoGraphCache measures path finding reading the graph from
+// disk, as done before the introduction of the graph cache.
+func BenchmarkFindPathNoGraphCache(b *testing.B) {
+	benchmarkFindPath(b, channeldb.OptionNoGraphCache())
+}
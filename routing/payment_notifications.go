@@ -0,0 +1,227 @@
+package routing
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// PaymentState describes the stage of its lifecycle an outgoing payment
+// dispatched by the ChannelRouter is in.
+type PaymentState uint8
+
+const (
+	// PaymentInFlight indicates that the router has started attempting to
+	// route the payment.
+	PaymentInFlight PaymentState = iota
+
+	// PaymentAttemptFailed indicates that a single attempt to route the
+	// payment along a particular route has failed. Unless the failure is
+	// terminal, the router will go on to attempt another route.
+	PaymentAttemptFailed
+
+	// PaymentSucceeded indicates that the payment was settled by the
+	// destination.
+	PaymentSucceeded
+
+	// PaymentFailed indicates that the router has given up on the payment.
+	PaymentFailed
+)
+
+// String returns a human readable version of the payment state.
+func (s PaymentState) String() string {
+	switch s {
+	case PaymentInFlight:
+		return "InFlight"
+	case PaymentAttemptFailed:
+		return "AttemptFailed"
+	case PaymentSucceeded:
+		return "Succeeded"
+	case PaymentFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// PaymentUpdate describes a single state transition of an outgoing payment.
+type PaymentUpdate struct {
+	// PaymentHash is the payment hash of the payment.
+	PaymentHash [32]byte
+
+	// State is the state the payment has transitioned to.
+	State PaymentState
+
+	// Route is the route of the failed attempt for a PaymentAttemptFailed
+	// update, and the route the payment traversed for a PaymentSucceeded
+	// update. It's nil otherwise.
+	Route *Route
+
+	// Preimage is the preimage of the payment for a PaymentSucceeded
+	// update.
+	Preimage [32]byte
+
+	// FailureReason describes why the attempt or payment failed for a
+	// PaymentAttemptFailed or PaymentFailed update.
+	FailureReason string
+}
+
+// PaymentClient represents an intent to receive notifications from the channel
+// router about the lifecycle of the outgoing payments it dispatches. The
+// updates for each payment are delivered in the order they occurred.
+type PaymentClient struct {
+	// PaymentUpdates is a receive only channel that payment updates will
+	// be sent over. It's closed once the client is cancelled or the
+	// router shuts down.
+	PaymentUpdates <-chan *PaymentUpdate
+
+	// Cancel is a function closure that should be executed when the client
+	// wishes to cancel their notification intent. Doing so allows the
+	// ChannelRouter to free up resources.
+	Cancel func()
+}
+
+// paymentClient is the channel router's side of a PaymentClient. Updates are
+// buffered within an unbounded queue so that a slow client never blocks
+// payments, while still receiving the updates in order.
+type paymentClient struct {
+	queue *chainntnfs.ConcurrentQueue
+
+	ntfnChan chan *PaymentUpdate
+
+	cancelOnce sync.Once
+	quit       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// SubscribePayments returns a new payment client which can be used by the
+// caller to receive notifications whenever an outgoing payment dispatched by
+// the router transitions to a new state.
+func (r *ChannelRouter) SubscribePayments() (*PaymentClient, error) {
+	select {
+	case <-r.quit:
+		return nil, errors.New("ChannelRouter shutting down")
+	default:
+	}
+
+	clientID := atomic.AddUint64(&r.paymentClientCounter, 1)
+
+	log.Debugf("New payment client subscription, client %v", clientID)
+
+	client := &paymentClient{
+		queue:    chainntnfs.NewConcurrentQueue(20),
+		ntfnChan: make(chan *PaymentUpdate),
+		quit:     make(chan struct{}),
+	}
+	client.queue.Start()
+
+	r.paymentClientsMtx.Lock()
+	r.paymentClients[clientID] = client
+	r.paymentClientsMtx.Unlock()
+
+	client.wg.Add(1)
+	go func() {
+		defer client.wg.Done()
+		defer close(client.ntfnChan)
+
+		for {
+			select {
+			case update := <-client.queue.ChanOut():
+				select {
+				case client.ntfnChan <- update.(*PaymentUpdate):
+				case <-client.quit:
+					return
+				case <-r.quit:
+					return
+				}
+
+			case <-client.quit:
+				return
+			case <-r.quit:
+				return
+			}
+		}
+	}()
+
+	return &PaymentClient{
+		PaymentUpdates: client.ntfnChan,
+		Cancel: func() {
+			r.paymentClientsMtx.Lock()
+			delete(r.paymentClients, clientID)
+			r.paymentClientsMtx.Unlock()
+
+			client.cancelOnce.Do(func() {
+				close(client.quit)
+				client.wg.Wait()
+				client.queue.Stop()
+			})
+		},
+	}, nil
+}
+
+// PaymentInProgress returns true if the router is currently dispatching the
+// payment with the target payment hash. While this is the case, the payment
+// may be retried along other routes even if its latest attempt has failed.
+func (r *ChannelRouter) PaymentInProgress(paymentHash [32]byte) bool {
+	r.paymentClientsMtx.Lock()
+	defer r.paymentClientsMtx.Unlock()
+
+	_, ok := r.activePayments[paymentHash]
+	return ok
+}
+
+// NotifyPaymentOutcome notifies all registered payment clients of the outcome
+// of a payment that isn't being dispatched by the router, such as one that was
+// still in flight when we last shut down. A nil paymentErr signals that the
+// payment succeeded. As the route of such a payment is no longer known, it
+// isn't included within the update.
+func (r *ChannelRouter) NotifyPaymentOutcome(paymentHash, preimage [32]byte,
+	paymentErr error) {
+
+	update := &PaymentUpdate{
+		PaymentHash: paymentHash,
+		State:       PaymentSucceeded,
+		Preimage:    preimage,
+	}
+	if paymentErr != nil {
+		update.State = PaymentFailed
+		update.Preimage = [32]byte{}
+		update.FailureReason = paymentErr.Error()
+	}
+
+	r.notifyPaymentUpdate(update)
+}
+
+// untrackPayment marks the payment with the target payment hash as no longer
+// being dispatched, without notifying payment clients.
+func (r *ChannelRouter) untrackPayment(paymentHash [32]byte) {
+	r.paymentClientsMtx.Lock()
+	delete(r.activePayments, paymentHash)
+	r.paymentClientsMtx.Unlock()
+}
+
+// notifyPaymentUpdate notifies all registered payment clients of a new payment
+// update without blocking on any of them.
+func (r *ChannelRouter) notifyPaymentUpdate(update *PaymentUpdate) {
+	log.Debugf("Payment %x transitioned to state %v", update.PaymentHash[:],
+		update.State)
+
+	r.paymentClientsMtx.Lock()
+	defer r.paymentClientsMtx.Unlock()
+
+	switch update.State {
+	case PaymentInFlight:
+		r.activePayments[update.PaymentHash] = struct{}{}
+	case PaymentSucceeded, PaymentFailed:
+		delete(r.activePayments, update.PaymentHash)
+	}
+
+	for _, client := range r.paymentClients {
+		select {
+		case client.queue.ChanIn() <- update:
+		case <-client.quit:
+		}
+	}
+}
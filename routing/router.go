@@ -2,6 +2,7 @@ package routing
 
 import (
 	"bytes"
+	"crypto/rand"
 	"fmt"
 	"runtime"
 	"sort"
@@ -9,9 +10,10 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -32,6 +34,10 @@ const (
 	// CLTV delta for a route if one is unspecified.
 	DefaultFinalCLTVDelta = 9
 
+	// DefaultChannelPruneExpiry is the default duration used to determine
+	// if a channel should be pruned as a zombie.
+	DefaultChannelPruneExpiry = time.Duration(time.Hour * 24 * 14)
+
 	// defaultPayAttemptTimeout is a duration that we'll use to determine
 	// if we should give up on a payment attempt. This will be used if a
 	// value isn't specified in the LightningNode struct.
@@ -68,14 +74,18 @@ type ChannelGraphSource interface {
 	IsStaleNode(node Vertex, timestamp time.Time) bool
 
 	// IsKnownEdge returns true if the graph source already knows of the
-	// passed channel ID.
+	// passed channel ID, either as a channel or as a zombie.
 	IsKnownEdge(chanID lnwire.ShortChannelID) bool
 
+	// MarkEdgeLive clears the zombie mark of the channel with the given
+	// ID, allowing its announcement to be accepted again.
+	MarkEdgeLive(chanID lnwire.ShortChannelID) error
+
 	// IsStaleEdgePolicy returns true if the graph source has a channel
 	// edge for the passed channel ID (and flags) that have a more recent
 	// timestamp.
 	IsStaleEdgePolicy(chanID lnwire.ShortChannelID, timestamp time.Time,
-		flags lnwire.ChanUpdateFlag) bool
+		flags lnwire.ChanUpdateChanFlags) bool
 
 	// ForAllOutgoingChannels is used to iterate over all channels
 	// emanating from the "source" node which is the center of the
@@ -154,6 +164,13 @@ type Config struct {
 	SendToSwitch func(firstHop [33]byte, htlcAdd *lnwire.UpdateAddHTLC,
 		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
 
+	// SendProbeToSwitch is a function that, like SendToSwitch, directs the
+	// link-layer switch to forward a fully encoded HTLC to the first hop
+	// in the route. It's used to send probes, which pay to a random
+	// payment hash and thus must not be recorded as payments.
+	SendProbeToSwitch func(firstHop [33]byte, htlcAdd *lnwire.UpdateAddHTLC,
+		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
+
 	// ChannelPruneExpiry is the duration used to determine if a channel
 	// should be pruned or not. If the delta between now and when the
 	// channel was last updated is greater than ChannelPruneExpiry, then
@@ -163,21 +180,31 @@ type Config struct {
 	// GraphPruneInterval is used as an interval to determine how often we
 	// should examine the channel graph to garbage collect zombie channels.
 	GraphPruneInterval time.Duration
+
+	// Clock is the time source used to determine whether channels are
+	// zombies, and when failures reported to mission control decay.
+	Clock clock.Clock
 }
 
 // routeTuple is an entry within the ChannelRouter's route cache. We cache
 // prospective routes based on first the destination, and then the target
 // amount. We required the target amount as that will influence the available
-// set of paths for a payment.
+// set of paths for a payment. The final CLTV delta is also part of the key,
+// as it determines the time locks of the cached routes.
 type routeTuple struct {
-	amt  lnwire.MilliSatoshi
-	dest [33]byte
+	amt            lnwire.MilliSatoshi
+	dest           [33]byte
+	finalCLTVDelta uint16
 }
 
-// newRouteTuple creates a new route tuple from the target and amount.
-func newRouteTuple(amt lnwire.MilliSatoshi, dest []byte) routeTuple {
+// newRouteTuple creates a new route tuple from the target, amount and final
+// CLTV delta.
+func newRouteTuple(amt lnwire.MilliSatoshi, dest []byte,
+	finalCLTVDelta uint16) routeTuple {
+
 	r := routeTuple{
-		amt: amt,
+		amt:            amt,
+		finalCLTVDelta: finalCLTVDelta,
 	}
 	copy(r.dest[:], dest)
 
@@ -192,7 +219,8 @@ func newRouteTuple(amt lnwire.MilliSatoshi, dest []byte) routeTuple {
 // automatically as new blocks are discovered which spend certain known funding
 // outpoints, thereby closing their respective channels.
 type ChannelRouter struct {
-	ntfnClientCounter uint64
+	ntfnClientCounter    uint64
+	paymentClientCounter uint64
 
 	started uint32
 	stopped uint32
@@ -244,6 +272,14 @@ type ChannelRouter struct {
 	// existing client.
 	ntfnClientUpdates chan *topologyClientUpdate
 
+	// paymentClients maps a client's unique notification ID to a
+	// paymentClient that's notified of the lifecycle of outgoing
+	// payments. activePayments tracks the payments we're currently
+	// dispatching. Both are guarded by paymentClientsMtx.
+	paymentClientsMtx sync.Mutex
+	paymentClients    map[uint64]*paymentClient
+	activePayments    map[[32]byte]struct{}
+
 	// missionControl is a shared memory of sorts that executions of
 	// payment path finding use in order to remember which vertexes/edges
 	// were pruned from prior attempts. During SendPayment execution,
@@ -288,7 +324,9 @@ func New(cfg Config) (*ChannelRouter, error) {
 		networkUpdates:    make(chan *routingMsg),
 		topologyClients:   make(map[uint64]*topologyClient),
 		ntfnClientUpdates: make(chan *topologyClientUpdate),
-		missionControl:    newMissionControl(cfg.Graph, selfNode),
+		paymentClients:    make(map[uint64]*paymentClient),
+		activePayments:    make(map[[32]byte]struct{}),
+		missionControl:    newMissionControl(cfg.Graph, selfNode, cfg.Clock),
 		channelEdgeMtx:    multimutex.NewMutex(),
 		selfNode:          selfNode,
 		routeCache:        make(map[routeTuple][]*Route),
@@ -532,10 +570,13 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 // pruneZombieChans is a method that will be called periodically to prune out
 // any "zombie" channels. We consider channels zombies if *both* edges haven't
 // been updated since our zombie horizon. We do this periodically to keep a
-// health, lively routing table.
+// health, lively routing table. Pruned channels are recorded within the
+// zombie index of the graph, so we don't accept their announcements again
+// unless either of their nodes sends us a fresh update for them.
 func (r *ChannelRouter) pruneZombieChans() error {
-	var chansToPrune []wire.OutPoint
+	var chansToPrune []*channeldb.ChannelEdgeInfo
 	chanExpiry := r.cfg.ChannelPruneExpiry
+	now := r.cfg.Clock.Now()
 
 	log.Infof("Examining Channel Graph for zombie channels")
 
@@ -558,7 +599,7 @@ func (r *ChannelRouter) pruneZombieChans() error {
 		// for graph pruning.
 		e1Zombie, e2Zombie := true, true
 		if e1 != nil {
-			e1Zombie = time.Since(e1.LastUpdate) >= chanExpiry
+			e1Zombie = now.Sub(e1.LastUpdate) >= chanExpiry
 			if e1Zombie {
 				log.Tracef("Edge #1 of ChannelPoint(%v) "+
 					"last update: %v",
@@ -566,7 +607,7 @@ func (r *ChannelRouter) pruneZombieChans() error {
 			}
 		}
 		if e2 != nil {
-			e2Zombie = time.Since(e2.LastUpdate) >= chanExpiry
+			e2Zombie = now.Sub(e2.LastUpdate) >= chanExpiry
 			if e2Zombie {
 				log.Tracef("Edge #2 of ChannelPoint(%v) "+
 					"last update: %v",
@@ -579,20 +620,12 @@ func (r *ChannelRouter) pruneZombieChans() error {
 
 			// TODO(roasbeef): add ability to delete single
 			// directional edge
-			chansToPrune = append(chansToPrune, info.ChannelPoint)
-
-			// As we're detecting this as a zombie channel, we'll
-			// add this to the set of recently rejected items so we
-			// don't re-accept it shortly after.
-			r.rejectCache[info.ChannelID] = struct{}{}
+			chansToPrune = append(chansToPrune, info)
 		}
 
 		return nil
 	}
 
-	r.rejectMtx.Lock()
-	defer r.rejectMtx.Unlock()
-
 	err := r.cfg.Graph.ForEachChannel(filterPruneChans)
 	if err != nil {
 		return fmt.Errorf("Unable to filter local zombie "+
@@ -602,11 +635,15 @@ func (r *ChannelRouter) pruneZombieChans() error {
 	log.Infof("Pruning %v Zombie Channels", len(chansToPrune))
 
 	// With the set zombie-like channels obtained, we'll do another pass to
-	// delete al zombie channels from the channel graph.
+	// delete al zombie channels from the channel graph, marking each of
+	// them as a zombie so we don't re-accept them shortly after.
 	for _, chanToPrune := range chansToPrune {
-		log.Tracef("Pruning zombie chan ChannelPoint(%v)", chanToPrune)
+		log.Tracef("Pruning zombie chan ChannelPoint(%v)",
+			chanToPrune.ChannelPoint)
 
-		err := r.cfg.Graph.DeleteChannelEdge(&chanToPrune)
+		err := r.cfg.Graph.DeleteChannelEdge(
+			&chanToPrune.ChannelPoint, true,
+		)
 		if err != nil {
 			return fmt.Errorf("Unable to prune zombie "+
 				"chans: %v", err)
@@ -923,6 +960,17 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 				"chan_id=%v", msg.ChannelID)
 		}
 
+		// Channels we've pruned as zombies won't be added back to the
+		// graph until they're resurrected by a fresh update.
+		isZombie, _, _, err := r.cfg.Graph.IsZombieEdge(msg.ChannelID)
+		if err != nil {
+			return errors.Errorf("unable to check for zombie "+
+				"edge: %v", err)
+		} else if isZombie {
+			return newErrf(ErrIgnored, "Ignoring msg for zombie "+
+				"chan_id=%v", msg.ChannelID)
+		}
+
 		// Query the database for the existence of the two nodes in this
 		// channel. If not found, add a partial node to the database,
 		// containing only the node keys.
@@ -1067,24 +1115,24 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 
 		// A flag set of 0 indicates this is an announcement for the
 		// "first" node in the channel.
-		case msg.Flags&lnwire.ChanUpdateDirection == 0:
+		case msg.ChannelFlags&lnwire.ChanUpdateDirection == 0:
 			if edge1Timestamp.After(msg.LastUpdate) ||
 				edge1Timestamp.Equal(msg.LastUpdate) {
 
 				return newErrf(ErrIgnored, "Ignoring update "+
-					"(flags=%v) for known chan_id=%v", msg.Flags,
+					"(flags=%v) for known chan_id=%v", msg.ChannelFlags,
 					msg.ChannelID)
 
 			}
 
 		// Similarly, a flag set of 1 indicates this is an announcement
 		// for the "second" node in the channel.
-		case msg.Flags&lnwire.ChanUpdateDirection == 1:
+		case msg.ChannelFlags&lnwire.ChanUpdateDirection == 1:
 			if edge2Timestamp.After(msg.LastUpdate) ||
 				edge2Timestamp.Equal(msg.LastUpdate) {
 
 				return newErrf(ErrIgnored, "Ignoring update "+
-					"(flags=%v) for known chan_id=%v", msg.Flags,
+					"(flags=%v) for known chan_id=%v", msg.ChannelFlags,
 					msg.ChannelID)
 			}
 		}
@@ -1280,6 +1328,42 @@ func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop, finalCLTVDelta
 	return validRoutes, nil
 }
 
+// RouteRestrictions is a set of optional restrictions that the routes
+// returned by FindRoutes must satisfy.
+type RouteRestrictions struct {
+	// Source is the node the routes should start from. If nil, the routes
+	// will start from our own node.
+	Source *btcec.PublicKey
+
+	// IgnoredNodes is a set of nodes the routes must not pass through.
+	IgnoredNodes map[Vertex]struct{}
+
+	// IgnoredEdges is a set of directed edges the routes must not
+	// traverse.
+	IgnoredEdges map[DirectedEdge]struct{}
+
+	// CLTVLimit is the maximum number of blocks the total time lock of a
+	// route may be set to in excess of the current height. A value of
+	// zero denotes no limit.
+	CLTVLimit uint32
+
+	// FinalCLTVDelta is the CLTV delta of the final hop of the routes. If
+	// zero, DefaultFinalCLTVDelta is used.
+	FinalCLTVDelta uint16
+
+	// RouteHints is an optional set of routing hints that can be used to
+	// reach the target, which may be a node that isn't publicly known.
+	RouteHints [][]HopHint
+}
+
+// restrictsPaths returns true if the restrictions affect the paths that can
+// be found to the target, rather than just the time locks of the routes.
+func (r *RouteRestrictions) restrictsPaths() bool {
+	return r.Source != nil || len(r.IgnoredNodes) != 0 ||
+		len(r.IgnoredEdges) != 0 || r.CLTVLimit != 0 ||
+		len(r.RouteHints) != 0
+}
+
 // FindRoutes attempts to query the ChannelRouter for a bounded number
 // available paths to a particular target destination which is able to send
 // `amt` after factoring in channel capacities and cumulative fees along each
@@ -1288,15 +1372,19 @@ func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop, finalCLTVDelta
 // within its inner loop.  Once we have a set of candidate routes, we calculate
 // the required fee and time lock values running backwards along the route. The
 // route that will be ranked the highest is the one with the lowest cumulative
-// fee along the route.
+// fee along the route. An optional set of restrictions can be passed in to
+// constrain the routes returned.
 func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi, numPaths uint32, finalExpiry ...uint16) ([]*Route, error) {
+	amt lnwire.MilliSatoshi, numPaths uint32,
+	restrictions *RouteRestrictions) ([]*Route, error) {
 
-	var finalCLTVDelta uint16
-	if len(finalExpiry) == 0 {
-		finalCLTVDelta = DefaultFinalCLTVDelta
-	} else {
-		finalCLTVDelta = finalExpiry[0]
+	if restrictions == nil {
+		restrictions = &RouteRestrictions{}
+	}
+
+	finalCLTVDelta := uint16(DefaultFinalCLTVDelta)
+	if restrictions.FinalCLTVDelta != 0 {
+		finalCLTVDelta = restrictions.FinalCLTVDelta
 	}
 
 	dest := target.SerializeCompressed()
@@ -1304,17 +1392,21 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 
 	// Before attempting to perform a series of graph traversals to find
 	// the k-shortest paths to the destination, we'll first consult our
-	// path cache
-	rt := newRouteTuple(amt, dest)
-	r.routeCacheMtx.RLock()
-	routes, ok := r.routeCache[rt]
-	r.routeCacheMtx.RUnlock()
-
-	// If we already have a cached route, and it contains at least the
-	// number of paths requested, then we'll return it directly as there's
-	// no need to repeat the computation.
-	if ok && uint32(len(routes)) >= numPaths {
-		return routes, nil
+	// path cache. As the cache only holds routes from our own node that
+	// aren't subject to any restrictions, we'll bypass it otherwise.
+	useCache := !restrictions.restrictsPaths()
+	rt := newRouteTuple(amt, dest, finalCLTVDelta)
+	if useCache {
+		r.routeCacheMtx.RLock()
+		routes, ok := r.routeCache[rt]
+		r.routeCacheMtx.RUnlock()
+
+		// If we already have a cached route, and it contains at least
+		// the number of paths requested, then we'll return it directly
+		// as there's no need to repeat the computation.
+		if ok && uint32(len(routes)) >= numPaths {
+			return routes, nil
+		}
 	}
 
 	// If we don't have a set of routes cached, we'll query the graph for a
@@ -1323,15 +1415,30 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// returned.
 
 	// We can short circuit the routing by opportunistically checking to
-	// see if the target vertex event exists in the current graph.
+	// see if the target vertex event exists in the current graph. If any
+	// routing hints were provided, then the target may be reachable
+	// through them nonetheless.
 	targetVertex := NewVertex(target)
 	if _, exists, err := r.cfg.Graph.HasLightningNode(targetVertex); err != nil {
 		return nil, err
-	} else if !exists {
+	} else if !exists && len(restrictions.RouteHints) == 0 {
 		log.Debugf("Target %x is not in known graph", dest)
 		return nil, newErrf(ErrTargetNotInNetwork, "target not found")
 	}
 
+	// Unless another source node was requested, the routes will start
+	// from our own node.
+	source := r.selfNode
+	if restrictions.Source != nil {
+		var err error
+		source, err = r.cfg.Graph.FetchLightningNode(restrictions.Source)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch source node "+
+				"%x: %v", restrictions.Source.SerializeCompressed(),
+				err)
+		}
+	}
+
 	// We'll also fetch the current block height so we can properly
 	// calculate the required HTLC time locks within the route.
 	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
@@ -1348,8 +1455,14 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// Now that we know the destination is reachable within the graph,
 	// we'll execute our KSP algorithm to find the k-shortest paths from
 	// our source to the destination.
+	additionalEdges := edgesFromRouteHints(restrictions.RouteHints, target)
 	shortestPaths, err := findPaths(
-		tx, r.cfg.Graph, r.selfNode, target, amt, numPaths,
+		tx, r.cfg.Graph, additionalEdges, source, target, amt, numPaths,
+		restrictions.IgnoredNodes, &pathRestrictions{
+			ignoredEdges:   restrictions.IgnoredEdges,
+			cltvLimit:      restrictions.CLTVLimit,
+			finalCLTVDelta: finalCLTVDelta,
+		},
 	)
 	if err != nil {
 		tx.Rollback()
@@ -1363,7 +1476,7 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// each path. During this process, some paths may be discarded if they
 	// aren't able to support the total satoshis flow once fees have been
 	// factored in.
-	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
+	sourceVertex := Vertex(source.PubKeyBytes)
 	validRoutes, err := pathsToFeeSortedRoutes(
 		sourceVertex, shortestPaths, finalCLTVDelta, amt,
 		uint32(currentHeight),
@@ -1380,13 +1493,164 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 
 	// Populate the cache with this set of fresh routes so we can reuse
 	// them in the future.
-	r.routeCacheMtx.Lock()
-	r.routeCache[rt] = validRoutes
-	r.routeCacheMtx.Unlock()
+	if useCache {
+		r.routeCacheMtx.Lock()
+		r.routeCache[rt] = validRoutes
+		r.routeCacheMtx.Unlock()
+	}
 
 	return validRoutes, nil
 }
 
+// BuildRoute constructs a route that pays amt to the last of the passed hops,
+// traveling through each of them in order. If chanIDs is non-nil, it must
+// specify the channel to use to reach each of the hops. Otherwise, the
+// cheapest channel able to carry the payment is selected. The fees and
+// time-locks of the route are computed from the current policies of the
+// selected channels.
+func (r *ChannelRouter) BuildRoute(amt lnwire.MilliSatoshi, hops []Vertex,
+	chanIDs []uint64, finalCLTVDelta uint16) (*Route, error) {
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("route must contain at least one hop")
+	}
+	if chanIDs != nil && len(chanIDs) != len(hops) {
+		return nil, fmt.Errorf("number of channel ids (%v) doesn't "+
+			"match number of hops (%v)", len(chanIDs), len(hops))
+	}
+
+	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll walk the hops in order, selecting the channel that'll carry
+	// the payment from the prior node to each of them.
+	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
+	pathEdges := make([]*ChannelHop, len(hops))
+	prevNode := sourceVertex
+	for i, hop := range hops {
+		var (
+			edge *ChannelHop
+			err  error
+		)
+		if chanIDs != nil {
+			edge, err = r.fetchChannelHop(prevNode, hop, chanIDs[i])
+		} else {
+			edge, err = r.selectChannelHop(prevNode, hop, amt)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pathEdges[i] = edge
+		prevNode = hop
+	}
+
+	return newRoute(
+		amt, sourceVertex, pathEdges, uint32(currentHeight),
+		finalCLTVDelta,
+	)
+}
+
+// fetchChannelHop returns the target channel as a hop from one node to the
+// other, using the policy of the channel in that direction.
+func (r *ChannelRouter) fetchChannelHop(from, to Vertex,
+	chanID uint64) (*ChannelHop, error) {
+
+	info, policy1, policy2, err := r.cfg.Graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch channel %v: %v", chanID,
+			err)
+	}
+
+	var policy *channeldb.ChannelEdgePolicy
+	switch {
+	case info.NodeKey1Bytes == from && info.NodeKey2Bytes == to:
+		policy = policy1
+	case info.NodeKey2Bytes == from && info.NodeKey1Bytes == to:
+		policy = policy2
+	default:
+		return nil, fmt.Errorf("channel %v doesn't connect %x to %x",
+			chanID, from[:], to[:])
+	}
+
+	if policy == nil {
+		return nil, fmt.Errorf("channel %v has no policy for %x",
+			chanID, from[:])
+	}
+
+	return &ChannelHop{
+		ChannelEdgePolicy: policy,
+		Capacity:          info.Capacity,
+	}, nil
+}
+
+// selectChannelHop returns the channel from one node to the other that is
+// able to carry amt for the lowest fee.
+func (r *ChannelRouter) selectChannelHop(from, to Vertex,
+	amt lnwire.MilliSatoshi) (*ChannelHop, error) {
+
+	fromNode := r.selfNode
+	if from != Vertex(r.selfNode.PubKeyBytes) {
+		pub, err := btcec.ParsePubKey(from[:], btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		fromNode, err = r.cfg.Graph.FetchLightningNode(pub)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch node %x: %v",
+				from[:], err)
+		}
+	}
+
+	var (
+		bestHop *ChannelHop
+		bestFee lnwire.MilliSatoshi
+	)
+	err := fromNode.ForEachChannel(nil, func(_ kvdb.Tx,
+		info *channeldb.ChannelEdgeInfo, outEdge,
+		_ *channeldb.ChannelEdgePolicy) error {
+
+		if outEdge == nil || outEdge.Node.PubKeyBytes != to {
+			return nil
+		}
+
+		// We'll skip any channels that are disabled or unable to
+		// carry the payment.
+		switch {
+		case outEdge.ChannelFlags&lnwire.ChanUpdateDisabled != 0:
+			return nil
+		case info.Capacity < amt.ToSatoshis():
+			return nil
+		case outEdge.MessageFlags.HasMaxHtlc() && amt > outEdge.MaxHTLC:
+			return nil
+		}
+
+		fee := computeFee(amt, outEdge)
+		if bestHop == nil || fee < bestFee {
+			bestHop = &ChannelHop{
+				ChannelEdgePolicy: outEdge,
+				Capacity:          info.Capacity,
+			}
+			bestFee = fee
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bestHop == nil {
+		return nil, newErrf(ErrNoPathFound, "no channel from %x to %x "+
+			"able to carry %v", from[:], to[:], amt)
+	}
+
+	return bestHop, nil
+}
+
 // generateSphinxPacket generates then encodes a sphinx packet which encodes
 // the onion route specified by the passed layer 3 route. The blob returned
 // from this function can immediately be included within an HTLC add packet to
@@ -1476,6 +1740,11 @@ type LightningPayment struct {
 	// used.
 	FinalCLTVDelta *uint16
 
+	// FeeLimit is the maximum total fee, in milli-satoshis, that we're
+	// willing to pay to route the payment to its destination. If this
+	// value is unspecified, then the fees paid aren't bounded.
+	FeeLimit *lnwire.MilliSatoshi
+
 	// PayAttemptTimeout is a timeout value that we'll use to determine
 	// when we should should abandon the payment attempt after consecutive
 	// payment failure. This prevents us from attempting to send a payment
@@ -1501,8 +1770,91 @@ type LightningPayment struct {
 // resulted in a failed payment. If the payment succeeds, then a non-nil Route
 // will be returned which describes the path the successful payment traversed
 // within the network to reach the destination. Additionally, the payment
-// preimage will also be returned.
+// preimage will also be returned. Each state transition of the payment is sent
+// to all clients registered via SubscribePayments.
 func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route, error) {
+	return r.dispatchPayment(
+		payment.PaymentHash, func() ([32]byte, *Route, error) {
+			return r.sendPayment(payment)
+		},
+	)
+}
+
+// SendToRoute attempts to send a payment with the given payment hash along the
+// passed routes, trying each of them in order until the payment succeeds or a
+// terminal error is encountered. If the payment succeeds, then the route it
+// traversed is returned along with the payment preimage. Each state
+// transition of the payment is sent to all clients registered via
+// SubscribePayments.
+func (r *ChannelRouter) SendToRoute(routes []*Route,
+	paymentHash [32]byte) ([32]byte, *Route, error) {
+
+	return r.dispatchPayment(
+		paymentHash, func() ([32]byte, *Route, error) {
+			return r.sendToRoute(routes, paymentHash)
+		},
+	)
+}
+
+// dispatchPayment carries out the passed send function for the payment with
+// the given payment hash, notifying payment clients of its outcome. Whether
+// the payment hash is already in flight or paid is left to the control tower
+// of the switch, as only it can check and record this atomically.
+func (r *ChannelRouter) dispatchPayment(paymentHash [32]byte,
+	send func() ([32]byte, *Route, error)) ([32]byte, *Route, error) {
+
+	r.notifyPaymentUpdate(&PaymentUpdate{
+		PaymentHash: paymentHash,
+		State:       PaymentInFlight,
+	})
+
+	preImage, route, err := send()
+	switch {
+	// If the control tower refused to send the payment as another HTLC
+	// for it is already in flight, then we won't notify payment clients
+	// of its failure, as the original payment is still being tracked and
+	// its outcome will be reported on its own.
+	case err == channeldb.ErrPaymentInFlight:
+		return preImage, nil, err
+
+	// Similarly, if the payment hash has already been paid, then there's
+	// no outcome to report, so we'll only stop tracking the payment.
+	case err == channeldb.ErrAlreadyPaid:
+		r.untrackPayment(paymentHash)
+		return preImage, nil, err
+
+	case err != nil:
+		r.notifyPaymentUpdate(&PaymentUpdate{
+			PaymentHash:   paymentHash,
+			State:         PaymentFailed,
+			FailureReason: err.Error(),
+		})
+
+		return preImage, nil, err
+	}
+
+	r.notifyPaymentUpdate(&PaymentUpdate{
+		PaymentHash: paymentHash,
+		State:       PaymentSucceeded,
+		Route:       route,
+		Preimage:    preImage,
+	})
+
+	return preImage, route, nil
+}
+
+// isDuplicatePayment returns true if the passed error indicates that the
+// control tower refused to send a payment, as its payment hash is either
+// already in flight or has already been paid.
+func isDuplicatePayment(err error) bool {
+	return err == channeldb.ErrPaymentInFlight ||
+		err == channeldb.ErrAlreadyPaid
+}
+
+// sendPayment carries out the route finding and dispatch of a payment on
+// behalf of SendPayment, attempting routes until the payment succeeds or
+// fails terminally.
+func (r *ChannelRouter) sendPayment(payment *LightningPayment) ([32]byte, *Route, error) {
 	log.Tracef("Dispatching route for lightning payment: %v",
 		newLogClosure(func() string {
 			// Remove the public key curve parameters when logging
@@ -1642,221 +1994,441 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			log.Errorf("Attempt to send payment %x failed: %v",
 				payment.PaymentHash, sendError)
 
+			// If the control tower refused the attempt, then it
+			// never left the switch, so there's no failed attempt
+			// to report.
+			if isDuplicatePayment(sendError) {
+				return preImage, nil, sendError
+			}
+
+			r.notifyPaymentUpdate(&PaymentUpdate{
+				PaymentHash:   payment.PaymentHash,
+				State:         PaymentAttemptFailed,
+				Route:         route,
+				FailureReason: sendError.Error(),
+			})
+
 			fErr, ok := sendError.(*htlcswitch.ForwardingError)
 			if !ok {
 				return preImage, nil, sendError
 			}
 
-			errSource := fErr.ErrorSource
+			if r.processSendError(
+				paySession, route, fErr, payment.PaymentHash,
+				errFailedFeeChans,
+			) {
+				return preImage, nil, sendError
+			}
+			continue
+		}
 
-			log.Tracef("node=%x reported failure when sending "+
-				"htlc=%x", errSource.SerializeCompressed(),
-				payment.PaymentHash[:])
+		return preImage, route, nil
+	}
+}
 
-			switch onionErr := fErr.FailureMessage.(type) {
-			// If the end destination didn't know they payment
-			// hash, then we'll terminate immediately.
-			case *lnwire.FailUnknownPaymentHash:
-				return preImage, nil, sendError
+// sendToRoute carries out the dispatch of a payment along the passed routes on
+// behalf of SendToRoute.
+func (r *ChannelRouter) sendToRoute(routes []*Route,
+	paymentHash [32]byte) ([32]byte, *Route, error) {
 
-			// If we sent the wrong amount to the destination, then
-			// we'll exit early.
-			case *lnwire.FailIncorrectPaymentAmount:
-				return preImage, nil, sendError
+	if len(routes) == 0 {
+		return [32]byte{}, nil, fmt.Errorf("no routes provided")
+	}
 
-			// If the time-lock that was extended to the final node
-			// was incorrect, then we can't proceed.
-			case *lnwire.FailFinalIncorrectCltvExpiry:
-				return preImage, nil, sendError
+	var (
+		preImage  [32]byte
+		sendError error
+	)
 
-			// If we crafted an invalid onion payload for the final
-			// node, then we'll exit early.
-			case *lnwire.FailFinalIncorrectHtlcAmount:
-				return preImage, nil, sendError
+	// As with SendPayment, we'll report the failures we encounter back to
+	// mission control, and keep track of fee related failures.
+	errFailedFeeChans := make(map[lnwire.ShortChannelID]struct{})
+	paySession := r.missionControl.NewPaymentSession(nil, nil)
 
-			// Similarly, if the HTLC expiry that we extended to
-			// the final hop expires too soon, then will fail the
-			// payment.
-			//
-			// TODO(roasbeef): can happen to to race condition, try
-			// again with recent block height
-			case *lnwire.FailFinalExpiryTooSoon:
-				return preImage, nil, sendError
+	for _, route := range routes {
+		select {
+		case <-r.quit:
+			return preImage, nil, fmt.Errorf("router shutting down")
+		default:
+		}
 
-			// If we erroneously attempted to cross a chain border,
-			// then we'll cancel the payment.
-			case *lnwire.FailInvalidRealm:
-				return preImage, nil, sendError
+		log.Tracef("Attempting to send payment %x, using route: %v",
+			paymentHash, newLogClosure(func() string {
+				return spew.Sdump(route)
+			}),
+		)
 
-			// If we get a notice that the expiry was too soon for
-			// an intermediate node, then we'll prune out the node
-			// that sent us this error, as it doesn't now what the
-			// correct block height is.
-			case *lnwire.FailExpiryTooSoon:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+		// Generate the raw encoded sphinx packet to be included along
+		// with the htlcAdd message that we send directly to the
+		// switch.
+		onionBlob, circuit, err := generateSphinxPacket(
+			route, paymentHash[:],
+		)
+		if err != nil {
+			return preImage, nil, err
+		}
 
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+		htlcAdd := &lnwire.UpdateAddHTLC{
+			Amount:      route.TotalAmount,
+			Expiry:      route.TotalTimeLock,
+			PaymentHash: paymentHash,
+		}
+		copy(htlcAdd.OnionBlob[:], onionBlob)
 
-			// If we hit an instance of onion payload corruption or
-			// an invalid version, then we'll exit early as this
-			// shouldn't happen in the typical case.
-			case *lnwire.FailInvalidOnionVersion:
-				return preImage, nil, sendError
-			case *lnwire.FailInvalidOnionHmac:
-				return preImage, nil, sendError
-			case *lnwire.FailInvalidOnionKey:
-				return preImage, nil, sendError
+		firstHop := route.Hops[0].Channel.Node.PubKeyBytes
+		preImage, sendError = r.cfg.SendToSwitch(
+			firstHop, htlcAdd, circuit,
+		)
+		if sendError == nil {
+			return preImage, route, nil
+		}
 
-			// If the onion error includes a channel update, and
-			// isn't necessarily fatal, then we'll apply the update
-			// and continue with the rest of the routes.
-			case *lnwire.FailAmountBelowMinimum:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+		log.Errorf("Attempt to send payment %x failed: %v",
+			paymentHash, sendError)
 
-				return preImage, nil, sendError
+		if isDuplicatePayment(sendError) {
+			return preImage, nil, sendError
+		}
 
-			// If we get a failure due to a fee, so we'll apply the
-			// new fee update, and retry our attempt using the
-			// newly updated fees.
-			case *lnwire.FailFeeInsufficient:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-
-					pruneEdgeFailure(
-						paySession, route, errSource,
-					)
-				}
+		r.notifyPaymentUpdate(&PaymentUpdate{
+			PaymentHash:   paymentHash,
+			State:         PaymentAttemptFailed,
+			Route:         route,
+			FailureReason: sendError.Error(),
+		})
+
+		// Unlike SendPayment, we won't find any new routes, but we'll
+		// still stop early if the error indicates that none of the
+		// remaining routes can succeed.
+		fErr, ok := sendError.(*htlcswitch.ForwardingError)
+		if !ok {
+			return preImage, nil, sendError
+		}
 
-				// We'll now check to see if we've already
-				// reported a fee related failure for this
-				// node. If so, then we'll actually prune out
-				// the vertex for now.
-				chanID := update.ShortChannelID
-				_, ok := errFailedFeeChans[chanID]
-				if ok {
-					pruneVertexFailure(
-						paySession, route, errSource, false,
-					)
-					continue
-				}
+		if r.processSendError(
+			paySession, route, fErr, paymentHash, errFailedFeeChans,
+		) {
+			return preImage, nil, sendError
+		}
+	}
 
-				// Finally, we'll record a fee failure from
-				// this node and move on.
-				errFailedFeeChans[chanID] = struct{}{}
-				continue
+	return preImage, nil, fmt.Errorf("unable to route payment to "+
+		"destination: %v", sendError)
+}
 
-			// If we get the failure for an intermediate node that
-			// disagrees with our time lock values, then we'll
-			// prune it out for now, and continue with path
-			// finding.
-			case *lnwire.FailIncorrectCltvExpiry:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+// EstimateRouteFee probes up to numRoutes candidate routes to the target for
+// the passed amount, cheapest first, by sending HTLCs that pay to a random
+// payment hash along them. A probe that's failed by the target itself with
+// an unknown payment hash error shows that the route is able to carry the
+// payment, so the first such route is returned. The failures encountered
+// along the way are reported to mission control, just as they would be for
+// a real payment, however the probes are never recorded as payments.
+func (r *ChannelRouter) EstimateRouteFee(target *btcec.PublicKey,
+	amt lnwire.MilliSatoshi, numRoutes uint32,
+	finalCLTVDelta uint16) (*Route, error) {
+
+	routes, err := r.FindRoutes(target, amt, numRoutes, &RouteRestrictions{
+		FinalCLTVDelta: finalCLTVDelta,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+	// As the destination must not be able to settle a probe, we'll send
+	// each of them to a random payment hash.
+	var probeHash [32]byte
+	if _, err := rand.Read(probeHash[:]); err != nil {
+		return nil, err
+	}
 
-			// The outgoing channel that this node was meant to
-			// forward one is currently disabled, so we'll apply
-			// the update and continue.
-			case *lnwire.FailChannelDisabled:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+	errFailedFeeChans := make(map[lnwire.ShortChannelID]struct{})
+	paySession := r.missionControl.NewPaymentSession(nil, nil)
 
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+	var probeErr error
+	for _, route := range routes {
+		select {
+		case <-r.quit:
+			return nil, fmt.Errorf("router shutting down")
+		default:
+		}
 
-			// It's likely that the outgoing channel didn't have
-			// sufficient capacity, so we'll prune this edge for
-			// now, and continue onwards with our path finding.
-			case *lnwire.FailTemporaryChannelFailure:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+		log.Tracef("Probing route to %x for %v: %v",
+			target.SerializeCompressed(), amt,
+			newLogClosure(func() string {
+				return spew.Sdump(route)
+			}),
+		)
 
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+		onionBlob, circuit, err := generateSphinxPacket(
+			route, probeHash[:],
+		)
+		if err != nil {
+			return nil, err
+		}
 
-			// If the send fail due to a node not having the
-			// required features, then we'll note this error and
-			// continue.
-			case *lnwire.FailRequiredNodeFeatureMissing:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+		htlcAdd := &lnwire.UpdateAddHTLC{
+			Amount:      route.TotalAmount,
+			Expiry:      route.TotalTimeLock,
+			PaymentHash: probeHash,
+		}
+		copy(htlcAdd.OnionBlob[:], onionBlob)
 
-			// If the send fail due to a node not having the
-			// required features, then we'll note this error and
-			// continue.
-			case *lnwire.FailRequiredChannelFeatureMissing:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+		firstHop := route.Hops[0].Channel.Node.PubKeyBytes
+		_, probeErr = r.cfg.SendProbeToSwitch(
+			firstHop, htlcAdd, circuit,
+		)
 
-			// If the next hop in the route wasn't known or
-			// offline, we'll only the channel which we attempted
-			// to route over. This is conservative, and it can
-			// handle faulty channels between nodes properly.
-			// Additionally, this guards against routing nodes
-			// returning errors in order to attempt to black list
-			// another node.
-			case *lnwire.FailUnknownNextPeer:
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+		// As the target can't know the preimage, a probe should never
+		// succeed. If it does nonetheless, the route clearly works.
+		if probeErr == nil {
+			return route, nil
+		}
 
-			// If the node wasn't able to forward for which ever
-			// reason, then we'll note this and continue with the
-			// routes.
-			case *lnwire.FailTemporaryNodeFailure:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+		fErr, ok := probeErr.(*htlcswitch.ForwardingError)
+		if !ok {
+			return nil, probeErr
+		}
 
-			case *lnwire.FailPermanentNodeFailure:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+		// If the target itself rejected the probe due to the unknown
+		// payment hash, then the probe made it all the way through
+		// the route.
+		_, unknownHash := fErr.FailureMessage.(*lnwire.FailUnknownPaymentHash)
+		if unknownHash && fErr.ErrorSource.IsEqual(target) {
+			log.Debugf("Probe to %x for %v succeeded with fee %v",
+				target.SerializeCompressed(), amt,
+				route.TotalFees)
+			return route, nil
+		}
 
-			// If we get a permanent channel or node failure, then
-			// we'll note this (exclude the vertex/edge), and
-			// continue with the rest of the routes.
-			case *lnwire.FailPermanentChannelFailure:
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+		log.Debugf("Probe to %x for %v failed: %v",
+			target.SerializeCompressed(), amt, probeErr)
 
-			default:
-				return preImage, nil, sendError
-			}
+		if r.processSendError(
+			paySession, route, fErr, probeHash, errFailedFeeChans,
+		) {
+			return nil, probeErr
 		}
+	}
 
-		return preImage, route, nil
+	return nil, newErrf(ErrNoPathFound, "unable to find a route able to "+
+		"carry the payment, last probe failed with: %v", probeErr)
+}
+
+// processSendError analyzes the error returned by the switch for a failed
+// payment attempt along the passed route, pruning the failing vertex or edge
+// from the payment session where appropriate. It returns true if the error is
+// terminal, in which case the payment shouldn't be retried along another
+// route.
+func (r *ChannelRouter) processSendError(paySession *paymentSession,
+	route *Route, fErr *htlcswitch.ForwardingError, paymentHash [32]byte,
+	errFailedFeeChans map[lnwire.ShortChannelID]struct{}) bool {
+
+	errSource := fErr.ErrorSource
+
+	log.Tracef("node=%x reported failure when sending "+
+		"htlc=%x", errSource.SerializeCompressed(),
+		paymentHash[:])
+
+	switch onionErr := fErr.FailureMessage.(type) {
+	// If the end destination didn't know they payment
+	// hash, then we'll terminate immediately.
+	case *lnwire.FailUnknownPaymentHash:
+		return true
+
+	// If we sent the wrong amount to the destination, then
+	// we'll exit early.
+	case *lnwire.FailIncorrectPaymentAmount:
+		return true
+
+	// If the time-lock that was extended to the final node
+	// was incorrect, then we can't proceed.
+	case *lnwire.FailFinalIncorrectCltvExpiry:
+		return true
+
+	// If we crafted an invalid onion payload for the final
+	// node, then we'll exit early.
+	case *lnwire.FailFinalIncorrectHtlcAmount:
+		return true
+
+	// Similarly, if the HTLC expiry that we extended to
+	// the final hop expires too soon, then will fail the
+	// payment.
+	//
+	// TODO(roasbeef): can happen to to race condition, try
+	// again with recent block height
+	case *lnwire.FailFinalExpiryTooSoon:
+		return true
+
+	// If we erroneously attempted to cross a chain border,
+	// then we'll cancel the payment.
+	case *lnwire.FailInvalidRealm:
+		return true
+
+	// If we get a notice that the expiry was too soon for
+	// an intermediate node, then we'll prune out the node
+	// that sent us this error, as it doesn't now what the
+	// correct block height is.
+	case *lnwire.FailExpiryTooSoon:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If we hit an instance of onion payload corruption or
+	// an invalid version, then we'll exit early as this
+	// shouldn't happen in the typical case.
+	case *lnwire.FailInvalidOnionVersion:
+		return true
+	case *lnwire.FailInvalidOnionHmac:
+		return true
+	case *lnwire.FailInvalidOnionKey:
+		return true
+
+	// If the onion error includes a channel update, and
+	// isn't necessarily fatal, then we'll apply the update
+	// and continue with the rest of the routes.
+	case *lnwire.FailAmountBelowMinimum:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		return true
+
+	// If we get a failure due to a fee, so we'll apply the
+	// new fee update, and retry our attempt using the
+	// newly updated fees.
+	case *lnwire.FailFeeInsufficient:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+
+			pruneEdgeFailure(
+				paySession, route, errSource,
+			)
+		}
+
+		// We'll now check to see if we've already
+		// reported a fee related failure for this
+		// node. If so, then we'll actually prune out
+		// the vertex for now.
+		chanID := update.ShortChannelID
+		_, ok := errFailedFeeChans[chanID]
+		if ok {
+			pruneVertexFailure(
+				paySession, route, errSource, false,
+			)
+			return false
+		}
+
+		// Finally, we'll record a fee failure from
+		// this node and move on.
+		errFailedFeeChans[chanID] = struct{}{}
+		return false
+
+	// If we get the failure for an intermediate node that
+	// disagrees with our time lock values, then we'll
+	// prune it out for now, and continue with path
+	// finding.
+	case *lnwire.FailIncorrectCltvExpiry:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// The outgoing channel that this node was meant to
+	// forward one is currently disabled, so we'll apply
+	// the update and continue.
+	case *lnwire.FailChannelDisabled:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	// It's likely that the outgoing channel didn't have
+	// sufficient capacity, so we'll prune this edge for
+	// now, and continue onwards with our path finding.
+	case *lnwire.FailTemporaryChannelFailure:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	// If the send fail due to a node not having the
+	// required features, then we'll note this error and
+	// continue.
+	case *lnwire.FailRequiredNodeFeatureMissing:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If the send fail due to a node not having the
+	// required features, then we'll note this error and
+	// continue.
+	case *lnwire.FailRequiredChannelFeatureMissing:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If the next hop in the route wasn't known or
+	// offline, we'll only the channel which we attempted
+	// to route over. This is conservative, and it can
+	// handle faulty channels between nodes properly.
+	// Additionally, this guards against routing nodes
+	// returning errors in order to attempt to black list
+	// another node.
+	case *lnwire.FailUnknownNextPeer:
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	// If the node wasn't able to forward for which ever
+	// reason, then we'll note this and continue with the
+	// routes.
+	case *lnwire.FailTemporaryNodeFailure:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	case *lnwire.FailPermanentNodeFailure:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If we get a permanent channel or node failure, then
+	// we'll note this (exclude the vertex/edge), and
+	// continue with the rest of the routes.
+	case *lnwire.FailPermanentChannelFailure:
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	default:
+		return true
 	}
 }
 
@@ -1929,9 +2501,11 @@ func (r *ChannelRouter) applyChannelUpdate(msg *lnwire.ChannelUpdate) error {
 		SigBytes:                  msg.Signature.ToSignatureBytes(),
 		ChannelID:                 msg.ShortChannelID.ToUint64(),
 		LastUpdate:                time.Unix(int64(msg.Timestamp), 0),
-		Flags:                     msg.Flags,
+		MessageFlags:              msg.MessageFlags,
+		ChannelFlags:              msg.ChannelFlags,
 		TimeLockDelta:             msg.TimeLockDelta,
 		MinHTLC:                   msg.HtlcMinimumMsat,
+		MaxHTLC:                   msg.HtlcMaximumMsat,
 		FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
 		FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
 	})
@@ -2036,7 +2610,7 @@ func (r *ChannelRouter) GetChannelByID(chanID lnwire.ShortChannelID) (
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) ForEachNode(cb func(*channeldb.LightningNode) error) error {
-	return r.cfg.Graph.ForEachNode(nil, func(_ *bolt.Tx, n *channeldb.LightningNode) error {
+	return r.cfg.Graph.ForEachNode(nil, func(_ kvdb.Tx, n *channeldb.LightningNode) error {
 		return cb(n)
 	})
 }
@@ -2048,7 +2622,7 @@ func (r *ChannelRouter) ForEachNode(cb func(*channeldb.LightningNode) error) err
 func (r *ChannelRouter) ForAllOutgoingChannels(cb func(*channeldb.ChannelEdgeInfo,
 	*channeldb.ChannelEdgePolicy) error) error {
 
-	return r.selfNode.ForEachChannel(nil, func(_ *bolt.Tx, c *channeldb.ChannelEdgeInfo,
+	return r.selfNode.ForEachChannel(nil, func(_ kvdb.Tx, c *channeldb.ChannelEdgeInfo,
 		e, _ *channeldb.ChannelEdgePolicy) error {
 
 		return cb(c, e)
@@ -2092,12 +2666,25 @@ func (r *ChannelRouter) IsStaleNode(node Vertex, timestamp time.Time) bool {
 }
 
 // IsKnownEdge returns true if the graph source already knows of the passed
-// channel ID.
+// channel ID, either as a channel within the graph or as a zombie.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) IsKnownEdge(chanID lnwire.ShortChannelID) bool {
 	_, _, exists, _ := r.cfg.Graph.HasChannelEdge(chanID.ToUint64())
-	return exists
+	if exists {
+		return true
+	}
+
+	isZombie, _, _, _ := r.cfg.Graph.IsZombieEdge(chanID.ToUint64())
+	return isZombie
+}
+
+// MarkEdgeLive clears the zombie mark of the channel with the given ID,
+// allowing its announcement to be accepted again.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) MarkEdgeLive(chanID lnwire.ShortChannelID) error {
+	return r.cfg.Graph.MarkEdgeLive(chanID.ToUint64())
 }
 
 // IsStaleEdgePolicy returns true if the graph soruce has a channel edge for
@@ -2105,7 +2692,7 @@ func (r *ChannelRouter) IsKnownEdge(chanID lnwire.ShortChannelID) bool {
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
-	timestamp time.Time, flags lnwire.ChanUpdateFlag) bool {
+	timestamp time.Time, flags lnwire.ChanUpdateChanFlags) bool {
 
 	edge1Timestamp, edge2Timestamp, exists, err := r.cfg.Graph.HasChannelEdge(
 		chanID.ToUint64(),
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/roasbeef/btcd/wire"
 
@@ -52,6 +53,7 @@ func (c *testCtx) RestartRouter() error {
 		},
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
+		Clock:              clock.NewDefaultClock(),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create router %v", err)
@@ -128,6 +130,7 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 		},
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
+		Clock:              clock.NewDefaultClock(),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create router %v", err)
@@ -173,7 +176,7 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := ctx.aliases["luoji"]
 	routes, err := ctx.router.FindRoutes(target, paymentAmt,
-		defaultNumRoutes, DefaultFinalCLTVDelta)
+		defaultNumRoutes, nil)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
 	}
@@ -203,6 +206,181 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	}
 }
 
+// TestFindRoutesRestrictions tests that the routes returned by FindRoutes
+// satisfy the restrictions passed in.
+func TestFindRoutesRestrictions(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := ctx.aliases["luoji"]
+	satoshi := NewVertex(ctx.aliases["satoshi"])
+	roasbeef := Vertex(ctx.router.selfNode.PubKeyBytes)
+
+	// Without any restrictions, we should find both the direct route to
+	// luo ji, and the route through satoshi.
+	routes, err := ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, nil)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("2 routes should've been selected, instead %v were: %v",
+			len(routes), spew.Sdump(routes))
+	}
+	directChanID := routes[0].Hops[0].Channel.ChannelID
+
+	// Ignoring satoshi should leave only the direct route.
+	routes, err = ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			IgnoredNodes: map[Vertex]struct{}{satoshi: {}},
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 1 || routes[0].containsNode(satoshi) {
+		t.Fatalf("only the direct route should've been selected, "+
+			"instead: %v", spew.Sdump(routes))
+	}
+
+	// Ignoring the direct channel in our direction should leave only the
+	// route through satoshi.
+	routes, err = ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			IgnoredEdges: map[DirectedEdge]struct{}{
+				{ChannelID: directChanID, From: roasbeef}: {},
+			},
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 1 || !routes[0].containsNode(satoshi) {
+		t.Fatalf("only the route through satoshi should've been "+
+			"selected, instead: %v", spew.Sdump(routes))
+	}
+
+	// Ignoring the direct channel in the opposite direction shouldn't
+	// affect the routes.
+	routes, err = ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			IgnoredEdges: map[DirectedEdge]struct{}{
+				{ChannelID: directChanID, From: NewVertex(target)}: {},
+			},
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("2 routes should've been selected, instead %v were: %v",
+			len(routes), spew.Sdump(routes))
+	}
+
+	// A CLTV limit that only allows for the final CLTV delta should leave
+	// only the direct route.
+	routes, err = ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			CLTVLimit: DefaultFinalCLTVDelta,
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 1 || len(routes[0].Hops) != 1 {
+		t.Fatalf("only the direct route should've been selected, "+
+			"instead: %v", spew.Sdump(routes))
+	}
+	if routes[0].TotalTimeLock > startingBlockHeight+DefaultFinalCLTVDelta {
+		t.Fatalf("route exceeds cltv limit: %v", spew.Sdump(routes[0]))
+	}
+
+	// A non-default final CLTV delta must be reflected in the time locks
+	// of the routes, even though routes to luo ji for the same amount
+	// have already been cached with the default delta.
+	const finalCLTVDelta = DefaultFinalCLTVDelta + 40
+	routes, err = ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			FinalCLTVDelta: finalCLTVDelta,
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	for _, route := range routes {
+		if len(route.Hops) != 1 {
+			continue
+		}
+
+		if route.TotalTimeLock != startingBlockHeight+finalCLTVDelta {
+			t.Fatalf("direct route doesn't use final cltv delta "+
+				"%v: %v", finalCLTVDelta, spew.Sdump(route))
+		}
+	}
+
+	// A node that isn't part of the graph can't be reached without any
+	// route hints.
+	unknownKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	unknownTarget := unknownKey.PubKey()
+	_, err = ctx.router.FindRoutes(unknownTarget, paymentAmt,
+		defaultNumRoutes, nil)
+	if !IsError(err, ErrTargetNotInNetwork) {
+		t.Fatalf("expected ErrTargetNotInNetwork, got: %v", err)
+	}
+
+	// With a hint of a private channel from luo ji, the node should be
+	// reached through luo ji over the hinted channel.
+	const hintChanID = 1234567
+	routes, err = ctx.router.FindRoutes(unknownTarget, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			RouteHints: [][]HopHint{{{
+				NodeID:          target,
+				ChannelID:       hintChanID,
+				CLTVExpiryDelta: 10,
+			}}},
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) == 0 {
+		t.Fatalf("no routes found through route hint")
+	}
+	for _, route := range routes {
+		lastHop := route.Hops[len(route.Hops)-1]
+		if lastHop.Channel.ChannelID != hintChanID ||
+			!route.containsNode(NewVertex(target)) {
+
+			t.Fatalf("route doesn't use route hint: %v",
+				spew.Sdump(route))
+		}
+	}
+
+	// Finally, routes found from satoshi should start at satoshi, with
+	// the cheapest being satoshi's direct channel to luo ji.
+	routes, err = ctx.router.FindRoutes(target, paymentAmt,
+		defaultNumRoutes, &RouteRestrictions{
+			Source: ctx.aliases["satoshi"],
+		})
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	for _, route := range routes {
+		if _, ok := route.nextHopMap[satoshi]; !ok {
+			t.Fatalf("route doesn't start at satoshi: %v",
+				spew.Sdump(route))
+		}
+	}
+	if len(routes[0].Hops) != 1 {
+		t.Fatalf("direct route from satoshi should've been selected "+
+			"first, instead: %v", spew.Sdump(routes[0]))
+	}
+}
+
 // TestSendPaymentRouteFailureFallback tests that when sending a payment, if
 // one of the target routes is seen as unavailable, then the next route in the
 // queue is used instead. This process should continue until either a payment
@@ -280,6 +458,510 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	}
 }
 
+// TestSendPaymentFeeLimit tests that the router won't fall back to a route
+// whose fees exceed the fee limit of the payment.
+func TestSendPaymentFeeLimit(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Craft a LightningPayment struct that'll send a payment from roasbeef
+	// to luo ji, without paying any fees.
+	var (
+		payHash  [32]byte
+		feeLimit lnwire.MilliSatoshi
+	)
+	payment := LightningPayment{
+		Target:      ctx.aliases["luoji"],
+		Amount:      lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash: payHash,
+		FeeLimit:    &feeLimit,
+	}
+
+	sourceNode := ctx.router.selfNode
+
+	// As in TestSendPaymentRouteFailureFallback, the direct path to luo ji
+	// will fail. The only other path, through satoshi, charges a fee, so
+	// it should never be attempted.
+	var numAttempts int
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		numAttempts++
+
+		if !bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
+			t.Fatalf("payment attempted over route exceeding the " +
+				"fee limit")
+		}
+
+		pub, err := sourceNode.PubKey()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return [32]byte{}, &htlcswitch.ForwardingError{
+			ErrorSource:    pub,
+			FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+		}
+	}
+
+	if _, _, err := ctx.router.SendPayment(&payment); err == nil {
+		t.Fatalf("payment should have failed")
+	}
+	if numAttempts != 1 {
+		t.Fatalf("expected 1 payment attempt, got %v", numAttempts)
+	}
+}
+
+// TestSubscribePayments tests that payment clients are notified of each state
+// transition of a payment dispatched by the router, in order.
+func TestSubscribePayments(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	client, err := ctx.router.SubscribePayments()
+	if err != nil {
+		t.Fatalf("unable to subscribe to payments: %v", err)
+	}
+	defer client.Cancel()
+
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:      ctx.aliases["luoji"],
+		Amount:      lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash: payHash,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	sourceNode := ctx.router.selfNode
+
+	// The direct route to luo ji will fail, forcing the router to make a
+	// second attempt through satoshi.
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
+			pub, err := sourceNode.PubKey()
+			if err != nil {
+				return preImage, err
+			}
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    pub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return preImage, nil
+	}
+
+	if _, _, err := ctx.router.SendPayment(&payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	expectedStates := []PaymentState{
+		PaymentInFlight, PaymentAttemptFailed, PaymentSucceeded,
+	}
+	for _, expectedState := range expectedStates {
+		var update *PaymentUpdate
+		select {
+		case update = <-client.PaymentUpdates:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("no update received for state %v",
+				expectedState)
+		}
+
+		if update.PaymentHash != payHash {
+			t.Fatalf("update for wrong payment: %x",
+				update.PaymentHash[:])
+		}
+		if update.State != expectedState {
+			t.Fatalf("expected state %v, got %v", expectedState,
+				update.State)
+		}
+
+		switch update.State {
+		case PaymentAttemptFailed:
+			if update.Route == nil || update.FailureReason == "" {
+				t.Fatalf("attempt failure missing route or "+
+					"reason: %v", spew.Sdump(update))
+			}
+
+		case PaymentSucceeded:
+			if update.Preimage != preImage {
+				t.Fatalf("incorrect preimage: expected %x "+
+					"got %x", preImage[:], update.Preimage[:])
+			}
+			if len(update.Route.Hops) != 2 {
+				t.Fatalf("incorrect route length: expected "+
+					"%v got %v", 2, len(update.Route.Hops))
+			}
+		}
+	}
+}
+
+// TestSendPaymentDuplicate tests that a payment refused by the control tower
+// of the switch, as its payment hash is already in flight or paid, isn't
+// reported as failed to payment clients.
+func TestSendPaymentDuplicate(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	client, err := ctx.router.SubscribePayments()
+	if err != nil {
+		t.Fatalf("unable to subscribe to payments: %v", err)
+	}
+	defer client.Cancel()
+
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:      ctx.aliases["luoji"],
+		Amount:      lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash: payHash,
+	}
+
+	for _, dupErr := range []error{
+		channeldb.ErrPaymentInFlight, channeldb.ErrAlreadyPaid,
+	} {
+		dupErr := dupErr
+		ctx.router.cfg.SendToSwitch = func(_ [33]byte,
+			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte,
+			error) {
+
+			return [32]byte{}, dupErr
+		}
+
+		_, _, err := ctx.router.SendPayment(&payment)
+		if err != dupErr {
+			t.Fatalf("expected %v, got %v", dupErr, err)
+		}
+
+		// Only the in flight update should be sent, as the outcome
+		// of the original payment is reported on its own.
+		select {
+		case update := <-client.PaymentUpdates:
+			if update.State != PaymentInFlight {
+				t.Fatalf("expected state %v, got %v",
+					PaymentInFlight, update.State)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("no in flight update received")
+		}
+
+		select {
+		case update := <-client.PaymentUpdates:
+			t.Fatalf("unexpected update: %v", spew.Sdump(update))
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+
+	// As the payment was already paid, it shouldn't be considered in
+	// progress anymore.
+	if ctx.router.PaymentInProgress(payHash) {
+		t.Fatal("paid payment still in progress")
+	}
+}
+
+// TestNotifyPaymentOutcome tests that payment clients are notified of the
+// outcome of a payment that wasn't dispatched by the router.
+func TestNotifyPaymentOutcome(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	client, err := ctx.router.SubscribePayments()
+	if err != nil {
+		t.Fatalf("unable to subscribe to payments: %v", err)
+	}
+	defer client.Cancel()
+
+	var payHash, preImage [32]byte
+	copy(payHash[:], bytes.Repeat([]byte{1}, 32))
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	ctx.router.NotifyPaymentOutcome(payHash, preImage, nil)
+	ctx.router.NotifyPaymentOutcome(
+		payHash, preImage, fmt.Errorf("payment failed"),
+	)
+
+	var update *PaymentUpdate
+	select {
+	case update = <-client.PaymentUpdates:
+	case <-time.After(time.Second * 5):
+		t.Fatal("no success update received")
+	}
+	if update.PaymentHash != payHash || update.State != PaymentSucceeded ||
+		update.Preimage != preImage {
+
+		t.Fatalf("unexpected success update: %v", spew.Sdump(update))
+	}
+
+	select {
+	case update = <-client.PaymentUpdates:
+	case <-time.After(time.Second * 5):
+		t.Fatal("no failure update received")
+	}
+	if update.PaymentHash != payHash || update.State != PaymentFailed ||
+		update.FailureReason != "payment failed" ||
+		update.Preimage != [32]byte{} {
+
+		t.Fatalf("unexpected failure update: %v", spew.Sdump(update))
+	}
+}
+
+// TestBuildRouteSendToRoute tests that we're able to build routes through an
+// explicit list of hops, and that SendToRoute tries the passed routes in order
+// until one of them succeeds.
+func TestBuildRouteSendToRoute(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	amt := lnwire.NewMSatFromSatoshis(1000)
+	satoshi := NewVertex(ctx.aliases["satoshi"])
+	luoji := NewVertex(ctx.aliases["luoji"])
+
+	// We'll build both the direct route to luo ji, and the route that
+	// travels through satoshi.
+	directRoute, err := ctx.router.BuildRoute(
+		amt, []Vertex{luoji}, nil, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to build direct route: %v", err)
+	}
+	if len(directRoute.Hops) != 1 {
+		t.Fatalf("incorrect route length: expected %v got %v", 1,
+			len(directRoute.Hops))
+	}
+
+	twoHopRoute, err := ctx.router.BuildRoute(
+		amt, []Vertex{satoshi, luoji}, nil, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to build two hop route: %v", err)
+	}
+	if len(twoHopRoute.Hops) != 2 {
+		t.Fatalf("incorrect route length: expected %v got %v", 2,
+			len(twoHopRoute.Hops))
+	}
+	if twoHopRoute.Hops[0].Channel.Node.Alias != "satoshi" {
+		t.Fatalf("route should go through satoshi as first hop, "+
+			"instead passes through: %v",
+			twoHopRoute.Hops[0].Channel.Node.Alias)
+	}
+	if twoHopRoute.Hops[1].AmtToForward != amt {
+		t.Fatalf("final hop should receive %v, instead receives %v",
+			amt, twoHopRoute.Hops[1].AmtToForward)
+	}
+	if twoHopRoute.TotalAmount != amt+twoHopRoute.TotalFees {
+		t.Fatalf("route total amount %v doesn't include fees %v",
+			twoHopRoute.TotalAmount, twoHopRoute.TotalFees)
+	}
+
+	// Building a route with an explicit channel should yield the same
+	// route.
+	chanIDs := []uint64{
+		twoHopRoute.Hops[0].Channel.ChannelID,
+		twoHopRoute.Hops[1].Channel.ChannelID,
+	}
+	explicitRoute, err := ctx.router.BuildRoute(
+		amt, []Vertex{satoshi, luoji}, chanIDs, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to build route with channel ids: %v", err)
+	}
+	if explicitRoute.TotalFees != twoHopRoute.TotalFees {
+		t.Fatalf("expected fees %v, got %v", twoHopRoute.TotalFees,
+			explicitRoute.TotalFees)
+	}
+
+	// A channel that doesn't connect the hops should be rejected.
+	_, err = ctx.router.BuildRoute(
+		amt, []Vertex{satoshi, luoji}, []uint64{chanIDs[1], chanIDs[0]},
+		DefaultFinalCLTVDelta,
+	)
+	if err == nil {
+		t.Fatalf("expected route with mismatched channels to fail")
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	sourceNode := ctx.router.selfNode
+
+	// We'll fail the direct route to luo ji, which should cause the
+	// router to move on to the next route we pass in.
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
+			pub, err := sourceNode.PubKey()
+			if err != nil {
+				return preImage, err
+			}
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    pub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return preImage, nil
+	}
+
+	var payHash [32]byte
+	paymentPreImage, route, err := ctx.router.SendToRoute(
+		[]*Route{directRoute, twoHopRoute}, payHash,
+	)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if route != twoHopRoute {
+		t.Fatalf("payment should have been sent over the two hop route")
+	}
+	if !bytes.Equal(paymentPreImage[:], preImage[:]) {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	// If only the failing route is passed, then the payment should fail.
+	_, _, err = ctx.router.SendToRoute([]*Route{directRoute}, payHash)
+	if err == nil {
+		t.Fatalf("expected payment over failing route to fail")
+	}
+}
+
+// TestEstimateRouteFee tests that probes are sent along the candidate routes
+// to a destination until one of them is failed by the destination due to the
+// unknown payment hash, and that the probes aren't recorded as payments.
+func TestEstimateRouteFee(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	target := ctx.aliases["luoji"]
+	amt := lnwire.NewMSatFromSatoshis(1000)
+
+	sourceNode := ctx.router.selfNode
+
+	// We'll fail the probe sent over the direct channel to luo ji, while
+	// the probe through satoshi makes it to luo ji, who fails it as it
+	// doesn't know the payment hash.
+	var probeHashes [][32]byte
+	ctx.router.cfg.SendProbeToSwitch = func(n [33]byte,
+		htlcAdd *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		probeHashes = append(probeHashes, htlcAdd.PaymentHash)
+
+		if bytes.Equal(target.SerializeCompressed(), n[:]) {
+			pub, err := sourceNode.PubKey()
+			if err != nil {
+				return [32]byte{}, err
+			}
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    pub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return [32]byte{}, &htlcswitch.ForwardingError{
+			ErrorSource:    target,
+			FailureMessage: &lnwire.FailUnknownPaymentHash{},
+		}
+	}
+	ctx.router.cfg.SendToSwitch = func(_ [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		t.Fatalf("probe sent as payment")
+		return [32]byte{}, nil
+	}
+
+	route, err := ctx.router.EstimateRouteFee(
+		target, amt, 10, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to estimate route fee: %v", err)
+	}
+
+	// The route through satoshi should have been selected, after both
+	// routes were probed.
+	if len(route.Hops) != 2 {
+		t.Fatalf("incorrect route length: expected %v got %v", 2,
+			len(route.Hops))
+	}
+	if route.Hops[0].Channel.Node.Alias != "satoshi" {
+		t.Fatalf("route should go through satoshi as first hop, "+
+			"instead passes through: %v",
+			route.Hops[0].Channel.Node.Alias)
+	}
+	if len(probeHashes) != 2 {
+		t.Fatalf("expected 2 probes, instead %v were sent",
+			len(probeHashes))
+	}
+
+	// The probes should've been sent to a random payment hash, which
+	// must not have been recorded as a payment.
+	if probeHashes[0] == ([32]byte{}) {
+		t.Fatalf("probe sent with empty payment hash")
+	}
+	_, err = ctx.graph.Database().FetchPaymentAttempt(probeHashes[0])
+	if err != channeldb.ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got: %v", err)
+	}
+
+	// If no probe makes it to the destination, then the estimation should
+	// fail.
+	ctx.router.cfg.SendProbeToSwitch = func(_ [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		pub, err := sourceNode.PubKey()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return [32]byte{}, &htlcswitch.ForwardingError{
+			ErrorSource:    pub,
+			FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+		}
+	}
+	_, err = ctx.router.EstimateRouteFee(
+		target, amt, 10, DefaultFinalCLTVDelta,
+	)
+	if err == nil {
+		t.Fatalf("expected estimation to fail")
+	}
+}
+
 // TestSendPaymentErrorRepeatedFeeInsufficient tests that if we receive
 // multiple fee related errors from a channel that we're attempting to route
 // through, then we'll prune the channel after the second attempt.
@@ -317,9 +999,11 @@ func TestSendPaymentErrorRepeatedFeeInsufficient(t *testing.T) {
 	errChanUpdate := lnwire.ChannelUpdate{
 		ShortChannelID:  lnwire.NewShortChanIDFromInt(chanID),
 		Timestamp:       uint32(edgeUpateToFail.LastUpdate.Unix()),
-		Flags:           edgeUpateToFail.Flags,
+		MessageFlags:    edgeUpateToFail.MessageFlags,
+		ChannelFlags:    edgeUpateToFail.ChannelFlags,
 		TimeLockDelta:   edgeUpateToFail.TimeLockDelta,
 		HtlcMinimumMsat: edgeUpateToFail.MinHTLC,
+		HtlcMaximumMsat: edgeUpateToFail.MaxHTLC,
 		BaseFee:         uint32(edgeUpateToFail.FeeBaseMSat),
 		FeeRate:         uint32(edgeUpateToFail.FeeProportionalMillionths),
 	}
@@ -416,9 +1100,11 @@ func TestSendPaymentErrorNonFinalTimeLockErrors(t *testing.T) {
 	errChanUpdate := lnwire.ChannelUpdate{
 		ShortChannelID:  lnwire.NewShortChanIDFromInt(chanID),
 		Timestamp:       uint32(edgeUpateToFail.LastUpdate.Unix()),
-		Flags:           edgeUpateToFail.Flags,
+		MessageFlags:    edgeUpateToFail.MessageFlags,
+		ChannelFlags:    edgeUpateToFail.ChannelFlags,
 		TimeLockDelta:   edgeUpateToFail.TimeLockDelta,
 		HtlcMinimumMsat: edgeUpateToFail.MinHTLC,
+		HtlcMaximumMsat: edgeUpateToFail.MaxHTLC,
 		BaseFee:         uint32(edgeUpateToFail.FeeBaseMSat),
 		FeeRate:         uint32(edgeUpateToFail.FeeProportionalMillionths),
 	}
@@ -843,7 +1529,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 		FeeBaseMSat:               10,
 		FeeProportionalMillionths: 10000,
 	}
-	edgePolicy.Flags = 0
+	edgePolicy.ChannelFlags = 0
 
 	if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
 		t.Fatalf("unable to update edge policy: %v", err)
@@ -859,7 +1545,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 		FeeBaseMSat:               10,
 		FeeProportionalMillionths: 10000,
 	}
-	edgePolicy.Flags = 1
+	edgePolicy.ChannelFlags = 1
 
 	if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
 		t.Fatalf("unable to update edge policy: %v", err)
@@ -939,7 +1625,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 		FeeBaseMSat:               10,
 		FeeProportionalMillionths: 10000,
 	}
-	edgePolicy.Flags = 0
+	edgePolicy.ChannelFlags = 0
 
 	if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
 		t.Fatalf("unable to update edge policy: %v", err)
@@ -954,7 +1640,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 		FeeBaseMSat:               10,
 		FeeProportionalMillionths: 10000,
 	}
-	edgePolicy.Flags = 1
+	edgePolicy.ChannelFlags = 1
 
 	if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
 		t.Fatalf("unable to update edge policy: %v", err)
@@ -964,7 +1650,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	targetNode := priv2.PubKey()
 	routes, err := ctx.router.FindRoutes(targetNode, paymentAmt,
-		defaultNumRoutes, DefaultFinalCLTVDelta)
+		defaultNumRoutes, nil)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
 	}
@@ -1007,7 +1693,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 	// Should still be able to find the routes, and the info should be
 	// updated.
 	routes, err = ctx.router.FindRoutes(targetNode, paymentAmt,
-		defaultNumRoutes, DefaultFinalCLTVDelta)
+		defaultNumRoutes, nil)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
 	}
@@ -1208,6 +1894,7 @@ func TestWakeUpOnStaleBranch(t *testing.T) {
 		},
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
+		Clock:              clock.NewDefaultClock(),
 	})
 	if err != nil {
 		t.Fatalf("unable to create router %v", err)
@@ -1617,7 +2304,7 @@ func TestFindPathFeeWeighting(t *testing.T) {
 	// path even though the direct path has a higher potential time lock.
 	path, err := findPath(
 		nil, ctx.graph, nil, sourceNode, target, ignoreVertex,
-		ignoreEdge, amt,
+		ignoreEdge, amt, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
@@ -1833,7 +2520,7 @@ func TestIsStaleEdgePolicy(t *testing.T) {
 		FeeBaseMSat:               10,
 		FeeProportionalMillionths: 10000,
 	}
-	edgePolicy.Flags = 0
+	edgePolicy.ChannelFlags = 0
 	if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
 		t.Fatalf("unable to update edge policy: %v", err)
 	}
@@ -1847,7 +2534,7 @@ func TestIsStaleEdgePolicy(t *testing.T) {
 		FeeBaseMSat:               10,
 		FeeProportionalMillionths: 10000,
 	}
-	edgePolicy.Flags = 1
+	edgePolicy.ChannelFlags = 1
 	if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
 		t.Fatalf("unable to update edge policy: %v", err)
 	}
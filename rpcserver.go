@@ -19,9 +19,10 @@ import (
 	"sync"
 	"sync/atomic"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/graphexport"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -136,6 +137,10 @@ var (
 			Entity: "onchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/BumpFee": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/SendMany": {{
 			Entity: "onchain",
 			Action: "write",
@@ -178,6 +183,13 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/BatchOpenChannel": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/CloseChannel": {{
 			Entity: "onchain",
 			Action: "write",
@@ -189,10 +201,22 @@ var (
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/UpdateNodeAnnouncement": {{
+			Entity: "info",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ListPeers": {{
 			Entity: "peers",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ListBannedPeers": {{
+			Entity: "peers",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/UnbanPeer": {{
+			Entity: "peers",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/WalletBalance": {{
 			Entity: "onchain",
 			Action: "read",
@@ -209,6 +233,10 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ClosedChannels": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/SendPayment": {{
 			Entity: "offchain",
 			Action: "write",
@@ -217,6 +245,18 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/SendToRoute": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/TrackPayment": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/TrackPayments": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/AddInvoice": {{
 			Entity: "invoices",
 			Action: "write",
@@ -245,6 +285,10 @@ var (
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ExportGraph": {{
+			Entity: "info",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/GetChanInfo": {{
 			Entity: "info",
 			Action: "read",
@@ -257,6 +301,14 @@ var (
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/BuildRoute": {{
+			Entity: "info",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/EstimateRouteFee": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/GetNetworkInfo": {{
 			Entity: "info",
 			Action: "read",
@@ -454,6 +506,69 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 	return &lnrpc.SendCoinsResponse{Txid: txid.String()}, nil
 }
 
+// BumpFee speeds up the confirmation of an unconfirmed transaction by
+// broadcasting a child-pays-for-parent transaction spending one of its outputs
+// that pays to the wallet.
+func (r *rpcServer) BumpFee(ctx context.Context,
+	in *lnrpc.BumpFeeRequest) (*lnrpc.BumpFeeResponse, error) {
+
+	if in.Outpoint == nil {
+		return nil, fmt.Errorf("an outpoint must be specified")
+	}
+
+	// The txid of the outpoint can be set as either a byte slice or a
+	// string.
+	var txid *chainhash.Hash
+	switch {
+	case len(in.Outpoint.TxidBytes) != 0:
+		hash, err := chainhash.NewHash(in.Outpoint.TxidBytes)
+		if err != nil {
+			return nil, err
+		}
+		txid = hash
+
+	case in.Outpoint.TxidStr != "":
+		hash, err := chainhash.NewHashFromStr(in.Outpoint.TxidStr)
+		if err != nil {
+			return nil, err
+		}
+		txid = hash
+
+	default:
+		return nil, fmt.Errorf("the txid of the outpoint must be " +
+			"specified")
+	}
+	op := wire.OutPoint{
+		Hash:  *txid,
+		Index: in.Outpoint.OutputIndex,
+	}
+
+	// Based on the passed fee related parameters, we'll determine the fee
+	// rate the parent and child transaction should reach as a package.
+	feeRate, err := determineFeePerVSize(
+		r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[bumpfee] outpoint=%v, sat/vbyte=%v", op,
+		int64(feeRate))
+
+	child, fee, err := r.server.feeBumper.BumpFee(op, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	childHash := child.TxHash()
+	rpcsLog.Infof("[bumpfee] child tx generated txid: %v", childHash)
+
+	return &lnrpc.BumpFeeResponse{
+		Txid:   childHash.String(),
+		FeeSat: int64(fee),
+	}, nil
+}
+
 // SendMany handles a request for a transaction create multiple specified
 // outputs in parallel.
 func (r *rpcServer) SendMany(ctx context.Context,
@@ -706,6 +821,24 @@ func (r *rpcServer) DisconnectPeer(ctx context.Context,
 	return &lnrpc.DisconnectPeerResponse{}, nil
 }
 
+// parseUpfrontShutdownAddress converts the passed address, that our funds
+// should be paid out to upon a cooperative close, into its script. An empty
+// address results in an empty script, meaning we won't commit to an address.
+func parseUpfrontShutdownAddress(address string) (lnwire.DeliveryAddress,
+	error) {
+
+	if address == "" {
+		return nil, nil
+	}
+
+	addr, err := btcutil.DecodeAddress(address, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid close address: %v", err)
+	}
+
+	return txscript.PayToAddrScript(addr)
+}
+
 // OpenChannel attempts to open a singly funded channel specified in the
 // request to a remote peer.
 func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
@@ -737,10 +870,15 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 
 	// Ensure that the user doesn't exceed the current soft-limit for
 	// channel size. If the funding amount is above the soft-limit, then
-	// we'll reject the request.
-	if localFundingAmt > maxFundingAmount {
+	// we'll reject the request. If large channels are enabled, the
+	// funding manager will further ensure that the peer supports them.
+	maxChanSize := maxFundingAmount
+	if cfg.LargeChannels {
+		maxChanSize = maxLargeFundingAmount
+	}
+	if localFundingAmt > maxChanSize {
 		return fmt.Errorf("funding amount is too large, the max "+
-			"channel size is: %v", maxFundingAmount)
+			"channel size is: %v", maxChanSize)
 	}
 
 	// Restrict the size of the channel we'll actually open. At a later
@@ -757,6 +895,13 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 		err             error
 	)
 
+	// If the user specified an address to commit to for cooperative
+	// closes, then we'll convert it into its script.
+	shutdownScript, err := parseUpfrontShutdownAddress(in.CloseAddress)
+	if err != nil {
+		return err
+	}
+
 	// TODO(roasbeef): also return channel ID?
 
 	// Ensure that the NodePubKey is set before attempting to use it
@@ -797,7 +942,7 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	updateChan, errChan := r.server.OpenChannel(
 		nodePubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc, feeRate, in.Private, remoteCsvDelay,
+		minHtlc, feeRate, in.Private, remoteCsvDelay, shutdownScript,
 	)
 
 	var outpoint wire.OutPoint
@@ -894,6 +1039,13 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	minHtlc := lnwire.MilliSatoshi(in.MinHtlcMsat)
 	remoteCsvDelay := uint16(in.RemoteCsvDelay)
 
+	// If the user specified an address to commit to for cooperative
+	// closes, then we'll convert it into its script.
+	shutdownScript, err := parseUpfrontShutdownAddress(in.CloseAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure that the initial balance of the remote party (if pushing
 	// satoshis) does not exceed the amount the local party has requested
 	// for funding.
@@ -925,7 +1077,7 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	updateChan, errChan := r.server.OpenChannel(
 		nodepubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc, feeRate, in.Private, remoteCsvDelay,
+		minHtlc, feeRate, in.Private, remoteCsvDelay, shutdownScript,
 	)
 
 	select {
@@ -957,6 +1109,124 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	}
 }
 
+// BatchOpenChannel attempts to open several singly funded channels, possibly
+// with different peers, within a single funding transaction. The call returns
+// once the funding transaction has been broadcast.
+func (r *rpcServer) BatchOpenChannel(ctx context.Context,
+	in *lnrpc.BatchOpenChannelRequest) (*lnrpc.BatchOpenChannelResponse,
+	error) {
+
+	rpcsLog.Tracef("[batchopenchannel] request to open %v channels",
+		len(in.Channels))
+
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	isSynced, _, err := r.server.cc.wallet.IsSynced()
+	if err != nil {
+		return nil, err
+	}
+	if !isSynced {
+		return nil, errors.New("channels cannot be created before the " +
+			"wallet is fully synced")
+	}
+
+	if len(in.Channels) == 0 {
+		return nil, fmt.Errorf("at least one channel must be specified")
+	}
+
+	maxChanSize := maxFundingAmount
+	if cfg.LargeChannels {
+		maxChanSize = maxLargeFundingAmount
+	}
+
+	// We'll apply the same restrictions to each channel within the batch
+	// as we would if it was opened on its own.
+	reqs := make([]*openChanReq, 0, len(in.Channels))
+	for _, channel := range in.Channels {
+		localFundingAmt := btcutil.Amount(channel.LocalFundingAmount)
+		remoteInitialBalance := btcutil.Amount(channel.PushSat)
+
+		if remoteInitialBalance >= localFundingAmt {
+			return nil, fmt.Errorf("amount pushed to remote peer " +
+				"for initial state must be below the local " +
+				"funding amount")
+		}
+		if localFundingAmt > maxChanSize {
+			return nil, fmt.Errorf("funding amount is too large, "+
+				"the max channel size is: %v", maxChanSize)
+		}
+		if localFundingAmt < minChanFundingSize {
+			return nil, fmt.Errorf("channel is too small, the "+
+				"minimum channel size is: %v SAT",
+				int64(minChanFundingSize))
+		}
+
+		if len(channel.NodePubkey) == 0 {
+			return nil, fmt.Errorf("NodePubKey is not set")
+		}
+		nodePubKey, err := btcec.ParsePubKey(
+			channel.NodePubkey, btcec.S256(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if nodePubKey.IsEqual(r.server.identityPriv.PubKey()) {
+			return nil, fmt.Errorf("cannot open channel to self")
+		}
+
+		reqs = append(reqs, &openChanReq{
+			targetPubkey:    nodePubKey,
+			localFundingAmt: localFundingAmt,
+			pushAmt: lnwire.NewMSatFromSatoshis(
+				remoteInitialBalance,
+			),
+			private:        channel.Private,
+			minHtlc:        lnwire.MilliSatoshi(channel.MinHtlcMsat),
+			remoteCsvDelay: uint16(channel.RemoteCsvDelay),
+		})
+	}
+
+	// Based on the passed fee related parameters, we'll determine an
+	// appropriate fee rate for the funding transaction.
+	feeRate, err := determineFeePerVSize(
+		r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[batchopenchannel]: using fee of %v sat/vbyte for "+
+		"funding tx", int64(feeRate))
+
+	respChan, errChan := r.server.BatchOpenChannel(reqs, feeRate)
+
+	select {
+	case err := <-errChan:
+		rpcsLog.Errorf("unable to open batch of channels: %v", err)
+		return nil, err
+
+	case fundingPoints := <-respChan:
+		resp := &lnrpc.BatchOpenChannelResponse{}
+		for _, fundingPoint := range fundingPoints {
+			txid := fundingPoint.Hash
+			resp.PendingChannels = append(
+				resp.PendingChannels, &lnrpc.PendingUpdate{
+					Txid:        txid[:],
+					OutputIndex: fundingPoint.Index,
+				},
+			)
+		}
+
+		return resp, nil
+
+	case <-r.quit:
+		return nil, nil
+	}
+}
+
 // getChanPointFundingTxid returns the given channel point's funding txid in
 // raw bytes.
 func getChanPointFundingTxid(chanPoint *lnrpc.ChannelPoint) ([]byte, error) {
@@ -1252,7 +1522,7 @@ func (r *rpcServer) GetInfo(ctx context.Context,
 	}
 
 	// TODO(roasbeef): add synced height n stuff
-	return &lnrpc.GetInfoResponse{
+	resp := &lnrpc.GetInfoResponse{
 		IdentityPubkey:      encodedIDPub,
 		NumPendingChannels:  nPendingChannels,
 		NumActiveChannels:   activeChannels,
@@ -1266,7 +1536,108 @@ func (r *rpcServer) GetInfo(ctx context.Context,
 		Alias:               nodeAnn.Alias.String(),
 		BestHeaderTimestamp: int64(bestHeaderTimestamp),
 		Version:             version(),
-	}, nil
+	}
+
+	// If we're part of a cluster, then we'll also report our ID within
+	// it. There's no need to report whether we're the leader, as the RPC
+	// server is only started once we've been elected, and lnd shuts down
+	// as soon as the leadership is lost.
+	if r.server.clusterStatus != nil {
+		resp.ClusterEnabled = true
+		resp.ClusterId = r.server.clusterStatus.ID()
+	}
+
+	return resp, nil
+}
+
+// UpdateNodeAnnouncement modifies the alias, color, advertised addresses and
+// optional feature bits of our node announcement, then re-signs it and
+// broadcasts it to the network.
+func (r *rpcServer) UpdateNodeAnnouncement(ctx context.Context,
+	in *lnrpc.UpdateNodeAnnouncementRequest) (
+	*lnrpc.UpdateNodeAnnouncementResponse, error) {
+
+	var updates []nodeAnnModifier
+
+	if in.Alias != "" {
+		alias, err := lnwire.NewNodeAlias(in.Alias)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, nodeAnnSetAlias(alias))
+	}
+
+	if in.Color != "" {
+		color, err := parseHexColor(in.Color)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, nodeAnnSetColor(color))
+	}
+
+	if in.UpdateAddresses {
+		addrs := make([]net.Addr, 0, len(in.Addresses))
+		for _, address := range in.Addresses {
+			// If the address doesn't already have a port, we'll
+			// assume the current default port.
+			addr := address
+			if _, _, err := net.SplitHostPort(address); err != nil {
+				addr = net.JoinHostPort(
+					address, strconv.Itoa(defaultPeerPort),
+				)
+			}
+
+			// We use ResolveTCPAddr here in case we wish to resolve
+			// hosts over Tor.
+			tcpAddr, err := cfg.net.ResolveTCPAddr("tcp", addr)
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, tcpAddr)
+		}
+		updates = append(updates, nodeAnnSetAddrs(addrs))
+	}
+
+	// Only optional feature bits may be changed, as toggling required
+	// ones could render us incompatible with the rest of the network.
+	toFeatureBits := func(bits []uint32) ([]lnwire.FeatureBit, error) {
+		featureBits := make([]lnwire.FeatureBit, 0, len(bits))
+		for _, bit := range bits {
+			if bit%2 == 0 {
+				return nil, fmt.Errorf("feature bit %v is not "+
+					"optional", bit)
+			}
+			featureBits = append(featureBits, lnwire.FeatureBit(bit))
+		}
+		return featureBits, nil
+	}
+	setFeatures, err := toFeatureBits(in.SetFeatures)
+	if err != nil {
+		return nil, err
+	}
+	unsetFeatures, err := toFeatureBits(in.UnsetFeatures)
+	if err != nil {
+		return nil, err
+	}
+	if len(setFeatures) != 0 || len(unsetFeatures) != 0 {
+		updates = append(
+			updates, nodeAnnUpdateFeatures(setFeatures, unsetFeatures),
+		)
+	}
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("no node announcement updates specified")
+	}
+
+	nodeAnn, err := r.server.UpdateNodeAnnouncement(updates...)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("Updated node announcement: alias=%v, addresses=%v",
+		nodeAnn.Alias.String(), nodeAnn.Addresses)
+
+	return &lnrpc.UpdateNodeAnnouncementResponse{}, nil
 }
 
 // ListPeers returns a verbose listing of all currently active peers.
@@ -1317,6 +1688,55 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 	return resp, nil
 }
 
+// ListBannedPeers returns all peers that are currently banned for sending
+// invalid gossip or violating the protocol.
+func (r *rpcServer) ListBannedPeers(ctx context.Context,
+	in *lnrpc.ListBannedPeersRequest) (*lnrpc.ListBannedPeersResponse, error) {
+
+	rpcsLog.Tracef("[listbannedpeers] request")
+
+	bans, err := r.server.peerBanMgr.BannedPeers()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListBannedPeersResponse{
+		Peers: make([]*lnrpc.BannedPeer, 0, len(bans)),
+	}
+	for _, ban := range bans {
+		resp.Peers = append(resp.Peers, &lnrpc.BannedPeer{
+			PubKey:      hex.EncodeToString(ban.PubKey[:]),
+			BannedUntil: ban.BannedUntil.Unix(),
+			Reason:      ban.Reason,
+		})
+	}
+
+	return resp, nil
+}
+
+// UnbanPeer lifts the ban of the target peer and clears its misbehavior
+// score, allowing connections with it once again.
+func (r *rpcServer) UnbanPeer(ctx context.Context,
+	in *lnrpc.UnbanPeerRequest) (*lnrpc.UnbanPeerResponse, error) {
+
+	rpcsLog.Debugf("[unbanpeer] peer(%s)", in.PubKey)
+
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode pubkey bytes: %v", err)
+	}
+	peerPubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pubkey: %v", err)
+	}
+
+	if err := r.server.peerBanMgr.Unban(peerPubKey); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.UnbanPeerResponse{}, nil
+}
+
 // WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
 // confirmed unspent outputs and all unconfirmed unspent outputs under control
 // by the wallet. This method can be modified by having the request specify
@@ -1707,63 +2127,262 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 	return resp, nil
 }
 
-// savePayment saves a successfully completed payment to the database for
-// historical record keeping.
-func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi, preImage []byte) error {
+// ClosedChannels returns a description of all the closed channels that this
+// node was a participant in, along with the on-chain resolution of each of
+// their outputs. Channels that are still in the process of closing are
+// omitted, as they're returned by PendingChannels instead.
+func (r *rpcServer) ClosedChannels(ctx context.Context,
+	in *lnrpc.ClosedChannelsRequest) (*lnrpc.ClosedChannelsResponse, error) {
 
-	paymentPath := make([][33]byte, len(route.Hops))
-	for i, hop := range route.Hops {
-		hopPub := hop.Channel.Node.PubKeyBytes
-		copy(paymentPath[i][:], hopPub[:])
-	}
+	// If no filters are set, then we'll return channels of every close
+	// type.
+	filterResults := in.Cooperative || in.LocalForce || in.RemoteForce ||
+		in.Breach || in.FundingCanceled
 
-	payment := &channeldb.OutgoingPayment{
-		Invoice: channeldb.Invoice{
-			Terms: channeldb.ContractTerm{
-				Value: amount,
-			},
-			CreationDate: time.Now(),
-		},
-		Path:           paymentPath,
-		Fee:            route.TotalFees,
-		TimeLockLength: route.TotalTimeLock,
-	}
-	copy(payment.PaymentPreimage[:], preImage)
+	resp := &lnrpc.ClosedChannelsResponse{}
 
-	return r.server.chanDB.AddPayment(payment)
-}
+	dbChannels, err := r.server.chanDB.FetchClosedChannels(false)
+	switch {
+	// If we've never closed a channel, there's nothing to return.
+	case err == channeldb.ErrNoClosedChannels:
+		return resp, nil
 
-// validatePayReqExpiry checks if the passed payment request has expired. In
-// the case it has expired, an error will be returned.
-func validatePayReqExpiry(payReq *zpay32.Invoice) error {
-	expiry := payReq.Expiry()
-	validUntil := payReq.Timestamp.Add(expiry)
-	if time.Now().After(validUntil) {
-		return fmt.Errorf("invoice expired. Valid until %v", validUntil)
+	case err != nil:
+		return nil, err
 	}
 
-	return nil
-}
+	rpcsLog.Infof("[closedchannels] fetched %v channels from DB",
+		len(dbChannels))
 
-// SendPayment dispatches a bi-directional streaming RPC for sending payments
-// through the Lightning Network. A single RPC invocation creates a persistent
-// bi-directional stream allowing clients to rapidly send payments through the
+	for _, dbChannel := range dbChannels {
+		if dbChannel.IsPending {
+			continue
+		}
+
+		var closeType lnrpc.ChannelCloseSummary_ClosureType
+		switch dbChannel.CloseType {
+		case channeldb.CooperativeClose:
+			if filterResults && !in.Cooperative {
+				continue
+			}
+			closeType = lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE
+
+		case channeldb.LocalForceClose:
+			if filterResults && !in.LocalForce {
+				continue
+			}
+			closeType = lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE
+
+		case channeldb.RemoteForceClose:
+			if filterResults && !in.RemoteForce {
+				continue
+			}
+			closeType = lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE
+
+		case channeldb.BreachClose:
+			if filterResults && !in.Breach {
+				continue
+			}
+			closeType = lnrpc.ChannelCloseSummary_BREACH_CLOSE
+
+		case channeldb.FundingCanceled:
+			if filterResults && !in.FundingCanceled {
+				continue
+			}
+			closeType = lnrpc.ChannelCloseSummary_FUNDING_CANCELED
+
+		default:
+			return nil, fmt.Errorf("unknown close type %v for "+
+				"ChannelPoint(%v)", dbChannel.CloseType,
+				dbChannel.ChanPoint)
+		}
+
+		reports, err := r.server.chanDB.FetchResolverReports(
+			&dbChannel.ChanPoint,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		channel := &lnrpc.ChannelCloseSummary{
+			ChannelPoint:      dbChannel.ChanPoint.String(),
+			ChanId:            dbChannel.ShortChanID.ToUint64(),
+			ChainHash:         dbChannel.ChainHash.String(),
+			ClosingTxHash:     dbChannel.ClosingTXID.String(),
+			RemotePubkey:      hex.EncodeToString(dbChannel.RemotePub.SerializeCompressed()),
+			Capacity:          int64(dbChannel.Capacity),
+			CloseHeight:       dbChannel.CloseHeight,
+			SettledBalance:    int64(dbChannel.SettledBalance),
+			TimeLockedBalance: int64(dbChannel.TimeLockedBalance),
+			CloseType:         closeType,
+			Resolutions:       make([]*lnrpc.Resolution, len(reports)),
+		}
+
+		for i, report := range reports {
+			var sweepTxid string
+			if report.SpendTxID != nil {
+				sweepTxid = report.SpendTxID.String()
+			}
+
+			// The resolver types and outcomes share the numbering
+			// of their RPC counterparts.
+			channel.Resolutions[i] = &lnrpc.Resolution{
+				ResolutionType: lnrpc.Resolution_ResolutionType(
+					report.ResolverType,
+				),
+				Outcome: lnrpc.Resolution_ResolutionOutcome(
+					report.ResolverOutcome,
+				),
+				Outpoint:  report.OutPoint.String(),
+				AmountSat: int64(report.Amount),
+				SweepTxid: sweepTxid,
+			}
+		}
+
+		resp.Channels = append(resp.Channels, channel)
+	}
+
+	return resp, nil
+}
+
+// savePayment saves a successfully completed payment to the database for
+// historical record keeping.
+func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi, preImage []byte) error {
+
+	paymentPath := make([][33]byte, len(route.Hops))
+	for i, hop := range route.Hops {
+		hopPub := hop.Channel.Node.PubKeyBytes
+		copy(paymentPath[i][:], hopPub[:])
+	}
+
+	payment := &channeldb.OutgoingPayment{
+		Invoice: channeldb.Invoice{
+			Terms: channeldb.ContractTerm{
+				Value: amount,
+			},
+			CreationDate: r.server.clock.Now(),
+		},
+		Path:           paymentPath,
+		Fee:            route.TotalFees,
+		TimeLockLength: route.TotalTimeLock,
+	}
+	copy(payment.PaymentPreimage[:], preImage)
+
+	return r.server.chanDB.AddPayment(payment)
+}
+
+// validatePayReqExpiry checks if the passed payment request has expired as of
+// the given time. In the case it has expired, an error will be returned.
+func validatePayReqExpiry(payReq *zpay32.Invoice, now time.Time) error {
+	expiry := payReq.Expiry()
+	validUntil := payReq.Timestamp.Add(expiry)
+	if now.After(validUntil) {
+		return fmt.Errorf("invoice expired. Valid until %v", validUntil)
+	}
+
+	return nil
+}
+
+// rpcSpendAmount returns the amount in satoshis that the passed RPC request
+// will spend. It is used by the macaroon service to enforce the spend limit
+// and daily budget caveats. Requests that don't spend funds return zero.
+func rpcSpendAmount(method string, req interface{}) (btcutil.Amount, error) {
+	switch r := req.(type) {
+	case *lnrpc.SendRequest:
+		// Without a fee limit, the fees paid to route the payment
+		// aren't bounded, so we can't tell how much it will spend.
+		if r.FeeLimit <= 0 {
+			return 0, fmt.Errorf("a fee limit must be set for " +
+				"payments made with a spend limited macaroon")
+		}
+		feeLimit := btcutil.Amount(r.FeeLimit)
+
+		if r.PaymentRequest == "" {
+			return btcutil.Amount(r.Amt) + feeLimit, nil
+		}
+
+		payReq, err := zpay32.Decode(
+			r.PaymentRequest, activeNetParams.Params,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		// As in SendPayment, an amount within the invoice overrides
+		// the amount of the request. Fractional satoshis are rounded
+		// up so that the limit can't be exceeded by tiny amounts.
+		if payReq.MilliSat == nil {
+			return btcutil.Amount(r.Amt) + feeLimit, nil
+		}
+		return (*payReq.MilliSat + 999).ToSatoshis() + feeLimit, nil
+
+	case *lnrpc.SendToRouteRequest:
+		// As at most one of the routes will succeed, the request can
+		// spend no more than the most expensive of them. The total
+		// amount of a route already includes the fees paid along it.
+		var maxAmt lnwire.MilliSatoshi
+		for _, route := range r.Routes {
+			amt := lnwire.MilliSatoshi(route.TotalAmtMsat)
+			if amt == 0 {
+				amt = lnwire.NewMSatFromSatoshis(
+					btcutil.Amount(route.TotalAmt),
+				)
+			}
+			if amt > maxAmt {
+				maxAmt = amt
+			}
+		}
+		return (maxAmt + 999).ToSatoshis(), nil
+
+	case *lnrpc.SendCoinsRequest:
+		return btcutil.Amount(r.Amount), nil
+
+	case *lnrpc.SendManyRequest:
+		var total btcutil.Amount
+		for _, amt := range r.AddrToAmount {
+			total += btcutil.Amount(amt)
+		}
+		return total, nil
+
+	// Opening a channel commits the local funding amount to it, and hands
+	// the pushed amount over to the remote party.
+	case *lnrpc.OpenChannelRequest:
+		return btcutil.Amount(r.LocalFundingAmount + r.PushSat), nil
+
+	case *lnrpc.BatchOpenChannelRequest:
+		var total btcutil.Amount
+		for _, channel := range r.Channels {
+			total += btcutil.Amount(
+				channel.LocalFundingAmount + channel.PushSat,
+			)
+		}
+		return total, nil
+
+	default:
+		return 0, nil
+	}
+}
+
+// SendPayment dispatches a bi-directional streaming RPC for sending payments
+// through the Lightning Network. A single RPC invocation creates a persistent
+// bi-directional stream allowing clients to rapidly send payments through the
 // Lightning Network with a single persistent connection.
 func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer) error {
 	// For each payment we need to know the msat amount, the destination
-	// public key, the payment hash, and the optional route hints.
+	// public key, the payment hash, and the optional route hints and fee
+	// limit.
 	type payment struct {
 		msat       lnwire.MilliSatoshi
 		dest       []byte
 		pHash      []byte
 		cltvDelta  uint16
 		routeHints [][]routing.HopHint
+		feeLimit   lnwire.MilliSatoshi
 	}
 	payChan := make(chan *payment)
 	errChan := make(chan error, 1)
 
-	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
-	//  * limit either a %, or absolute, or iff more than sending
+	// TODO(roasbeef): allow fee limits as a % of the amount sent
 
 	// We don't allow payments to be sent while the daemon itself is still
 	// syncing as we may be trying to sent a payment over a "stale"
@@ -1843,7 +2462,9 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 
 					// We first check that this payment
 					// request has not expired.
-					err = validatePayReqExpiry(payReq)
+					err = validatePayReqExpiry(
+						payReq, r.server.clock.Now(),
+					)
 					if err != nil {
 						select {
 						case errChan <- err:
@@ -1882,6 +2503,12 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 					p.cltvDelta = uint16(nextPayment.FinalCltvDelta)
 				}
 
+				if nextPayment.FeeLimit > 0 {
+					p.feeLimit = lnwire.NewMSatFromSatoshis(
+						btcutil.Amount(nextPayment.FeeLimit),
+					)
+				}
+
 				select {
 				case payChan <- p:
 				case <-reqQuit:
@@ -1958,6 +2585,9 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				if p.cltvDelta != 0 {
 					payment.FinalCLTVDelta = &p.cltvDelta
 				}
+				if p.feeLimit != 0 {
+					payment.FeeLimit = &p.feeLimit
+				}
 				preImage, route, err := r.server.chanRouter.SendPayment(payment)
 				if err != nil {
 					// If we receive payment error than,
@@ -2028,7 +2658,8 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		}
 
 		// We first check that this payment request has not expired.
-		if err := validatePayReqExpiry(payReq); err != nil {
+		err = validatePayReqExpiry(payReq, r.server.clock.Now())
+		if err != nil {
 			return nil, err
 		}
 
@@ -2104,6 +2735,12 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	if cltvDelta != 0 {
 		payment.FinalCLTVDelta = &cltvDelta
 	}
+	if nextPayment.FeeLimit > 0 {
+		feeLimit := lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(nextPayment.FeeLimit),
+		)
+		payment.FeeLimit = &feeLimit
+	}
 	preImage, route, err := r.server.chanRouter.SendPayment(payment)
 	if err != nil {
 		return &lnrpc.SendResponse{
@@ -2123,6 +2760,368 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	}, nil
 }
 
+// SendToRoute is the synchronous non-streaming version of SendPayment which
+// dispatches the payment along the passed routes rather than ones found by
+// the channel router. The routes are tried in order until the payment
+// succeeds or a terminal error is encountered.
+func (r *rpcServer) SendToRoute(ctx context.Context,
+	req *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	if len(req.Routes) == 0 {
+		return nil, fmt.Errorf("unable to send, no routes provided")
+	}
+
+	var (
+		paymentHash []byte
+		rHash       [32]byte
+		err         error
+	)
+	if req.PaymentHashString != "" {
+		paymentHash, err = hex.DecodeString(req.PaymentHashString)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		paymentHash = req.PaymentHash
+	}
+	if len(paymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(paymentHash))
+	}
+	copy(rHash[:], paymentHash)
+
+	routes := make([]*routing.Route, len(req.Routes))
+	for i, rpcRoute := range req.Routes {
+		route, err := r.unmarshallRoute(rpcRoute)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route %v: %v", i, err)
+		}
+
+		// Currently, within the bootstrap phase of the network, we
+		// limit the largest payment size allotted to (2^32) - 1 mSAT
+		// or 4.29 million satoshis.
+		if route.TotalAmount > maxPaymentMSat {
+			err := fmt.Errorf("payment of %v is too large, max "+
+				"payment allowed is %v", route.TotalAmount,
+				maxPaymentMSat)
+			return &lnrpc.SendResponse{
+				PaymentError: err.Error(),
+			}, nil
+		}
+
+		routes[i] = route
+	}
+
+	preImage, route, err := r.server.chanRouter.SendToRoute(routes, rHash)
+	if err != nil {
+		return &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}, nil
+	}
+
+	// With the payment completed successfully, we now save the details of
+	// the completed payment to the database for historical record keeping.
+	amtMSat := route.TotalAmount - route.TotalFees
+	if err := r.savePayment(route, amtMSat, preImage[:]); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SendResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshallRoute(route),
+	}, nil
+}
+
+// unmarshallRoute converts an RPC route into the format required by the
+// channel router. The public key of a hop may be omitted, in which case it's
+// derived from the hop's channel and the public key of the prior hop.
+func (r *rpcServer) unmarshallRoute(rpcRoute *lnrpc.Route) (*routing.Route,
+	error) {
+
+	graph := r.server.chanDB.ChannelGraph()
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		return nil, err
+	}
+	sourceVertex := routing.Vertex(sourceNode.PubKeyBytes)
+
+	// If the amounts weren't specified in milli-satoshis, then we'll fall
+	// back to the amounts in satoshis.
+	msat := func(amtMSat, amt int64) lnwire.MilliSatoshi {
+		if amtMSat != 0 {
+			return lnwire.MilliSatoshi(amtMSat)
+		}
+		return lnwire.NewMSatFromSatoshis(btcutil.Amount(amt))
+	}
+
+	hops := make([]*routing.Hop, len(rpcRoute.Hops))
+	prevNode := sourceVertex
+	for i, rpcHop := range rpcRoute.Hops {
+		var pubKey routing.Vertex
+		if rpcHop.PubKey != "" {
+			pubKeyBytes, err := hex.DecodeString(rpcHop.PubKey)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := btcec.ParsePubKey(
+				pubKeyBytes, btcec.S256(),
+			); err != nil {
+				return nil, err
+			}
+			copy(pubKey[:], pubKeyBytes)
+		} else {
+			info, _, _, err := graph.FetchChannelEdgesByID(
+				rpcHop.ChanId,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch "+
+					"channel %v: %v", rpcHop.ChanId, err)
+			}
+
+			switch prevNode {
+			case info.NodeKey1Bytes:
+				pubKey = info.NodeKey2Bytes
+			case info.NodeKey2Bytes:
+				pubKey = info.NodeKey1Bytes
+			default:
+				return nil, fmt.Errorf("channel %v isn't "+
+					"connected to %x", rpcHop.ChanId,
+					prevNode[:])
+			}
+		}
+
+		hops[i] = &routing.Hop{
+			Channel: &routing.ChannelHop{
+				Capacity: btcutil.Amount(rpcHop.ChanCapacity),
+				ChannelEdgePolicy: &channeldb.ChannelEdgePolicy{
+					ChannelID: rpcHop.ChanId,
+					Node: &channeldb.LightningNode{
+						PubKeyBytes: pubKey,
+					},
+				},
+			},
+			OutgoingTimeLock: rpcHop.Expiry,
+			AmtToForward: msat(
+				rpcHop.AmtToForwardMsat, rpcHop.AmtToForward,
+			),
+			Fee: msat(rpcHop.FeeMsat, rpcHop.Fee),
+		}
+		prevNode = pubKey
+	}
+
+	return routing.NewRouteFromHops(
+		msat(rpcRoute.TotalAmtMsat, rpcRoute.TotalAmt),
+		rpcRoute.TotalTimeLock, sourceVertex, hops,
+	)
+}
+
+// TrackPayment returns a uni-directional stream (server -> client) of the state
+// transitions of the outgoing payment with the target payment hash. If the
+// payment has already completed, its final state is sent and the stream is
+// closed.
+func (r *rpcServer) TrackPayment(req *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
+
+	var (
+		paymentHash [32]byte
+		rHash       []byte
+		err         error
+	)
+
+	// If the payment hash as a raw string was provided, then decode that
+	// and use that directly. Otherwise, we use the raw bytes provided.
+	if req.PaymentHashStr != "" {
+		rHash, err = hex.DecodeString(req.PaymentHashStr)
+		if err != nil {
+			return err
+		}
+	} else {
+		rHash = req.PaymentHash
+	}
+
+	// Ensure that the payment hash is *exactly* 32-bytes.
+	if len(rHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes, is "+
+			"instead %v", len(rHash))
+	}
+	copy(paymentHash[:], rHash)
+
+	rpcsLog.Debugf("[trackpayment] payment_hash=%x", paymentHash[:])
+
+	// We'll subscribe to payment updates before examining the stored
+	// state of the payment, to ensure we don't miss any transition in
+	// between.
+	client, err := r.server.chanRouter.SubscribePayments()
+	if err != nil {
+		return err
+	}
+	defer client.Cancel()
+
+	// fetchFinalState returns the final state of the payment if it has
+	// completed, or nil if it's still in flight.
+	fetchFinalState := func() (*lnrpc.PaymentUpdate, error) {
+		attempt, err := r.server.chanDB.FetchPaymentAttempt(paymentHash)
+		if err != nil {
+			return nil, err
+		}
+
+		// A failed attempt doesn't mean the payment is complete if
+		// the router is still trying other routes.
+		switch {
+		case attempt.Status == channeldb.StatusSucceeded:
+		case attempt.Status == channeldb.StatusFailed &&
+			!r.server.chanRouter.PaymentInProgress(paymentHash):
+		default:
+			return nil, nil
+		}
+
+		return marshallPaymentAttempt(attempt), nil
+	}
+
+	finalState, err := fetchFinalState()
+	switch {
+	case err == channeldb.ErrPaymentNotInitiated:
+		return fmt.Errorf("payment %x not found", paymentHash[:])
+	case err != nil:
+		return err
+	case finalState != nil:
+		return updateStream.Send(finalState)
+	}
+
+	err = updateStream.Send(&lnrpc.PaymentUpdate{
+		PaymentHash: paymentHash[:],
+		State:       lnrpc.PaymentUpdate_IN_FLIGHT,
+	})
+	if err != nil {
+		return err
+	}
+
+	// From here on, the outcome of the payment is reported by the router,
+	// including that of a payment sent before a restart, which is relayed
+	// by the switch once it's resolved.
+	for {
+		select {
+		case update, ok := <-client.PaymentUpdates:
+			if !ok {
+				return errors.New("server shutting down")
+			}
+
+			if update.PaymentHash != paymentHash {
+				continue
+			}
+
+			// The router has already sent an in flight update on
+			// our behalf above.
+			if update.State == routing.PaymentInFlight {
+				continue
+			}
+
+			rpcUpdate := marshallPaymentUpdate(update)
+			if err := updateStream.Send(rpcUpdate); err != nil {
+				return err
+			}
+
+			if update.State == routing.PaymentSucceeded ||
+				update.State == routing.PaymentFailed {
+
+				return nil
+			}
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// TrackPayments returns a uni-directional stream (server -> client) of the
+// state transitions of all outgoing payments.
+func (r *rpcServer) TrackPayments(req *lnrpc.TrackPaymentsRequest,
+	updateStream lnrpc.Lightning_TrackPaymentsServer) error {
+
+	client, err := r.server.chanRouter.SubscribePayments()
+	if err != nil {
+		return err
+	}
+	defer client.Cancel()
+
+	for {
+		select {
+		case update, ok := <-client.PaymentUpdates:
+			if !ok {
+				return errors.New("server shutting down")
+			}
+
+			rpcUpdate := marshallPaymentUpdate(update)
+			if err := updateStream.Send(rpcUpdate); err != nil {
+				return err
+			}
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallPaymentUpdate converts a payment update emitted by the router into
+// its RPC counterpart.
+func marshallPaymentUpdate(update *routing.PaymentUpdate) *lnrpc.PaymentUpdate {
+	rpcUpdate := &lnrpc.PaymentUpdate{
+		PaymentHash:   update.PaymentHash[:],
+		FailureReason: update.FailureReason,
+	}
+
+	switch update.State {
+	case routing.PaymentInFlight:
+		rpcUpdate.State = lnrpc.PaymentUpdate_IN_FLIGHT
+	case routing.PaymentAttemptFailed:
+		rpcUpdate.State = lnrpc.PaymentUpdate_ATTEMPT_FAILED
+	case routing.PaymentSucceeded:
+		rpcUpdate.State = lnrpc.PaymentUpdate_SUCCEEDED
+		rpcUpdate.PaymentPreimage = update.Preimage[:]
+	case routing.PaymentFailed:
+		rpcUpdate.State = lnrpc.PaymentUpdate_FAILED
+	}
+
+	if update.Route != nil {
+		rpcUpdate.Route = marshallRoute(update.Route)
+		if update.State == routing.PaymentSucceeded {
+			rpcUpdate.FeeMsat = int64(update.Route.TotalFees)
+		}
+	}
+
+	return rpcUpdate
+}
+
+// marshallPaymentAttempt converts the stored state of a completed payment into
+// a payment update. As the route of the payment isn't stored along with it,
+// it isn't included.
+func marshallPaymentAttempt(attempt *channeldb.PaymentAttempt) *lnrpc.PaymentUpdate {
+	rpcUpdate := &lnrpc.PaymentUpdate{
+		PaymentHash: attempt.PaymentHash[:],
+	}
+
+	switch attempt.Status {
+	case channeldb.StatusSucceeded:
+		rpcUpdate.State = lnrpc.PaymentUpdate_SUCCEEDED
+		rpcUpdate.PaymentPreimage = attempt.Preimage[:]
+	case channeldb.StatusFailed:
+		rpcUpdate.State = lnrpc.PaymentUpdate_FAILED
+		rpcUpdate.FailureReason = attempt.FailureReason
+	default:
+		rpcUpdate.State = lnrpc.PaymentUpdate_IN_FLIGHT
+	}
+
+	return rpcUpdate
+}
+
 // AddInvoice attempts to add a new invoice to the invoice database. Any
 // duplicated invoices are rejected, therefore all invoices *must* have a
 // unique payment preimage.
@@ -2355,7 +3354,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 	}
 
 	// Create and encode the payment request as a bech32 (zpay32) string.
-	creationDate := time.Now()
+	creationDate := r.server.clock.Now()
 	payReq, err := zpay32.NewInvoice(
 		activeNetParams.Params, rHash, creationDate, options...,
 	)
@@ -2485,6 +3484,39 @@ func createRPCRouteHints(routeHints [][]routing.HopHint) []*lnrpc.RouteHint {
 	return res
 }
 
+// unmarshallRouteHints converts the passed RPC routing hints into the format
+// required by the channel router.
+func unmarshallRouteHints(
+	rpcRouteHints []*lnrpc.RouteHint) ([][]routing.HopHint, error) {
+
+	var routeHints [][]routing.HopHint
+	for _, rpcRouteHint := range rpcRouteHints {
+		routeHint := make([]routing.HopHint, 0, len(rpcRouteHint.HopHints))
+		for _, rpcHopHint := range rpcRouteHint.HopHints {
+			pubKeyBytes, err := hex.DecodeString(rpcHopHint.NodeId)
+			if err != nil {
+				return nil, err
+			}
+			pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+			if err != nil {
+				return nil, err
+			}
+
+			routeHint = append(routeHint, routing.HopHint{
+				NodeID:                    pubKey,
+				ChannelID:                 rpcHopHint.ChanId,
+				FeeBaseMSat:               rpcHopHint.FeeBaseMsat,
+				FeeProportionalMillionths: rpcHopHint.FeeProportionalMillionths,
+				CLTVExpiryDelta:           uint16(rpcHopHint.CltvExpiryDelta),
+			})
+		}
+
+		routeHints = append(routeHints, routeHint)
+	}
+
+	return routeHints, nil
+}
+
 // LookupInvoice attempts to look up an invoice according to its payment hash.
 // The passed payment hash *must* be exactly 32 bytes, if not an error is
 // returned.
@@ -2684,7 +3716,7 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 	// First iterate through all the known nodes (connected or unconnected
 	// within the graph), collating their current state into the RPC
 	// response.
-	err := graph.ForEachNode(nil, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
+	err := graph.ForEachNode(nil, func(_ kvdb.Tx, node *channeldb.LightningNode) error {
 		nodeAddrs := make([]*lnrpc.NodeAddress, 0)
 		for _, addr := range node.Addresses {
 			nodeAddr := &lnrpc.NodeAddress{
@@ -2726,6 +3758,67 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 	return resp, nil
 }
 
+// graphChunkSize is the maximum size of the chunks a graph export is streamed
+// to the client in.
+const graphChunkSize = 64 * 1024
+
+// ExportGraph returns a uni-directional stream (server -> client) of the
+// channel graph encoded in the requested format, restricted to the nodes and
+// channels matching the given filters. Unlike lnd-dbtool's exportgraph
+// command, this can be used while lnd is running.
+func (r *rpcServer) ExportGraph(req *lnrpc.ExportGraphRequest,
+	updateStream lnrpc.Lightning_ExportGraphServer) error {
+
+	format, err := graphexport.ParseFormat(req.Format)
+	if err != nil {
+		return err
+	}
+
+	filter := &graphexport.Filter{
+		Hops:             int(req.Hops),
+		MinCapacity:      btcutil.Amount(req.MinCapacity),
+		MinAge:           req.MinAge,
+		MaxAge:           req.MaxAge,
+		MaxBaseFee:       lnwire.MilliSatoshi(req.MaxBaseFeeMsat),
+		MaxFeeRate:       lnwire.MilliSatoshi(req.MaxFeeRate),
+		MaxTimeLockDelta: uint16(req.MaxTimeLockDelta),
+		ExcludeDisabled:  req.ExcludeDisabled,
+	}
+
+	filter.Nodes, err = graphexport.ParseNodes(req.Nodes)
+	if err != nil {
+		return err
+	}
+
+	if filter.MinAge != 0 || filter.MaxAge != 0 {
+		_, bestHeight, err := r.server.cc.chainIO.GetBestBlock()
+		if err != nil {
+			return err
+		}
+		filter.BestHeight = uint32(bestHeight)
+	}
+
+	// The export is encoded in full before any of it is sent, so the
+	// database transactions it reads the graph in aren't held open for as
+	// long as a slow client takes to receive it.
+	var b bytes.Buffer
+	graph := r.server.chanDB.ChannelGraph()
+	if err := graphexport.Export(graph, &b, format, filter); err != nil {
+		return err
+	}
+
+	for b.Len() > 0 {
+		chunk := &lnrpc.ExportGraphChunk{
+			Data: b.Next(graphChunkSize),
+		}
+		if err := updateStream.Send(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
 	c1, c2 *channeldb.ChannelEdgePolicy) *lnrpc.ChannelEdge {
 
@@ -2751,26 +3844,37 @@ func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
 	}
 
 	if c1 != nil {
-		edge.Node1Policy = &lnrpc.RoutingPolicy{
-			TimeLockDelta:    uint32(c1.TimeLockDelta),
-			MinHtlc:          int64(c1.MinHTLC),
-			FeeBaseMsat:      int64(c1.FeeBaseMSat),
-			FeeRateMilliMsat: int64(c1.FeeProportionalMillionths),
-		}
+		edge.Node1Policy = marshalDbRoutingPolicy(c1)
 	}
 
 	if c2 != nil {
-		edge.Node2Policy = &lnrpc.RoutingPolicy{
-			TimeLockDelta:    uint32(c2.TimeLockDelta),
-			MinHtlc:          int64(c2.MinHTLC),
-			FeeBaseMsat:      int64(c2.FeeBaseMSat),
-			FeeRateMilliMsat: int64(c2.FeeProportionalMillionths),
-		}
+		edge.Node2Policy = marshalDbRoutingPolicy(c2)
 	}
 
 	return edge
 }
 
+// marshalDbRoutingPolicy converts a directed channel edge policy into the
+// RoutingPolicy returned over RPC.
+func marshalDbRoutingPolicy(
+	policy *channeldb.ChannelEdgePolicy) *lnrpc.RoutingPolicy {
+
+	disabled := policy.ChannelFlags&lnwire.ChanUpdateDisabled != 0
+
+	rpcPolicy := &lnrpc.RoutingPolicy{
+		TimeLockDelta:    uint32(policy.TimeLockDelta),
+		MinHtlc:          int64(policy.MinHTLC),
+		FeeBaseMsat:      int64(policy.FeeBaseMSat),
+		FeeRateMilliMsat: int64(policy.FeeProportionalMillionths),
+		Disabled:         disabled,
+	}
+	if policy.MessageFlags.HasMaxHtlc() {
+		rpcPolicy.MaxHtlcMsat = uint64(policy.MaxHTLC)
+	}
+
+	return rpcPolicy
+}
+
 // GetChanInfo returns the latest authenticated network announcement for the
 // given channel identified by its channel ID: an 8-byte integer which uniquely
 // identifies the location of transaction's funding output within the block
@@ -2825,7 +3929,7 @@ func (r *rpcServer) GetNodeInfo(ctx context.Context,
 		numChannels   uint32
 		totalCapacity btcutil.Amount
 	)
-	if err := node.ForEachChannel(nil, func(_ *bolt.Tx, edge *channeldb.ChannelEdgeInfo,
+	if err := node.ForEachChannel(nil, func(_ kvdb.Tx, edge *channeldb.ChannelEdgeInfo,
 		_, _ *channeldb.ChannelEdgePolicy) error {
 
 		numChannels++
@@ -2892,11 +3996,59 @@ func (r *rpcServer) QueryRoutes(ctx context.Context,
 			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
 	}
 
+	// Next, we'll parse the optional restrictions the routes must
+	// satisfy.
+	restrictions := &routing.RouteRestrictions{
+		IgnoredNodes:   make(map[routing.Vertex]struct{}),
+		IgnoredEdges:   make(map[routing.DirectedEdge]struct{}),
+		CLTVLimit:      in.CltvLimit,
+		FinalCLTVDelta: uint16(in.FinalCltvDelta),
+	}
+
+	if in.SourcePubKey != "" {
+		sourceBytes, err := hex.DecodeString(in.SourcePubKey)
+		if err != nil {
+			return nil, err
+		}
+		restrictions.Source, err = btcec.ParsePubKey(
+			sourceBytes, btcec.S256(),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, node := range in.IgnoredNodes {
+		nodeVertex, err := parseVertex(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignored node %v: %v",
+				node, err)
+		}
+		restrictions.IgnoredNodes[nodeVertex] = struct{}{}
+	}
+
+	for _, edge := range in.IgnoredEdges {
+		fromVertex, err := parseVertex(edge.FromNode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node of ignored edge "+
+				"%v: %v", edge.ChanId, err)
+		}
+		restrictions.IgnoredEdges[routing.DirectedEdge{
+			ChannelID: edge.ChanId,
+			From:      fromVertex,
+		}] = struct{}{}
+	}
+
+	restrictions.RouteHints, err = unmarshallRouteHints(in.RouteHints)
+	if err != nil {
+		return nil, err
+	}
+
 	// Query the channel router for a possible path to the destination that
 	// can carry `in.Amt` satoshis _including_ the total fee required on
 	// the route.
 	routes, err := r.server.chanRouter.FindRoutes(
-		pubKey, amtMSat, uint32(in.NumRoutes),
+		pubKey, amtMSat, uint32(in.NumRoutes), restrictions,
 	)
 	if err != nil {
 		return nil, err
@@ -2924,6 +4076,121 @@ func (r *rpcServer) QueryRoutes(ctx context.Context,
 	return routeResp, nil
 }
 
+// BuildRoute constructs a route through the passed ordered list of hops that
+// pays the requested amount to the final hop. The fees and time-locks of the
+// route are computed from the current policies of the traversed channels.
+func (r *rpcServer) BuildRoute(ctx context.Context,
+	in *lnrpc.BuildRouteRequest) (*lnrpc.BuildRouteResponse, error) {
+
+	amtMSat := lnwire.MilliSatoshi(in.AmtMsat)
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", amtMSat, maxPaymentMSat)
+	}
+
+	hops := make([]routing.Vertex, len(in.HopPubkeys))
+	for i, hop := range in.HopPubkeys {
+		hopVertex, err := parseVertex(hop)
+		if err != nil {
+			return nil, err
+		}
+		hops[i] = hopVertex
+	}
+
+	// If no channels were specified, then the router will select the
+	// cheapest channel between each pair of hops.
+	var chanIDs []uint64
+	if len(in.ChanIds) != 0 {
+		chanIDs = in.ChanIds
+	}
+
+	finalCLTVDelta := uint16(routing.DefaultFinalCLTVDelta)
+	if in.FinalCltvDelta != 0 {
+		finalCLTVDelta = uint16(in.FinalCltvDelta)
+	}
+
+	route, err := r.server.chanRouter.BuildRoute(
+		amtMSat, hops, chanIDs, finalCLTVDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.BuildRouteResponse{
+		Route: marshallRoute(route),
+	}, nil
+}
+
+// EstimateRouteFee probes the candidate routes to the target destination by
+// sending HTLCs that pay to a random payment hash along them, returning the
+// cheapest route that was able to carry the payment along with its fee and
+// time lock.
+func (r *rpcServer) EstimateRouteFee(ctx context.Context,
+	in *lnrpc.EstimateRouteFeeRequest) (*lnrpc.EstimateRouteFeeResponse, error) {
+
+	// As the probes are sent through the switch, we don't allow them to be
+	// sent while the daemon itself is still syncing.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	amt := btcutil.Amount(in.Amt)
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
+	}
+
+	numRoutes := uint32(10)
+	if in.NumRoutes > 0 {
+		numRoutes = uint32(in.NumRoutes)
+	}
+
+	finalCLTVDelta := uint16(routing.DefaultFinalCLTVDelta)
+	if in.FinalCltvDelta != 0 {
+		finalCLTVDelta = uint16(in.FinalCltvDelta)
+	}
+
+	route, err := r.server.chanRouter.EstimateRouteFee(
+		pubKey, amtMSat, numRoutes, finalCLTVDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.EstimateRouteFeeResponse{
+		Route:    marshallRoute(route),
+		FeeMsat:  int64(route.TotalFees),
+		TimeLock: route.TotalTimeLock,
+	}, nil
+}
+
+// parseVertex parses the passed hex-encoded public key into a vertex.
+func parseVertex(pubKeyStr string) (routing.Vertex, error) {
+	var v routing.Vertex
+
+	pubKeyBytes, err := hex.DecodeString(pubKeyStr)
+	if err != nil {
+		return v, err
+	}
+	if _, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256()); err != nil {
+		return v, err
+	}
+	copy(v[:], pubKeyBytes)
+
+	return v, nil
+}
+
 func marshallRoute(route *routing.Route) *lnrpc.Route {
 	resp := &lnrpc.Route{
 		TotalTimeLock: route.TotalTimeLock,
@@ -2942,6 +4209,9 @@ func marshallRoute(route *routing.Route) *lnrpc.Route {
 			Fee:              int64(hop.Fee.ToSatoshis()),
 			FeeMsat:          int64(hop.Fee),
 			Expiry:           uint32(hop.OutgoingTimeLock),
+			PubKey: hex.EncodeToString(
+				hop.Channel.Node.PubKeyBytes[:],
+			),
 		}
 	}
 
@@ -2973,7 +4243,7 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 	// network, tallying up the total number of nodes, and also gathering
 	// each node so we can measure the graph diameter and degree stats
 	// below.
-	if err := graph.ForEachNode(nil, func(tx *bolt.Tx, node *channeldb.LightningNode) error {
+	if err := graph.ForEachNode(nil, func(tx kvdb.Tx, node *channeldb.LightningNode) error {
 		// Increment the total number of nodes with each iteration.
 		numNodes++
 
@@ -2983,7 +4253,7 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 		// through the db transaction from the outer view so we can
 		// re-use it within this inner view.
 		var outDegree uint32
-		if err := node.ForEachChannel(tx, func(_ *bolt.Tx,
+		if err := node.ForEachChannel(tx, func(_ kvdb.Tx,
 			edge *channeldb.ChannelEdgeInfo, _, _ *channeldb.ChannelEdgePolicy) error {
 
 			// Bump up the out degree for this node for each
@@ -3166,6 +4436,8 @@ func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopol
 				MinHtlc:          int64(channelUpdate.MinHTLC),
 				FeeBaseMsat:      int64(channelUpdate.BaseFee),
 				FeeRateMilliMsat: int64(channelUpdate.FeeRate),
+				Disabled:         channelUpdate.Disabled,
+				MaxHtlcMsat:      uint64(channelUpdate.MaxHTLC),
 			},
 			AdvertisingNode: encodeKey(channelUpdate.AdvertisingNode),
 			ConnectingNode:  encodeKey(channelUpdate.ConnectingNode),
@@ -3348,7 +4620,7 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 	}
 
 	var feeReports []*lnrpc.ChannelFeeReport
-	err = selfNode.ForEachChannel(nil, func(_ *bolt.Tx, chanInfo *channeldb.ChannelEdgeInfo,
+	err = selfNode.ForEachChannel(nil, func(_ kvdb.Tx, chanInfo *channeldb.ChannelEdgeInfo,
 		edgePolicy, _ *channeldb.ChannelEdgePolicy) error {
 
 		// We'll compute the effective fee rate by converting from a
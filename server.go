@@ -15,11 +15,13 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/kvdb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/cluster"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -49,9 +51,10 @@ var (
 	// reconnecting to persistent peers.
 	defaultBackoff = time.Second
 
-	// maximumBackoff is the largest backoff we will permit when
-	// reattempting connections to persistent peers.
-	maximumBackoff = time.Hour
+	// multiAddrConnectionStagger is the delay we'll wait between
+	// launching connection requests to the different addresses of a
+	// persistent peer, giving the earlier ones a chance to succeed first.
+	multiAddrConnectionStagger = 10 * time.Second
 )
 
 // server is the main server of the Lightning Network Daemon. The server houses
@@ -87,6 +90,10 @@ type server struct {
 	persistentConnReqs     map[string][]*connmgr.ConnReq
 	persistentRetryCancels map[string]chan struct{}
 
+	// persistentPeerAddrs tracks all addresses we know for each of our
+	// persistent peers, which we'll rotate through when reconnecting.
+	persistentPeerAddrs map[string]*nodeAddresses
+
 	// ignorePeerTermination tracks peers for which the server has initiated
 	// a disconnect. Adding a peer to this map causes the peer termination
 	// watcher to short circuit in the event that peers are purposefully
@@ -97,8 +104,15 @@ type server struct {
 
 	fundingMgr *fundingManager
 
+	// clusterStatus tracks whether this node is currently the leader of
+	// its cluster. It's nil if clustering is disabled.
+	clusterStatus *cluster.Status
+
 	chanDB *channeldb.DB
 
+	// clock is the time source shared by the server's sub-systems.
+	clock clock.Clock
+
 	htlcSwitch *htlcswitch.Switch
 
 	invoices *invoiceRegistry
@@ -111,8 +125,14 @@ type server struct {
 
 	authGossiper *discovery.AuthenticatedGossiper
 
+	chanStatusMgr *chanStatusManager
+
+	peerBanMgr *peerBanManager
+
 	utxoNursery *utxoNursery
 
+	feeBumper *feeBumper
+
 	chainArb *contractcourt.ChainArbitrator
 
 	sphinx *htlcswitch.OnionProcessor
@@ -133,24 +153,14 @@ type server struct {
 	wg sync.WaitGroup
 }
 
-// newServer creates a new instance of the server which is to listen using the
-// passed listener address.
-func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
+// newServer creates a new instance of the server which is to accept peer
+// connections from the passed listeners. Each listener is expected to perform
+// the brontide handshake on any connection it accepts.
+func newServer(listeners []net.Listener, chanDB *channeldb.DB, cc *chainControl,
 	privKey *btcec.PrivateKey) (*server, error) {
 
 	var err error
 
-	listeners := make([]net.Listener, len(listenAddrs))
-	for i, addr := range listenAddrs {
-		// Note: though brontide.NewListener uses ResolveTCPAddr, it
-		// doesn't need to call the general lndResolveTCP function
-		// since we are resolving a local address.
-		listeners[i], err = brontide.NewListener(privKey, addr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	globalFeatures := lnwire.NewRawFeatureVector()
 
 	serializedPubKey := privKey.PubKey().SerializeCompressed()
@@ -163,11 +173,14 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		sharedSecretPath, privKey, activeNetParams.Params, cc.chainNotifier,
 	)
 
+	defaultClock := clock.NewDefaultClock()
+
 	s := &server{
 		chanDB: chanDB,
 		cc:     cc,
+		clock:  defaultClock,
 
-		invoices: newInvoiceRegistry(chanDB),
+		invoices: newInvoiceRegistry(chanDB, defaultClock),
 
 		identityPriv: privKey,
 		nodeSigner:   newNodeSigner(privKey),
@@ -181,6 +194,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		persistentPeersBackoff: make(map[string]time.Duration),
 		persistentConnReqs:     make(map[string][]*connmgr.ConnReq),
 		persistentRetryCancels: make(map[string]chan struct{}),
+		persistentPeerAddrs:    make(map[string]*nodeAddresses),
 		ignorePeerTermination:  make(map[*peer]struct{}),
 
 		peersByPub:             make(map[string]*peer),
@@ -237,6 +251,16 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		FwdingLog:             chanDB.ForwardingLog(),
 		SwitchPackager:        channeldb.NewSwitchPackager(),
 		ExtractErrorEncrypter: s.sphinx.ExtractErrorEncrypter,
+		Clock:                 s.clock,
+		NotifyPaymentOutcome: func(paymentHash, preimage [32]byte,
+			paymentErr error) {
+
+			// The router is created below, but the switch only
+			// resolves payments once it has been started.
+			s.chanRouter.NotifyPaymentOutcome(
+				paymentHash, preimage, paymentErr,
+			)
+		},
 	})
 	if err != nil {
 		return nil, err
@@ -342,13 +366,41 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 
 			return s.htlcSwitch.SendHTLC(firstHopPub, htlcAdd, errorDecryptor)
 		},
-		ChannelPruneExpiry: time.Duration(time.Hour * 24 * 14),
+		SendProbeToSwitch: func(firstHopPub [33]byte,
+			htlcAdd *lnwire.UpdateAddHTLC,
+			circuit *sphinx.Circuit) ([32]byte, error) {
+
+			errorDecryptor := &htlcswitch.SphinxErrorDecrypter{
+				OnionErrorDecrypter: sphinx.NewOnionErrorDecrypter(circuit),
+			}
+
+			return s.htlcSwitch.SendProbe(firstHopPub, htlcAdd, errorDecryptor)
+		},
+		ChannelPruneExpiry: routing.DefaultChannelPruneExpiry,
 		GraphPruneInterval: time.Duration(time.Hour),
+		Clock:              s.clock,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
 	}
 
+	s.peerBanMgr = newPeerBanManager(&peerBanConfig{
+		DB:                 chanDB,
+		BanThreshold:       cfg.BanThreshold,
+		BanDuration:        cfg.BanDuration,
+		ScoreDecayInterval: defaultBanScoreDecayInterval,
+		Clock:              s.clock,
+		DisconnectPeer:     s.disconnectPeer,
+		HasActiveChannels: func(pub *btcec.PublicKey) (bool, error) {
+			channels, err := chanDB.FetchOpenChannels(pub)
+			if err != nil {
+				return false, err
+			}
+
+			return len(channels) > 0, nil
+		},
+	})
+
 	s.authGossiper, err = discovery.New(discovery.Config{
 		Router:           s.chanRouter,
 		Notifier:         s.cc.chainNotifier,
@@ -361,6 +413,13 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		RetransmitDelay:  time.Minute * 30,
 		DB:               chanDB,
 		AnnSigner:        s.nodeSigner,
+		Clock:            s.clock,
+
+		ChannelPruneExpiry:    routing.DefaultChannelPruneExpiry,
+		NodeAnnUpdateInterval: discovery.DefaultNodeAnnUpdateInterval,
+		PeerMisbehaved: func(peer *btcec.PublicKey, reason string) {
+			s.peerMisbehaved(peer, invalidGossipBanScore, reason)
+		},
 	},
 		s.identityPriv.PubKey(),
 	)
@@ -368,6 +427,13 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		return nil, err
 	}
 
+	s.chanStatusMgr = newChanStatusManager(&chanStatusConfig{
+		DisableTimeout:       cfg.ChanDisableTimeout,
+		FetchAllOpenChannels: chanDB.FetchAllOpenChannels,
+		FetchOpenChannels:    chanDB.FetchOpenChannels,
+		PropagateChanStatus:  s.authGossiper.PropagateChanStatusUpdate,
+	})
+
 	utxnStore, err := newNurseryStore(activeNetParams.GenesisHash, chanDB)
 	if err != nil {
 		srvrLog.Errorf("unable to create nursery store: %v", err)
@@ -388,6 +454,16 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		Store:              utxnStore,
 	})
 
+	s.feeBumper = newFeeBumper(&FeeBumperConfig{
+		Wallet:   cc.wallet,
+		Signer:   cc.wallet.Cfg.Signer,
+		ChainIO:  cc.chainIO,
+		Notifier: cc.chainNotifier,
+		FetchCommitOutput: func(op wire.OutPoint) (*CommitOutput, error) {
+			return fetchCommitOutput(chanDB, op)
+		},
+	})
+
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
 	closeLink := func(chanPoint *wire.OutPoint,
 		closureType htlcswitch.ChannelCloseType) {
@@ -519,6 +595,9 @@ func (s *server) Start() error {
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
+	if err := s.feeBumper.Start(); err != nil {
+		return err
+	}
 	if err := s.chainArb.Start(); err != nil {
 		return err
 	}
@@ -531,6 +610,9 @@ func (s *server) Start() error {
 	if err := s.chanRouter.Start(); err != nil {
 		return err
 	}
+	if err := s.chanStatusMgr.Start(); err != nil {
+		return err
+	}
 
 	// With all the relevant sub-systems started, we'll now attempt to
 	// establish persistent connections to our direct channel collaborators
@@ -574,11 +656,13 @@ func (s *server) Stop() error {
 	close(s.quit)
 
 	// Shutdown the wallet, funding manager, and the rpc server.
+	s.chanStatusMgr.Stop()
 	s.cc.chainNotifier.Stop()
 	s.chanRouter.Stop()
 	s.htlcSwitch.Stop()
 	s.sphinx.Stop()
 	s.utxoNursery.Stop()
+	s.feeBumper.Stop()
 	s.breachArbiter.Stop()
 	s.authGossiper.Stop()
 	s.chainArb.Stop()
@@ -590,7 +674,7 @@ func (s *server) Stop() error {
 	// Disconnect from each active peers to ensure that
 	// peerTerminationWatchers signal completion to each peer.
 	for _, peer := range s.Peers() {
-		s.DisconnectPeer(peer.addr.IdentityKey)
+		s.disconnectPeer(peer.addr.IdentityKey)
 	}
 
 	// Wait for all lingering goroutines to quit.
@@ -806,11 +890,55 @@ func (s *server) peerBootstrapper(numTargetPeers uint32,
 	}
 }
 
+// nodeAnnModifier is a closure that modifies an attribute of our node
+// announcement before it's re-signed.
+type nodeAnnModifier func(*lnwire.NodeAnnouncement)
+
+// nodeAnnSetAlias returns a nodeAnnModifier that sets the alias of our node.
+func nodeAnnSetAlias(alias lnwire.NodeAlias) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) {
+		nodeAnn.Alias = alias
+	}
+}
+
+// nodeAnnSetColor returns a nodeAnnModifier that sets the color of our node.
+func nodeAnnSetColor(nodeColor color.RGBA) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) {
+		nodeAnn.RGBColor = nodeColor
+	}
+}
+
+// nodeAnnSetAddrs returns a nodeAnnModifier that replaces the addresses we
+// advertise for our node.
+func nodeAnnSetAddrs(addrs []net.Addr) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) {
+		nodeAnn.Addresses = addrs
+	}
+}
+
+// nodeAnnUpdateFeatures returns a nodeAnnModifier that sets and unsets the
+// passed feature bits within the features we advertise for our node.
+func nodeAnnUpdateFeatures(set, unset []lnwire.FeatureBit) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) {
+		// We'll modify a copy of the features, as they're shared with
+		// the global features we send to our peers.
+		features := nodeAnn.Features.Clone()
+		for _, bit := range set {
+			features.Set(bit)
+		}
+		for _, bit := range unset {
+			features.Unset(bit)
+		}
+		nodeAnn.Features = features
+	}
+}
+
 // genNodeAnnouncement generates and returns the current fully signed node
 // announcement. If refresh is true, then the time stamp of the announcement
-// will be updated in order to ensure it propagates through the network.
-func (s *server) genNodeAnnouncement(
-	refresh bool) (lnwire.NodeAnnouncement, error) {
+// will be updated in order to ensure it propagates through the network, after
+// applying the passed modifiers.
+func (s *server) genNodeAnnouncement(refresh bool,
+	updates ...nodeAnnModifier) (lnwire.NodeAnnouncement, error) {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -819,27 +947,119 @@ func (s *server) genNodeAnnouncement(
 		return *s.currentNodeAnn, nil
 	}
 
-	var err error
+	nodeAnn, err := s.signNodeAnnouncement(updates...)
+	if err != nil {
+		return lnwire.NodeAnnouncement{}, err
+	}
+
+	s.currentNodeAnn = &nodeAnn
+
+	return nodeAnn, nil
+}
+
+// signNodeAnnouncement applies the passed modifiers to a copy of our current
+// node announcement, then bumps its time stamp and signs it. The current
+// announcement is left untouched, so that a failure leaves it intact.
+//
+// NOTE: The server's mutex MUST be held when calling this method.
+func (s *server) signNodeAnnouncement(
+	updates ...nodeAnnModifier) (lnwire.NodeAnnouncement, error) {
 
-	newStamp := uint32(time.Now().Unix())
-	if newStamp <= s.currentNodeAnn.Timestamp {
-		newStamp = s.currentNodeAnn.Timestamp + 1
+	// The modifiers only ever replace the fields of the announcement, so
+	// a shallow copy is enough to keep them from reaching the current one.
+	nodeAnn := *s.currentNodeAnn
+	for _, update := range updates {
+		update(&nodeAnn)
 	}
 
-	s.currentNodeAnn.Timestamp = newStamp
+	newStamp := uint32(s.clock.Now().Unix())
+	if newStamp <= nodeAnn.Timestamp {
+		newStamp = nodeAnn.Timestamp + 1
+	}
+
+	nodeAnn.Timestamp = newStamp
 	sig, err := discovery.SignAnnouncement(
-		s.nodeSigner, s.identityPriv.PubKey(), s.currentNodeAnn,
+		s.nodeSigner, s.identityPriv.PubKey(), &nodeAnn,
 	)
 	if err != nil {
 		return lnwire.NodeAnnouncement{}, err
 	}
 
-	s.currentNodeAnn.Signature, err = lnwire.NewSigFromSignature(sig)
+	nodeAnn.Signature, err = lnwire.NewSigFromSignature(sig)
+	if err != nil {
+		return lnwire.NodeAnnouncement{}, err
+	}
+
+	return nodeAnn, nil
+}
+
+// UpdateNodeAnnouncement applies the passed modifiers to our node
+// announcement, re-signs it and commits it to the graph as our source node.
+// Our current announcement is only replaced once this succeeds. The new
+// announcement is then handed to the gossiper, which rate limits its
+// broadcast to the network.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) UpdateNodeAnnouncement(
+	updates ...nodeAnnModifier) (lnwire.NodeAnnouncement, error) {
+
+	nodeAnn, err := s.storeNodeAnnouncement(updates...)
 	if err != nil {
 		return lnwire.NodeAnnouncement{}, err
 	}
 
-	return *s.currentNodeAnn, nil
+	// Finally, we'll have the gossiper propagate the new announcement to
+	// the network.
+	if err := s.authGossiper.PropagateNodeAnnouncement(&nodeAnn); err != nil {
+		return lnwire.NodeAnnouncement{}, err
+	}
+
+	return nodeAnn, nil
+}
+
+// storeNodeAnnouncement signs a new node announcement with the passed
+// modifiers applied, and commits it to the graph as our source node before
+// making it our current announcement.
+func (s *server) storeNodeAnnouncement(
+	updates ...nodeAnnModifier) (lnwire.NodeAnnouncement, error) {
+
+	// We'll hold the mutex until the new announcement is stored, so that
+	// concurrent updates can't be committed out of order.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodeAnn, err := s.signNodeAnnouncement(updates...)
+	if err != nil {
+		return lnwire.NodeAnnouncement{}, fmt.Errorf("unable to "+
+			"generate node announcement: %v", err)
+	}
+
+	// We'll load and modify our source node rather than creating a new
+	// one, so we don't risk overwriting any other fields.
+	chanGraph := s.chanDB.ChannelGraph()
+	selfNode, err := chanGraph.SourceNode()
+	if err != nil {
+		return lnwire.NodeAnnouncement{}, fmt.Errorf("unable to "+
+			"fetch source node: %v", err)
+	}
+	selfNode.HaveNodeAnnouncement = true
+	selfNode.LastUpdate = time.Unix(int64(nodeAnn.Timestamp), 0)
+	selfNode.Addresses = nodeAnn.Addresses
+	selfNode.Alias = nodeAnn.Alias.String()
+	selfNode.Features = lnwire.NewFeatureVector(
+		nodeAnn.Features, lnwire.GlobalFeatures,
+	)
+	selfNode.Color = nodeAnn.RGBColor
+	selfNode.AuthSigBytes = nodeAnn.Signature.ToSignatureBytes()
+
+	if err := chanGraph.SetSourceNode(selfNode); err != nil {
+		return lnwire.NodeAnnouncement{}, fmt.Errorf("unable to "+
+			"update self node: %v", err)
+	}
+
+	s.currentNodeAnn = &nodeAnn
+
+	return nodeAnn, nil
 }
 
 type nodeAddresses struct {
@@ -848,10 +1068,10 @@ type nodeAddresses struct {
 }
 
 // establishPersistentConnections attempts to establish persistent connections
-// to all our direct channel collaborators.  In order to promote liveness of
-// our active channels, we instruct the connection manager to attempt to
-// establish and maintain persistent connections to all our direct channel
-// counterparties.
+// to all our direct channel collaborators, along with any peers the user asked
+// us to always stay connected to.  In order to promote liveness of our active
+// channels, we instruct the connection manager to attempt to establish and
+// maintain persistent connections to all our direct channel counterparties.
 func (s *server) establishPersistentConnections() error {
 	// nodeAddrsMap stores the combination of node public keys and
 	// addresses that we'll attempt to reconnect to. PubKey strings are
@@ -895,7 +1115,7 @@ func (s *server) establishPersistentConnections() error {
 	// TODO(roasbeef): instead iterate over link nodes and query graph for
 	// each of the nodes.
 	err = sourceNode.ForEachChannel(nil, func(
-		_ *bolt.Tx,
+		_ kvdb.Tx,
 		_ *channeldb.ChannelEdgeInfo,
 		policy, _ *channeldb.ChannelEdgePolicy) error {
 
@@ -950,13 +1170,36 @@ func (s *server) establishPersistentConnections() error {
 		return err
 	}
 
+	// Finally, add the peers the user asked us to always stay connected to,
+	// along with the addresses we last reached them at.
+	persistentPeers, err := s.chanDB.FetchPersistentPeers()
+	if err != nil {
+		return err
+	}
+	for _, peer := range persistentPeers {
+		pubStr := string(peer.IdentityPub.SerializeCompressed())
+
+		nodeAddrs, ok := nodeAddrsMap[pubStr]
+		if !ok {
+			nodeAddrs = &nodeAddresses{
+				pubKey:    peer.IdentityPub,
+				addresses: s.fetchNodeAddrs(peer.IdentityPub),
+			}
+			nodeAddrsMap[pubStr] = nodeAddrs
+		}
+		nodeAddrs.addresses = mergeAddrs(
+			nodeAddrs.addresses, peer.Addresses...,
+		)
+	}
+
 	// Acquire and hold server lock until all persistent connection requests
 	// have been recorded and sent to the connection manager.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Iterate through the combined list of addresses from prior links and
-	// node announcements and attempt to reconnect to each node.
+	// Iterate through the combined list of addresses from prior links,
+	// node announcements and persistent peers and attempt to reconnect to
+	// each node.
 	for pubStr, nodeAddr := range nodeAddrsMap {
 		// Add this peer to the set of peers we should maintain a
 		// persistent connection with.
@@ -964,34 +1207,183 @@ func (s *server) establishPersistentConnections() error {
 		if _, ok := s.persistentPeersBackoff[pubStr]; !ok {
 			s.persistentPeersBackoff[pubStr] = defaultBackoff
 		}
+		s.addPersistentPeerAddrs(nodeAddr.pubKey, nodeAddr.addresses...)
 
-		for _, address := range nodeAddr.addresses {
-			// Create a wrapper address which couples the IP and
-			// the pubkey so the brontide authenticated connection
-			// can be established.
-			lnAddr := &lnwire.NetAddress{
-				IdentityKey: nodeAddr.pubKey,
-				Address:     address,
+		srvrLog.Debugf("Attempting persistent connection to peer %x",
+			nodeAddr.pubKey.SerializeCompressed())
+
+		// Send the persistent connection requests to the connection
+		// manager, saving the requests themselves so we can
+		// cancel/restart the process as needed.
+		connReqs := s.createPersistentConnReqs(pubStr)
+		cancelChan := s.persistentRetryCancel(pubStr)
+		go s.connectToPersistentPeer(connReqs, 0, cancelChan)
+	}
+
+	return nil
+}
+
+// fetchNodeAddrs returns the addresses of the target node known from our prior
+// connections with it, along with those it advertised within its node
+// announcement.
+func (s *server) fetchNodeAddrs(pubKey *btcec.PublicKey) []net.Addr {
+	var addrs []net.Addr
+
+	linkNode, err := s.chanDB.FetchLinkNode(pubKey)
+	switch {
+	case err == nil:
+		for _, address := range linkNode.Addresses {
+			addr, ok := address.(*net.TCPAddr)
+			if ok && addr.Port == 0 {
+				addr.Port = defaultPeerPort
 			}
-			srvrLog.Debugf("Attempting persistent connection to "+
-				"channel peer %v", lnAddr)
-
-			// Send the persistent connection request to the
-			// connection manager, saving the request itself so we
-			// can cancel/restart the process as needed.
-			connReq := &connmgr.ConnReq{
-				Addr:      lnAddr,
-				Permanent: true,
+		}
+		addrs = mergeAddrs(addrs, linkNode.Addresses...)
+
+	case err != channeldb.ErrNodeNotFound &&
+		err != channeldb.ErrLinkNodesNotFound:
+
+		srvrLog.Errorf("Unable to fetch link node %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+
+	node, err := s.chanDB.ChannelGraph().FetchLightningNode(pubKey)
+	switch {
+	case err == nil:
+		addrs = mergeAddrs(addrs, node.Addresses...)
+
+	case err != channeldb.ErrGraphNodeNotFound &&
+		err != channeldb.ErrGraphNotFound:
+
+		srvrLog.Errorf("Unable to fetch node announcement of %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+
+	return addrs
+}
+
+// mergeAddrs appends the passed addresses to addrs, skipping any that are
+// already present.
+func mergeAddrs(addrs []net.Addr, newAddrs ...net.Addr) []net.Addr {
+	for _, newAddr := range newAddrs {
+		var known bool
+		for _, addr := range addrs {
+			if addr.String() == newAddr.String() {
+				known = true
+				break
 			}
+		}
+		if !known {
+			addrs = append(addrs, newAddr)
+		}
+	}
+
+	return addrs
+}
+
+// addPersistentPeerAddrs adds the passed addresses to the set of addresses we
+// know for the target persistent peer.
+//
+// NOTE: This MUST be called with the server's mutex held.
+func (s *server) addPersistentPeerAddrs(pubKey *btcec.PublicKey,
+	addrs ...net.Addr) {
+
+	pubStr := string(pubKey.SerializeCompressed())
+	nodeAddrs, ok := s.persistentPeerAddrs[pubStr]
+	if !ok {
+		nodeAddrs = &nodeAddresses{
+			pubKey: pubKey,
+		}
+		s.persistentPeerAddrs[pubStr] = nodeAddrs
+	}
+
+	nodeAddrs.addresses = mergeAddrs(nodeAddrs.addresses, addrs...)
+}
 
-			s.persistentConnReqs[pubStr] = append(
-				s.persistentConnReqs[pubStr], connReq)
+// createPersistentConnReqs creates a permanent connection request for each of
+// the addresses we know for the target persistent peer, and records them so
+// they can be canceled once a connection is established. The addresses are
+// rotated on each call, so that each reconnection attempt starts off with a
+// different address.
+//
+// NOTE: This MUST be called with the server's mutex held.
+func (s *server) createPersistentConnReqs(pubStr string) []*connmgr.ConnReq {
+	nodeAddrs, ok := s.persistentPeerAddrs[pubStr]
+	if !ok || len(nodeAddrs.addresses) == 0 {
+		return nil
+	}
 
-			go s.connMgr.Connect(connReq)
+	connReqs := make([]*connmgr.ConnReq, 0, len(nodeAddrs.addresses))
+	for _, address := range nodeAddrs.addresses {
+		// Create a wrapper address which couples the IP and the pubkey
+		// so the brontide authenticated connection can be established.
+		lnAddr := &lnwire.NetAddress{
+			IdentityKey: nodeAddrs.pubKey,
+			Address:     address,
 		}
+
+		connReqs = append(connReqs, &connmgr.ConnReq{
+			Addr:      lnAddr,
+			Permanent: true,
+		})
 	}
+	s.persistentConnReqs[pubStr] = append(
+		s.persistentConnReqs[pubStr], connReqs...,
+	)
 
-	return nil
+	// Move the address we'll try first to the back of the list, so the
+	// next attempt starts off with the one following it.
+	addrs := nodeAddrs.addresses
+	nodeAddrs.addresses = append(
+		append([]net.Addr{}, addrs[1:]...), addrs[0],
+	)
+
+	return connReqs
+}
+
+// persistentRetryCancel returns the retry canceller of the target persistent
+// peer, initializing one if it does not exist.
+//
+// NOTE: This MUST be called with the server's mutex held.
+func (s *server) persistentRetryCancel(pubStr string) chan struct{} {
+	cancelChan, ok := s.persistentRetryCancels[pubStr]
+	if !ok {
+		cancelChan = make(chan struct{})
+		s.persistentRetryCancels[pubStr] = cancelChan
+	}
+
+	return cancelChan
+}
+
+// connectToPersistentPeer hands the passed connection requests of a persistent
+// peer to the connection manager once the given delay has elapsed. Rather than
+// dialing all of the peer's addresses at once, each request is launched
+// multiAddrConnectionStagger after the prior one. We'll stop early if the
+// cancel channel is closed, which happens once a connection to the peer is
+// established, or if the server is shutting down.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) connectToPersistentPeer(connReqs []*connmgr.ConnReq,
+	delay time.Duration, cancelChan chan struct{}) {
+
+	for _, connReq := range connReqs {
+		select {
+		case <-s.clock.TickAfter(delay):
+		case <-cancelChan:
+			return
+		case <-s.quit:
+			return
+		}
+		delay = multiAddrConnectionStagger
+
+		srvrLog.Debugf("Attempting persistent connection to %v",
+			connReq.Addr)
+
+		// We choose not to wait group this go routine since the
+		// Connect call can stall for arbitrarily long if we shutdown
+		// while an outbound connection attempt is being made.
+		go s.connMgr.Connect(connReq)
+	}
 }
 
 // BroadcastMessage sends a request to the server to broadcast a set of
@@ -1278,6 +1670,11 @@ func (s *server) peerTerminationWatcher(p *peer) {
 	// Next, check to see if this is a persistent peer or not.
 	pubStr := string(p.addr.IdentityKey.SerializeCompressed())
 	_, ok := s.persistentPeers[pubStr]
+	if ok && s.peerBanMgr.IsBanned(p.addr.IdentityKey) {
+		srvrLog.Debugf("Not re-establishing connection to banned "+
+			"peer %v", p)
+		return
+	}
 	if ok {
 		// We'll only need to re-launch a connection request if one
 		// isn't already currently pending.
@@ -1285,14 +1682,17 @@ func (s *server) peerTerminationWatcher(p *peer) {
 			return
 		}
 
-		// Otherwise, we'll launch a new connection request in order to
-		// attempt to maintain a persistent connection with this peer.
-		connReq := &connmgr.ConnReq{
-			Addr:      p.addr,
-			Permanent: true,
-		}
-		s.persistentConnReqs[pubStr] = append(
-			s.persistentConnReqs[pubStr], connReq)
+		// Otherwise, we'll refresh the set of addresses we know for
+		// this peer, as it may have announced new ones since we last
+		// connected, and launch new connection requests in order to
+		// attempt to maintain a persistent connection with it.
+		s.addPersistentPeerAddrs(
+			p.addr.IdentityKey, p.addr.Address,
+		)
+		s.addPersistentPeerAddrs(
+			p.addr.IdentityKey, s.fetchNodeAddrs(p.addr.IdentityKey)...,
+		)
+		connReqs := s.createPersistentConnReqs(pubStr)
 
 		// Record the computed backoff in the backoff map.
 		backoff := s.nextPeerBackoff(pubStr)
@@ -1300,32 +1700,12 @@ func (s *server) peerTerminationWatcher(p *peer) {
 
 		// Initialize a retry canceller for this peer if one does not
 		// exist.
-		cancelChan, ok := s.persistentRetryCancels[pubStr]
-		if !ok {
-			cancelChan = make(chan struct{})
-			s.persistentRetryCancels[pubStr] = cancelChan
-		}
+		cancelChan := s.persistentRetryCancel(pubStr)
 
-		// We choose not to wait group this go routine since the Connect
-		// call can stall for arbitrarily long if we shutdown while an
-		// outbound connection attempt is being made.
-		go func() {
-			srvrLog.Debugf("Scheduling connection re-establishment to "+
-				"persistent peer %v in %s", p, backoff)
+		srvrLog.Debugf("Scheduling connection re-establishment to "+
+			"persistent peer %v in %s", p, backoff)
 
-			select {
-			case <-time.After(backoff):
-			case <-cancelChan:
-				return
-			case <-s.quit:
-				return
-			}
-
-			srvrLog.Debugf("Attempting to re-establish persistent "+
-				"connection to peer %v", p)
-
-			s.connMgr.Connect(connReq)
-		}()
+		go s.connectToPersistentPeer(connReqs, backoff, cancelChan)
 	}
 }
 
@@ -1342,7 +1722,7 @@ func (s *server) nextPeerBackoff(pubStr string) time.Duration {
 
 	// Otherwise, use a previous backoff to compute the
 	// subsequent randomized exponential backoff duration.
-	return computeNextBackoff(backoff)
+	return computeNextBackoff(backoff, cfg.MaxBackoff)
 }
 
 // shouldRequestGraphSync returns true if the servers deems it necessary that
@@ -1396,6 +1776,22 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		localFeatures.Set(lnwire.InitialRoutingSync)
 	}
 
+	// If we're willing to open and accept channels above the regular
+	// channel size limit, then we'll signal so to the remote node.
+	if cfg.LargeChannels {
+		localFeatures.Set(lnwire.LargeChannelsOptional)
+	}
+
+	// We always understand upfront shutdown scripts, so we'll signal so
+	// to the remote node.
+	localFeatures.Set(lnwire.UpfrontShutdownScriptOptional)
+
+	// If we're willing to take part in dual-funded channels, then we'll
+	// signal so to the remote node.
+	if cfg.DualFunding.Active {
+		localFeatures.Set(lnwire.DualFundingOptional)
+	}
+
 	// Now that we've established a connection, create a peer, and it to
 	// the set of currently active peers.
 	p, err := newPeer(conn, connReq, s, peerAddr, inbound, localFeatures)
@@ -1447,6 +1843,14 @@ func (s *server) InboundPeerConnected(conn net.Conn) {
 	nodePub := conn.(*brontide.Conn).RemotePub()
 	pubStr := string(nodePub.SerializeCompressed())
 
+	// Refuse any connections from peers we've banned.
+	if s.peerBanMgr.IsBanned(nodePub) {
+		srvrLog.Infof("Rejecting inbound connection from banned "+
+			"peer %x", nodePub.SerializeCompressed())
+		conn.Close()
+		return
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -1520,6 +1924,17 @@ func (s *server) OutboundPeerConnected(connReq *connmgr.ConnReq, conn net.Conn)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// We won't connect to peers we've banned, nor keep retrying to.
+	if s.peerBanMgr.IsBanned(nodePub) {
+		srvrLog.Infof("Dropping outbound connection to banned peer %x",
+			nodePub.SerializeCompressed())
+		if connReq != nil {
+			s.connMgr.Remove(connReq.ID())
+		}
+		conn.Close()
+		return
+	}
+
 	// If we already have an outbound connection to this peer, then ignore
 	// this new connection.
 	if _, ok := s.outboundPeers[pubStr]; ok {
@@ -1692,6 +2107,10 @@ func (s *server) addPeer(p *peer) {
 		close(con)
 	}
 	delete(s.peerConnectedListeners, pubStr)
+
+	// Now that the peer is online, any of our channels with it that we
+	// previously disabled can be re-enabled.
+	s.chanStatusMgr.PeerOnline(p.addr.IdentityKey)
 }
 
 // removePeer removes the passed peer from the server's state of all active
@@ -1726,6 +2145,10 @@ func (s *server) removePeer(p *peer) {
 	} else {
 		delete(s.outboundPeers, pubStr)
 	}
+
+	// With the peer gone, we'll start the countdown to disabling our
+	// channels with it, unless it reconnects in the meantime.
+	s.chanStatusMgr.PeerOffline(p.addr.IdentityKey)
 }
 
 // openChanReq is a message sent to the server in order to request the
@@ -1749,6 +2172,10 @@ type openChanReq struct {
 
 	remoteCsvDelay uint16
 
+	// shutdownScript is the script we'll commit to paying our funds out
+	// to upon a cooperative close of the channel, if any.
+	shutdownScript lnwire.DeliveryAddress
+
 	// TODO(roasbeef): add ability to specify channel constraints as well
 
 	updates chan *lnrpc.OpenStatusUpdate
@@ -1764,6 +2191,19 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress, perm bool) error {
 
 	targetPub := string(addr.IdentityKey.SerializeCompressed())
 
+	if s.peerBanMgr.IsBanned(addr.IdentityKey) {
+		return fmt.Errorf("peer %x is banned",
+			addr.IdentityKey.SerializeCompressed())
+	}
+
+	// If the connection should be persistent, we'll gather all other
+	// addresses we know for the peer, which we'll rotate through when
+	// reconnecting.
+	var knownAddrs []net.Addr
+	if perm {
+		knownAddrs = s.fetchNodeAddrs(addr.IdentityKey)
+	}
+
 	// Acquire mutex, but use explicit unlocking instead of defer for
 	// better granularity.  In certain conditions, this method requires
 	// making an outbound connection to a remote peer, which requires the
@@ -1792,6 +2232,16 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress, perm bool) error {
 	// persistent connection to the peer.
 	srvrLog.Debugf("Connecting to %v", addr)
 	if perm {
+		// We'll store the peer so the connection is also
+		// re-established after restarts.
+		err := s.chanDB.AddPersistentPeer(
+			addr.IdentityKey, []net.Addr{addr.Address},
+		)
+		if err != nil {
+			s.mu.Unlock()
+			return err
+		}
+
 		connReq := &connmgr.ConnReq{
 			Addr:      addr,
 			Permanent: true,
@@ -1801,6 +2251,8 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress, perm bool) error {
 		if _, ok := s.persistentPeersBackoff[targetPub]; !ok {
 			s.persistentPeersBackoff[targetPub] = defaultBackoff
 		}
+		s.addPersistentPeerAddrs(addr.IdentityKey, addr.Address)
+		s.addPersistentPeerAddrs(addr.IdentityKey, knownAddrs...)
 		s.persistentConnReqs[targetPub] = append(
 			s.persistentConnReqs[targetPub], connReq)
 		s.mu.Unlock()
@@ -1828,11 +2280,47 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress, perm bool) error {
 	return nil
 }
 
+// peerMisbehaved adds the passed score to the misbehavior score of the target
+// peer, disconnecting and banning it once the score crosses the ban threshold.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) peerMisbehaved(peerKey *btcec.PublicKey, score uint32,
+	reason string) {
+
+	_, err := s.peerBanMgr.Misbehaved(peerKey, score, reason)
+	if err != nil {
+		srvrLog.Errorf("Unable to record misbehavior of peer %x: %v",
+			peerKey.SerializeCompressed(), err)
+	}
+}
+
 // DisconnectPeer sends the request to server to close the connection with peer
-// identified by public key.
+// identified by public key. As this is an explicit request from the user, the
+// peer is also removed from our set of persistent peers, so we won't attempt
+// to reconnect to it after a restart.
 //
 // NOTE: This function is safe for concurrent access.
 func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
+	if err := s.disconnectPeer(pubKey); err != nil {
+		return err
+	}
+
+	err := s.chanDB.DeletePersistentPeer(pubKey)
+	if err != nil && err != channeldb.ErrPersistentPeerNotFound {
+		srvrLog.Errorf("Unable to remove persistent peer %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+
+	return nil
+}
+
+// disconnectPeer closes the connection with the peer identified by public key,
+// and stops any attempts to reconnect to it for the remainder of this
+// session. Unlike DisconnectPeer, the peer's persistent peer record is left
+// untouched, so it's used when shutting down or banning a peer.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) disconnectPeer(pubKey *btcec.PublicKey) error {
 	pubBytes := pubKey.SerializeCompressed()
 	pubStr := string(pubBytes)
 
@@ -1856,6 +2344,7 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 	// disconnect.
 	delete(s.persistentPeers, pubStr)
 	delete(s.persistentPeersBackoff, pubStr)
+	delete(s.persistentPeerAddrs, pubStr)
 
 	// Remove the current peer from the server's internal state and signal
 	// that the peer termination watcher does not need to execute for this
@@ -1873,7 +2362,9 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 	localAmt btcutil.Amount, pushAmt, minHtlc lnwire.MilliSatoshi,
 	fundingFeePerVSize lnwallet.SatPerVByte, private bool,
-	remoteCsvDelay uint16) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	remoteCsvDelay uint16,
+	shutdownScript lnwire.DeliveryAddress) (chan *lnrpc.OpenStatusUpdate,
+	chan error) {
 
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
 	errChan := make(chan error, 1)
@@ -1928,6 +2419,7 @@ func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 		private:            private,
 		minHtlc:            minHtlc,
 		remoteCsvDelay:     remoteCsvDelay,
+		shutdownScript:     shutdownScript,
 		updates:            updateChan,
 		err:                errChan,
 	}
@@ -1939,6 +2431,67 @@ func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 	return updateChan, errChan
 }
 
+// BatchOpenChannel sends a request to the server to open a channel with each
+// of the passed requests' target peers within a single funding transaction.
+// The funding outpoint of every channel is delivered on the returned channel
+// once the funding transaction has been broadcast. If the funding flow of any
+// channel within the batch fails, then none of the channels are opened.
+func (s *server) BatchOpenChannel(reqs []*openChanReq,
+	fundingFeePerVSize lnwallet.SatPerVByte) (chan []wire.OutPoint,
+	chan error) {
+
+	respChan := make(chan []wire.OutPoint, 1)
+	errChan := make(chan error, 1)
+
+	if len(reqs) == 0 {
+		errChan <- fmt.Errorf("batch must contain at least one channel")
+		return respChan, errChan
+	}
+
+	// Each of the target peers must be connected for us to be able to
+	// negotiate the channels.
+	channels := make([]*initFundingMsg, 0, len(reqs))
+	for _, req := range reqs {
+		pubKeyBytes := req.targetPubkey.SerializeCompressed()
+
+		s.mu.RLock()
+		targetPeer, ok := s.peersByPub[string(pubKeyBytes)]
+		s.mu.RUnlock()
+		if !ok {
+			errChan <- fmt.Errorf("peer is not connected "+
+				"NodeKey(%x)", pubKeyBytes)
+			return respChan, errChan
+		}
+
+		req.chainHash = *activeNetParams.GenesisHash
+		channels = append(channels, &initFundingMsg{
+			peerAddress: targetPeer.addr,
+			openChanReq: req,
+		})
+	}
+
+	// If the fee rate wasn't specified, then we'll use a default
+	// confirmation target.
+	if fundingFeePerVSize == 0 {
+		var err error
+		estimator := s.cc.feeEstimator
+		fundingFeePerVSize, err = estimator.EstimateFeePerVSize(6)
+		if err != nil {
+			errChan <- err
+			return respChan, errChan
+		}
+	}
+
+	go s.fundingMgr.initBatchFundingWorkflow(&initBatchFundingMsg{
+		channels:           channels,
+		fundingFeePerVSize: fundingFeePerVSize,
+		resp:               respChan,
+		err:                errChan,
+	})
+
+	return respChan, errChan
+}
+
 // Peers returns a slice of all active peers.
 //
 // NOTE: This function is safe for concurrent access.
@@ -1973,23 +2526,24 @@ func parseHexColor(colorStr string) (color.RGBA, error) {
 }
 
 // computeNextBackoff uses a truncated exponential backoff to compute the next
-// backoff using the value of the exiting backoff. The returned duration is
-// randomized in either direction by 1/20 to prevent tight loops from
-// stabilizing.
-func computeNextBackoff(currBackoff time.Duration) time.Duration {
+// backoff using the value of the exiting backoff, capped at maxBackoff. The
+// returned duration is randomized in either direction by 1/20 to prevent tight
+// loops from stabilizing.
+func computeNextBackoff(currBackoff,
+	maxBackoff time.Duration) time.Duration {
+
 	// Double the current backoff, truncating if it exceeds our maximum.
 	nextBackoff := 2 * currBackoff
-	if nextBackoff > maximumBackoff {
-		nextBackoff = maximumBackoff
+	if nextBackoff > maxBackoff {
+		nextBackoff = maxBackoff
 	}
 
 	// Using 1/10 of our duration as a margin, compute a random offset to
 	// avoid the nodes entering connection cycles.
 	margin := nextBackoff / 10
 
-	var wiggle big.Int
-	wiggle.SetUint64(uint64(margin))
-	if _, err := rand.Int(rand.Reader, &wiggle); err != nil {
+	wiggle, err := rand.Int(rand.Reader, big.NewInt(int64(margin)))
+	if err != nil {
 		// Randomizing is not mission critical, so we'll just return the
 		// current backoff.
 		return nextBackoff
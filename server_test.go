@@ -2,7 +2,19 @@
 
 package main
 
-import "testing"
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/connmgr"
+)
 
 func TestParseHexColor(t *testing.T) {
 	empty := ""
@@ -34,3 +46,174 @@ func TestParseHexColor(t *testing.T) {
 		t.Fatalf("Color %s incorrectly parsed as %v", valid, color)
 	}
 }
+
+// TestComputeNextBackoff asserts that the reconnection backoff doubles on each
+// attempt, within its random margin, and never exceeds the maximum backoff.
+func TestComputeNextBackoff(t *testing.T) {
+	const maxBackoff = time.Minute
+
+	backoff := defaultBackoff
+	for i := 0; i < 10; i++ {
+		nextBackoff := computeNextBackoff(backoff, maxBackoff)
+
+		expected := 2 * backoff
+		if expected > maxBackoff {
+			expected = maxBackoff
+		}
+
+		margin := expected / 20
+		if nextBackoff < expected-margin || nextBackoff > expected+margin {
+			t.Fatalf("expected backoff within %v of %v, got %v",
+				margin, expected, nextBackoff)
+		}
+
+		backoff = nextBackoff
+	}
+}
+
+// newPersistentPeerTestServer creates a server backed by the passed database,
+// with only the state required to maintain persistent connections. Every
+// address dialed by its connection manager is delivered over the returned
+// channel.
+func newPersistentPeerTestServer(t *testing.T,
+	db *channeldb.DB) (*server, chan net.Addr) {
+
+	s := &server{
+		chanDB:                 db,
+		persistentPeers:        make(map[string]struct{}),
+		persistentPeersBackoff: make(map[string]time.Duration),
+		persistentConnReqs:     make(map[string][]*connmgr.ConnReq),
+		persistentRetryCancels: make(map[string]chan struct{}),
+		persistentPeerAddrs:    make(map[string]*nodeAddresses),
+		quit:                   make(chan struct{}),
+	}
+
+	dialed := make(chan net.Addr, 10)
+	cmgr, err := connmgr.New(&connmgr.Config{
+		RetryDuration: time.Hour,
+		Dial: func(addr net.Addr) (net.Conn, error) {
+			dialed <- addr.(*lnwire.NetAddress).Address
+			return nil, errors.New("unable to dial")
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to create conn manager: %v", err)
+	}
+	cmgr.Start()
+	s.connMgr = cmgr
+
+	return s, dialed
+}
+
+// TestPersistentPeerRestart asserts that the peers we've been asked to stay
+// connected to are redialed after a restart, and that their addresses are
+// rotated across reconnection attempts.
+func TestPersistentPeerRestart(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "persistentpeers")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	selfPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	selfNode := &channeldb.LightningNode{
+		LastUpdate: time.Now(),
+	}
+	copy(selfNode.PubKeyBytes[:], selfPriv.PubKey().SerializeCompressed())
+	if err := db.ChannelGraph().SetSourceNode(selfNode); err != nil {
+		t.Fatalf("unable to set source node: %v", err)
+	}
+
+	peerPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peerPub := peerPriv.PubKey()
+	pubStr := string(peerPub.SerializeCompressed())
+
+	addrs := []net.Addr{
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735},
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 9735},
+	}
+	if err := db.AddPersistentPeer(peerPub, addrs); err != nil {
+		t.Fatalf("unable to add persistent peer: %v", err)
+	}
+
+	assertDialed := func(dialed chan net.Addr, expected net.Addr) {
+		t.Helper()
+
+		select {
+		case addr := <-dialed:
+			if addr.String() != expected.String() {
+				t.Fatalf("expected to dial %v, dialed %v",
+					expected, addr)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("persistent peer %v not dialed", expected)
+		}
+	}
+
+	// We'll start up a server for each restart. As nothing touches the
+	// persistent peer record in between, the peer should be redialed at
+	// its first address every time.
+	for i := 0; i < 2; i++ {
+		s, dialed := newPersistentPeerTestServer(t, db)
+
+		if err := s.establishPersistentConnections(); err != nil {
+			t.Fatalf("unable to establish persistent "+
+				"connections: %v", err)
+		}
+		if _, ok := s.persistentPeers[pubStr]; !ok {
+			t.Fatalf("peer not marked as persistent after restart")
+		}
+		assertDialed(dialed, addrs[0])
+
+		// The next reconnection attempt should start off with the
+		// peer's other address, and wrap back around after that.
+		s.mu.Lock()
+		connReqs := s.createPersistentConnReqs(pubStr)
+		s.mu.Unlock()
+		if len(connReqs) != len(addrs) {
+			t.Fatalf("expected %v conn reqs, got %v", len(addrs),
+				len(connReqs))
+		}
+		firstAddr := connReqs[0].Addr.(*lnwire.NetAddress).Address
+		if firstAddr.String() != addrs[1].String() {
+			t.Fatalf("expected addresses to be rotated to %v, "+
+				"got %v", addrs[1], firstAddr)
+		}
+
+		s.mu.Lock()
+		connReqs = s.createPersistentConnReqs(pubStr)
+		s.mu.Unlock()
+		firstAddr = connReqs[0].Addr.(*lnwire.NetAddress).Address
+		if firstAddr.String() != addrs[0].String() {
+			t.Fatalf("expected addresses to be rotated to %v, "+
+				"got %v", addrs[0], firstAddr)
+		}
+
+		// Shutting down must leave the persistent peer record intact.
+		close(s.quit)
+		s.connMgr.Stop()
+
+		peers, err := db.FetchPersistentPeers()
+		if err != nil {
+			t.Fatalf("unable to fetch persistent peers: %v", err)
+		}
+		if len(peers) != 1 {
+			t.Fatalf("expected 1 persistent peer, got %v",
+				len(peers))
+		}
+	}
+}
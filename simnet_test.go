@@ -0,0 +1,890 @@
+// +build !rpctest
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntest/memnet"
+	"github.com/lightningnetwork/lnd/lntest/simchain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const (
+	// simNetTimeout is the maximum amount of time we'll wait for the
+	// simulated network to reach an expected state.
+	simNetTimeout = time.Second * 20
+
+	// simNetBasePort is the port the first node of a simulated network
+	// listens on. Each subsequent node listens on the next port.
+	simNetBasePort = 10000
+
+	// simNetCSVDelay is the CSV delay the nodes of a simulated network
+	// require on the commitment outputs of their counterparties, kept
+	// short so force closes mature quickly.
+	simNetCSVDelay = 4
+)
+
+// simNetwork is a set of lnd nodes running in-process on top of a simulated
+// chain, connected to each other through an in-memory network. It allows the
+// integration scenarios, which otherwise require lnd and btcd binaries, to
+// run as regular tests.
+type simNetwork struct {
+	t *testing.T
+
+	clock *clock.TestClock
+	chain *simchain.Chain
+	net   *memnet.Network
+
+	nodes []*simNode
+}
+
+// simNode is a single lnd node within a simulated network.
+type simNode struct {
+	name string
+	addr string
+
+	dir    string
+	chanDB *channeldb.DB
+
+	wallet     *simchain.Wallet
+	lnWallet   *lnwallet.LightningWallet
+	server     *server
+	fundingMgr *fundingManager
+	rpc        *rpcServer
+}
+
+// newSimNetwork creates a new simulated network with an empty chain. As lnd
+// relies on a number of globals, only a single simulated network may be active
+// at a time.
+func newSimNetwork(t *testing.T) *simNetwork {
+	testClock := clock.NewTestClock(time.Unix(1500000000, 0))
+	chain := simchain.NewChain(regTestNetParams.Params, testClock)
+	network := memnet.NewNetwork()
+
+	activeNetParams = regTestNetParams
+	registeredChains = newChainRegistry()
+	registeredChains.RegisterPrimaryChain(bitcoinChain)
+
+	cfg = &config{
+		Bitcoin: &chainConfig{
+			Active:        true,
+			RegTest:       true,
+			MinHTLC:       defaultBitcoinMinHTLCMSat,
+			BaseFee:       defaultBitcoinBaseFeeMSat,
+			FeeRate:       defaultBitcoinFeeRate,
+			TimeLockDelta: defaultBitcoinTimeLockDelta,
+
+			DefaultRemoteDelay: simNetCSVDelay,
+		},
+		Litecoin:           &chainConfig{},
+		DualFunding:        &dualFundingConfig{},
+		MaxPendingChannels: defaultMaxPendingChannels,
+		MinChanSize:        int64(minChanFundingSize),
+		NoNetBootstrap:     true,
+		TrickleDelay:       10,
+		Color:              defaultColor,
+		ChanDisableTimeout: defaultChanDisableTimeout,
+		net:                network,
+	}
+
+	return &simNetwork{
+		t:     t,
+		clock: testClock,
+		chain: chain,
+		net:   network,
+	}
+}
+
+// NewNode creates a new node with the given name, and starts it. The node's
+// wallet is funded with a single confirmed output of the passed amount.
+func (n *simNetwork) NewNode(name string, funds btcutil.Amount) *simNode {
+	dir, err := ioutil.TempDir("", "simnet-"+name)
+	if err != nil {
+		n.t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	node := &simNode{
+		name: name,
+		addr: fmt.Sprintf("127.0.0.1:%d", simNetBasePort+len(n.nodes)),
+		dir:  dir,
+	}
+	n.nodes = append(n.nodes, node)
+
+	if err := n.startNode(node); err != nil {
+		n.t.Fatalf("unable to start node %v: %v", name, err)
+	}
+
+	if funds != 0 {
+		addr, err := node.wallet.NewAddress(lnwallet.WitnessPubKey, false)
+		if err != nil {
+			n.t.Fatalf("unable to create address: %v", err)
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			n.t.Fatalf("unable to create script: %v", err)
+		}
+
+		n.chain.SendTo(pkScript, funds)
+		n.MineBlocks(1)
+	}
+
+	return node
+}
+
+// startNode creates all the subsystems of the passed node on top of the
+// simulated chain and network, and starts them.
+func (n *simNetwork) startNode(node *simNode) error {
+	chanDB, err := channeldb.Open(node.dir)
+	if err != nil {
+		return err
+	}
+	node.chanDB = chanDB
+
+	// Each node derives its keys from a seed based on its name, so the
+	// identity of a node is stable across restarts.
+	seed := sha256.Sum256([]byte(node.name))
+	wallet, err := simchain.NewWallet(n.chain, seed[:])
+	if err != nil {
+		return err
+	}
+	node.wallet = wallet
+
+	notifier := simchain.NewNotifier(n.chain)
+	if err := notifier.Start(); err != nil {
+		return err
+	}
+
+	estimator := lnwallet.StaticFeeEstimator{FeeRate: 250}
+	lnWallet, err := lnwallet.NewLightningWallet(lnwallet.Config{
+		Database:           chanDB,
+		Notifier:           notifier,
+		SecretKeyRing:      wallet,
+		WalletController:   wallet,
+		Signer:             wallet,
+		ChainIO:            n.chain,
+		FeeEstimator:       estimator,
+		NetParams:          *n.chain.Params(),
+		DefaultConstraints: defaultBtcChannelConstraints,
+	})
+	if err != nil {
+		return err
+	}
+	if err := lnWallet.Startup(); err != nil {
+		return err
+	}
+	node.lnWallet = lnWallet
+
+	cc := &chainControl{
+		chainIO:       n.chain,
+		feeEstimator:  estimator,
+		signer:        wallet,
+		msgSigner:     wallet,
+		chainNotifier: notifier,
+		chainView:     simchain.NewChainView(n.chain),
+		wallet:        lnWallet,
+		routingPolicy: htlcswitch.ForwardingPolicy{
+			MinHTLC:       cfg.Bitcoin.MinHTLC,
+			BaseFee:       cfg.Bitcoin.BaseFee,
+			FeeRate:       cfg.Bitcoin.FeeRate,
+			TimeLockDelta: cfg.Bitcoin.TimeLockDelta,
+		},
+	}
+
+	idPrivKey, err := wallet.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamilyNodeKey,
+			Index:  0,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	idPrivKey.Curve = btcec.S256()
+
+	l, err := n.net.Listen(node.addr)
+	if err != nil {
+		return err
+	}
+	listener := brontide.WrapListener(idPrivKey, l)
+
+	server, err := newServer(
+		[]net.Listener{listener}, chanDB, cc, idPrivKey,
+	)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	node.server = server
+
+	fundingMgr, err := newServerFundingManager(
+		server, chanDB, cc, idPrivKey,
+	)
+	if err != nil {
+		return err
+	}
+	if err := fundingMgr.Start(); err != nil {
+		return err
+	}
+	server.fundingMgr = fundingMgr
+	node.fundingMgr = fundingMgr
+
+	node.rpc = newRPCServer(server)
+	if err := node.rpc.Start(); err != nil {
+		return err
+	}
+
+	return server.Start()
+}
+
+// stopNode shuts down all the subsystems of the passed node.
+func (n *simNetwork) stopNode(node *simNode) {
+	node.rpc.Stop()
+	node.fundingMgr.Stop()
+	node.server.Stop()
+	node.server.WaitForShutdown()
+	node.lnWallet.Shutdown()
+	node.chanDB.Close()
+}
+
+// RestartNode stops the passed node and starts it again with its existing
+// database.
+func (n *simNetwork) RestartNode(node *simNode) {
+	n.stopNode(node)
+	if err := n.startNode(node); err != nil {
+		n.t.Fatalf("unable to restart node %v: %v", node.name, err)
+	}
+}
+
+// TearDown stops all nodes of the network, and removes their databases.
+func (n *simNetwork) TearDown() {
+	for _, node := range n.nodes {
+		n.stopNode(node)
+		os.RemoveAll(node.dir)
+	}
+}
+
+// MineBlocks mines the given number of blocks, including all transactions
+// within the mempool in the first one.
+func (n *simNetwork) MineBlocks(num int) []*chainhash.Hash {
+	blocks := n.chain.Mine(num)
+
+	hashes := make([]*chainhash.Hash, len(blocks))
+	for i, block := range blocks {
+		hash := block.BlockHash()
+		hashes[i] = &hash
+	}
+
+	return hashes
+}
+
+// ConnectNodes connects node a to node b, and waits for both of them to
+// consider each other a peer.
+func (n *simNetwork) ConnectNodes(a, b *simNode) {
+	ctx := context.Background()
+	req := &lnrpc.ConnectPeerRequest{
+		Addr: &lnrpc.LightningAddress{
+			Pubkey: b.PubKeyStr(),
+			Host:   b.addr,
+		},
+	}
+	if _, err := a.rpc.ConnectPeer(ctx, req); err != nil {
+		n.t.Fatalf("unable to connect %v to %v: %v", a.name, b.name,
+			err)
+	}
+
+	n.WaitFor(func() error {
+		if _, err := a.server.FindPeer(b.PubKey()); err != nil {
+			return err
+		}
+		_, err := b.server.FindPeer(a.PubKey())
+		return err
+	})
+}
+
+// OpenChannel opens a channel of the given capacity from node a to node b,
+// mines enough blocks for it to be announced, and waits until both nodes
+// consider it active.
+func (n *simNetwork) OpenChannel(a, b *simNode, amt,
+	pushAmt btcutil.Amount) *lnrpc.ChannelPoint {
+
+	ctx := context.Background()
+	chanPoint, err := a.rpc.OpenChannelSync(ctx, &lnrpc.OpenChannelRequest{
+		NodePubkeyString:   b.PubKeyStr(),
+		LocalFundingAmount: int64(amt),
+		PushSat:            int64(pushAmt),
+	})
+	if err != nil {
+		n.t.Fatalf("unable to open channel: %v", err)
+	}
+
+	n.MineBlocks(6)
+
+	for _, node := range []*simNode{a, b} {
+		node := node
+		n.WaitFor(func() error {
+			return node.assertActiveChannel(chanPoint)
+		})
+	}
+
+	return chanPoint
+}
+
+// CloseChannel closes the passed channel from node a, either cooperatively or
+// by force, mines a block confirming the closing transaction, and waits until
+// the close has been completed. The closing transaction is returned.
+func (n *simNetwork) CloseChannel(a *simNode, chanPoint *lnrpc.ChannelPoint,
+	force bool) *chainhash.Hash {
+
+	stream := &simCloseStream{
+		updates: make(chan *lnrpc.CloseStatusUpdate, 10),
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- a.rpc.CloseChannel(&lnrpc.CloseChannelRequest{
+			ChannelPoint: chanPoint,
+			Force:        force,
+		}, stream)
+	}()
+
+	// The first update is sent once the closing transaction has been
+	// broadcast.
+	var closingTxid *chainhash.Hash
+	select {
+	case update := <-stream.updates:
+		pending, ok := update.Update.(*lnrpc.CloseStatusUpdate_ClosePending)
+		if !ok {
+			n.t.Fatalf("expected close pending update, got %v",
+				update)
+		}
+
+		var err error
+		closingTxid, err = chainhash.NewHash(pending.ClosePending.Txid)
+		if err != nil {
+			n.t.Fatalf("invalid closing txid: %v", err)
+		}
+
+	case err := <-errChan:
+		n.t.Fatalf("unable to close channel: %v", err)
+
+	case <-time.After(simNetTimeout):
+		n.t.Fatalf("closing transaction not broadcast in time")
+	}
+
+	n.MineBlocks(1)
+	if _, _, _, ok := n.chain.TxConfirmation(closingTxid); !ok {
+		n.t.Fatalf("closing transaction %v not confirmed", closingTxid)
+	}
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			n.t.Fatalf("unable to close channel: %v", err)
+		}
+
+	case <-time.After(simNetTimeout):
+		n.t.Fatalf("channel close not completed in time")
+	}
+
+	return closingTxid
+}
+
+// WaitFor polls the passed predicate until it no longer returns an error,
+// failing the test if that doesn't happen within simNetTimeout.
+func (n *simNetwork) WaitFor(pred func() error) {
+	var err error
+	timeout := time.After(simNetTimeout)
+	for {
+		if err = pred(); err == nil {
+			return
+		}
+
+		select {
+		case <-time.After(time.Millisecond * 50):
+		case <-timeout:
+			n.t.Fatalf("timeout waiting for predicate: %v", err)
+		}
+	}
+}
+
+// PubKey returns the identity public key of the node.
+func (s *simNode) PubKey() *btcec.PublicKey {
+	return s.server.identityPriv.PubKey()
+}
+
+// PubKeyStr returns the hex encoded identity public key of the node.
+func (s *simNode) PubKeyStr() string {
+	return fmt.Sprintf("%x", s.PubKey().SerializeCompressed())
+}
+
+// findChannel returns the open channel of the node with the given channel
+// point.
+func (s *simNode) findChannel(chanPoint *lnrpc.ChannelPoint) (*lnrpc.Channel,
+	error) {
+
+	txid, err := getChanPointFundingTxid(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+	txHash, err := chainhash.NewHash(txid)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%v:%v", txHash, chanPoint.OutputIndex)
+
+	resp, err := s.rpc.ListChannels(
+		context.Background(), &lnrpc.ListChannelsRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range resp.Channels {
+		if channel.ChannelPoint == target {
+			return channel, nil
+		}
+	}
+
+	return nil, fmt.Errorf("channel %v of %v not found", target, s.name)
+}
+
+// assertActiveChannel returns an error if the node doesn't have an active
+// channel with the given channel point.
+func (s *simNode) assertActiveChannel(chanPoint *lnrpc.ChannelPoint) error {
+	channel, err := s.findChannel(chanPoint)
+	if err != nil {
+		return err
+	}
+	if !channel.Active {
+		return fmt.Errorf("channel %v of %v not active",
+			channel.ChannelPoint, s.name)
+	}
+
+	return nil
+}
+
+// assertLocalBalance returns an error if the node's local balance within the
+// channel with the given channel point doesn't match the expected amount.
+func (s *simNode) assertLocalBalance(chanPoint *lnrpc.ChannelPoint,
+	amt btcutil.Amount) error {
+
+	channel, err := s.findChannel(chanPoint)
+	if err != nil {
+		return err
+	}
+	if channel.LocalBalance != int64(amt) {
+		return fmt.Errorf("expected local balance %v of %v, got %v",
+			amt, s.name, channel.LocalBalance)
+	}
+
+	return nil
+}
+
+// assertNoChannels returns an error if the node still has any open or
+// pending channels.
+func (s *simNode) assertNoChannels() error {
+	ctx := context.Background()
+
+	open, err := s.rpc.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return err
+	}
+	if len(open.Channels) != 0 {
+		return fmt.Errorf("%v has %v open channels", s.name,
+			len(open.Channels))
+	}
+
+	pending, err := s.rpc.PendingChannels(
+		ctx, &lnrpc.PendingChannelsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+	numPending := len(pending.PendingOpenChannels) +
+		len(pending.PendingClosingChannels) +
+		len(pending.PendingForceClosingChannels) +
+		len(pending.WaitingCloseChannels)
+	if numPending != 0 {
+		return fmt.Errorf("%v has %v pending channels", s.name,
+			numPending)
+	}
+
+	return nil
+}
+
+// confirmedBalance returns the confirmed balance of the node's wallet.
+func (s *simNode) confirmedBalance() (btcutil.Amount, error) {
+	resp, err := s.rpc.WalletBalance(
+		context.Background(), &lnrpc.WalletBalanceRequest{},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return btcutil.Amount(resp.ConfirmedBalance), nil
+}
+
+// simCloseStream is a mock CloseChannel stream, which hands all updates sent
+// over it to the test.
+type simCloseStream struct {
+	grpc.ServerStream
+
+	updates chan *lnrpc.CloseStatusUpdate
+}
+
+// Send sends an update to the test.
+func (s *simCloseStream) Send(update *lnrpc.CloseStatusUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+// Context returns the context of the stream.
+func (s *simCloseStream) Context() context.Context {
+	return context.Background()
+}
+
+// TestSimNetOpenChannel asserts that two nodes of a simulated network are
+// able to open a channel, and that it's active on both ends once confirmed.
+func TestSimNetOpenChannel(t *testing.T) {
+	net := newSimNetwork(t)
+	defer net.TearDown()
+
+	alice := net.NewNode("alice", btcutil.SatoshiPerBitcoin)
+	bob := net.NewNode("bob", 0)
+
+	net.ConnectNodes(alice, bob)
+
+	const (
+		chanAmt = btcutil.Amount(1000000)
+		pushAmt = btcutil.Amount(100000)
+	)
+	chanPoint := net.OpenChannel(alice, bob, chanAmt, pushAmt)
+
+	// Bob's balance should reflect the pushed amount, while Alice's
+	// should be the remainder minus the commitment fee.
+	resp, err := bob.rpc.ListChannels(
+		context.Background(), &lnrpc.ListChannelsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unable to list channels: %v", err)
+	}
+	if len(resp.Channels) != 1 {
+		t.Fatalf("expected 1 channel, got %v", len(resp.Channels))
+	}
+	if resp.Channels[0].LocalBalance != int64(pushAmt) {
+		t.Fatalf("expected local balance %v, got %v", pushAmt,
+			resp.Channels[0].LocalBalance)
+	}
+	if resp.Channels[0].Capacity != int64(chanAmt) {
+		t.Fatalf("expected capacity %v, got %v", chanAmt,
+			resp.Channels[0].Capacity)
+	}
+
+	// After restarting Alice and reconnecting the two nodes, the channel
+	// should be reactivated on both ends.
+	net.RestartNode(alice)
+	net.ConnectNodes(alice, bob)
+	for _, node := range []*simNode{alice, bob} {
+		node := node
+		net.WaitFor(func() error {
+			return node.assertActiveChannel(chanPoint)
+		})
+	}
+}
+
+// TestSimNetPayment asserts that a node of a simulated network is able to pay
+// an invoice of its channel counterparty, and that the payment is reflected
+// within the balances of the channel.
+func TestSimNetPayment(t *testing.T) {
+	net := newSimNetwork(t)
+	defer net.TearDown()
+
+	alice := net.NewNode("alice", btcutil.SatoshiPerBitcoin)
+	bob := net.NewNode("bob", 0)
+
+	net.ConnectNodes(alice, bob)
+
+	const (
+		chanAmt    = btcutil.Amount(1000000)
+		paymentAmt = btcutil.Amount(10000)
+	)
+	chanPoint := net.OpenChannel(alice, bob, chanAmt, 0)
+
+	ctx := context.Background()
+	invoice, err := bob.rpc.AddInvoice(ctx, &lnrpc.Invoice{
+		Value: int64(paymentAmt),
+	})
+	if err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	// Wait for Alice's router to learn of the channel, so a route to Bob
+	// can be found.
+	net.WaitFor(func() error {
+		_, err := alice.server.chanRouter.FindRoutes(
+			bob.PubKey(), lnwire.NewMSatFromSatoshis(paymentAmt),
+			1, nil,
+		)
+		return err
+	})
+
+	resp, err := alice.rpc.SendPaymentSync(ctx, &lnrpc.SendRequest{
+		PaymentRequest: invoice.PaymentRequest,
+	})
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if resp.PaymentError != "" {
+		t.Fatalf("payment failed: %v", resp.PaymentError)
+	}
+
+	// Bob's invoice should now be settled with the preimage Alice
+	// received.
+	settled, err := bob.rpc.LookupInvoice(ctx, &lnrpc.PaymentHash{
+		RHash: invoice.RHash,
+	})
+	if err != nil {
+		t.Fatalf("unable to look up invoice: %v", err)
+	}
+	if !settled.Settled {
+		t.Fatalf("invoice not settled")
+	}
+	if !bytes.Equal(settled.RPreimage, resp.PaymentPreimage) {
+		t.Fatalf("expected preimage %x, got %x", settled.RPreimage,
+			resp.PaymentPreimage)
+	}
+
+	net.WaitFor(func() error {
+		return bob.assertLocalBalance(chanPoint, paymentAmt)
+	})
+}
+
+// TestSimNetCooperativeClose asserts that a channel within a simulated
+// network can be closed cooperatively, paying out the balance of each node to
+// its wallet.
+func TestSimNetCooperativeClose(t *testing.T) {
+	net := newSimNetwork(t)
+	defer net.TearDown()
+
+	alice := net.NewNode("alice", btcutil.SatoshiPerBitcoin)
+	bob := net.NewNode("bob", 0)
+
+	net.ConnectNodes(alice, bob)
+
+	const (
+		chanAmt = btcutil.Amount(1000000)
+		pushAmt = btcutil.Amount(100000)
+	)
+	chanPoint := net.OpenChannel(alice, bob, chanAmt, pushAmt)
+
+	net.CloseChannel(alice, chanPoint, false)
+
+	for _, node := range []*simNode{alice, bob} {
+		net.WaitFor(node.assertNoChannels)
+	}
+
+	// As the initiator pays the fee of the closing transaction, Bob
+	// should receive exactly the amount pushed to him.
+	net.WaitFor(func() error {
+		balance, err := bob.confirmedBalance()
+		if err != nil {
+			return err
+		}
+		if balance != pushAmt {
+			return fmt.Errorf("expected balance %v, got %v",
+				pushAmt, balance)
+		}
+		return nil
+	})
+}
+
+// TestSimNetForceClose asserts that a node of a simulated network is able to
+// force close a channel, and sweeps its CSV delayed output to its wallet once
+// it has matured.
+func TestSimNetForceClose(t *testing.T) {
+	net := newSimNetwork(t)
+	defer net.TearDown()
+
+	alice := net.NewNode("alice", btcutil.SatoshiPerBitcoin)
+	bob := net.NewNode("bob", 0)
+
+	net.ConnectNodes(alice, bob)
+
+	const (
+		chanAmt = btcutil.Amount(1000000)
+		pushAmt = btcutil.Amount(100000)
+	)
+	chanPoint := net.OpenChannel(alice, bob, chanAmt, pushAmt)
+
+	startBalance, err := alice.confirmedBalance()
+	if err != nil {
+		t.Fatalf("unable to fetch balance: %v", err)
+	}
+
+	closingTxid := net.CloseChannel(alice, chanPoint, true)
+
+	// Once the closing transaction has confirmed, Alice's output should
+	// be incubated until its CSV delay has passed.
+	var blocksTilMaturity int32
+	net.WaitFor(func() error {
+		resp, err := alice.rpc.PendingChannels(
+			context.Background(), &lnrpc.PendingChannelsRequest{},
+		)
+		if err != nil {
+			return err
+		}
+		if len(resp.PendingForceClosingChannels) != 1 {
+			return fmt.Errorf("expected 1 force closing channel, "+
+				"got %v", len(resp.PendingForceClosingChannels))
+		}
+
+		forceClose := resp.PendingForceClosingChannels[0]
+		if forceClose.ClosingTxid != closingTxid.String() {
+			return fmt.Errorf("expected closing txid %v, got %v",
+				closingTxid, forceClose.ClosingTxid)
+		}
+		if forceClose.MaturityHeight == 0 {
+			return fmt.Errorf("output not yet incubated")
+		}
+
+		blocksTilMaturity = forceClose.BlocksTilMaturity
+		return nil
+	})
+	if blocksTilMaturity <= 0 || blocksTilMaturity > simNetCSVDelay {
+		t.Fatalf("expected output to mature within %v blocks, got %v",
+			simNetCSVDelay, blocksTilMaturity)
+	}
+
+	// Bob should consider the channel closed as soon as the closing
+	// transaction has confirmed.
+	net.WaitFor(bob.assertNoChannels)
+
+	// Once the output has matured, Alice should sweep it back into her
+	// wallet.
+	net.MineBlocks(int(blocksTilMaturity))
+	net.WaitFor(func() error {
+		for _, tx := range net.chain.MempoolTransactions() {
+			if spendsTx(tx, closingTxid) {
+				return nil
+			}
+		}
+		return fmt.Errorf("sweep transaction not broadcast")
+	})
+	net.MineBlocks(1)
+
+	net.WaitFor(alice.assertNoChannels)
+	net.WaitFor(func() error {
+		balance, err := alice.confirmedBalance()
+		if err != nil {
+			return err
+		}
+		if balance <= startBalance {
+			return fmt.Errorf("swept output not in wallet, "+
+				"balance %v", balance)
+		}
+		return nil
+	})
+}
+
+// TestSimNetOpenChannelAfterReorg asserts that the channel edge of a channel
+// whose funding transaction is reorganized out of the chain is removed from
+// the graph.
+func TestSimNetOpenChannelAfterReorg(t *testing.T) {
+	net := newSimNetwork(t)
+	defer net.TearDown()
+
+	alice := net.NewNode("alice", btcutil.SatoshiPerBitcoin)
+	bob := net.NewNode("bob", 0)
+
+	net.ConnectNodes(alice, bob)
+
+	ctx := context.Background()
+	chanPoint, err := alice.rpc.OpenChannelSync(
+		ctx, &lnrpc.OpenChannelRequest{
+			NodePubkeyString:   bob.PubKeyStr(),
+			LocalFundingAmount: 1000000,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to open channel: %v", err)
+	}
+	txid, err := getChanPointFundingTxid(chanPoint)
+	if err != nil {
+		t.Fatalf("invalid channel point: %v", err)
+	}
+	fundingTxid, err := chainhash.NewHash(txid)
+	if err != nil {
+		t.Fatalf("invalid funding txid: %v", err)
+	}
+
+	// Confirm the funding transaction deeply enough for the channel to be
+	// opened and announced.
+	net.MineBlocks(10)
+	for _, node := range []*simNode{alice, bob} {
+		node := node
+		net.WaitFor(func() error {
+			return node.assertActiveChannel(chanPoint)
+		})
+	}
+	assertNumEdges := func(num int) error {
+		graph, err := alice.rpc.DescribeGraph(
+			ctx, &lnrpc.ChannelGraphRequest{},
+		)
+		if err != nil {
+			return err
+		}
+		if len(graph.Edges) != num {
+			return fmt.Errorf("expected %v edges, found %v", num,
+				len(graph.Edges))
+		}
+		return nil
+	}
+	net.WaitFor(func() error {
+		return assertNumEdges(1)
+	})
+
+	// Replace the chain with a longer one that doesn't include the
+	// funding transaction. The channel edge should be pruned from Alice's
+	// graph.
+	if _, err := net.chain.Reorg(10, 15, *fundingTxid); err != nil {
+		t.Fatalf("unable to reorg: %v", err)
+	}
+	if _, _, _, ok := net.chain.TxConfirmation(fundingTxid); ok {
+		t.Fatalf("funding transaction still confirmed after reorg")
+	}
+	net.WaitFor(func() error {
+		return assertNumEdges(0)
+	})
+}
+
+// spendsTx returns true if the passed transaction spends an output of the
+// transaction with the given txid.
+func spendsTx(tx *wire.MsgTx, txid *chainhash.Hash) bool {
+	for _, txIn := range tx.TxIn {
+		if txIn.PreviousOutPoint.Hash == *txid {
+			return true
+		}
+	}
+
+	return false
+}
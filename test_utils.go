@@ -12,6 +12,7 @@ import (
 
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/keychain"
@@ -338,12 +339,14 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 	s := &server{
 		chanDB:        dbAlice,
 		cc:            cc,
+		clock:         clock.NewDefaultClock(),
 		breachArbiter: breachArbiter,
 		chainArb:      chainArb,
 	}
 	htlcSwitch, err := htlcswitch.New(htlcswitch.Config{
 		DB:             dbAlice,
 		SwitchPackager: channeldb.NewSwitchPackager(),
+		Clock:          s.clock,
 	})
 	if err != nil {
 		return nil, nil, nil, nil, err